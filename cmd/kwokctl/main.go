@@ -27,9 +27,12 @@ import (
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/signals"
 
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/attach"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/binary"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/compose"
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/crio"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kind"
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kubernetes"
 )
 
 func main() {
@@ -20,6 +20,7 @@ require (
 	github.com/prometheus/client_model v0.6.1
 	github.com/spf13/cobra v1.8.1
 	github.com/spf13/pflag v1.0.5
+	github.com/tetratelabs/wazero v1.7.3
 	github.com/wzshiming/cmux v0.3.3
 	github.com/wzshiming/ctc v1.2.3
 	github.com/wzshiming/easycel v0.5.0
@@ -30,9 +30,12 @@ import (
 	kwokctlcmd "sigs.k8s.io/kwok/pkg/kwokctl/cmd"
 	"sigs.k8s.io/kwok/pkg/log"
 
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/attach"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/binary"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/compose"
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/crio"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kind"
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kubernetes"
 )
 
 const basePath = "./site/content/en/docs/generated/"
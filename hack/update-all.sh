@@ -83,6 +83,11 @@ if [[ "${UPDATE_STAGES:-true}" == "true" ]]; then
   "${ROOT_DIR}"/hack/update-stages.sh || failed+=(stages)
 fi
 
+if [[ "${UPDATE_METRICS_COST:-true}" == "true" ]]; then
+  echo "[*] Update metrics cost..."
+  "${ROOT_DIR}"/hack/update-metrics-cost.sh || failed+=(metrics-cost)
+fi
+
 if [[ "${UPDATE_HELM_CHARTS:-true}" == "true" ]]; then
   echo "[*] Update helm charts..."
   "${ROOT_DIR}"/hack/update-helm-charts.sh || failed+=(helm-charts)
@@ -0,0 +1,41 @@
+#!/usr/bin/env bash
+# Copyright 2024 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+DIR="$(dirname "${BASH_SOURCE[0]}")"
+
+ROOT_DIR="$(realpath "${DIR}/..")"
+
+COST_DIR="${ROOT_DIR}/kustomize/metrics/cost"
+
+function update() {
+  local table
+  local price
+  local content
+
+  table="$(tr '\n' ' ' <"${COST_DIR}/price-table.cel" | sed -e 's/ \+/ /g' -e 's/{ /{/' -e 's/ *} *$/}/')"
+  price="(\"kwok.x-k8s.io/hourly-price\" in node.metadata.annotations ? double(node.metadata.annotations[\"kwok.x-k8s.io/hourly-price\"]) : \"node.kubernetes.io/instance-type\" in node.metadata.labels && node.metadata.labels[\"node.kubernetes.io/instance-type\"] in ${table} ? ${table}[node.metadata.labels[\"node.kubernetes.io/instance-type\"]] : 0.1)"
+  content="$(<"${COST_DIR}/metrics-cost.tpl.yaml")"
+
+  {
+    echo "# Code generated by hack/update-metrics-cost.sh from metrics-cost.tpl.yaml and price-table.cel. DO NOT EDIT."
+    echo "${content//__HOURLY_PRICE__/"${price}"}"
+  } >"${COST_DIR}/metrics-cost.yaml"
+}
+
+cd "${ROOT_DIR}" && update
@@ -98,6 +98,11 @@ if [[ "${VERIFY_STAGES:-true}" == "true" ]]; then
   "${ROOT_DIR}"/hack/verify-stages.sh || failed+=(stages)
 fi
 
+if [[ "${VERIFY_METRICS_COST:-true}" == "true" ]]; then
+  echo "[*] Verifying metrics cost..."
+  "${ROOT_DIR}"/hack/verify-metrics-cost.sh || failed+=(metrics-cost)
+fi
+
 if [[ "${VERIFY_HELM_CHARTS:-true}" == "true" ]]; then
   echo "[*] Verifying helm charts..."
   "${ROOT_DIR}"/hack/verify-helm-charts.sh || failed+=(helm-charts)
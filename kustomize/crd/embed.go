@@ -69,4 +69,8 @@ var (
 	// Metric is the custom resource definition for metrics.
 	//go:embed bases/kwok.x-k8s.io_metrics.yaml
 	Metric []byte
+
+	// Pause is the custom resource definition for pauses.
+	//go:embed bases/kwok.x-k8s.io_pauses.yaml
+	Pause []byte
 )
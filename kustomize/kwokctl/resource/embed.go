@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package resource contains node and pod resource for kwokctl.
+// Package resource contains the resources and presets for kwokctl scale.
 package resource
 
 import (
@@ -29,4 +29,32 @@ var (
 	// DefaultPod is the default pod resource.
 	//go:embed pod.yaml
 	DefaultPod string
+
+	// DefaultDeployment is the default deployment resource.
+	//go:embed deployment.yaml
+	DefaultDeployment string
+
+	// DefaultStatefulSet is the default statefulset resource.
+	//go:embed statefulset.yaml
+	DefaultStatefulSet string
+
+	// DefaultJob is the default job resource.
+	//go:embed job.yaml
+	DefaultJob string
+
+	// DefaultService is the default service resource.
+	//go:embed service.yaml
+	DefaultService string
+
+	// NodePresets is the presets of node shapes.
+	//go:embed node-presets.yaml
+	NodePresets string
+
+	// PodPresets is the presets of pod shapes.
+	//go:embed pod-presets.yaml
+	PodPresets string
+
+	// WorkloadPresets is the presets of common application shapes.
+	//go:embed workload-presets.yaml
+	WorkloadPresets string
 )
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package general contains the general certificate signing request stages for kwok.
+package general
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultCSRApproved is the default csr approved yaml.
+	//go:embed csr-approved.yaml
+	DefaultCSRApproved string
+
+	// DefaultCSRDenied is the default csr denied yaml.
+	//go:embed csr-denied.yaml
+	DefaultCSRDenied string
+
+	// DefaultCSRIssued is the default csr issued yaml.
+	//go:embed csr-issued.yaml
+	DefaultCSRIssued string
+
+	// DefaultCSRSigningFailed is the default csr signing failed yaml.
+	//go:embed csr-signing-failed.yaml
+	DefaultCSRSigningFailed string
+)
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package general contains the general gateway stages for kwok.
+package general
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultGatewayClassAccepted is the default gateway class accepted yaml.
+	//go:embed gatewayclass-accepted.yaml
+	DefaultGatewayClassAccepted string
+
+	// DefaultGatewayProgrammed is the default gateway programmed yaml.
+	//go:embed gateway-programmed.yaml
+	DefaultGatewayProgrammed string
+
+	// DefaultHTTPRouteAccepted is the default http route accepted yaml.
+	//go:embed httproute-accepted.yaml
+	DefaultHTTPRouteAccepted string
+)
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos contains the chaos pod stages for kwok.
+package chaos
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultPodContainerRunningFailed is the default pod container running failed yaml.
+	//go:embed pod-container-running-failed.yaml
+	DefaultPodContainerRunningFailed string
+
+	// DefaultPodInitContainerRunningFailed is the default pod init container running failed yaml.
+	//go:embed pod-init-container-running-failed.yaml
+	DefaultPodInitContainerRunningFailed string
+)
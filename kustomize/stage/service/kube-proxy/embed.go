@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeproxy contains the kube-proxy stages of services and endpoint slices for kwok.
+package kubeproxy
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultServiceProxySynced is the default service proxy synced yaml.
+	//go:embed service-proxy-synced.yaml
+	DefaultServiceProxySynced string
+
+	// DefaultEndpointSliceProxySynced is the default endpoint slice proxy synced yaml.
+	//go:embed endpointslice-proxy-synced.yaml
+	DefaultEndpointSliceProxySynced string
+)
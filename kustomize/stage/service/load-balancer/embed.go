@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadbalancer contains the service load balancer stages for kwok.
+package loadbalancer
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultServiceLoadBalancerReady is the default service load balancer ready yaml.
+	//go:embed service-load-balancer-ready.yaml
+	DefaultServiceLoadBalancerReady string
+
+	// DefaultServiceLoadBalancerQuotaExceeded is the default service load balancer quota exceeded yaml.
+	//go:embed service-load-balancer-quota-exceeded.yaml
+	DefaultServiceLoadBalancerQuotaExceeded string
+)
@@ -49,6 +49,11 @@ type KwokConfigurationOptions struct {
 	// +default="10.0.0.1/24"
 	CIDR string `json:"cidr,omitempty"`
 
+	// The IP range assigned to the ingress of Services of type LoadBalancer.
+	// is the default value for flag --load-balancer-cidr
+	// +default="10.1.0.1/16"
+	LoadBalancerCIDR string `json:"loadBalancerCIDR,omitempty"`
+
 	// The ip of all nodes maintained by the Kwok
 	// is the default value for flag --node-ip
 	NodeIP string `json:"nodeIP,omitempty"`
@@ -70,6 +75,15 @@ type KwokConfigurationOptions struct {
 	// is the default value for flag --tls-private-key-file
 	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile,omitempty"`
 
+	// CSRSignerCertFile is the file containing x509 Certificate of the CA signing the CertificateSigningRequests.
+	// If --csr-signer-cert-file and --csr-signer-key-file are not provided, a self-signed CA is generated.
+	// is the default value for flag --csr-signer-cert-file
+	CSRSignerCertFile string `json:"csrSignerCertFile,omitempty"`
+
+	// CSRSignerKeyFile is the file containing x509 private key matching --csr-signer-cert-file.
+	// is the default value for flag --csr-signer-key-file
+	CSRSignerKeyFile string `json:"csrSignerKeyFile,omitempty"`
+
 	// ManageSingleNode is the option to manage a single node name.
 	// is the default value for flag --manage-single-node
 	// Note: when `manage-all-nodes` is specified as true or
@@ -145,4 +159,111 @@ type KwokConfigurationOptions struct {
 	// NodeLeaseParallelism is the number of NodeLeases that are allowed to be processed in parallel.
 	// +default=4
 	NodeLeaseParallelism uint `json:"nodeLeaseParallelism,omitempty"`
+
+	// EventRecordQPS is the fill rate of the token bucket in queries per second
+	// that limits the events recorded about each object, 0 means the default of client-go.
+	EventRecordQPS float64 `json:"eventRecordQPS,omitempty"`
+
+	// EventBurst is the burst size of the token bucket
+	// that limits the events recorded about each object, 0 means the default of client-go.
+	EventBurst int `json:"eventBurst,omitempty"`
+
+	// EventAggregationMaxEvents is the number of similar events in an interval before they are aggregated,
+	// 0 means the default of client-go.
+	EventAggregationMaxEvents int `json:"eventAggregationMaxEvents,omitempty"`
+
+	// EventAggregationMaxIntervalSeconds is the interval in seconds in which similar events are aggregated,
+	// 0 means the default of client-go.
+	EventAggregationMaxIntervalSeconds int `json:"eventAggregationMaxIntervalSeconds,omitempty"`
+
+	// EventCacheSize is the size of the cache of the recorded events used by the rate limiting and the aggregation,
+	// 0 means the default of client-go.
+	EventCacheSize int `json:"eventCacheSize,omitempty"`
+
+	// MaxManagedNodes is the maximum number of nodes managed by the controller,
+	// the nodes beyond it wait until the managed nodes are deleted, 0 means no limit.
+	MaxManagedNodes uint `json:"maxManagedNodes,omitempty"`
+
+	// MaxManagedPods is the maximum number of pods managed by the controller,
+	// the pods beyond it wait until the managed pods are deleted, 0 means no limit.
+	MaxManagedPods uint `json:"maxManagedPods,omitempty"`
+
+	// MaxManagedPodsPerNamespace is the maximum number of pods managed by the controller in each namespace,
+	// the pods beyond it wait until the managed pods in the namespace are deleted, 0 means no limit.
+	MaxManagedPodsPerNamespace uint `json:"maxManagedPodsPerNamespace,omitempty"`
+
+	// TimeAcceleration is the factor by which the time of the simulation is accelerated,
+	// the delays of the stages and the renew interval of the node leases are divided by it,
+	// and the timestamps generated by the stages pass faster by it, 0 or 1 means real time.
+	TimeAcceleration float64 `json:"timeAcceleration,omitempty"`
+
+	// Seed is the seed of the random numbers of the jitters and the weighted selections of the stages,
+	// the runs with the same seed and inputs play the same stages with the same delays, 0 means random.
+	Seed int64 `json:"seed,omitempty"`
+
+	// OrphanPodPolicy is what the controller does with the pods on a managed node after the node is deleted,
+	// they are left for the pod garbage collector of kube-controller-manager if empty.
+	// +optional
+	OrphanPodPolicy OrphanPodPolicy `json:"orphanPodPolicy,omitempty"`
+
+	// OrphanPodDelaySeconds is the delay in seconds after the node is deleted before the OrphanPodPolicy is applied,
+	// the pods are kept if the node is created again in the meantime.
+	OrphanPodDelaySeconds uint `json:"orphanPodDelaySeconds,omitempty"`
+
+	// EnforceNodeAllocatable makes the controller refuse to run the pods whose requests exceed
+	// the remaining allocatable of their nodes, they are marked back to Pending with an event
+	// until there is room for them, e.g. to catch the scheduler overcommitting the nodes.
+	EnforceNodeAllocatable bool `json:"enforceNodeAllocatable,omitempty"`
+
+	// DecisionLogPath is the path of the file the decisions of the controller are appended to as JSON lines,
+	// each of them is a stage played on an object with the rendered patches, the delay and the timestamps,
+	// for the analysis of the simulation after the run, e.g. by kwokctl analyze decisions.
+	// +optional
+	DecisionLogPath string `json:"decisionLogPath,omitempty"`
+
+	// Clusters is the clusters managed by the controller besides the one of --kubeconfig,
+	// each of them is watched and managed by its own controller in the same process,
+	// sharing the stages and the options, to simulate many small clusters from one process.
+	// It is not supported with the server of --server-address or --node-port.
+	// +optional
+	Clusters []KwokConfigurationCluster `json:"clusters,omitempty"`
+}
+
+// KwokConfigurationCluster is a cluster managed by the controller besides the one of --kubeconfig.
+type KwokConfigurationCluster struct {
+	// Name is the name of the cluster, used in the logs.
+	Name string `json:"name"`
+
+	// Kubeconfig is the path of the kubeconfig of the cluster.
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Context is the context of the kubeconfig to use, the current context of the kubeconfig is used if empty.
+	// +optional
+	Context string `json:"context,omitempty"`
+
+	// ManageSingleNode is the node of the cluster to manage.
+	// If none of the selectors of the cluster is set, the nodes are selected as the cluster of --kubeconfig.
+	// +optional
+	ManageSingleNode string `json:"manageSingleNode,omitempty"`
+
+	// ManageNodesWithAnnotationSelector is the annotation selector of the nodes of the cluster to manage.
+	// +optional
+	ManageNodesWithAnnotationSelector string `json:"manageNodesWithAnnotationSelector,omitempty"`
+
+	// ManageNodesWithLabelSelector is the label selector of the nodes of the cluster to manage.
+	// +optional
+	ManageNodesWithLabelSelector string `json:"manageNodesWithLabelSelector,omitempty"`
 }
+
+// OrphanPodPolicy defines what to do with the pods whose node is deleted.
+// +enum
+type OrphanPodPolicy string
+
+const (
+	// OrphanPodPolicyIgnore leaves the pods for the pod garbage collector of kube-controller-manager.
+	OrphanPodPolicyIgnore OrphanPodPolicy = "ignore"
+	// OrphanPodPolicyDelete force-deletes the pods.
+	OrphanPodPolicyDelete OrphanPodPolicy = "delete"
+	// OrphanPodPolicyFail marks the pods which haven't terminated as Failed with the reason NodeLost.
+	OrphanPodPolicyFail OrphanPodPolicy = "fail"
+)
@@ -35,6 +35,11 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SoftLinks != nil {
+		in, out := &in.SoftLinks, &out.SoftLinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
@@ -55,6 +60,11 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = make([]Env, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnvFiles != nil {
+		in, out := &in.EnvFiles, &out.EnvFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]Volume, len(*in))
@@ -116,11 +126,26 @@ func (in *ComponentPatches) DeepCopyInto(out *ComponentPatches) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RemoveVolumes != nil {
+		in, out := &in.RemoveVolumes, &out.RemoveVolumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExtraEnvs != nil {
 		in, out := &in.ExtraEnvs, &out.ExtraEnvs
 		*out = make([]Env, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExtraEnvFiles != nil {
+		in, out := &in.ExtraEnvFiles, &out.ExtraEnvFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraSecrets != nil {
+		in, out := &in.ExtraSecrets, &out.ExtraSecrets
+		*out = make([]SecretFile, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -193,6 +218,22 @@ func (in *KwokConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KwokConfigurationCluster) DeepCopyInto(out *KwokConfigurationCluster) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KwokConfigurationCluster.
+func (in *KwokConfigurationCluster) DeepCopy() *KwokConfigurationCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(KwokConfigurationCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions) {
 	*out = *in
@@ -226,6 +267,11 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]KwokConfigurationCluster, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -259,6 +305,13 @@ func (in *KwokctlConfiguration) DeepCopyInto(out *KwokctlConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraComponents != nil {
+		in, out := &in.ExtraComponents, &out.ExtraComponents
+		*out = make([]Component, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Status = in.Status
 	return
 }
@@ -289,11 +342,46 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.KubeApiserverFaults != nil {
+		in, out := &in.KubeApiserverFaults, &out.KubeApiserverFaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Runtimes != nil {
 		in, out := &in.Runtimes, &out.Runtimes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EtcdEndpoints != nil {
+		in, out := &in.EtcdEndpoints, &out.EtcdEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableCoreDNS != nil {
+		in, out := &in.EnableCoreDNS, &out.EnableCoreDNS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableKubeStateMetrics != nil {
+		in, out := &in.EnableKubeStateMetrics, &out.EnableKubeStateMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableOtelCollector != nil {
+		in, out := &in.EnableOtelCollector, &out.EnableOtelCollector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableKonnectivity != nil {
+		in, out := &in.EnableKonnectivity, &out.EnableKonnectivity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableCSRSigner != nil {
+		in, out := &in.EnableCSRSigner, &out.EnableCSRSigner
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SecurePort != nil {
 		in, out := &in.SecurePort, &out.SecurePort
 		*out = new(bool)
@@ -319,6 +407,21 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableLoadBalancer != nil {
+		in, out := &in.EnableLoadBalancer, &out.EnableLoadBalancer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableKubeProxy != nil {
+		in, out := &in.EnableKubeProxy, &out.EnableKubeProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableKubeAuditSink != nil {
+		in, out := &in.EnableKubeAuditSink, &out.EnableKubeAuditSink
+		*out = new(bool)
+		**out = **in
+	}
 	if in.KubeAuthorization != nil {
 		in, out := &in.KubeAuthorization, &out.KubeAuthorization
 		*out = new(bool)
@@ -334,16 +437,43 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(float64)
 		**out = **in
 	}
+	if in.TimeAcceleration != nil {
+		in, out := &in.TimeAcceleration, &out.TimeAcceleration
+		*out = new(float64)
+		**out = **in
+	}
+	if in.NodeProfiles != nil {
+		in, out := &in.NodeProfiles, &out.NodeProfiles
+		*out = make([]NodeProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.KubeApiserverCertSANs != nil {
 		in, out := &in.KubeApiserverCertSANs, &out.KubeApiserverCertSANs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.DisableQPSLimits != nil {
 		in, out := &in.DisableQPSLimits, &out.DisableQPSLimits
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LogVolumes != nil {
+		in, out := &in.LogVolumes, &out.LogVolumes
+		*out = new(LogVolumes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuperviseComponents != nil {
+		in, out := &in.SuperviseComponents, &out.SuperviseComponents
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -404,6 +534,81 @@ func (in *KwokctlResource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVolumeMount) DeepCopyInto(out *LogVolumeMount) {
+	*out = *in
+	if in.ReadWrite != nil {
+		in, out := &in.ReadWrite, &out.ReadWrite
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVolumeMount.
+func (in *LogVolumeMount) DeepCopy() *LogVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVolumes) DeepCopyInto(out *LogVolumes) {
+	*out = *in
+	if in.AllowedPaths != nil {
+		in, out := &in.AllowedPaths, &out.AllowedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedPaths != nil {
+		in, out := &in.DeniedPaths, &out.DeniedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]LogVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVolumes.
+func (in *LogVolumes) DeepCopy() *LogVolumes {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVolumes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProfile) DeepCopyInto(out *NodeProfile) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProfile.
+func (in *NodeProfile) DeepCopy() *NodeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Port) DeepCopyInto(out *Port) {
 	*out = *in
@@ -420,6 +625,22 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretFile) DeepCopyInto(out *SecretFile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretFile.
+func (in *SecretFile) DeepCopy() *SecretFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
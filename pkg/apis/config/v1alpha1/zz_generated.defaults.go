@@ -38,6 +38,9 @@ func SetObjectDefaults_KwokConfiguration(in *KwokConfiguration) {
 	if in.Options.CIDR == "" {
 		in.Options.CIDR = "10.0.0.1/24"
 	}
+	if in.Options.LoadBalancerCIDR == "" {
+		in.Options.LoadBalancerCIDR = "10.1.0.1/16"
+	}
 	if in.Options.ManageAllNodes == nil {
 		var ptrVar1 bool = false
 		in.Options.ManageAllNodes = &ptrVar1
@@ -86,6 +89,14 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 		var ptrVar1 bool = false
 		in.Options.EnableMetricsServer = &ptrVar1
 	}
+	if in.Options.EnableCSRSigner == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableCSRSigner = &ptrVar1
+	}
+	if in.Options.EnableLoadBalancer == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableLoadBalancer = &ptrVar1
+	}
 	if in.Options.EtcdPrefix == "" {
 		in.Options.EtcdPrefix = "/registry"
 	}
@@ -108,6 +119,9 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 	if in.Options.BindAddress == "" {
 		in.Options.BindAddress = "0.0.0.0"
 	}
+	if in.Options.IPFamily == "" {
+		in.Options.IPFamily = "ipv4"
+	}
 	if in.Options.DisableQPSLimits == nil {
 		var ptrVar1 bool = false
 		in.Options.DisableQPSLimits = &ptrVar1
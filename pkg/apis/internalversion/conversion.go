@@ -43,6 +43,22 @@ func ConvertToInternalKwokctlConfiguration(in *configv1alpha1.KwokctlConfigurati
 	if err != nil {
 		return nil, err
 	}
+	for _, p := range []*string{
+		&out.Options.KubeAuditPolicy,
+		&out.Options.KubeSchedulerConfig,
+		&out.Options.CacheDir,
+		&out.Options.EtcdCaFile,
+		&out.Options.EtcdCertFile,
+		&out.Options.EtcdKeyFile,
+	} {
+		if *p == "" {
+			continue
+		}
+		*p, err = path.Expand(*p)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &out, nil
 }
 
@@ -185,14 +201,9 @@ func ConvertToInternalClusterLogs(in *v1alpha1.ClusterLogs) (*ClusterLogs, error
 	if err != nil {
 		return nil, err
 	}
-	for i := range out.Spec.Logs {
-		logsFile := out.Spec.Logs[i].LogsFile
-		if logsFile != "" {
-			out.Spec.Logs[i].LogsFile, err = path.Expand(logsFile)
-			if err != nil {
-				return nil, err
-			}
-		}
+	err = expandLogsFiles(out.Spec.Logs)
+	if err != nil {
+		return nil, err
 	}
 	return &out, nil
 }
@@ -250,14 +261,9 @@ func ConvertToInternalLogs(in *v1alpha1.Logs) (*Logs, error) {
 	if err != nil {
 		return nil, err
 	}
-	for i := range out.Spec.Logs {
-		logsFile := out.Spec.Logs[i].LogsFile
-		if logsFile != "" {
-			out.Spec.Logs[i].LogsFile, err = path.Expand(logsFile)
-			if err != nil {
-				return nil, err
-			}
-		}
+	err = expandLogsFiles(out.Spec.Logs)
+	if err != nil {
+		return nil, err
 	}
 	return &out, nil
 }
@@ -391,6 +397,29 @@ func ConvertToInternalMetric(in *v1alpha1.Metric) (*Metric, error) {
 	return &out, nil
 }
 
+// ConvertToV1Alpha1Pause converts an internal version Pause to a v1alpha1.Pause.
+func ConvertToV1Alpha1Pause(in *Pause) (*v1alpha1.Pause, error) {
+	var out v1alpha1.Pause
+	out.APIVersion = v1alpha1.GroupVersion.String()
+	out.Kind = v1alpha1.PauseKind
+	err := Convert_internalversion_Pause_To_v1alpha1_Pause(in, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConvertToInternalPause converts a v1alpha1.Pause to an internal version.
+func ConvertToInternalPause(in *v1alpha1.Pause) (*Pause, error) {
+	var out Pause
+	v1alpha1.SetObjectDefaults_Pause(in)
+	err := Convert_v1alpha1_Pause_To_internalversion_Pause(in, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Convert_v1alpha1_StageNext_To_internalversion_StageNext converts a v1alpha1.StageNext to an internal version.
 func Convert_v1alpha1_StageNext_To_internalversion_StageNext(in *v1alpha1.StageNext, out *StageNext, s conversion.Scope) error {
 	err := autoConvert_v1alpha1_StageNext_To_internalversion_StageNext(in, out, s)
@@ -450,3 +479,22 @@ func Convert_internalversion_StageNext_To_v1alpha1_StageNext(in *StageNext, out
 func Convert_internalversion_StagePatch_To_v1alpha1_StagePatch(in *StagePatch, out *v1alpha1.StagePatch, s conversion.Scope) error {
 	return autoConvert_internalversion_StagePatch_To_v1alpha1_StagePatch(in, out, s)
 }
+
+// expandLogsFiles expands the paths of the files of the logs.
+func expandLogsFiles(logs []Log) (err error) {
+	for i := range logs {
+		if logs[i].LogsFile != "" {
+			logs[i].LogsFile, err = path.Expand(logs[i].LogsFile)
+			if err != nil {
+				return err
+			}
+		}
+		if logs[i].PreviousLogsFile != "" {
+			logs[i].PreviousLogsFile, err = path.Expand(logs[i].PreviousLogsFile)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -37,6 +37,9 @@ type KwokConfigurationOptions struct {
 	// The default IP assigned to the Pod on maintained Nodes.
 	CIDR string
 
+	// The IP range assigned to the ingress of Services of type LoadBalancer.
+	LoadBalancerCIDR string
+
 	// The ip of all nodes maintained by the Kwok
 	NodeIP string
 
@@ -52,6 +55,12 @@ type KwokConfigurationOptions struct {
 	// TLSPrivateKeyFile is the ile containing x509 private key
 	TLSPrivateKeyFile string
 
+	// CSRSignerCertFile is the file containing x509 Certificate of the CA signing the CertificateSigningRequests
+	CSRSignerCertFile string
+
+	// CSRSignerKeyFile is the file containing x509 private key matching CSRSignerCertFile
+	CSRSignerKeyFile string
+
 	// ManageSingleNode is the option to manage a single node name
 	ManageSingleNode string
 
@@ -99,4 +108,77 @@ type KwokConfigurationOptions struct {
 
 	// NodeLeaseParallelism is the number of NodeLeases that are allowed to be processed in parallel.
 	NodeLeaseParallelism uint
+
+	// EventRecordQPS is the fill rate of the token bucket that limits the events recorded about each object.
+	EventRecordQPS float64
+
+	// EventBurst is the burst size of the token bucket that limits the events recorded about each object.
+	EventBurst int
+
+	// EventAggregationMaxEvents is the number of similar events in an interval before they are aggregated.
+	EventAggregationMaxEvents int
+
+	// EventAggregationMaxIntervalSeconds is the interval in seconds in which similar events are aggregated.
+	EventAggregationMaxIntervalSeconds int
+
+	// EventCacheSize is the size of the cache of the recorded events used by the rate limiting and the aggregation.
+	EventCacheSize int
+
+	// MaxManagedNodes is the maximum number of nodes managed by the controller.
+	MaxManagedNodes uint
+
+	// MaxManagedPods is the maximum number of pods managed by the controller.
+	MaxManagedPods uint
+
+	// MaxManagedPodsPerNamespace is the maximum number of pods managed by the controller in each namespace.
+	MaxManagedPodsPerNamespace uint
+
+	// TimeAcceleration is the factor by which the time of the simulation is accelerated.
+	TimeAcceleration float64
+
+	// Seed is the seed of the random numbers of the jitters and the weighted selections of the stages.
+	Seed int64
+
+	// OrphanPodPolicy is what the controller does with the pods on a managed node after the node is deleted.
+	OrphanPodPolicy OrphanPodPolicy
+
+	// OrphanPodDelaySeconds is the delay in seconds after the node is deleted before the OrphanPodPolicy is applied.
+	OrphanPodDelaySeconds uint
+
+	// EnforceNodeAllocatable makes the controller refuse to run the pods whose requests exceed the remaining allocatable of their nodes.
+	EnforceNodeAllocatable bool
+
+	// DecisionLogPath is the path of the file the decisions of the controller are appended to.
+	DecisionLogPath string
+
+	// Clusters is the clusters managed by the controller besides the one of --kubeconfig.
+	Clusters []KwokConfigurationCluster
 }
+
+// KwokConfigurationCluster is a cluster managed by the controller besides the one of --kubeconfig.
+type KwokConfigurationCluster struct {
+	// Name is the name of the cluster, used in the logs.
+	Name string
+	// Kubeconfig is the path of the kubeconfig of the cluster.
+	Kubeconfig string
+	// Context is the context of the kubeconfig to use.
+	Context string
+	// ManageSingleNode is the node of the cluster to manage.
+	ManageSingleNode string
+	// ManageNodesWithAnnotationSelector is the annotation selector of the nodes of the cluster to manage.
+	ManageNodesWithAnnotationSelector string
+	// ManageNodesWithLabelSelector is the label selector of the nodes of the cluster to manage.
+	ManageNodesWithLabelSelector string
+}
+
+// OrphanPodPolicy defines what to do with the pods whose node is deleted.
+type OrphanPodPolicy string
+
+const (
+	// OrphanPodPolicyIgnore leaves the pods for the pod garbage collector of kube-controller-manager.
+	OrphanPodPolicyIgnore OrphanPodPolicy = "ignore"
+	// OrphanPodPolicyDelete force-deletes the pods.
+	OrphanPodPolicyDelete OrphanPodPolicy = "delete"
+	// OrphanPodPolicyFail marks the pods which haven't terminated as Failed with the reason NodeLost.
+	OrphanPodPolicyFail OrphanPodPolicy = "fail"
+)
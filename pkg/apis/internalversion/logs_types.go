@@ -43,4 +43,6 @@ type Log struct {
 	LogsFile string
 	// Follow up if true
 	Follow bool
+	// PreviousLogsFile is the file of the logs of the previous terminated container.
+	PreviousLogsFile string
 }
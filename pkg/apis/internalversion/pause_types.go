@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Pause pauses the simulation of the selected objects until it is deleted.
+type Pause struct {
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta
+	// Spec holds spec for pause.
+	Spec PauseSpec
+}
+
+// PauseSpec holds spec for pause.
+type PauseSpec struct {
+	// ResourceRef specifies the Kind and version of the resource to pause.
+	ResourceRef StageResourceRef
+	// Selector is a selector to filter the objects to pause by name and namespace.
+	Selector *ObjectSelector
+	// MatchLabels is a map of {key,value} pairs of the labels of the objects to pause.
+	MatchLabels map[string]string
+}
+
+// Match returns true if the object is selected by the pause.
+func (s *PauseSpec) Match(ref StageResourceRef, obj metav1.Object) bool {
+	if s.ResourceRef != ref {
+		return false
+	}
+	if !s.Selector.Match(obj.GetName(), obj.GetNamespace()) {
+		return false
+	}
+	if len(s.MatchLabels) != 0 && !labels.SelectorFromSet(s.MatchLabels).Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	return true
+}
@@ -44,6 +44,8 @@ type StageSpec struct {
 	WeightFrom *ExpressionFromSource
 	// Delay means there is a delay in this stage.
 	Delay *StageDelay
+	// Concurrency limits how many objects may be in this stage at the same time.
+	Concurrency *StageConcurrency
 	// Next indicates that this stage will be moved to.
 	Next StageNext
 	// ImmediateNextStage means that the next stage of matching is performed immediately, without waiting for the Apiserver to push.
@@ -58,6 +60,26 @@ type StageResourceRef struct {
 	Kind string
 }
 
+// StageConcurrency limits how many objects may be in a stage at the same time,
+// an object is in the stage from the time it is matched until the stage is played after the delay,
+// and the objects over the limit wait in order before their delays start.
+type StageConcurrency struct {
+	// Limit is the max number of the objects in the stage at the same time.
+	Limit uint
+	// Scope is the scope of the limit, the objects in the whole cluster or on the same node.
+	Scope StageConcurrencyScope
+}
+
+// StageConcurrencyScope is the scope of the concurrency limit of a stage.
+type StageConcurrencyScope string
+
+const (
+	// StageConcurrencyScopeCluster limits the objects in the whole cluster.
+	StageConcurrencyScopeCluster StageConcurrencyScope = "cluster"
+	// StageConcurrencyScopeNode limits the objects on the same node, by the .spec.nodeName of the objects or the name of the nodes.
+	StageConcurrencyScopeNode StageConcurrencyScope = "node"
+)
+
 // StageDelay describes the delay time before going to next.
 type StageDelay struct {
 	// DurationMilliseconds indicates the stage delay time.
@@ -87,8 +109,40 @@ type StageNext struct {
 	Delete bool
 	// Patches means that the resource will be patched.
 	Patches []StagePatch
+	// Webhook means that the delay and the patches are decided by an external HTTP endpoint.
+	Webhook *StageWebhook
+	// Plugin means that the delay and the patches are decided by a WebAssembly module.
+	Plugin *StagePlugin
+}
+
+// StagePlugin describes the WebAssembly module that decides the next of the resource.
+type StagePlugin struct {
+	// Path is the path of the module.
+	Path string
+	// TimeoutMilliseconds is the timeout of the call.
+	TimeoutMilliseconds *int64
+}
+
+// StageWebhook describes the external HTTP endpoint that decides the next of the resource.
+type StageWebhook struct {
+	// URL is the URL of the endpoint.
+	URL string
+	// TimeoutMilliseconds is the timeout of the request.
+	TimeoutMilliseconds *int64
+	// FailurePolicy is the policy when the request fails.
+	FailurePolicy StageWebhookFailurePolicy
 }
 
+// StageWebhookFailurePolicy is the policy when the request of the webhook fails.
+type StageWebhookFailurePolicy string
+
+const (
+	// StageWebhookFailurePolicyFail means that the stage is not played until the resource is changed again.
+	StageWebhookFailurePolicyFail StageWebhookFailurePolicy = "Fail"
+	// StageWebhookFailurePolicyIgnore means that the stage is played without the decision of the webhook.
+	StageWebhookFailurePolicyIgnore StageWebhookFailurePolicy = "Ignore"
+)
+
 // StagePatch describes the patch for the resource.
 type StagePatch struct {
 	// Subresource indicates the name of the subresource that will be patched.
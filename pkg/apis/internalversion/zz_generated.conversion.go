@@ -330,6 +330,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KwokConfigurationCluster)(nil), (*configv1alpha1.KwokConfigurationCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_KwokConfigurationCluster_To_v1alpha1_KwokConfigurationCluster(a.(*KwokConfigurationCluster), b.(*configv1alpha1.KwokConfigurationCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.KwokConfigurationCluster)(nil), (*KwokConfigurationCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KwokConfigurationCluster_To_internalversion_KwokConfigurationCluster(a.(*configv1alpha1.KwokConfigurationCluster), b.(*KwokConfigurationCluster), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KwokConfigurationOptions)(nil), (*configv1alpha1.KwokConfigurationOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfigurationOptions(a.(*KwokConfigurationOptions), b.(*configv1alpha1.KwokConfigurationOptions), scope)
 	}); err != nil {
@@ -390,6 +400,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LogVolumeMount)(nil), (*configv1alpha1.LogVolumeMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogVolumeMount_To_v1alpha1_LogVolumeMount(a.(*LogVolumeMount), b.(*configv1alpha1.LogVolumeMount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.LogVolumeMount)(nil), (*LogVolumeMount)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogVolumeMount_To_internalversion_LogVolumeMount(a.(*configv1alpha1.LogVolumeMount), b.(*LogVolumeMount), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LogVolumes)(nil), (*configv1alpha1.LogVolumes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogVolumes_To_v1alpha1_LogVolumes(a.(*LogVolumes), b.(*configv1alpha1.LogVolumes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.LogVolumes)(nil), (*LogVolumes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogVolumes_To_internalversion_LogVolumes(a.(*configv1alpha1.LogVolumes), b.(*LogVolumes), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Logs)(nil), (*v1alpha1.Logs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Logs_To_v1alpha1_Logs(a.(*Logs), b.(*v1alpha1.Logs), scope)
 	}); err != nil {
@@ -460,6 +490,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeProfile)(nil), (*configv1alpha1.NodeProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_NodeProfile_To_v1alpha1_NodeProfile(a.(*NodeProfile), b.(*configv1alpha1.NodeProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.NodeProfile)(nil), (*NodeProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodeProfile_To_internalversion_NodeProfile(a.(*configv1alpha1.NodeProfile), b.(*NodeProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ObjectSelector)(nil), (*v1alpha1.ObjectSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ObjectSelector_To_v1alpha1_ObjectSelector(a.(*ObjectSelector), b.(*v1alpha1.ObjectSelector), scope)
 	}); err != nil {
@@ -480,6 +520,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Pause)(nil), (*v1alpha1.Pause)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_Pause_To_v1alpha1_Pause(a.(*Pause), b.(*v1alpha1.Pause), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.Pause)(nil), (*Pause)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Pause_To_internalversion_Pause(a.(*v1alpha1.Pause), b.(*Pause), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PauseSpec)(nil), (*v1alpha1.PauseSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_PauseSpec_To_v1alpha1_PauseSpec(a.(*PauseSpec), b.(*v1alpha1.PauseSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.PauseSpec)(nil), (*PauseSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PauseSpec_To_internalversion_PauseSpec(a.(*v1alpha1.PauseSpec), b.(*PauseSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PortForward)(nil), (*v1alpha1.PortForward)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_PortForward_To_v1alpha1_PortForward(a.(*PortForward), b.(*v1alpha1.PortForward), scope)
 	}); err != nil {
@@ -540,6 +600,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SecretFile)(nil), (*configv1alpha1.SecretFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_SecretFile_To_v1alpha1_SecretFile(a.(*SecretFile), b.(*configv1alpha1.SecretFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.SecretFile)(nil), (*SecretFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SecretFile_To_internalversion_SecretFile(a.(*configv1alpha1.SecretFile), b.(*SecretFile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecurityContext)(nil), (*v1alpha1.SecurityContext)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(a.(*SecurityContext), b.(*v1alpha1.SecurityContext), scope)
 	}); err != nil {
@@ -570,6 +640,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageConcurrency)(nil), (*v1alpha1.StageConcurrency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageConcurrency_To_v1alpha1_StageConcurrency(a.(*StageConcurrency), b.(*v1alpha1.StageConcurrency), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageConcurrency)(nil), (*StageConcurrency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageConcurrency_To_internalversion_StageConcurrency(a.(*v1alpha1.StageConcurrency), b.(*StageConcurrency), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageDelay)(nil), (*v1alpha1.StageDelay)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageDelay_To_v1alpha1_StageDelay(a.(*StageDelay), b.(*v1alpha1.StageDelay), scope)
 	}); err != nil {
@@ -605,6 +685,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StagePlugin)(nil), (*v1alpha1.StagePlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StagePlugin_To_v1alpha1_StagePlugin(a.(*StagePlugin), b.(*v1alpha1.StagePlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StagePlugin)(nil), (*StagePlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StagePlugin_To_internalversion_StagePlugin(a.(*v1alpha1.StagePlugin), b.(*StagePlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StageWebhook)(nil), (*v1alpha1.StageWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(a.(*StageWebhook), b.(*v1alpha1.StageWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageWebhook)(nil), (*StageWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(a.(*v1alpha1.StageWebhook), b.(*StageWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageResourceRef)(nil), (*v1alpha1.StageResourceRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageResourceRef_To_v1alpha1_StageResourceRef(a.(*StageResourceRef), b.(*v1alpha1.StageResourceRef), scope)
 	}); err != nil {
@@ -1050,6 +1150,7 @@ func Convert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourc
 func autoConvert_internalversion_Component_To_v1alpha1_Component(in *Component, out *configv1alpha1.Component, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Links = *(*[]string)(unsafe.Pointer(&in.Links))
+	out.SoftLinks = *(*[]string)(unsafe.Pointer(&in.SoftLinks))
 	out.Binary = in.Binary
 	out.Image = in.Image
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
@@ -1058,6 +1159,7 @@ func autoConvert_internalversion_Component_To_v1alpha1_Component(in *Component,
 	out.WorkDir = in.WorkDir
 	out.Ports = *(*[]configv1alpha1.Port)(unsafe.Pointer(&in.Ports))
 	out.Envs = *(*[]configv1alpha1.Env)(unsafe.Pointer(&in.Envs))
+	out.EnvFiles = *(*[]string)(unsafe.Pointer(&in.EnvFiles))
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]configv1alpha1.Volume, len(*in))
@@ -1072,6 +1174,12 @@ func autoConvert_internalversion_Component_To_v1alpha1_Component(in *Component,
 	out.Metric = (*configv1alpha1.ComponentMetric)(unsafe.Pointer(in.Metric))
 	out.MetricsDiscovery = (*configv1alpha1.ComponentMetric)(unsafe.Pointer(in.MetricsDiscovery))
 	out.Version = in.Version
+	out.StartPolicy = configv1alpha1.StartPolicy(in.StartPolicy)
+	out.RestartPolicy = configv1alpha1.RestartPolicy(in.RestartPolicy)
+	out.ReadinessTimeoutMilliseconds = in.ReadinessTimeoutMilliseconds
+	out.ReadinessRetries = in.ReadinessRetries
+	out.CPULimit = in.CPULimit
+	out.MemoryLimit = in.MemoryLimit
 	return nil
 }
 
@@ -1083,6 +1191,7 @@ func Convert_internalversion_Component_To_v1alpha1_Component(in *Component, out
 func autoConvert_v1alpha1_Component_To_internalversion_Component(in *configv1alpha1.Component, out *Component, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Links = *(*[]string)(unsafe.Pointer(&in.Links))
+	out.SoftLinks = *(*[]string)(unsafe.Pointer(&in.SoftLinks))
 	out.Binary = in.Binary
 	out.Image = in.Image
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
@@ -1091,6 +1200,7 @@ func autoConvert_v1alpha1_Component_To_internalversion_Component(in *configv1alp
 	out.WorkDir = in.WorkDir
 	out.Ports = *(*[]Port)(unsafe.Pointer(&in.Ports))
 	out.Envs = *(*[]Env)(unsafe.Pointer(&in.Envs))
+	out.EnvFiles = *(*[]string)(unsafe.Pointer(&in.EnvFiles))
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]Volume, len(*in))
@@ -1105,6 +1215,12 @@ func autoConvert_v1alpha1_Component_To_internalversion_Component(in *configv1alp
 	out.Metric = (*ComponentMetric)(unsafe.Pointer(in.Metric))
 	out.MetricsDiscovery = (*ComponentMetric)(unsafe.Pointer(in.MetricsDiscovery))
 	out.Version = in.Version
+	out.StartPolicy = StartPolicy(in.StartPolicy)
+	out.RestartPolicy = RestartPolicy(in.RestartPolicy)
+	out.ReadinessTimeoutMilliseconds = in.ReadinessTimeoutMilliseconds
+	out.ReadinessRetries = in.ReadinessRetries
+	out.CPULimit = in.CPULimit
+	out.MemoryLimit = in.MemoryLimit
 	return nil
 }
 
@@ -1157,7 +1273,16 @@ func autoConvert_internalversion_ComponentPatches_To_v1alpha1_ComponentPatches(i
 	} else {
 		out.ExtraVolumes = nil
 	}
+	out.RemoveVolumes = *(*[]string)(unsafe.Pointer(&in.RemoveVolumes))
 	out.ExtraEnvs = *(*[]configv1alpha1.Env)(unsafe.Pointer(&in.ExtraEnvs))
+	out.ExtraEnvFiles = *(*[]string)(unsafe.Pointer(&in.ExtraEnvFiles))
+	out.ExtraSecrets = *(*[]configv1alpha1.SecretFile)(unsafe.Pointer(&in.ExtraSecrets))
+	out.StartPolicy = configv1alpha1.StartPolicy(in.StartPolicy)
+	out.RestartPolicy = configv1alpha1.RestartPolicy(in.RestartPolicy)
+	out.ReadinessTimeoutMilliseconds = in.ReadinessTimeoutMilliseconds
+	out.ReadinessRetries = in.ReadinessRetries
+	out.CPULimit = in.CPULimit
+	out.MemoryLimit = in.MemoryLimit
 	return nil
 }
 
@@ -1180,7 +1305,16 @@ func autoConvert_v1alpha1_ComponentPatches_To_internalversion_ComponentPatches(i
 	} else {
 		out.ExtraVolumes = nil
 	}
+	out.RemoveVolumes = *(*[]string)(unsafe.Pointer(&in.RemoveVolumes))
 	out.ExtraEnvs = *(*[]Env)(unsafe.Pointer(&in.ExtraEnvs))
+	out.ExtraEnvFiles = *(*[]string)(unsafe.Pointer(&in.ExtraEnvFiles))
+	out.ExtraSecrets = *(*[]SecretFile)(unsafe.Pointer(&in.ExtraSecrets))
+	out.StartPolicy = StartPolicy(in.StartPolicy)
+	out.RestartPolicy = RestartPolicy(in.RestartPolicy)
+	out.ReadinessTimeoutMilliseconds = in.ReadinessTimeoutMilliseconds
+	out.ReadinessRetries = in.ReadinessRetries
+	out.CPULimit = in.CPULimit
+	out.MemoryLimit = in.MemoryLimit
 	return nil
 }
 
@@ -1482,14 +1616,47 @@ func Convert_v1alpha1_KwokConfiguration_To_internalversion_KwokConfiguration(in
 	return autoConvert_v1alpha1_KwokConfiguration_To_internalversion_KwokConfiguration(in, out, s)
 }
 
+func autoConvert_internalversion_KwokConfigurationCluster_To_v1alpha1_KwokConfigurationCluster(in *KwokConfigurationCluster, out *configv1alpha1.KwokConfigurationCluster, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kubeconfig = in.Kubeconfig
+	out.Context = in.Context
+	out.ManageSingleNode = in.ManageSingleNode
+	out.ManageNodesWithAnnotationSelector = in.ManageNodesWithAnnotationSelector
+	out.ManageNodesWithLabelSelector = in.ManageNodesWithLabelSelector
+	return nil
+}
+
+// Convert_internalversion_KwokConfigurationCluster_To_v1alpha1_KwokConfigurationCluster is an autogenerated conversion function.
+func Convert_internalversion_KwokConfigurationCluster_To_v1alpha1_KwokConfigurationCluster(in *KwokConfigurationCluster, out *configv1alpha1.KwokConfigurationCluster, s conversion.Scope) error {
+	return autoConvert_internalversion_KwokConfigurationCluster_To_v1alpha1_KwokConfigurationCluster(in, out, s)
+}
+
+func autoConvert_v1alpha1_KwokConfigurationCluster_To_internalversion_KwokConfigurationCluster(in *configv1alpha1.KwokConfigurationCluster, out *KwokConfigurationCluster, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Kubeconfig = in.Kubeconfig
+	out.Context = in.Context
+	out.ManageSingleNode = in.ManageSingleNode
+	out.ManageNodesWithAnnotationSelector = in.ManageNodesWithAnnotationSelector
+	out.ManageNodesWithLabelSelector = in.ManageNodesWithLabelSelector
+	return nil
+}
+
+// Convert_v1alpha1_KwokConfigurationCluster_To_internalversion_KwokConfigurationCluster is an autogenerated conversion function.
+func Convert_v1alpha1_KwokConfigurationCluster_To_internalversion_KwokConfigurationCluster(in *configv1alpha1.KwokConfigurationCluster, out *KwokConfigurationCluster, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KwokConfigurationCluster_To_internalversion_KwokConfigurationCluster(in, out, s)
+}
+
 func autoConvert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfigurationOptions(in *KwokConfigurationOptions, out *configv1alpha1.KwokConfigurationOptions, s conversion.Scope) error {
 	out.EnableCRDs = *(*[]string)(unsafe.Pointer(&in.EnableCRDs))
 	out.CIDR = in.CIDR
+	out.LoadBalancerCIDR = in.LoadBalancerCIDR
 	out.NodeIP = in.NodeIP
 	out.NodeName = in.NodeName
 	out.NodePort = in.NodePort
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	out.CSRSignerCertFile = in.CSRSignerCertFile
+	out.CSRSignerKeyFile = in.CSRSignerKeyFile
 	out.ManageSingleNode = in.ManageSingleNode
 	if err := v1.Convert_bool_To_Pointer_bool(&in.ManageAllNodes, &out.ManageAllNodes, s); err != nil {
 		return err
@@ -1515,6 +1682,21 @@ func autoConvert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfig
 	out.NodePlayStageParallelism = in.NodePlayStageParallelism
 	out.NodeLeaseDurationSeconds = in.NodeLeaseDurationSeconds
 	out.NodeLeaseParallelism = in.NodeLeaseParallelism
+	out.EventRecordQPS = in.EventRecordQPS
+	out.EventBurst = in.EventBurst
+	out.EventAggregationMaxEvents = in.EventAggregationMaxEvents
+	out.EventAggregationMaxIntervalSeconds = in.EventAggregationMaxIntervalSeconds
+	out.EventCacheSize = in.EventCacheSize
+	out.MaxManagedNodes = in.MaxManagedNodes
+	out.MaxManagedPods = in.MaxManagedPods
+	out.MaxManagedPodsPerNamespace = in.MaxManagedPodsPerNamespace
+	out.TimeAcceleration = in.TimeAcceleration
+	out.Seed = in.Seed
+	out.OrphanPodPolicy = configv1alpha1.OrphanPodPolicy(in.OrphanPodPolicy)
+	out.OrphanPodDelaySeconds = in.OrphanPodDelaySeconds
+	out.EnforceNodeAllocatable = in.EnforceNodeAllocatable
+	out.DecisionLogPath = in.DecisionLogPath
+	out.Clusters = *(*[]configv1alpha1.KwokConfigurationCluster)(unsafe.Pointer(&in.Clusters))
 	return nil
 }
 
@@ -1526,11 +1708,14 @@ func Convert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfigurat
 func autoConvert_v1alpha1_KwokConfigurationOptions_To_internalversion_KwokConfigurationOptions(in *configv1alpha1.KwokConfigurationOptions, out *KwokConfigurationOptions, s conversion.Scope) error {
 	out.EnableCRDs = *(*[]string)(unsafe.Pointer(&in.EnableCRDs))
 	out.CIDR = in.CIDR
+	out.LoadBalancerCIDR = in.LoadBalancerCIDR
 	out.NodeIP = in.NodeIP
 	out.NodeName = in.NodeName
 	out.NodePort = in.NodePort
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	out.CSRSignerCertFile = in.CSRSignerCertFile
+	out.CSRSignerKeyFile = in.CSRSignerKeyFile
 	out.ManageSingleNode = in.ManageSingleNode
 	if err := v1.Convert_Pointer_bool_To_bool(&in.ManageAllNodes, &out.ManageAllNodes, s); err != nil {
 		return err
@@ -1556,6 +1741,21 @@ func autoConvert_v1alpha1_KwokConfigurationOptions_To_internalversion_KwokConfig
 	out.NodePlayStageParallelism = in.NodePlayStageParallelism
 	out.NodeLeaseDurationSeconds = in.NodeLeaseDurationSeconds
 	out.NodeLeaseParallelism = in.NodeLeaseParallelism
+	out.EventRecordQPS = in.EventRecordQPS
+	out.EventBurst = in.EventBurst
+	out.EventAggregationMaxEvents = in.EventAggregationMaxEvents
+	out.EventAggregationMaxIntervalSeconds = in.EventAggregationMaxIntervalSeconds
+	out.EventCacheSize = in.EventCacheSize
+	out.MaxManagedNodes = in.MaxManagedNodes
+	out.MaxManagedPods = in.MaxManagedPods
+	out.MaxManagedPodsPerNamespace = in.MaxManagedPodsPerNamespace
+	out.TimeAcceleration = in.TimeAcceleration
+	out.Seed = in.Seed
+	out.OrphanPodPolicy = OrphanPodPolicy(in.OrphanPodPolicy)
+	out.OrphanPodDelaySeconds = in.OrphanPodDelaySeconds
+	out.EnforceNodeAllocatable = in.EnforceNodeAllocatable
+	out.DecisionLogPath = in.DecisionLogPath
+	out.Clusters = *(*[]KwokConfigurationCluster)(unsafe.Pointer(&in.Clusters))
 	return nil
 }
 
@@ -1591,6 +1791,17 @@ func autoConvert_internalversion_KwokctlConfiguration_To_v1alpha1_KwokctlConfigu
 	} else {
 		out.ComponentsPatches = nil
 	}
+	if in.ExtraComponents != nil {
+		in, out := &in.ExtraComponents, &out.ExtraComponents
+		*out = make([]configv1alpha1.Component, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_Component_To_v1alpha1_Component(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ExtraComponents = nil
+	}
 	if err := Convert_internalversion_KwokctlConfigurationStatus_To_v1alpha1_KwokctlConfigurationStatus(&in.Status, &out.Status, s); err != nil {
 		return err
 	}
@@ -1630,6 +1841,17 @@ func autoConvert_v1alpha1_KwokctlConfiguration_To_internalversion_KwokctlConfigu
 	} else {
 		out.ComponentsPatches = nil
 	}
+	if in.ExtraComponents != nil {
+		in, out := &in.ExtraComponents, &out.ExtraComponents
+		*out = make([]Component, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_Component_To_internalversion_Component(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ExtraComponents = nil
+	}
 	if err := Convert_v1alpha1_KwokctlConfigurationStatus_To_internalversion_KwokctlConfigurationStatus(&in.Status, &out.Status, s); err != nil {
 		return err
 	}
@@ -1646,6 +1868,8 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.KubeApiserverPort = in.KubeApiserverPort
 	out.KubeApiserverInsecurePort = in.KubeApiserverInsecurePort
 	out.InsecureKubeconfig = in.InsecureKubeconfig
+	out.KubeApiserverFaults = *(*[]string)(unsafe.Pointer(&in.KubeApiserverFaults))
+	out.KubeApiserverFaultProxyPort = in.KubeApiserverFaultProxyPort
 	out.Runtime = in.Runtime
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
 	out.PrometheusPort = in.PrometheusPort
@@ -1676,6 +1900,12 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableMetricsServer, &out.EnableMetricsServer, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableLoadBalancer, &out.EnableLoadBalancer, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableKubeProxy, &out.EnableKubeProxy, s); err != nil {
+		return err
+	}
 	out.EtcdImage = in.EtcdImage
 	out.KubeApiserverImage = in.KubeApiserverImage
 	out.KubeControllerManagerImage = in.KubeControllerManagerImage
@@ -1688,6 +1918,11 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.JaegerImage = in.JaegerImage
 	out.MetricsServerImage = in.MetricsServerImage
 	out.KindNodeImage = in.KindNodeImage
+	out.KindWorkers = in.KindWorkers
+	out.KindRealWorkers = in.KindRealWorkers
+	out.AttachKubeconfig = in.AttachKubeconfig
+	out.AttachLocal = in.AttachLocal
+	out.Rootless = in.Rootless
 	out.BinSuffix = in.BinSuffix
 	out.KubeApiserverBinary = in.KubeApiserverBinary
 	out.KubeControllerManagerBinary = in.KubeControllerManagerBinary
@@ -1697,6 +1932,58 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.EtcdBinary = in.EtcdBinary
 	out.EtcdBinaryTar = in.EtcdBinaryTar
 	out.EtcdPrefix = in.EtcdPrefix
+	out.EtcdTemplate = in.EtcdTemplate
+	out.EtcdReplicas = in.EtcdReplicas
+	out.EtcdBackend = in.EtcdBackend
+	out.KineEndpoint = in.KineEndpoint
+	out.KineVersion = in.KineVersion
+	out.KineImage = in.KineImage
+	out.KineBinary = in.KineBinary
+	out.EtcdEndpoints = *(*[]string)(unsafe.Pointer(&in.EtcdEndpoints))
+	out.EtcdCaFile = in.EtcdCaFile
+	out.EtcdCertFile = in.EtcdCertFile
+	out.EtcdKeyFile = in.EtcdKeyFile
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.HaproxyVersion = in.HaproxyVersion
+	out.HaproxyImage = in.HaproxyImage
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableCoreDNS, &out.EnableCoreDNS, s); err != nil {
+		return err
+	}
+	out.CoreDNSVersion = in.CoreDNSVersion
+	out.CoreDNSImage = in.CoreDNSImage
+	out.CoreDNSBinary = in.CoreDNSBinary
+	out.CoreDNSPort = in.CoreDNSPort
+	out.CloudProvider = in.CloudProvider
+	out.CloudControllerManagerImage = in.CloudControllerManagerImage
+	out.CloudControllerManagerBinary = in.CloudControllerManagerBinary
+	out.CloudControllerManagerPort = in.CloudControllerManagerPort
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableKubeStateMetrics, &out.EnableKubeStateMetrics, s); err != nil {
+		return err
+	}
+	out.KubeStateMetricsVersion = in.KubeStateMetricsVersion
+	out.KubeStateMetricsImage = in.KubeStateMetricsImage
+	out.KubeStateMetricsBinary = in.KubeStateMetricsBinary
+	out.KubeStateMetricsPort = in.KubeStateMetricsPort
+	out.GrafanaPort = in.GrafanaPort
+	out.GrafanaVersion = in.GrafanaVersion
+	out.GrafanaImage = in.GrafanaImage
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableOtelCollector, &out.EnableOtelCollector, s); err != nil {
+		return err
+	}
+	out.OtlpEndpoint = in.OtlpEndpoint
+	out.OtelCollectorPort = in.OtelCollectorPort
+	out.OtelCollectorVersion = in.OtelCollectorVersion
+	out.OtelCollectorImage = in.OtelCollectorImage
+	out.OtelCollectorBinary = in.OtelCollectorBinary
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableKonnectivity, &out.EnableKonnectivity, s); err != nil {
+		return err
+	}
+	out.KonnectivityVersion = in.KonnectivityVersion
+	out.KonnectivityServerImage = in.KonnectivityServerImage
+	out.KonnectivityAgentImage = in.KonnectivityAgentImage
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableCSRSigner, &out.EnableCSRSigner, s); err != nil {
+		return err
+	}
 	out.KwokControllerBinary = in.KwokControllerBinary
 	out.PrometheusBinary = in.PrometheusBinary
 	out.PrometheusBinaryTar = in.PrometheusBinaryTar
@@ -1706,7 +1993,13 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.KindBinary = in.KindBinary
 	out.KubeFeatureGates = in.KubeFeatureGates
 	out.KubeRuntimeConfig = in.KubeRuntimeConfig
+	out.KubeEmulateRemovals = in.KubeEmulateRemovals
 	out.KubeAuditPolicy = in.KubeAuditPolicy
+	out.KubeAuditWebhook = in.KubeAuditWebhook
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableKubeAuditSink, &out.EnableKubeAuditSink, s); err != nil {
+		return err
+	}
+	out.KubeAuditSinkPort = in.KubeAuditSinkPort
 	if err := v1.Convert_bool_To_Pointer_bool(&in.KubeAuthorization, &out.KubeAuthorization, s); err != nil {
 		return err
 	}
@@ -1728,11 +2021,26 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	if err := v1.Convert_float64_To_Pointer_float64(&in.HeartbeatFactor, &out.HeartbeatFactor, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_float64_To_Pointer_float64(&in.TimeAcceleration, &out.TimeAcceleration, s); err != nil {
+		return err
+	}
+	out.Lifecycle = in.Lifecycle
+	out.NodeProfiles = *(*[]configv1alpha1.NodeProfile)(unsafe.Pointer(&in.NodeProfiles))
+	out.ReadinessFailurePolicy = configv1alpha1.ReadinessFailurePolicy(in.ReadinessFailurePolicy)
 	out.BindAddress = in.BindAddress
+	out.IPFamily = in.IPFamily
 	out.KubeApiserverCertSANs = *(*[]string)(unsafe.Pointer(&in.KubeApiserverCertSANs))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.CaCert = in.CaCert
+	out.CaKey = in.CaKey
 	if err := v1.Convert_bool_To_Pointer_bool(&in.DisableQPSLimits, &out.DisableQPSLimits, s); err != nil {
 		return err
 	}
+	out.LogVolumes = (*configv1alpha1.LogVolumes)(unsafe.Pointer(in.LogVolumes))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.SuperviseComponents, &out.SuperviseComponents, s); err != nil {
+		return err
+	}
+	out.InitSystem = in.InitSystem
 	return nil
 }
 
@@ -1746,6 +2054,8 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.KubeApiserverPort = in.KubeApiserverPort
 	out.KubeApiserverInsecurePort = in.KubeApiserverInsecurePort
 	out.InsecureKubeconfig = in.InsecureKubeconfig
+	out.KubeApiserverFaults = *(*[]string)(unsafe.Pointer(&in.KubeApiserverFaults))
+	out.KubeApiserverFaultProxyPort = in.KubeApiserverFaultProxyPort
 	out.Runtime = in.Runtime
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
 	out.PrometheusPort = in.PrometheusPort
@@ -1776,6 +2086,12 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableMetricsServer, &out.EnableMetricsServer, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableLoadBalancer, &out.EnableLoadBalancer, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableKubeProxy, &out.EnableKubeProxy, s); err != nil {
+		return err
+	}
 	// INFO: in.KubeImagePrefix opted out of conversion generation
 	// INFO: in.EtcdImagePrefix opted out of conversion generation
 	// INFO: in.KwokImagePrefix opted out of conversion generation
@@ -1796,6 +2112,11 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.MetricsServerImage = in.MetricsServerImage
 	// INFO: in.KindNodeImagePrefix opted out of conversion generation
 	out.KindNodeImage = in.KindNodeImage
+	out.KindWorkers = in.KindWorkers
+	out.KindRealWorkers = in.KindRealWorkers
+	out.AttachKubeconfig = in.AttachKubeconfig
+	out.AttachLocal = in.AttachLocal
+	out.Rootless = in.Rootless
 	out.BinSuffix = in.BinSuffix
 	// INFO: in.KubeBinaryPrefix opted out of conversion generation
 	out.KubeApiserverBinary = in.KubeApiserverBinary
@@ -1807,6 +2128,65 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.EtcdBinary = in.EtcdBinary
 	// INFO: in.EtcdBinaryTar opted out of conversion generation
 	out.EtcdPrefix = in.EtcdPrefix
+	out.EtcdTemplate = in.EtcdTemplate
+	out.EtcdReplicas = in.EtcdReplicas
+	out.EtcdBackend = in.EtcdBackend
+	out.KineEndpoint = in.KineEndpoint
+	out.KineVersion = in.KineVersion
+	out.KineImage = in.KineImage
+	out.KineBinary = in.KineBinary
+	out.EtcdEndpoints = *(*[]string)(unsafe.Pointer(&in.EtcdEndpoints))
+	out.EtcdCaFile = in.EtcdCaFile
+	out.EtcdCertFile = in.EtcdCertFile
+	out.EtcdKeyFile = in.EtcdKeyFile
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.HaproxyVersion = in.HaproxyVersion
+	out.HaproxyImage = in.HaproxyImage
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableCoreDNS, &out.EnableCoreDNS, s); err != nil {
+		return err
+	}
+	out.CoreDNSVersion = in.CoreDNSVersion
+	// INFO: in.CoreDNSImagePrefix opted out of conversion generation
+	out.CoreDNSImage = in.CoreDNSImage
+	// INFO: in.CoreDNSBinaryPrefix opted out of conversion generation
+	out.CoreDNSBinary = in.CoreDNSBinary
+	out.CoreDNSPort = in.CoreDNSPort
+	out.CloudProvider = in.CloudProvider
+	out.CloudControllerManagerImage = in.CloudControllerManagerImage
+	out.CloudControllerManagerBinary = in.CloudControllerManagerBinary
+	out.CloudControllerManagerPort = in.CloudControllerManagerPort
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableKubeStateMetrics, &out.EnableKubeStateMetrics, s); err != nil {
+		return err
+	}
+	out.KubeStateMetricsVersion = in.KubeStateMetricsVersion
+	// INFO: in.KubeStateMetricsImagePrefix opted out of conversion generation
+	out.KubeStateMetricsImage = in.KubeStateMetricsImage
+	out.KubeStateMetricsBinary = in.KubeStateMetricsBinary
+	out.KubeStateMetricsPort = in.KubeStateMetricsPort
+	out.GrafanaPort = in.GrafanaPort
+	out.GrafanaVersion = in.GrafanaVersion
+	// INFO: in.GrafanaImagePrefix opted out of conversion generation
+	out.GrafanaImage = in.GrafanaImage
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableOtelCollector, &out.EnableOtelCollector, s); err != nil {
+		return err
+	}
+	out.OtlpEndpoint = in.OtlpEndpoint
+	out.OtelCollectorPort = in.OtelCollectorPort
+	out.OtelCollectorVersion = in.OtelCollectorVersion
+	// INFO: in.OtelCollectorImagePrefix opted out of conversion generation
+	out.OtelCollectorImage = in.OtelCollectorImage
+	// INFO: in.OtelCollectorBinaryPrefix opted out of conversion generation
+	out.OtelCollectorBinary = in.OtelCollectorBinary
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableKonnectivity, &out.EnableKonnectivity, s); err != nil {
+		return err
+	}
+	out.KonnectivityVersion = in.KonnectivityVersion
+	// INFO: in.KonnectivityImagePrefix opted out of conversion generation
+	out.KonnectivityServerImage = in.KonnectivityServerImage
+	out.KonnectivityAgentImage = in.KonnectivityAgentImage
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableCSRSigner, &out.EnableCSRSigner, s); err != nil {
+		return err
+	}
 	// INFO: in.KwokBinaryPrefix opted out of conversion generation
 	out.KwokControllerBinary = in.KwokControllerBinary
 	// INFO: in.PrometheusBinaryPrefix opted out of conversion generation
@@ -1822,7 +2202,13 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	// INFO: in.Mode opted out of conversion generation
 	out.KubeFeatureGates = in.KubeFeatureGates
 	out.KubeRuntimeConfig = in.KubeRuntimeConfig
+	out.KubeEmulateRemovals = in.KubeEmulateRemovals
 	out.KubeAuditPolicy = in.KubeAuditPolicy
+	out.KubeAuditWebhook = in.KubeAuditWebhook
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableKubeAuditSink, &out.EnableKubeAuditSink, s); err != nil {
+		return err
+	}
+	out.KubeAuditSinkPort = in.KubeAuditSinkPort
 	if err := v1.Convert_Pointer_bool_To_bool(&in.KubeAuthorization, &out.KubeAuthorization, s); err != nil {
 		return err
 	}
@@ -1844,11 +2230,26 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	if err := v1.Convert_Pointer_float64_To_float64(&in.HeartbeatFactor, &out.HeartbeatFactor, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_float64_To_float64(&in.TimeAcceleration, &out.TimeAcceleration, s); err != nil {
+		return err
+	}
+	out.Lifecycle = in.Lifecycle
+	out.NodeProfiles = *(*[]NodeProfile)(unsafe.Pointer(&in.NodeProfiles))
+	out.ReadinessFailurePolicy = ReadinessFailurePolicy(in.ReadinessFailurePolicy)
 	out.BindAddress = in.BindAddress
+	out.IPFamily = in.IPFamily
 	out.KubeApiserverCertSANs = *(*[]string)(unsafe.Pointer(&in.KubeApiserverCertSANs))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.CaCert = in.CaCert
+	out.CaKey = in.CaKey
 	if err := v1.Convert_Pointer_bool_To_bool(&in.DisableQPSLimits, &out.DisableQPSLimits, s); err != nil {
 		return err
 	}
+	out.LogVolumes = (*LogVolumes)(unsafe.Pointer(in.LogVolumes))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.SuperviseComponents, &out.SuperviseComponents, s); err != nil {
+		return err
+	}
+	out.InitSystem = in.InitSystem
 	return nil
 }
 
@@ -1910,6 +2311,9 @@ func autoConvert_internalversion_Log_To_v1alpha1_Log(in *Log, out *v1alpha1.Log,
 	if err := v1.Convert_bool_To_Pointer_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_string_To_Pointer_string(&in.PreviousLogsFile, &out.PreviousLogsFile, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1926,6 +2330,9 @@ func autoConvert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log,
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_string_To_string(&in.PreviousLogsFile, &out.PreviousLogsFile, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1934,6 +2341,56 @@ func Convert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log, s c
 	return autoConvert_v1alpha1_Log_To_internalversion_Log(in, out, s)
 }
 
+func autoConvert_internalversion_LogVolumeMount_To_v1alpha1_LogVolumeMount(in *LogVolumeMount, out *configv1alpha1.LogVolumeMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.ReadWrite = (*bool)(unsafe.Pointer(in.ReadWrite))
+	out.MountPropagation = configv1alpha1.MountPropagationMode(in.MountPropagation)
+	return nil
+}
+
+// Convert_internalversion_LogVolumeMount_To_v1alpha1_LogVolumeMount is an autogenerated conversion function.
+func Convert_internalversion_LogVolumeMount_To_v1alpha1_LogVolumeMount(in *LogVolumeMount, out *configv1alpha1.LogVolumeMount, s conversion.Scope) error {
+	return autoConvert_internalversion_LogVolumeMount_To_v1alpha1_LogVolumeMount(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogVolumeMount_To_internalversion_LogVolumeMount(in *configv1alpha1.LogVolumeMount, out *LogVolumeMount, s conversion.Scope) error {
+	out.Path = in.Path
+	out.ReadWrite = (*bool)(unsafe.Pointer(in.ReadWrite))
+	out.MountPropagation = MountPropagationMode(in.MountPropagation)
+	return nil
+}
+
+// Convert_v1alpha1_LogVolumeMount_To_internalversion_LogVolumeMount is an autogenerated conversion function.
+func Convert_v1alpha1_LogVolumeMount_To_internalversion_LogVolumeMount(in *configv1alpha1.LogVolumeMount, out *LogVolumeMount, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogVolumeMount_To_internalversion_LogVolumeMount(in, out, s)
+}
+
+func autoConvert_internalversion_LogVolumes_To_v1alpha1_LogVolumes(in *LogVolumes, out *configv1alpha1.LogVolumes, s conversion.Scope) error {
+	out.ReadWrite = in.ReadWrite
+	out.AllowedPaths = *(*[]string)(unsafe.Pointer(&in.AllowedPaths))
+	out.DeniedPaths = *(*[]string)(unsafe.Pointer(&in.DeniedPaths))
+	out.Mounts = *(*[]configv1alpha1.LogVolumeMount)(unsafe.Pointer(&in.Mounts))
+	return nil
+}
+
+// Convert_internalversion_LogVolumes_To_v1alpha1_LogVolumes is an autogenerated conversion function.
+func Convert_internalversion_LogVolumes_To_v1alpha1_LogVolumes(in *LogVolumes, out *configv1alpha1.LogVolumes, s conversion.Scope) error {
+	return autoConvert_internalversion_LogVolumes_To_v1alpha1_LogVolumes(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogVolumes_To_internalversion_LogVolumes(in *configv1alpha1.LogVolumes, out *LogVolumes, s conversion.Scope) error {
+	out.ReadWrite = in.ReadWrite
+	out.AllowedPaths = *(*[]string)(unsafe.Pointer(&in.AllowedPaths))
+	out.DeniedPaths = *(*[]string)(unsafe.Pointer(&in.DeniedPaths))
+	out.Mounts = *(*[]LogVolumeMount)(unsafe.Pointer(&in.Mounts))
+	return nil
+}
+
+// Convert_v1alpha1_LogVolumes_To_internalversion_LogVolumes is an autogenerated conversion function.
+func Convert_v1alpha1_LogVolumes_To_internalversion_LogVolumes(in *configv1alpha1.LogVolumes, out *LogVolumes, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogVolumes_To_internalversion_LogVolumes(in, out, s)
+}
+
 func autoConvert_internalversion_Logs_To_v1alpha1_Logs(in *Logs, out *v1alpha1.Logs, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_LogsSpec_To_v1alpha1_LogsSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2130,6 +2587,30 @@ func Convert_v1alpha1_MetricSpec_To_internalversion_MetricSpec(in *v1alpha1.Metr
 	return autoConvert_v1alpha1_MetricSpec_To_internalversion_MetricSpec(in, out, s)
 }
 
+func autoConvert_internalversion_NodeProfile_To_v1alpha1_NodeProfile(in *NodeProfile, out *configv1alpha1.NodeProfile, s conversion.Scope) error {
+	out.Preset = in.Preset
+	out.Replicas = in.Replicas
+	out.Params = *(*[]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_internalversion_NodeProfile_To_v1alpha1_NodeProfile is an autogenerated conversion function.
+func Convert_internalversion_NodeProfile_To_v1alpha1_NodeProfile(in *NodeProfile, out *configv1alpha1.NodeProfile, s conversion.Scope) error {
+	return autoConvert_internalversion_NodeProfile_To_v1alpha1_NodeProfile(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodeProfile_To_internalversion_NodeProfile(in *configv1alpha1.NodeProfile, out *NodeProfile, s conversion.Scope) error {
+	out.Preset = in.Preset
+	out.Replicas = in.Replicas
+	out.Params = *(*[]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_v1alpha1_NodeProfile_To_internalversion_NodeProfile is an autogenerated conversion function.
+func Convert_v1alpha1_NodeProfile_To_internalversion_NodeProfile(in *configv1alpha1.NodeProfile, out *NodeProfile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodeProfile_To_internalversion_NodeProfile(in, out, s)
+}
+
 func autoConvert_internalversion_ObjectSelector_To_v1alpha1_ObjectSelector(in *ObjectSelector, out *v1alpha1.ObjectSelector, s conversion.Scope) error {
 	out.MatchNamespaces = *(*[]string)(unsafe.Pointer(&in.MatchNamespaces))
 	out.MatchNames = *(*[]string)(unsafe.Pointer(&in.MatchNames))
@@ -2178,6 +2659,62 @@ func Convert_v1alpha1_Port_To_internalversion_Port(in *configv1alpha1.Port, out
 	return autoConvert_v1alpha1_Port_To_internalversion_Port(in, out, s)
 }
 
+func autoConvert_internalversion_Pause_To_v1alpha1_Pause(in *Pause, out *v1alpha1.Pause, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_internalversion_PauseSpec_To_v1alpha1_PauseSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_Pause_To_v1alpha1_Pause is an autogenerated conversion function.
+func Convert_internalversion_Pause_To_v1alpha1_Pause(in *Pause, out *v1alpha1.Pause, s conversion.Scope) error {
+	return autoConvert_internalversion_Pause_To_v1alpha1_Pause(in, out, s)
+}
+
+func autoConvert_v1alpha1_Pause_To_internalversion_Pause(in *v1alpha1.Pause, out *Pause, s conversion.Scope) error {
+	// INFO: in.TypeMeta opted out of conversion generation
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_PauseSpec_To_internalversion_PauseSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	// INFO: in.Status opted out of conversion generation
+	return nil
+}
+
+// Convert_v1alpha1_Pause_To_internalversion_Pause is an autogenerated conversion function.
+func Convert_v1alpha1_Pause_To_internalversion_Pause(in *v1alpha1.Pause, out *Pause, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Pause_To_internalversion_Pause(in, out, s)
+}
+
+func autoConvert_internalversion_PauseSpec_To_v1alpha1_PauseSpec(in *PauseSpec, out *v1alpha1.PauseSpec, s conversion.Scope) error {
+	if err := Convert_internalversion_StageResourceRef_To_v1alpha1_StageResourceRef(&in.ResourceRef, &out.ResourceRef, s); err != nil {
+		return err
+	}
+	out.Selector = (*v1alpha1.ObjectSelector)(unsafe.Pointer(in.Selector))
+	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
+	return nil
+}
+
+// Convert_internalversion_PauseSpec_To_v1alpha1_PauseSpec is an autogenerated conversion function.
+func Convert_internalversion_PauseSpec_To_v1alpha1_PauseSpec(in *PauseSpec, out *v1alpha1.PauseSpec, s conversion.Scope) error {
+	return autoConvert_internalversion_PauseSpec_To_v1alpha1_PauseSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_PauseSpec_To_internalversion_PauseSpec(in *v1alpha1.PauseSpec, out *PauseSpec, s conversion.Scope) error {
+	if err := Convert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(&in.ResourceRef, &out.ResourceRef, s); err != nil {
+		return err
+	}
+	out.Selector = (*ObjectSelector)(unsafe.Pointer(in.Selector))
+	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
+	return nil
+}
+
+// Convert_v1alpha1_PauseSpec_To_internalversion_PauseSpec is an autogenerated conversion function.
+func Convert_v1alpha1_PauseSpec_To_internalversion_PauseSpec(in *v1alpha1.PauseSpec, out *PauseSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PauseSpec_To_internalversion_PauseSpec(in, out, s)
+}
+
 func autoConvert_internalversion_PortForward_To_v1alpha1_PortForward(in *PortForward, out *v1alpha1.PortForward, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_PortForwardSpec_To_v1alpha1_PortForwardSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2318,6 +2855,30 @@ func Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(i
 	return autoConvert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(in, out, s)
 }
 
+func autoConvert_internalversion_SecretFile_To_v1alpha1_SecretFile(in *SecretFile, out *configv1alpha1.SecretFile, s conversion.Scope) error {
+	out.HostPath = in.HostPath
+	out.MountPath = in.MountPath
+	out.EnvName = in.EnvName
+	return nil
+}
+
+// Convert_internalversion_SecretFile_To_v1alpha1_SecretFile is an autogenerated conversion function.
+func Convert_internalversion_SecretFile_To_v1alpha1_SecretFile(in *SecretFile, out *configv1alpha1.SecretFile, s conversion.Scope) error {
+	return autoConvert_internalversion_SecretFile_To_v1alpha1_SecretFile(in, out, s)
+}
+
+func autoConvert_v1alpha1_SecretFile_To_internalversion_SecretFile(in *configv1alpha1.SecretFile, out *SecretFile, s conversion.Scope) error {
+	out.HostPath = in.HostPath
+	out.MountPath = in.MountPath
+	out.EnvName = in.EnvName
+	return nil
+}
+
+// Convert_v1alpha1_SecretFile_To_internalversion_SecretFile is an autogenerated conversion function.
+func Convert_v1alpha1_SecretFile_To_internalversion_SecretFile(in *configv1alpha1.SecretFile, out *SecretFile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SecretFile_To_internalversion_SecretFile(in, out, s)
+}
+
 func autoConvert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(in *SecurityContext, out *v1alpha1.SecurityContext, s conversion.Scope) error {
 	out.RunAsUser = (*int64)(unsafe.Pointer(in.RunAsUser))
 	out.RunAsGroup = (*int64)(unsafe.Pointer(in.RunAsGroup))
@@ -2392,6 +2953,28 @@ func Convert_v1alpha1_Stage_To_internalversion_Stage(in *v1alpha1.Stage, out *St
 	return autoConvert_v1alpha1_Stage_To_internalversion_Stage(in, out, s)
 }
 
+func autoConvert_internalversion_StageConcurrency_To_v1alpha1_StageConcurrency(in *StageConcurrency, out *v1alpha1.StageConcurrency, s conversion.Scope) error {
+	out.Limit = in.Limit
+	out.Scope = v1alpha1.StageConcurrencyScope(in.Scope)
+	return nil
+}
+
+// Convert_internalversion_StageConcurrency_To_v1alpha1_StageConcurrency is an autogenerated conversion function.
+func Convert_internalversion_StageConcurrency_To_v1alpha1_StageConcurrency(in *StageConcurrency, out *v1alpha1.StageConcurrency, s conversion.Scope) error {
+	return autoConvert_internalversion_StageConcurrency_To_v1alpha1_StageConcurrency(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageConcurrency_To_internalversion_StageConcurrency(in *v1alpha1.StageConcurrency, out *StageConcurrency, s conversion.Scope) error {
+	out.Limit = in.Limit
+	out.Scope = StageConcurrencyScope(in.Scope)
+	return nil
+}
+
+// Convert_v1alpha1_StageConcurrency_To_internalversion_StageConcurrency is an autogenerated conversion function.
+func Convert_v1alpha1_StageConcurrency_To_internalversion_StageConcurrency(in *v1alpha1.StageConcurrency, out *StageConcurrency, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageConcurrency_To_internalversion_StageConcurrency(in, out, s)
+}
+
 func autoConvert_internalversion_StageDelay_To_v1alpha1_StageDelay(in *StageDelay, out *v1alpha1.StageDelay, s conversion.Scope) error {
 	out.DurationMilliseconds = (*int64)(unsafe.Pointer(in.DurationMilliseconds))
 	out.DurationFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.DurationFrom))
@@ -2481,6 +3064,8 @@ func autoConvert_internalversion_StageNext_To_v1alpha1_StageNext(in *StageNext,
 	} else {
 		out.Patches = nil
 	}
+	out.Webhook = (*v1alpha1.StageWebhook)(unsafe.Pointer(in.Webhook))
+	out.Plugin = (*v1alpha1.StagePlugin)(unsafe.Pointer(in.Plugin))
 	return nil
 }
 
@@ -2499,12 +3084,60 @@ func autoConvert_v1alpha1_StageNext_To_internalversion_StageNext(in *v1alpha1.St
 	} else {
 		out.Patches = nil
 	}
+	out.Webhook = (*StageWebhook)(unsafe.Pointer(in.Webhook))
+	out.Plugin = (*StagePlugin)(unsafe.Pointer(in.Plugin))
 	// INFO: in.StatusTemplate opted out of conversion generation
 	// INFO: in.StatusSubresource opted out of conversion generation
 	// INFO: in.StatusPatchAs opted out of conversion generation
 	return nil
 }
 
+func autoConvert_internalversion_StagePlugin_To_v1alpha1_StagePlugin(in *StagePlugin, out *v1alpha1.StagePlugin, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	return nil
+}
+
+// Convert_internalversion_StagePlugin_To_v1alpha1_StagePlugin is an autogenerated conversion function.
+func Convert_internalversion_StagePlugin_To_v1alpha1_StagePlugin(in *StagePlugin, out *v1alpha1.StagePlugin, s conversion.Scope) error {
+	return autoConvert_internalversion_StagePlugin_To_v1alpha1_StagePlugin(in, out, s)
+}
+
+func autoConvert_v1alpha1_StagePlugin_To_internalversion_StagePlugin(in *v1alpha1.StagePlugin, out *StagePlugin, s conversion.Scope) error {
+	out.Path = in.Path
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	return nil
+}
+
+// Convert_v1alpha1_StagePlugin_To_internalversion_StagePlugin is an autogenerated conversion function.
+func Convert_v1alpha1_StagePlugin_To_internalversion_StagePlugin(in *v1alpha1.StagePlugin, out *StagePlugin, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StagePlugin_To_internalversion_StagePlugin(in, out, s)
+}
+
+func autoConvert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in *StageWebhook, out *v1alpha1.StageWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	out.FailurePolicy = v1alpha1.StageWebhookFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook is an autogenerated conversion function.
+func Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in *StageWebhook, out *v1alpha1.StageWebhook, s conversion.Scope) error {
+	return autoConvert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in *v1alpha1.StageWebhook, out *StageWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	out.FailurePolicy = StageWebhookFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook is an autogenerated conversion function.
+func Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in *v1alpha1.StageWebhook, out *StageWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in, out, s)
+}
+
 func autoConvert_internalversion_StagePatch_To_v1alpha1_StagePatch(in *StagePatch, out *v1alpha1.StagePatch, s conversion.Scope) error {
 	out.Subresource = in.Subresource
 	out.Root = in.Root
@@ -2582,6 +3215,7 @@ func autoConvert_internalversion_StageSpec_To_v1alpha1_StageSpec(in *StageSpec,
 	out.Weight = in.Weight
 	out.WeightFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.WeightFrom))
 	out.Delay = (*v1alpha1.StageDelay)(unsafe.Pointer(in.Delay))
+	out.Concurrency = (*v1alpha1.StageConcurrency)(unsafe.Pointer(in.Concurrency))
 	if err := Convert_internalversion_StageNext_To_v1alpha1_StageNext(&in.Next, &out.Next, s); err != nil {
 		return err
 	}
@@ -2604,6 +3238,7 @@ func autoConvert_v1alpha1_StageSpec_To_internalversion_StageSpec(in *v1alpha1.St
 	out.Weight = in.Weight
 	out.WeightFrom = (*ExpressionFromSource)(unsafe.Pointer(in.WeightFrom))
 	out.Delay = (*StageDelay)(unsafe.Pointer(in.Delay))
+	out.Concurrency = (*StageConcurrency)(unsafe.Pointer(in.Concurrency))
 	if err := Convert_v1alpha1_StageNext_To_internalversion_StageNext(&in.Next, &out.Next, s); err != nil {
 		return err
 	}
@@ -2626,6 +3261,8 @@ func autoConvert_internalversion_Volume_To_v1alpha1_Volume(in *Volume, out *conf
 	out.HostPath = in.HostPath
 	out.MountPath = in.MountPath
 	out.PathType = configv1alpha1.HostPathType(in.PathType)
+	out.SubPath = in.SubPath
+	out.MountPropagation = configv1alpha1.MountPropagationMode(in.MountPropagation)
 	return nil
 }
 
@@ -2642,6 +3279,8 @@ func autoConvert_v1alpha1_Volume_To_internalversion_Volume(in *configv1alpha1.Vo
 	out.HostPath = in.HostPath
 	out.MountPath = in.MountPath
 	out.PathType = HostPathType(in.PathType)
+	out.SubPath = in.SubPath
+	out.MountPropagation = MountPropagationMode(in.MountPropagation)
 	return nil
 }
 
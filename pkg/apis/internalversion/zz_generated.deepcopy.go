@@ -325,6 +325,11 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SoftLinks != nil {
+		in, out := &in.SoftLinks, &out.SoftLinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
@@ -345,6 +350,11 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = make([]Env, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnvFiles != nil {
+		in, out := &in.EnvFiles, &out.EnvFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]Volume, len(*in))
@@ -402,11 +412,26 @@ func (in *ComponentPatches) DeepCopyInto(out *ComponentPatches) {
 		*out = make([]Volume, len(*in))
 		copy(*out, *in)
 	}
+	if in.RemoveVolumes != nil {
+		in, out := &in.RemoveVolumes, &out.RemoveVolumes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExtraEnvs != nil {
 		in, out := &in.ExtraEnvs, &out.ExtraEnvs
 		*out = make([]Env, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExtraEnvFiles != nil {
+		in, out := &in.ExtraEnvFiles, &out.ExtraEnvFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraSecrets != nil {
+		in, out := &in.ExtraSecrets, &out.ExtraSecrets
+		*out = make([]SecretFile, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -674,6 +699,22 @@ func (in *KwokConfiguration) DeepCopy() *KwokConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KwokConfigurationCluster) DeepCopyInto(out *KwokConfigurationCluster) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KwokConfigurationCluster.
+func (in *KwokConfigurationCluster) DeepCopy() *KwokConfigurationCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(KwokConfigurationCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions) {
 	*out = *in
@@ -682,6 +723,11 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]KwokConfigurationCluster, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -714,6 +760,13 @@ func (in *KwokctlConfiguration) DeepCopyInto(out *KwokctlConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraComponents != nil {
+		in, out := &in.ExtraComponents, &out.ExtraComponents
+		*out = make([]Component, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Status = in.Status
 	return
 }
@@ -736,16 +789,43 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.KubeApiserverFaults != nil {
+		in, out := &in.KubeApiserverFaults, &out.KubeApiserverFaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Runtimes != nil {
 		in, out := &in.Runtimes, &out.Runtimes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EtcdEndpoints != nil {
+		in, out := &in.EtcdEndpoints, &out.EtcdEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeProfiles != nil {
+		in, out := &in.NodeProfiles, &out.NodeProfiles
+		*out = make([]NodeProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.KubeApiserverCertSANs != nil {
 		in, out := &in.KubeApiserverCertSANs, &out.KubeApiserverCertSANs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogVolumes != nil {
+		in, out := &in.LogVolumes, &out.LogVolumes
+		*out = new(LogVolumes)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -818,6 +898,60 @@ func (in *Log) DeepCopy() *Log {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVolumeMount) DeepCopyInto(out *LogVolumeMount) {
+	*out = *in
+	if in.ReadWrite != nil {
+		in, out := &in.ReadWrite, &out.ReadWrite
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVolumeMount.
+func (in *LogVolumeMount) DeepCopy() *LogVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogVolumes) DeepCopyInto(out *LogVolumes) {
+	*out = *in
+	if in.AllowedPaths != nil {
+		in, out := &in.AllowedPaths, &out.AllowedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedPaths != nil {
+		in, out := &in.DeniedPaths, &out.DeniedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([]LogVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogVolumes.
+func (in *LogVolumes) DeepCopy() *LogVolumes {
+	if in == nil {
+		return nil
+	}
+	out := new(LogVolumes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Logs) DeepCopyInto(out *Logs) {
 	*out = *in
@@ -984,6 +1118,27 @@ func (in *ObjectSelector) DeepCopy() *ObjectSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeProfile) DeepCopyInto(out *NodeProfile) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeProfile.
+func (in *NodeProfile) DeepCopy() *NodeProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Port) DeepCopyInto(out *Port) {
 	*out = *in
@@ -1000,6 +1155,53 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pause) DeepCopyInto(out *Pause) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pause.
+func (in *Pause) DeepCopy() *Pause {
+	if in == nil {
+		return nil
+	}
+	out := new(Pause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSpec) DeepCopyInto(out *PauseSpec) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(ObjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSpec.
+func (in *PauseSpec) DeepCopy() *PauseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortForward) DeepCopyInto(out *PortForward) {
 	*out = *in
@@ -1136,6 +1338,22 @@ func (in *ResourceUsageValue) DeepCopy() *ResourceUsageValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretFile) DeepCopyInto(out *SecretFile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretFile.
+func (in *SecretFile) DeepCopy() *SecretFile {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityContext) DeepCopyInto(out *SecurityContext) {
 	*out = *in
@@ -1201,6 +1419,22 @@ func (in *Stage) DeepCopy() *Stage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageConcurrency) DeepCopyInto(out *StageConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageConcurrency.
+func (in *StageConcurrency) DeepCopy() *StageConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(StageConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 	*out = *in
@@ -1299,6 +1533,16 @@ func (in *StageNext) DeepCopyInto(out *StageNext) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(StageWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(StagePlugin)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1312,6 +1556,48 @@ func (in *StageNext) DeepCopy() *StageNext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagePlugin) DeepCopyInto(out *StagePlugin) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagePlugin.
+func (in *StagePlugin) DeepCopy() *StagePlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(StagePlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageWebhook) DeepCopyInto(out *StageWebhook) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageWebhook.
+func (in *StageWebhook) DeepCopy() *StageWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(StageWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StagePatch) DeepCopyInto(out *StagePatch) {
 	*out = *in
@@ -1410,6 +1696,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(StageDelay)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(StageConcurrency)
+		**out = **in
+	}
 	in.Next.DeepCopyInto(&out.Next)
 	return
 }
@@ -69,6 +69,9 @@ type Log struct {
 	LogsFile *string `json:"logsFile,omitempty"`
 	// Follow up if true
 	Follow *bool `json:"follow,omitempty"`
+	// PreviousLogsFile is the file of the logs of the previous terminated container,
+	// which is served when the logs are requested with previous.
+	PreviousLogsFile *string `json:"previousLogsFile,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PauseKind is the kind for Pause.
+	PauseKind = "Pause"
+
+	// PauseConditionReady is the condition type of a Pause which is enforced by the controller.
+	PauseConditionReady = "Ready"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:rbac:groups=kwok.x-k8s.io,resources=pauses,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=kwok.x-k8s.io,resources=pauses/status,verbs=update;patch
+
+// Pause pauses the simulation of the selected objects until it is deleted.
+type Pause struct {
+	//+k8s:conversion-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec holds spec for pause.
+	Spec PauseSpec `json:"spec"`
+	// Status holds status for pause
+	//+k8s:conversion-gen=false
+	Status PauseStatus `json:"status,omitempty"`
+}
+
+// PauseStatus holds status for pause
+type PauseStatus struct {
+	// Paused is the number of the objects matched and paused by it.
+	Paused int64 `json:"paused,omitempty"`
+	// Conditions holds conditions for pause
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// PauseSpec holds spec for pause.
+type PauseSpec struct {
+	// ResourceRef specifies the Kind and version of the resource to pause.
+	ResourceRef StageResourceRef `json:"resourceRef"`
+	// Selector is a selector to filter the objects to pause by name and namespace.
+	Selector *ObjectSelector `json:"selector,omitempty"`
+	// MatchLabels is a map of {key,value} pairs of the labels of the objects to pause.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// PauseList is a list of Pause.
+type PauseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Pause `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Pause{}, &PauseList{})
+}
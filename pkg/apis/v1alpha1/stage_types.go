@@ -75,6 +75,8 @@ type StageSpec struct {
 	WeightFrom *ExpressionFromSource `json:"weightFrom,omitempty"`
 	// Delay means there is a delay in this stage.
 	Delay *StageDelay `json:"delay,omitempty"`
+	// Concurrency limits how many objects may be in this stage at the same time.
+	Concurrency *StageConcurrency `json:"concurrency,omitempty"`
 	// Next indicates that this stage will be moved to.
 	Next StageNext `json:"next"`
 	// ImmediateNextStage means that the next stage of matching is performed immediately, without waiting for the Apiserver to push.
@@ -112,6 +114,30 @@ type StageDelay struct {
 	JitterDurationFrom *ExpressionFromSource `json:"jitterDurationFrom,omitempty"`
 }
 
+// StageConcurrency limits how many objects may be in a stage at the same time,
+// an object is in the stage from the time it is matched until the stage is played after the delay,
+// and the objects over the limit wait in order before their delays start.
+type StageConcurrency struct {
+	// Limit is the max number of the objects in the stage at the same time.
+	// +kubebuilder:validation:Minimum=1
+	Limit uint `json:"limit"`
+	// Scope is the scope of the limit, the objects in the whole cluster or on the same node.
+	// +default="cluster"
+	// +kubebuilder:default="cluster"
+	Scope StageConcurrencyScope `json:"scope,omitempty"`
+}
+
+// StageConcurrencyScope is the scope of the concurrency limit of a stage.
+// +enum
+type StageConcurrencyScope string
+
+const (
+	// StageConcurrencyScopeCluster limits the objects in the whole cluster.
+	StageConcurrencyScopeCluster StageConcurrencyScope = "cluster"
+	// StageConcurrencyScopeNode limits the objects on the same node, by the .spec.nodeName of the objects or the name of the nodes.
+	StageConcurrencyScopeNode StageConcurrencyScope = "node"
+)
+
 // StageNext describes a stage will be moved to.
 type StageNext struct {
 	// Event means that an event will be sent.
@@ -122,6 +148,12 @@ type StageNext struct {
 	Delete bool `json:"delete,omitempty"`
 	// Patches means that the resource will be patched.
 	Patches []StagePatch `json:"patches,omitempty"`
+	// Webhook means that the delay and the patches are decided by an external HTTP endpoint,
+	// the decision is made when the stage is matched and added to the delay and the patches of the stage.
+	Webhook *StageWebhook `json:"webhook,omitempty"`
+	// Plugin means that the delay and the patches are decided by a WebAssembly module,
+	// it is the same as the webhook but runs in the sandbox of kwok, it can't be used with the webhook.
+	Plugin *StagePlugin `json:"plugin,omitempty"`
 
 	// StatusTemplate indicates the template for modifying the status of the resource in the next.
 	// Deprecated: Use Patches instead.
@@ -143,6 +175,40 @@ type StageNext struct {
 	StatusPatchAs *ImpersonationConfig `json:"statusPatchAs,omitempty"`
 }
 
+// StageWebhook describes the external HTTP endpoint that decides the next of the resource.
+// The endpoint receives a POST of the stage name and the resource as JSON,
+// and responds with the delay in milliseconds and the patches of the resource.
+type StageWebhook struct {
+	// URL is the URL of the endpoint.
+	URL string `json:"url"`
+	// TimeoutMilliseconds is the timeout of the request, defaults to 10 seconds.
+	TimeoutMilliseconds *int64 `json:"timeoutMilliseconds,omitempty"`
+	// FailurePolicy is the policy when the request fails, defaults to Fail.
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	FailurePolicy StageWebhookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// StagePlugin describes the WebAssembly module that decides the next of the resource.
+// The module exports the memory, `alloc(size i32) i32` and `decide(ptr i32, size i32) i64`,
+// `decide` receives the same JSON as the webhook and returns the pointer and the size of the response
+// in the high and the low 32 bits.
+type StagePlugin struct {
+	// Path is the path of the module, it is reloaded when the file is modified.
+	Path string `json:"path"`
+	// TimeoutMilliseconds is the timeout of the call, defaults to 10 seconds.
+	TimeoutMilliseconds *int64 `json:"timeoutMilliseconds,omitempty"`
+}
+
+// StageWebhookFailurePolicy is the policy when the request of the webhook fails.
+type StageWebhookFailurePolicy string
+
+const (
+	// StageWebhookFailurePolicyFail means that the stage is not played until the resource is changed again.
+	StageWebhookFailurePolicyFail StageWebhookFailurePolicy = "Fail"
+	// StageWebhookFailurePolicyIgnore means that the stage is played without the decision of the webhook.
+	StageWebhookFailurePolicyIgnore StageWebhookFailurePolicy = "Ignore"
+)
+
 // StagePatch describes the patch for the resource.
 type StagePatch struct {
 	// Subresource indicates the name of the subresource that will be patched.
@@ -1023,6 +1023,11 @@ func (in *Log) DeepCopyInto(out *Log) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PreviousLogsFile != nil {
+		in, out := &in.PreviousLogsFile, &out.PreviousLogsFile
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -1334,6 +1339,119 @@ func (in *ObjectSelector) DeepCopy() *ObjectSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pause) DeepCopyInto(out *Pause) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pause.
+func (in *Pause) DeepCopy() *Pause {
+	if in == nil {
+		return nil
+	}
+	out := new(Pause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pause) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseList) DeepCopyInto(out *PauseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pause, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseList.
+func (in *PauseList) DeepCopy() *PauseList {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PauseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSpec) DeepCopyInto(out *PauseSpec) {
+	*out = *in
+	out.ResourceRef = in.ResourceRef
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(ObjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSpec.
+func (in *PauseSpec) DeepCopy() *PauseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseStatus) DeepCopyInto(out *PauseStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseStatus.
+func (in *PauseStatus) DeepCopy() *PauseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortForward) DeepCopyInto(out *PortForward) {
 	*out = *in
@@ -1677,6 +1795,22 @@ func (in *Stage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageConcurrency) DeepCopyInto(out *StageConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageConcurrency.
+func (in *StageConcurrency) DeepCopy() *StageConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(StageConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 	*out = *in
@@ -1808,6 +1942,16 @@ func (in *StageNext) DeepCopyInto(out *StageNext) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(StageWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(StagePlugin)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StatusSubresource != nil {
 		in, out := &in.StatusSubresource, &out.StatusSubresource
 		*out = new(string)
@@ -1831,6 +1975,48 @@ func (in *StageNext) DeepCopy() *StageNext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagePlugin) DeepCopyInto(out *StagePlugin) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagePlugin.
+func (in *StagePlugin) DeepCopy() *StagePlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(StagePlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageWebhook) DeepCopyInto(out *StageWebhook) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageWebhook.
+func (in *StageWebhook) DeepCopy() *StageWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(StageWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StagePatch) DeepCopyInto(out *StagePatch) {
 	*out = *in
@@ -1929,6 +2115,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(StageDelay)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(StageConcurrency)
+		**out = **in
+	}
 	in.Next.DeepCopyInto(&out.Next)
 	if in.ImmediateNextStage != nil {
 		in, out := &in.ImmediateNextStage, &out.ImmediateNextStage
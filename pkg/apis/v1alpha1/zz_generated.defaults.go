@@ -31,6 +31,8 @@ import (
 func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&Metric{}, func(obj interface{}) { SetObjectDefaults_Metric(obj.(*Metric)) })
 	scheme.AddTypeDefaultingFunc(&MetricList{}, func(obj interface{}) { SetObjectDefaults_MetricList(obj.(*MetricList)) })
+	scheme.AddTypeDefaultingFunc(&Pause{}, func(obj interface{}) { SetObjectDefaults_Pause(obj.(*Pause)) })
+	scheme.AddTypeDefaultingFunc(&PauseList{}, func(obj interface{}) { SetObjectDefaults_PauseList(obj.(*PauseList)) })
 	scheme.AddTypeDefaultingFunc(&Stage{}, func(obj interface{}) { SetObjectDefaults_Stage(obj.(*Stage)) })
 	scheme.AddTypeDefaultingFunc(&StageList{}, func(obj interface{}) { SetObjectDefaults_StageList(obj.(*StageList)) })
 	return nil
@@ -52,6 +54,19 @@ func SetObjectDefaults_MetricList(in *MetricList) {
 	}
 }
 
+func SetObjectDefaults_Pause(in *Pause) {
+	if in.Spec.ResourceRef.APIGroup == "" {
+		in.Spec.ResourceRef.APIGroup = "v1"
+	}
+}
+
+func SetObjectDefaults_PauseList(in *PauseList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_Pause(a)
+	}
+}
+
 func SetObjectDefaults_Stage(in *Stage) {
 	if in.Spec.ResourceRef.APIGroup == "" {
 		in.Spec.ResourceRef.APIGroup = "v1"
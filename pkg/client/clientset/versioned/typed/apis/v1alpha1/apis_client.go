@@ -37,6 +37,7 @@ type KwokV1alpha1Interface interface {
 	ExecsGetter
 	LogsGetter
 	MetricsGetter
+	PausesGetter
 	PortForwardsGetter
 	ResourceUsagesGetter
 	StagesGetter
@@ -83,6 +84,10 @@ func (c *KwokV1alpha1Client) Metrics() MetricInterface {
 	return newMetrics(c)
 }
 
+func (c *KwokV1alpha1Client) Pauses() PauseInterface {
+	return newPauses(c)
+}
+
 func (c *KwokV1alpha1Client) PortForwards(namespace string) PortForwardInterface {
 	return newPortForwards(c, namespace)
 }
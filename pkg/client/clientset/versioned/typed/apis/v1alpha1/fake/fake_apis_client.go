@@ -64,6 +64,10 @@ func (c *FakeKwokV1alpha1) Metrics() v1alpha1.MetricInterface {
 	return &FakeMetrics{c}
 }
 
+func (c *FakeKwokV1alpha1) Pauses() v1alpha1.PauseInterface {
+	return &FakePauses{c}
+}
+
 func (c *FakeKwokV1alpha1) PortForwards(namespace string) v1alpha1.PortForwardInterface {
 	return &FakePortForwards{c, namespace}
 }
@@ -36,6 +36,8 @@ type LogsExpansion interface{}
 
 type MetricExpansion interface{}
 
+type PauseExpansion interface{}
+
 type PortForwardExpansion interface{}
 
 type ResourceUsageExpansion interface{}
@@ -179,6 +179,12 @@ var configHandlers = map[string]configHandler{
 		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalMetric),
 		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1Alpha1Metric),
 	},
+	v1alpha1.PauseKind: {
+		Unmarshal:        unmarshalConfig[*v1alpha1.Pause],
+		Marshal:          marshalConfig,
+		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalPause),
+		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1Alpha1Pause),
+	},
 }
 
 func unmarshalConfig[T versiondObject](raw []byte) (versiondObject, error) {
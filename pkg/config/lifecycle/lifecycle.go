@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lifecycle provides the bundled stages which simulate the lifecycle of nodes, pods and other resources.
+package lifecycle
+
+import (
+	"fmt"
+
+	csrgeneral "sigs.k8s.io/kwok/kustomize/stage/csr/general"
+	gatewaygeneral "sigs.k8s.io/kwok/kustomize/stage/gateway/general"
+	ingressgeneral "sigs.k8s.io/kwok/kustomize/stage/ingress/general"
+	nodecloudprovider "sigs.k8s.io/kwok/kustomize/stage/node/cloud-provider"
+	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
+	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
+	podchaos "sigs.k8s.io/kwok/kustomize/stage/pod/chaos"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	podgeneral "sigs.k8s.io/kwok/kustomize/stage/pod/general"
+	servicekubeproxy "sigs.k8s.io/kwok/kustomize/stage/service/kube-proxy"
+	serviceloadbalancer "sigs.k8s.io/kwok/kustomize/stage/service/load-balancer"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+const (
+	// Fast is the lifecycle that pods are ready immediately after they are scheduled,
+	// and completed immediately if they are owned by a Job.
+	Fast = "fast"
+	// Realistic is the lifecycle that pods go through the init containers and the containers
+	// with delays of a few seconds, close to the behavior of a real kubelet.
+	Realistic = "realistic"
+	// Chaos is the Realistic lifecycle that also fails about one in sixteen pods,
+	// the failure can be forced or prevented per pod by the labels of the chaos stages.
+	Chaos = "chaos"
+	// None is the lifecycle without any stages, nodes and pods are left as they are.
+	None = "none"
+)
+
+// Annotation is the annotation of namespaces to select the bundled lifecycle of the pods in them.
+const Annotation = "kwok.x-k8s.io/lifecycle"
+
+// Names is the names of the bundled lifecycles.
+var Names = []string{Fast, Realistic, Chaos, None}
+
+// NodeStages returns the stages of nodes, which initialize the nodes and keep them heartbeating.
+func NodeStages(lease bool) ([]*internalversion.Stage, error) {
+	rawHeartbeat := nodeheartbeat.DefaultNodeHeartbeat
+	if lease {
+		rawHeartbeat = nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease
+	}
+	return unmarshal(nodefast.DefaultNodeInit, rawHeartbeat)
+}
+
+// NodeCloudProviderStages returns the stages of nodes, which taint the nodes as uninitialized
+// until they are initialized by an external cloud-controller-manager.
+func NodeCloudProviderStages() ([]*internalversion.Stage, error) {
+	return unmarshal(nodecloudprovider.DefaultNodeCloudProviderUninitialized)
+}
+
+// PodStages returns the stages of pods of the lifecycle.
+func PodStages(name string) ([]*internalversion.Stage, error) {
+	switch name {
+	case Fast:
+		return unmarshal(
+			podfast.DefaultPodReady,
+			podfast.DefaultPodComplete,
+			podfast.DefaultPodDelete,
+		)
+	case Realistic:
+		return unmarshal(
+			podgeneral.DefaultPodCreate,
+			podgeneral.DefaultPodInitContainerRunning,
+			podgeneral.DefaultPodInitContainerCompleted,
+			podgeneral.DefaultPodReady,
+			podgeneral.DefaultPodComplete,
+			podgeneral.DefaultPodRemoveFinalizer,
+			podgeneral.DefaultPodDelete,
+		)
+	case Chaos:
+		stages, err := PodStages(Realistic)
+		if err != nil {
+			return nil, err
+		}
+		chaosStages, err := unmarshal(
+			podchaos.DefaultPodInitContainerRunningFailed,
+			podchaos.DefaultPodContainerRunningFailed,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, stage := range chaosStages {
+			sampleByUID(stage)
+		}
+		return append(stages, chaosStages...), nil
+	case None:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown lifecycle %q, must be one of %v", name, Names)
+}
+
+// ServiceStages returns the stages of services, which assign load balancers to the services of type LoadBalancer.
+func ServiceStages() ([]*internalversion.Stage, error) {
+	return unmarshal(
+		serviceloadbalancer.DefaultServiceLoadBalancerReady,
+		serviceloadbalancer.DefaultServiceLoadBalancerQuotaExceeded,
+	)
+}
+
+// KubeProxyStages returns the stages of services and endpoint slices, which report the proxy rules of them as synced like kube-proxy.
+func KubeProxyStages() ([]*internalversion.Stage, error) {
+	return unmarshal(
+		servicekubeproxy.DefaultServiceProxySynced,
+		servicekubeproxy.DefaultEndpointSliceProxySynced,
+	)
+}
+
+// IngressStages returns the stages of ingresses, which publish the address of the ingresses.
+func IngressStages() ([]*internalversion.Stage, error) {
+	return unmarshal(ingressgeneral.DefaultIngressReady)
+}
+
+// GatewayStages returns the stages of the Gateway API, which accept and program the gateway classes, gateways and HTTP routes.
+func GatewayStages() ([]*internalversion.Stage, error) {
+	return unmarshal(
+		gatewaygeneral.DefaultGatewayClassAccepted,
+		gatewaygeneral.DefaultGatewayProgrammed,
+		gatewaygeneral.DefaultHTTPRouteAccepted,
+	)
+}
+
+// CSRStages returns the stages of certificate signing requests, which approve and sign the requests.
+func CSRStages() ([]*internalversion.Stage, error) {
+	return unmarshal(
+		csrgeneral.DefaultCSRApproved,
+		csrgeneral.DefaultCSRDenied,
+		csrgeneral.DefaultCSRIssued,
+		csrgeneral.DefaultCSRSigningFailed,
+	)
+}
+
+// sampleByUID makes the stage, which is opted in by a label, match a sample of objects by default.
+// The label still takes precedence, so that the stage can be forced with "true" or prevented with "false".
+func sampleByUID(stage *internalversion.Stage) {
+	if stage.Spec.Selector == nil {
+		return
+	}
+	label := fmt.Sprintf(".metadata.labels[%q]", stage.Name+".stage.kwok.x-k8s.io")
+	for i, expr := range stage.Spec.Selector.MatchExpressions {
+		if expr.Key == label {
+			stage.Spec.Selector.MatchExpressions[i].Key = label + ` // (if .metadata.uid[-1:] == "0" then "true" else "false" end)`
+		}
+	}
+}
+
+func unmarshal(raws ...string) ([]*internalversion.Stage, error) {
+	return slices.MapWithError(raws, config.UnmarshalWithType[*internalversion.Stage, string])
+}
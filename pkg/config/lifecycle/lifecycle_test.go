@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestPodStages(t *testing.T) {
+	for _, name := range Names {
+		stages, err := PodStages(name)
+		if err != nil {
+			t.Fatalf("PodStages(%q) error = %v", name, err)
+		}
+		if name == None {
+			if len(stages) != 0 {
+				t.Errorf("PodStages(%q) = %d stages, want none", name, len(stages))
+			}
+			continue
+		}
+		if len(stages) == 0 {
+			t.Errorf("PodStages(%q) = no stages", name)
+		}
+		for _, stage := range stages {
+			if stage.Spec.ResourceRef.Kind != "Pod" {
+				t.Errorf("PodStages(%q) contains stage %q for %s", name, stage.Name, stage.Spec.ResourceRef.Kind)
+			}
+		}
+	}
+
+	_, err := PodStages("unknown")
+	if err == nil {
+		t.Errorf("PodStages(unknown) error = nil, want error")
+	}
+}
+
+func TestSampleByUID(t *testing.T) {
+	stages, err := PodStages(Chaos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stage := stages[len(stages)-1]
+	key := stage.Spec.Selector.MatchExpressions[0]
+	req, err := expression.NewRequirement(key.Key, key.Operator, key.Values)
+	if err != nil {
+		t.Fatalf("NewRequirement(%q) error = %v", key.Key, err)
+	}
+
+	tests := []struct {
+		name string
+		pod  map[string]any
+		want bool
+	}{
+		{
+			name: "sampled",
+			pod:  map[string]any{"metadata": map[string]any{"uid": "abc0"}},
+			want: true,
+		},
+		{
+			name: "not sampled",
+			pod:  map[string]any{"metadata": map[string]any{"uid": "abc1"}},
+			want: false,
+		},
+		{
+			name: "forced by label",
+			pod: map[string]any{"metadata": map[string]any{"uid": "abc1", "labels": map[string]any{
+				stage.Name + ".stage.kwok.x-k8s.io": "true",
+			}}},
+			want: true,
+		},
+		{
+			name: "prevented by label",
+			pod: map[string]any{"metadata": map[string]any{"uid": "abc0", "labels": map[string]any{
+				stage.Name + ".stage.kwok.x-k8s.io": "false",
+			}}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := req.Matches(context.Background(), tt.pod)
+			if err != nil {
+				t.Fatalf("Matches() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceStages(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages func() ([]*internalversion.Stage, error)
+		kinds  []string
+	}{
+		{name: "node-cloud-provider", stages: NodeCloudProviderStages, kinds: []string{"Node"}},
+		{name: "service", stages: ServiceStages, kinds: []string{"Service"}},
+		{name: "kube-proxy", stages: KubeProxyStages, kinds: []string{"Service", "EndpointSlice"}},
+		{name: "ingress", stages: IngressStages, kinds: []string{"Ingress"}},
+		{name: "gateway", stages: GatewayStages, kinds: []string{"GatewayClass", "Gateway", "HTTPRoute"}},
+		{name: "csr", stages: CSRStages, kinds: []string{"CertificateSigningRequest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := tt.stages()
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(stages) == 0 {
+				t.Errorf("no stages")
+			}
+			for _, stage := range stages {
+				if !slices.Contains(tt.kinds, stage.Spec.ResourceRef.Kind) {
+					t.Errorf("contains stage %q for %s", stage.Name, stage.Spec.ResourceRef.Kind)
+				}
+			}
+		})
+	}
+}
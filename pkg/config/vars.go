@@ -44,6 +44,9 @@ var (
 	// ClustersDir is the directory of the clusters.
 	ClustersDir = path.Join(WorkDir, "clusters")
 
+	// TemplatesDir is the directory of the etcd data templates of clusters.
+	TemplatesDir = path.Join(WorkDir, "templates")
+
 	// GOOS is the operating system target for which the code is compiled.
 	GOOS = runtime.GOOS
 
@@ -202,6 +205,11 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 			conf.Runtimes = append(conf.Runtimes,
 				consts.RuntimeTypeNerdctl,
 			)
+		} else {
+			// Finch is the alternative of Docker Desktop on macOS and Windows
+			conf.Runtimes = append(conf.Runtimes,
+				consts.RuntimeTypeFinch,
+			)
 		}
 		conf.Runtimes = append(conf.Runtimes,
 			consts.RuntimeTypeBinary,
@@ -237,6 +245,10 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 
 	setKwokctlEtcdConfig(conf)
 
+	setKwokctlKineConfig(conf)
+
+	setKwokctlIPFamilyConfig(conf)
+
 	setKwokctlKindConfig(conf)
 
 	setKwokctlDashboardConfig(conf)
@@ -247,6 +259,18 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 
 	setMetricsServerConfig(conf)
 
+	setCoreDNSConfig(conf)
+
+	setCloudControllerManagerConfig(conf)
+
+	setKubeStateMetricsConfig(conf)
+
+	setGrafanaConfig(conf)
+
+	setOtelCollectorConfig(conf)
+
+	setKonnectivityConfig(conf)
+
 	return config
 }
 
@@ -259,6 +283,22 @@ func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 
 	conf.KubeApiserverPort = envs.GetEnvWithPrefix("KUBE_APISERVER_PORT", conf.KubeApiserverPort)
 	conf.KubeApiserverInsecurePort = envs.GetEnvWithPrefix("KUBE_APISERVER_INSECURE_PORT", conf.KubeApiserverInsecurePort)
+	conf.KubeApiserverFaultProxyPort = envs.GetEnvWithPrefix("KUBE_APISERVER_FAULT_PROXY_PORT", conf.KubeApiserverFaultProxyPort)
+
+	if conf.KubeApiserverReplicas == 0 {
+		conf.KubeApiserverReplicas = 1
+	}
+	conf.KubeApiserverReplicas = envs.GetEnvWithPrefix("KUBE_APISERVER_REPLICAS", conf.KubeApiserverReplicas)
+
+	if conf.HaproxyVersion == "" {
+		conf.HaproxyVersion = consts.HaproxyVersion
+	}
+	conf.HaproxyVersion = version.TrimPrefixV(envs.GetEnvWithPrefix("HAPROXY_VERSION", conf.HaproxyVersion))
+
+	if conf.HaproxyImage == "" {
+		conf.HaproxyImage = joinImageURI(consts.HaproxyImagePrefix, "haproxy", conf.HaproxyVersion)
+	}
+	conf.HaproxyImage = envs.GetEnvWithPrefix("HAPROXY_IMAGE", conf.HaproxyImage)
 
 	if conf.KubeFeatureGates == "" {
 		if conf.Mode == configv1alpha1.ModeStableFeatureGateAndAPI {
@@ -274,8 +314,14 @@ func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 	}
 	conf.KubeRuntimeConfig = envs.GetEnvWithPrefix("KUBE_RUNTIME_CONFIG", conf.KubeRuntimeConfig)
 
+	conf.KubeEmulateRemovals = envs.GetEnvWithPrefix("KUBE_EMULATE_REMOVALS", conf.KubeEmulateRemovals)
+
 	conf.KubeAuditPolicy = envs.GetEnvWithPrefix("KUBE_AUDIT_POLICY", conf.KubeAuditPolicy)
 
+	conf.KubeAuditWebhook = envs.GetEnvWithPrefix("KUBE_AUDIT_WEBHOOK", conf.KubeAuditWebhook)
+
+	conf.KubeAuditSinkPort = envs.GetEnvWithPrefix("KUBE_AUDIT_SINK_PORT", conf.KubeAuditSinkPort)
+
 	kubectlBinaryPrefix := conf.KubeBinaryPrefix
 	if conf.KubeBinaryPrefix == "" {
 		// https://www.downloadkubernetes.com/
@@ -369,6 +415,11 @@ func setKwokctlEtcdConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	}
 	conf.EtcdVersion = version.TrimPrefixV(envs.GetEnvWithPrefix("ETCD_VERSION", conf.EtcdVersion))
 
+	if conf.EtcdReplicas == 0 {
+		conf.EtcdReplicas = 1
+	}
+	conf.EtcdReplicas = envs.GetEnvWithPrefix("ETCD_REPLICAS", conf.EtcdReplicas)
+
 	if conf.EtcdBinaryPrefix == "" {
 		conf.EtcdBinaryPrefix = consts.EtcdBinaryPrefix + "/v" + strings.TrimSuffix(conf.EtcdVersion, "-0")
 	}
@@ -407,6 +458,38 @@ func setKwokctlEtcdConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	}
 }
 
+func setKwokctlKineConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	if conf.EtcdBackend == "" {
+		conf.EtcdBackend = consts.EtcdBackendEtcd
+	}
+	conf.EtcdBackend = envs.GetEnvWithPrefix("ETCD_BACKEND", conf.EtcdBackend)
+
+	conf.KineEndpoint = envs.GetEnvWithPrefix("KINE_ENDPOINT", conf.KineEndpoint)
+
+	if conf.KineVersion == "" {
+		conf.KineVersion = consts.KineVersion
+	}
+	conf.KineVersion = version.AddPrefixV(envs.GetEnvWithPrefix("KINE_VERSION", conf.KineVersion))
+
+	if conf.KineImage == "" {
+		conf.KineImage = joinImageURI(consts.KineImagePrefix, "kine", conf.KineVersion)
+	}
+	conf.KineImage = envs.GetEnvWithPrefix("KINE_IMAGE", conf.KineImage)
+
+	// The releases of kine only have the binaries of linux
+	if conf.KineBinary == "" && GOOS == "linux" {
+		conf.KineBinary = consts.KineBinaryPrefix + "/" + conf.KineVersion + "/kine-" + GOARCH
+	}
+	conf.KineBinary = envs.GetEnvWithPrefix("KINE_BINARY", conf.KineBinary)
+}
+
+func setKwokctlIPFamilyConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	if conf.IPFamily == "" {
+		conf.IPFamily = consts.IPFamilyIPv4
+	}
+	conf.IPFamily = envs.GetEnvWithPrefix("IP_FAMILY", conf.IPFamily)
+}
+
 func setKwokctlKindConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	if conf.KindNodeImagePrefix == "" {
 		conf.KindNodeImagePrefix = consts.KindNodeImagePrefix
@@ -593,3 +676,136 @@ func parseRelease(ver string) int {
 	}
 	return int(v.Minor)
 }
+
+func setCoreDNSConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	if conf.CoreDNSVersion == "" {
+		conf.CoreDNSVersion = consts.CoreDNSVersion
+	}
+	conf.CoreDNSVersion = version.AddPrefixV(envs.GetEnvWithPrefix("COREDNS_VERSION", conf.CoreDNSVersion))
+
+	if conf.CoreDNSImagePrefix == "" {
+		conf.CoreDNSImagePrefix = consts.CoreDNSImagePrefix
+	}
+	conf.CoreDNSImagePrefix = envs.GetEnvWithPrefix("COREDNS_IMAGE_PREFIX", conf.CoreDNSImagePrefix)
+
+	if conf.CoreDNSImage == "" {
+		conf.CoreDNSImage = joinImageURI(conf.CoreDNSImagePrefix, "coredns", conf.CoreDNSVersion)
+	}
+	conf.CoreDNSImage = envs.GetEnvWithPrefix("COREDNS_IMAGE", conf.CoreDNSImage)
+
+	if conf.CoreDNSBinaryPrefix == "" {
+		conf.CoreDNSBinaryPrefix = consts.CoreDNSBinaryPrefix + "/" + conf.CoreDNSVersion
+	}
+	conf.CoreDNSBinaryPrefix = envs.GetEnvWithPrefix("COREDNS_BINARY_PREFIX", conf.CoreDNSBinaryPrefix)
+
+	// The releases of CoreDNS are tgz for all the platforms
+	if conf.CoreDNSBinary == "" {
+		conf.CoreDNSBinary = conf.CoreDNSBinaryPrefix + "/coredns_" + strings.TrimPrefix(conf.CoreDNSVersion, "v") + "_" + GOOS + "_" + GOARCH + ".tgz#coredns" + conf.BinSuffix
+	}
+	conf.CoreDNSBinary = envs.GetEnvWithPrefix("COREDNS_BINARY", conf.CoreDNSBinary)
+
+	conf.CoreDNSPort = envs.GetEnvWithPrefix("COREDNS_PORT", conf.CoreDNSPort)
+}
+
+// setCloudControllerManagerConfig sets the external cloud-controller-manager,
+// which has no default as it is provided by the cloud provider.
+func setCloudControllerManagerConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	conf.CloudProvider = envs.GetEnvWithPrefix("CLOUD_PROVIDER", conf.CloudProvider)
+	conf.CloudControllerManagerImage = envs.GetEnvWithPrefix("CLOUD_CONTROLLER_MANAGER_IMAGE", conf.CloudControllerManagerImage)
+	conf.CloudControllerManagerBinary = envs.GetEnvWithPrefix("CLOUD_CONTROLLER_MANAGER_BINARY", conf.CloudControllerManagerBinary)
+	conf.CloudControllerManagerPort = envs.GetEnvWithPrefix("CLOUD_CONTROLLER_MANAGER_PORT", conf.CloudControllerManagerPort)
+}
+
+func setKubeStateMetricsConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	if conf.KubeStateMetricsVersion == "" {
+		conf.KubeStateMetricsVersion = consts.KubeStateMetricsVersion
+	}
+	conf.KubeStateMetricsVersion = version.AddPrefixV(envs.GetEnvWithPrefix("KUBE_STATE_METRICS_VERSION", conf.KubeStateMetricsVersion))
+
+	if conf.KubeStateMetricsImagePrefix == "" {
+		conf.KubeStateMetricsImagePrefix = consts.KubeStateMetricsImagePrefix
+	}
+	conf.KubeStateMetricsImagePrefix = envs.GetEnvWithPrefix("KUBE_STATE_METRICS_IMAGE_PREFIX", conf.KubeStateMetricsImagePrefix)
+
+	if conf.KubeStateMetricsImage == "" {
+		conf.KubeStateMetricsImage = joinImageURI(conf.KubeStateMetricsImagePrefix, "kube-state-metrics", conf.KubeStateMetricsVersion)
+	}
+	conf.KubeStateMetricsImage = envs.GetEnvWithPrefix("KUBE_STATE_METRICS_IMAGE", conf.KubeStateMetricsImage)
+
+	// There are no released binaries of kube-state-metrics, so it has to be given for the binary runtime
+	conf.KubeStateMetricsBinary = envs.GetEnvWithPrefix("KUBE_STATE_METRICS_BINARY", conf.KubeStateMetricsBinary)
+
+	conf.KubeStateMetricsPort = envs.GetEnvWithPrefix("KUBE_STATE_METRICS_PORT", conf.KubeStateMetricsPort)
+}
+
+func setGrafanaConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	conf.GrafanaPort = envs.GetEnvWithPrefix("GRAFANA_PORT", conf.GrafanaPort)
+
+	if conf.GrafanaVersion == "" {
+		conf.GrafanaVersion = consts.GrafanaVersion
+	}
+	conf.GrafanaVersion = version.TrimPrefixV(envs.GetEnvWithPrefix("GRAFANA_VERSION", conf.GrafanaVersion))
+
+	if conf.GrafanaImagePrefix == "" {
+		conf.GrafanaImagePrefix = consts.GrafanaImagePrefix
+	}
+	conf.GrafanaImagePrefix = envs.GetEnvWithPrefix("GRAFANA_IMAGE_PREFIX", conf.GrafanaImagePrefix)
+
+	if conf.GrafanaImage == "" {
+		conf.GrafanaImage = joinImageURI(conf.GrafanaImagePrefix, "grafana", conf.GrafanaVersion)
+	}
+	conf.GrafanaImage = envs.GetEnvWithPrefix("GRAFANA_IMAGE", conf.GrafanaImage)
+}
+
+func setOtelCollectorConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	conf.OtlpEndpoint = envs.GetEnvWithPrefix("OTLP_ENDPOINT", conf.OtlpEndpoint)
+
+	conf.OtelCollectorPort = envs.GetEnvWithPrefix("OTEL_COLLECTOR_PORT", conf.OtelCollectorPort)
+
+	if conf.OtelCollectorVersion == "" {
+		conf.OtelCollectorVersion = consts.OtelCollectorVersion
+	}
+	conf.OtelCollectorVersion = version.AddPrefixV(envs.GetEnvWithPrefix("OTEL_COLLECTOR_VERSION", conf.OtelCollectorVersion))
+
+	if conf.OtelCollectorImagePrefix == "" {
+		conf.OtelCollectorImagePrefix = consts.OtelCollectorImagePrefix
+	}
+	conf.OtelCollectorImagePrefix = envs.GetEnvWithPrefix("OTEL_COLLECTOR_IMAGE_PREFIX", conf.OtelCollectorImagePrefix)
+
+	if conf.OtelCollectorImage == "" {
+		conf.OtelCollectorImage = joinImageURI(conf.OtelCollectorImagePrefix, "opentelemetry-collector", strings.TrimPrefix(conf.OtelCollectorVersion, "v"))
+	}
+	conf.OtelCollectorImage = envs.GetEnvWithPrefix("OTEL_COLLECTOR_IMAGE", conf.OtelCollectorImage)
+
+	if conf.OtelCollectorBinaryPrefix == "" {
+		conf.OtelCollectorBinaryPrefix = consts.OtelCollectorBinaryPrefix + "/" + conf.OtelCollectorVersion
+	}
+	conf.OtelCollectorBinaryPrefix = envs.GetEnvWithPrefix("OTEL_COLLECTOR_BINARY_PREFIX", conf.OtelCollectorBinaryPrefix)
+
+	if conf.OtelCollectorBinary == "" {
+		conf.OtelCollectorBinary = conf.OtelCollectorBinaryPrefix + "/otelcol_" + strings.TrimPrefix(conf.OtelCollectorVersion, "v") + "_" + GOOS + "_" + GOARCH + ".tar.gz#otelcol" + conf.BinSuffix
+	}
+	conf.OtelCollectorBinary = envs.GetEnvWithPrefix("OTEL_COLLECTOR_BINARY", conf.OtelCollectorBinary)
+}
+
+func setKonnectivityConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	if conf.KonnectivityVersion == "" {
+		conf.KonnectivityVersion = consts.KonnectivityVersion
+	}
+	conf.KonnectivityVersion = version.AddPrefixV(envs.GetEnvWithPrefix("KONNECTIVITY_VERSION", conf.KonnectivityVersion))
+
+	if conf.KonnectivityImagePrefix == "" {
+		conf.KonnectivityImagePrefix = consts.KonnectivityImagePrefix
+	}
+	conf.KonnectivityImagePrefix = envs.GetEnvWithPrefix("KONNECTIVITY_IMAGE_PREFIX", conf.KonnectivityImagePrefix)
+
+	if conf.KonnectivityServerImage == "" {
+		conf.KonnectivityServerImage = joinImageURI(conf.KonnectivityImagePrefix, "proxy-server", conf.KonnectivityVersion)
+	}
+	conf.KonnectivityServerImage = envs.GetEnvWithPrefix("KONNECTIVITY_SERVER_IMAGE", conf.KonnectivityServerImage)
+
+	if conf.KonnectivityAgentImage == "" {
+		conf.KonnectivityAgentImage = joinImageURI(conf.KonnectivityImagePrefix, "proxy-agent", conf.KonnectivityVersion)
+	}
+	conf.KonnectivityAgentImage = envs.GetEnvWithPrefix("KONNECTIVITY_AGENT_IMAGE", conf.KonnectivityAgentImage)
+}
@@ -63,6 +63,30 @@ var (
 	MetricsServerBinaryPrefix = "https://github.com/kubernetes-sigs/metrics-server/releases/download"
 	MetricsServerImagePrefix  = "registry.k8s.io/metrics-server"
 
+	KineVersion      = "0.13.2"
+	KineBinaryPrefix = "https://github.com/k3s-io/kine/releases/download"
+	KineImagePrefix  = "docker.io/rancher"
+
+	HaproxyVersion     = "3.0.2"
+	HaproxyImagePrefix = "docker.io/library"
+
+	CoreDNSVersion      = "1.11.1"
+	CoreDNSBinaryPrefix = "https://github.com/coredns/coredns/releases/download"
+	CoreDNSImagePrefix  = "registry.k8s.io/coredns"
+
+	KubeStateMetricsVersion     = "2.12.0"
+	KubeStateMetricsImagePrefix = "registry.k8s.io/kube-state-metrics"
+
+	GrafanaVersion     = "11.1.0"
+	GrafanaImagePrefix = "docker.io/grafana"
+
+	OtelCollectorVersion      = "0.104.0"
+	OtelCollectorBinaryPrefix = "https://github.com/open-telemetry/opentelemetry-collector-releases/releases/download"
+	OtelCollectorImagePrefix  = "docker.io/otel"
+
+	KonnectivityVersion     = "0.30.3"
+	KonnectivityImagePrefix = "registry.k8s.io/kas-network-proxy"
+
 	DefaultUnlimitedQPS   = 5000.0
 	DefaultUnlimitedBurst = 10000
 )
@@ -71,6 +95,8 @@ var (
 const (
 	// RuntimeTypeBinary is the binary runtime.
 	RuntimeTypeBinary = "binary"
+	// RuntimeTypeCrio is the CRI-O runtime, will create a pod sandbox with host network for each component.
+	RuntimeTypeCrio = "crio"
 
 	// Container runtime type, will create a container for each component.
 
@@ -97,6 +123,40 @@ const (
 	RuntimeTypeKindLima = RuntimeTypeKind + "-" + RuntimeTypeLima
 	// RuntimeTypeKindFinch is the kind runtime with finch.
 	RuntimeTypeKindFinch = RuntimeTypeKind + "-" + RuntimeTypeFinch
+
+	// RuntimeTypeKubernetes is the kubernetes runtime, deploys the components into an existing cluster.
+	RuntimeTypeKubernetes = "kubernetes"
+
+	// RuntimeTypeAttach is the attach runtime, runs only the kwok-controller for an existing cluster.
+	RuntimeTypeAttach = "attach"
+)
+
+// The following init system is provided for the binary runtime.
+const (
+	// InitSystemSystemd manages the components as systemd units.
+	InitSystemSystemd = "systemd"
+)
+
+// The following etcd backend is provided.
+const (
+	// EtcdBackendEtcd is the etcd backend.
+	EtcdBackendEtcd = "etcd"
+	// EtcdBackendKineSqlite is the kine backend with a sqlite database in the workdir.
+	EtcdBackendKineSqlite = "kine-sqlite"
+	// EtcdBackendKineMysql is the kine backend with an external MySQL database.
+	EtcdBackendKineMysql = "kine-mysql"
+	// EtcdBackendKinePostgres is the kine backend with an external Postgres database.
+	EtcdBackendKinePostgres = "kine-postgres"
+)
+
+// The following IP family is provided.
+const (
+	// IPFamilyIPv4 is the IPv4-only cluster.
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 is the IPv6-only cluster.
+	IPFamilyIPv6 = "ipv6"
+	// IPFamilyDual is the dual-stack cluster with IPv4 as the primary family.
+	IPFamilyDual = "dual"
 )
 
 // The following components is provided.
@@ -104,7 +164,10 @@ const (
 	ComponentEtcd                       = "etcd"
 	ComponentKubeApiserver              = "kube-apiserver"
 	ComponentKubeApiserverInsecureProxy = "kube-apiserver-insecure-proxy"
+	ComponentKubeApiserverLoadBalancer  = "kube-apiserver-lb"
+	ComponentKubeApiserverFaultProxy    = "kube-apiserver-fault-proxy"
 	ComponentKubeControllerManager      = "kube-controller-manager"
+	ComponentCloudControllerManager     = "cloud-controller-manager"
 	ComponentKubeScheduler              = "kube-scheduler"
 	ComponentKwokController             = "kwok-controller"
 	ComponentDashboard                  = "dashboard"
@@ -112,4 +175,19 @@ const (
 	ComponentPrometheus                 = "prometheus"
 	ComponentJaeger                     = "jaeger"
 	ComponentMetricsServer              = "metrics-server"
+	ComponentCoreDNS                    = "coredns"
+	ComponentKubeStateMetrics           = "kube-state-metrics"
+	ComponentGrafana                    = "grafana"
+	ComponentOtelCollector              = "otel-collector"
+	ComponentKubeAuditSink              = "kube-audit-sink"
+	ComponentKonnectivityServer         = "konnectivity-server"
+	ComponentKonnectivityAgent          = "konnectivity-agent"
 )
+
+// ShardLabel is the label of the nodes to specify the shard of the kwok-controller which manages them,
+// the kwok-controllers of the shards run on the workers of kind.
+const ShardLabel = "kwok.x-k8s.io/shard"
+
+// NodeTypeLabel is the label of the real worker nodes of kind to tell them from the fake nodes,
+// which are annotated with kwok.x-k8s.io/node=fake and managed by the kwok-controllers.
+const NodeTypeLabel = "kwok.x-k8s.io/node"
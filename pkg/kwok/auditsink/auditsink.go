@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditsink provides a backend of the audit webhook of kube-apiserver,
+// which stores the received audit events as JSON lines, one event per line.
+package auditsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// Handler receives the batches of audit events sent by kube-apiserver and writes them as JSON lines.
+type Handler struct {
+	mut sync.Mutex
+	w   io.Writer
+}
+
+// NewHandler returns a new Handler writing to w.
+func NewHandler(w io.Writer) *Handler {
+	return &Handler{
+		w: w,
+	}
+}
+
+// eventList is the audit.k8s.io EventList, the events are kept as they are sent.
+type eventList struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var list eventList
+	err := json.NewDecoder(r.Body).Decode(&list)
+	if err != nil {
+		http.Error(rw, "invalid event list: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for _, item := range list.Items {
+		err = json.Compact(buf, item)
+		if err != nil {
+			http.Error(rw, "invalid event: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		buf.WriteByte('\n')
+	}
+
+	h.mut.Lock()
+	_, err = h.w.Write(buf.Bytes())
+	h.mut.Unlock()
+	if err != nil {
+		logger := log.FromContext(r.Context())
+		logger.Error("Failed to write audit events", err)
+		http.Error(rw, "failed to write audit events", http.StatusInternalServerError)
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+}
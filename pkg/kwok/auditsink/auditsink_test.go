@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditsink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:   "events",
+			method: http.MethodPost,
+			body: `{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[
+				{"kind":"Event","auditID":"1","verb":"get"},
+				{"kind":"Event","auditID":"2","verb":"list"}
+			]}`,
+			wantStatus: http.StatusOK,
+			want: `{"kind":"Event","auditID":"1","verb":"get"}
+{"kind":"Event","auditID":"2","verb":"list"}
+`,
+		},
+		{
+			name:       "invalid",
+			method:     http.MethodPost,
+			body:       `{"items":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			h := NewHandler(buf)
+
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body)))
+			if rw.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rw.Code, tt.wantStatus)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("written = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
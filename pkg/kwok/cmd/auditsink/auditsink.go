@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auditsink contains a command to serve a backend of the audit webhook of kube-apiserver.
+package auditsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwok/auditsink"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Address string
+	Output  string
+}
+
+// NewCommand returns a new cobra.Command for the audit sink
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "audit-sink",
+		Short: "Serve a backend of the audit webhook of kube-apiserver which stores the events as JSON lines",
+		Long: `Serve a backend of the audit webhook of kube-apiserver over plain HTTP,
+the received audit events are appended to the output file, one event per line.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Address, "address", ":8080", "Address to serve the audit sink on")
+	cmd.Flags().StringVar(&flags.Output, "output", flags.Output, "Path to the file which the audit events are appended to")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	logger := log.FromContext(ctx)
+
+	if flags.Output == "" {
+		return fmt.Errorf("--output is required")
+	}
+	output, err := path.Expand(flags.Output)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(output), 0750)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	listener, err := net.Listen("tcp", flags.Address)
+	if err != nil {
+		return err
+	}
+
+	svc := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+		Handler: auditsink.NewHandler(f),
+	}
+	go func() {
+		<-ctx.Done()
+		_ = svc.Close()
+	}()
+
+	logger.Info("Serving the audit sink",
+		"address", listener.Addr().String(),
+		"output", output,
+	)
+	err = svc.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the audit sink: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// setupClients sets the clients of the cluster to the config of the controller, once the cluster is ready.
+func setupClients(ctx context.Context, conf *controllers.Config, clientset client.Clientset) error {
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return err
+	}
+
+	restMapper, err := clientset.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	restClient, err := rest.RESTClientFor(restConfig)
+	if err != nil {
+		return err
+	}
+
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	typedKwokClient, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	err = waitForReady(ctx, typedClient)
+	if err != nil {
+		return err
+	}
+
+	conf.DynamicClient = dynamicClient
+	conf.RESTClient = restClient
+	conf.RESTMapper = restMapper
+	conf.ImpersonatingDynamicClient = clientset.ToImpersonatingDynamicClient()
+	conf.TypedClient = typedClient
+	conf.TypedKwokClient = typedKwokClient
+	return nil
+}
+
+// startClusters starts a controller for each of the clusters besides the one of --kubeconfig,
+// with the same config as the controller of it except the clients and the selectors of the nodes.
+func startClusters(ctx context.Context, clusters []internalversion.KwokConfigurationCluster, conf controllers.Config) error {
+	logger := log.FromContext(ctx)
+
+	names := map[string]struct{}{}
+	for _, cluster := range clusters {
+		if cluster.Name == "" {
+			return fmt.Errorf("the name of the cluster is required")
+		}
+		if _, ok := names[cluster.Name]; ok {
+			return fmt.Errorf("duplicate cluster %q", cluster.Name)
+		}
+		names[cluster.Name] = struct{}{}
+		if cluster.Kubeconfig == "" {
+			return fmt.Errorf("the kubeconfig of cluster %q is required", cluster.Name)
+		}
+	}
+
+	for _, cluster := range clusters {
+		kubeconfigPath, err := path.Expand(cluster.Kubeconfig)
+		if err != nil {
+			return err
+		}
+
+		clusterLogger := logger.With("cluster", cluster.Name)
+		clusterCtx := log.NewContext(ctx, clusterLogger)
+
+		clientset, err := client.NewClientset("", kubeconfigPath, client.WithContext(cluster.Context))
+		if err != nil {
+			return err
+		}
+
+		clusterConf := clusterConfig(conf, cluster)
+		err = setupClients(clusterCtx, &clusterConf, clientset)
+		if err != nil {
+			return fmt.Errorf("failed to connect to cluster %q: %w", cluster.Name, err)
+		}
+
+		ctr, err := controllers.NewController(clusterConf)
+		if err != nil {
+			return fmt.Errorf("failed to create the controller of cluster %q: %w", cluster.Name, err)
+		}
+
+		err = ctr.Start(clusterCtx)
+		if err != nil {
+			return fmt.Errorf("failed to start the controller of cluster %q: %w", cluster.Name, err)
+		}
+		clusterLogger.Info("Started the controller of the cluster",
+			"kubeconfig", kubeconfigPath,
+			"context", cluster.Context,
+		)
+	}
+	return nil
+}
+
+// clusterConfig returns the config of the controller of the cluster,
+// the selectors of the nodes of the cluster override the ones of conf if any of them is set.
+func clusterConfig(conf controllers.Config, cluster internalversion.KwokConfigurationCluster) controllers.Config {
+	if cluster.ManageSingleNode != "" ||
+		cluster.ManageNodesWithAnnotationSelector != "" ||
+		cluster.ManageNodesWithLabelSelector != "" {
+		conf.ManageAllNodes = false
+		conf.ManageSingleNode = cluster.ManageSingleNode
+		conf.ManageNodesWithAnnotationSelector = cluster.ManageNodesWithAnnotationSelector
+		conf.ManageNodesWithLabelSelector = cluster.ManageNodesWithLabelSelector
+	}
+	return conf
+}
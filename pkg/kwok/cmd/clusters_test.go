@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+)
+
+func TestStartClustersValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []internalversion.KwokConfigurationCluster
+		wantErr  string
+	}{
+		{
+			name: "missing name",
+			clusters: []internalversion.KwokConfigurationCluster{
+				{Kubeconfig: "/tmp/kubeconfig"},
+			},
+			wantErr: "the name of the cluster is required",
+		},
+		{
+			name: "duplicate name",
+			clusters: []internalversion.KwokConfigurationCluster{
+				{Name: "dev", Kubeconfig: "/tmp/dev"},
+				{Name: "dev", Kubeconfig: "/tmp/staging"},
+			},
+			wantErr: `duplicate cluster "dev"`,
+		},
+		{
+			name: "missing kubeconfig",
+			clusters: []internalversion.KwokConfigurationCluster{
+				{Name: "dev", Kubeconfig: "/tmp/dev"},
+				{Name: "staging"},
+			},
+			wantErr: `the kubeconfig of cluster "staging" is required`,
+		},
+		{
+			name: "no clusters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := startClusters(context.Background(), tt.clusters, controllers.Config{})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("startClusters() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("startClusters() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClusterConfig(t *testing.T) {
+	conf := controllers.Config{
+		ManageAllNodes:               true,
+		ManageNodesWithLabelSelector: "type=default",
+		NodeIP:                       "10.0.0.1",
+	}
+
+	tests := []struct {
+		name    string
+		cluster internalversion.KwokConfigurationCluster
+		want    controllers.Config
+	}{
+		{
+			name:    "inherit the selectors",
+			cluster: internalversion.KwokConfigurationCluster{Name: "dev"},
+			want:    conf,
+		},
+		{
+			name: "override the selectors",
+			cluster: internalversion.KwokConfigurationCluster{
+				Name:                              "dev",
+				ManageNodesWithAnnotationSelector: "kwok.x-k8s.io/node=fake",
+			},
+			want: controllers.Config{
+				ManageNodesWithAnnotationSelector: "kwok.x-k8s.io/node=fake",
+				NodeIP:                            "10.0.0.1",
+			},
+		},
+		{
+			name: "override with a single node",
+			cluster: internalversion.KwokConfigurationCluster{
+				Name:             "dev",
+				ManageSingleNode: "node-0",
+			},
+			want: controllers.Config{
+				ManageSingleNode: "node-0",
+				NodeIP:           "10.0.0.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clusterConfig(conf, tt.cluster)
+			if got.ManageAllNodes != tt.want.ManageAllNodes ||
+				got.ManageSingleNode != tt.want.ManageSingleNode ||
+				got.ManageNodesWithAnnotationSelector != tt.want.ManageNodesWithAnnotationSelector ||
+				got.ManageNodesWithLabelSelector != tt.want.ManageNodesWithLabelSelector ||
+				got.NodeIP != tt.want.NodeIP {
+				t.Errorf("clusterConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if !conf.ManageAllNodes || conf.ManageNodesWithLabelSelector != "type=default" {
+		t.Errorf("clusterConfig() modified the config of the main cluster: %+v", conf)
+	}
+}
+
+func TestGetServerAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		options internalversion.KwokConfigurationOptions
+		want    string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:    "node port",
+			options: internalversion.KwokConfigurationOptions{NodePort: 10247},
+			want:    "0.0.0.0:10247",
+		},
+		{
+			name:    "server address",
+			options: internalversion.KwokConfigurationOptions{ServerAddress: "127.0.0.1:10250", NodePort: 10247},
+			want:    "127.0.0.1:10250",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getServerAddress(&tt.options); got != tt.want {
+				t.Errorf("getServerAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
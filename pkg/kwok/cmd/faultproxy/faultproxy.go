@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package faultproxy contains a command to serve a proxy of the kube-apiserver which injects faults into the requests.
+package faultproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/kwok/pkg/kwok/faultproxy"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Kubeconfig string
+	Master     string
+	Address    string
+	Faults     []string
+}
+
+// NewCommand returns a new cobra.Command for the fault proxy
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "fault-proxy",
+		Short: "Serve a proxy of the kube-apiserver which injects latency, errors and throttling into the requests",
+		Long: `Serve a proxy of the kube-apiserver over plain HTTP, the requests are forwarded with the credentials of the kubeconfig.
+The faults of the first rule matching the verb and the resource of a request are injected into it,
+a rule is comma-separated key=value pairs of verb, resource, delay, jitter, error-rate and throttle-rate,
+e.g. "verb=list,resource=pods,delay=500ms,jitter=100ms,error-rate=0.1,throttle-rate=0.05".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "Path to the kubeconfig file to use")
+	cmd.Flags().StringVar(&flags.Master, "master", flags.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
+	cmd.Flags().StringVar(&flags.Address, "address", ":8001", "Address to serve the proxy on")
+	cmd.Flags().StringArrayVar(&flags.Faults, "fault", flags.Faults, "Rule of the faults injected into the requests, can be repeated, the first matching rule is used")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	logger := log.FromContext(ctx)
+
+	rules, err := faultproxy.ParseRules(flags.Faults)
+	if err != nil {
+		return err
+	}
+
+	if flags.Kubeconfig != "" {
+		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+	clientset, err := client.NewClientset(flags.Master, flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	transport, err := rest.TransportFor(restConfig)
+	if err != nil {
+		return err
+	}
+	target, err := url.Parse(restConfig.Host)
+	if err != nil {
+		return err
+	}
+
+	upstream := httputil.NewSingleHostReverseProxy(target)
+	upstream.Transport = transport
+	// Flush the responses of watches immediately
+	upstream.FlushInterval = -1
+
+	listener, err := net.Listen("tcp", flags.Address)
+	if err != nil {
+		return err
+	}
+
+	svc := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+		Handler: faultproxy.NewHandler(upstream, rules),
+	}
+	go func() {
+		<-ctx.Done()
+		_ = svc.Close()
+	}()
+
+	logger.Info("Serving the fault proxy",
+		"address", listener.Addr().String(),
+		"upstream", restConfig.Host,
+		"faults", flags.Faults,
+	)
+	err = svc.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the fault proxy: %w", err)
+	}
+	return nil
+}
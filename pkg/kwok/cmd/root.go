@@ -26,7 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 
 	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
@@ -37,7 +37,10 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwok/cmd/auditsink"
+	"sigs.k8s.io/kwok/pkg/kwok/cmd/faultproxy"
 	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
 	"sigs.k8s.io/kwok/pkg/kwok/server"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
@@ -45,6 +48,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
@@ -77,11 +81,14 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
 
 	cmd.Flags().StringVar(&flags.Options.CIDR, "cidr", flags.Options.CIDR, "CIDR of the pod ip")
+	cmd.Flags().StringVar(&flags.Options.LoadBalancerCIDR, "load-balancer-cidr", flags.Options.LoadBalancerCIDR, "CIDR of the load balancer ip of services")
 	cmd.Flags().StringVar(&flags.Options.NodeIP, "node-ip", flags.Options.NodeIP, "IP of the node")
 	cmd.Flags().StringVar(&flags.Options.NodeName, "node-name", flags.Options.NodeName, "Name of the node")
 	cmd.Flags().IntVar(&flags.Options.NodePort, "node-port", flags.Options.NodePort, "Port of the node")
 	cmd.Flags().StringVar(&flags.Options.TLSCertFile, "tls-cert-file", flags.Options.TLSCertFile, "File containing the default x509 Certificate for HTTPS")
 	cmd.Flags().StringVar(&flags.Options.TLSPrivateKeyFile, "tls-private-key-file", flags.Options.TLSPrivateKeyFile, "File containing the default x509 private key matching --tls-cert-file")
+	cmd.Flags().StringVar(&flags.Options.CSRSignerCertFile, "csr-signer-cert-file", flags.Options.CSRSignerCertFile, "File containing the x509 Certificate of the CA signing the CertificateSigningRequests")
+	cmd.Flags().StringVar(&flags.Options.CSRSignerKeyFile, "csr-signer-key-file", flags.Options.CSRSignerKeyFile, "File containing the x509 private key matching --csr-signer-cert-file")
 	cmd.Flags().StringVar(&flags.Options.ManageSingleNode, "manage-single-node", flags.Options.ManageSingleNode, "Node that matches the name will be watched and managed. It's conflicted with manage-nodes-with-annotation-selector, manage-nodes-with-label-selector and manage-all-nodes.")
 	cmd.Flags().BoolVar(&flags.Options.ManageAllNodes, "manage-all-nodes", flags.Options.ManageAllNodes, "All nodes will be watched and managed. It's conflicted with manage-nodes-with-annotation-selector, manage-nodes-with-label-selector and manage-single-node.")
 	cmd.Flags().StringVar(&flags.Options.ManageNodesWithAnnotationSelector, "manage-nodes-with-annotation-selector", flags.Options.ManageNodesWithAnnotationSelector, "Nodes that match the annotation selector will be watched and managed. It's conflicted with manage-all-nodes and manage-single-node.")
@@ -94,12 +101,24 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Master, "master", flags.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
 	cmd.Flags().StringVar(&flags.Options.ServerAddress, "server-address", flags.Options.ServerAddress, "Address to expose the server on")
 	cmd.Flags().UintVar(&flags.Options.NodeLeaseDurationSeconds, "node-lease-duration-seconds", flags.Options.NodeLeaseDurationSeconds, "Duration of node lease seconds")
+	cmd.Flags().UintVar(&flags.Options.MaxManagedNodes, "max-managed-nodes", flags.Options.MaxManagedNodes, "Maximum number of nodes to manage, the nodes beyond it wait until the managed nodes are deleted, 0 means no limit")
+	cmd.Flags().UintVar(&flags.Options.MaxManagedPods, "max-managed-pods", flags.Options.MaxManagedPods, "Maximum number of pods to manage, the pods beyond it wait until the managed pods are deleted, 0 means no limit")
+	cmd.Flags().UintVar(&flags.Options.MaxManagedPodsPerNamespace, "max-managed-pods-per-namespace", flags.Options.MaxManagedPodsPerNamespace, "Maximum number of pods to manage in each namespace, 0 means no limit")
+	cmd.Flags().Float64Var(&flags.Options.TimeAcceleration, "time-acceleration", flags.Options.TimeAcceleration, "Factor by which the time of the simulation is accelerated, the delays of the stages and the renew interval of the node leases are divided by it, and the timestamps generated by the stages pass faster by it, 0 or 1 means real time")
+	cmd.Flags().StringVar((*string)(&flags.Options.OrphanPodPolicy), "orphan-pod-policy", string(flags.Options.OrphanPodPolicy), "What to do with the pods on a managed node after the node is deleted, one of ignore, delete and fail, ignore leaves them for kube-controller-manager")
+	cmd.Flags().UintVar(&flags.Options.OrphanPodDelaySeconds, "orphan-pod-delay-seconds", flags.Options.OrphanPodDelaySeconds, "Delay in seconds after a node is deleted before the orphan pod policy is applied to its pods")
+	cmd.Flags().BoolVar(&flags.Options.EnforceNodeAllocatable, "enforce-node-allocatable", flags.Options.EnforceNodeAllocatable, "Refuse to run the pods whose requests exceed the remaining allocatable of their nodes, marking them back to Pending with an event")
+	cmd.Flags().StringVar(&flags.Options.DecisionLogPath, "decision-log-path", flags.Options.DecisionLogPath, "Path of the file the decisions of the controller are appended to as JSON lines, for the analysis of the simulation after the run")
+	cmd.Flags().Int64Var(&flags.Options.Seed, "seed", flags.Options.Seed, "Seed of the random numbers of the jitters and the weighted selections of the stages, 0 means random")
 	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
 
 	cmd.Flags().BoolVar(&flags.Options.EnableCNI, "experimental-enable-cni", flags.Options.EnableCNI, "Experimental support for getting pod ip from CNI, for CNI-related components, Only works with Linux")
 	if config.GOOS != "linux" {
 		_ = cmd.Flags().MarkHidden("experimental-enable-cni")
 	}
+
+	cmd.AddCommand(faultproxy.NewCommand(ctx))
+	cmd.AddCommand(auditsink.NewCommand(ctx))
 	return cmd
 }
 
@@ -116,6 +135,7 @@ var crdDefines = map[string]struct{}{
 	v1alpha1.ResourceUsageKind:        {},
 	v1alpha1.ClusterResourceUsageKind: {},
 	v1alpha1.MetricKind:               {},
+	v1alpha1.PauseKind:                {},
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
@@ -140,6 +160,16 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	}
 
+	// The server only serves the nodes of the cluster of --kubeconfig
+	if len(flags.Options.Clusters) != 0 && getServerAddress(&flags.Options) != "" {
+		return fmt.Errorf("the clusters option is not supported with the server, unset --server-address and --node-port")
+	}
+
+	if flags.Options.Seed != 0 {
+		rand.Seed(flags.Options.Seed)
+		logger.Info("Seeded the random numbers", "seed", flags.Options.Seed)
+	}
+
 	stagesData := config.FilterWithTypeFromContext[*internalversion.Stage](ctx)
 	err := checkConfigOrCRD(flags.Options.EnableCRDs, v1alpha1.StageKind, stagesData)
 	if err != nil {
@@ -181,42 +211,6 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
-	restConfig, err := clientset.ToRESTConfig()
-	if err != nil {
-		return err
-	}
-
-	dynamicClient, err := clientset.ToDynamicClient()
-	if err != nil {
-		return err
-	}
-
-	impersonatingDynamicClient := clientset.ToImpersonatingDynamicClient()
-
-	restMapper, err := clientset.ToRESTMapper()
-	if err != nil {
-		return err
-	}
-
-	restClient, err := rest.RESTClientFor(restConfig)
-	if err != nil {
-		return err
-	}
-
-	typedClient, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return err
-	}
-	typedKwokClient, err := versioned.NewForConfig(restConfig)
-	if err != nil {
-		return err
-	}
-
-	err = waitForReady(ctx, typedClient)
-	if err != nil {
-		return err
-	}
-
 	switch {
 	case flags.Options.ManageSingleNode != "":
 		logger.Info("Watch single node",
@@ -237,16 +231,32 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 	ctx = log.NewContext(ctx, logger.With("id", id))
 
+	pauses := config.FilterWithTypeFromContext[*internalversion.Pause](ctx)
+	err = checkConfigOrCRD(flags.Options.EnableCRDs, v1alpha1.PauseKind, pauses)
+	if err != nil {
+		return err
+	}
+
+	var decisionRecorder *decisions.Recorder
+	if flags.Options.DecisionLogPath != "" {
+		decisionLogPath, err := path.Expand(flags.Options.DecisionLogPath)
+		if err != nil {
+			return err
+		}
+		decisionRecorder, err = decisions.NewFileRecorder(decisionLogPath)
+		if err != nil {
+			return fmt.Errorf("failed to open the decision log: %w", err)
+		}
+		defer func() {
+			_ = decisionRecorder.Close()
+		}()
+		logger.Info("Recording the decisions", "path", decisionLogPath)
+	}
+
 	metrics := config.FilterWithTypeFromContext[*internalversion.Metric](ctx)
 	enableMetrics := len(metrics) != 0 || slices.Contains(flags.Options.EnableCRDs, v1alpha1.MetricKind)
-	ctr, err := controllers.NewController(controllers.Config{
+	ctrConf := controllers.Config{
 		Clock:                                 clock.RealClock{},
-		DynamicClient:                         dynamicClient,
-		RESTClient:                            restClient,
-		RESTMapper:                            restMapper,
-		ImpersonatingDynamicClient:            impersonatingDynamicClient,
-		TypedClient:                           typedClient,
-		TypedKwokClient:                       typedKwokClient,
 		EnableCNI:                             flags.Options.EnableCNI,
 		EnableMetrics:                         enableMetrics,
 		EnablePodCache:                        enableMetrics,
@@ -257,16 +267,37 @@ func runE(ctx context.Context, flags *flagpole) error {
 		DisregardStatusWithAnnotationSelector: flags.Options.DisregardStatusWithAnnotationSelector,
 		DisregardStatusWithLabelSelector:      flags.Options.DisregardStatusWithLabelSelector,
 		CIDR:                                  flags.Options.CIDR,
+		LoadBalancerCIDR:                      flags.Options.LoadBalancerCIDR,
+		CSRSignerCertFile:                     flags.Options.CSRSignerCertFile,
+		CSRSignerKeyFile:                      flags.Options.CSRSignerKeyFile,
 		NodeIP:                                flags.Options.NodeIP,
 		NodeName:                              flags.Options.NodeName,
 		NodePort:                              flags.Options.NodePort,
 		PodPlayStageParallelism:               flags.Options.PodPlayStageParallelism,
 		NodePlayStageParallelism:              flags.Options.NodePlayStageParallelism,
 		LocalStages:                           groupStages,
+		LocalPauses:                           pauses,
+		EnablePauseCRD:                        slices.Contains(flags.Options.EnableCRDs, v1alpha1.PauseKind),
 		NodeLeaseParallelism:                  flags.Options.NodeLeaseParallelism,
 		NodeLeaseDurationSeconds:              flags.Options.NodeLeaseDurationSeconds,
+		MaxManagedNodes:                       flags.Options.MaxManagedNodes,
+		MaxManagedPods:                        flags.Options.MaxManagedPods,
+		MaxManagedPodsPerNamespace:            flags.Options.MaxManagedPodsPerNamespace,
+		TimeAcceleration:                      flags.Options.TimeAcceleration,
+		OrphanPodPolicy:                       flags.Options.OrphanPodPolicy,
+		OrphanPodDelay:                        time.Duration(flags.Options.OrphanPodDelaySeconds) * time.Second,
+		EnforceNodeAllocatable:                flags.Options.EnforceNodeAllocatable,
 		ID:                                    id,
-	})
+		DecisionRecorder:                      decisionRecorder,
+		EventCorrelatorOptions:                eventCorrelatorOptions(&flags.Options),
+	}
+
+	err = setupClients(ctx, &ctrConf, clientset)
+	if err != nil {
+		return err
+	}
+
+	ctr, err := controllers.NewController(ctrConf)
 	if err != nil {
 		return err
 	}
@@ -276,7 +307,12 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
-	err = startServer(ctx, flags, ctr, typedKwokClient)
+	err = startClusters(ctx, flags.Options.Clusters, ctrConf)
+	if err != nil {
+		return err
+	}
+
+	err = startServer(ctx, flags, ctr, ctrConf.TypedKwokClient)
 	if err != nil {
 		return err
 	}
@@ -285,14 +321,30 @@ func runE(ctx context.Context, flags *flagpole) error {
 	return nil
 }
 
-func startServer(ctx context.Context, flags *flagpole, ctr *controllers.Controller, typedKwokClient versioned.Interface) (err error) {
-	logger := log.FromContext(ctx)
+// eventCorrelatorOptions returns the rate limits and the aggregation of the events of the options,
+// the unset ones are zero so the defaults of client-go are used.
+func eventCorrelatorOptions(options *internalversion.KwokConfigurationOptions) record.CorrelatorOptions {
+	return record.CorrelatorOptions{
+		QPS:                  float32(options.EventRecordQPS),
+		BurstSize:            options.EventBurst,
+		MaxEvents:            options.EventAggregationMaxEvents,
+		MaxIntervalInSeconds: options.EventAggregationMaxIntervalSeconds,
+		LRUCacheSize:         options.EventCacheSize,
+	}
+}
 
-	serverAddress := flags.Options.ServerAddress
-	if serverAddress == "" && flags.Options.NodePort != 0 {
-		serverAddress = "0.0.0.0:" + format.String(flags.Options.NodePort)
+// getServerAddress returns the address the server listens on, empty if the server is disabled.
+func getServerAddress(options *internalversion.KwokConfigurationOptions) string {
+	if options.ServerAddress == "" && options.NodePort != 0 {
+		return "0.0.0.0:" + format.String(options.NodePort)
 	}
+	return options.ServerAddress
+}
+
+func startServer(ctx context.Context, flags *flagpole, ctr *controllers.Controller, typedKwokClient versioned.Interface) (err error) {
+	logger := log.FromContext(ctx)
 
+	serverAddress := getServerAddress(&flags.Options)
 	if serverAddress != "" {
 		clusterPortForwards := config.FilterWithTypeFromContext[*internalversion.ClusterPortForward](ctx)
 		err = checkConfigOrCRD(flags.Options.EnableCRDs, v1alpha1.ClusterPortForwardKind, clusterPortForwards)
@@ -403,6 +455,11 @@ func startServer(ctx context.Context, flags *flagpole, ctr *controllers.Controll
 			return fmt.Errorf("failed to install metrics: %w", err)
 		}
 
+		err = svc.InstallResourceUsage()
+		if err != nil {
+			return fmt.Errorf("failed to install resource usage: %w", err)
+		}
+
 		go func() {
 			err := svc.Run(ctx, serverAddress, flags.Options.TLSCertFile, flags.Options.TLSPrivateKeyFile)
 			if err != nil {
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestEventCorrelatorOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options internalversion.KwokConfigurationOptions
+		want    record.CorrelatorOptions
+	}{
+		{
+			name: "unset",
+			// The zero options make client-go use its defaults
+			want: record.CorrelatorOptions{},
+		},
+		{
+			name: "set",
+			options: internalversion.KwokConfigurationOptions{
+				EventRecordQPS:                     0.5,
+				EventBurst:                         50,
+				EventAggregationMaxEvents:          20,
+				EventAggregationMaxIntervalSeconds: 300,
+				EventCacheSize:                     8192,
+			},
+			want: record.CorrelatorOptions{
+				QPS:                  0.5,
+				BurstSize:            50,
+				MaxEvents:            20,
+				MaxIntervalInSeconds: 300,
+				LRUCacheSize:         8192,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventCorrelatorOptions(&tt.options)
+			if got.QPS != tt.want.QPS ||
+				got.BurstSize != tt.want.BurstSize ||
+				got.MaxEvents != tt.want.MaxEvents ||
+				got.MaxIntervalInSeconds != tt.want.MaxIntervalInSeconds ||
+				got.LRUCacheSize != tt.want.LRUCacheSize ||
+				got.KeyFunc != nil || got.MessageFunc != nil || got.SpamKeyFunc != nil || got.Clock != nil {
+				t.Errorf("eventCorrelatorOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,12 +41,15 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
+	bundledlifecycle "sigs.k8s.io/kwok/pkg/config/lifecycle"
 	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/gotpl"
 	"sigs.k8s.io/kwok/pkg/utils/informer"
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/patch"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
@@ -71,6 +74,9 @@ type Controller struct {
 	nodeCacheGetter      informer.Getter[*corev1.Node]
 	podCacheGetter       informer.Getter[*corev1.Pod]
 	nodeLeaseCacheGetter informer.Getter[*coordinationv1.Lease]
+	namespaceCacheGetter informer.Getter[*corev1.Namespace]
+
+	bundledLifecycles maps.SyncMap[string, lifecycle.Lifecycle]
 
 	onNodeManagedFunc   func(nodeName string)
 	onNodeUnmanagedFunc func(nodeName string)
@@ -88,13 +94,22 @@ type Controller struct {
 	nodeLeasesInformer *informer.Informer[*coordinationv1.Lease, *coordinationv1.LeaseList]
 	nodesInformer      *informer.Informer[*corev1.Node, *corev1.NodeList]
 	podsInformer       *informer.Informer[*corev1.Pod, *corev1.PodList]
+	namespacesInformer *informer.Informer[*corev1.Namespace, *corev1.NamespaceList]
 
 	patchMeta *patch.PatchMetaFromOpenAPI3
 
+	loadBalancerIPs *loadBalancerIPAllocator
+	csrSigner       *csrSigner
+
 	stageGetter resources.DynamicGetter[[]*internalversion.Stage]
 
+	pauses *pauses
+
 	podOnNodeManageQueue queue.Queue[string]
 	nodeManageQueue      queue.Queue[string]
+	orphanPodsQueue      queue.DelayingQueue[string]
+
+	startTime time.Time
 }
 
 // Config is the configuration for the controller
@@ -114,18 +129,32 @@ type Config struct {
 	DisregardStatusWithAnnotationSelector string
 	DisregardStatusWithLabelSelector      string
 	CIDR                                  string
+	LoadBalancerCIDR                      string
+	CSRSignerCertFile                     string
+	CSRSignerKeyFile                      string
 	NodeIP                                string
 	NodeName                              string
 	NodePort                              int
 	LocalStages                           map[internalversion.StageResourceRef][]*internalversion.Stage
+	LocalPauses                           []*internalversion.Pause
+	EnablePauseCRD                        bool
 	PodPlayStageParallelism               uint
 	NodePlayStageParallelism              uint
 	NodeLeaseDurationSeconds              uint
 	NodeLeaseParallelism                  uint
+	MaxManagedNodes                       uint
+	MaxManagedPods                        uint
+	MaxManagedPodsPerNamespace            uint
+	EnforceNodeAllocatable                bool
+	TimeAcceleration                      float64
+	OrphanPodPolicy                       internalversion.OrphanPodPolicy
+	OrphanPodDelay                        time.Duration
 	ID                                    string
 	EnableMetrics                         bool
 	EnablePodCache                        bool
 	FuncMap                               gotpl.FuncMap
+	EventCorrelatorOptions                record.CorrelatorOptions
+	DecisionRecorder                      *decisions.Recorder
 }
 
 func (c Config) validate() error {
@@ -145,6 +174,15 @@ func (c Config) validate() error {
 	default:
 		return fmt.Errorf("no nodes are managed")
 	}
+
+	switch c.OrphanPodPolicy {
+	case "",
+		internalversion.OrphanPodPolicyIgnore,
+		internalversion.OrphanPodPolicyDelete,
+		internalversion.OrphanPodPolicyFail:
+	default:
+		return fmt.Errorf("unsupported orphan pod policy %q", c.OrphanPodPolicy)
+	}
 	return nil
 }
 
@@ -180,9 +218,19 @@ func (c *Controller) init(ctx context.Context) (err error) {
 		c.managePodsWithFieldSelector = fields.OneTermNotEqualSelector("spec.nodeName", "").String()
 	}
 
-	c.broadcaster = record.NewBroadcaster()
-	c.recorder = c.broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kwok_controller"})
-	c.broadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: c.conf.TypedClient.CoreV1().Events("")})
+	if c.conf.LoadBalancerCIDR != "" {
+		c.loadBalancerIPs, err = newLoadBalancerIPAllocator(c.conf.LoadBalancerCIDR)
+		if err != nil {
+			return fmt.Errorf("failed to parse load balancer cidr: %w", err)
+		}
+	}
+
+	c.csrSigner, err = newCSRSigner(c.conf.CSRSignerCertFile, c.conf.CSRSignerKeyFile)
+	if err != nil {
+		return err
+	}
+
+	c.broadcaster, c.recorder = newEventRecorder(c.conf.TypedClient, c.conf.EventCorrelatorOptions)
 
 	c.nodesChan = make(chan informer.Event[*corev1.Node], 1)
 	c.podsChan = make(chan informer.Event[*corev1.Pod], 1)
@@ -213,6 +261,13 @@ func (c *Controller) init(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to watch pods: %w", err)
 	}
 
+	namespacesCli := c.conf.TypedClient.CoreV1().Namespaces()
+	c.namespacesInformer = informer.NewInformer[*corev1.Namespace, *corev1.NamespaceList](namespacesCli)
+	c.namespaceCacheGetter, err = c.namespacesInformer.WatchWithCache(ctx, informer.Option{}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to watch namespaces: %w", err)
+	}
+
 	if c.conf.NodeLeaseDurationSeconds != 0 {
 		nodeLeasesCli := c.conf.TypedClient.CoordinationV1().Leases(corev1.NamespaceNodeLease)
 		c.nodeLeasesInformer = informer.NewInformer[*coordinationv1.Lease, *coordinationv1.LeaseList](nodeLeasesCli)
@@ -220,11 +275,45 @@ func (c *Controller) init(ctx context.Context) (err error) {
 
 	c.patchMeta = patch.NewPatchMetaFromOpenAPI3(c.conf.RESTClient)
 
+	c.initTimeAcceleration()
+
+	err = c.initPauses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init pauses: %w", err)
+	}
+
 	c.podOnNodeManageQueue = queue.NewQueue[string]()
 	c.nodeManageQueue = queue.NewQueue[string]()
+	c.initOrphanPods(ctx)
 	return nil
 }
 
+// initTimeAcceleration makes the timestamps generated by the stages pass as fast as their delays,
+// the time of the simulation starts with the controller.
+func (c *Controller) initTimeAcceleration() {
+	c.startTime = c.clockNow()
+	if c.conf.TimeAcceleration <= 0 || c.conf.TimeAcceleration == 1 {
+		return
+	}
+	c.conf.FuncMap = maps.Merge(gotpl.FuncMap{
+		"Now": func() string {
+			return c.simulationNow().Format(time.RFC3339Nano)
+		},
+	}, c.conf.FuncMap)
+}
+
+// simulationNow returns the current time of the simulation, which is accelerated since the controller starts.
+func (c *Controller) simulationNow() time.Time {
+	return accelerateTime(c.clockNow(), c.startTime, c.conf.TimeAcceleration)
+}
+
+func (c *Controller) clockNow() time.Time {
+	if c.conf.Clock == nil {
+		return time.Now()
+	}
+	return c.conf.Clock.Now()
+}
+
 func (c *Controller) initNodeLeaseController(ctx context.Context) error {
 	if c.conf.NodeLeaseDurationSeconds == 0 {
 		// Manage pods ignores leases
@@ -244,7 +333,7 @@ func (c *Controller) initNodeLeaseController(ctx context.Context) error {
 
 	leaseDuration := time.Duration(c.conf.NodeLeaseDurationSeconds) * time.Second
 	// https://github.com/kubernetes/kubernetes/blob/02f4d643eae2e225591702e1bbf432efea453a26/pkg/kubelet/kubelet.go#L199-L200
-	renewInterval := leaseDuration / 4
+	renewInterval := accelerate(leaseDuration/4, c.conf.TimeAcceleration)
 	// https://github.com/kubernetes/component-helpers/blob/d17b6f1e84500ee7062a26f5327dc73cb3e9374a/apimachinery/lease/controller.go#L100
 	renewIntervalJitter := 0.04
 	c.nodeLeases, err = NewNodeLeaseController(NodeLeaseControllerConfig{
@@ -255,6 +344,13 @@ func (c *Controller) initNodeLeaseController(ctx context.Context) error {
 		GetLease: func(nodeName string) (*coordinationv1.Lease, bool) {
 			return c.nodeLeaseCacheGetter.GetWithNamespace(nodeName, corev1.NamespaceNodeLease)
 		},
+		GetNodeAnnotations: func(nodeName string) map[string]string {
+			node, ok := c.nodeCacheGetter.Get(nodeName)
+			if !ok {
+				return nil
+			}
+			return node.Annotations
+		},
 		RenewInterval:       renewInterval,
 		RenewIntervalJitter: renewIntervalJitter,
 		MutateLeaseFunc: setNodeOwnerFunc(func(nodeName string) []metav1.OwnerReference {
@@ -328,6 +424,15 @@ func (c *Controller) nodeLeaseSyncWorker(ctx context.Context) {
 	}
 }
 
+// newEventRecorder returns the broadcaster recording the events to the cluster and the recorder of it,
+// the events are rate limited and aggregated with the options, the unset ones are the defaults of client-go.
+func newEventRecorder(typedClient kubernetes.Interface, opts record.CorrelatorOptions) (record.EventBroadcaster, record.EventRecorder) {
+	broadcaster := record.NewBroadcaster(record.WithCorrelatorOptions(opts))
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kwok_controller"})
+	broadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: typedClient.CoreV1().Events("")})
+	return broadcaster, recorder
+}
+
 var podRef = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"}
 var nodeRef = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Node"}
 
@@ -400,6 +505,43 @@ func (c *Controller) initStagesManager(ctx context.Context) error {
 	return nil
 }
 
+// initPauses watches the Pause resources if the CRD is enabled, otherwise uses the local ones.
+func (c *Controller) initPauses(ctx context.Context) error {
+	if !c.conf.EnablePauseCRD {
+		c.pauses = newPauses(resources.NewStaticGetter(c.conf.LocalPauses))
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	getter := resources.NewDynamicGetter[
+		[]*internalversion.Pause,
+		*v1alpha1.Pause,
+		*v1alpha1.PauseList,
+	](
+		c.conf.TypedKwokClient.KwokV1alpha1().Pauses(),
+		func(objs []*v1alpha1.Pause) []*internalversion.Pause {
+			return slices.FilterAndMap(objs, func(obj *v1alpha1.Pause) (*internalversion.Pause, bool) {
+				r, err := internalversion.ConvertToInternalPause(obj)
+				if err != nil {
+					logger.Error("failed to convert to internal pause", err, "obj", obj)
+					return nil, false
+				}
+				return r, true
+			})
+		},
+	)
+
+	err := getter.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.pauses = newPauses(getter)
+	c.pauses.Start(ctx)
+	c.pauses.StartStatus(ctx, c.conf.TypedKwokClient.KwokV1alpha1().Pauses(), c.conf.Clock, pauseStatusInterval)
+	return nil
+}
+
 func (c *Controller) onNodeManaged(nodeName string) {
 	if c.onNodeManagedFunc == nil {
 		return
@@ -425,12 +567,17 @@ func (c *Controller) initNodeController(ctx context.Context, lifecycle resources
 		DisregardStatusWithLabelSelector:      c.conf.DisregardStatusWithLabelSelector,
 		OnNodeManagedFunc:                     c.onNodeManaged,
 		OnNodeUnmanagedFunc:                   c.onNodeUnmanaged,
+		OnNodeDeletedFunc:                     c.onNodeDeleted,
 		Lifecycle:                             lifecycle,
 		PlayStageParallelism:                  c.conf.NodePlayStageParallelism,
 		FuncMap:                               c.conf.FuncMap,
 		Recorder:                              c.recorder,
+		DecisionRecorder:                      c.conf.DecisionRecorder,
 		ReadOnlyFunc:                          c.readOnlyFunc,
 		EnableMetrics:                         c.conf.EnableMetrics,
+		MaxManagedNodes:                       c.conf.MaxManagedNodes,
+		TimeAcceleration:                      c.conf.TimeAcceleration,
+		Pauses:                                c.pauses,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create nodes controller: %w", err)
@@ -453,6 +600,7 @@ func (c *Controller) initPodController(ctx context.Context, lifecycle resources.
 		DisregardStatusWithAnnotationSelector: c.conf.DisregardStatusWithAnnotationSelector,
 		DisregardStatusWithLabelSelector:      c.conf.DisregardStatusWithLabelSelector,
 		Lifecycle:                             lifecycle,
+		NamespaceLifecycleFunc:                c.namespaceLifecycle,
 		PlayStageParallelism:                  c.conf.PodPlayStageParallelism,
 		NodeGetFunc: func(nodeName string) (*NodeInfo, bool) {
 			if c.nodes == nil {
@@ -461,10 +609,16 @@ func (c *Controller) initPodController(ctx context.Context, lifecycle resources.
 
 			return c.nodes.Get(nodeName)
 		},
-		FuncMap:       c.conf.FuncMap,
-		Recorder:      c.recorder,
-		ReadOnlyFunc:  c.readOnlyFunc,
-		EnableMetrics: c.conf.EnableMetrics,
+		FuncMap:                    c.conf.FuncMap,
+		Recorder:                   c.recorder,
+		DecisionRecorder:           c.conf.DecisionRecorder,
+		ReadOnlyFunc:               c.readOnlyFunc,
+		EnableMetrics:              c.conf.EnableMetrics,
+		MaxManagedPods:             c.conf.MaxManagedPods,
+		MaxManagedPodsPerNamespace: c.conf.MaxManagedPodsPerNamespace,
+		EnforceNodeAllocatable:     c.conf.EnforceNodeAllocatable,
+		TimeAcceleration:           c.conf.TimeAcceleration,
+		Pauses:                     c.pauses,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create pods controller: %w", err)
@@ -478,6 +632,32 @@ func (c *Controller) initPodController(ctx context.Context, lifecycle resources.
 	return nil
 }
 
+// namespaceLifecycle returns the bundled lifecycle selected by the annotation of the namespace.
+func (c *Controller) namespaceLifecycle(namespace string) (lifecycle.Lifecycle, bool, error) {
+	ns, ok := c.namespaceCacheGetter.Get(namespace)
+	if !ok {
+		return nil, false, nil
+	}
+	name := ns.Annotations[bundledlifecycle.Annotation]
+	if name == "" {
+		return nil, false, nil
+	}
+
+	if lc, ok := c.bundledLifecycles.Load(name); ok {
+		return lc, true, nil
+	}
+	stages, err := bundledlifecycle.PodStages(name)
+	if err != nil {
+		return nil, false, err
+	}
+	lc, err := lifecycle.NewLifecycle(stages)
+	if err != nil {
+		return nil, false, err
+	}
+	c.bundledLifecycles.Store(name, lc)
+	return lc, true, nil
+}
+
 func (c *Controller) initStageController(ctx context.Context, ref internalversion.StageResourceRef, lifecycle resources.Getter[lifecycle.Lifecycle]) error {
 	logger := log.FromContext(ctx)
 
@@ -516,6 +696,12 @@ func (c *Controller) initStageController(ctx context.Context, ref internalversio
 		PlayStageParallelism:                  1,
 		FuncMap:                               c.conf.FuncMap,
 		Recorder:                              c.recorder,
+		DecisionRecorder:                      c.conf.DecisionRecorder,
+		TimeAcceleration:                      c.conf.TimeAcceleration,
+		LoadBalancerIPs:                       c.loadBalancerIPs,
+		CSRSigner:                             c.csrSigner,
+		ResourceRef:                           ref,
+		Pauses:                                c.pauses,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create stage controller: %w", err)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +28,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
 
 	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
 	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
@@ -193,3 +196,199 @@ func TestController(t *testing.T) {
 		})
 	}
 }
+
+type fakeNamespaceGetter map[string]*corev1.Namespace
+
+func (g fakeNamespaceGetter) Get(name string) (*corev1.Namespace, bool) {
+	ns, ok := g[name]
+	return ns, ok
+}
+
+func (g fakeNamespaceGetter) GetWithNamespace(name, _ string) (*corev1.Namespace, bool) {
+	return g.Get(name)
+}
+
+func (g fakeNamespaceGetter) List() []*corev1.Namespace {
+	return nil
+}
+
+func TestControllerNamespaceLifecycle(t *testing.T) {
+	c := &Controller{
+		namespaceCacheGetter: fakeNamespaceGetter{
+			"default": &corev1.Namespace{},
+			"chaos": &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"kwok.x-k8s.io/lifecycle": "chaos"},
+			}},
+			"none": &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"kwok.x-k8s.io/lifecycle": "none"},
+			}},
+			"unknown": &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"kwok.x-k8s.io/lifecycle": "unknown"},
+			}},
+		},
+	}
+
+	for _, ns := range []string{"default", "missing"} {
+		_, ok, err := c.namespaceLifecycle(ns)
+		if err != nil || ok {
+			t.Errorf("namespaceLifecycle(%q) = %v, %v, want no lifecycle", ns, ok, err)
+		}
+	}
+
+	lc, ok, err := c.namespaceLifecycle("chaos")
+	if err != nil || !ok || len(lc) == 0 {
+		t.Errorf("namespaceLifecycle(chaos) = %d stages, %v, %v, want the chaos stages", len(lc), ok, err)
+	}
+	if cached, _ := c.bundledLifecycles.Load("chaos"); len(cached) != len(lc) {
+		t.Errorf("namespaceLifecycle(chaos) is not cached")
+	}
+
+	lc, ok, err = c.namespaceLifecycle("none")
+	if err != nil || !ok || len(lc) != 0 {
+		t.Errorf("namespaceLifecycle(none) = %d stages, %v, %v, want no stages", len(lc), ok, err)
+	}
+
+	_, _, err = c.namespaceLifecycle("unknown")
+	if err == nil {
+		t.Errorf("namespaceLifecycle(unknown) error = nil, want error")
+	}
+}
+
+func TestNewEventRecorder(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-0",
+			Namespace: "default",
+			UID:       "pod-0",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		opts     record.CorrelatorOptions
+		messages []string
+		// wantRecorded is the number of the events created or patched in the cluster
+		wantRecorded int
+		wantCombined bool
+	}{
+		{
+			name:         "default burst",
+			messages:     repeat("Started", 30),
+			wantRecorded: 25,
+		},
+		{
+			name: "burst",
+			opts: record.CorrelatorOptions{
+				QPS:       0.001,
+				BurstSize: 3,
+			},
+			messages:     repeat("Started", 10),
+			wantRecorded: 3,
+		},
+		{
+			name:         "default aggregation",
+			messages:     []string{"Started 0", "Started 1", "Started 2", "Started 3"},
+			wantRecorded: 4,
+		},
+		{
+			name: "aggregation",
+			opts: record.CorrelatorOptions{
+				MaxEvents:            2,
+				MaxIntervalInSeconds: 600,
+				LRUCacheSize:         16,
+			},
+			messages:     []string{"Started 0", "Started 1", "Started 2", "Started 3"},
+			wantRecorded: 4,
+			wantCombined: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			broadcaster, recorder := newEventRecorder(client, tt.opts)
+			defer broadcaster.Shutdown()
+
+			for _, message := range tt.messages {
+				recorder.Event(pod, corev1.EventTypeNormal, "Started", message)
+			}
+
+			recorded := func() int {
+				n := 0
+				for _, action := range client.Actions() {
+					if action.GetResource().Resource == "events" && (action.GetVerb() == "create" || action.GetVerb() == "patch") {
+						n++
+					}
+				}
+				return n
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+				return recorded() >= tt.wantRecorded, nil
+			}, wait.WithInterval(10*time.Millisecond))
+			if err != nil {
+				t.Fatalf("want %d recorded events, got %d: %v", tt.wantRecorded, recorded(), err)
+			}
+			// Make sure no more events are recorded beyond the limit
+			time.Sleep(100 * time.Millisecond)
+			if got := recorded(); got != tt.wantRecorded {
+				t.Fatalf("want %d recorded events, got %d", tt.wantRecorded, got)
+			}
+
+			events, err := client.CoreV1().Events("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			combined := slices.Filter(events.Items, func(event corev1.Event) bool {
+				return strings.HasPrefix(event.Message, "(combined from similar events)")
+			})
+			if (len(combined) != 0) != tt.wantCombined {
+				t.Errorf("want combined events %v, got %v", tt.wantCombined, combined)
+			}
+		})
+	}
+}
+
+func repeat(s string, n int) []string {
+	out := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestControllerTimeAcceleration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := testingclock.NewFakeClock(start)
+	c := &Controller{
+		conf: Config{
+			Clock:            clock,
+			TimeAcceleration: 10,
+		},
+	}
+	c.initTimeAcceleration()
+
+	now, ok := c.conf.FuncMap["Now"].(func() string)
+	if !ok {
+		t.Fatalf("the Now of the templates is not accelerated")
+	}
+
+	clock.Step(time.Minute)
+	want := start.Add(10 * time.Minute)
+	if got := now(); got != want.Format(time.RFC3339Nano) {
+		t.Errorf("Now() = %s, want %s", got, want.Format(time.RFC3339Nano))
+	}
+	if got := c.now(); !got.Time.Equal(want) {
+		t.Errorf("now() = %s, want %s", got, want)
+	}
+
+	c = &Controller{
+		conf: Config{
+			Clock: clock,
+		},
+	}
+	c.initTimeAcceleration()
+	if _, ok := c.conf.FuncMap["Now"]; ok {
+		t.Errorf("the Now of the templates is overridden in real time")
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// csrDefaultDuration is the duration of the certificates if the request does not set expirationSeconds,
+	// it is the same as the default of the kube-controller-manager.
+	csrDefaultDuration = 365 * 24 * time.Hour
+	// csrMinDuration is the minimum duration of the certificates allowed by the CertificateSigningRequest API.
+	csrMinDuration = 10 * time.Minute
+	// csrBackdate is the backdate of the certificates to tolerate the clock skew.
+	csrBackdate = 5 * time.Minute
+)
+
+// csrSigner signs the CertificateSigningRequests with a CA
+type csrSigner struct {
+	certFile string
+	keyFile  string
+
+	once sync.Once
+	cert *x509.Certificate
+	key  crypto.Signer
+	err  error
+}
+
+// newCSRSigner creates a csrSigner, the CA is loaded from the files on the first signing,
+// or generated if the files are not provided
+func newCSRSigner(certFile, keyFile string) (*csrSigner, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("csr signer cert file and key file must be provided together")
+	}
+	return &csrSigner{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}, nil
+}
+
+func (s *csrSigner) load() (*x509.Certificate, crypto.Signer, error) {
+	s.once.Do(func() {
+		if s.certFile == "" {
+			s.cert, s.key, s.err = generateCSRSignerCA()
+			return
+		}
+		s.cert, s.key, s.err = loadCSRSignerCA(s.certFile, s.keyFile)
+	})
+	return s.cert, s.key, s.err
+}
+
+func generateCSRSignerCA() (*x509.Certificate, crypto.Signer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	cert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "kwok-csr-signer"}, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA: %w", err)
+	}
+	return cert, key, nil
+}
+
+func loadCSRSignerCA(certFile, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	certs, err := certutil.CertsFromFile(certFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read csr signer cert: %w", err)
+	}
+	key, err := keyutil.PrivateKeyFromFile(keyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read csr signer key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("csr signer key %s is not a signer", keyFile)
+	}
+	return certs[0], signer, nil
+}
+
+// Sign signs the spec of the CertificateSigningRequest, and returns the base64 encoded certificate
+func (s *csrSigner) Sign(spec map[string]any) (string, error) {
+	caCert, caKey, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	var csrSpec certificatesv1.CertificateSigningRequestSpec
+	err = json.Unmarshal(data, &csrSpec)
+	if err != nil {
+		return "", err
+	}
+
+	block, _ := pem.Decode(csrSpec.Request)
+	if block == nil || block.Type != certutil.CertificateRequestBlockType {
+		return "", fmt.Errorf("failed to decode certificate request PEM")
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+	err = req.CheckSignature()
+	if err != nil {
+		return "", fmt.Errorf("invalid signature of certificate request: %w", err)
+	}
+
+	keyUsage, extKeyUsage, err := csrKeyUsages(csrSpec.Usages)
+	if err != nil {
+		return "", err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", err
+	}
+
+	duration := csrDefaultDuration
+	if csrSpec.ExpirationSeconds != nil {
+		duration = time.Duration(*csrSpec.ExpirationSeconds) * time.Second
+		if duration < csrMinDuration {
+			duration = csrMinDuration
+		}
+	}
+	now := time.Now()
+	notAfter := now.Add(duration)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               req.Subject,
+		DNSNames:              req.DNSNames,
+		IPAddresses:           req.IPAddresses,
+		EmailAddresses:        req.EmailAddresses,
+		URIs:                  req.URIs,
+		NotBefore:             now.Add(-csrBackdate),
+		NotAfter:              notAfter,
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           extKeyUsage,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, req.PublicKey, caKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  certutil.CertificateBlockType,
+		Bytes: der,
+	})
+	return base64.StdEncoding.EncodeToString(certPEM), nil
+}
+
+var (
+	csrKeyUsageDict = map[certificatesv1.KeyUsage]x509.KeyUsage{
+		certificatesv1.UsageSigning:           x509.KeyUsageDigitalSignature,
+		certificatesv1.UsageDigitalSignature:  x509.KeyUsageDigitalSignature,
+		certificatesv1.UsageContentCommitment: x509.KeyUsageContentCommitment,
+		certificatesv1.UsageKeyEncipherment:   x509.KeyUsageKeyEncipherment,
+		certificatesv1.UsageKeyAgreement:      x509.KeyUsageKeyAgreement,
+		certificatesv1.UsageDataEncipherment:  x509.KeyUsageDataEncipherment,
+		certificatesv1.UsageCertSign:          x509.KeyUsageCertSign,
+		certificatesv1.UsageCRLSign:           x509.KeyUsageCRLSign,
+		certificatesv1.UsageEncipherOnly:      x509.KeyUsageEncipherOnly,
+		certificatesv1.UsageDecipherOnly:      x509.KeyUsageDecipherOnly,
+	}
+	csrExtKeyUsageDict = map[certificatesv1.KeyUsage]x509.ExtKeyUsage{
+		certificatesv1.UsageAny:             x509.ExtKeyUsageAny,
+		certificatesv1.UsageServerAuth:      x509.ExtKeyUsageServerAuth,
+		certificatesv1.UsageClientAuth:      x509.ExtKeyUsageClientAuth,
+		certificatesv1.UsageCodeSigning:     x509.ExtKeyUsageCodeSigning,
+		certificatesv1.UsageEmailProtection: x509.ExtKeyUsageEmailProtection,
+		certificatesv1.UsageSMIME:           x509.ExtKeyUsageEmailProtection,
+		certificatesv1.UsageIPsecEndSystem:  x509.ExtKeyUsageIPSECEndSystem,
+		certificatesv1.UsageIPsecTunnel:     x509.ExtKeyUsageIPSECTunnel,
+		certificatesv1.UsageIPsecUser:       x509.ExtKeyUsageIPSECUser,
+		certificatesv1.UsageTimestamping:    x509.ExtKeyUsageTimeStamping,
+		certificatesv1.UsageOCSPSigning:     x509.ExtKeyUsageOCSPSigning,
+		certificatesv1.UsageMicrosoftSGC:    x509.ExtKeyUsageMicrosoftServerGatedCrypto,
+		certificatesv1.UsageNetscapeSGC:     x509.ExtKeyUsageNetscapeServerGatedCrypto,
+	}
+)
+
+// csrKeyUsages converts the usages of the CertificateSigningRequest to the x509 key usages
+func csrKeyUsages(usages []certificatesv1.KeyUsage) (x509.KeyUsage, []x509.ExtKeyUsage, error) {
+	var keyUsage x509.KeyUsage
+	var extKeyUsage []x509.ExtKeyUsage
+	for _, usage := range usages {
+		if ku, ok := csrKeyUsageDict[usage]; ok {
+			keyUsage |= ku
+		} else if eku, ok := csrExtKeyUsageDict[usage]; ok {
+			extKeyUsage = append(extKeyUsage, eku)
+		} else {
+			return 0, nil, fmt.Errorf("unknown key usage %q", usage)
+		}
+	}
+	return keyUsage, extKeyUsage, nil
+}
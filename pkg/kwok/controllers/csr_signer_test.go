@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newTestCSRRequest(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   "system:node:node-0",
+			Organization: []string{"system:nodes"},
+		},
+		DNSNames: []string{"node-0"},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: certutil.CertificateRequestBlockType, Bytes: der})
+}
+
+func Test_csrSigner_Sign(t *testing.T) {
+	signer, err := newCSRSigner("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := map[string]any{
+		"request":           base64.StdEncoding.EncodeToString(newTestCSRRequest(t)),
+		"signerName":        "kubernetes.io/kubelet-serving",
+		"expirationSeconds": int64(3600),
+		"usages":            []any{"digital signature", "server auth"},
+	}
+	got, err := signer.Sign(spec)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs, err := certutil.ParseCertsPEM(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := certs[0]
+
+	caCert, _, err := signer.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cert.CheckSignatureFrom(caCert)
+	if err != nil {
+		t.Errorf("CheckSignatureFrom() error = %v", err)
+	}
+	if cert.Subject.CommonName != "system:node:node-0" {
+		t.Errorf("CommonName = %q, want %q", cert.Subject.CommonName, "system:node:node-0")
+	}
+	if len(cert.DNSNames) != 1 || cert.DNSNames[0] != "node-0" {
+		t.Errorf("DNSNames = %v, want [node-0]", cert.DNSNames)
+	}
+	if cert.KeyUsage != x509.KeyUsageDigitalSignature {
+		t.Errorf("KeyUsage = %v, want %v", cert.KeyUsage, x509.KeyUsageDigitalSignature)
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageServerAuth {
+		t.Errorf("ExtKeyUsage = %v, want [%v]", cert.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+	}
+	if d := cert.NotAfter.Sub(cert.NotBefore); d != time.Hour+csrBackdate {
+		t.Errorf("duration = %v, want %v", d, time.Hour+csrBackdate)
+	}
+}
+
+func Test_csrSigner_SignInvalid(t *testing.T) {
+	signer, err := newCSRSigner("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		spec map[string]any
+	}{
+		{
+			name: "invalid request",
+			spec: map[string]any{
+				"request": base64.StdEncoding.EncodeToString([]byte("invalid")),
+			},
+		},
+		{
+			name: "unknown usage",
+			spec: map[string]any{
+				"request": base64.StdEncoding.EncodeToString(newTestCSRRequest(t)),
+				"usages":  []any{"unknown"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Sign(tt.spec)
+			if err == nil {
+				t.Errorf("Sign() error = nil, want error")
+			}
+		})
+	}
+}
+
+func Test_newCSRSigner(t *testing.T) {
+	_, err := newCSRSigner("ca.crt", "")
+	if err == nil {
+		t.Errorf("newCSRSigner() error = nil, want error")
+	}
+}
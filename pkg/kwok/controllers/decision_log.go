@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
+)
+
+// newDecision returns the decision of playing the stage of the job, nil if the decisions are not recorded.
+func newDecision[T metav1.Object](recorder *decisions.Recorder, kind string, job resourceStageJob[T]) *decisions.Decision {
+	if recorder == nil {
+		return nil
+	}
+	return &decisions.Decision{
+		DecidedTime: job.DecidedTime,
+		Kind:        kind,
+		Namespace:   job.Resource.GetNamespace(),
+		Name:        job.Resource.GetName(),
+		Stage:       job.Stage.Name(),
+		Delay:       metav1.Duration{Duration: job.Delay},
+		Retry:       atomic.LoadUint64(job.RetryCount),
+	}
+}
+
+// addDecisionPatch adds the applied patch to the decision.
+func addDecisionPatch(decision *decisions.Decision, patch *lifecycle.Patch) {
+	if decision == nil {
+		return
+	}
+	decision.Patches = append(decision.Patches, decisions.Patch{
+		Subresource: patch.Subresource,
+		Type:        string(patch.Type),
+		Data:        patch.Data,
+	})
+}
+
+// recordDecision records the decision once the stage is played.
+func recordDecision(ctx context.Context, recorder *decisions.Recorder, decision *decisions.Decision, now time.Time, err error) {
+	if decision == nil {
+		return
+	}
+	decision.Time = now
+	if err != nil {
+		decision.Error = err.Error()
+	}
+	err = recorder.Record(decision)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Error("Failed to record the decision", err,
+			"stage", decision.Stage,
+		)
+	}
+}
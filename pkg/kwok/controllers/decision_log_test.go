@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
+	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
+)
+
+func TestRecordDecision(t *testing.T) {
+	stage, err := lifecycle.NewStage(&internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-ready"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+			Selector:    &internalversion.StageSelector{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := resourceStageJob[*corev1.Pod]{
+		Resource:    &corev1.Pod{},
+		Stage:       stage,
+		RetryCount:  new(uint64),
+		DecidedTime: now,
+		Delay:       time.Second,
+	}
+	job.Resource.Namespace = "default"
+	job.Resource.Name = "pod-0"
+	*job.RetryCount = 1
+
+	if d := newDecision(nil, "Pod", job); d != nil {
+		t.Fatalf("expected no decision without a recorder, got %v", d)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	recorder := decisions.NewRecorder(buf)
+	decision := newDecision(recorder, "Pod", job)
+	addDecisionPatch(decision, &lifecycle.Patch{
+		Data:        []byte(`{"status":{"phase":"Running"}}`),
+		Type:        types.MergePatchType,
+		Subresource: "status",
+	})
+	recordDecision(context.Background(), recorder, decision, now.Add(2*time.Second), errors.New("conflict"))
+
+	var got []*decisions.Decision
+	err = decisions.Decode(buf, func(d *decisions.Decision) error {
+		got = append(got, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 decision, got %d", len(got))
+	}
+	d := got[0]
+	if d.Kind != "Pod" || d.Namespace != "default" || d.Name != "pod-0" || d.Stage != "pod-ready" {
+		t.Errorf("unexpected object or stage: %+v", d)
+	}
+	if d.Delay.Duration != time.Second || d.Retry != 1 || d.Error != "conflict" {
+		t.Errorf("unexpected delay, retry or error: %+v", d)
+	}
+	if !d.DecidedTime.Equal(now) || !d.Time.Equal(now.Add(2*time.Second)) {
+		t.Errorf("unexpected timestamps: %+v", d)
+	}
+	if len(d.Patches) != 1 || d.Patches[0].Subresource != "status" || d.Patches[0].Type != string(types.MergePatchType) {
+		t.Errorf("unexpected patches: %+v", d.Patches)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// loadBalancerIPAllocator allocates the ingress ip of load balancers, one ip per resource
+type loadBalancerIPAllocator struct {
+	mut      sync.Mutex
+	pool     *ipPool
+	assigned map[string]string
+}
+
+func newLoadBalancerIPAllocator(cidr string) (*loadBalancerIPAllocator, error) {
+	ipnet, err := parseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return &loadBalancerIPAllocator{
+		pool:     newIPPool(ipnet),
+		assigned: map[string]string{},
+	}, nil
+}
+
+// Get returns the ip assigned to the uid, or assigns a new one
+func (a *loadBalancerIPAllocator) Get(uid string) string {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if ip, ok := a.assigned[uid]; ok {
+		return ip
+	}
+	ip := a.pool.Get()
+	a.assigned[uid] = ip
+	return ip
+}
+
+// Use marks the ip as assigned to the uid
+func (a *loadBalancerIPAllocator) Use(uid string, ip string) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	a.pool.Use(ip)
+	a.assigned[uid] = ip
+}
+
+// Put releases the ip assigned to the uid
+func (a *loadBalancerIPAllocator) Put(uid string) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	ip, ok := a.assigned[uid]
+	if !ok {
+		return
+	}
+	delete(a.assigned, uid)
+	a.pool.Put(ip)
+}
+
+// loadBalancerIngressIPs returns the ips in the status.loadBalancer.ingress of the resource
+func loadBalancerIngressIPs(resource *unstructured.Unstructured) []string {
+	ingress, _, _ := unstructured.NestedSlice(resource.Object, "status", "loadBalancer", "ingress")
+	ips := make([]string, 0, len(ingress))
+	for _, item := range ingress {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		ip, _ := m["ip"].(string)
+		if ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
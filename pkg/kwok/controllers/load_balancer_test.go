@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_loadBalancerIPAllocator(t *testing.T) {
+	a, err := newLoadBalancerIPAllocator("10.1.0.1/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ip1 := a.Get("uid1")
+	if ip1 != "10.1.0.1" {
+		t.Errorf("Get(uid1) = %q, want %q", ip1, "10.1.0.1")
+	}
+	if got := a.Get("uid1"); got != ip1 {
+		t.Errorf("Get(uid1) again = %q, want %q", got, ip1)
+	}
+
+	a.Use("uid2", "10.1.0.2")
+	if got := a.Get("uid2"); got != "10.1.0.2" {
+		t.Errorf("Get(uid2) = %q, want %q", got, "10.1.0.2")
+	}
+	if got := a.Get("uid3"); got != "10.1.0.3" {
+		t.Errorf("Get(uid3) = %q, want %q", got, "10.1.0.3")
+	}
+
+	a.Put("uid1")
+	if got := a.Get("uid4"); got != ip1 {
+		t.Errorf("Get(uid4) = %q, want the recycled %q", got, ip1)
+	}
+}
+
+func Test_loadBalancerIngressIPs(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"loadBalancer": map[string]any{
+				"ingress": []any{
+					map[string]any{"ip": "10.1.0.1"},
+					map[string]any{"hostname": "lb.example.com"},
+				},
+			},
+		},
+	}}
+	want := []string{"10.1.0.1"}
+	if got := loadBalancerIngressIPs(resource); !reflect.DeepEqual(got, want) {
+		t.Errorf("loadBalancerIngressIPs() = %v, want %v", got, want)
+	}
+
+	if got := loadBalancerIngressIPs(&unstructured.Unstructured{Object: map[string]any{}}); len(got) != 0 {
+		t.Errorf("loadBalancerIngressIPs() = %v, want none", got)
+	}
+}
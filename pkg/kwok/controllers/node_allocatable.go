@@ -0,0 +1,340 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
+)
+
+// nodeAllocatable keeps the resources committed to the pods on each node,
+// the pods whose requests exceed the remaining allocatable of the node are rejected until there is room for them.
+type nodeAllocatable struct {
+	mut       sync.Mutex
+	committed map[string]map[log.ObjectRef]corev1.ResourceList
+	nodeOf    map[log.ObjectRef]string
+	rejected  map[log.ObjectRef]*corev1.Pod
+}
+
+// newNodeAllocatable returns a new nodeAllocatable, or nil if it is not enforced.
+func newNodeAllocatable(enforce bool) *nodeAllocatable {
+	if !enforce {
+		return nil
+	}
+	return &nodeAllocatable{
+		committed: map[string]map[log.ObjectRef]corev1.ResourceList{},
+		nodeOf:    map[log.ObjectRef]string{},
+		rejected:  map[log.ObjectRef]*corev1.Pod{},
+	}
+}
+
+// Commit commits the requests of the pod to the node without checking the allocatable,
+// e.g. for the pods which are already running.
+func (a *nodeAllocatable) Commit(ref log.ObjectRef, nodeName string, requests corev1.ResourceList) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	delete(a.rejected, ref)
+	a.commit(ref, nodeName, requests)
+}
+
+// Admit commits the requests of the pod to the node if they fit into the remaining allocatable,
+// otherwise the latest pod is kept as rejected with the insufficient resources,
+// and first is true the first time it is rejected.
+func (a *nodeAllocatable) Admit(pod *corev1.Pod, requests, allocatable corev1.ResourceList) (admitted bool, insufficient []insufficientResource, first bool) {
+	ref := log.KObj(pod)
+	nodeName := pod.Spec.NodeName
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if a.nodeOf[ref] == nodeName {
+		return true, nil, false
+	}
+
+	insufficient = a.fits(ref, nodeName, requests, allocatable)
+	if len(insufficient) == 0 {
+		delete(a.rejected, ref)
+		a.commit(ref, nodeName, requests)
+		return true, nil, false
+	}
+
+	_, rejected := a.rejected[ref]
+	a.rejected[ref] = pod
+	return false, insufficient, !rejected
+}
+
+// Release releases the requests of the pod and returns the rejected pods on the node to admit again.
+func (a *nodeAllocatable) Release(ref log.ObjectRef) []*corev1.Pod {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if _, ok := a.rejected[ref]; ok {
+		delete(a.rejected, ref)
+		return nil
+	}
+
+	nodeName, ok := a.nodeOf[ref]
+	if !ok {
+		return nil
+	}
+	a.release(ref)
+
+	var out []*corev1.Pod
+	for _, pod := range a.rejected {
+		if pod.Spec.NodeName == nodeName {
+			out = append(out, pod)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreationTimestamp.Before(&out[j].CreationTimestamp)
+	})
+	return out
+}
+
+func (a *nodeAllocatable) commit(ref log.ObjectRef, nodeName string, requests corev1.ResourceList) {
+	if old, ok := a.nodeOf[ref]; ok && old != nodeName {
+		a.release(ref)
+	}
+	pods, ok := a.committed[nodeName]
+	if !ok {
+		pods = map[log.ObjectRef]corev1.ResourceList{}
+		a.committed[nodeName] = pods
+	}
+	pods[ref] = requests
+	a.nodeOf[ref] = nodeName
+}
+
+func (a *nodeAllocatable) release(ref log.ObjectRef) {
+	nodeName := a.nodeOf[ref]
+	delete(a.nodeOf, ref)
+	pods := a.committed[nodeName]
+	delete(pods, ref)
+	if len(pods) == 0 {
+		delete(a.committed, nodeName)
+	}
+}
+
+// insufficientResource is a resource of the node which doesn't have enough room for the pod.
+type insufficientResource struct {
+	Name      corev1.ResourceName
+	Requested resource.Quantity
+	Used      resource.Quantity
+	Capacity  resource.Quantity
+}
+
+func (a *nodeAllocatable) fits(ref log.ObjectRef, nodeName string, requests, allocatable corev1.ResourceList) []insufficientResource {
+	used := corev1.ResourceList{}
+	for r, committed := range a.committed[nodeName] {
+		if r == ref {
+			continue
+		}
+		addResourceList(used, committed)
+	}
+
+	var insufficient []insufficientResource
+	for name, requested := range requests {
+		capacity, ok := allocatable[name]
+		if !ok {
+			// The resources the node doesn't report are not enforced, e.g. the ones of the device plugins.
+			continue
+		}
+		u := used[name]
+		total := u.DeepCopy()
+		total.Add(requested)
+		if total.Cmp(capacity) > 0 {
+			insufficient = append(insufficient, insufficientResource{
+				Name:      name,
+				Requested: requested,
+				Used:      u,
+				Capacity:  capacity,
+			})
+		}
+	}
+	sort.Slice(insufficient, func(i, j int) bool {
+		return insufficient[i].Name < insufficient[j].Name
+	})
+	return insufficient
+}
+
+// podRequests returns the resources requested by the pod the way the scheduler counts them,
+// the sum of the containers or the largest init container, whichever is larger, plus the overhead and the pod itself.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if value, ok := requests[name]; !ok || quantity.Cmp(value) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResourceList(requests, pod.Spec.Overhead)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return requests
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// admitAllocatable returns true if the requests of the pod fit into the remaining allocatable of its node,
+// the pods which are already running are committed without checking.
+func (c *PodController) admitAllocatable(ctx context.Context, pod *corev1.Pod) bool {
+	if c.allocatable == nil || pod.Spec.NodeName == "" {
+		return true
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded, corev1.PodFailed:
+		c.releaseAllocatable(ctx, pod)
+		return true
+	case corev1.PodRunning, corev1.PodUnknown:
+		c.allocatable.Commit(log.KObj(pod), pod.Spec.NodeName, podRequests(pod))
+		return true
+	}
+
+	if c.nodeCacheGetter == nil {
+		return true
+	}
+	node, ok := c.nodeCacheGetter.Get(pod.Spec.NodeName)
+	if !ok || node.Status.Allocatable == nil {
+		c.allocatable.Commit(log.KObj(pod), pod.Spec.NodeName, podRequests(pod))
+		return true
+	}
+
+	admitted, insufficient, first := c.allocatable.Admit(pod, podRequests(pod), node.Status.Allocatable)
+	if !admitted {
+		c.allocatableExceeded(ctx, pod, insufficient, first)
+	}
+	return admitted
+}
+
+// releaseAllocatable releases the requests of the pod and manages the rejected pods on the node which fit now
+func (c *PodController) releaseAllocatable(ctx context.Context, pod *corev1.Pod) {
+	if c.allocatable == nil {
+		return
+	}
+	for _, p := range c.allocatable.Release(log.KObj(pod)) {
+		if c.admitAllocatable(ctx, p) {
+			c.manage(ctx, p, informer.Added)
+		}
+	}
+}
+
+// allocatableExceeded marks the pod back to Pending the way the kubelet rejects the pods it can't admit,
+// the status is queued as a stage to the workers like the other changes of the pod, so the informer isn't blocked on it
+func (c *PodController) allocatableExceeded(ctx context.Context, pod *corev1.Pod, insufficient []insufficientResource, first bool) {
+	logger := log.FromContext(ctx)
+	reason := "OutOf" + string(insufficient[0].Name)
+	messages := make([]string, 0, len(insufficient))
+	for _, r := range insufficient {
+		messages = append(messages, fmt.Sprintf("%s, requested: %s, used: %s, capacity: %s",
+			r.Name, r.Requested.String(), r.Used.String(), r.Capacity.String()))
+	}
+	message := "Node didn't have enough resource: " + strings.Join(messages, "; ")
+
+	if first {
+		logger.Warn("Skip pod",
+			"reason", "node allocatable exceeded",
+			"pod", log.KObj(pod),
+			"node", pod.Spec.NodeName,
+			"message", message,
+		)
+		if c.recorder != nil {
+			c.recorder.Event(pod, corev1.EventTypeWarning, reason, message)
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodPending && pod.Status.Reason == reason && pod.Status.Message == message {
+		return
+	}
+
+	stage, err := allocatableExceededStage(reason, message)
+	if err != nil {
+		logger.Error("Failed to mark pod as pending", err,
+			"pod", log.KObj(pod),
+			"node", pod.Spec.NodeName,
+		)
+		return
+	}
+	c.addStageJob(ctx, resourceStageJob[*corev1.Pod]{
+		Resource:    pod,
+		Stage:       stage,
+		Key:         log.KObj(pod).String(),
+		RetryCount:  new(uint64),
+		DecidedTime: c.clock.Now(),
+	}, 0, 0)
+}
+
+// allocatableExceededStageName is the name of the stage which marks the pod back to Pending
+const allocatableExceededStageName = "pod-allocatable-exceeded"
+
+// allocatableExceededStage returns the stage which patches the status of the pod to Pending with the reason and the message
+func allocatableExceededStage(reason, message string) (*lifecycle.Stage, error) {
+	status, err := json.Marshal(corev1.PodStatus{
+		Phase:   corev1.PodPending,
+		Reason:  reason,
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lifecycle.NewStage(&internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: allocatableExceededStageName,
+		},
+		Spec: internalversion.StageSpec{
+			ResourceRef: internalversion.StageResourceRef{
+				APIGroup: "v1",
+				Kind:     "Pod",
+			},
+			Selector: &internalversion.StageSelector{},
+			Next: internalversion.StageNext{
+				Patches: []internalversion.StagePatch{
+					{
+						Subresource: "status",
+						Root:        "status",
+						Template:    string(status),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	"sigs.k8s.io/kwok/pkg/utils/queue"
+)
+
+func TestNodeAllocatable(t *testing.T) {
+	a := newNodeAllocatable(true)
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:  resource.MustParse("2"),
+		corev1.ResourcePods: resource.MustParse("110"),
+	}
+	newPod := func(name, cpu string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: "node0",
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse(cpu),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	admit := func(pod *corev1.Pod) (bool, []insufficientResource, bool) {
+		return a.Admit(pod, podRequests(pod), allocatable)
+	}
+
+	a.Commit(log.KRef("default", "running"), "node0", podRequests(newPod("running", "500m")))
+	if admitted, _, _ := admit(newPod("a", "1")); !admitted {
+		t.Fatalf("expected a to be admitted")
+	}
+	if admitted, _, _ := admit(newPod("a", "1")); !admitted {
+		t.Fatalf("expected a to stay admitted")
+	}
+
+	b := newPod("b", "1")
+	admitted, insufficient, first := admit(b)
+	if admitted || !first {
+		t.Fatalf("expected b to be rejected the first time, got admitted=%v first=%v", admitted, first)
+	}
+	if len(insufficient) != 1 || insufficient[0].Name != corev1.ResourceCPU ||
+		insufficient[0].Used.String() != "1500m" || insufficient[0].Capacity.String() != "2" {
+		t.Fatalf("unexpected insufficient resources %+v", insufficient)
+	}
+	if admitted, _, first := admit(b); admitted || first {
+		t.Fatalf("expected b to stay rejected, got admitted=%v first=%v", admitted, first)
+	}
+
+	if got := a.Release(log.KRef("default", "unknown")); got != nil {
+		t.Fatalf("expected nothing to admit again, got %v", got)
+	}
+	got := a.Release(log.KRef("default", "running"))
+	if len(got) != 1 || got[0].Name != "b" {
+		t.Fatalf("expected b to admit again, got %v", got)
+	}
+	if admitted, _, _ := admit(got[0]); !admitted {
+		t.Fatalf("expected b to be admitted")
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("2"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				},
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("500m"),
+						},
+					},
+				},
+			},
+			Overhead: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("100m"),
+			},
+		},
+	}
+
+	got := podRequests(pod)
+	want := map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    "2100m",
+		corev1.ResourceMemory: "128Mi",
+		corev1.ResourcePods:   "1",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for name, value := range want {
+		q := got[name]
+		if q.String() != value {
+			t.Errorf("expected %s to be %s, got %s", name, value, q.String())
+		}
+	}
+}
+
+func TestAllocatableExceeded(t *testing.T) {
+	// The controller has no client, the status must be queued to the workers instead of updated in place
+	c := &PodController{
+		clock:      clock.RealClock{},
+		delayQueue: queue.NewWeightDelayingQueue[resourceStageJob[*corev1.Pod]](clock.RealClock{}),
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node0"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	insufficient := []insufficientResource{
+		{
+			Name:      corev1.ResourceCPU,
+			Requested: resource.MustParse("1"),
+			Used:      resource.MustParse("1500m"),
+			Capacity:  resource.MustParse("2"),
+		},
+	}
+	c.allocatableExceeded(context.Background(), pod, insufficient, true)
+
+	job, ok := c.delayQueueMapping.Load("default/pod")
+	if !ok {
+		t.Fatalf("expected the status of the pod to be queued")
+	}
+	if job.Stage.Name() != allocatableExceededStageName {
+		t.Fatalf("expected stage %s, got %s", allocatableExceededStageName, job.Stage.Name())
+	}
+	patches, err := job.Stage.Next().Patches(job.Resource, gotpl.NewRenderer(nil))
+	if err != nil {
+		t.Fatalf("failed to get patches: %v", err)
+	}
+	if len(patches) != 1 || patches[0].Subresource != "status" {
+		t.Fatalf("expected one patch of the status, got %+v", patches)
+	}
+	var got struct {
+		Status corev1.PodStatus `json:"status"`
+	}
+	if err := json.Unmarshal(patches[0].Data, &got); err != nil {
+		t.Fatalf("failed to unmarshal patch %s: %v", patches[0].Data, err)
+	}
+	want := corev1.PodStatus{
+		Phase:   corev1.PodPending,
+		Reason:  "OutOfcpu",
+		Message: "Node didn't have enough resource: cpu, requested: 1, used: 1500m, capacity: 2",
+	}
+	if got.Status.Phase != want.Phase || got.Status.Reason != want.Reason || got.Status.Message != want.Message {
+		t.Fatalf("expected status %+v, got %+v", want, got.Status)
+	}
+
+	// The pod already marked doesn't need to be queued again
+	c.delayQueueMapping.Delete("default/pod")
+	pod.Status = want
+	c.allocatableExceeded(context.Background(), pod, insufficient, false)
+	if _, ok := c.delayQueueMapping.Load("default/pod"); ok {
+		t.Fatalf("expected the status of the marked pod not to be queued again")
+	}
+}
@@ -32,6 +32,7 @@ import (
 	netutils "k8s.io/utils/net"
 
 	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/gotpl"
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -53,6 +55,7 @@ type NodeController struct {
 	disregardStatusWithLabelSelector      labels.Selector
 	onNodeManagedFunc                     func(nodeName string)
 	onNodeUnmanagedFunc                   func(nodeName string)
+	onNodeDeletedFunc                     func(nodeName string)
 	nodesSets                             maps.SyncMap[string, *NodeInfo]
 	renderer                              gotpl.Renderer
 	preprocessChan                        chan *corev1.Node
@@ -62,8 +65,14 @@ type NodeController struct {
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Node]]
 	backoff                               wait.Backoff
 	recorder                              record.EventRecorder
+	decisionRecorder                      *decisions.Recorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
+	quota                                 *quota[*corev1.Node]
+	paused                                *pausedObjects[*corev1.Node]
+	timeAcceleration                      float64
+	stageConcurrency                      *stageConcurrency
+	attempts                              rand.Attempts
 }
 
 // NodeControllerConfig is the configuration for the NodeController
@@ -72,6 +81,7 @@ type NodeControllerConfig struct {
 	TypedClient                           kubernetes.Interface
 	OnNodeManagedFunc                     func(nodeName string)
 	OnNodeUnmanagedFunc                   func(nodeName string)
+	OnNodeDeletedFunc                     func(nodeName string)
 	DisregardStatusWithAnnotationSelector string
 	DisregardStatusWithLabelSelector      string
 	NodeIP                                string
@@ -81,8 +91,12 @@ type NodeControllerConfig struct {
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	DecisionRecorder                      *decisions.Recorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
+	MaxManagedNodes                       uint
+	TimeAcceleration                      float64
+	Pauses                                *pauses
 }
 
 // NodeInfo is the collection of necessary node information
@@ -117,6 +131,7 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 		disregardStatusWithLabelSelector:      disregardStatusWithLabelSelector,
 		onNodeManagedFunc:                     conf.OnNodeManagedFunc,
 		onNodeUnmanagedFunc:                   conf.OnNodeUnmanagedFunc,
+		onNodeDeletedFunc:                     conf.OnNodeDeletedFunc,
 		nodeIP:                                conf.NodeIP,
 		nodeName:                              conf.NodeName,
 		nodePort:                              conf.NodePort,
@@ -126,8 +141,13 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Node),
 		recorder:                              conf.Recorder,
+		decisionRecorder:                      conf.DecisionRecorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
+		quota:                                 newQuota[*corev1.Node]("nodes", conf.MaxManagedNodes, 0),
+		paused:                                newPausedObjects[*corev1.Node]("nodes", nodeRef, conf.Pauses),
+		timeAcceleration:                      conf.TimeAcceleration,
+		stageConcurrency:                      newStageConcurrency(),
 	}
 
 	funcMap := maps.Merge(gotpl.FuncMap{
@@ -184,23 +204,21 @@ loop:
 			case informer.Added, informer.Modified, informer.Sync:
 				node := event.Object
 				if c.need(node) {
-					c.putNodeInfo(node)
-					if c.readOnly(node.Name) {
-						logger.Debug("Skip node",
-							"reason", "read only",
-							"event", event.Type,
-							"node", node.Name,
-						)
-					} else {
-						c.preprocessChan <- node
-					}
-
-					if c.onNodeManagedFunc != nil && event.Type != informer.Modified {
-						c.onNodeManagedFunc(node.Name)
+					admitted, exceeded := c.quota.Admit(log.KObj(node), node)
+					if !admitted {
+						if exceeded {
+							c.quotaExceeded(ctx, node)
+						}
+						continue
 					}
+					c.manage(ctx, node, event.Type)
 				}
 			case informer.Deleted:
 				node := event.Object
+				c.paused.Release(log.KObj(node))
+				for _, n := range c.quota.Release(log.KObj(node)) {
+					c.manage(ctx, n, informer.Added)
+				}
 				if _, has := c.nodesSets.Load(node.Name); has {
 					c.deleteNodeInfo(node)
 
@@ -210,12 +228,22 @@ loop:
 					if ok {
 						c.delayQueue.Cancel(resourceJob)
 					}
+					c.stageConcurrency.Forget(key)
+					c.attempts.Forget(key)
+
+					if c.onNodeDeletedFunc != nil {
+						c.onNodeDeletedFunc(node.Name)
+					}
 				}
 
 				if c.onNodeUnmanagedFunc != nil {
 					c.onNodeUnmanagedFunc(node.Name)
 				}
 			}
+		case <-c.paused.Changed():
+			for _, n := range c.paused.Resumed() {
+				c.manage(ctx, n, informer.Sync)
+			}
 		case <-ctx.Done():
 			break loop
 		}
@@ -223,6 +251,46 @@ loop:
 	logger.Info("Stop watch nodes")
 }
 
+// manage starts to manage the node
+func (c *NodeController) manage(ctx context.Context, node *corev1.Node, eventType informer.EventType) {
+	c.putNodeInfo(node)
+	if c.readOnly(node.Name) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Skip node",
+			"reason", "read only",
+			"event", eventType,
+			"node", node.Name,
+		)
+	} else if c.paused.Hold(node) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Skip node",
+			"reason", "paused",
+			"event", eventType,
+			"node", node.Name,
+		)
+	} else {
+		c.preprocessChan <- node
+	}
+
+	if c.onNodeManagedFunc != nil && eventType != informer.Modified {
+		c.onNodeManagedFunc(node.Name)
+	}
+}
+
+// quotaExceeded reports the node waits for the quota
+func (c *NodeController) quotaExceeded(ctx context.Context, node *corev1.Node) {
+	logger := log.FromContext(ctx)
+	logger.Warn("Skip node",
+		"reason", "quota exceeded",
+		"node", node.Name,
+		"maxManagedNodes", c.quota.max,
+	)
+	if c.recorder != nil {
+		c.recorder.Eventf(node, corev1.EventTypeWarning, "QuotaExceeded",
+			"Node is waiting to be managed, the number of managed nodes reached the limit of %d", c.quota.max)
+	}
+}
+
 // deleteResource deletes a node
 func (c *NodeController) deleteResource(ctx context.Context, node *corev1.Node) error {
 	logger := log.FromContext(ctx)
@@ -284,6 +352,8 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 	}
 
 	lc := c.lifecycle.Get()
+	attempt := c.attempts.Next(key)
+	ctx = lifecycle.NewAttemptContext(ctx, attempt)
 	stage, err := lc.Match(ctx, node.Labels, node.Annotations, data)
 	if err != nil {
 		return fmt.Errorf("stage match: %w", err)
@@ -294,9 +364,20 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 		)
 		return nil
 	}
+	stage, err = stage.Decide(ctx, node)
+	if err != nil {
+		return fmt.Errorf("stage decide: %w", err)
+	}
+	if stage == nil {
+		logger.Debug("Skip node",
+			"reason", "skipped by the decision of the stage",
+		)
+		return nil
+	}
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
+	delay = accelerate(delay, c.timeAcceleration)
 
 	if delay != 0 {
 		stageName := stage.Name()
@@ -306,15 +387,23 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 		)
 	}
 
+	slot, limit := concurrencySlotOf(stage, node.Name)
 	item := resourceStageJob[*corev1.Node]{
-		Resource:   node,
-		Stage:      stage,
-		Key:        key,
-		RetryCount: new(uint64),
+		Resource:    node,
+		Stage:       stage,
+		Key:         key,
+		Slot:        slot,
+		RetryCount:  new(uint64),
+		Attempt:     attempt,
+		DecidedTime: now,
+		Delay:       delay,
 	}
 	// we add a normal(fresh) stage job with weight 0,
 	// resulting in that it will always be processed with high priority compared to those retry ones
-	c.addStageJob(ctx, item, delay, 0)
+	// the job is added once the object holds a slot of the stage if the stage has a concurrency limit
+	c.stageConcurrency.Acquire(key, item.Slot, limit, func() {
+		c.addStageJob(ctx, item, delay, 0)
+	})
 	return nil
 }
 
@@ -328,7 +417,20 @@ func (c *NodeController) playStageWorker(ctx context.Context) {
 			return
 		}
 		c.delayQueueMapping.Delete(node.Key)
-		needRetry, err := c.playStage(ctx, node.Resource, node.Stage)
+		if c.paused.Keep(node.Resource) {
+			logger.Debug("Skip node",
+				"reason", "paused",
+				"node", node.Key,
+				"stage", node.Stage.Name(),
+			)
+			c.stageConcurrency.Release(node.Key, node.Slot)
+			continue
+		}
+		decision := newDecision(c.decisionRecorder, "Node", node)
+		needRetry, err := c.playStage(ctx, node.Resource, node.Stage, decision)
+		now := c.clock.Now()
+		recordDecision(ctx, c.decisionRecorder, decision, now, err)
+		observeStageLatency("Node", node, now)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"node", node.Key,
@@ -344,15 +446,17 @@ func (c *NodeController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(node.Key, node.Attempt, retryCount, c.backoff)
 			c.addStageJob(ctx, node, retryDelay, 1)
+		} else {
+			c.stageConcurrency.Release(node.Key, node.Slot)
 		}
 	}
 }
 
 // playStage plays the stage.
 // The returned boolean indicates whether the applying action needs to be retried.
-func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage *lifecycle.Stage) (bool, error) {
+func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage *lifecycle.Stage, decision *decisions.Decision) (bool, error) {
 	next := stage.Next()
 	logger := log.FromContext(ctx)
 	logger = logger.With(
@@ -379,6 +483,7 @@ func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage
 		return false, fmt.Errorf("failed to get finalizers for node %s: %w", node.Name, err)
 	}
 	if patch != nil {
+		addDecisionPatch(decision, patch)
 		result, err = c.patchResource(ctx, node, patch)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to patch the finalizer of node %s: %w", node.Name, err)
@@ -386,6 +491,9 @@ func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage
 	}
 
 	if next.Delete() {
+		if decision != nil {
+			decision.Delete = true
+		}
 		err = c.deleteResource(ctx, node)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to delete node %s: %w", node.Name, err)
@@ -407,6 +515,7 @@ func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage
 					"reason", "do not need to modify",
 				)
 			} else {
+				addDecisionPatch(decision, patch)
 				result, err = c.patchResource(ctx, node, patch)
 				if err != nil {
 					return shouldRetry(err), fmt.Errorf("failed to patch node %s: %w", node.Name, err)
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	coordinationv1 "k8s.io/api/coordination/v1"
@@ -32,9 +33,23 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
+const (
+	// NodeLeaseRenewIntervalAnnotation is the annotation of the node to override the interval of renewing its lease.
+	NodeLeaseRenewIntervalAnnotation = "node-lease.kwok.x-k8s.io/renew-interval"
+	// NodeLeaseStopAnnotation is the annotation of the node to stop renewing its lease, so the lease goes stale.
+	NodeLeaseStopAnnotation = "node-lease.kwok.x-k8s.io/stop"
+	// NodeLeaseFlapPeriodAnnotation is the annotation of the node to alternate between renewing and not renewing its lease
+	// at the given period.
+	NodeLeaseFlapPeriodAnnotation = "node-lease.kwok.x-k8s.io/flap-period"
+	// NodeLeaseClockSkewAnnotation is the annotation of the node to shift the renew time written to its lease,
+	// as if the clock of the node were skewed.
+	NodeLeaseClockSkewAnnotation = "node-lease.kwok.x-k8s.io/clock-skew"
+)
+
 // NodeLeaseController is responsible for creating and renewing a lease object
 type NodeLeaseController struct {
 	typedClient          clientset.Interface
@@ -44,13 +59,15 @@ type NodeLeaseController struct {
 	renewIntervalJitter  float64
 	clock                clock.Clock
 
-	getLease func(nodeName string) (*coordinationv1.Lease, bool)
+	getLease           func(nodeName string) (*coordinationv1.Lease, bool)
+	getNodeAnnotations func(nodeName string) map[string]string
 
 	// mutateLeaseFunc allows customizing a lease object
 	mutateLeaseFunc func(*coordinationv1.Lease) error
 
 	delayQueue   queue.WeightDelayingQueue[string]
 	holdLeaseSet maps.SyncMap[string, struct{}]
+	renewals     rand.Attempts
 
 	holderIdentity    string
 	onNodeManagedFunc func(nodeName string)
@@ -64,6 +81,7 @@ type NodeLeaseControllerConfig struct {
 	LeaseDurationSeconds uint
 	LeaseParallelism     uint
 	GetLease             func(nodeName string) (*coordinationv1.Lease, bool)
+	GetNodeAnnotations   func(nodeName string) map[string]string
 	RenewInterval        time.Duration
 	RenewIntervalJitter  float64
 	MutateLeaseFunc      func(*coordinationv1.Lease) error
@@ -86,6 +104,7 @@ func NewNodeLeaseController(conf NodeLeaseControllerConfig) (*NodeLeaseControlle
 		leaseDurationSeconds: conf.LeaseDurationSeconds,
 		leaseParallelism:     conf.LeaseParallelism,
 		getLease:             conf.GetLease,
+		getNodeAnnotations:   conf.GetNodeAnnotations,
 		renewInterval:        conf.RenewInterval,
 		renewIntervalJitter:  conf.RenewIntervalJitter,
 		mutateLeaseFunc:      conf.MutateLeaseFunc,
@@ -117,9 +136,26 @@ func (c *NodeLeaseController) syncWorker(ctx context.Context) {
 			continue
 		}
 
-		dur := c.interval()
+		behavior, err := c.behavior(nodeName)
+		if err != nil {
+			logger.Warn("Invalid node lease behavior, ignore it",
+				"node", nodeName,
+				"err", err,
+			)
+		}
 
-		lease, err := c.sync(ctx, nodeName)
+		dur := c.interval(nodeName, behavior)
+
+		now := c.clock.Now()
+		if behavior.paused(now) {
+			logger.Debug("Skip renewing lease",
+				"node", nodeName,
+			)
+			c.delayQueue.AddWeightAfter(nodeName, 1, dur)
+			continue
+		}
+
+		lease, err := c.sync(ctx, nodeName, behavior.clockSkew)
 		if err != nil {
 			logger.Error("Failed to sync lease", err,
 				"node", nodeName,
@@ -134,16 +170,33 @@ func (c *NodeLeaseController) syncWorker(ctx context.Context) {
 			continue
 		}
 
-		now := c.clock.Now()
-		expireDuration := expireTime.Sub(now)
+		now = c.clock.Now()
+		// The lease is renewed by the skewed clock, so the expiration is also measured by it.
+		expireDuration := expireTime.Sub(now.Add(behavior.clockSkew))
 		hold := tryAcquireOrRenew(lease, c.holderIdentity, now)
 		nextTry := nextTryDuration(dur, expireDuration, hold)
 		c.delayQueue.AddWeightAfter(nodeName, 2, nextTry)
 	}
 }
 
-func (c *NodeLeaseController) interval() time.Duration {
-	return wait.Jitter(c.renewInterval, c.renewIntervalJitter)
+// interval returns the interval of the next renewal of the lease of the node,
+// the jitter is derived from the name of the node and the count of its renewals, so it is reproducible with the seed
+func (c *NodeLeaseController) interval(nodeName string, behavior nodeLeaseBehavior) time.Duration {
+	renewInterval := c.renewInterval
+	if behavior.renewInterval > 0 {
+		renewInterval = behavior.renewInterval
+	}
+	renewal := c.renewals.Next(nodeName)
+	source := rand.New(nodeName, "lease", strconv.FormatUint(renewal, 10))
+	return wait.JitterWithSource(source, renewInterval, c.renewIntervalJitter)
+}
+
+// behavior returns the lease behavior of the node
+func (c *NodeLeaseController) behavior(nodeName string) (nodeLeaseBehavior, error) {
+	if c.getNodeAnnotations == nil {
+		return nodeLeaseBehavior{}, nil
+	}
+	return parseNodeLeaseBehavior(c.getNodeAnnotations(nodeName))
 }
 
 // TryHold tries to hold a lease for the NodeLeaseController
@@ -158,6 +211,7 @@ func (c *NodeLeaseController) TryHold(name string) {
 func (c *NodeLeaseController) ReleaseHold(name string) {
 	_ = c.delayQueue.Cancel(name)
 	c.holdLeaseSet.Delete(name)
+	c.renewals.Forget(name)
 }
 
 // Held returns true if the NodeLeaseController holds the lease
@@ -171,7 +225,7 @@ func (c *NodeLeaseController) Held(name string) bool {
 }
 
 // sync syncs a lease for a node
-func (c *NodeLeaseController) sync(ctx context.Context, nodeName string) (lease *coordinationv1.Lease, err error) {
+func (c *NodeLeaseController) sync(ctx context.Context, nodeName string, clockSkew time.Duration) (lease *coordinationv1.Lease, err error) {
 	logger := log.FromContext(ctx)
 	logger = logger.With("node", nodeName)
 
@@ -182,7 +236,7 @@ func (c *NodeLeaseController) sync(ctx context.Context, nodeName string) (lease
 			return nil, nil
 		}
 		logger.Info("Syncing lease")
-		lease, err := c.renewLease(ctx, lease)
+		lease, err := c.renewLease(ctx, lease, clockSkew)
 		if err != nil {
 			return nil, fmt.Errorf("failed to update lease using lease: %w", err)
 		}
@@ -192,7 +246,7 @@ func (c *NodeLeaseController) sync(ctx context.Context, nodeName string) (lease
 	}
 
 	logger.Info("Creating lease")
-	lease, err = c.ensureLease(ctx, nodeName)
+	lease, err = c.ensureLease(ctx, nodeName, clockSkew)
 	if err != nil {
 		if apierrors.IsAlreadyExists(err) {
 			return nil, fmt.Errorf("failed to create lease, lease already exists: %w", err)
@@ -202,7 +256,7 @@ func (c *NodeLeaseController) sync(ctx context.Context, nodeName string) (lease
 		for apierrors.IsNotFound(err) {
 			logger.Error("lease namespace not found, retrying in 1 second", err)
 			c.clock.Sleep(1 * time.Second)
-			lease, err = c.ensureLease(ctx, nodeName)
+			lease, err = c.ensureLease(ctx, nodeName, clockSkew)
 		}
 		if err != nil {
 			return lease, fmt.Errorf("failed to create lease after retrying: %w", err)
@@ -222,7 +276,7 @@ func (c *NodeLeaseController) onNodeManaged(nodeName string) {
 }
 
 // ensureLease creates a lease if it does not exist
-func (c *NodeLeaseController) ensureLease(ctx context.Context, leaseName string) (*coordinationv1.Lease, error) {
+func (c *NodeLeaseController) ensureLease(ctx context.Context, leaseName string, clockSkew time.Duration) (*coordinationv1.Lease, error) {
 	lease := &coordinationv1.Lease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      leaseName,
@@ -231,7 +285,7 @@ func (c *NodeLeaseController) ensureLease(ctx context.Context, leaseName string)
 		Spec: coordinationv1.LeaseSpec{
 			HolderIdentity:       &c.holderIdentity,
 			LeaseDurationSeconds: format.Ptr(int32(c.leaseDurationSeconds)),
-			RenewTime:            format.Ptr(metav1.NewMicroTime(c.clock.Now())),
+			RenewTime:            format.Ptr(metav1.NewMicroTime(c.clock.Now().Add(clockSkew))),
 		},
 	}
 	if c.mutateLeaseFunc != nil {
@@ -249,7 +303,7 @@ func (c *NodeLeaseController) ensureLease(ctx context.Context, leaseName string)
 }
 
 // renewLease attempts to update the lease for maxUpdateRetries, call this once you're sure the lease has been created
-func (c *NodeLeaseController) renewLease(ctx context.Context, base *coordinationv1.Lease) (*coordinationv1.Lease, error) {
+func (c *NodeLeaseController) renewLease(ctx context.Context, base *coordinationv1.Lease, clockSkew time.Duration) (*coordinationv1.Lease, error) {
 	lease := base.DeepCopy()
 
 	transitions := format.ElemOrDefault(lease.Spec.HolderIdentity) != c.holderIdentity
@@ -258,7 +312,7 @@ func (c *NodeLeaseController) renewLease(ctx context.Context, base *coordination
 		lease.Spec.LeaseDurationSeconds = format.Ptr(int32(c.leaseDurationSeconds))
 		lease.Spec.LeaseTransitions = format.Ptr(format.ElemOrDefault(lease.Spec.LeaseTransitions) + 1)
 	}
-	lease.Spec.RenewTime = format.Ptr(metav1.NewMicroTime(c.clock.Now()))
+	lease.Spec.RenewTime = format.Ptr(metav1.NewMicroTime(c.clock.Now().Add(clockSkew)))
 
 	if c.mutateLeaseFunc != nil {
 		err := c.mutateLeaseFunc(lease)
@@ -274,6 +328,58 @@ func (c *NodeLeaseController) renewLease(ctx context.Context, base *coordination
 	return lease, nil
 }
 
+// nodeLeaseBehavior is the behavior of renewing the lease of a node
+type nodeLeaseBehavior struct {
+	renewInterval time.Duration
+	stop          bool
+	flapPeriod    time.Duration
+	clockSkew     time.Duration
+}
+
+// paused returns true if the lease should not be renewed at the given time
+func (b nodeLeaseBehavior) paused(now time.Time) bool {
+	if b.stop {
+		return true
+	}
+	if b.flapPeriod <= 0 {
+		return false
+	}
+	// Renew in the even periods and pause in the odd periods,
+	// so that all instances of kwok agree on the phase.
+	return (now.UnixNano()/int64(b.flapPeriod))%2 == 1
+}
+
+// parseNodeLeaseBehavior parses the behavior of renewing the lease from the annotations of the node
+func parseNodeLeaseBehavior(annotations map[string]string) (nodeLeaseBehavior, error) {
+	var behavior nodeLeaseBehavior
+	var err error
+	if v, ok := annotations[NodeLeaseRenewIntervalAnnotation]; ok {
+		behavior.renewInterval, err = time.ParseDuration(v)
+		if err != nil {
+			return nodeLeaseBehavior{}, fmt.Errorf("failed to parse %s: %w", NodeLeaseRenewIntervalAnnotation, err)
+		}
+	}
+	if v, ok := annotations[NodeLeaseStopAnnotation]; ok {
+		behavior.stop, err = strconv.ParseBool(v)
+		if err != nil {
+			return nodeLeaseBehavior{}, fmt.Errorf("failed to parse %s: %w", NodeLeaseStopAnnotation, err)
+		}
+	}
+	if v, ok := annotations[NodeLeaseFlapPeriodAnnotation]; ok {
+		behavior.flapPeriod, err = time.ParseDuration(v)
+		if err != nil {
+			return nodeLeaseBehavior{}, fmt.Errorf("failed to parse %s: %w", NodeLeaseFlapPeriodAnnotation, err)
+		}
+	}
+	if v, ok := annotations[NodeLeaseClockSkewAnnotation]; ok {
+		behavior.clockSkew, err = time.ParseDuration(v)
+		if err != nil {
+			return nodeLeaseBehavior{}, fmt.Errorf("failed to parse %s: %w", NodeLeaseClockSkewAnnotation, err)
+		}
+	}
+	return behavior, nil
+}
+
 // setNodeOwnerFunc helps construct a mutateLeaseFunc which sets a node OwnerReference to the given lease object
 // https://github.com/kubernetes/kubernetes/blob/1f22a173d9538e01c92529d02e4c95f77f5ea823/pkg/kubelet/util/nodelease.go#L32
 func setNodeOwnerFunc(nodeOwnerFunc func(nodeName string) []metav1.OwnerReference) func(lease *coordinationv1.Lease) error {
@@ -315,3 +315,97 @@ func TestExpireTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseNodeLeaseBehavior(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        nodeLeaseBehavior
+		wantErr     bool
+	}{
+		{
+			name: "empty",
+			want: nodeLeaseBehavior{},
+		},
+		{
+			name: "all",
+			annotations: map[string]string{
+				NodeLeaseRenewIntervalAnnotation: "5s",
+				NodeLeaseStopAnnotation:          "true",
+				NodeLeaseFlapPeriodAnnotation:    "1m",
+				NodeLeaseClockSkewAnnotation:     "-30s",
+			},
+			want: nodeLeaseBehavior{
+				renewInterval: 5 * time.Second,
+				stop:          true,
+				flapPeriod:    time.Minute,
+				clockSkew:     -30 * time.Second,
+			},
+		},
+		{
+			name: "invalid stop",
+			annotations: map[string]string{
+				NodeLeaseStopAnnotation: "yes",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid clock skew",
+			annotations: map[string]string{
+				NodeLeaseClockSkewAnnotation: "30",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodeLeaseBehavior(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodeLeaseBehavior() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNodeLeaseBehavior() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nodeLeaseBehavior_paused(t *testing.T) {
+	tests := []struct {
+		name     string
+		behavior nodeLeaseBehavior
+		now      time.Time
+		want     bool
+	}{
+		{
+			name: "default",
+			now:  time.Unix(0, 0),
+			want: false,
+		},
+		{
+			name:     "stop",
+			behavior: nodeLeaseBehavior{stop: true},
+			now:      time.Unix(0, 0),
+			want:     true,
+		},
+		{
+			name:     "flap renewing",
+			behavior: nodeLeaseBehavior{flapPeriod: time.Minute},
+			now:      time.Unix(130, 0),
+			want:     false,
+		},
+		{
+			name:     "flap paused",
+			behavior: nodeLeaseBehavior{flapPeriod: time.Minute},
+			now:      time.Unix(70, 0),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.behavior.paused(tt.now); got != tt.want {
+				t.Errorf("paused() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
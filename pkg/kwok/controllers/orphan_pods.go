@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/queue"
+)
+
+const (
+	// nodeLostReason is the reason of the pods marked as Failed after their node is deleted,
+	// which is the same as the one used by the node lifecycle controller.
+	nodeLostReason = "NodeLost"
+)
+
+// initOrphanPods starts to apply the orphan pod policy to the pods on the managed nodes which are deleted,
+// the pods are left for the pod garbage collector of kube-controller-manager if the policy is ignore.
+func (c *Controller) initOrphanPods(ctx context.Context) {
+	switch c.conf.OrphanPodPolicy {
+	case internalversion.OrphanPodPolicyDelete, internalversion.OrphanPodPolicyFail:
+	default:
+		return
+	}
+
+	var clk queue.Clock = c.conf.Clock
+	if c.conf.Clock == nil {
+		clk = clock.RealClock{}
+	}
+	c.orphanPodsQueue = queue.NewDelayingQueue[string](clk)
+	go c.orphanPodsWorker(ctx)
+}
+
+// onNodeDeleted schedules the orphan pod policy for the pods on the deleted node.
+func (c *Controller) onNodeDeleted(nodeName string) {
+	if c.orphanPodsQueue == nil {
+		return
+	}
+	c.orphanPodsQueue.AddAfter(nodeName, c.conf.OrphanPodDelay)
+}
+
+func (c *Controller) orphanPodsWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName, ok := c.orphanPodsQueue.GetOrWaitWithDone(ctx.Done())
+		if !ok {
+			return
+		}
+		if _, ok := c.nodeCacheGetter.Get(nodeName); ok {
+			logger.Debug("Skip orphan pods",
+				"reason", "node created again",
+				"node", nodeName,
+			)
+			continue
+		}
+		err := c.handleOrphanPods(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to handle orphan pods", err, "node", nodeName)
+		}
+	}
+}
+
+// handleOrphanPods applies the orphan pod policy to the pods on the deleted node.
+func (c *Controller) handleOrphanPods(ctx context.Context, nodeName string) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("node", nodeName)
+
+	cli := c.conf.TypedClient.CoreV1()
+	list, err := cli.Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+
+	var errs []error
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		switch c.conf.OrphanPodPolicy {
+		case internalversion.OrphanPodPolicyDelete:
+			err = cli.Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOpt)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					errs = append(errs, fmt.Errorf("delete pod %s: %w", log.KObj(pod), err))
+				}
+				continue
+			}
+			logger.Info("Deleted orphan pod", "pod", log.KObj(pod))
+		case internalversion.OrphanPodPolicyFail:
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			_, err = cli.Pods(pod.Namespace).UpdateStatus(ctx, failOrphanPod(pod, nodeName, c.now()), metav1.UpdateOptions{})
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					errs = append(errs, fmt.Errorf("fail pod %s: %w", log.KObj(pod), err))
+				}
+				continue
+			}
+			logger.Info("Failed orphan pod", "pod", log.KObj(pod))
+			if c.recorder != nil {
+				c.recorder.Eventf(pod, corev1.EventTypeWarning, nodeLostReason, "Node %s which was running the pod was deleted", nodeName)
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%d of %d orphan pods: %w", len(errs), len(list.Items), errs[0])
+	}
+	return nil
+}
+
+// failOrphanPod returns the pod marked as Failed the way the node lifecycle controller does,
+// the running containers are terminated and the pod is no longer ready.
+func failOrphanPod(pod *corev1.Pod, nodeName string, now metav1.Time) *corev1.Pod {
+	pod = pod.DeepCopy()
+	pod.Status.Phase = corev1.PodFailed
+	pod.Status.Reason = nodeLostReason
+	pod.Status.Message = fmt.Sprintf("Node %s which was running pod %s was deleted", nodeName, pod.Name)
+
+	for i, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady || cond.Type == corev1.ContainersReady {
+			if cond.Status != corev1.ConditionFalse {
+				pod.Status.Conditions[i].Status = corev1.ConditionFalse
+				pod.Status.Conditions[i].Reason = nodeLostReason
+				pod.Status.Conditions[i].LastTransitionTime = now
+			}
+		}
+	}
+
+	for i, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			continue
+		}
+		var startedAt metav1.Time
+		if status.State.Running != nil {
+			startedAt = status.State.Running.StartedAt
+		}
+		pod.Status.ContainerStatuses[i].Ready = false
+		pod.Status.ContainerStatuses[i].State = corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode:   137,
+				Reason:     nodeLostReason,
+				StartedAt:  startedAt,
+				FinishedAt: now,
+			},
+		}
+	}
+	return pod
+}
+
+func (c *Controller) now() metav1.Time {
+	return metav1.NewTime(c.simulationNow())
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func orphanPod(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "app",
+					Ready: true,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestHandleOrphanPods(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delete", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(
+			orphanPod("running", "node0", corev1.PodRunning),
+			orphanPod("other", "node1", corev1.PodRunning),
+		)
+		c := &Controller{conf: Config{
+			TypedClient:     clientset,
+			OrphanPodPolicy: internalversion.OrphanPodPolicyDelete,
+		}}
+		err := c.handleOrphanPods(ctx, "node0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		pods, err := clientset.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pods.Items) != 1 || pods.Items[0].Name != "other" {
+			t.Errorf("expected only the pod on the other node to be kept, got %v", pods.Items)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(
+			orphanPod("running", "node0", corev1.PodRunning),
+			orphanPod("succeeded", "node0", corev1.PodSucceeded),
+		)
+		c := &Controller{conf: Config{
+			TypedClient:     clientset,
+			OrphanPodPolicy: internalversion.OrphanPodPolicyFail,
+		}}
+		err := c.handleOrphanPods(ctx, "node0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pod, err := clientset.CoreV1().Pods("default").Get(ctx, "running", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pod.Status.Phase != corev1.PodFailed || pod.Status.Reason != nodeLostReason {
+			t.Errorf("expected the running pod to be failed with %s, got %s %s", nodeLostReason, pod.Status.Phase, pod.Status.Reason)
+		}
+
+		pod, err = clientset.CoreV1().Pods("default").Get(ctx, "succeeded", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pod.Status.Phase != corev1.PodSucceeded {
+			t.Errorf("expected the succeeded pod to be kept, got %s", pod.Status.Phase)
+		}
+	})
+}
+
+func TestFailOrphanPod(t *testing.T) {
+	now := metav1.NewTime(time.Unix(100, 0))
+	pod := orphanPod("running", "node0", corev1.PodRunning)
+	got := failOrphanPod(pod, "node0", now)
+
+	if pod.Status.Phase != corev1.PodRunning {
+		t.Errorf("expected the original pod not to be modified")
+	}
+	if got.Status.Conditions[0].Status != corev1.ConditionFalse {
+		t.Errorf("expected the pod not to be ready, got %v", got.Status.Conditions[0])
+	}
+	status := got.Status.ContainerStatuses[0]
+	if status.Ready || status.State.Terminated == nil || status.State.Terminated.FinishedAt != now {
+		t.Errorf("expected the container to be terminated at %v, got %v", now, status)
+	}
+}
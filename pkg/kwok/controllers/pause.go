@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	kwokv1alpha1 "sigs.k8s.io/kwok/pkg/client/clientset/versioned/typed/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// pauseStatusInterval is the interval to write the number of the paused objects to the status of the Pauses.
+const pauseStatusInterval = 10 * time.Second
+
+// PausedAnnotation is the annotation to pause the simulation of an object,
+// no stages are played for the object while it is "true".
+const PausedAnnotation = "kwok.x-k8s.io/paused"
+
+var (
+	pausedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kwok_paused",
+			Help: "Number of the objects whose simulation is paused",
+		},
+		[]string{"resource"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(pausedGauge)
+}
+
+// pauses tells whether the objects are paused by the annotation or by the Pause resources.
+type pauses struct {
+	getter resources.Getter[[]*internalversion.Pause]
+
+	mut         sync.Mutex
+	subscribers []chan struct{}
+	counters    []func(pause *internalversion.Pause) int
+
+	// reported is the number of the objects paused by each Pause written to its status.
+	reported map[types.UID]int64
+}
+
+// newPauses returns a new pauses with the given Pause resources.
+func newPauses(getter resources.Getter[[]*internalversion.Pause]) *pauses {
+	return &pauses{
+		getter: getter,
+	}
+}
+
+// Start notifies the subscribers whenever the Pause resources change.
+func (p *pauses) Start(ctx context.Context) {
+	synced, ok := p.getter.(resources.Synced)
+	if !ok {
+		return
+	}
+	go func() {
+		changed := synced.Sync()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				p.notify()
+			}
+		}
+	}()
+}
+
+// IsPaused returns true if the object of the resource is paused.
+func (p *pauses) IsPaused(ref internalversion.StageResourceRef, obj metav1.Object) bool {
+	if obj.GetAnnotations()[PausedAnnotation] == "true" {
+		return true
+	}
+	if p == nil || p.getter == nil {
+		return false
+	}
+	for _, pause := range p.getter.Get() {
+		if pause.Spec.Match(ref, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe returns a channel that receives when the Pause resources change.
+func (p *pauses) Subscribe() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.subscribers = append(p.subscribers, ch)
+	return ch
+}
+
+// addCounter adds the function to count the held objects matched by a Pause.
+func (p *pauses) addCounter(counter func(pause *internalversion.Pause) int) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.counters = append(p.counters, counter)
+}
+
+// Paused returns the number of the objects paused by the Pause.
+func (p *pauses) Paused(pause *internalversion.Pause) int64 {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	var n int64
+	for _, counter := range p.counters {
+		n += int64(counter(pause))
+	}
+	return n
+}
+
+// StartStatus writes the number of the objects paused by each Pause to its status periodically.
+func (p *pauses) StartStatus(ctx context.Context, client kwokv1alpha1.PauseInterface, clk clock.Clock, interval time.Duration) {
+	if clk == nil {
+		clk = clock.RealClock{}
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-clk.After(interval):
+				p.syncStatus(ctx, client, clk.Now())
+			}
+		}
+	}()
+}
+
+// syncStatus updates the status of the Pauses whose number of the paused objects changed.
+func (p *pauses) syncStatus(ctx context.Context, client kwokv1alpha1.PauseInterface, now time.Time) {
+	logger := log.FromContext(ctx)
+	pauses := p.getter.Get()
+	reported := make(map[types.UID]int64, len(pauses))
+	for _, pause := range pauses {
+		paused := p.Paused(pause)
+		if last, ok := p.reported[pause.UID]; ok && last == paused {
+			reported[pause.UID] = paused
+			continue
+		}
+
+		obj, err := client.Get(ctx, pause.Name, metav1.GetOptions{})
+		if err != nil {
+			logger.Warn("Failed to get pause", "pause", pause.Name, "err", err)
+			continue
+		}
+		status := newPauseStatus(obj.Status, paused, metav1.NewTime(now))
+		if !reflect.DeepEqual(status, obj.Status) {
+			obj.Status = status
+			_, err = client.UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+			if err != nil {
+				logger.Warn("Failed to update pause status", "pause", pause.Name, "err", err)
+				continue
+			}
+		}
+		reported[pause.UID] = paused
+	}
+	// The deleted Pauses are forgotten
+	p.reported = reported
+}
+
+// newPauseStatus returns the status of a Pause with the number of the objects paused by it,
+// the Ready condition tells that the Pause is enforced by the controller.
+func newPauseStatus(status v1alpha1.PauseStatus, paused int64, now metav1.Time) v1alpha1.PauseStatus {
+	ready := v1alpha1.Condition{
+		Type:               v1alpha1.PauseConditionReady,
+		Status:             v1alpha1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             "Pausing",
+		Message:            fmt.Sprintf("%d objects are paused", paused),
+	}
+
+	out := v1alpha1.PauseStatus{
+		Paused: paused,
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type != v1alpha1.PauseConditionReady {
+			out.Conditions = append(out.Conditions, cond)
+			continue
+		}
+		if cond.Status == ready.Status {
+			ready.LastTransitionTime = cond.LastTransitionTime
+		}
+	}
+	out.Conditions = append(out.Conditions, ready)
+	return out
+}
+
+func (p *pauses) notify() {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	for _, ch := range p.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// pausedObjects holds the latest of the paused objects of a resource,
+// so that they are managed again once they are resumed.
+type pausedObjects[T metav1.Object] struct {
+	resource string
+	ref      internalversion.StageResourceRef
+	pauses   *pauses
+	changed  <-chan struct{}
+
+	mut     sync.Mutex
+	objects map[log.ObjectRef]T
+}
+
+// newPausedObjects returns a new pausedObjects, or nil if there are no pauses.
+func newPausedObjects[T metav1.Object](resource string, ref internalversion.StageResourceRef, pauses *pauses) *pausedObjects[T] {
+	if pauses == nil {
+		return nil
+	}
+	p := &pausedObjects[T]{
+		resource: resource,
+		ref:      ref,
+		pauses:   pauses,
+		changed:  pauses.Subscribe(),
+		objects:  map[log.ObjectRef]T{},
+	}
+	pauses.addCounter(p.count)
+	return p
+}
+
+// Hold returns true if the object is paused and keeps it as the latest one,
+// otherwise the object is no longer held.
+func (p *pausedObjects[T]) Hold(obj T) bool {
+	return p.hold(obj, true)
+}
+
+// Keep is like Hold, but does not replace the object already held,
+// it is used for the objects of the stage jobs which may be older than the held one.
+func (p *pausedObjects[T]) Keep(obj T) bool {
+	return p.hold(obj, false)
+}
+
+func (p *pausedObjects[T]) hold(obj T, replace bool) bool {
+	if p == nil {
+		return false
+	}
+
+	ref := log.KObj(obj)
+	paused := p.pauses.IsPaused(p.ref, obj)
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	_, held := p.objects[ref]
+	if !paused {
+		if held {
+			delete(p.objects, ref)
+			p.updateMetrics()
+		}
+		return false
+	}
+	if !held || replace {
+		p.objects[ref] = obj
+		p.updateMetrics()
+	}
+	return true
+}
+
+// Release forgets the object, it is called when the object is deleted.
+func (p *pausedObjects[T]) Release(ref log.ObjectRef) {
+	if p == nil {
+		return
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if _, ok := p.objects[ref]; ok {
+		delete(p.objects, ref)
+		p.updateMetrics()
+	}
+}
+
+// Changed returns a channel that receives when the Pause resources change.
+func (p *pausedObjects[T]) Changed() <-chan struct{} {
+	if p == nil {
+		return nil
+	}
+	return p.changed
+}
+
+// Resumed returns the held objects which are no longer paused and forgets them.
+func (p *pausedObjects[T]) Resumed() []T {
+	if p == nil {
+		return nil
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	var out []T
+	for ref, obj := range p.objects {
+		if p.pauses.IsPaused(p.ref, obj) {
+			continue
+		}
+		delete(p.objects, ref)
+		out = append(out, obj)
+	}
+	if len(out) != 0 {
+		p.updateMetrics()
+	}
+	return out
+}
+
+// count returns the number of the held objects matched by the Pause.
+func (p *pausedObjects[T]) count(pause *internalversion.Pause) int {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	n := 0
+	for _, obj := range p.objects {
+		if pause.Spec.Match(p.ref, obj) {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *pausedObjects[T]) updateMetrics() {
+	pausedGauge.WithLabelValues(p.resource).Set(float64(len(p.objects)))
+}
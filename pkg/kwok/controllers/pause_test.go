@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/client/clientset/versioned/fake"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+type fakePauseGetter struct {
+	pauses []*internalversion.Pause
+}
+
+func (g *fakePauseGetter) Get() []*internalversion.Pause {
+	return g.pauses
+}
+
+func (g *fakePauseGetter) Version() string {
+	return ""
+}
+
+func TestPausedObjects(t *testing.T) {
+	getter := &fakePauseGetter{
+		pauses: []*internalversion.Pause{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "frozen"},
+				Spec: internalversion.PauseSpec{
+					ResourceRef: podRef,
+					Selector: &internalversion.ObjectSelector{
+						MatchNamespaces: []string{"frozen"},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "labeled"},
+				Spec: internalversion.PauseSpec{
+					ResourceRef: podRef,
+					MatchLabels: map[string]string{"app": "frozen"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+				Spec: internalversion.PauseSpec{
+					ResourceRef: nodeRef,
+				},
+			},
+		},
+	}
+	p := newPausedObjects[*corev1.Pod]("pods", podRef, newPauses(getter))
+
+	pod := func(namespace, name, resourceVersion string, labels, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            name,
+				ResourceVersion: resourceVersion,
+				Labels:          labels,
+				Annotations:     annotations,
+			},
+		}
+	}
+
+	if !p.Hold(pod("frozen", "a", "1", nil, nil)) {
+		t.Fatalf("expected frozen/a to be paused by the namespace")
+	}
+	if !p.Hold(pod("default", "b", "1", map[string]string{"app": "frozen"}, nil)) {
+		t.Fatalf("expected default/b to be paused by the labels")
+	}
+	if !p.Hold(pod("default", "c", "1", nil, map[string]string{PausedAnnotation: "true"})) {
+		t.Fatalf("expected default/c to be paused by the annotation")
+	}
+	if p.Hold(pod("default", "d", "1", nil, nil)) {
+		t.Fatalf("expected default/d not to be paused")
+	}
+
+	if !p.Hold(pod("frozen", "a", "2", nil, nil)) {
+		t.Fatalf("expected frozen/a to be paused")
+	}
+	if !p.Keep(pod("frozen", "a", "1", nil, nil)) {
+		t.Fatalf("expected frozen/a to be paused")
+	}
+	if got := p.objects[log.KRef("frozen", "a")].ResourceVersion; got != "2" {
+		t.Fatalf("expected the latest frozen/a to be held, got resource version %s", got)
+	}
+
+	p.Release(log.KRef("default", "b"))
+	if got := p.Resumed(); got != nil {
+		t.Fatalf("expected nothing to be resumed, got %v", got)
+	}
+
+	getter.pauses = getter.pauses[1:]
+	got := p.Resumed()
+	if len(got) != 1 || log.KObj(got[0]) != log.KRef("frozen", "a") || got[0].ResourceVersion != "2" {
+		t.Fatalf("expected frozen/a to be resumed, got %v", got)
+	}
+	if want := []log.ObjectRef{log.KRef("default", "c")}; !reflect.DeepEqual(maps.Keys(p.objects), want) {
+		t.Fatalf("expected %v to be held, got %v", want, maps.Keys(p.objects))
+	}
+
+	if p.Hold(pod("default", "c", "2", nil, nil)) {
+		t.Fatalf("expected default/c to be resumed after removing the annotation")
+	}
+	if len(p.objects) != 0 {
+		t.Fatalf("expected nothing to be held, got %v", maps.Keys(p.objects))
+	}
+}
+
+func TestPausedObjectsWithoutPauses(t *testing.T) {
+	p := newPausedObjects[*corev1.Pod]("pods", podRef, nil)
+	if p != nil {
+		t.Fatalf("expected no paused objects")
+	}
+	if p.Hold(&corev1.Pod{}) {
+		t.Fatalf("expected not to be paused without pauses")
+	}
+	if got := p.Resumed(); got != nil {
+		t.Fatalf("expected nothing to be resumed, got %v", got)
+	}
+}
+
+func TestPausesStatus(t *testing.T) {
+	ctx := context.Background()
+	getter := &fakePauseGetter{
+		pauses: []*internalversion.Pause{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "frozen", UID: "frozen"},
+				Spec: internalversion.PauseSpec{
+					ResourceRef: podRef,
+					Selector: &internalversion.ObjectSelector{
+						MatchNamespaces: []string{"frozen"},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodes", UID: "nodes"},
+				Spec: internalversion.PauseSpec{
+					ResourceRef: nodeRef,
+				},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset(
+		&v1alpha1.Pause{ObjectMeta: metav1.ObjectMeta{Name: "frozen", UID: "frozen"}},
+		&v1alpha1.Pause{ObjectMeta: metav1.ObjectMeta{Name: "nodes", UID: "nodes"}},
+	)
+	pausesCli := client.KwokV1alpha1().Pauses()
+
+	pauses := newPauses(getter)
+	pods := newPausedObjects[*corev1.Pod]("pods", podRef, pauses)
+	nodes := newPausedObjects[*corev1.Node]("nodes", nodeRef, pauses)
+	pods.Hold(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "frozen", Name: "a"}})
+	pods.Hold(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "frozen", Name: "b"}})
+	pods.Hold(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "c", Annotations: map[string]string{PausedAnnotation: "true"}}})
+	nodes.Hold(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}})
+
+	getStatus := func(name string) v1alpha1.PauseStatus {
+		t.Helper()
+		obj, err := pausesCli.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return obj.Status
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pauses.syncStatus(ctx, pausesCli, start)
+	want := v1alpha1.PauseStatus{
+		Paused: 2,
+		Conditions: []v1alpha1.Condition{
+			{
+				Type:               v1alpha1.PauseConditionReady,
+				Status:             v1alpha1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(start),
+				Reason:             "Pausing",
+				Message:            "2 objects are paused",
+			},
+		},
+	}
+	if got := getStatus("frozen"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the status of frozen to be %+v, got %+v", want, got)
+	}
+	if got := getStatus("nodes"); got.Paused != 1 {
+		t.Errorf("expected 1 node to be paused, got %d", got.Paused)
+	}
+
+	// Nothing is written if the numbers are unchanged
+	client.ClearActions()
+	pauses.syncStatus(ctx, pausesCli, start.Add(time.Minute))
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("expected no actions, got %v", actions)
+	}
+
+	pods.Release(log.KRef("frozen", "a"))
+	pauses.syncStatus(ctx, pausesCli, start.Add(time.Minute))
+	want.Paused = 1
+	want.Conditions[0].Message = "1 objects are paused"
+	if got := getStatus("frozen"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the status of frozen to be %+v with the transition time kept, got %+v", want, got)
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/kwok/cni"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/gotpl"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -63,12 +65,20 @@ type PodController struct {
 	preprocessChan                        chan *corev1.Pod
 	playStageParallelism                  uint
 	lifecycle                             resources.Getter[lifecycle.Lifecycle]
+	namespaceLifecycleFunc                func(namespace string) (lifecycle.Lifecycle, bool, error)
 	delayQueue                            queue.WeightDelayingQueue[resourceStageJob[*corev1.Pod]]
 	backoff                               wait.Backoff
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Pod]]
 	recorder                              record.EventRecorder
+	decisionRecorder                      *decisions.Recorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
+	quota                                 *quota[*corev1.Pod]
+	paused                                *pausedObjects[*corev1.Pod]
+	allocatable                           *nodeAllocatable
+	timeAcceleration                      float64
+	stageConcurrency                      *stageConcurrency
+	attempts                              rand.Attempts
 }
 
 // PodInfo is the collection of necessary pod information
@@ -88,11 +98,18 @@ type PodControllerConfig struct {
 	NodeGetFunc                           func(nodeName string) (*NodeInfo, bool)
 	NodeHasMetric                         func(nodeName string) bool
 	Lifecycle                             resources.Getter[lifecycle.Lifecycle]
+	NamespaceLifecycleFunc                func(namespace string) (lifecycle.Lifecycle, bool, error)
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	DecisionRecorder                      *decisions.Recorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
+	MaxManagedPods                        uint
+	MaxManagedPodsPerNamespace            uint
+	EnforceNodeAllocatable                bool
+	TimeAcceleration                      float64
+	Pauses                                *pauses
 }
 
 // NewPodController creates a new fake pods controller
@@ -128,11 +145,18 @@ func NewPodController(conf PodControllerConfig) (*PodController, error) {
 		delayQueue:                            queue.NewWeightDelayingQueue[resourceStageJob[*corev1.Pod]](conf.Clock),
 		backoff:                               defaultBackoff(),
 		lifecycle:                             conf.Lifecycle,
+		namespaceLifecycleFunc:                conf.NamespaceLifecycleFunc,
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Pod),
 		recorder:                              conf.Recorder,
+		decisionRecorder:                      conf.DecisionRecorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
+		quota:                                 newQuota[*corev1.Pod]("pods", conf.MaxManagedPods, conf.MaxManagedPodsPerNamespace),
+		paused:                                newPausedObjects[*corev1.Pod]("pods", podRef, conf.Pauses),
+		allocatable:                           newNodeAllocatable(conf.EnforceNodeAllocatable),
+		timeAcceleration:                      conf.TimeAcceleration,
+		stageConcurrency:                      newStageConcurrency(),
 	}
 	funcMap := maps.Merge(gotpl.FuncMap{
 		"NodeIP":     c.funcNodeIP,
@@ -219,6 +243,16 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 	}
 
 	lc := c.lifecycle.Get()
+	if c.namespaceLifecycleFunc != nil {
+		nslc, ok, err := c.namespaceLifecycleFunc(pod.Namespace)
+		if err != nil {
+			logger.Warn("Failed to get the lifecycle of the namespace, using the default", "err", err)
+		} else if ok {
+			lc = nslc
+		}
+	}
+	attempt := c.attempts.Next(key)
+	ctx = lifecycle.NewAttemptContext(ctx, attempt)
 	stage, err := lc.Match(ctx, pod.Labels, pod.Annotations, data)
 	if err != nil {
 		return fmt.Errorf("stage match: %w", err)
@@ -229,9 +263,20 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 		)
 		return nil
 	}
+	stage, err = stage.Decide(ctx, pod)
+	if err != nil {
+		return fmt.Errorf("stage decide: %w", err)
+	}
+	if stage == nil {
+		logger.Debug("Skip pod",
+			"reason", "skipped by the decision of the stage",
+		)
+		return nil
+	}
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
+	delay = accelerate(delay, c.timeAcceleration)
 
 	if delay != 0 {
 		stageName := stage.Name()
@@ -241,15 +286,23 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 		)
 	}
 
+	slot, limit := concurrencySlotOf(stage, pod.Spec.NodeName)
 	item := resourceStageJob[*corev1.Pod]{
-		Resource:   pod,
-		Stage:      stage,
-		Key:        key,
-		RetryCount: new(uint64),
+		Resource:    pod,
+		Stage:       stage,
+		Key:         key,
+		Slot:        slot,
+		RetryCount:  new(uint64),
+		Attempt:     attempt,
+		DecidedTime: now,
+		Delay:       delay,
 	}
 	// we add a normal(fresh) stage job with weight 0,
 	// resulting in that it will always be processed with high priority compared to those retry ones
-	c.addStageJob(ctx, item, delay, 0)
+	// the job is added once the object holds a slot of the stage if the stage has a concurrency limit
+	c.stageConcurrency.Acquire(key, item.Slot, limit, func() {
+		c.addStageJob(ctx, item, delay, 0)
+	})
 	return nil
 }
 
@@ -263,7 +316,20 @@ func (c *PodController) playStageWorker(ctx context.Context) {
 			return
 		}
 		c.delayQueueMapping.Delete(pod.Key)
-		needRetry, err := c.playStage(ctx, pod.Resource, pod.Stage)
+		if c.paused.Keep(pod.Resource) {
+			logger.Debug("Skip pod",
+				"reason", "paused",
+				"pod", pod.Key,
+				"stage", pod.Stage.Name(),
+			)
+			c.stageConcurrency.Release(pod.Key, pod.Slot)
+			continue
+		}
+		decision := newDecision(c.decisionRecorder, "Pod", pod)
+		needRetry, err := c.playStage(ctx, pod.Resource, pod.Stage, decision)
+		now := c.clock.Now()
+		recordDecision(ctx, c.decisionRecorder, decision, now, err)
+		observeStageLatency("Pod", pod, now)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"pod", pod.Key,
@@ -279,15 +345,17 @@ func (c *PodController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(pod.Key, pod.Attempt, retryCount, c.backoff)
 			c.addStageJob(ctx, pod, retryDelay, 1)
+		} else {
+			c.stageConcurrency.Release(pod.Key, pod.Slot)
 		}
 	}
 }
 
 // playStage plays the stage.
 // The returned boolean indicates whether the applying action needs to be retried.
-func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *lifecycle.Stage) (bool, error) {
+func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *lifecycle.Stage, decision *decisions.Decision) (bool, error) {
 	next := stage.Next()
 	logger := log.FromContext(ctx)
 	logger = logger.With(
@@ -315,6 +383,7 @@ func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *l
 		return false, fmt.Errorf("failed to get finalizers for pod %s: %w", pod.Name, err)
 	}
 	if patch != nil {
+		addDecisionPatch(decision, patch)
 		result, err = c.patchResource(ctx, pod, patch)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to patch the finalizer of pod %s: %w", pod.Name, err)
@@ -322,6 +391,9 @@ func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *l
 	}
 
 	if next.Delete() {
+		if decision != nil {
+			decision.Delete = true
+		}
 		err = c.deleteResource(ctx, pod)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
@@ -343,6 +415,7 @@ func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *l
 					"reason", "do not need to modify",
 				)
 			} else {
+				addDecisionPatch(decision, patch)
 				result, err = c.patchResource(ctx, pod, patch)
 				if err != nil {
 					return shouldRetry(err), fmt.Errorf("failed to patch pod %s: %w", pod.Name, err)
@@ -426,15 +499,13 @@ loop:
 					c.putPodInfo(pod)
 				}
 				if c.need(pod) {
-					if c.readOnly(pod.Spec.NodeName) {
-						logger.Debug("Skip pod",
-							"reason", "read only",
-							"event", event.Type,
-							"pod", log.KObj(pod),
-							"node", pod.Spec.NodeName,
-						)
-					} else {
-						c.preprocessChan <- pod.DeepCopy()
+					admitted, exceeded := c.quota.Admit(log.KObj(pod), pod.DeepCopy())
+					if admitted {
+						if c.admitAllocatable(ctx, pod.DeepCopy()) {
+							c.manage(ctx, pod.DeepCopy(), event.Type)
+						}
+					} else if exceeded {
+						c.quotaExceeded(ctx, pod)
 					}
 				} else {
 					logger.Debug("Skip pod",
@@ -458,6 +529,13 @@ loop:
 				if c.enableMetrics {
 					c.deletePodInfo(pod)
 				}
+				c.paused.Release(log.KObj(pod))
+				for _, p := range c.quota.Release(log.KObj(pod)) {
+					if c.admitAllocatable(ctx, p) {
+						c.manage(ctx, p, informer.Added)
+					}
+				}
+				c.releaseAllocatable(ctx, pod)
 				if c.need(pod) {
 					// Recycling PodIP
 					c.recyclingPodIP(ctx, pod)
@@ -468,8 +546,14 @@ loop:
 					if ok {
 						c.delayQueue.Cancel(resourceJob)
 					}
+					c.stageConcurrency.Forget(key)
+					c.attempts.Forget(key)
 				}
 			}
+		case <-c.paused.Changed():
+			for _, p := range c.paused.Resumed() {
+				c.manage(ctx, p, informer.Sync)
+			}
 		case <-ctx.Done():
 			break loop
 		}
@@ -477,6 +561,47 @@ loop:
 	logger.Info("Stop watch pods")
 }
 
+// manage sends the pod to preprocessChan if the node of it is not read only and the pod is not paused
+func (c *PodController) manage(ctx context.Context, pod *corev1.Pod, eventType informer.EventType) {
+	if c.readOnly(pod.Spec.NodeName) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Skip pod",
+			"reason", "read only",
+			"event", eventType,
+			"pod", log.KObj(pod),
+			"node", pod.Spec.NodeName,
+		)
+		return
+	}
+	if c.paused.Hold(pod) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Skip pod",
+			"reason", "paused",
+			"event", eventType,
+			"pod", log.KObj(pod),
+			"node", pod.Spec.NodeName,
+		)
+		return
+	}
+	c.preprocessChan <- pod
+}
+
+// quotaExceeded reports the pod waits for the quota
+func (c *PodController) quotaExceeded(ctx context.Context, pod *corev1.Pod) {
+	logger := log.FromContext(ctx)
+	logger.Warn("Skip pod",
+		"reason", "quota exceeded",
+		"pod", log.KObj(pod),
+		"node", pod.Spec.NodeName,
+		"maxManagedPods", c.quota.max,
+		"maxManagedPodsPerNamespace", c.quota.maxPerNamespace,
+	)
+	if c.recorder != nil {
+		c.recorder.Eventf(pod, corev1.EventTypeWarning, "QuotaExceeded",
+			"Pod is waiting to be managed, the number of managed pods reached the limit of the cluster (%d) or the namespace (%d)", c.quota.max, c.quota.maxPerNamespace)
+	}
+}
+
 // ipPool returns the ipPool for the given cidr
 func (c *PodController) ipPool(cidr string) (*ipPool, error) {
 	pool, ok := c.ipPools.Load(cidr)
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+var (
+	quotaManaged = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kwok_quota_managed",
+			Help: "Number of the objects managed within the quota",
+		},
+		[]string{"resource"},
+	)
+	quotaWaiting = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kwok_quota_waiting",
+			Help: "Number of the objects waiting for the quota",
+		},
+		[]string{"resource"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(quotaManaged, quotaWaiting)
+}
+
+// quota limits the number of the objects managed by the controller,
+// the objects beyond it wait in order until there is room for them.
+type quota[T any] struct {
+	resource        string
+	max             uint
+	maxPerNamespace uint
+
+	mut          sync.Mutex
+	admitted     map[log.ObjectRef]struct{}
+	namespaces   map[string]uint
+	waiting      map[log.ObjectRef]T
+	waitingOrder []log.ObjectRef
+}
+
+// newQuota returns a new quota, or nil if there is no limit.
+func newQuota[T any](resource string, max, maxPerNamespace uint) *quota[T] {
+	if max == 0 && maxPerNamespace == 0 {
+		return nil
+	}
+	return &quota[T]{
+		resource:        resource,
+		max:             max,
+		maxPerNamespace: maxPerNamespace,
+		admitted:        map[log.ObjectRef]struct{}{},
+		namespaces:      map[string]uint{},
+		waiting:         map[log.ObjectRef]T{},
+	}
+}
+
+// Admit returns true if the object is managed,
+// otherwise the latest object is kept to wait and exceeded is true the first time it waits.
+func (q *quota[T]) Admit(ref log.ObjectRef, obj T) (admitted bool, exceeded bool) {
+	if q == nil {
+		return true, false
+	}
+
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	if _, ok := q.admitted[ref]; ok {
+		return true, false
+	}
+
+	_, waiting := q.waiting[ref]
+	if !waiting && q.fits(ref) {
+		q.admit(ref)
+		q.updateMetrics()
+		return true, false
+	}
+
+	q.waiting[ref] = obj
+	if !waiting {
+		q.waitingOrder = append(q.waitingOrder, ref)
+		q.updateMetrics()
+	}
+	return false, !waiting
+}
+
+// Release releases the room of the object and returns the waiting objects admitted into it.
+func (q *quota[T]) Release(ref log.ObjectRef) []T {
+	if q == nil {
+		return nil
+	}
+
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	if _, ok := q.waiting[ref]; ok {
+		delete(q.waiting, ref)
+		q.updateMetrics()
+		return nil
+	}
+
+	if _, ok := q.admitted[ref]; !ok {
+		return nil
+	}
+	delete(q.admitted, ref)
+	if ref.Namespace != "" {
+		q.namespaces[ref.Namespace]--
+		if q.namespaces[ref.Namespace] == 0 {
+			delete(q.namespaces, ref.Namespace)
+		}
+	}
+
+	var out []T
+	order := q.waitingOrder[:0]
+	for _, r := range q.waitingOrder {
+		obj, ok := q.waiting[r]
+		if !ok {
+			continue
+		}
+		if q.fits(r) {
+			delete(q.waiting, r)
+			q.admit(r)
+			out = append(out, obj)
+			continue
+		}
+		order = append(order, r)
+	}
+	q.waitingOrder = order
+	q.updateMetrics()
+	return out
+}
+
+func (q *quota[T]) fits(ref log.ObjectRef) bool {
+	if q.max != 0 && uint(len(q.admitted)) >= q.max {
+		return false
+	}
+	if q.maxPerNamespace != 0 && ref.Namespace != "" && q.namespaces[ref.Namespace] >= q.maxPerNamespace {
+		return false
+	}
+	return true
+}
+
+func (q *quota[T]) admit(ref log.ObjectRef) {
+	q.admitted[ref] = struct{}{}
+	if ref.Namespace != "" {
+		q.namespaces[ref.Namespace]++
+	}
+}
+
+func (q *quota[T]) updateMetrics() {
+	quotaManaged.WithLabelValues(q.resource).Set(float64(len(q.admitted)))
+	quotaWaiting.WithLabelValues(q.resource).Set(float64(len(q.waiting)))
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+func TestQuota(t *testing.T) {
+	q := newQuota[string]("test", 3, 2)
+
+	admit := func(namespace, name string) (bool, bool) {
+		return q.Admit(log.KRef(namespace, name), namespace+"/"+name)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if admitted, _ := admit("ns1", name); !admitted {
+			t.Fatalf("expected %s to be admitted", name)
+		}
+	}
+	if admitted, exceeded := admit("ns1", "c"); admitted || !exceeded {
+		t.Fatalf("expected ns1/c to exceed the quota of the namespace, got admitted=%v exceeded=%v", admitted, exceeded)
+	}
+	if admitted, exceeded := admit("ns1", "c"); admitted || exceeded {
+		t.Fatalf("expected ns1/c to keep waiting, got admitted=%v exceeded=%v", admitted, exceeded)
+	}
+	if admitted, _ := admit("ns2", "a"); !admitted {
+		t.Fatalf("expected ns2/a to be admitted")
+	}
+	if admitted, exceeded := admit("ns2", "b"); admitted || !exceeded {
+		t.Fatalf("expected ns2/b to exceed the quota, got admitted=%v exceeded=%v", admitted, exceeded)
+	}
+
+	if got := q.Release(log.KRef("ns2", "a")); !reflect.DeepEqual(got, []string{"ns2/b"}) {
+		t.Fatalf("expected ns2/b to be admitted, got %v", got)
+	}
+	if got := q.Release(log.KRef("ns1", "a")); !reflect.DeepEqual(got, []string{"ns1/c"}) {
+		t.Fatalf("expected ns1/c to be admitted, got %v", got)
+	}
+	if got := q.Release(log.KRef("ns3", "unknown")); got != nil {
+		t.Fatalf("expected nothing to be admitted, got %v", got)
+	}
+}
+
+func TestQuotaNoLimit(t *testing.T) {
+	q := newQuota[string]("test", 0, 0)
+	if q != nil {
+		t.Fatalf("expected no quota")
+	}
+	if admitted, _ := q.Admit(log.KRef("", "a"), "a"); !admitted {
+		t.Fatalf("expected to be admitted without quota")
+	}
+	if got := q.Release(log.KRef("", "a")); got != nil {
+		t.Fatalf("expected nothing to be admitted, got %v", got)
+	}
+}
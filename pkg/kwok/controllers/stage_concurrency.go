@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
+)
+
+// stageConcurrency limits the number of the objects in the stages with a concurrency limit,
+// an object holds a slot of the stage from the time it is matched until the stage is played,
+// and the objects over the limit wait in order for the slots to be released before their jobs are added.
+type stageConcurrency struct {
+	mut     sync.Mutex
+	slots   map[string]*concurrencySlot
+	objects map[string]string
+}
+
+type concurrencySlot struct {
+	limit   uint
+	holders map[string]struct{}
+	waiting []concurrencyWaiter
+}
+
+type concurrencyWaiter struct {
+	key   string
+	start func()
+}
+
+func newStageConcurrency() *stageConcurrency {
+	return &stageConcurrency{
+		slots:   map[string]*concurrencySlot{},
+		objects: map[string]string{},
+	}
+}
+
+// concurrencySlotOf returns the slot of the stage for an object on the node and the limit of the slot,
+// the slot is empty if the stage is unlimited.
+func concurrencySlotOf(stage *lifecycle.Stage, nodeName string) (string, uint) {
+	concurrency := stage.Concurrency()
+	if concurrency == nil {
+		return "", 0
+	}
+	if concurrency.Scope == internalversion.StageConcurrencyScopeNode {
+		return stage.Name() + "/" + nodeName, concurrency.Limit
+	}
+	return stage.Name(), concurrency.Limit
+}
+
+// Acquire calls start once the object of the key holds the slot,
+// which is at once if the slot is empty or below the limit, or when a slot is released otherwise.
+// The slot held or waited by the object for another stage is released first,
+// and a waiting object keeps its place in the order with the latest start.
+func (s *stageConcurrency) Acquire(key, slot string, limit uint, start func()) {
+	s.mut.Lock()
+	var starts []func()
+	if old, ok := s.objects[key]; ok && old != slot {
+		starts = s.release(key)
+	}
+
+	if slot == "" {
+		s.mut.Unlock()
+		for _, f := range starts {
+			f()
+		}
+		start()
+		return
+	}
+
+	cs, ok := s.slots[slot]
+	if !ok {
+		cs = &concurrencySlot{
+			holders: map[string]struct{}{},
+		}
+		s.slots[slot] = cs
+	}
+	cs.limit = limit
+	s.objects[key] = slot
+
+	switch {
+	case has(cs.holders, key):
+		starts = append(starts, start)
+	case cs.updateWaiting(key, start):
+	case uint(len(cs.holders)) < cs.limit:
+		cs.holders[key] = struct{}{}
+		starts = append(starts, start)
+	default:
+		cs.waiting = append(cs.waiting, concurrencyWaiter{key: key, start: start})
+	}
+	s.mut.Unlock()
+
+	for _, f := range starts {
+		f()
+	}
+}
+
+// Release releases the slot held or waited by the object of the key if it is the slot,
+// and starts the next waiting objects of the slot.
+func (s *stageConcurrency) Release(key, slot string) {
+	if slot == "" {
+		return
+	}
+	s.mut.Lock()
+	var starts []func()
+	if s.objects[key] == slot {
+		starts = s.release(key)
+	}
+	s.mut.Unlock()
+
+	for _, f := range starts {
+		f()
+	}
+}
+
+// Forget releases any slot held or waited by the object of the key, e.g. when the object is deleted.
+func (s *stageConcurrency) Forget(key string) {
+	s.mut.Lock()
+	starts := s.release(key)
+	s.mut.Unlock()
+
+	for _, f := range starts {
+		f()
+	}
+}
+
+// release must be called with the lock held, it returns the starts of the objects which hold the slot then.
+func (s *stageConcurrency) release(key string) []func() {
+	slot, ok := s.objects[key]
+	if !ok {
+		return nil
+	}
+	delete(s.objects, key)
+	cs := s.slots[slot]
+
+	var starts []func()
+	if has(cs.holders, key) {
+		delete(cs.holders, key)
+		for uint(len(cs.holders)) < cs.limit && len(cs.waiting) != 0 {
+			next := cs.waiting[0]
+			cs.waiting = cs.waiting[1:]
+			cs.holders[next.key] = struct{}{}
+			starts = append(starts, next.start)
+		}
+	} else {
+		cs.removeWaiting(key)
+	}
+
+	if len(cs.holders) == 0 && len(cs.waiting) == 0 {
+		delete(s.slots, slot)
+	}
+	return starts
+}
+
+func (cs *concurrencySlot) updateWaiting(key string, start func()) bool {
+	for i := range cs.waiting {
+		if cs.waiting[i].key == key {
+			cs.waiting[i].start = start
+			return true
+		}
+	}
+	return false
+}
+
+func (cs *concurrencySlot) removeWaiting(key string) {
+	for i := range cs.waiting {
+		if cs.waiting[i].key == key {
+			cs.waiting = append(cs.waiting[:i], cs.waiting[i+1:]...)
+			return
+		}
+	}
+}
+
+func has(m map[string]struct{}, key string) bool {
+	_, ok := m[key]
+	return ok
+}
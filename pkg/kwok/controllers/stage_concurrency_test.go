@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStageConcurrency(t *testing.T) {
+	s := newStageConcurrency()
+	var started []string
+	start := func(key string) func() {
+		return func() {
+			started = append(started, key)
+		}
+	}
+
+	s.Acquire("a", "pull", 2, start("a"))
+	s.Acquire("b", "pull", 2, start("b"))
+	s.Acquire("c", "pull", 2, start("c"))
+	s.Acquire("d", "pull", 2, start("d"))
+	s.Acquire("e", "", 0, start("e"))
+	if want := []string{"a", "b", "e"}; !reflect.DeepEqual(started, want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+
+	// the waiting object keeps its place with the latest start
+	s.Acquire("c", "pull", 2, start("c2"))
+
+	// releasing another slot is a no-op
+	s.Release("a", "other")
+	if want := []string{"a", "b", "e"}; !reflect.DeepEqual(started, want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+
+	s.Release("a", "pull")
+	if want := []string{"a", "b", "e", "c2"}; !reflect.DeepEqual(started, want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+
+	// moving to another stage releases the slot held
+	s.Acquire("b", "ready", 1, start("b2"))
+	if want := []string{"a", "b", "e", "c2", "d", "b2"}; !reflect.DeepEqual(started, want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+
+	s.Forget("c")
+	s.Forget("d")
+	s.Forget("b")
+	if len(s.slots) != 0 || len(s.objects) != 0 {
+		t.Fatalf("slots = %v, objects = %v, want empty", s.slots, s.objects)
+	}
+}
+
+func TestStageConcurrencyForgetWaiting(t *testing.T) {
+	s := newStageConcurrency()
+	var started []string
+	start := func(key string) func() {
+		return func() {
+			started = append(started, key)
+		}
+	}
+
+	s.Acquire("a", "pull", 1, start("a"))
+	s.Acquire("b", "pull", 1, start("b"))
+	s.Acquire("c", "pull", 1, start("c"))
+	s.Forget("b")
+	s.Release("a", "pull")
+	if want := []string{"a", "c"}; !reflect.DeepEqual(started, want) {
+		t.Fatalf("started = %v, want %v", started, want)
+	}
+}
@@ -33,7 +33,9 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
@@ -42,6 +44,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -62,6 +65,13 @@ type StageController struct {
 	backoff                               wait.Backoff
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*unstructured.Unstructured]]
 	recorder                              record.EventRecorder
+	decisionRecorder                      *decisions.Recorder
+	timeAcceleration                      float64
+	stageConcurrency                      *stageConcurrency
+	attempts                              rand.Attempts
+	loadBalancerIPs                       *loadBalancerIPAllocator
+	csrSigner                             *csrSigner
+	paused                                *pausedObjects[*unstructured.Unstructured]
 }
 
 // StageControllerConfig is the configuration for the StageController
@@ -77,6 +87,12 @@ type StageControllerConfig struct {
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	DecisionRecorder                      *decisions.Recorder
+	TimeAcceleration                      float64
+	LoadBalancerIPs                       *loadBalancerIPAllocator
+	CSRSigner                             *csrSigner
+	ResourceRef                           internalversion.StageResourceRef
+	Pauses                                *pauses
 }
 
 // NewStageController creates a new fake resources controller
@@ -113,9 +129,19 @@ func NewStageController(conf StageControllerConfig) (*StageController, error) {
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *unstructured.Unstructured),
 		recorder:                              conf.Recorder,
+		decisionRecorder:                      conf.DecisionRecorder,
+		timeAcceleration:                      conf.TimeAcceleration,
+		stageConcurrency:                      newStageConcurrency(),
+		loadBalancerIPs:                       conf.LoadBalancerIPs,
+		csrSigner:                             conf.CSRSigner,
+		paused:                                newPausedObjects[*unstructured.Unstructured](conf.GVR.Resource, conf.ResourceRef, conf.Pauses),
 	}
 
-	c.renderer = gotpl.NewRenderer(conf.FuncMap)
+	funcMap := maps.Merge(gotpl.FuncMap{
+		"LoadBalancerIP": c.funcLoadBalancerIP,
+		"SignCSR":        c.funcSignCSR,
+	}, conf.FuncMap)
+	c.renderer = gotpl.NewRenderer(funcMap)
 	return c, nil
 }
 
@@ -196,6 +222,8 @@ func (c *StageController) preprocess(ctx context.Context, resource *unstructured
 	}
 
 	lc := c.lifecycle.Get()
+	attempt := c.attempts.Next(key)
+	ctx = lifecycle.NewAttemptContext(ctx, attempt)
 	stage, err := lc.Match(ctx, resource.GetLabels(), resource.GetAnnotations(), data)
 	if err != nil {
 		return fmt.Errorf("stage match: %w", err)
@@ -206,9 +234,20 @@ func (c *StageController) preprocess(ctx context.Context, resource *unstructured
 		)
 		return nil
 	}
+	stage, err = stage.Decide(ctx, resource.Object)
+	if err != nil {
+		return fmt.Errorf("stage decide: %w", err)
+	}
+	if stage == nil {
+		logger.Debug("Skip resource",
+			"reason", "skipped by the decision of the stage",
+		)
+		return nil
+	}
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
+	delay = accelerate(delay, c.timeAcceleration)
 
 	if delay != 0 {
 		stageName := stage.Name()
@@ -218,16 +257,25 @@ func (c *StageController) preprocess(ctx context.Context, resource *unstructured
 		)
 	}
 
+	nodeName, _, _ := unstructured.NestedString(resource.Object, "spec", "nodeName")
+	slot, limit := concurrencySlotOf(stage, nodeName)
 	item := resourceStageJob[*unstructured.Unstructured]{
-		Resource:   resource,
-		Stage:      stage,
-		Key:        key,
-		RetryCount: new(uint64),
+		Resource:    resource,
+		Stage:       stage,
+		Key:         key,
+		Slot:        slot,
+		RetryCount:  new(uint64),
+		Attempt:     attempt,
+		DecidedTime: now,
+		Delay:       delay,
 	}
 
 	// we add a normal(fresh) stage job with weight 0,
 	// resulting in that it will always be processed with high priority compared to those retry ones
-	c.addStageJob(ctx, item, delay, 0)
+	// the job is added once the object holds a slot of the stage if the stage has a concurrency limit
+	c.stageConcurrency.Acquire(key, item.Slot, limit, func() {
+		c.addStageJob(ctx, item, delay, 0)
+	})
 	return nil
 }
 
@@ -241,7 +289,20 @@ func (c *StageController) playStageWorker(ctx context.Context) {
 			return
 		}
 		c.delayQueueMapping.Delete(resource.Key)
-		needRetry, err := c.playStage(ctx, resource.Resource, resource.Stage)
+		if c.paused.Keep(resource.Resource) {
+			logger.Debug("Skip resource",
+				"reason", "paused",
+				"resource", resource.Key,
+				"stage", resource.Stage.Name(),
+			)
+			c.stageConcurrency.Release(resource.Key, resource.Slot)
+			continue
+		}
+		decision := newDecision(c.decisionRecorder, resource.Resource.GetKind(), resource)
+		needRetry, err := c.playStage(ctx, resource.Resource, resource.Stage, decision)
+		now := c.clock.Now()
+		recordDecision(ctx, c.decisionRecorder, decision, now, err)
+		observeStageLatency(resource.Resource.GetKind(), resource, now)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"resource", resource.Key,
@@ -257,15 +318,17 @@ func (c *StageController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(resource.Key, resource.Attempt, retryCount, c.backoff)
 			c.addStageJob(ctx, resource, retryDelay, 1)
+		} else {
+			c.stageConcurrency.Release(resource.Key, resource.Slot)
 		}
 	}
 }
 
 // playStage plays the stage.
 // The returned boolean indicates whether the applying action needs to be retried.
-func (c *StageController) playStage(ctx context.Context, resource *unstructured.Unstructured, stage *lifecycle.Stage) (bool, error) {
+func (c *StageController) playStage(ctx context.Context, resource *unstructured.Unstructured, stage *lifecycle.Stage, decision *decisions.Decision) (bool, error) {
 	next := stage.Next()
 	logger := log.FromContext(ctx)
 	logger = logger.With(
@@ -293,6 +356,7 @@ func (c *StageController) playStage(ctx context.Context, resource *unstructured.
 		return false, fmt.Errorf("failed to get finalizers for resource %s: %w", resource.GetName(), err)
 	}
 	if patch != nil {
+		addDecisionPatch(decision, patch)
 		result, err = c.patchResource(ctx, resource, patch)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to patch the finalizer of resource %s: %w", resource.GetName(), err)
@@ -300,6 +364,9 @@ func (c *StageController) playStage(ctx context.Context, resource *unstructured.
 	}
 
 	if next.Delete() {
+		if decision != nil {
+			decision.Delete = true
+		}
 		err = c.deleteResource(ctx, resource)
 		if err != nil {
 			return shouldRetry(err), fmt.Errorf("failed to delete resource %s: %w", resource.GetName(), err)
@@ -321,6 +388,7 @@ func (c *StageController) playStage(ctx context.Context, resource *unstructured.
 					"reason", "do not need to modify",
 				)
 			} else {
+				addDecisionPatch(decision, patch)
 				result, err = c.patchResource(ctx, resource, patch)
 				if err != nil {
 					return shouldRetry(err), fmt.Errorf("failed to patch resource %s: %w", resource.GetName(), err)
@@ -406,8 +474,9 @@ loop:
 			switch event.Type {
 			case informer.Added, informer.Modified, informer.Sync:
 				resource := event.Object
+				c.markLoadBalancerIP(resource)
 				if c.need(resource) {
-					c.preprocessChan <- resource.DeepCopy()
+					c.manage(ctx, resource.DeepCopy(), event.Type)
 				} else {
 					logger.Debug("Skip resource",
 						"reason", "not managed",
@@ -418,6 +487,8 @@ loop:
 
 			case informer.Deleted:
 				resource := event.Object
+				c.recyclingLoadBalancerIP(resource)
+				c.paused.Release(log.KObj(resource))
 				if c.need(resource) {
 					// Cancel delay job
 					key := log.KObj(resource).String()
@@ -425,8 +496,14 @@ loop:
 					if ok {
 						c.delayQueue.Cancel(resourceJob)
 					}
+					c.stageConcurrency.Forget(key)
+					c.attempts.Forget(key)
 				}
 			}
+		case <-c.paused.Changed():
+			for _, resource := range c.paused.Resumed() {
+				c.manage(ctx, resource, informer.Sync)
+			}
 		case <-ctx.Done():
 			break loop
 		}
@@ -434,6 +511,20 @@ loop:
 	logger.Info("Stop watch resources")
 }
 
+// manage sends the resource to preprocessChan if it is not paused
+func (c *StageController) manage(ctx context.Context, resource *unstructured.Unstructured, eventType informer.EventType) {
+	if c.paused.Hold(resource) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Skip resource",
+			"reason", "paused",
+			"event", eventType,
+			"resource", log.KObj(resource),
+		)
+		return
+	}
+	c.preprocessChan <- resource
+}
+
 // addStageJob adds a stage to be applied into the underlying weight delay queue and the associated helper map
 func (c *StageController) addStageJob(ctx context.Context, job resourceStageJob[*unstructured.Unstructured], delay time.Duration, weight int) {
 	old, loaded := c.delayQueueMapping.Swap(job.Key, job)
@@ -447,3 +538,35 @@ func (c *StageController) addStageJob(ctx context.Context, job resourceStageJob[
 	}
 	c.delayQueue.AddWeightAfter(job, weight, delay)
 }
+
+// markLoadBalancerIP marks the load balancer ip already assigned to the resource as used
+func (c *StageController) markLoadBalancerIP(resource *unstructured.Unstructured) {
+	if c.loadBalancerIPs == nil {
+		return
+	}
+	for _, ip := range loadBalancerIngressIPs(resource) {
+		c.loadBalancerIPs.Use(string(resource.GetUID()), ip)
+	}
+}
+
+// recyclingLoadBalancerIP recycling the load balancer ip of the resource
+func (c *StageController) recyclingLoadBalancerIP(resource *unstructured.Unstructured) {
+	if c.loadBalancerIPs == nil {
+		return
+	}
+	c.loadBalancerIPs.Put(string(resource.GetUID()))
+}
+
+func (c *StageController) funcLoadBalancerIP(uid string) (string, error) {
+	if c.loadBalancerIPs == nil {
+		return "", fmt.Errorf("load balancer cidr is not configured")
+	}
+	return c.loadBalancerIPs.Get(uid), nil
+}
+
+func (c *StageController) funcSignCSR(spec map[string]any) (string, error) {
+	if c.csrSigner == nil {
+		return "", fmt.Errorf("csr signer is not configured")
+	}
+	return c.csrSigner.Sign(spec)
+}
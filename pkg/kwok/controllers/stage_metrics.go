@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	stageLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kwok_stage_latency_seconds",
+			Help:    "Seconds from the stage matched to the object until it is played, including the delay of the stage",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 16),
+		},
+		[]string{"kind", "stage"},
+	)
+	stageLag = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kwok_stage_lag_seconds",
+			Help:    "Seconds between the expiration of the delay of the stage and playing it, which grows when the controller can't keep up with the load",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+		[]string{"kind", "stage"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(stageLatency, stageLag)
+}
+
+// observeStageLatency observes the latency of playing the stage of the job,
+// the retries are delayed by the backoff rather than the stage, so they aren't observed.
+func observeStageLatency[T metav1.Object](kind string, job resourceStageJob[T], now time.Time) {
+	if job.DecidedTime.IsZero() || atomic.LoadUint64(job.RetryCount) != 0 {
+		return
+	}
+	latency := now.Sub(job.DecidedTime)
+	lag := latency - job.Delay
+	if lag < 0 {
+		lag = 0
+	}
+	stageLatency.WithLabelValues(kind, job.Stage.Name()).Observe(latency.Seconds())
+	stageLag.WithLabelValues(kind, job.Stage.Name()).Observe(lag.Seconds())
+}
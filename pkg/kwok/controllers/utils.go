@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -34,9 +35,27 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
+// accelerate divides the delay by the time acceleration, 0 or 1 means real time
+func accelerate(delay time.Duration, timeAcceleration float64) time.Duration {
+	if timeAcceleration <= 0 || timeAcceleration == 1 {
+		return delay
+	}
+	return time.Duration(float64(delay) / timeAcceleration)
+}
+
+// accelerateTime moves the time away from the start by the time acceleration,
+// so that the timestamps pass as fast as the accelerated delays, 0 or 1 means real time
+func accelerateTime(now, start time.Time, timeAcceleration float64) time.Time {
+	if timeAcceleration <= 0 || timeAcceleration == 1 {
+		return now
+	}
+	return start.Add(time.Duration(float64(now.Sub(start)) * timeAcceleration))
+}
+
 func parseCIDR(s string) (*net.IPNet, error) {
 	return utilsnet.ParseCIDR(s)
 }
@@ -124,9 +143,17 @@ type resourceStageJob[T any] struct {
 	Resource T
 	Stage    *lifecycle.Stage
 	Key      string
+	// Slot is the concurrency slot of the stage held by the job, empty if the stage is unlimited.
+	Slot string
 	// RetryCount is used for tracking the retry times of a job.
 	// Must be initialized to 0.
 	RetryCount *uint64
+	// DecidedTime is when the stage was matched to the resource.
+	DecidedTime time.Time
+	// Delay is the delay of the stage.
+	Delay time.Duration
+	// Attempt is the attempt of the transition of the resource the stage was matched in.
+	Attempt uint64
 }
 
 // defaultBackoff provides a backoff setting for kwok controllers to apply failed jobs
@@ -134,12 +161,14 @@ func defaultBackoff() wait.Backoff {
 	return wait.Backoff{Duration: 1 * time.Second, Factor: 2.0, Jitter: 0.2, Cap: 32 * time.Minute}
 }
 
-// backoffDelayByStep calculates the backoff delay period based on steps
-func backoffDelayByStep(steps uint64, c wait.Backoff) time.Duration {
+// backoffDelayByStep calculates the backoff delay period based on steps,
+// the jitter is derived from the key and the attempt of the job, so it is reproducible with the seed
+func backoffDelayByStep(key string, attempt uint64, steps uint64, c wait.Backoff) time.Duration {
 	delay := math.Min(
 		float64(c.Duration)*math.Pow(c.Factor, float64(steps)),
 		float64(c.Cap))
-	return wait.Jitter(time.Duration(delay), c.Jitter)
+	source := rand.New(key, strconv.FormatUint(attempt, 10), "backoff", strconv.FormatUint(steps, 10))
+	return wait.JitterWithSource(source, time.Duration(delay), c.Jitter)
 }
 
 // shouldRetry determines if a certain error needs to be retried
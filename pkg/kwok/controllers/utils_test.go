@@ -23,9 +23,12 @@ import (
 	"reflect"
 	"syscall"
 	"testing"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 func Test_parseCIDR(t *testing.T) {
@@ -188,3 +191,66 @@ func Test_shouldRetry(t *testing.T) {
 		})
 	}
 }
+
+func Test_accelerate(t *testing.T) {
+	testCases := []struct {
+		delay            time.Duration
+		timeAcceleration float64
+		expected         time.Duration
+	}{
+		{delay: time.Minute, timeAcceleration: 0, expected: time.Minute},
+		{delay: time.Minute, timeAcceleration: 1, expected: time.Minute},
+		{delay: time.Minute, timeAcceleration: 10, expected: 6 * time.Second},
+		{delay: time.Minute, timeAcceleration: 0.5, expected: 2 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		if got := accelerate(tc.delay, tc.timeAcceleration); got != tc.expected {
+			t.Errorf("accelerate(%s, %v) = %s, expected %s", tc.delay, tc.timeAcceleration, got, tc.expected)
+		}
+	}
+}
+
+func Test_accelerateTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+	testCases := []struct {
+		timeAcceleration float64
+		expected         time.Time
+	}{
+		{timeAcceleration: 0, expected: now},
+		{timeAcceleration: 1, expected: now},
+		{timeAcceleration: 10, expected: start.Add(10 * time.Minute)},
+		{timeAcceleration: 0.5, expected: start.Add(30 * time.Second)},
+	}
+
+	for _, tc := range testCases {
+		if got := accelerateTime(now, start, tc.timeAcceleration); !got.Equal(tc.expected) {
+			t.Errorf("accelerateTime(%s, %s, %v) = %s, expected %s", now, start, tc.timeAcceleration, got, tc.expected)
+		}
+	}
+}
+
+func Test_backoffDelayByStep(t *testing.T) {
+	rand.Seed(42)
+	defer rand.Seed(time.Now().UnixNano())
+
+	backoff := defaultBackoff()
+	first := backoffDelayByStep("default/pod", 0, 2, backoff)
+	// Draw from the global sequence in between to show it doesn't affect the jitter
+	_ = rand.Float64()
+	if got := backoffDelayByStep("default/pod", 0, 2, backoff); got != first {
+		t.Errorf("expected the same jitter of the same job, got %s and %s", first, got)
+	}
+	if first < 4*time.Second || first > 4*time.Second+4*time.Second/5 {
+		t.Errorf("expected the delay of step 2 in [4s, 4.8s], got %s", first)
+	}
+
+	delays := map[time.Duration]struct{}{}
+	for attempt := uint64(0); attempt < 8; attempt++ {
+		delays[backoffDelayByStep("default/pod", attempt, 2, backoff)] = struct{}{}
+	}
+	if len(delays) == 1 {
+		t.Errorf("expected the jitter to change with the attempt, got %v", delays)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decisions provides the log of the decisions made by kwok-controller,
+// which stage is played on which object, with what patches and after how long,
+// so that how a simulation evolved can be analyzed after the run.
+package decisions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Decision is a stage played on an object.
+type Decision struct {
+	// Time is when the stage was played.
+	Time time.Time `json:"time"`
+	// DecidedTime is when the stage was matched to the object.
+	DecidedTime time.Time `json:"decidedTime"`
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+	// Stage is the name of the stage.
+	Stage string `json:"stage"`
+	// Delay is the delay of the stage, after the time acceleration.
+	Delay metav1.Duration `json:"delay"`
+	// Retry is the number of the previous failed attempts to play the stage.
+	Retry uint64 `json:"retry,omitempty"`
+	// Patches is the rendered patches applied to the object.
+	Patches []Patch `json:"patches,omitempty"`
+	// Delete is true if the object is deleted by the stage.
+	Delete bool `json:"delete,omitempty"`
+	// Error is the error of playing the stage.
+	Error string `json:"error,omitempty"`
+}
+
+// Patch is a rendered patch of a stage.
+type Patch struct {
+	// Subresource is the subresource the patch is applied to.
+	Subresource string `json:"subresource,omitempty"`
+	// Type is the type of the patch.
+	Type string `json:"type"`
+	// Data is the content of the patch.
+	Data json.RawMessage `json:"data"`
+}
+
+// Recorder writes the decisions as JSON lines.
+type Recorder struct {
+	mut    sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+// NewRecorder returns a new Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	r := &Recorder{
+		enc: json.NewEncoder(w),
+	}
+	if c, ok := w.(io.Closer); ok {
+		r.closer = c
+	}
+	return r
+}
+
+// NewFileRecorder returns a new Recorder appending to the file.
+func NewFileRecorder(path string) (*Recorder, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0750)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecorder(f), nil
+}
+
+// Record writes the decision.
+func (r *Recorder) Record(d *Decision) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.enc.Encode(d)
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (r *Recorder) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.closer.Close()
+}
+
+// Decode reads the decisions written by a Recorder and calls fn for each of them.
+func Decode(r io.Reader, fn func(d *Decision) error) error {
+	dec := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		d := &Decision{}
+		err := dec.Decode(d)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode the decision %d: %w", i, err)
+		}
+		err = fn(d)
+		if err != nil {
+			return err
+		}
+	}
+}
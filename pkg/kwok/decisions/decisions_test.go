@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordAndDecode(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := []*Decision{
+		{
+			Time:        now.Add(time.Second),
+			DecidedTime: now,
+			Kind:        "Pod",
+			Namespace:   "default",
+			Name:        "pod-0",
+			Stage:       "pod-ready",
+			Delay:       metav1.Duration{Duration: time.Second},
+			Patches: []Patch{
+				{
+					Subresource: "status",
+					Type:        "application/merge-patch+json",
+					Data:        json.RawMessage(`{"status":{"phase":"Running"}}`),
+				},
+			},
+		},
+		{
+			Time:        now.Add(2 * time.Second),
+			DecidedTime: now.Add(time.Second),
+			Kind:        "Pod",
+			Namespace:   "default",
+			Name:        "pod-0",
+			Stage:       "pod-delete",
+			Delete:      true,
+			Error:       "not found",
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	r := NewRecorder(buf)
+	for _, d := range want {
+		err := r.Record(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*Decision
+	err := Decode(buf, func(d *Decision) error {
+		got = append(got, d)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected decisions (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	err := Decode(bytes.NewBufferString(`{"kind":"Pod"}`+"\n"+`{`), func(d *Decision) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestSummarizer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	decisions := []*Decision{
+		{
+			Time:        now.Add(2 * time.Second),
+			DecidedTime: now,
+			Kind:        "Pod",
+			Name:        "pod-0",
+			Stage:       "pod-ready",
+			Delay:       metav1.Duration{Duration: time.Second},
+		},
+		{
+			Time:        now.Add(5 * time.Second),
+			DecidedTime: now.Add(time.Second),
+			Kind:        "Pod",
+			Name:        "pod-1",
+			Stage:       "pod-ready",
+			Delay:       metav1.Duration{Duration: 3 * time.Second},
+			Error:       "conflict",
+		},
+		{
+			Time:        now.Add(8 * time.Second),
+			DecidedTime: now.Add(time.Second),
+			Kind:        "Pod",
+			Name:        "pod-1",
+			Stage:       "pod-ready",
+			Delay:       metav1.Duration{Duration: 3 * time.Second},
+			Retry:       1,
+		},
+		{
+			Time:        now,
+			DecidedTime: now,
+			Kind:        "Node",
+			Name:        "node-0",
+			Stage:       "node-initialize",
+		},
+	}
+
+	s := NewSummarizer()
+	for _, d := range decisions {
+		s.Add(d)
+	}
+
+	want := []StageSummary{
+		{
+			Kind:    "Node",
+			Stage:   "node-initialize",
+			Count:   1,
+			Objects: 1,
+			First:   now,
+			Last:    now,
+		},
+		{
+			Kind:      "Pod",
+			Stage:     "pod-ready",
+			Count:     3,
+			Objects:   2,
+			Errors:    1,
+			Retries:   1,
+			MinDelay:  time.Second,
+			MeanDelay: 7 * time.Second / 3,
+			MaxDelay:  3 * time.Second,
+			MeanLag:   time.Second,
+			MaxLag:    time.Second,
+			First:     now.Add(2 * time.Second),
+			Last:      now.Add(8 * time.Second),
+		},
+	}
+	got := s.Summary()
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(StageSummary{})); diff != "" {
+		t.Errorf("unexpected summary (-want +got):\n%s", diff)
+	}
+}
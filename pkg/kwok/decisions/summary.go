@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"sort"
+	"time"
+)
+
+// StageSummary is the summary of the decisions of a stage.
+type StageSummary struct {
+	Kind  string
+	Stage string
+	// Count is the number of the decisions.
+	Count int
+	// Objects is the number of the distinct objects the stage is played on.
+	Objects int
+	// Errors is the number of the decisions which failed.
+	Errors int
+	// Retries is the number of the decisions which are retries of the failed ones.
+	Retries int
+	// Deletes is the number of the objects deleted by the stage.
+	Deletes int
+	// MinDelay, MeanDelay and MaxDelay are the statistics of the delays of the stage.
+	MinDelay  time.Duration
+	MeanDelay time.Duration
+	MaxDelay  time.Duration
+	// MeanLag and MaxLag are the statistics of the time between the expiration of the delay and playing the stage,
+	// which grows when kwok-controller can't keep up with the load.
+	MeanLag time.Duration
+	MaxLag  time.Duration
+	// First and Last are when the stage was played for the first and the last time.
+	First time.Time
+	Last  time.Time
+
+	objects  map[string]struct{}
+	sumDelay time.Duration
+	sumLag   time.Duration
+}
+
+// Summarizer aggregates the decisions into the summaries of the stages.
+type Summarizer struct {
+	stages map[[2]string]*StageSummary
+}
+
+// NewSummarizer returns a new Summarizer.
+func NewSummarizer() *Summarizer {
+	return &Summarizer{
+		stages: map[[2]string]*StageSummary{},
+	}
+}
+
+// Add adds the decision to the summary.
+func (s *Summarizer) Add(d *Decision) {
+	key := [2]string{d.Kind, d.Stage}
+	sum, ok := s.stages[key]
+	if !ok {
+		sum = &StageSummary{
+			Kind:     d.Kind,
+			Stage:    d.Stage,
+			MinDelay: d.Delay.Duration,
+			First:    d.Time,
+			objects:  map[string]struct{}{},
+		}
+		s.stages[key] = sum
+	}
+
+	sum.Count++
+	sum.objects[d.Namespace+"/"+d.Name] = struct{}{}
+	if d.Error != "" {
+		sum.Errors++
+	}
+	if d.Retry != 0 {
+		sum.Retries++
+	}
+	if d.Delete && d.Error == "" {
+		sum.Deletes++
+	}
+
+	delay := d.Delay.Duration
+	sum.sumDelay += delay
+	if delay < sum.MinDelay {
+		sum.MinDelay = delay
+	}
+	if delay > sum.MaxDelay {
+		sum.MaxDelay = delay
+	}
+
+	// The retries are delayed by the backoff rather than the stage, so their lag isn't counted
+	if d.Retry == 0 && !d.DecidedTime.IsZero() {
+		lag := d.Time.Sub(d.DecidedTime) - delay
+		if lag < 0 {
+			lag = 0
+		}
+		sum.sumLag += lag
+		if lag > sum.MaxLag {
+			sum.MaxLag = lag
+		}
+	}
+
+	if d.Time.Before(sum.First) {
+		sum.First = d.Time
+	}
+	if d.Time.After(sum.Last) {
+		sum.Last = d.Time
+	}
+}
+
+// Summary returns the summaries of the stages, sorted by the kind and the time the stage was first played.
+func (s *Summarizer) Summary() []StageSummary {
+	out := make([]StageSummary, 0, len(s.stages))
+	for _, sum := range s.stages {
+		item := *sum
+		item.Objects = len(sum.objects)
+		item.MeanDelay = sum.sumDelay / time.Duration(sum.Count)
+		if n := sum.Count - sum.Retries; n > 0 {
+			item.MeanLag = sum.sumLag / time.Duration(n)
+		}
+		item.objects = nil
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		if !out[i].First.Equal(out[j].First) {
+			return out[i].First.Before(out[j].First)
+		}
+		return out[i].Stage < out[j].Stage
+	})
+	return out
+}
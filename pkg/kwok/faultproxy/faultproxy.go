@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package faultproxy provides a proxy of the kube-apiserver which injects latency, errors and throttling into the requests,
+// so that the backoff of clients and the resilience of controllers can be tested against a degraded API.
+package faultproxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
+)
+
+// throttleRetryAfterSeconds is the Retry-After of the throttled requests.
+const throttleRetryAfterSeconds = 1
+
+// Handler injects the faults of the first matching rule into the requests, and forwards them to the upstream.
+type Handler struct {
+	upstream        http.Handler
+	rules           []Rule
+	requestInfoFunc request.RequestInfoResolver
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(upstream http.Handler, rules []Rule) *Handler {
+	return &Handler{
+		upstream: upstream,
+		rules:    rules,
+		requestInfoFunc: &request.RequestInfoFactory{
+			APIPrefixes:          sets.NewString("api", "apis"),
+			GrouplessAPIPrefixes: sets.NewString("api"),
+		},
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rule, ok := h.match(r)
+	if !ok {
+		h.upstream.ServeHTTP(rw, r)
+		return
+	}
+
+	delay := rule.Delay
+	if rule.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(rule.Jitter)))
+	}
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if rule.ErrorRate > 0 || rule.ThrottleRate > 0 {
+		n := rand.Float64()
+		switch {
+		case n < rule.ThrottleRate:
+			rw.Header().Set("Retry-After", fmt.Sprint(throttleRetryAfterSeconds))
+			writeStatus(rw, apierrors.NewTooManyRequests("the request is throttled by the fault proxy", throttleRetryAfterSeconds))
+			return
+		case n < rule.ThrottleRate+rule.ErrorRate:
+			writeStatus(rw, apierrors.NewInternalError(fmt.Errorf("the request is failed by the fault proxy")))
+			return
+		}
+	}
+
+	h.upstream.ServeHTTP(rw, r)
+}
+
+func (h *Handler) match(r *http.Request) (Rule, bool) {
+	if len(h.rules) == 0 {
+		return Rule{}, false
+	}
+	info, err := h.requestInfoFunc.NewRequestInfo(r)
+	if err != nil {
+		logger := log.FromContext(r.Context())
+		logger.Warn("Failed to resolve the request", "url", r.URL, "err", err)
+		return Rule{}, false
+	}
+	if !info.IsResourceRequest {
+		return Rule{}, false
+	}
+	for _, rule := range h.rules {
+		if rule.Matches(info.Verb, info.Resource, info.Subresource) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func writeStatus(rw http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.Status()
+	status.TypeMeta = metav1.TypeMeta{
+		Kind:       "Status",
+		APIVersion: "v1",
+	}
+	data, _ := json.Marshal(status)
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(int(status.Code))
+	_, _ = rw.Write(data)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultproxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandler(t *testing.T) {
+	upstream := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	handler := NewHandler(upstream, []Rule{
+		{Verb: "list", Resource: "pods", ThrottleRate: 1},
+		{Verb: "get", Resource: "nodes", ErrorRate: 1},
+		{Resource: "configmaps", Delay: 50 * time.Millisecond},
+	})
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(method, path, nil))
+		return rw
+	}
+
+	rw := serve(http.MethodGet, "/api/v1/namespaces/default/pods")
+	if rw.Code != http.StatusTooManyRequests {
+		t.Errorf("unexpected status of listing pods %d", rw.Code)
+	}
+	if got := rw.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("unexpected Retry-After %q", got)
+	}
+	var status metav1.Status
+	err := json.Unmarshal(rw.Body.Bytes(), &status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Reason != metav1.StatusReasonTooManyRequests {
+		t.Errorf("unexpected reason %q", status.Reason)
+	}
+
+	rw = serve(http.MethodGet, "/api/v1/namespaces/default/pods/foo")
+	if rw.Code != http.StatusOK {
+		t.Errorf("unexpected status of getting a pod %d", rw.Code)
+	}
+
+	rw = serve(http.MethodGet, "/api/v1/nodes/foo")
+	if rw.Code != http.StatusInternalServerError {
+		t.Errorf("unexpected status of getting a node %d", rw.Code)
+	}
+
+	start := time.Now()
+	rw = serve(http.MethodGet, "/api/v1/namespaces/default/configmaps")
+	if rw.Code != http.StatusOK {
+		t.Errorf("unexpected status of listing configmaps %d", rw.Code)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("the request is not delayed, elapsed %v", elapsed)
+	}
+
+	rw = serve(http.MethodGet, "/healthz")
+	if rw.Code != http.StatusOK {
+		t.Errorf("unexpected status of healthz %d", rw.Code)
+	}
+}
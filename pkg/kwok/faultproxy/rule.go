@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultproxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule is a fault injected into the requests which match the verb and the resource.
+type Rule struct {
+	// Verb is the verb of the requests, e.g. get, list, watch, create, update, patch, delete, empty matches all.
+	Verb string
+	// Resource is the resource of the requests, optionally with the subresource, e.g. pods or pods/status, empty matches all.
+	Resource string
+	// Delay is the latency added before the requests are forwarded.
+	Delay time.Duration
+	// Jitter is the maximum random latency added on top of the delay.
+	Jitter time.Duration
+	// ErrorRate is the fraction of the requests which are answered with 500 Internal Server Error.
+	ErrorRate float64
+	// ThrottleRate is the fraction of the requests which are answered with 429 Too Many Requests.
+	ThrottleRate float64
+}
+
+// ParseRule parses a rule in the form of comma-separated key=value pairs,
+// e.g. "verb=list,resource=pods,delay=500ms,jitter=100ms,error-rate=0.1,throttle-rate=0.05".
+func ParseRule(s string) (Rule, error) {
+	var rule Rule
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("invalid fault %q: %q is not key=value", s, item)
+		}
+		var err error
+		switch key {
+		case "verb":
+			rule.Verb = value
+		case "resource":
+			rule.Resource = value
+		case "delay":
+			rule.Delay, err = time.ParseDuration(value)
+		case "jitter":
+			rule.Jitter, err = time.ParseDuration(value)
+		case "error-rate":
+			rule.ErrorRate, err = parseRate(value)
+		case "throttle-rate":
+			rule.ThrottleRate, err = parseRate(value)
+		default:
+			return Rule{}, fmt.Errorf("invalid fault %q: unknown key %q", s, key)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid fault %q: %s: %w", s, key, err)
+		}
+	}
+	if rule.ErrorRate+rule.ThrottleRate > 1 {
+		return Rule{}, fmt.Errorf("invalid fault %q: the sum of error-rate and throttle-rate is greater than 1", s)
+	}
+	return rule, nil
+}
+
+// ParseRules parses the rules, see ParseRule.
+func ParseRules(rules []string) ([]Rule, error) {
+	out := make([]Rule, 0, len(rules))
+	for _, s := range rules {
+		rule, err := ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+func parseRate(s string) (float64, error) {
+	rate, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("%v is not in [0, 1]", rate)
+	}
+	return rate, nil
+}
+
+// Matches returns true if the rule matches the verb and the resource of the request.
+func (r Rule) Matches(verb, resource, subresource string) bool {
+	if r.Verb != "" && r.Verb != verb {
+		return false
+	}
+	if r.Resource == "" {
+		return true
+	}
+	if subresource != "" {
+		return r.Resource == resource+"/"+subresource
+	}
+	return r.Resource == resource
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultproxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Rule
+		wantErr bool
+	}{
+		{
+			name: "all",
+			s:    "verb=list,resource=pods,delay=500ms,jitter=100ms,error-rate=0.1,throttle-rate=0.05",
+			want: Rule{
+				Verb:         "list",
+				Resource:     "pods",
+				Delay:        500 * time.Millisecond,
+				Jitter:       100 * time.Millisecond,
+				ErrorRate:    0.1,
+				ThrottleRate: 0.05,
+			},
+		},
+		{
+			name: "subresource",
+			s:    "resource=pods/status, throttle-rate=1",
+			want: Rule{
+				Resource:     "pods/status",
+				ThrottleRate: 1,
+			},
+		},
+		{
+			name:    "unknown key",
+			s:       "verbs=list",
+			wantErr: true,
+		},
+		{
+			name:    "not key value",
+			s:       "list",
+			wantErr: true,
+		},
+		{
+			name:    "rate out of range",
+			s:       "error-rate=2",
+			wantErr: true,
+		},
+		{
+			name:    "sum of rates out of range",
+			s:       "error-rate=0.6,throttle-rate=0.6",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRule(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseRule() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        Rule
+		verb        string
+		resource    string
+		subresource string
+		want        bool
+	}{
+		{name: "empty", rule: Rule{}, verb: "get", resource: "pods", want: true},
+		{name: "verb", rule: Rule{Verb: "list"}, verb: "get", resource: "pods", want: false},
+		{name: "resource", rule: Rule{Resource: "pods"}, verb: "get", resource: "pods", want: true},
+		{name: "resource of subresource", rule: Rule{Resource: "pods"}, verb: "patch", resource: "pods", subresource: "status", want: false},
+		{name: "subresource", rule: Rule{Resource: "pods/status"}, verb: "patch", resource: "pods", subresource: "status", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.verb, tt.resource, tt.subresource); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
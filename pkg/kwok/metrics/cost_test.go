@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/kustomize/metrics/cost"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func TestMetricsCost(t *testing.T) {
+	metric, err := config.UnmarshalWithType[*internalversion.Metric](cost.DefaultMetricsCost)
+	if err != nil {
+		t.Fatalf("failed to unmarshal metrics cost: %v", err)
+	}
+
+	env, err := NewEnvironment(EnvironmentConfig{
+		PodResourceUsage: func(resourceName, podNamespace, podName string) float64 {
+			return 0.5
+		},
+		NodeResourceUsage: func(resourceName, nodeName string) float64 {
+			if nodeName == "idle" {
+				return 0
+			}
+			return 2
+		},
+		PodResourceCumulativeUsage: func(resourceName, podNamespace, podName string) float64 {
+			return 25
+		},
+		NodeResourceCumulativeUsage: func(resourceName, nodeName string) float64 {
+			return 100
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to instantiate node Evaluator: %v", err)
+	}
+
+	evaluators := map[string]*Evaluator{}
+	for _, m := range metric.Spec.Metrics {
+		eval, err := env.Compile(m.Value)
+		if err != nil {
+			t.Fatalf("failed to compile expression of %s: %v", m.Name, err)
+		}
+		evaluators[m.Name] = eval
+	}
+
+	newNode := func(name string, age time.Duration, labels, annotations map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Labels:            labels,
+				Annotations:       annotations,
+				CreationTimestamp: metav1.Time{Time: time.Now().Add(-age)},
+			},
+		}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		node   *corev1.Node
+		pod    *corev1.Pod
+		want   float64
+	}{
+		{
+			name:   "price from instance type",
+			metric: "node_hourly_cost",
+			node:   newNode("node", time.Hour, map[string]string{"node.kubernetes.io/instance-type": "m5.large"}, nil),
+			want:   0.096,
+		},
+		{
+			name:   "price from annotation",
+			metric: "node_hourly_cost",
+			node: newNode("node", time.Hour,
+				map[string]string{"node.kubernetes.io/instance-type": "m5.large"},
+				map[string]string{"kwok.x-k8s.io/hourly-price": "0.5"},
+			),
+			want: 0.5,
+		},
+		{
+			name:   "default price of unknown instance type",
+			metric: "node_hourly_cost",
+			node:   newNode("node", time.Hour, map[string]string{"node.kubernetes.io/instance-type": "unknown"}, nil),
+			want:   0.1,
+		},
+		{
+			name:   "default price without instance type",
+			metric: "node_hourly_cost",
+			node:   newNode("node", time.Hour, nil, nil),
+			want:   0.1,
+		},
+		{
+			name:   "node cost since created",
+			metric: "node_cost_total",
+			node:   newNode("node", 2*time.Hour, map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge"}, nil),
+			want:   0.384,
+		},
+		{
+			name:   "pod hourly cost by share of cpu usage",
+			metric: "pod_hourly_cost",
+			node:   newNode("node", time.Hour, map[string]string{"node.kubernetes.io/instance-type": "m5.large"}, nil),
+			pod:    pod,
+			want:   0.024,
+		},
+		{
+			name:   "pod hourly cost on idle node",
+			metric: "pod_hourly_cost",
+			node:   newNode("idle", time.Hour, map[string]string{"node.kubernetes.io/instance-type": "m5.large"}, nil),
+			pod:    pod,
+			want:   0,
+		},
+		{
+			name:   "pod cost by share of cumulative cpu usage",
+			metric: "pod_cost_total",
+			node:   newNode("node", 10*time.Hour, map[string]string{"node.kubernetes.io/instance-type": "e2-standard-4"}, nil),
+			pod:    pod,
+			want:   0.335,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval, ok := evaluators[tt.metric]
+			if !ok {
+				t.Fatalf("metric %s not found", tt.metric)
+			}
+			got, err := eval.EvaluateFloat64(context.Background(), Data{
+				Node: tt.node,
+				Pod:  tt.pod,
+			})
+			if err != nil {
+				t.Fatalf("evaluation failed: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kwok/pkg/utils/cel"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
@@ -58,12 +60,21 @@ func NewEnvironment(conf EnvironmentConfig) (*Environment, error) {
 
 		usageName           = "Usage"
 		cumulativeUsageName = "CumulativeUsage"
+
+		randName = "Rand"
 	)
+	e := &Environment{
+		conf: conf,
+	}
+
 	types := slices.Clone(cel.DefaultTypes)
 	conversions := slices.Clone(cel.DefaultConversions)
 	funcs := maps.Clone(cel.DefaultFuncs)
 	methods := maps.Clone(cel.FuncsToMethods(cel.DefaultFuncs))
 
+	// Rand is drawn from the random numbers of the object being evaluated
+	funcs[randName] = []any{e.randFloat64}
+
 	if conf.Now != nil {
 		funcs[nowOldName] = []any{conf.Now}
 		funcs[nowName] = []any{conf.Now}
@@ -131,10 +142,7 @@ func NewEnvironment(conf EnvironmentConfig) (*Environment, error) {
 	if err != nil {
 		return nil, err
 	}
-	e := &Environment{
-		env:  env,
-		conf: conf,
-	}
+	e.env = env
 
 	if conf.EnableResultCache {
 		e.resultCacheVer = new(int64)
@@ -149,6 +157,17 @@ type Environment struct {
 
 	conf           EnvironmentConfig
 	resultCacheVer *int64
+
+	randMut      sync.Mutex
+	randSource   *rand.Source
+	randAttempts rand.Attempts
+}
+
+func (e *Environment) randFloat64() float64 {
+	if e.randSource == nil {
+		return rand.Float64()
+	}
+	return e.randSource.Float64()
 }
 
 // Compile is responsible for compiling a cel program
@@ -162,6 +181,10 @@ func (e *Environment) Compile(src string) (*Evaluator, error) {
 		program:        program,
 		latestCacheVer: e.resultCacheVer,
 	}
+	if strings.Contains(src, "Rand(") {
+		evaluator.src = src
+		evaluator.env = e
+	}
 	return evaluator, nil
 }
 
@@ -177,6 +200,10 @@ func (e *Environment) ClearResultCache() {
 type Evaluator struct {
 	program cel.Program
 
+	// env is set if the program calls Rand
+	env *Environment
+	src string
+
 	latestCacheVer *int64
 	cacheVer       int64
 
@@ -198,6 +225,20 @@ func resultUniqueKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Conta
 	return strings.Join(tmp, "/")
 }
 
+func resultObjectKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Container) string {
+	tmp := make([]string, 0, 4)
+	if node != nil {
+		tmp = append(tmp, node.Name)
+	}
+	if pod != nil {
+		tmp = append(tmp, pod.Namespace, pod.Name)
+	}
+	if container != nil {
+		tmp = append(tmp, container.Name)
+	}
+	return strings.Join(tmp, "/")
+}
+
 func (e *Evaluator) evaluate(ctx context.Context, data Data) (cel.Val, error) {
 	var key string
 	if e.latestCacheVer != nil {
@@ -215,6 +256,18 @@ func (e *Evaluator) evaluate(ctx context.Context, data Data) (cel.Val, error) {
 		}
 	}
 
+	if e.env != nil {
+		// The numbers of Rand are derived from the seed, the program, the object and the count of its evaluations,
+		// so they don't depend on the order the objects are evaluated in.
+		e.env.randMut.Lock()
+		defer e.env.randMut.Unlock()
+		key := e.src + "\x00" + resultObjectKey(data.Node, data.Pod, data.Container)
+		e.env.randSource = rand.New(key, strconv.FormatUint(e.env.randAttempts.Next(key), 10))
+		defer func() {
+			e.env.randSource = nil
+		}()
+	}
+
 	refVal, _, err := e.program.ContextEval(ctx, map[string]any{
 		"node":      data.Node,
 		"pod":       data.Pod,
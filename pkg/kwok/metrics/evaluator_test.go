@@ -18,12 +18,15 @@ package metrics
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 func TestNodeEvaluation(t *testing.T) {
@@ -116,3 +119,85 @@ func TestResourceEvaluation(t *testing.T) {
 		t.Errorf("expected %v, got %v", 18, actual)
 	}
 }
+
+func TestOwnerReferenceEvaluation(t *testing.T) {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "ReplicaSet",
+					Name: "foo",
+				},
+			},
+		},
+	}
+
+	exp := `pod.metadata.ownerReferences.size() > 0 ? pod.metadata.ownerReferences[0].kind + "/" + pod.metadata.ownerReferences[0].name : ""`
+
+	env, err := NewEnvironment(EnvironmentConfig{})
+	if err != nil {
+		t.Fatalf("failed to instantiate node Evaluator: %v", err)
+	}
+
+	eval, err := env.Compile(exp)
+	if err != nil {
+		t.Fatalf("failed to compile expression: %v", err)
+	}
+
+	actual, err := eval.EvaluateString(context.Background(), Data{
+		Pod: p,
+	})
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+
+	if actual != "ReplicaSet/foo" {
+		t.Errorf("expected %v, got %v", "ReplicaSet/foo", actual)
+	}
+}
+
+func TestRandEvaluation(t *testing.T) {
+	rand.Seed(42)
+	defer rand.Seed(time.Now().UnixNano())
+
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1"}},
+	}
+
+	// evaluate evaluates Rand twice on each pod in the order
+	evaluate := func(order []int) map[string][]float64 {
+		env, err := NewEnvironment(EnvironmentConfig{})
+		if err != nil {
+			t.Fatalf("failed to instantiate node Evaluator: %v", err)
+		}
+		eval, err := env.Compile("Rand()")
+		if err != nil {
+			t.Fatalf("failed to compile expression: %v", err)
+		}
+		out := map[string][]float64{}
+		for i := 0; i < 2; i++ {
+			for _, o := range order {
+				// Draw from the global sequence in between to show it doesn't affect the numbers
+				_ = rand.Float64()
+				actual, err := eval.EvaluateFloat64(context.Background(), Data{Pod: pods[o]})
+				if err != nil {
+					t.Fatalf("evaluation failed: %v", err)
+				}
+				out[pods[o].Name] = append(out[pods[o].Name], actual)
+			}
+		}
+		return out
+	}
+
+	want := evaluate([]int{0, 1})
+	got := evaluate([]int{1, 0})
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("expected the same numbers of the pods in any order, got %v and %v", want, got)
+	}
+	for name, values := range want {
+		if values[0] == values[1] {
+			t.Errorf("expected new numbers on each evaluation of %s, got %v", name, values)
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/emicklei/go-restful/v3"
 	"github.com/fsnotify/fsnotify"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/util/flushwriter"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 
@@ -66,13 +67,24 @@ var (
 // GetContainerLogs returns logs for a container in a pod.
 // If follow is true, it streams the logs until the connection is closed by the client.
 func (s *Server) GetContainerLogs(ctx context.Context, podName, podNamespace, container string, logOptions *corev1.PodLogOptions, stdout, stderr io.Writer) error {
-	log, err := getPodLogs(s.logs.Get(), s.clusterLogs.Get(), podName, podNamespace, container)
+	podLog, err := getPodLogs(s.logs.Get(), s.clusterLogs.Get(), podName, podNamespace, container)
 	if err != nil {
 		return err
 	}
 
+	logsFile := podLog.LogsFile
+	if logOptions.Previous {
+		if podLog.PreviousLogsFile == "" {
+			return fmt.Errorf("previous terminated container %q in pod %q not found", container, log.KRef(podNamespace, podName))
+		}
+		logsFile = podLog.PreviousLogsFile
+	}
+
 	opts := newLogOptions(logOptions, time.Now())
-	return readLogs(ctx, log.LogsFile, opts, stdout, stderr)
+	// Like a terminated container, the logs are only followed if the simulation follows them,
+	// and the previous container never writes more logs.
+	opts.follow = opts.follow && podLog.Follow && !logOptions.Previous
+	return readLogs(ctx, logsFile, opts, stdout, stderr)
 }
 
 // getContainerLogs handles containerLogs request against the Kubelet
@@ -113,6 +125,10 @@ func (s *Server) getContainerLogs(request *restful.Request, response *restful.Re
 		_ = response.WriteError(http.StatusBadRequest, fmt.Errorf(`{"message": "Unable to decode query."}`))
 		return
 	}
+	if errs := validatePodLogOptions(logOptions); len(errs) > 0 {
+		_ = response.WriteError(http.StatusUnprocessableEntity, fmt.Errorf(`{"message": "Invalid request."}`))
+		return
+	}
 
 	if _, ok := response.ResponseWriter.(http.Flusher); !ok {
 		_ = response.WriteError(http.StatusInternalServerError, fmt.Errorf("unable to convert %v into http.Flusher, cannot show logs", reflect.TypeOf(response)))
@@ -126,6 +142,26 @@ func (s *Server) getContainerLogs(request *restful.Request, response *restful.Re
 	}
 }
 
+// validatePodLogOptions validates the options in the same way as the kubelet.
+func validatePodLogOptions(opts *corev1.PodLogOptions) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if opts.TailLines != nil && *opts.TailLines < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("tailLines"), *opts.TailLines, "must be greater than or equal to 0"))
+	}
+	if opts.LimitBytes != nil && *opts.LimitBytes < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("limitBytes"), *opts.LimitBytes, "must be greater than 0"))
+	}
+	switch {
+	case opts.SinceSeconds != nil && opts.SinceTime != nil:
+		allErrs = append(allErrs, field.Forbidden(field.NewPath(""), "at most one of `sinceTime` or `sinceSeconds` may be specified"))
+	case opts.SinceSeconds != nil:
+		if *opts.SinceSeconds < 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("sinceSeconds"), *opts.SinceSeconds, "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
 func getPodLogs(rules []*internalversion.Logs, clusterRules []*internalversion.ClusterLogs, podName, podNamespace, containerName string) (*internalversion.Log, error) {
 	l, has := slices.Find(rules, func(l *internalversion.Logs) bool {
 		return l.Name == podName && l.Namespace == podNamespace
@@ -34,6 +34,7 @@ import (
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/utils/format"
 )
 
@@ -290,6 +291,49 @@ func Test_logOptions(t *testing.T) {
 	}
 }
 
+func Test_validatePodLogOptions(t *testing.T) {
+	var (
+		negative  = int64(-1)
+		zero      = int64(0)
+		positive  = int64(10)
+		timestamp = metav1.Now()
+	)
+	for c, test := range []struct {
+		apiOpts *corev1.PodLogOptions
+		valid   bool
+	}{
+		{ // empty options
+			apiOpts: &corev1.PodLogOptions{},
+			valid:   true,
+		},
+		{ // zero tail lines
+			apiOpts: &corev1.PodLogOptions{TailLines: &zero},
+			valid:   true,
+		},
+		{ // negative tail lines
+			apiOpts: &corev1.PodLogOptions{TailLines: &negative},
+		},
+		{ // zero limit bytes
+			apiOpts: &corev1.PodLogOptions{LimitBytes: &zero},
+		},
+		{ // zero since seconds
+			apiOpts: &corev1.PodLogOptions{SinceSeconds: &zero},
+		},
+		{ // both since seconds and since time
+			apiOpts: &corev1.PodLogOptions{SinceSeconds: &positive, SinceTime: &timestamp},
+		},
+		{ // all valid
+			apiOpts: &corev1.PodLogOptions{TailLines: &positive, LimitBytes: &positive, SinceSeconds: &positive, Timestamps: true},
+			valid:   true,
+		},
+	} {
+		errs := validatePodLogOptions(test.apiOpts)
+		if valid := len(errs) == 0; valid != test.valid {
+			t.Errorf("TestCase #%d: expected valid %v, got errors %v", c, test.valid, errs)
+		}
+	}
+}
+
 func Test_readLogs(t *testing.T) {
 	file, err := os.CreateTemp("", "Test_readLogs")
 	if err != nil {
@@ -770,3 +814,50 @@ func TestReadLogsLimitsWithTimestamps(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestGetContainerLogsPrevious(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current.log")
+	previous := filepath.Join(dir, "previous.log")
+	if err := os.WriteFile(current, []byte("2024-01-01T00:00:00Z stdout F current\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(previous, []byte("2024-01-01T00:00:00Z stdout F previous\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		clusterLogs: resources.NewStaticGetter[[]*internalversion.ClusterLogs](nil),
+		logs: resources.NewStaticGetter([]*internalversion.Logs{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec: internalversion.LogsSpec{
+					Logs: []internalversion.Log{
+						{Containers: []string{"with-previous"}, LogsFile: current, PreviousLogsFile: previous},
+						{Containers: []string{"without-previous"}, LogsFile: current},
+					},
+				},
+			},
+		}),
+	}
+
+	for _, test := range []struct {
+		container string
+		previous  bool
+		want      string
+		wantErr   bool
+	}{
+		{container: "with-previous", want: "current\n"},
+		{container: "with-previous", previous: true, want: "previous\n"},
+		{container: "without-previous", previous: true, wantErr: true},
+	} {
+		stdout := bytes.NewBuffer(nil)
+		err := s.GetContainerLogs(context.Background(), "foo", "default", test.container, &corev1.PodLogOptions{Previous: test.previous}, stdout, io.Discard)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("GetContainerLogs(%q, previous=%v) error = %v, wantErr %v", test.container, test.previous, err, test.wantErr)
+		}
+		if got := stdout.String(); got != test.want {
+			t.Errorf("GetContainerLogs(%q, previous=%v) = %q, want %q", test.container, test.previous, got, test.want)
+		}
+	}
+}
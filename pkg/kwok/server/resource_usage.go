@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/emicklei/go-restful/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceUsageItem is the simulated resource usage of a node or a pod.
+type ResourceUsageItem struct {
+	// Namespace is the namespace of the pod, empty for a node.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the node or the pod.
+	Name string `json:"name"`
+	// NodeName is the name of the node that the pod is running on, empty for a node.
+	NodeName string `json:"nodeName,omitempty"`
+	// Usage is the usage of the resources, the cpu is in cores and the memory is in bytes.
+	Usage map[string]float64 `json:"usage"`
+}
+
+// NodeUtilizationItem is the simulated utilization of a node.
+type NodeUtilizationItem struct {
+	// Name is the name of the node.
+	Name string `json:"name"`
+	// Usage is the usage of the resources, the cpu is in cores and the memory is in bytes.
+	Usage map[string]float64 `json:"usage"`
+	// Allocatable is the allocatable resources of the node, in the same units as the usage.
+	Allocatable map[string]float64 `json:"allocatable"`
+	// Utilization is the percentage of the usage in the allocatable resources,
+	// the resources without allocatable are omitted.
+	Utilization map[string]float64 `json:"utilization"`
+}
+
+var usageResourceNames = []string{"cpu", "memory"}
+
+// InstallResourceUsage installs the handlers that serve the simulated resource usage.
+// It must be called after InstallMetrics, since the usage is evaluated in the same CEL environment.
+func (s *Server) InstallResourceUsage() error {
+	if s.env == nil {
+		return fmt.Errorf("CEL environment is not initialized")
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/usage")
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("/nodes").To(s.getNodesResourceUsage))
+	ws.Route(ws.GET("/nodes/utilization").To(s.getNodesUtilization))
+	ws.Route(ws.GET("/pods").To(s.getPodsResourceUsage))
+	s.restfulCont.Add(ws)
+	return nil
+}
+
+func (s *Server) getNodesResourceUsage(req *restful.Request, resp *restful.Response) {
+	nodeNames := s.dataSource.ListNodes()
+	sort.Strings(nodeNames)
+
+	items := make([]ResourceUsageItem, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		usage := map[string]float64{}
+		for _, resourceName := range usageResourceNames {
+			usage[resourceName] = s.nodeResourceUsage(resourceName, nodeName)
+		}
+		items = append(items, ResourceUsageItem{
+			Name:  nodeName,
+			Usage: usage,
+		})
+	}
+
+	err := resp.WriteAsJson(items)
+	if err != nil {
+		http.Error(resp.ResponseWriter, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) getPodsResourceUsage(req *restful.Request, resp *restful.Response) {
+	namespace := req.QueryParameter("namespace")
+
+	nodeNames := s.dataSource.ListNodes()
+	sort.Strings(nodeNames)
+
+	items := []ResourceUsageItem{}
+	for _, nodeName := range nodeNames {
+		pods, ok := s.dataSource.ListPods(nodeName)
+		if !ok {
+			continue
+		}
+		for _, pod := range pods {
+			if namespace != "" && pod.Namespace != namespace {
+				continue
+			}
+			usage := map[string]float64{}
+			for _, resourceName := range usageResourceNames {
+				usage[resourceName] = s.podResourceUsage(resourceName, pod.Namespace, pod.Name)
+			}
+			items = append(items, ResourceUsageItem{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				NodeName:  nodeName,
+				Usage:     usage,
+			})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	err := resp.WriteAsJson(items)
+	if err != nil {
+		http.Error(resp.ResponseWriter, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) getNodesUtilization(req *restful.Request, resp *restful.Response) {
+	nodeNames := s.dataSource.ListNodes()
+	sort.Strings(nodeNames)
+
+	items := make([]NodeUtilizationItem, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		node, ok := s.nodeCacheGetter.Get(nodeName)
+		if !ok {
+			continue
+		}
+		usage := map[string]float64{}
+		for _, resourceName := range usageResourceNames {
+			usage[resourceName] = s.nodeResourceUsage(resourceName, nodeName)
+		}
+		items = append(items, nodeUtilization(node, usage))
+	}
+
+	switch format := req.QueryParameter("format"); format {
+	case "", "json":
+		err := resp.WriteAsJson(items)
+		if err != nil {
+			http.Error(resp.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		}
+	case "prometheus":
+		resp.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		err := writeNodesUtilizationPrometheus(resp, items)
+		if err != nil {
+			http.Error(resp.ResponseWriter, err.Error(), http.StatusInternalServerError)
+		}
+	default:
+		http.Error(resp.ResponseWriter, fmt.Sprintf("unsupported format %q, it must be json or prometheus", format), http.StatusBadRequest)
+	}
+}
+
+func nodeUtilization(node *corev1.Node, usage map[string]float64) NodeUtilizationItem {
+	allocatable := map[string]float64{}
+	utilization := map[string]float64{}
+	for _, resourceName := range usageResourceNames {
+		q, ok := node.Status.Allocatable[corev1.ResourceName(resourceName)]
+		if !ok {
+			continue
+		}
+		a := q.AsApproximateFloat64()
+		allocatable[resourceName] = a
+		if a > 0 {
+			utilization[resourceName] = usage[resourceName] / a * 100
+		}
+	}
+	return NodeUtilizationItem{
+		Name:        node.Name,
+		Usage:       usage,
+		Allocatable: allocatable,
+		Utilization: utilization,
+	}
+}
+
+// writeNodesUtilizationPrometheus writes the utilization in the Prometheus text format,
+// the instance label is the name of the node as the load-aware descheduling expects.
+func writeNodesUtilizationPrometheus(w io.Writer, items []NodeUtilizationItem) error {
+	_, err := io.WriteString(w, "# HELP kwok_node_utilization_ratio Ratio of the simulated usage in the allocatable resources of the node\n"+
+		"# TYPE kwok_node_utilization_ratio gauge\n")
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		for _, resourceName := range usageResourceNames {
+			u, ok := item.Utilization[resourceName]
+			if !ok {
+				continue
+			}
+			_, err = fmt.Fprintf(w, "kwok_node_utilization_ratio{instance=%s,node=%s,resource=%s} %s\n",
+				strconv.Quote(item.Name), strconv.Quote(item.Name), strconv.Quote(resourceName),
+				strconv.FormatFloat(u/100, 'g', -1, 64))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
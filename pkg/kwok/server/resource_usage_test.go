@@ -0,0 +1,265 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+func TestNodeUtilization(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-0",
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			},
+		},
+	}
+	usage := map[string]float64{
+		"cpu":    1,
+		"memory": 1024,
+	}
+
+	got := nodeUtilization(node, usage)
+	want := NodeUtilizationItem{
+		Name:        "node-0",
+		Usage:       usage,
+		Allocatable: map[string]float64{"cpu": 4},
+		Utilization: map[string]float64{"cpu": 25},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("nodeUtilization() = %v, want %v", got, want)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := writeNodesUtilizationPrometheus(buf, []NodeUtilizationItem{got})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantText := `# HELP kwok_node_utilization_ratio Ratio of the simulated usage in the allocatable resources of the node
+# TYPE kwok_node_utilization_ratio gauge
+kwok_node_utilization_ratio{instance="node-0",node="node-0",resource="cpu"} 0.25
+`
+	if buf.String() != wantText {
+		t.Fatalf("writeNodesUtilizationPrometheus() = %q, want %q", buf.String(), wantText)
+	}
+}
+
+type fakeDataSource struct {
+	pods map[string][]log.ObjectRef
+}
+
+func (f *fakeDataSource) ListPods(nodeName string) ([]log.ObjectRef, bool) {
+	pods, ok := f.pods[nodeName]
+	return pods, ok
+}
+
+func (f *fakeDataSource) ListNodes() []string {
+	nodes := make([]string, 0, len(f.pods))
+	for nodeName := range f.pods {
+		nodes = append(nodes, nodeName)
+	}
+	return nodes
+}
+
+func (f *fakeDataSource) StartedContainersTotal(nodeName string) int64 {
+	return 0
+}
+
+type fakeCacheGetter[T runtime.Object] struct {
+	objs map[log.ObjectRef]T
+}
+
+func (f *fakeCacheGetter[T]) Get(name string) (T, bool) {
+	return f.GetWithNamespace(name, "")
+}
+
+func (f *fakeCacheGetter[T]) GetWithNamespace(name, namespace string) (T, bool) {
+	obj, ok := f.objs[log.KRef(namespace, name)]
+	return obj, ok
+}
+
+func (f *fakeCacheGetter[T]) List() []T {
+	objs := make([]T, 0, len(f.objs))
+	for _, obj := range f.objs {
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
+func newResourceUsageTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	newNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		}
+	}
+	newPod := func(namespace, name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "app"}},
+			},
+		}
+	}
+	cpu := resource.MustParse("500m")
+	memory := resource.MustParse("256Mi")
+
+	s, err := NewServer(Config{
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu":    {Value: &cpu},
+								"memory": {Value: &memory},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node-1": {
+					log.KRef("default", "pod-b"),
+					log.KRef("kube-system", "pod-c"),
+				},
+				"node-0": {
+					log.KRef("default", "pod-a"),
+				},
+				// node-2 has no pods, so it has no usage
+				"node-2": nil,
+			},
+		},
+		NodeCacheGetter: &fakeCacheGetter[*corev1.Node]{
+			objs: map[log.ObjectRef]*corev1.Node{
+				log.KRef("", "node-0"): newNode("node-0"),
+				log.KRef("", "node-1"): newNode("node-1"),
+				log.KRef("", "node-2"): newNode("node-2"),
+			},
+		},
+		PodCacheGetter: &fakeCacheGetter[*corev1.Pod]{
+			objs: map[log.ObjectRef]*corev1.Pod{
+				log.KRef("default", "pod-a"):     newPod("default", "pod-a", "node-0"),
+				log.KRef("default", "pod-b"):     newPod("default", "pod-b", "node-1"),
+				log.KRef("kube-system", "pod-c"): newPod("kube-system", "pod-c", "node-1"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ctx = context.Background()
+	err = s.initCEL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.InstallResourceUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestResourceUsageHandlers(t *testing.T) {
+	const (
+		cpu    = 0.5
+		memory = 256 * 1024 * 1024
+	)
+	s := newResourceUsageTestServer(t)
+
+	tests := []struct {
+		name string
+		path string
+		want []ResourceUsageItem
+	}{
+		{
+			name: "nodes",
+			path: "/usage/nodes",
+			want: []ResourceUsageItem{
+				{Name: "node-0", Usage: map[string]float64{"cpu": cpu, "memory": memory}},
+				{Name: "node-1", Usage: map[string]float64{"cpu": 2 * cpu, "memory": 2 * memory}},
+				{Name: "node-2", Usage: map[string]float64{"cpu": 0, "memory": 0}},
+			},
+		},
+		{
+			name: "pods in all namespaces",
+			path: "/usage/pods",
+			want: []ResourceUsageItem{
+				{Namespace: "default", Name: "pod-a", NodeName: "node-0", Usage: map[string]float64{"cpu": cpu, "memory": memory}},
+				{Namespace: "default", Name: "pod-b", NodeName: "node-1", Usage: map[string]float64{"cpu": cpu, "memory": memory}},
+				{Namespace: "kube-system", Name: "pod-c", NodeName: "node-1", Usage: map[string]float64{"cpu": cpu, "memory": memory}},
+			},
+		},
+		{
+			name: "pods in a namespace",
+			path: "/usage/pods?namespace=kube-system",
+			want: []ResourceUsageItem{
+				{Namespace: "kube-system", Name: "pod-c", NodeName: "node-1", Usage: map[string]float64{"cpu": cpu, "memory": memory}},
+			},
+		},
+		{
+			name: "pods in a namespace without pods",
+			path: "/usage/pods?namespace=empty",
+			want: []ResourceUsageItem{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.restfulCont.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d, want %d: %s", tt.path, rec.Code, http.StatusOK, rec.Body.String())
+			}
+
+			var got []ResourceUsageItem
+			err := json.Unmarshal(rec.Body.Bytes(), &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GET %s = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+const (
+	// LockName is the name of the lock of the versions in the bundle.
+	LockName = "kwok.lock"
+	// FilesName is the name of the directory of the files referenced by the config in the bundle.
+	FilesName = "files"
+	// ScalesName is the name of the directory of the scale records in the bundle.
+	ScalesName = "scales"
+)
+
+// Bundle is the definition of a cluster loaded from a bundle.
+type Bundle struct {
+	// Config is the config of the cluster.
+	Config *internalversion.KwokctlConfiguration
+	// Objects is the other objects of the config, e.g. the stages and the metrics.
+	Objects []config.InternalObject
+	// Lock is the lock of the versions, nil if the bundle does not have one.
+	Lock *Lock
+
+	dir string
+}
+
+// Export exports the cluster in the workdir as a bundle into the dir.
+func Export(ctx context.Context, workdir, dir string) error {
+	objs, err := config.Load(ctx, path.Join(workdir, consts.ConfigName))
+	if err != nil {
+		return err
+	}
+	confs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(confs) == 0 {
+		return fmt.Errorf("failed to load config of cluster in %s", workdir)
+	}
+	conf := confs[0].DeepCopy()
+
+	err = file.MkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	err = SaveLock(path.Join(dir, LockName), NewLock(conf))
+	if err != nil {
+		return err
+	}
+
+	// The components and the status are built up again when the cluster is created,
+	// and the ports are assigned again so that the cluster does not conflict with the one it is exported from.
+	conf.Components = nil
+	conf.Status = internalversion.KwokctlConfigurationStatus{}
+	resetPorts(&conf.Options)
+
+	for _, ref := range fileRefs(&conf.Options) {
+		if *ref.path == "" {
+			continue
+		}
+		name := path.Join(FilesName, ref.name+path.Ext(*ref.path))
+		err = file.MkdirAll(path.Join(dir, FilesName))
+		if err != nil {
+			return err
+		}
+		err = file.Copy(*ref.path, path.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("copy %s: %w", *ref.path, err)
+		}
+		*ref.path = name
+	}
+
+	objs = append([]config.InternalObject{conf}, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs)...)
+	err = config.Save(ctx, path.Join(dir, consts.ConfigName), objs)
+	if err != nil {
+		return err
+	}
+
+	return copyScales(path.Join(workdir, runtime.ScalesName), path.Join(dir, ScalesName))
+}
+
+// Load loads the bundle in the dir.
+func Load(ctx context.Context, dir string) (*Bundle, error) {
+	objs, err := config.Load(ctx, path.Join(dir, consts.ConfigName))
+	if err != nil {
+		return nil, err
+	}
+	confs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(confs) == 0 {
+		return nil, fmt.Errorf("failed to load config of bundle %s", dir)
+	}
+	conf := confs[0]
+
+	for _, ref := range fileRefs(&conf.Options) {
+		if *ref.path != "" {
+			*ref.path = resolveFile(dir, *ref.path)
+		}
+	}
+
+	lock, err := LoadLock(path.Join(dir, LockName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bundle{
+		Config:  conf,
+		Objects: config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs),
+		Lock:    lock,
+		dir:     dir,
+	}, nil
+}
+
+// RestoreScales restores the scale records of the bundle into the workdir of the cluster,
+// and returns the records restored, which can be resumed with 'kwokctl scale <resource> [name] --resume'.
+func (b *Bundle) RestoreScales(workdir string) ([]*scale.Record, error) {
+	src := path.Join(b.dir, ScalesName)
+	err := copyScales(src, path.Join(workdir, runtime.ScalesName))
+	if err != nil {
+		return nil, err
+	}
+	names, err := listScales(src)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*scale.Record, 0, len(names))
+	for _, name := range names {
+		record, err := scale.LoadRecord(path.Join(src, name))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type fileRef struct {
+	name string
+	path *string
+}
+
+// fileRefs returns the files referenced by the options.
+func fileRefs(conf *internalversion.KwokctlConfigurationOptions) []fileRef {
+	return []fileRef{
+		{name: "kube-scheduler-config", path: &conf.KubeSchedulerConfig},
+		{name: "kube-audit-policy", path: &conf.KubeAuditPolicy},
+		{name: "etcd-template", path: &conf.EtcdTemplate},
+	}
+}
+
+// resolveFile resolves the path of the file referenced relative to the bundle,
+// which has been resolved relative to the working directory if it is expanded when the config is loaded.
+func resolveFile(dir, p string) string {
+	if filepath.IsAbs(p) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return p
+		}
+		rel, err := filepath.Rel(wd, p)
+		if err != nil || !strings.HasPrefix(filepath.ToSlash(rel), FilesName+"/") {
+			return p
+		}
+		p = rel
+	}
+	return path.Join(dir, p)
+}
+
+// resetPorts resets the ports given to the host.
+func resetPorts(conf *internalversion.KwokctlConfigurationOptions) {
+	conf.KubeApiserverPort = 0
+	conf.KubeApiserverInsecurePort = 0
+	conf.KubeApiserverFaultProxyPort = 0
+	conf.PrometheusPort = 0
+	conf.JaegerPort = 0
+	conf.JaegerOtlpGrpcPort = 0
+	conf.EtcdPeerPort = 0
+	conf.EtcdPort = 0
+	conf.KubeControllerManagerPort = 0
+	conf.KubeSchedulerPort = 0
+	conf.DashboardPort = 0
+	conf.KwokControllerPort = 0
+	conf.MetricsServerPort = 0
+	conf.CoreDNSPort = 0
+	conf.CloudControllerManagerPort = 0
+	conf.KubeStateMetricsPort = 0
+	conf.GrafanaPort = 0
+	conf.OtelCollectorPort = 0
+	conf.KubeAuditSinkPort = 0
+}
+
+func listScales(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+func copyScales(src, dst string) error {
+	names, err := listScales(src)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	err = file.MkdirAll(dst)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		err = file.Copy(path.Join(src, name), path.Join(dst, name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
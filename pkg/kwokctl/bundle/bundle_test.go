@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+func TestExportAndLoad(t *testing.T) {
+	ctx := context.Background()
+	workdir := t.TempDir()
+	dir := path.Join(t.TempDir(), "bundle")
+
+	auditPolicy := path.Join(t.TempDir(), "audit.yaml")
+	err := file.Write(auditPolicy, []byte("kind: Policy\n"))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	components := []internalversion.Component{
+		{Name: "etcd", Version: "3.5.11", Image: "registry.k8s.io/etcd:3.5.11-0"},
+		{Name: "kube-apiserver", Version: "1.30.2", Image: "registry.k8s.io/kube-apiserver:v1.30.2"},
+	}
+	conf := &internalversion.KwokctlConfiguration{
+		Options: internalversion.KwokctlConfigurationOptions{
+			Runtime:           "docker",
+			KubeApiserverPort: 32766,
+			KubeAuditPolicy:   auditPolicy,
+		},
+		Components: components,
+		Status: internalversion.KwokctlConfigurationStatus{
+			Version: consts.Version,
+		},
+	}
+	stage := &internalversion.Stage{}
+	stage.Name = "node-initialize"
+	err = config.Save(ctx, path.Join(workdir, consts.ConfigName), []config.InternalObject{conf, stage})
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	record := &scale.Record{Kind: "node", Name: "node", Replicas: 10}
+	err = scale.SaveRecord(scale.RecordPath(path.Join(workdir, runtime.ScalesName), record.Kind, record.Name), record)
+	if err != nil {
+		t.Fatalf("SaveRecord() error = %v", err)
+	}
+
+	err = Export(ctx, workdir, dir)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	b, err := Load(ctx, dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if b.Config.Options.KubeApiserverPort != 0 {
+		t.Errorf("KubeApiserverPort = %d, want 0", b.Config.Options.KubeApiserverPort)
+	}
+	if len(b.Config.Components) != 0 {
+		t.Errorf("Components = %v, want empty", b.Config.Components)
+	}
+	if want := path.Join(dir, FilesName, "kube-audit-policy.yaml"); b.Config.Options.KubeAuditPolicy != want {
+		t.Errorf("KubeAuditPolicy = %q, want %q", b.Config.Options.KubeAuditPolicy, want)
+	}
+	if !file.Exists(b.Config.Options.KubeAuditPolicy) {
+		t.Errorf("KubeAuditPolicy %q does not exist", b.Config.Options.KubeAuditPolicy)
+	}
+	if stages := config.FilterWithType[*internalversion.Stage](b.Objects); len(stages) != 1 || stages[0].Name != stage.Name {
+		t.Errorf("Objects = %v, want the stage %s", b.Objects, stage.Name)
+	}
+	if b.Lock == nil {
+		t.Fatalf("Lock = nil")
+	}
+	if warnings := CheckLock(*b.Lock, components); len(warnings) != 0 {
+		t.Errorf("CheckLock() = %v, want no warnings", warnings)
+	}
+
+	target := t.TempDir()
+	records, err := b.RestoreScales(target)
+	if err != nil {
+		t.Fatalf("RestoreScales() error = %v", err)
+	}
+	if diff := cmp.Diff([]*scale.Record{record}, records); diff != "" {
+		t.Errorf("RestoreScales() mismatch (-want +got):\n%s", diff)
+	}
+	if !file.Exists(scale.RecordPath(path.Join(target, runtime.ScalesName), record.Kind, record.Name)) {
+		t.Errorf("scale record is not restored")
+	}
+}
+
+func TestCheckLock(t *testing.T) {
+	lock := Lock{
+		KwokctlVersion: consts.Version,
+		Runtime:        "binary",
+		Components: []LockedComponent{
+			{Name: "etcd", Version: "3.5.11", Binary: "https://example.com/etcd"},
+			{Name: "kube-apiserver", Version: "1.30.2", Binary: "https://example.com/kube-apiserver"},
+		},
+	}
+	tests := []struct {
+		name         string
+		lock         Lock
+		components   []internalversion.Component
+		wantWarnings int
+	}{
+		{
+			name: "identical",
+			lock: lock,
+			components: []internalversion.Component{
+				{Name: "etcd", Version: "3.5.11", Binary: "https://example.com/etcd"},
+				{Name: "kube-apiserver", Version: "1.30.2", Binary: "https://example.com/kube-apiserver"},
+			},
+		},
+		{
+			name: "different version",
+			lock: lock,
+			components: []internalversion.Component{
+				{Name: "etcd", Version: "3.5.11", Binary: "https://example.com/etcd"},
+				{Name: "kube-apiserver", Version: "1.29.0", Binary: "https://example.com/kube-apiserver"},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name: "missing and extra",
+			lock: lock,
+			components: []internalversion.Component{
+				{Name: "etcd", Version: "3.5.11", Binary: "https://example.com/etcd"},
+				{Name: "kine", Version: "0.13.2"},
+			},
+			wantWarnings: 2,
+		},
+		{
+			name: "different kwokctl",
+			lock: Lock{KwokctlVersion: "0.0.1"},
+			components: []internalversion.Component{
+				{Name: "etcd"},
+			},
+			wantWarnings: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := CheckLock(tt.lock, tt.components)
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("CheckLock() = %v, want %d warnings", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// Lock is the versions of the kwokctl and the components the bundle is exported with.
+type Lock struct {
+	// KwokctlVersion is the version of the kwokctl that created the cluster.
+	KwokctlVersion string `json:"kwokctlVersion"`
+	// Runtime is the runtime of the cluster.
+	Runtime string `json:"runtime"`
+	// Components is the components of the cluster.
+	Components []LockedComponent `json:"components,omitempty"`
+}
+
+// LockedComponent is the version of a component of the cluster.
+type LockedComponent struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Version is the version of the component.
+	Version string `json:"version,omitempty"`
+	// Image is the image of the component.
+	Image string `json:"image,omitempty"`
+	// Binary is the binary of the component.
+	Binary string `json:"binary,omitempty"`
+}
+
+// NewLock returns the lock of the cluster.
+func NewLock(conf *internalversion.KwokctlConfiguration) Lock {
+	lock := Lock{
+		KwokctlVersion: conf.Status.Version,
+		Runtime:        conf.Options.Runtime,
+	}
+	for _, component := range conf.Components {
+		lock.Components = append(lock.Components, lockComponent(component))
+	}
+	return lock
+}
+
+func lockComponent(component internalversion.Component) LockedComponent {
+	return LockedComponent{
+		Name:    component.Name,
+		Version: component.Version,
+		Image:   component.Image,
+		Binary:  component.Binary,
+	}
+}
+
+// SaveLock saves the lock to the path.
+func SaveLock(p string, lock Lock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return file.Write(p, data)
+}
+
+// LoadLock loads the lock from the path, nil is returned if it does not exist.
+func LoadLock(p string) (*Lock, error) {
+	data, err := file.Read(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lock := &Lock{}
+	err = yaml.Unmarshal(data, lock)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal lock %s: %w", p, err)
+	}
+	return lock, nil
+}
+
+// CheckLock checks the components of the created cluster against the lock,
+// the returned warnings are the differences that make the cluster not identical to the one the bundle is exported from.
+func CheckLock(lock Lock, components []internalversion.Component) (warnings []string) {
+	if lock.KwokctlVersion != "" && lock.KwokctlVersion != consts.Version {
+		warnings = append(warnings, fmt.Sprintf("the bundle is exported from a cluster created by kwokctl %s, but the kwokctl is %s", lock.KwokctlVersion, consts.Version))
+	}
+
+	actual := map[string]LockedComponent{}
+	for _, component := range components {
+		actual[component.Name] = lockComponent(component)
+	}
+	for _, locked := range lock.Components {
+		got, ok := actual[locked.Name]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("component %s is locked but not created", locked.Name))
+			continue
+		}
+		delete(actual, locked.Name)
+		if got != locked {
+			warnings = append(warnings, fmt.Sprintf("component %s is locked with %s, but created with %s", locked.Name, describe(locked), describe(got)))
+		}
+	}
+	for _, component := range components {
+		if _, ok := actual[component.Name]; ok {
+			warnings = append(warnings, fmt.Sprintf("component %s is created but not locked", component.Name))
+		}
+	}
+	return warnings
+}
+
+// describe returns the version and the image or the binary of the component.
+func describe(c LockedComponent) string {
+	s := "version " + c.Version
+	if c.Image != "" {
+		s += ", image " + c.Image
+	}
+	if c.Binary != "" {
+		s += ", binary " + c.Binary
+	}
+	return s
+}
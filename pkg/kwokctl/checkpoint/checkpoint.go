@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// Operation is the kwokctl operation which is checkpointed.
+type Operation string
+
+// The operations which are checkpointed when they are interrupted.
+const (
+	OperationCreate       Operation = "create"
+	OperationSnapshotSave Operation = "snapshot-save"
+	OperationReplay       Operation = "replay"
+)
+
+// Checkpoint is the state of an interrupted operation.
+type Checkpoint struct {
+	// Operation is the interrupted operation.
+	Operation Operation `json:"operation"`
+	// Path is the file the operation was working on.
+	Path string `json:"path,omitempty"`
+	// Progress is the number of the steps the operation has done,
+	// e.g. the number of the patches replayed.
+	Progress uint64 `json:"progress,omitempty"`
+	// Message is the reason why the operation is left unfinished.
+	Message string `json:"message,omitempty"`
+	// InterruptedTime is the time when the operation is interrupted.
+	InterruptedTime time.Time `json:"interruptedTime"`
+}
+
+// Path returns the path of the checkpoint of the operation in the directory.
+func Path(dir string, op Operation) string {
+	return path.Join(dir, string(op)+".json")
+}
+
+// Load loads the checkpoint of the operation from the directory,
+// nil is returned if the operation is not interrupted.
+func Load(dir string, op Operation) (*Checkpoint, error) {
+	p := Path(dir, op)
+	data, err := file.Read(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var c Checkpoint
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal checkpoint %s: %w", p, err)
+	}
+	return &c, nil
+}
+
+// Save saves the checkpoint to the directory.
+func Save(dir string, c *Checkpoint) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = file.MkdirAll(dir)
+	if err != nil {
+		return err
+	}
+	return file.Write(Path(dir, c.Operation), data)
+}
+
+// Remove removes the checkpoint of the operation from the directory once it is done.
+func Remove(dir string, op Operation) error {
+	p := Path(dir, op)
+	if !file.Exists(p) {
+		return nil
+	}
+	return file.Remove(p)
+}
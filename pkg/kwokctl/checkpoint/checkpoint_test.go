@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := Load(dir, OperationReplay)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Load() = %+v, want nil before any interrupt", got)
+	}
+
+	c := &Checkpoint{
+		Operation:       OperationReplay,
+		Path:            "/tmp/recording.yaml",
+		Progress:        42,
+		InterruptedTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err = Save(dir, c)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err = Load(dir, OperationReplay)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Load() = %+v, want %+v", got, c)
+	}
+
+	other, err := Load(dir, OperationSnapshotSave)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if other != nil {
+		t.Errorf("Load() = %+v, want nil for another operation", other)
+	}
+
+	err = Remove(dir, OperationReplay)
+	if err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	got, err = Load(dir, OperationReplay)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("Load() = %+v, want nil after Remove()", got)
+	}
+	err = Remove(dir, OperationReplay)
+	if err != nil {
+		t.Errorf("Remove() error = %v, want nil if nothing is recorded", err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decisions contains a command to summarize the decision log of kwok-controller.
+package decisions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+type flagpole struct {
+	Kind      string
+	Namespace string
+}
+
+// NewCommand returns a new cobra.Command for analyze decisions
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.MinimumNArgs(1),
+		Use:   "decisions <decision-log>...",
+		Short: "Summarize the decision log of kwok-controller",
+		Long: `Summarize the decision log written by kwok-controller with --decision-log-path,
+showing for each stage how many times it was played on how many objects, the failures and the retries,
+the delays, the lags between the expiration of the delays and playing the stages,
+and when it was played for the first and the last time since the first decision.
+The logs of several kwok-controllers, e.g. the shards, can be summarized together, "-" reads from stdin.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Kind, "kind", "", "Only summarize the decisions of the kind")
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "", "Only summarize the decisions of the objects in the namespace")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	summarizer := decisions.NewSummarizer()
+	total := 0
+	add := func(d *decisions.Decision) error {
+		if flags.Kind != "" && d.Kind != flags.Kind {
+			return nil
+		}
+		if flags.Namespace != "" && d.Namespace != flags.Namespace {
+			return nil
+		}
+		summarizer.Add(d)
+		total++
+		return nil
+	}
+
+	for _, arg := range args {
+		err := decode(arg, add)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", arg, err)
+		}
+	}
+
+	if total == 0 {
+		return fmt.Errorf("no decisions found")
+	}
+	return printSummary(os.Stdout, summarizer.Summary())
+}
+
+func decode(name string, fn func(d *decisions.Decision) error) error {
+	if name == "-" {
+		return decisions.Decode(os.Stdin, fn)
+	}
+	name, err := path.Expand(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return decisions.Decode(f, fn)
+}
+
+func printSummary(w io.Writer, summary []decisions.StageSummary) error {
+	var start time.Time
+	for _, s := range summary {
+		if start.IsZero() || s.First.Before(start) {
+			start = s.First
+		}
+	}
+
+	records := [][]string{
+		{"KIND", "STAGE", "COUNT", "OBJECTS", "ERRORS", "RETRIES", "DELETES", "DELAY(MIN/MEAN/MAX)", "LAG(MEAN/MAX)", "FIRST", "LAST"},
+	}
+	for _, s := range summary {
+		records = append(records, []string{
+			s.Kind,
+			s.Stage,
+			format.String(s.Count),
+			format.String(s.Objects),
+			format.String(s.Errors),
+			format.String(s.Retries),
+			format.String(s.Deletes),
+			formatDuration(s.MinDelay) + "/" + formatDuration(s.MeanDelay) + "/" + formatDuration(s.MaxDelay),
+			formatDuration(s.MeanLag) + "/" + formatDuration(s.MaxLag),
+			"+" + formatDuration(s.First.Sub(start)),
+			"+" + formatDuration(s.Last.Sub(start)),
+		})
+	}
+	return printers.NewTablePrinter(w).WriteAll(records)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decisions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/kwok/decisions"
+)
+
+func TestPrintSummary(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	summary := []decisions.StageSummary{
+		{
+			Kind:    "Node",
+			Stage:   "node-initialize",
+			Count:   2,
+			Objects: 2,
+			First:   now,
+			Last:    now.Add(time.Second),
+		},
+		{
+			Kind:      "Pod",
+			Stage:     "pod-ready",
+			Count:     3,
+			Objects:   2,
+			Errors:    1,
+			Retries:   1,
+			MinDelay:  time.Second,
+			MeanDelay: 1500 * time.Millisecond,
+			MaxDelay:  2 * time.Second,
+			MeanLag:   10 * time.Millisecond,
+			MaxLag:    20 * time.Millisecond,
+			First:     now.Add(2 * time.Second),
+			Last:      now.Add(time.Minute),
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := printSummary(buf, summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"DELAY(MIN/MEAN/MAX)",
+		"node-initialize",
+		"+0s",
+		"1s/1.5s/2s",
+		"10ms/20ms",
+		"+1m0s",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output:\n%s", want, out)
+		}
+	}
+}
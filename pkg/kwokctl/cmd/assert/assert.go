@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package assert contains a command to assert the state of a cluster.
+package assert
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scenario"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Assertion     scenario.Assertion
+	Count         int
+	MinCount      int
+	MaxCount      int
+	Query         string
+	Min           float64
+	Max           float64
+	Timeout       time.Duration
+	PrometheusURL string
+}
+
+// NewCommand returns a new cobra.Command for assert
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "assert",
+		Short: "Assert the state of the cluster",
+		Long:  "Assert the number of objects of a resource or the value of a Prometheus query, and retry until it passes or the timeout expires",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			a := flags.Assertion
+			if cmd.Flags().Changed("count") {
+				a.Count = &flags.Count
+			}
+			if cmd.Flags().Changed("min-count") {
+				a.MinCount = &flags.MinCount
+			}
+			if cmd.Flags().Changed("max-count") {
+				a.MaxCount = &flags.MaxCount
+			}
+			if flags.Query != "" {
+				a.Prometheus = &scenario.PrometheusAssertion{
+					Query: flags.Query,
+				}
+				if cmd.Flags().Changed("min") {
+					a.Prometheus.Min = &flags.Min
+				}
+				if cmd.Flags().Changed("max") {
+					a.Prometheus.Max = &flags.Max
+				}
+			}
+			a.Timeout = metav1.Duration{Duration: flags.Timeout}
+			return runE(cmd.Context(), flags, a)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Assertion.Resource, "resource", "", "Resource of the objects, e.g. pods or nodes")
+	cmd.Flags().StringVarP(&flags.Assertion.Namespace, "namespace", "n", "", "Namespace of the objects, all namespaces if empty")
+	cmd.Flags().StringVarP(&flags.Assertion.Selector, "selector", "l", "", "Label selector of the objects")
+	cmd.Flags().StringVar(&flags.Assertion.FieldSelector, "field-selector", "", "Field selector of the objects, e.g. status.phase=Running")
+	cmd.Flags().StringVar(&flags.Assertion.Condition, "condition", "", "Type of the condition, only the objects with the condition true are counted, e.g. Ready")
+	cmd.Flags().BoolVar(&flags.Assertion.All, "all", false, "Assert all the matched objects have the condition")
+	cmd.Flags().IntVar(&flags.Count, "count", 0, "Exact number of the objects")
+	cmd.Flags().IntVar(&flags.MinCount, "min-count", 0, "Minimum number of the objects")
+	cmd.Flags().IntVar(&flags.MaxCount, "max-count", 0, "Maximum number of the objects")
+	cmd.Flags().StringVar(&flags.Query, "query", "", "Prometheus query of a single value")
+	cmd.Flags().Float64Var(&flags.Min, "min", 0, "Minimum of the value of the query")
+	cmd.Flags().Float64Var(&flags.Max, "max", 0, "Maximum of the value of the query")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 0, "Time to wait for the assertion to pass, it is checked once if 0")
+	cmd.Flags().StringVar(&flags.PrometheusURL, "prometheus-url", "", "URL of the Prometheus of the query, defaults to the Prometheus of the cluster")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, a scenario.Assertion) error {
+	err := a.Validate()
+	if err != nil {
+		return err
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Assert the state of the cluster")
+		return nil
+	}
+
+	runner, err := scenario.NewRuntimeRunner(ctx, scenario.RuntimeRunnerConfig{
+		Runtime:       rt,
+		Name:          flags.Name,
+		PrometheusURL: flags.PrometheusURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = scenario.Assert(ctx, runner, a)
+	if err != nil {
+		return err
+	}
+	logger.Info("Assertion passed", "elapsed", time.Since(start))
+	return nil
+}
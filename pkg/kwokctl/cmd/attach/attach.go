@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package attach implements the attach command
+package attach
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name       string
+	Timeout    time.Duration
+	Wait       time.Duration
+	Kubeconfig string
+
+	*internalversion.KwokctlConfiguration
+}
+
+// NewCommand returns a new cobra.Command for attaching to an existing cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.KwokctlConfiguration = config.GetKwokctlConfiguration(ctx)
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "attach",
+		Short: "Attach a kwok-controller to an existing cluster",
+		Long: `Attach a kwok-controller to an existing cluster, and register the cluster in kwokctl so that it can be managed like the created ones.
+The kwok-controller manages the nodes annotated with kwok.x-k8s.io/node=fake only, which are created by 'kwokctl scale node'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file of the existing cluster, the current context is used")
+	cmd.Flags().BoolVar(&flags.Options.AttachLocal, "local", flags.Options.AttachLocal, "Run the kwok-controller as a local process instead of deploying it into the existing cluster")
+	cmd.Flags().StringVar(&flags.Options.KwokControllerImage, "kwok-controller-image", flags.Options.KwokControllerImage, `Image of kwok-controller deployed into the existing cluster
+'${KWOK_IMAGE_PREFIX}/kwok:${KWOK_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.KwokControllerBinary, "kwok-controller-binary", flags.Options.KwokControllerBinary, `Binary of kwok-controller, only for --local
+`)
+	cmd.Flags().Uint32Var(&flags.Options.KwokControllerPort, "controller-port", flags.Options.KwokControllerPort, `Port of kwok-controller given to the host, only for --local`)
+	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 0, "Timeout for waiting for the kwok-controller to be attached")
+	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the kwok-controller to be ready")
+	_ = cmd.MarkFlagRequired("kubeconfig")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) (retErr error) {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	if flags.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flags.Timeout)
+		defer cancel()
+	}
+
+	var err error
+	flags.Options.AttachKubeconfig, err = path.Expand(flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	flags.Options.Runtime = consts.RuntimeTypeAttach
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(flags.Options.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", flags.Options.Runtime)
+	}
+	rt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", flags.Options.Runtime, err)
+	}
+	err = rt.Available(ctx)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", flags.Options.Runtime, err)
+	}
+
+	_, err = rt.Config(ctx)
+	if err == nil {
+		return fmt.Errorf("cluster %q already exists, delete it first or use another name", flags.Name)
+	}
+
+	cleanUp := func() {
+		subCtx := context.WithoutCancel(ctx)
+		err := rt.Uninstall(subCtx)
+		if err != nil {
+			logger.Error("Failed to clean up cluster", err)
+		} else {
+			logger.Info("Cluster is cleaned up")
+		}
+	}
+	defer func() {
+		if retErr != nil {
+			cleanUp()
+		}
+	}()
+
+	err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
+	if err != nil {
+		return fmt.Errorf("failed to set config: %w", err)
+	}
+	err = rt.Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	start := time.Now()
+	logger.Info("Cluster is attaching")
+	err = rt.Install(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to setup config: %w", err)
+	}
+	err = rt.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start kwok-controller: %w", err)
+	}
+	logger.Info("Cluster is attached",
+		"elapsed", time.Since(start),
+	)
+
+	err = rt.InitCRDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init crds %q: %w", name, err)
+	}
+	err = rt.InitCRs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init crs %q: %w", name, err)
+	}
+
+	if flags.Wait > 0 {
+		start = time.Now()
+		logger.Info("Waiting for cluster to be ready")
+		err = rt.WaitReady(ctx, flags.Wait)
+		if err != nil {
+			logger.Error("Failed to wait for cluster to be ready", err,
+				"elapsed", time.Since(start),
+			)
+		} else {
+			logger.Info("Cluster is ready",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+
+	return nil
+}
@@ -22,7 +22,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/get"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/imports"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/listimports"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/reset"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/set"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/tidy"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/view"
 )
@@ -32,13 +36,17 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "config [command]",
-		Short: "Manage [reset, tidy, view] default config",
+		Short: "Manage [import, list-imports, reset, tidy, view] default config and [get, set] config of the cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
+	cmd.AddCommand(get.NewCommand(ctx))
+	cmd.AddCommand(imports.NewCommand(ctx))
+	cmd.AddCommand(listimports.NewCommand(ctx))
 	cmd.AddCommand(reset.NewCommand(ctx))
+	cmd.AddCommand(set.NewCommand(ctx))
 	cmd.AddCommand(tidy.NewCommand(ctx))
 	cmd.AddCommand(view.NewCommand(ctx))
 	return cmd
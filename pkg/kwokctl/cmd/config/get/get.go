@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package get provides the kwokctl config get command.
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/fieldpath"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+type flagpole struct {
+	Name string
+}
+
+// NewCommand returns a new cobra.Command for config get
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "get [path]",
+		Short: "Display a field of the config of the cluster by its path, e.g. componentsPatches.kube-apiserver.extraArgs.v",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, fieldPath string) error {
+	p := path.Join(config.ClustersDir, flags.Name, runtime.ConfigName)
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Displaying %s of config file %s", fieldPath, p)
+		return nil
+	}
+	if !file.Exists(p) {
+		return fmt.Errorf("cluster %q does not exist", flags.Name)
+	}
+
+	objs, err := config.Load(ctx, p)
+	if err != nil {
+		return err
+	}
+	configs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(configs) == 0 {
+		return fmt.Errorf("failed to load config")
+	}
+	conf, err := internalversion.ConvertToV1alpha1KwokctlConfiguration(configs[0])
+	if err != nil {
+		return err
+	}
+
+	value, err := fieldpath.Get(conf, fieldPath)
+	if err != nil {
+		return err
+	}
+	return printValue(value)
+}
+
+// printValue prints the scalars as they are and the others as YAML.
+func printValue(value any) error {
+	if value == nil {
+		return nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	default:
+		_, err := fmt.Fprintln(os.Stdout, value)
+		return err
+	}
+}
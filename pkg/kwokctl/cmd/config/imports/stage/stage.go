@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stage contains a command to import stages into the default config.
+package stage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/imports"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+type flagpole struct {
+	Digest string
+}
+
+// NewCommand returns a new cobra.Command for config import stage
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "stage <source>",
+		Short: "Import stages from a file, an http(s) URL or an OCI artifact (oci://<registry>/<repository>:<tag>) into the default config",
+		Long:  "Import stages from a file, an http(s) URL or an OCI artifact (oci://<registry>/<repository>:<tag>) into the default config, the stages with the same name are replaced, and the import is recorded so that it can be listed with 'kwokctl config list-imports'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&flags.Digest, "digest", "", "Pin the sha256 digest of the content, e.g. sha256:<hex>; the cached content is reused if it matches")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, src string) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("uri", src)
+
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Import stages from %s", src)
+		return nil
+	}
+
+	conf := config.GetKwokctlConfiguration(ctx)
+	data, digest, err := imports.FetchWithCache(ctx, conf.Options.CacheDir, src, flags.Digest)
+	if err != nil {
+		return err
+	}
+
+	stages, err := loadStages(ctx, imports.CachePath(conf.Options.CacheDir, digest))
+	if err != nil {
+		return fmt.Errorf("%s: %w", src, err)
+	}
+	if len(stages) == 0 {
+		return fmt.Errorf("%s: no stages found", src)
+	}
+	names := slices.Map(stages, func(s *internalversion.Stage) string {
+		return s.Name
+	})
+
+	list := config.GetFromContext(ctx)
+	list = slices.Filter(list, func(obj config.InternalObject) bool {
+		s, ok := obj.(*internalversion.Stage)
+		return !ok || !slices.Contains(names, s.Name)
+	})
+	for _, s := range stages {
+		list = append(list, s)
+	}
+
+	err = config.Save(ctx, path.Join(config.WorkDir, consts.ConfigName), list)
+	if err != nil {
+		return err
+	}
+
+	err = imports.AddToIndex(path.Join(config.WorkDir, imports.IndexName), imports.Import{
+		Kind:            "Stage",
+		Source:          src,
+		Digest:          digest,
+		Names:           names,
+		ImportTimestamp: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Imported stages",
+		"digest", digest,
+		"size", len(data),
+		"stages", names,
+	)
+	return nil
+}
+
+// loadStages loads the stages from the file, an error is returned if there is any other kind of object.
+func loadStages(ctx context.Context, p string) ([]*internalversion.Stage, error) {
+	objs, err := config.Load(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	stages := config.FilterWithType[*internalversion.Stage](objs)
+	if len(stages) != len(objs) {
+		return nil, fmt.Errorf("only stages can be imported, but got %d other objects", len(objs)-len(stages))
+	}
+	return stages, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package listimports contains a command to list the imported configurations.
+package listimports
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/imports"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+// NewCommand returns a new cobra.Command for config list-imports
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "list-imports",
+		Short: "List the configurations imported into the default config",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context())
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context) error {
+	list, err := imports.LoadIndex(path.Join(config.WorkDir, imports.IndexName))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	records := [][]string{
+		{"KIND", "SOURCE", "DIGEST", "NAMES", "AGE"},
+	}
+	for _, imp := range list {
+		digest := imp.Digest
+		if len(digest) > len("sha256:")+12 {
+			digest = digest[:len("sha256:")+12]
+		}
+		records = append(records, []string{
+			imp.Kind,
+			imp.Source,
+			digest,
+			strings.Join(imp.Names, ","),
+			format.HumanDuration(now.Sub(imp.ImportTimestamp)),
+		})
+	}
+	return printers.NewTablePrinter(os.Stdout).WriteAll(records)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package set provides the kwokctl config set command.
+package set
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/fieldpath"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+type flagpole struct {
+	Name string
+}
+
+// NewCommand returns a new cobra.Command for config set
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.MinimumNArgs(1),
+		Use:   "set [path=value...]",
+		Short: "Set fields of the config of the cluster by their paths, e.g. componentsPatches.kube-apiserver.extraArgs.v=4",
+		Long: "Set fields of the config of the cluster by their paths, e.g. componentsPatches.kube-apiserver.extraArgs.v=4. " +
+			"The elements of the lists are selected by their names or keys, or by their indexes, and the missing ones are added. " +
+			"The values are parsed as YAML into the types of the fields, and the config is validated before it is saved",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	p := path.Join(config.ClustersDir, flags.Name, runtime.ConfigName)
+	if !dryrun.DryRun && !file.Exists(p) {
+		return fmt.Errorf("cluster %q does not exist", flags.Name)
+	}
+
+	fields := make([][2]string, 0, len(args))
+	for _, arg := range args {
+		fieldPath, value, ok := strings.Cut(arg, "=")
+		if !ok || fieldPath == "" {
+			return fmt.Errorf("invalid argument %q, expected path=value", arg)
+		}
+		fields = append(fields, [2]string{fieldPath, value})
+	}
+
+	if dryrun.DryRun {
+		for _, field := range fields {
+			dryrun.PrintMessage("# Set %s to %q in config file %s", field[0], field[1], p)
+		}
+		return nil
+	}
+
+	objs, err := config.Load(ctx, p)
+	if err != nil {
+		return err
+	}
+	index := -1
+	for i, obj := range objs {
+		if _, ok := obj.(*internalversion.KwokctlConfiguration); ok {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("failed to load config")
+	}
+	oldConf := objs[index].(*internalversion.KwokctlConfiguration)
+	conf, err := internalversion.ConvertToV1alpha1KwokctlConfiguration(oldConf)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		err = fieldpath.Set(conf, field[0], field[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	newConf, err := internalversion.ConvertToInternalKwokctlConfiguration(conf)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	err = validate(newConf)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+
+	// The patches are applied to the components when the cluster is created,
+	// so the changed ones are also applied to the components of the existing cluster.
+	patches := changedPatches(oldConf.ComponentsPatches, newConf.ComponentsPatches)
+	if len(patches) != 0 {
+		if components.GetRuntimeMode(newConf.Options.Runtime) == components.RuntimeModeCluster {
+			logger.Warn("The patches of the components take effect when the cluster is recreated with the runtime",
+				"runtime", newConf.Options.Runtime,
+			)
+		} else {
+			patches, err = runtime.ExpandComponentPatchesFiles(patches, newConf.Options.Runtime != consts.RuntimeTypeBinary)
+			if err != nil {
+				return err
+			}
+			for i := range newConf.Components {
+				runtime.ApplyComponentPatches(&newConf.Components[i], patches)
+			}
+		}
+	}
+	objs[index] = newConf
+
+	err = config.Save(ctx, p, objs)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Cluster config is updated, restart the cluster or the components for it to take effect")
+	return nil
+}
+
+// changedPatches returns the patches which are added or changed.
+func changedPatches(oldPatches, newPatches []internalversion.ComponentPatches) []internalversion.ComponentPatches {
+	var out []internalversion.ComponentPatches
+	for _, patch := range newPatches {
+		if _, ok := slices.Find(oldPatches, func(old internalversion.ComponentPatches) bool {
+			return reflect.DeepEqual(old, patch)
+		}); !ok {
+			out = append(out, patch)
+		}
+	}
+	return out
+}
+
+func validate(conf *internalversion.KwokctlConfiguration) error {
+	if _, ok := runtime.DefaultRegistry.Get(conf.Options.Runtime); !ok {
+		return fmt.Errorf("runtime %q not found", conf.Options.Runtime)
+	}
+	names := map[string]struct{}{}
+	for _, component := range conf.Components {
+		if component.Name == "" {
+			return fmt.Errorf("component name is required")
+		}
+		if _, ok := names[component.Name]; ok {
+			return fmt.Errorf("duplicate component %q", component.Name)
+		}
+		names[component.Name] = struct{}{}
+	}
+	for _, patch := range conf.ComponentsPatches {
+		if patch.Name == "" {
+			return fmt.Errorf("component patch name is required")
+		}
+	}
+	return nil
+}
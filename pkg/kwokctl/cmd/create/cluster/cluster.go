@@ -19,6 +19,7 @@ package cluster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -27,12 +28,26 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/config/lifecycle"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/bundle"
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/fleet"
+	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/signals"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
 
 type flagpole struct {
@@ -41,8 +56,17 @@ type flagpole struct {
 	Wait       time.Duration
 	Kubeconfig string
 	ExtraArgs  []string
+	Count      int
+	Workers    int
+
+	NodeProfiles []string
+
+	FromExistingData bool
+	FromBundle       string
 
 	*internalversion.KwokctlConfiguration
+
+	bundle *bundle.Bundle
 }
 
 // NewCommand returns a new cobra.Command for cluster creation
@@ -63,14 +87,30 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverPort, "kube-apiserver-port", flags.Options.KubeApiserverPort, `Port of the apiserver (default random)`)
 	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverInsecurePort, "kube-apiserver-insecure-port", flags.Options.KubeApiserverInsecurePort, `Insecure port of the apiserver`)
+	cmd.Flags().StringArrayVar(&flags.Options.KubeApiserverFaults, "kube-apiserver-fault", flags.Options.KubeApiserverFaults, `Rule of the faults injected by the fault proxy of the apiserver, e.g. 'verb=list,resource=pods,delay=500ms,jitter=100ms,error-rate=0.1,throttle-rate=0.05', can be repeated, only for binary/docker/podman/nerdctl runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverFaultProxyPort, "kube-apiserver-fault-proxy-port", flags.Options.KubeApiserverFaultProxyPort, `Port of the fault proxy of the apiserver served over plain HTTP, a random one is used if not set`)
 	cmd.Flags().Uint32Var(&flags.Options.PrometheusPort, "prometheus-port", flags.Options.PrometheusPort, `Port to expose Prometheus metrics`)
+	cmd.Flags().Uint32Var(&flags.Options.GrafanaPort, "grafana-port", flags.Options.GrafanaPort, `Port to expose Grafana with the Prometheus as the datasource and the bundled dashboards, requires --prometheus-port, only for docker/podman/nerdctl runtime`)
 	cmd.Flags().Uint32Var(&flags.Options.JaegerPort, "jaeger-port", flags.Options.JaegerPort, `Port to expose Jaeger UI`)
+	cmd.Flags().BoolVar(&flags.Options.EnableOtelCollector, "enable-otel-collector", flags.Options.EnableOtelCollector, `Enable otel-collector which receives the traces of kube-apiserver and etcd, and forwards them to Jaeger and the --otlp-endpoint, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().StringVar(&flags.Options.OtlpEndpoint, "otlp-endpoint", flags.Options.OtlpEndpoint, `OTLP gRPC endpoint of an external backend which otel-collector forwards the traces to, with the https:// scheme for TLS, requires --enable-otel-collector`)
+	cmd.Flags().Uint32Var(&flags.Options.OtelCollectorPort, "otel-collector-port", flags.Options.OtelCollectorPort, `Port of the OTLP gRPC receiver of otel-collector given to the host, a random one is used for binary runtime if not set`)
+	cmd.Flags().BoolVar(&flags.Options.EnableKonnectivity, "enable-konnectivity", flags.Options.EnableKonnectivity, `Enable konnectivity-server and konnectivity-agent which proxy the traffic from kube-apiserver to the cluster, requires --secure-port, only for docker/podman/nerdctl runtime`)
 	cmd.Flags().BoolVar(&flags.Options.SecurePort, "secure-port", flags.Options.SecurePort, `The apiserver port on which to serve HTTPS with authentication and authorization, is not available before Kubernetes 1.13.0`)
 	cmd.Flags().BoolVar(&flags.Options.QuietPull, "quiet-pull", flags.Options.QuietPull, `Pull without printing progress information`)
 	cmd.Flags().StringVar(&flags.Options.KubeSchedulerConfig, "kube-scheduler-config", flags.Options.KubeSchedulerConfig, `Path to a kube-scheduler configuration file`)
 	cmd.Flags().BoolVar(&flags.Options.DisableKubeScheduler, "disable-kube-scheduler", flags.Options.DisableKubeScheduler, `Disable the kube-scheduler`)
 	cmd.Flags().BoolVar(&flags.Options.DisableKubeControllerManager, "disable-kube-controller-manager", flags.Options.DisableKubeControllerManager, `Disable the kube-controller-manager`)
 	cmd.Flags().BoolVar(&flags.Options.EnableMetricsServer, "enable-metrics-server", flags.Options.EnableMetricsServer, `Enable the metrics-server`)
+	cmd.Flags().BoolVar(&flags.Options.EnableCSRSigner, "enable-csr-signer", flags.Options.EnableCSRSigner, `Enable kwok-controller to sign the CertificateSigningRequests with the CA of the cluster, the key of the CA is passed to it, only for binary/docker/podman/nerdctl runtime`)
+	cmd.Flags().BoolVar(&flags.Options.EnableCoreDNS, "enable-coredns", flags.Options.EnableCoreDNS, `Enable CoreDNS which resolves the services and pods of the cluster, not supported by kind/kubernetes runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.CoreDNSPort, "coredns-port", flags.Options.CoreDNSPort, `Port of CoreDNS given to the host for both UDP and TCP, a random one is used for binary/crio runtime if not set`)
+	cmd.Flags().StringVar(&flags.Options.CloudProvider, "cloud-provider", flags.Options.CloudProvider, `Name of the external cloud provider, launch the cloud-controller-manager of it and taint the nodes as uninitialized until it initializes them, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.CloudControllerManagerPort, "cloud-controller-manager-port", flags.Options.CloudControllerManagerPort, `Port of cloud-controller-manager given to the host, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().BoolVar(&flags.Options.EnableKubeStateMetrics, "enable-kube-state-metrics", flags.Options.EnableKubeStateMetrics, `Enable kube-state-metrics which exposes the metrics of the objects of the cluster, scraped by Prometheus if enabled, not supported by kind/kubernetes runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeStateMetricsPort, "kube-state-metrics-port", flags.Options.KubeStateMetricsPort, `Port of kube-state-metrics given to the host, a random one is used for binary/crio runtime if not set`)
+	cmd.Flags().BoolVar(&flags.Options.EnableLoadBalancer, "enable-load-balancer", flags.Options.EnableLoadBalancer, `Enable the stages of the load balancer of services and ingresses`)
+	cmd.Flags().BoolVar(&flags.Options.EnableKubeProxy, "enable-kube-proxy", flags.Options.EnableKubeProxy, `Enable the stages of kube-proxy which report the proxy rules of services and endpoint slices as synced, without iptables`)
 	cmd.Flags().StringVar(&flags.Options.EtcdImage, "etcd-image", flags.Options.EtcdImage, `Image of etcd, only for docker/podman/nerdctl runtime
 '${KWOK_KUBE_IMAGE_PREFIX}/etcd:${KWOK_ETCD_VERSION}'
 `)
@@ -82,6 +122,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 '${KWOK_KUBE_IMAGE_PREFIX}/kube-controller-manager:${KWOK_KUBE_VERSION}'
 `)
 	cmd.Flags().Uint32Var(&flags.Options.KubeControllerManagerPort, "kube-controller-manager-port", flags.Options.KubeControllerManagerPort, `Port of kube-controller-manager given to the host, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().StringVar(&flags.Options.CloudControllerManagerImage, "cloud-controller-manager-image", flags.Options.CloudControllerManagerImage, `Image of the cloud-controller-manager of the cloud provider, required if --cloud-provider is set, only for docker/podman/nerdctl runtime`)
 	cmd.Flags().StringVar(&flags.Options.KubeSchedulerImage, "kube-scheduler-image", flags.Options.KubeSchedulerImage, `Image of kube-scheduler, only for docker/podman/nerdctl runtime
 '${KWOK_KUBE_IMAGE_PREFIX}/kube-scheduler:${KWOK_KUBE_VERSION}'
 `)
@@ -91,9 +132,27 @@ func NewCommand(ctx context.Context) *cobra.Command {
 `)
 	cmd.Flags().StringVar(&flags.Options.MetricsServerImage, "metrics-server-image", flags.Options.MetricsServerImage, `Image of metrics-server, only for docker/podman/nerdctl/kind/kind-podman runtime
 '${KWOK_METRICS_SERVER_IMAGE_PREFIX}/metrics-server:${KWOK_METRICS_SERVER_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.CoreDNSImage, "coredns-image", flags.Options.CoreDNSImage, `Image of CoreDNS, only for docker/podman/nerdctl/crio runtime
+'${KWOK_COREDNS_IMAGE_PREFIX}/coredns:${KWOK_COREDNS_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.KubeStateMetricsImage, "kube-state-metrics-image", flags.Options.KubeStateMetricsImage, `Image of kube-state-metrics, only for docker/podman/nerdctl/crio runtime
+'${KWOK_KUBE_STATE_METRICS_IMAGE_PREFIX}/kube-state-metrics:${KWOK_KUBE_STATE_METRICS_VERSION}'
 `)
 	cmd.Flags().StringVar(&flags.Options.PrometheusImage, "prometheus-image", flags.Options.PrometheusImage, `Image of Prometheus, only for docker/podman/nerdctl/kind/kind-podman runtime
 '${KWOK_PROMETHEUS_IMAGE_PREFIX}/prometheus:${KWOK_PROMETHEUS_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.GrafanaImage, "grafana-image", flags.Options.GrafanaImage, `Image of Grafana, only for docker/podman/nerdctl runtime
+'${KWOK_GRAFANA_IMAGE_PREFIX}/grafana:${KWOK_GRAFANA_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.OtelCollectorImage, "otel-collector-image", flags.Options.OtelCollectorImage, `Image of otel-collector, only for docker/podman/nerdctl runtime
+'${KWOK_OTEL_COLLECTOR_IMAGE_PREFIX}/opentelemetry-collector:${KWOK_OTEL_COLLECTOR_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.KonnectivityServerImage, "konnectivity-server-image", flags.Options.KonnectivityServerImage, `Image of konnectivity-server, only for docker/podman/nerdctl runtime
+'${KWOK_KONNECTIVITY_IMAGE_PREFIX}/proxy-server:${KWOK_KONNECTIVITY_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.KonnectivityAgentImage, "konnectivity-agent-image", flags.Options.KonnectivityAgentImage, `Image of konnectivity-agent, only for docker/podman/nerdctl runtime
+'${KWOK_KONNECTIVITY_IMAGE_PREFIX}/proxy-agent:${KWOK_KONNECTIVITY_VERSION}'
 `)
 	cmd.Flags().StringVar(&flags.Options.JaegerImage, "jaeger-image", flags.Options.JaegerImage, `Image of Jaeger, only for docker/podman/nerdctl/kind/kind-podman runtime
 '${KWOK_JAEGER_IMAGE_PREFIX}/all-in-one:${KWOK_JAEGER_VERSION}'
@@ -110,6 +169,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 `)
 	cmd.Flags().StringVar(&flags.Options.KubeControllerManagerBinary, "kube-controller-manager-binary", flags.Options.KubeControllerManagerBinary, `Binary of kube-controller-manager, only for binary runtime
 `)
+	cmd.Flags().StringVar(&flags.Options.CloudControllerManagerBinary, "cloud-controller-manager-binary", flags.Options.CloudControllerManagerBinary, `Binary of the cloud-controller-manager of the cloud provider, required if --cloud-provider is set, only for binary runtime`)
 	cmd.Flags().StringVar(&flags.Options.KubeSchedulerBinary, "kube-scheduler-binary", flags.Options.KubeSchedulerBinary, `Binary of kube-scheduler, only for binary runtime
 `)
 	cmd.Flags().StringVar(&flags.Options.KwokControllerBinary, "kwok-controller-binary", flags.Options.KwokControllerBinary, `Binary of kwok-controller, only for binary runtime
@@ -118,21 +178,51 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Options.EtcdBinaryTar, "etcd-binary-tar", flags.Options.EtcdBinaryTar, `Tar of etcd, if --etcd-binary is set, this is ignored, only for binary runtime
 `)
 	_ = cmd.Flags().MarkDeprecated("etcd-binary-tar", "--etcd-binary-tar will be removed in a future release, please use --etcd-binary instead")
+	cmd.Flags().Uint32Var(&flags.Options.EtcdReplicas, "etcd-replicas", flags.Options.EtcdReplicas, `Number of the members of etcd, wired with peer TLS, only for docker/podman/nerdctl runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverReplicas, "kube-apiserver-replicas", flags.Options.KubeApiserverReplicas, `Number of the kube-apiservers sharing the same etcd, load balanced by haproxy which the kubeconfig points at, only for docker/podman/nerdctl runtime`)
+	cmd.Flags().StringVar(&flags.Options.HaproxyImage, "haproxy-image", flags.Options.HaproxyImage, `Image of haproxy which load balances the kube-apiservers, only for docker/podman/nerdctl runtime
+'docker.io/library/haproxy:${KWOK_HAPROXY_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.EtcdBackend, "etcd-backend", flags.Options.EtcdBackend, `Backend of etcd, one of etcd, kine-sqlite, kine-mysql or kine-postgres, kine is not supported by kind runtime`)
+	cmd.Flags().StringVar(&flags.Options.KineEndpoint, "kine-endpoint", flags.Options.KineEndpoint, `Endpoint of the database for kine, required for kine-mysql and kine-postgres`)
+	cmd.Flags().StringVar(&flags.Options.KineImage, "kine-image", flags.Options.KineImage, `Image of kine, only for docker/podman/nerdctl runtime
+'docker.io/rancher/kine:${KWOK_KINE_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.KineBinary, "kine-binary", flags.Options.KineBinary, `Binary of kine, only for binary runtime`)
+	cmd.Flags().StringSliceVar(&flags.Options.EtcdEndpoints, "etcd-endpoints", flags.Options.EtcdEndpoints, `Endpoints of an external etcd to use instead of launching one, not supported by kind/kubernetes runtime`)
+	cmd.Flags().StringVar(&flags.Options.EtcdCaFile, "etcd-ca-file", flags.Options.EtcdCaFile, `Path of the CA certificate to verify the external etcd`)
+	cmd.Flags().StringVar(&flags.Options.EtcdCertFile, "etcd-cert-file", flags.Options.EtcdCertFile, `Path of the client certificate to access the external etcd`)
+	cmd.Flags().StringVar(&flags.Options.EtcdKeyFile, "etcd-key-file", flags.Options.EtcdKeyFile, `Path of the client key to access the external etcd`)
 	cmd.Flags().StringVar(&flags.Options.EtcdPrefix, "etcd-prefix", flags.Options.EtcdPrefix, `prefix of the key`)
+	cmd.Flags().StringVar(&flags.Options.EtcdTemplate, "etcd-template", flags.Options.EtcdTemplate, `Path of an etcd snapshot or name of a template saved by 'kwokctl snapshot save --as-template' to pre-seed the data of etcd`)
+	cmd.Flags().StringVar(&flags.Options.IPFamily, "ip-family", flags.Options.IPFamily, `IP family of the cluster, one of ipv4, ipv6 or dual, only ipv4 is supported by crio/kubernetes runtime`)
+	cmd.Flags().StringVar(&flags.Options.CaCert, "ca-cert", flags.Options.CaCert, `Path of an existing CA certificate to sign the certs of the cluster, a new CA is generated if it is empty`)
+	cmd.Flags().StringVar(&flags.Options.CaKey, "ca-key", flags.Options.CaKey, `Path of the key of the CA certificate, required with --ca-cert`)
 	cmd.Flags().StringVar(&flags.Options.MetricsServerBinary, "metrics-server-binary", flags.Options.MetricsServerBinary, `Binary of metrics-server, only for binary runtime`)
+	cmd.Flags().StringVar(&flags.Options.CoreDNSBinary, "coredns-binary", flags.Options.CoreDNSBinary, `Binary of CoreDNS, only for binary runtime`)
+	cmd.Flags().StringVar(&flags.Options.KubeStateMetricsBinary, "kube-state-metrics-binary", flags.Options.KubeStateMetricsBinary, `Binary of kube-state-metrics, required if --enable-kube-state-metrics is set as there are no released binaries, only for binary runtime`)
 	cmd.Flags().StringVar(&flags.Options.PrometheusBinary, "prometheus-binary", flags.Options.PrometheusBinary, `Binary of Prometheus, only for binary runtime`)
 	cmd.Flags().StringVar(&flags.Options.PrometheusBinaryTar, "prometheus-binary-tar", flags.Options.PrometheusBinaryTar, `Tar of Prometheus, if --prometheus-binary is set, this is ignored, only for binary runtime
 `)
 	_ = cmd.Flags().MarkDeprecated("prometheus-binary-tar", "--prometheus-binary-tar will be removed in a future release, please use --prometheus-binary instead")
+	cmd.Flags().StringVar(&flags.Options.OtelCollectorBinary, "otel-collector-binary", flags.Options.OtelCollectorBinary, `Binary of otel-collector, only for binary runtime`)
 	cmd.Flags().StringVar(&flags.Options.JaegerBinary, "jaeger-binary", flags.Options.JaegerBinary, `Binary of Jaeger, only for binary runtime`)
 	cmd.Flags().StringVar(&flags.Options.JaegerBinaryTar, "jaeger-binary-tar", flags.Options.JaegerBinaryTar, `Tar of Jaeger, if --jaeger-binary is set, this is ignored, only for binary runtime
 `)
 	_ = cmd.Flags().MarkDeprecated("jaeger-binary-tar", "--jaeger-binary-tar will be removed in a future release, please use --jaeger-binary instead")
+	cmd.Flags().UintVar(&flags.Options.KindWorkers, "kind-workers", flags.Options.KindWorkers, `Number of the workers of kind, a kwok-controller runs on each of them to manage the nodes labeled with kwok.x-k8s.io/shard=<index of the worker>, only for kind/kind-podman runtime`)
+	cmd.Flags().UintVar(&flags.Options.KindRealWorkers, "kind-real-workers", flags.Options.KindRealWorkers, `Number of the real workers of kind, which run the pods with their kubelets alongside the fake nodes and are labeled with kwok.x-k8s.io/node=real, only for kind/kind-podman runtime`)
+	cmd.Flags().BoolVar(&flags.Options.Rootless, "rootless", flags.Options.Rootless, `Handle the daemon of the container runtime as rootless, remap the privileged ports, drop the mount propagation and run the components as the user of the daemon, it is detected automatically if not set, only for docker/podman/nerdctl runtime`)
+	cmd.Flags().StringArrayVar(&flags.NodeProfiles, "node-profile", flags.NodeProfiles, "Register the nodes with the shape of a node preset when the cluster is created in the format of preset=replicas, e.g. eks/m5.xlarge=100, see 'kwokctl presets list node'")
 	cmd.Flags().StringVar(&flags.Options.KindBinary, "kind-binary", flags.Options.KindBinary, `Binary of kind, only for kind/kind-podman runtime
 `)
 	cmd.Flags().StringVar(&flags.Options.KubeFeatureGates, "kube-feature-gates", flags.Options.KubeFeatureGates, `A set of key=value pairs that describe feature gates for alpha/experimental features of Kubernetes`)
 	cmd.Flags().StringVar(&flags.Options.KubeRuntimeConfig, "kube-runtime-config", flags.Options.KubeRuntimeConfig, `A set of key=value pairs that enable or disable built-in APIs`)
+	cmd.Flags().StringVar(&flags.Options.KubeEmulateRemovals, "emulate-removals", flags.Options.KubeEmulateRemovals, "Disable the APIs removed by a release of Kubernetes, e.g. v1.33, to test the clients against the upcoming removals of APIs")
 	cmd.Flags().StringVar(&flags.Options.KubeAuditPolicy, "kube-audit-policy", flags.Options.KubeAuditPolicy, "Path to the file that defines the audit policy configuration")
+	cmd.Flags().StringVar(&flags.Options.KubeAuditWebhook, "kube-audit-webhook", flags.Options.KubeAuditWebhook, "URL of the backend which kube-apiserver sends the audit events to, requires --kube-audit-policy, only for binary and docker/podman/nerdctl runtime")
+	cmd.Flags().BoolVar(&flags.Options.EnableKubeAuditSink, "enable-kube-audit-sink", flags.Options.EnableKubeAuditSink, "Enable the audit sink which receives the audit events of kube-apiserver by the webhook and stores them as JSON lines in the logs of the cluster, requires --kube-audit-policy, only for binary and docker/podman/nerdctl runtime")
+	cmd.Flags().Uint32Var(&flags.Options.KubeAuditSinkPort, "kube-audit-sink-port", flags.Options.KubeAuditSinkPort, "Port of the audit sink given to the host, a random one is used for binary runtime if not set")
 	cmd.Flags().BoolVar(&flags.Options.KubeAuthorization, "kube-authorization", flags.Options.KubeAuthorization, "Enable authorization for kube-apiserver, only for non kind/kind-podman runtime")
 	cmd.Flags().BoolVar(&flags.Options.KubeAdmission, "kube-admission", flags.Options.KubeAdmission, "Enable admission for kube-apiserver, only for non kind/kind-podman runtime")
 	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the cluster (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
@@ -140,9 +230,22 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready")
 	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file will be added to the newly created cluster and set to current-context")
 	cmd.Flags().BoolVar(&flags.Options.DisableQPSLimits, "disable-qps-limits", flags.Options.DisableQPSLimits, "Disable QPS limits for components")
+	cmd.Flags().BoolVar(&flags.Options.SuperviseComponents, "supervise-components", flags.Options.SuperviseComponents, "Restart the components of the binary runtime when they exit and record their restarts")
+	cmd.Flags().StringVar(&flags.Options.InitSystem, "init", flags.Options.InitSystem, "Init system to manage the components of the binary runtime (systemd), the components are forked by kwokctl if empty")
+	cmd.Flags().StringSliceVar(&flags.Options.DNSNames, "dns-names", flags.Options.DNSNames, "DNS names of the apiserver and the components, added to the certs, the first one is used as the TLS server name in the kubeconfig")
 	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
 	cmd.Flags().UintVar(&flags.Options.NodeLeaseDurationSeconds, "node-lease-duration-seconds", flags.Options.NodeLeaseDurationSeconds, "Duration of node lease in seconds")
 	cmd.Flags().Float64Var(&flags.Options.HeartbeatFactor, "heartbeat-factor", flags.Options.HeartbeatFactor, "Scale factor for all about heartbeat")
+	cmd.Flags().Float64Var(&flags.Options.TimeAcceleration, "time-acceleration", flags.Options.TimeAcceleration, "Factor by which the time of the simulation is accelerated, the delays of the stages and the intervals and the timeouts of the heartbeats are divided by it, and the timestamps generated by the stages pass faster by it, 0 or 1 means real time")
+	cmd.Flags().StringVar(&flags.Options.Lifecycle, "lifecycle", flags.Options.Lifecycle, `Bundled stages to simulate the lifecycle of pods when no stage is configured (fast or realistic or chaos or none)
+fast: pods are ready as soon as they are scheduled, the default of kwok-controller
+realistic: pods go through the init containers and the containers with delays of a few seconds
+chaos: realistic, and about one in sixteen pods fail
+none: no stages, the Stage CRD is enabled so that stages can be applied later`)
+	cmd.Flags().IntVar(&flags.Count, "count", 1, "Number of clusters to create, the clusters are named with the name and an index suffix when it is greater than 1, and the ports must be left random")
+	cmd.Flags().IntVar(&flags.Workers, "workers", 4, "Number of clusters to create concurrently with --count")
+	cmd.Flags().BoolVar(&flags.FromExistingData, "from-existing-data", false, "Recreate the cluster from the data kept by 'kwokctl delete cluster --keep-data', the other flags of the cluster are ignored")
+	cmd.Flags().StringVar(&flags.FromBundle, "from-bundle", "", "Create the cluster from a bundle exported by 'kwokctl export bundle', the other flags of the cluster are ignored")
 	cmd.Flags().StringArrayVar(&flags.ExtraArgs, "extra-args", flags.ExtraArgs, "Pass a single extra arg key-value pair to the component in the format `component=key=value`")
 
 	return cmd
@@ -163,6 +266,129 @@ func mutationHeartbeat(flags *flagpole) {
 	flags.Options.HeartbeatFactor = 1
 }
 
+func divideByFactor[T ~int64 | ~uint](num *T, factor float64) {
+	if *num == 0 {
+		return
+	}
+	*num = max(T(float64(*num)/factor), 1)
+}
+
+func mutationTimeAcceleration(flags *flagpole) error {
+	if flags.Options.TimeAcceleration == 0 || flags.Options.TimeAcceleration == 1 {
+		return nil
+	}
+	if flags.Options.TimeAcceleration < 0 {
+		return fmt.Errorf("--time-acceleration must be positive, got %v", flags.Options.TimeAcceleration)
+	}
+	// The kwok-controller accelerates the heartbeats itself, only kube-controller-manager needs to keep up with it
+	divideByFactor(&flags.Options.KubeControllerManagerNodeMonitorGracePeriodMilliseconds, flags.Options.TimeAcceleration)
+	divideByFactor(&flags.Options.KubeControllerManagerNodeMonitorPeriodMilliseconds, flags.Options.TimeAcceleration)
+	return nil
+}
+
+func mutationLifecycle(flags *flagpole) error {
+	if flags.Options.Lifecycle == "" {
+		return nil
+	}
+	if !slices.Contains(lifecycle.Names, flags.Options.Lifecycle) {
+		return fmt.Errorf("unknown lifecycle %q, must be one of %v", flags.Options.Lifecycle, lifecycle.Names)
+	}
+	if flags.Options.Lifecycle == lifecycle.None && !slices.Contains(flags.Options.EnableCRDs, v1alpha1.StageKind) {
+		flags.Options.EnableCRDs = append(flags.Options.EnableCRDs, v1alpha1.StageKind)
+	}
+	return nil
+}
+
+func mutationEmulateRemovals(flags *flagpole) error {
+	if flags.Options.KubeEmulateRemovals == "" {
+		return nil
+	}
+	target, err := version.ParseVersion(flags.Options.KubeEmulateRemovals)
+	if err != nil {
+		return fmt.Errorf("invalid --emulate-removals %q: %w", flags.Options.KubeEmulateRemovals, err)
+	}
+	current, err := version.ParseVersion(flags.Options.KubeVersion)
+	if err != nil {
+		return fmt.Errorf("invalid kube version %q: %w", flags.Options.KubeVersion, err)
+	}
+	if target.Major != current.Major || target.Minor <= current.Minor {
+		return fmt.Errorf("--emulate-removals %s must be a later release than the kube version %s", flags.Options.KubeEmulateRemovals, flags.Options.KubeVersion)
+	}
+
+	runtimeConfig := k8s.GetEmulateRemovalsRuntimeConfig(int(current.Minor), int(target.Minor))
+	if runtimeConfig == "" {
+		return nil
+	}
+	if flags.Options.KubeRuntimeConfig != "" {
+		runtimeConfig = flags.Options.KubeRuntimeConfig + "," + runtimeConfig
+	}
+	flags.Options.KubeRuntimeConfig = runtimeConfig
+	return nil
+}
+
+// checkExternalEtcd checks the options conflicting with an external etcd, and expands the paths of its certificates.
+func checkExternalEtcd(flags *flagpole) (err error) {
+	if len(flags.Options.EtcdEndpoints) == 0 {
+		return nil
+	}
+	if components.IsKineBackend(flags.Options.EtcdBackend) {
+		return fmt.Errorf("--etcd-endpoints is not supported by etcd backend %q", flags.Options.EtcdBackend)
+	}
+	if flags.Options.EtcdReplicas > 1 {
+		return fmt.Errorf("--etcd-endpoints is not supported with %d members of etcd", flags.Options.EtcdReplicas)
+	}
+	if flags.Options.EtcdTemplate != "" {
+		return fmt.Errorf("--etcd-template is not supported with --etcd-endpoints")
+	}
+	for _, p := range []*string{
+		&flags.Options.EtcdCaFile,
+		&flags.Options.EtcdCertFile,
+		&flags.Options.EtcdKeyFile,
+	} {
+		if *p == "" {
+			continue
+		}
+		*p, err = path.Expand(*p)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkIPFamily checks the IP family, and binds the components to the IPv6 address for an IPv6-only cluster.
+func checkIPFamily(flags *flagpole) error {
+	switch flags.Options.IPFamily {
+	case consts.IPFamilyIPv4, consts.IPFamilyDual:
+	case consts.IPFamilyIPv6:
+		if flags.Options.BindAddress == net.PublicAddress {
+			flags.Options.BindAddress = net.PublicIPv6Address
+		}
+	default:
+		return fmt.Errorf("invalid --ip-family %q, must be one of ipv4, ipv6 or dual", flags.Options.IPFamily)
+	}
+	return nil
+}
+
+// checkCA checks the existing CA, and expands the paths of its certificate and key.
+func checkCA(flags *flagpole) (err error) {
+	if (flags.Options.CaCert == "") != (flags.Options.CaKey == "") {
+		return fmt.Errorf("--ca-cert and --ca-key must be specified together")
+	}
+	if flags.Options.CaCert == "" {
+		return nil
+	}
+	flags.Options.CaCert, err = path.Expand(flags.Options.CaCert)
+	if err != nil {
+		return err
+	}
+	flags.Options.CaKey, err = path.Expand(flags.Options.CaKey)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func mutationComponentPatches(flags *flagpole) {
 	componentPatches := make([]internalversion.ComponentPatches, 0, len(flags.ExtraArgs))
 	componentNames := make(map[string]int)
@@ -193,6 +419,130 @@ func mutationComponentPatches(flags *flagpole) {
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
+	var err error
+	if flags.Kubeconfig != "" {
+		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.FromExistingData {
+		return attachCluster(ctx, flags)
+	}
+
+	if flags.FromBundle != "" {
+		// The config of the bundle has been mutated when the cluster is created from the flags.
+		ctx, err = loadBundle(ctx, flags)
+		if err != nil {
+			return err
+		}
+	} else {
+		mutationHeartbeat(flags)
+		err = mutationTimeAcceleration(flags)
+		if err != nil {
+			return err
+		}
+		mutationComponentPatches(flags)
+		err = mutationLifecycle(flags)
+		if err != nil {
+			return err
+		}
+		err = mutationEmulateRemovals(flags)
+		if err != nil {
+			return err
+		}
+		for _, s := range flags.NodeProfiles {
+			profile, err := parseNodeProfile(s)
+			if err != nil {
+				return err
+			}
+			flags.Options.NodeProfiles = append(flags.Options.NodeProfiles, profile)
+		}
+	}
+	err = checkExternalEtcd(flags)
+	if err != nil {
+		return err
+	}
+	err = checkIPFamily(flags)
+	if err != nil {
+		return err
+	}
+	err = checkCA(flags)
+	if err != nil {
+		return err
+	}
+	if flags.Options.EtcdTemplate != "" {
+		if components.IsKineBackend(flags.Options.EtcdBackend) {
+			return fmt.Errorf("--etcd-template is not supported by etcd backend %q", flags.Options.EtcdBackend)
+		}
+		flags.Options.EtcdTemplate, err = snapshot.ResolveTemplate(config.TemplatesDir, flags.Options.EtcdTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.Count <= 1 {
+		return createCluster(ctx, flags)
+	}
+	if dryrun.DryRun && dryrun.Format == dryrun.FormatCompose {
+		return fmt.Errorf("--dry-run-format=%s does not support --count", dryrun.FormatCompose)
+	}
+
+	names := fleet.MemberNames(flags.Name, flags.Count)
+	_, err = fleet.Run(ctx, names, flags.Workers, func(ctx context.Context, clusterName string) error {
+		f := *flags
+		f.Name = clusterName
+		f.KwokctlConfiguration = flags.KwokctlConfiguration.DeepCopy()
+		return createCluster(ctx, &f)
+	})
+	return err
+}
+
+// loadBundle loads the bundle into the flags, and returns the context with the objects of the bundle.
+func loadBundle(ctx context.Context, flags *flagpole) (context.Context, error) {
+	dir, err := path.Expand(flags.FromBundle)
+	if err != nil {
+		return nil, err
+	}
+	b, err := bundle.Load(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bundle %q: %w", flags.FromBundle, err)
+	}
+	flags.bundle = b
+	*flags.KwokctlConfiguration = *b.Config
+
+	objs := append([]config.InternalObject{flags.KwokctlConfiguration}, b.Objects...)
+	return config.NewContext(ctx, objs), nil
+}
+
+// restoreBundle restores the scale records of the bundle into the cluster and checks the components against the lock of it.
+func restoreBundle(ctx context.Context, rt runtime.Runtime, workdir string, b *bundle.Bundle, conf *internalversion.KwokctlConfiguration) {
+	logger := log.FromContext(ctx)
+	if b.Lock != nil {
+		for _, warning := range bundle.CheckLock(*b.Lock, conf.Components) {
+			logger.Warn("The cluster is not identical to the bundle", "reason", warning)
+		}
+	}
+
+	if rt.IsDryRun() {
+		return
+	}
+	records, err := b.RestoreScales(workdir)
+	if err != nil {
+		logger.Warn("Failed to restore scale records of bundle", "err", err)
+		return
+	}
+	for _, record := range records {
+		logger.Info("Restored scale record, resume it with 'kwokctl scale <resource> [name] --resume'",
+			"resource", record.Kind,
+			"name", record.Name,
+			"replicas", record.Replicas,
+		)
+	}
+}
+
+func attachCluster(ctx context.Context, flags *flagpole) error {
 	name := config.ClusterName(flags.Name)
 	workdir := path.Join(config.ClustersDir, flags.Name)
 
@@ -200,14 +550,120 @@ func runE(ctx context.Context, flags *flagpole) error {
 	logger = logger.With("cluster", flags.Name)
 	ctx = log.NewContext(ctx, logger)
 
-	var err error
+	if flags.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flags.Timeout)
+		defer cancel()
+	}
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster data does not exist")
+		}
+		return err
+	}
+
+	err = rt.Available(ctx)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	logger.Info("Cluster is attaching")
+	err = rt.Attach(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to attach cluster %q: %w", name, err)
+	}
+	logger.Info("Cluster is attached",
+		"elapsed", time.Since(start),
+	)
+
 	if flags.Kubeconfig != "" {
-		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		err = rt.AddContext(ctx, flags.Kubeconfig)
 		if err != nil {
-			return err
+			logger.Error("Failed to add context to kubeconfig", err,
+				"kubeconfig", flags.Kubeconfig,
+			)
+		} else {
+			recordKubeconfig(ctx, rt, workdir, flags.Kubeconfig)
 		}
 	}
 
+	if flags.Wait > 0 {
+		start = time.Now()
+		logger.Info("Waiting for cluster to be ready")
+		err = rt.WaitReady(ctx, flags.Wait)
+		if err != nil {
+			logger.Error("Failed to wait for cluster to be ready", err,
+				"elapsed", time.Since(start),
+			)
+		} else {
+			logger.Info("Cluster is ready",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+	return nil
+}
+
+func hasReadinessTimeout(components []internalversion.Component) bool {
+	for _, component := range components {
+		if component.ReadinessTimeoutMilliseconds > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// recordKubeconfig records the kubeconfig the context is added to, so it can be cleaned up on deletion.
+func recordKubeconfig(ctx context.Context, rt runtime.Runtime, workdir string, kubeconfigPath string) {
+	if rt.IsDryRun() {
+		return
+	}
+	err := runtime.RecordKubeconfig(workdir, kubeconfigPath)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to record kubeconfig", "kubeconfig", kubeconfigPath, "err", err)
+	}
+}
+
+// setComposeFile sets the compose file of the components for the compose format of dry-run.
+func setComposeFile(ctx context.Context, rt runtime.Runtime, name string, runtimeType string) error {
+	switch runtimeType {
+	case consts.RuntimeTypeDocker,
+		consts.RuntimeTypePodman,
+		consts.RuntimeTypeNerdctl,
+		consts.RuntimeTypeLima,
+		consts.RuntimeTypeFinch:
+	default:
+		return fmt.Errorf("--dry-run-format=%s is not supported by %s runtime", dryrun.FormatCompose, runtimeType)
+	}
+
+	list, err := rt.ListComponents(ctx)
+	if err != nil {
+		return err
+	}
+	compose, err := components.ConvertToCompose(name, list)
+	if err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(compose)
+	if err != nil {
+		return err
+	}
+	dryrun.SetComposeFile(content)
+	return nil
+}
+
+func createCluster(ctx context.Context, flags *flagpole) (retErr error) {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
 	gctx := ctx
 	if flags.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -215,10 +671,8 @@ func runE(ctx context.Context, flags *flagpole) error {
 		defer cancel()
 	}
 
-	mutationHeartbeat(flags)
-	mutationComponentPatches(flags)
-
 	// Choose runtime
+	var err error
 	var rt runtime.Runtime
 	if flags.Options.Runtime == "" {
 		errs := make([]error, 0, len(flags.Options.Runtimes))
@@ -264,6 +718,26 @@ func runE(ctx context.Context, flags *flagpole) error {
 	exist := err == nil
 	if exist {
 		logger.Info("Cluster already exists")
+		checkpointsDir := rt.GetWorkdirPath(runtime.CheckpointsName)
+		last, err := checkpoint.Load(checkpointsDir, checkpoint.OperationCreate)
+		if err != nil {
+			return err
+		}
+		if last != nil {
+			logger.Warn("The last creation of the cluster was interrupted", "message", last.Message)
+		}
+		defer func() {
+			switch {
+			case retErr == nil && last != nil:
+				err := checkpoint.Remove(checkpointsDir, checkpoint.OperationCreate)
+				if err != nil {
+					logger.Warn("Failed to remove the checkpoint of the creation", "error", err)
+				}
+			case retErr != nil && signals.IsInterrupted(ctx):
+				logger.Warn("Cluster creation is interrupted, run it again to continue it")
+				recordInterrupted(context.WithoutCancel(ctx), rt, retErr)
+			}
+		}()
 		if ready, err := rt.Ready(ctx); err == nil && ready {
 			logger.Info("Cluster is already ready")
 			return nil
@@ -271,7 +745,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 		logger.Info("Cluster is not ready yet, continue it")
 	} else {
 		cleanUp := func() {
-			subCtx := context.Background()
+			subCtx := context.WithoutCancel(ctx)
 			err := rt.Uninstall(subCtx)
 			if err != nil {
 				logger.Error("Failed to clean up cluster", err)
@@ -301,9 +775,42 @@ func runE(ctx context.Context, flags *flagpole) error {
 			cleanUp()
 			return err
 		}
+		list, err := rt.ListComponents(ctx)
+		if err != nil {
+			logger.Error("Failed to list components", err)
+			cleanUp()
+			return err
+		}
+		_, err = components.GroupByLinks(list)
+		if err != nil {
+			logger.Error("Invalid links of components", err)
+			cleanUp()
+			return err
+		}
+
+		// Roll back the cluster if it is interrupted before it is ready to use,
+		// rather than leaving a half-created cluster for the next run to trip over.
+		defer func() {
+			if retErr == nil || !signals.IsInterrupted(ctx) {
+				return
+			}
+			logger.Warn("Cluster creation is interrupted, rolling back")
+			subCtx := context.WithoutCancel(ctx)
+			err := rollbackCluster(subCtx, rt, flags.Kubeconfig)
+			if err != nil {
+				logger.Error("Failed to roll back cluster, run it again to continue it or delete it", err)
+				recordInterrupted(subCtx, rt, retErr)
+				return
+			}
+			logger.Info("Cluster is rolled back")
+		}()
 		logger.Info("Cluster is created",
 			"elapsed", time.Since(start),
 		)
+
+		if rt.IsDryRun() && dryrun.Format == dryrun.FormatCompose {
+			return setComposeFile(ctx, rt, name, flags.Options.Runtime)
+		}
 	}
 
 	if flags.Kubeconfig != "" {
@@ -317,6 +824,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 				logger.Debug("Added context to kubeconfig",
 					"kubeconfig", flags.Kubeconfig,
 				)
+				recordKubeconfig(ctx, rt, workdir, flags.Kubeconfig)
 			}
 		}
 
@@ -343,6 +851,15 @@ func runE(ctx context.Context, flags *flagpole) error {
 		"elapsed", time.Since(start),
 	)
 
+	// Only the binary runtime pre-seeds the data of etcd before it starts,
+	// so restore the template into the started cluster for the others.
+	if !exist && flags.Options.EtcdTemplate != "" && flags.Options.Runtime != consts.RuntimeTypeBinary {
+		err = rt.SnapshotRestore(ctx, flags.Options.EtcdTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to restore etcd template of cluster %q: %w", name, err)
+		}
+	}
+
 	err = rt.InitCRDs(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to init crds %q: %w", name, err)
@@ -352,8 +869,43 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return fmt.Errorf("failed to init crs %q: %w", name, err)
 	}
 
+	if !exist {
+		err = registerNodes(ctx, rt, &flags.Options)
+		if err != nil {
+			return fmt.Errorf("failed to register nodes of cluster %q: %w", name, err)
+		}
+	}
+
+	// Wait for components to be ready
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if !exist && flags.bundle != nil {
+		restoreBundle(ctx, rt, workdir, flags.bundle, conf)
+	}
+	waitCluster := flags.Wait > 0
+	if conf.Options.ReadinessFailurePolicy != "" || hasReadinessTimeout(conf.Components) {
+		start = time.Now()
+		logger.Info("Waiting for components to be ready")
+		summary, err := runtime.WaitComponentsReady(gctx, rt, conf.Options.ReadinessFailurePolicy, flags.Wait)
+		logger.Info("Components readiness",
+			"elapsed", time.Since(start),
+			"ready", summary.Ready,
+			"timedOut", summary.TimedOut,
+			"skipped", summary.Skipped,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to wait for components of cluster %q to be ready: %w", name, err)
+		}
+		// The cluster is not ready as a whole if some components are not ready
+		if len(summary.TimedOut) != 0 || len(summary.Skipped) != 0 {
+			waitCluster = false
+		}
+	}
+
 	// Wait for cluster to be ready
-	if flags.Wait > 0 {
+	if waitCluster {
 		start = time.Now()
 		logger.Info("Waiting for cluster to be ready")
 		err = rt.WaitReady(gctx, flags.Wait)
@@ -368,7 +920,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	}
 
-	if log.IsTerminal() && flags.Kubeconfig != "" && !rt.IsDryRun() {
+	if log.IsTerminal() && flags.Kubeconfig != "" && !rt.IsDryRun() && flags.Count <= 1 {
 		_, _ = fmt.Fprintf(os.Stderr, `You can now use your cluster with:
 
 	kubectl cluster-info --context %s
@@ -378,3 +930,42 @@ Thanks for using kwok!
 	}
 	return nil
 }
+
+// rollbackCluster stops and uninstalls the cluster whose creation is interrupted.
+// The working directory is kept if the cluster fails to stop,
+// so that the cluster can still be continued or deleted.
+func rollbackCluster(ctx context.Context, rt runtime.Runtime, kubeconfigPath string) error {
+	err := rt.Down(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stop cluster: %w", err)
+	}
+	var errs []error
+	if kubeconfigPath != "" {
+		err = rt.RemoveContext(ctx, kubeconfigPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove context from kubeconfig: %w", err))
+		}
+	}
+	err = rt.Uninstall(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to clean up cluster: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// recordInterrupted records the creation of the cluster left unfinished by an interrupt,
+// so that the next creation knows it continues a half-created cluster.
+func recordInterrupted(ctx context.Context, rt runtime.Runtime, cause error) {
+	if rt.IsDryRun() {
+		return
+	}
+	err := checkpoint.Save(rt.GetWorkdirPath(runtime.CheckpointsName), &checkpoint.Checkpoint{
+		Operation:       checkpoint.OperationCreate,
+		Message:         cause.Error(),
+		InterruptedTime: time.Now(),
+	})
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Error("Failed to record the interrupted creation", err)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type fakeRuntime struct {
+	runtime.Runtime
+	workdir      string
+	downErr      error
+	removeErr    error
+	uninstallErr error
+	operations   []string
+}
+
+func (f *fakeRuntime) Down(ctx context.Context) error {
+	f.operations = append(f.operations, "down")
+	return f.downErr
+}
+
+func (f *fakeRuntime) RemoveContext(ctx context.Context, kubeconfigPath string) error {
+	f.operations = append(f.operations, "remove-context")
+	return f.removeErr
+}
+
+func (f *fakeRuntime) Uninstall(ctx context.Context) error {
+	f.operations = append(f.operations, "uninstall")
+	return f.uninstallErr
+}
+
+func (f *fakeRuntime) GetWorkdirPath(name string) string {
+	return path.Join(f.workdir, name)
+}
+
+func (f *fakeRuntime) IsDryRun() bool {
+	return false
+}
+
+func TestRollbackCluster(t *testing.T) {
+	errFake := errors.New("fake")
+	tests := []struct {
+		name           string
+		rt             *fakeRuntime
+		kubeconfig     string
+		wantOperations []string
+		wantErr        bool
+	}{
+		{
+			name:           "rolled back",
+			rt:             &fakeRuntime{},
+			kubeconfig:     "kubeconfig",
+			wantOperations: []string{"down", "remove-context", "uninstall"},
+		},
+		{
+			name:           "without kubeconfig",
+			rt:             &fakeRuntime{},
+			wantOperations: []string{"down", "uninstall"},
+		},
+		{
+			name:           "failed to stop",
+			rt:             &fakeRuntime{downErr: errFake},
+			kubeconfig:     "kubeconfig",
+			wantOperations: []string{"down"},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to remove context",
+			rt:             &fakeRuntime{removeErr: errFake},
+			kubeconfig:     "kubeconfig",
+			wantOperations: []string{"down", "remove-context", "uninstall"},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to uninstall",
+			rt:             &fakeRuntime{uninstallErr: errFake},
+			wantOperations: []string{"down", "uninstall"},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rollbackCluster(context.Background(), tt.rt, tt.kubeconfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rollbackCluster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantOperations, tt.rt.operations); diff != "" {
+				t.Errorf("rollbackCluster() operations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordInterrupted(t *testing.T) {
+	rt := &fakeRuntime{workdir: t.TempDir()}
+	recordInterrupted(context.Background(), rt, errors.New("failed to start cluster"))
+
+	last, err := checkpoint.Load(rt.GetWorkdirPath(runtime.CheckpointsName), checkpoint.OperationCreate)
+	if err != nil {
+		t.Fatalf("checkpoint.Load() error = %v", err)
+	}
+	if last == nil || last.Message != "failed to start cluster" {
+		t.Errorf("checkpoint.Load() = %+v, want the interrupted creation", last)
+	}
+}
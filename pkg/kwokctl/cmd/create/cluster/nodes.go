@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// parseNodeProfile parses the node profile in the format of preset=replicas.
+func parseNodeProfile(s string) (internalversion.NodeProfile, error) {
+	preset, replicas, ok := strings.Cut(s, "=")
+	if !ok || preset == "" {
+		return internalversion.NodeProfile{}, fmt.Errorf("invalid node profile %q, expected preset=replicas", s)
+	}
+	n, err := strconv.ParseUint(replicas, 10, 0)
+	if err != nil || n == 0 {
+		return internalversion.NodeProfile{}, fmt.Errorf("invalid replicas of node profile %q", s)
+	}
+	return internalversion.NodeProfile{
+		Preset:   preset,
+		Replicas: uint(n),
+	}, nil
+}
+
+// nodeProfileName returns the prefix of the names of the nodes of the preset, e.g. eks-m5-xlarge for eks/m5.xlarge.
+func nodeProfileName(preset string) string {
+	return strings.NewReplacer("/", "-", ".", "-", "_", "-").Replace(strings.ToLower(preset))
+}
+
+// shardDistributions returns the distribution of the shard label over the kwok-controllers on the kind workers.
+func shardDistributions(conf *internalversion.KwokctlConfigurationOptions) []scale.Distribution {
+	if conf.KindWorkers == 0 || components.GetRuntimeMode(conf.Runtime) != components.RuntimeModeCluster {
+		return nil
+	}
+	d := scale.Distribution{
+		Key: consts.ShardLabel,
+	}
+	for i := uint(0); i < conf.KindWorkers; i++ {
+		d.Values = append(d.Values, scale.WeightedValue{
+			Value:  strconv.FormatUint(uint64(i), 10),
+			Weight: 1,
+		})
+	}
+	return []scale.Distribution{d}
+}
+
+// registerNodes registers the nodes of the profiles with the shapes of their presets.
+func registerNodes(ctx context.Context, rt runtime.Runtime, conf *internalversion.KwokctlConfigurationOptions) error {
+	if len(conf.NodeProfiles) == 0 {
+		return nil
+	}
+
+	if rt.IsDryRun() {
+		for _, profile := range conf.NodeProfiles {
+			dryrun.PrintMessage("# Register %d nodes of %s", profile.Replicas, profile.Preset)
+		}
+		return nil
+	}
+
+	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
+	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
+		return krc.Name == "node"
+	})
+	if !ok {
+		var err error
+		krc, err = config.UnmarshalWithType[*internalversion.KwokctlResource](resource.DefaultNode)
+		if err != nil {
+			return err
+		}
+	}
+
+	presets, err := scale.BuiltinPresets("node")
+	if err != nil {
+		return err
+	}
+
+	clientset, err := client.NewClientset("", rt.GetWorkdirPath(runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	labels := shardDistributions(conf)
+	for _, profile := range conf.NodeProfiles {
+		preset, err := scale.FindPreset(presets, profile.Preset)
+		if err != nil {
+			return err
+		}
+		rawParameters, err := scale.MergeParameters(krc.Parameters, preset.Parameters)
+		if err != nil {
+			return err
+		}
+		parameters, err := scale.NewParameters(ctx, rawParameters, profile.Params)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Registering nodes", "preset", profile.Preset, "replicas", profile.Replicas)
+		err = scale.Scale(ctx, clientset, scale.Config{
+			Parameters:   parameters,
+			Template:     krc.Template,
+			Name:         nodeProfileName(profile.Preset),
+			Replicas:     int(profile.Replicas),
+			SerialLength: 6,
+			Labels:       labels,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register the nodes of %s: %w", profile.Preset, err)
+		}
+	}
+	return nil
+}
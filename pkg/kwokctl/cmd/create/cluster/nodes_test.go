@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+)
+
+func TestParseNodeProfile(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    internalversion.NodeProfile
+		wantErr bool
+	}{
+		{input: "eks/m5.xlarge=100", want: internalversion.NodeProfile{Preset: "eks/m5.xlarge", Replicas: 100}},
+		{input: "eks/m5.xlarge", wantErr: true},
+		{input: "=10", wantErr: true},
+		{input: "eks/m5.xlarge=0", wantErr: true},
+		{input: "eks/m5.xlarge=a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseNodeProfile(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodeProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseNodeProfile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodeProfileName(t *testing.T) {
+	if got, want := nodeProfileName("eks/m5.xlarge"), "eks-m5-xlarge"; got != want {
+		t.Errorf("nodeProfileName() = %q, want %q", got, want)
+	}
+}
+
+func TestShardDistributions(t *testing.T) {
+	got := shardDistributions(&internalversion.KwokctlConfigurationOptions{
+		Runtime:     consts.RuntimeTypeKind,
+		KindWorkers: 2,
+	})
+	want := []scale.Distribution{
+		{
+			Key: consts.ShardLabel,
+			Values: []scale.WeightedValue{
+				{Value: "0", Weight: 1},
+				{Value: "1", Weight: 1},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("shardDistributions() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = shardDistributions(&internalversion.KwokctlConfigurationOptions{
+		Runtime:     consts.RuntimeTypeBinary,
+		KindWorkers: 2,
+	})
+	if got != nil {
+		t.Errorf("shardDistributions() = %v, want nil for the non-kind runtime", got)
+	}
+}
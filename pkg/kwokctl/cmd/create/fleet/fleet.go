@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fleet defines a command to create a fleet of clusters for testing the multi-cluster controllers.
+package fleet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/create/cluster"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/fleet"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name          string
+	Members       int
+	HubKubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for fleet creation,
+// it takes all the flags of creating a cluster, which are applied to each member of the fleet.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := cluster.NewCommand(ctx)
+	cmd.Use = "fleet"
+	cmd.Short = "Creates a fleet of clusters with an aggregated hub kubeconfig"
+	cmd.Long = "Creates a fleet of clusters named with the name and an index suffix, all the flags of the cluster are applied to each member, " +
+		"and the contexts of the members are aggregated into a hub kubeconfig whose current context is the first member"
+	createClusters := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		flags.Name = config.DefaultCluster
+		if flags.Members < 2 {
+			return fmt.Errorf("a fleet has at least 2 members, got %d", flags.Members)
+		}
+		err := cmd.Flags().Set("count", format.String(flags.Members))
+		if err != nil {
+			return err
+		}
+		err = createClusters(cmd, args)
+		if err != nil {
+			return err
+		}
+		return runE(cmd.Context(), flags)
+	}
+	_ = cmd.Flags().MarkHidden("count")
+	cmd.Flags().IntVar(&flags.Members, "members", 3, "Number of the member clusters of the fleet")
+	cmd.Flags().StringVar(&flags.HubKubeconfig, "hub-kubeconfig", "", "Path of the hub kubeconfig aggregating the contexts of the members (default ~/.kwok/fleets/<name>/kubeconfig.yaml)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) (err error) {
+	hubKubeconfig := flags.HubKubeconfig
+	if hubKubeconfig == "" {
+		hubKubeconfig = path.Join(config.WorkDir, "fleets", flags.Name, runtime.InHostKubeconfigName)
+	}
+	hubKubeconfig, err = path.Expand(hubKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// The members are the clusters created with --count
+	members := fleet.MemberNames(flags.Name, flags.Members)
+	if dryrun.DryRun {
+		for _, member := range members {
+			dryrun.PrintMessage("# Add context %s to %s", config.ClusterName(member), hubKubeconfig)
+		}
+		return nil
+	}
+
+	for _, member := range members {
+		err = addMemberContext(hubKubeconfig, member)
+		if err != nil {
+			return fmt.Errorf("failed to add the context of member %q: %w", member, err)
+		}
+	}
+
+	// The first member is the hub, which the multi-cluster controllers are usually deployed to
+	err = kubeconfig.ModifyContext(hubKubeconfig, func(kc *clientcmdapi.Config) error {
+		kc.CurrentContext = config.ClusterName(members[0])
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Fleet is ready",
+		"members", members,
+		"hubKubeconfig", hubKubeconfig,
+	)
+	return nil
+}
+
+// addMemberContext adds the context of the member from its kubeconfig to the hub kubeconfig,
+// and records the hub kubeconfig so the context is removed when the member is deleted.
+func addMemberContext(hubKubeconfig string, member string) error {
+	workdir := path.Join(config.ClustersDir, member)
+	kc, err := kubeconfig.LoadFromFile(path.Join(workdir, runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+	kctx, ok := kc.Contexts[kc.CurrentContext]
+	if !ok {
+		return fmt.Errorf("context %q not found", kc.CurrentContext)
+	}
+	cluster, ok := kc.Clusters[kctx.Cluster]
+	if !ok {
+		return fmt.Errorf("cluster %q not found", kctx.Cluster)
+	}
+	user, ok := kc.AuthInfos[kctx.AuthInfo]
+	if !ok {
+		return fmt.Errorf("user %q not found", kctx.AuthInfo)
+	}
+
+	// The location of origin is cleared, otherwise they are written back to the kubeconfig of the member
+	cluster = cluster.DeepCopy()
+	cluster.LocationOfOrigin = ""
+	user = user.DeepCopy()
+	user.LocationOfOrigin = ""
+
+	name := config.ClusterName(member)
+	err = kubeconfig.AddContext(hubKubeconfig, name, &kubeconfig.Config{
+		Cluster: cluster,
+		User:    user,
+		Context: &clientcmdapi.Context{
+			Cluster:  name,
+			AuthInfo: name,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return runtime.RecordKubeconfig(workdir, hubKubeconfig)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+const memberKubeconfig = `apiVersion: v1
+kind: Config
+current-context: kwok-%[1]s
+clusters:
+- name: kwok-%[1]s
+  cluster:
+    server: https://127.0.0.1:%[2]d
+contexts:
+- name: kwok-%[1]s
+  context:
+    cluster: kwok-%[1]s
+    user: kwok-%[1]s
+users:
+- name: kwok-%[1]s
+  user:
+    token: %[1]s
+`
+
+func TestRunE(t *testing.T) {
+	clustersDir := config.ClustersDir
+	defer func() {
+		config.ClustersDir = clustersDir
+	}()
+	config.ClustersDir = t.TempDir()
+
+	members := []string{"fleet-0", "fleet-1", "fleet-2"}
+	for i, member := range members {
+		workdir := path.Join(config.ClustersDir, member)
+		err := os.MkdirAll(workdir, 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path.Join(workdir, runtime.InHostKubeconfigName), []byte(fmt.Sprintf(memberKubeconfig, member, 32766+i)), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hubKubeconfig := path.Join(t.TempDir(), "hub.yaml")
+	err := runE(context.Background(), &flagpole{
+		Name:          "fleet",
+		Members:       len(members),
+		HubKubeconfig: hubKubeconfig,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kc, err := kubeconfig.LoadFromFile(hubKubeconfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kc.CurrentContext != "kwok-fleet-0" {
+		t.Errorf("expected the current context to be the first member, got %q", kc.CurrentContext)
+	}
+	for i, member := range members {
+		name := config.ClusterName(member)
+		kctx, ok := kc.Contexts[name]
+		if !ok {
+			t.Fatalf("expected the context %q in the hub kubeconfig", name)
+		}
+		cluster, ok := kc.Clusters[kctx.Cluster]
+		if !ok || cluster.Server != fmt.Sprintf("https://127.0.0.1:%d", 32766+i) {
+			t.Errorf("unexpected cluster of the context %q: %+v", name, cluster)
+		}
+		user, ok := kc.AuthInfos[kctx.AuthInfo]
+		if !ok || user.Token != member {
+			t.Errorf("unexpected user of the context %q: %+v", name, user)
+		}
+
+		recorded, err := runtime.RecordedKubeconfigs(path.Join(config.ClustersDir, member))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Contains(recorded, hubKubeconfig) {
+			t.Errorf("expected the hub kubeconfig to be recorded by member %q, got %v", member, recorded)
+		}
+	}
+}
+
+func TestRunEMissingMember(t *testing.T) {
+	clustersDir := config.ClustersDir
+	defer func() {
+		config.ClustersDir = clustersDir
+	}()
+	config.ClustersDir = t.TempDir()
+
+	err := runE(context.Background(), &flagpole{
+		Name:          "fleet",
+		Members:       2,
+		HubKubeconfig: path.Join(t.TempDir(), "hub.yaml"),
+	})
+	if err == nil {
+		t.Fatal("expected an error without the kubeconfigs of the members")
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dashboard contains a command to observe the simulation of a cluster in the terminal.
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name      string
+	TUI       bool
+	Interval  time.Duration
+	MaxEvents int
+}
+
+// eventSource is the component of the events emitted by the stages of kwok-controller.
+const eventSource = "kwok_controller"
+
+// NewCommand returns a new cobra.Command for dashboard
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "dashboard",
+		Short: "Observe the simulation of the cluster",
+		Long:  "Observe the simulation of the cluster, showing the node and pod counts, the firing rates of the stages, the health of the components and the recent events emitted by the stages",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().BoolVar(&flags.TUI, "tui", false, "Show the dashboard in the terminal")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 2*time.Second, "Interval to refresh the dashboard")
+	cmd.Flags().IntVar(&flags.MaxEvents, "max-events", 10, "Maximum number of recent events to show")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if !flags.TUI {
+		return fmt.Errorf("only the terminal dashboard is supported, please specify --tui")
+	}
+	if flags.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("kubectl get nodes")
+		dryrun.PrintMessage("kubectl get pods -A")
+		dryrun.PrintMessage("kubectl get events -A --field-selector source=%s", eventSource)
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	v := newView(flags.Name, flags.MaxEvents)
+
+	stdinFd := int(os.Stdin.Fd())
+	stdoutFd := int(os.Stdout.Fd())
+	if !term.IsTerminal(stdoutFd) || !term.IsTerminal(stdinFd) {
+		// Render a single frame when not attached to a terminal
+		s, err := collect(ctx, rt, typedClient)
+		if err != nil {
+			return err
+		}
+		return v.render(os.Stdout, s)
+	}
+
+	state, err := term.MakeRaw(stdinFd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = term.Restore(stdinFd, state)
+	}()
+
+	// Switch to the alternate screen and hide the cursor, and restore them on exit
+	_, _ = os.Stdout.WriteString("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		_, _ = os.Stdout.WriteString("\x1b[?25h\x1b[?1049l")
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		defer cancel()
+		buf := make([]byte, 1)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			// q, Q, Ctrl+C or Ctrl+D
+			if n == 1 && (buf[0] == 'q' || buf[0] == 'Q' || buf[0] == 3 || buf[0] == 4) {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(flags.Interval)
+	defer ticker.Stop()
+	for {
+		frame := bytes.NewBuffer(nil)
+		s, err := collect(ctx, rt, typedClient)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			_, _ = fmt.Fprintf(frame, "Failed to collect: %v\n", err)
+		} else {
+			err = v.render(frame, s)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The terminal is in raw mode, so the line feed does not return the carriage
+		_, _ = os.Stdout.WriteString("\x1b[H\x1b[2J")
+		_, _ = os.Stdout.Write(bytes.ReplaceAll(frame.Bytes(), []byte("\n"), []byte("\r\n")))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect collects the snapshot of the cluster.
+func collect(ctx context.Context, rt runtime.Runtime, typedClient kubernetes.Interface) (*snapshot, error) {
+	s := &snapshot{
+		Time:  time.Now(),
+		Nodes: map[string]int64{},
+		Pods:  map[string]int64{},
+	}
+
+	nodes, err := typedClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		if isNodeReady(&node) {
+			s.Nodes["Ready"]++
+		} else {
+			s.Nodes["NotReady"]++
+		}
+	}
+
+	pods, err := typedClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		phase := pod.Status.Phase
+		if phase == "" {
+			phase = corev1.PodPending
+		}
+		s.Pods[string(phase)]++
+	}
+
+	components, err := rt.ListComponents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, component := range components {
+		status, err := rt.InspectComponent(ctx, component.Name)
+		if err != nil {
+			s.Components = append(s.Components, componentHealth{Name: component.Name, Status: "Error:" + err.Error()})
+			continue
+		}
+		s.Components = append(s.Components, componentHealth{Name: component.Name, Status: componentStatus(status)})
+	}
+
+	events, err := typedClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("source", eventSource).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Events = events.Items
+
+	return s, nil
+}
+
+func componentStatus(status runtime.ComponentStatus) string {
+	switch status {
+	case runtime.ComponentStatusReady:
+		return "Ready"
+	case runtime.ComponentStatusRunning:
+		return "NotReady"
+	case runtime.ComponentStatusStopped:
+		return "Stopped"
+	default:
+		return "Unknown"
+	}
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
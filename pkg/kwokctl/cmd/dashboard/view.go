@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+// snapshot is the state of the cluster at a point in time.
+type snapshot struct {
+	Time       time.Time
+	Nodes      map[string]int64
+	Pods       map[string]int64
+	Components []componentHealth
+	Events     []corev1.Event
+}
+
+// componentHealth is the health of a component of the cluster.
+type componentHealth struct {
+	Name   string
+	Status string
+}
+
+// stageRate is the firing rate of the stages with the same event reason.
+type stageRate struct {
+	Reason string
+	Total  int64
+	Rate   float64
+}
+
+// view keeps the previous snapshot to calculate the firing rates of the stages.
+type view struct {
+	name       string
+	maxEvents  int
+	prevTime   time.Time
+	prevTotals map[string]int64
+}
+
+func newView(name string, maxEvents int) *view {
+	return &view{
+		name:      name,
+		maxEvents: maxEvents,
+	}
+}
+
+// stageRates calculates the firing rates of the stages since the previous snapshot.
+// The stages are identified by the reason of the events they emitted.
+func (v *view) stageRates(s *snapshot) []stageRate {
+	totals := map[string]int64{}
+	for _, event := range s.Events {
+		count := int64(event.Count)
+		if count == 0 {
+			count = 1
+		}
+		totals[event.Reason] += count
+	}
+
+	rates := make([]stageRate, 0, len(totals))
+	elapsed := s.Time.Sub(v.prevTime).Seconds()
+	for reason, total := range totals {
+		rate := stageRate{
+			Reason: reason,
+			Total:  total,
+			Rate:   -1,
+		}
+		if v.prevTotals != nil && elapsed > 0 {
+			delta := total - v.prevTotals[reason]
+			if delta < 0 {
+				delta = 0
+			}
+			rate.Rate = float64(delta) / elapsed
+		}
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Reason < rates[j].Reason
+	})
+
+	v.prevTime = s.Time
+	v.prevTotals = totals
+	return rates
+}
+
+// render writes a frame of the dashboard.
+func (v *view) render(w io.Writer, s *snapshot) error {
+	buf := bytes.NewBuffer(nil)
+
+	_, _ = fmt.Fprintf(buf, "Cluster: %s    Time: %s    (press q to quit)\n\n", v.name, s.Time.Format(time.TimeOnly))
+
+	records := [][]string{
+		{"NODES", "READY", "NOTREADY"},
+		{
+			format.String(s.Nodes["Ready"] + s.Nodes["NotReady"]),
+			format.String(s.Nodes["Ready"]),
+			format.String(s.Nodes["NotReady"]),
+		},
+	}
+	err := printers.NewTablePrinter(buf).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(buf)
+
+	phases := []corev1.PodPhase{corev1.PodPending, corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed, corev1.PodUnknown}
+	var total int64
+	header := []string{"PODS"}
+	row := []string{""}
+	for _, phase := range phases {
+		total += s.Pods[string(phase)]
+		header = append(header, strings.ToUpper(string(phase)))
+		row = append(row, format.String(s.Pods[string(phase)]))
+	}
+	row[0] = format.String(total)
+	err = printers.NewTablePrinter(buf).WriteAll([][]string{header, row})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(buf)
+
+	records = [][]string{
+		{"COMPONENT", "STATUS"},
+	}
+	for _, c := range s.Components {
+		records = append(records, []string{c.Name, c.Status})
+	}
+	err = printers.NewTablePrinter(buf).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(buf)
+
+	records = [][]string{
+		{"STAGE EVENT", "TOTAL", "RATE(/s)"},
+	}
+	for _, r := range v.stageRates(s) {
+		rate := "-"
+		if r.Rate >= 0 {
+			rate = fmt.Sprintf("%.2f", r.Rate)
+		}
+		records = append(records, []string{r.Reason, format.String(r.Total), rate})
+	}
+	err = printers.NewTablePrinter(buf).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(buf)
+
+	events := make([]corev1.Event, len(s.Events))
+	copy(events, s.Events)
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).After(eventTime(events[j]))
+	})
+	if len(events) > v.maxEvents {
+		events = events[:v.maxEvents]
+	}
+	records = [][]string{
+		{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"},
+	}
+	for _, event := range events {
+		object := strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name
+		if event.InvolvedObject.Namespace != "" {
+			object = event.InvolvedObject.Namespace + "/" + object
+		}
+		records = append(records, []string{
+			format.HumanDuration(s.Time.Sub(eventTime(event))),
+			event.Type,
+			event.Reason,
+			object,
+			event.Message,
+		})
+	}
+	err = printers.NewTablePrinter(buf).WriteAll(records)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
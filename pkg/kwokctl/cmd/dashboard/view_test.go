@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestViewStageRates(t *testing.T) {
+	now := time.Now()
+	v := newView("kwok", 10)
+
+	got := v.stageRates(&snapshot{
+		Time: now,
+		Events: []corev1.Event{
+			{Reason: "Created", Count: 2},
+			{Reason: "Created", Count: 3},
+			{Reason: "Ready"},
+		},
+	})
+	want := []stageRate{
+		{Reason: "Created", Total: 5, Rate: -1},
+		{Reason: "Ready", Total: 1, Rate: -1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("stageRates() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = v.stageRates(&snapshot{
+		Time: now.Add(2 * time.Second),
+		Events: []corev1.Event{
+			{Reason: "Created", Count: 9},
+			{Reason: "Deleted", Count: 4},
+		},
+	})
+	want = []stageRate{
+		{Reason: "Created", Total: 9, Rate: 2},
+		{Reason: "Deleted", Total: 4, Rate: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("stageRates() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestViewRender(t *testing.T) {
+	now := time.Now()
+	v := newView("kwok", 1)
+
+	s := &snapshot{
+		Time:  now,
+		Nodes: map[string]int64{"Ready": 2, "NotReady": 1},
+		Pods:  map[string]int64{"Running": 3, "Pending": 1},
+		Components: []componentHealth{
+			{Name: "etcd", Status: "Ready"},
+		},
+		Events: []corev1.Event{
+			{
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "old"},
+				Reason:         "Created",
+				LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+			},
+			{
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "new"},
+				Reason:         "Started",
+				LastTimestamp:  metav1.NewTime(now.Add(-time.Second)),
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := v.render(buf, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{"Cluster: kwok", "etcd", "default/pod/new", "Started"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "default/pod/old") {
+		t.Errorf("render() output should only contain the most recent event:\n%s", out)
+	}
+}
@@ -21,11 +21,13 @@ import (
 	"context"
 	"errors"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/fleet"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
@@ -33,10 +35,14 @@ import (
 )
 
 type flagpole struct {
-	Name       string
-	Kubeconfig string
-	All        bool
-	Force      bool
+	Name         string
+	Kubeconfig   string
+	All          bool
+	Force        bool
+	KeepData     bool
+	KeepContexts bool
+	Workers      int
+	DrainTimeout time.Duration
 }
 
 // NewCommand returns a new cobra.Command for cluster deletion
@@ -57,7 +63,11 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file that will remove the deleted cluster")
 	cmd.Flags().BoolVar(&flags.All, "all", flags.All, "Delete all clusters managed by kwokctl")
-	cmd.Flags().BoolVar(&flags.Force, "force", false, "Force delete the cluster")
+	cmd.Flags().BoolVar(&flags.Force, "force", false, "Force delete the cluster, skip stopping the components one by one and go on when the cluster fails to stop")
+	cmd.Flags().BoolVar(&flags.KeepData, "keep-data", false, "Delete the cluster from the runtime but keep the data of etcd, certs and config, it can be recreated by 'kwokctl create cluster --from-existing-data'")
+	cmd.Flags().BoolVar(&flags.KeepContexts, "keep-contexts", false, "Keep the context, cluster and user of the cluster in the kubeconfig files, by default they are removed from --kubeconfig and the kubeconfig files they were added to at creation")
+	cmd.Flags().IntVar(&flags.Workers, "workers", 4, "Number of clusters to delete concurrently with --all")
+	cmd.Flags().DurationVar(&flags.DrainTimeout, "drain-timeout", 30*time.Second, "Timeout to stop each of the components before the cluster is stopped")
 	return cmd
 }
 
@@ -70,14 +80,14 @@ func runE(ctx context.Context, flags *flagpole) error {
 		if err != nil {
 			return err
 		}
-		for _, cluster := range clusters {
-			err = deleteCluster(ctx, cluster, flags.Kubeconfig, flags.Force)
-			if err != nil {
-				return err
-			}
+		_, err = fleet.Run(ctx, clusters, flags.Workers, func(ctx context.Context, cluster string) error {
+			return deleteCluster(ctx, cluster, flags)
+		})
+		if err != nil {
+			return err
 		}
 	} else {
-		err = deleteCluster(ctx, flags.Name, flags.Kubeconfig, flags.Force)
+		err = deleteCluster(ctx, flags.Name, flags)
 		if err != nil {
 			return err
 		}
@@ -86,7 +96,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 	return nil
 }
 
-func deleteCluster(ctx context.Context, clusterName string, kubeconfigPath string, force bool) error {
+func deleteCluster(ctx context.Context, clusterName string, flags *flagpole) error {
 	name := config.ClusterName(clusterName)
 	workdir := path.Join(config.ClustersDir, clusterName)
 
@@ -94,8 +104,7 @@ func deleteCluster(ctx context.Context, clusterName string, kubeconfigPath strin
 	logger = logger.With("cluster", clusterName)
 	ctx = log.NewContext(ctx, logger)
 
-	var err error
-	kubeconfigPath, err = path.Expand(kubeconfigPath)
+	kubeconfigPath, err := path.Expand(flags.Kubeconfig)
 	if err != nil {
 		return err
 	}
@@ -109,44 +118,122 @@ func deleteCluster(ctx context.Context, clusterName string, kubeconfigPath strin
 		return err
 	}
 
+	var kubeconfigPaths []string
+	if !flags.KeepContexts {
+		kubeconfigPaths = getKubeconfigPaths(ctx, workdir, kubeconfigPath)
+	}
+
 	if err := rt.Available(ctx); err != nil {
-		if !force {
+		if !flags.Force {
 			return err
 		}
 		logger.Warn("Unavailable runtime but proceed with force delete", "err", err)
 	}
 
-	// Stop the cluster
+	if flags.KeepData {
+		return detachCluster(ctx, rt, clusterName, kubeconfigPaths)
+	}
+
+	// Stop the components which write the objects first
 	start := time.Now()
+	if !flags.Force {
+		logger.Info("Cluster is draining")
+		err = drainComponents(ctx, rt, flags.DrainTimeout)
+		if err != nil {
+			logger.Warn("Failed to drain cluster", "err", err)
+		} else {
+			logger.Info("Cluster is drained",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+
+	// Once the cluster is stopping, an interrupt doesn't abort the teardown,
+	// so the containers and networks are not leaked, the second interrupt still exits directly.
+	if ctx.Err() != nil {
+		logger.Warn("Interrupted, finishing the deletion of the cluster")
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	// Stop the cluster
+	start = time.Now()
 	logger.Info("Cluster is stopping")
 	err = rt.Down(ctx)
 	if err != nil {
-		return err
+		if !flags.Force {
+			return err
+		}
+		logger.Warn("Failed to stop cluster but proceed with force delete", "err", err)
+	} else {
+		logger.Info("Cluster is stopped",
+			"elapsed", time.Since(start),
+		)
 	}
-	logger.Info("Cluster is stopped",
-		"elapsed", time.Since(start),
-	)
 
 	// Delete the cluster
 	start = time.Now()
 	logger.Info("Cluster is deleting")
+	removeContexts(ctx, rt, kubeconfigPaths)
+	err = rt.Uninstall(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("Cluster is deleted",
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
+
+// getKubeconfigPaths returns the kubeconfig given by the flag and the ones recorded at creation.
+func getKubeconfigPaths(ctx context.Context, workdir string, kubeconfigPath string) []string {
+	var paths []string
 	if kubeconfigPath != "" {
-		err = rt.RemoveContext(ctx, kubeconfigPath)
+		paths = append(paths, kubeconfigPath)
+	}
+	recorded, err := runtime.RecordedKubeconfigs(workdir)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to get the recorded kubeconfigs", "err", err)
+		return paths
+	}
+	for _, p := range recorded {
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+func removeContexts(ctx context.Context, rt runtime.Runtime, kubeconfigPaths []string) {
+	logger := log.FromContext(ctx)
+	for _, kubeconfigPath := range kubeconfigPaths {
+		err := rt.RemoveContext(ctx, kubeconfigPath)
 		if err != nil {
 			logger.Error("Failed to remove context from kubeconfig", err,
 				"kubeconfig", kubeconfigPath,
 			)
+			continue
 		}
 		logger.Debug("Remove context from kubeconfig",
 			"kubeconfig", kubeconfigPath,
 		)
 	}
-	err = rt.Uninstall(ctx)
+}
+
+func detachCluster(ctx context.Context, rt runtime.Runtime, clusterName string, kubeconfigPaths []string) error {
+	logger := log.FromContext(ctx)
+
+	start := time.Now()
+	logger.Info("Cluster is detaching")
+	err := rt.Detach(ctx)
 	if err != nil {
 		return err
 	}
-	logger.Info("Cluster is deleted",
+	removeContexts(ctx, rt, kubeconfigPaths)
+	logger.Info("Cluster is deleted and the data is kept",
 		"elapsed", time.Since(start),
+		"workdir", path.Join(config.ClustersDir, clusterName),
+		"recreate", "kwokctl create cluster --name "+clusterName+" --from-existing-data",
 	)
 	return nil
 }
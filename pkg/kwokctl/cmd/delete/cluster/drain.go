@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// drainOrder returns the names of the components to stop before the cluster is stopped,
+// in the reverse order of their dependencies, etcd and kube-apiserver are left to the runtime.
+func drainOrder(cs []internalversion.Component) ([]string, error) {
+	groups, err := components.GroupByLinks(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, group := range slices.Reverse(groups) {
+		for _, component := range group {
+			if components.IsEtcdComponent(component.Name) || components.IsKubeApiserverComponent(component.Name) {
+				continue
+			}
+			names = append(names, component.Name)
+		}
+	}
+	return names, nil
+}
+
+// drainComponents stops the components one by one before the cluster is stopped,
+// so the controllers which keep writing the objects are stopped first,
+// and kube-apiserver and etcd don't have to serve them while they are shutting down with a large population of objects.
+// A component which can't be stopped in time is left to be removed with the cluster.
+func drainComponents(ctx context.Context, rt runtime.Runtime, timeout time.Duration) error {
+	logger := log.FromContext(ctx)
+
+	cs, err := rt.ListComponents(ctx)
+	if err != nil {
+		return err
+	}
+	names, err := drainOrder(cs)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	for i, name := range names {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		s := time.Now()
+		stopCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := rt.StopComponent(stopCtx, name)
+		cancel()
+		if err != nil {
+			logger.Warn("Failed to stop component, it is removed with the cluster",
+				"component", name,
+				"err", err,
+			)
+		} else {
+			logger.Debug("Component is stopped",
+				"component", name,
+				"elapsed", time.Since(s),
+			)
+		}
+		logger.Info("Progress",
+			"done", i+1,
+			"total", len(names),
+			"elapsed", time.Since(start),
+		)
+	}
+	return nil
+}
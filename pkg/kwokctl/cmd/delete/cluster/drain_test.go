@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestDrainOrder(t *testing.T) {
+	cs := []internalversion.Component{
+		{
+			Name: "etcd",
+		},
+		{
+			Name:  "kube-apiserver",
+			Links: []string{"etcd"},
+		},
+		{
+			Name:  "kube-controller-manager",
+			Links: []string{"kube-apiserver"},
+		},
+		{
+			Name:  "kwok-controller",
+			Links: []string{"kube-apiserver"},
+		},
+		{
+			Name:  "prometheus",
+			Links: []string{"kwok-controller"},
+		},
+	}
+
+	got, err := drainOrder(cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"prometheus",
+		"kube-controller-manager",
+		"kwok-controller",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("drainOrder() mismatch (-want +got):\n%s", diff)
+	}
+}
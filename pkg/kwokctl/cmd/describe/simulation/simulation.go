@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulation contains a command to describe the simulation of an object.
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
+	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/tools/stage"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+type flagpole struct {
+	Name      string
+	Namespace string
+}
+
+// NewCommand returns a new cobra.Command for describe simulation
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "simulation <resource>/<name>",
+		Short: "Describe the simulation of an object",
+		Long:  "Describe the simulation of an object, showing the history reconstructed from the events and the managed fields, and the stages that match the current state of the object",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "default", "Namespace of the object")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	resource, objName, ok := strings.Cut(args[0], "/")
+	if !ok || resource == "" || objName == "" {
+		return fmt.Errorf("invalid argument %q, expected <resource>/<name>", args[0])
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("kubectl get %s -n %s -o yaml", args[0], flags.Namespace)
+		dryrun.PrintMessage("kubectl get events -n %s --field-selector involvedObject.name=%s", flags.Namespace, objName)
+		return nil
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	restMapper, err := clientset.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+	mapping, err := client.MappingFor(restMapper, resource)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	var obj *unstructured.Unstructured
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = flags.Namespace
+		obj, err = dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(ctx, objName, metav1.GetOptions{})
+	} else {
+		obj, err = dynamicClient.Resource(mapping.Resource).Get(ctx, objName, metav1.GetOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	events, err := typedClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": mapping.GroupVersionKind.Kind,
+			"involvedObject.name": objName,
+		}.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var stages []*internalversion.Stage
+	if slices.Contains(conf.Options.EnableCRDs, v1alpha1.StageKind) {
+		typedKwokClient, err := versioned.NewForConfig(restConfig)
+		if err != nil {
+			return err
+		}
+		list, err := typedKwokClient.KwokV1alpha1().Stages().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			s, err := internalversion.ConvertToInternalStage(&list.Items[i])
+			if err != nil {
+				return err
+			}
+			stages = append(stages, s)
+		}
+	} else {
+		objs, err := config.Load(ctx, rt.GetWorkdirPath(runtime.ConfigName))
+		if err != nil {
+			return err
+		}
+		stages = config.FilterWithType[*internalversion.Stage](objs)
+	}
+
+	stages, err = withDefaultStages(stages, mapping.GroupVersionKind.GroupVersion().String(), mapping.GroupVersionKind.Kind, conf.Options.NodeLeaseDurationSeconds != 0)
+	if err != nil {
+		return err
+	}
+
+	return describe(ctx, os.Stdout, obj, events.Items, stages)
+}
+
+// withDefaultStages appends the default stages used by kwok-controller if there is no stage for the resource.
+func withDefaultStages(stages []*internalversion.Stage, apiGroup, kind string, lease bool) ([]*internalversion.Stage, error) {
+	ref := internalversion.StageResourceRef{APIGroup: apiGroup, Kind: kind}
+	if slices.Contains(slices.Map(stages, func(s *internalversion.Stage) internalversion.StageResourceRef {
+		return s.Spec.ResourceRef
+	}), ref) {
+		return stages, nil
+	}
+
+	var raws []string
+	switch ref {
+	case internalversion.StageResourceRef{APIGroup: "v1", Kind: "Node"}:
+		raws = []string{nodefast.DefaultNodeInit, nodeheartbeat.DefaultNodeHeartbeat}
+		if lease {
+			raws[1] = nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease
+		}
+	case internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"}:
+		raws = []string{podfast.DefaultPodReady, podfast.DefaultPodComplete, podfast.DefaultPodDelete}
+	default:
+		return stages, nil
+	}
+
+	defaults, err := slices.MapWithError(raws, config.UnmarshalWithType[*internalversion.Stage, string])
+	if err != nil {
+		return nil, err
+	}
+	return append(stages, defaults...), nil
+}
+
+// historyItem is an item of the history of the simulation of an object.
+type historyItem struct {
+	Time    time.Time
+	Source  string
+	Action  string
+	Message string
+}
+
+func history(obj *unstructured.Unstructured, events []corev1.Event) []historyItem {
+	items := []historyItem{
+		{
+			Time:   obj.GetCreationTimestamp().Time,
+			Action: "Created",
+		},
+	}
+
+	for _, field := range obj.GetManagedFields() {
+		if field.Time == nil {
+			continue
+		}
+		action := string(field.Operation)
+		if field.Subresource != "" {
+			action += " " + field.Subresource
+		}
+		items = append(items, historyItem{
+			Time:   field.Time.Time,
+			Source: field.Manager,
+			Action: action,
+		})
+	}
+
+	for _, event := range events {
+		t := event.LastTimestamp.Time
+		if t.IsZero() {
+			t = event.EventTime.Time
+		}
+		if t.IsZero() {
+			t = event.CreationTimestamp.Time
+		}
+		message := event.Message
+		if event.Count > 1 {
+			message = fmt.Sprintf("%s (x%d)", message, event.Count)
+		}
+		items = append(items, historyItem{
+			Time:    t,
+			Source:  event.Source.Component,
+			Action:  "Event " + event.Reason,
+			Message: message,
+		})
+	}
+
+	if ts := obj.GetDeletionTimestamp(); ts != nil {
+		items = append(items, historyItem{
+			Time:   ts.Time,
+			Action: "Deleting",
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Time.Before(items[j].Time)
+	})
+	return items
+}
+
+func describe(ctx context.Context, w io.Writer, obj *unstructured.Unstructured, events []corev1.Event, stages []*internalversion.Stage) error {
+	ref := strings.ToLower(obj.GetKind()) + "/" + obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		ref = ns + "/" + ref
+	}
+	_, _ = fmt.Fprintf(w, "Object: %s\n\n", ref)
+
+	now := time.Now()
+	records := [][]string{
+		{"AGE", "SOURCE", "ACTION", "MESSAGE"},
+	}
+	for _, item := range history(obj, events) {
+		records = append(records, []string{
+			format.HumanDuration(now.Sub(item.Time)),
+			item.Source,
+			item.Action,
+			item.Message,
+		})
+	}
+	_, _ = fmt.Fprintln(w, "History:")
+	err := printers.NewTablePrinter(w).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w)
+
+	if obj.GetKind() == "Pod" {
+		nodeName, _, _ := unstructured.NestedString(obj.Object, "spec", "nodeName")
+		if nodeName == "" {
+			_, _ = fmt.Fprintln(w, "Hint: the pod is not scheduled to any node, stages only play on pods scheduled to nodes managed by kwok")
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+
+	out, err := stage.TestingStages(ctx, obj, stages)
+	if err != nil {
+		return err
+	}
+	if m, ok := out.(map[string]any); ok {
+		if s, ok := m["stages"].([]any); ok && len(s) == 0 {
+			_, _ = fmt.Fprintln(w, "Matched stages: none, the object will stay in its current state")
+			return nil
+		}
+	}
+	_, _ = fmt.Fprintln(w, "Matched stages:")
+	return yaml.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDescribe(t *testing.T) {
+	now := time.Now()
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Pod")
+	obj.SetNamespace("default")
+	obj.SetName("foo")
+	obj.SetCreationTimestamp(metav1.NewTime(now.Add(-time.Minute)))
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:     "kwok",
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			Subresource: "status",
+			Time:        &metav1.Time{Time: now.Add(-10 * time.Second)},
+		},
+	})
+	_ = unstructured.SetNestedField(obj.Object, "node-0", "spec", "nodeName")
+
+	events := []corev1.Event{
+		{
+			Source:        corev1.EventSource{Component: "default-scheduler"},
+			Reason:        "Scheduled",
+			Message:       "Successfully assigned default/foo to node-0",
+			LastTimestamp: metav1.NewTime(now.Add(-30 * time.Second)),
+		},
+	}
+
+	stages, err := withDefaultStages(nil, "v1", "Pod", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) == 0 {
+		t.Fatal("expected default stages for pod")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = describe(context.Background(), buf, obj, events, stages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{"default/pod/foo", "Event Scheduled", "Update status", "pod-ready"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("describe() output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "Event Scheduled") > strings.Index(out, "Update status") {
+		t.Errorf("describe() history is not sorted by time:\n%s", out)
+	}
+}
@@ -20,6 +20,7 @@ package etcdctl
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -67,6 +68,14 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 		return err
 	}
 
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if len(conf.Options.EtcdEndpoints) != 0 {
+		return fmt.Errorf("the cluster uses an external etcd, please run etcdctl with --endpoints=%s instead", strings.Join(conf.Options.EtcdEndpoints, ","))
+	}
+
 	err = rt.EtcdctlInCluster(exec.WithStdIO(ctx), args...)
 
 	if err != nil {
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle implements the `bundle` command
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/bundle"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+type flagpole struct {
+	Name   string
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for exporting the cluster as a bundle
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "bundle",
+		Short: "Exports the definition of the cluster as a bundle, which can be created elsewhere with 'kwokctl create cluster --from-bundle'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(ctx, flags)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Directory to export the bundle to (default the export/bundle directory of the cluster)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx).With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	_, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	dir := flags.Output
+	if dir == "" {
+		dir = path.Join(workdir, "export", "bundle")
+	}
+
+	err = bundle.Export(ctx, workdir, dir)
+	if err != nil {
+		return err
+	}
+	logger.Info("Exported bundle", "dir", dir)
+	return nil
+}
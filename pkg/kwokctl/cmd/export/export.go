@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/export/bundle"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/export/logs"
 )
 
@@ -30,12 +31,13 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "export",
-		Short: "Exports one of [logs]",
+		Short: "Exports one of [logs, bundle]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 	// add subcommands
 	cmd.AddCommand(logs.NewCommand(ctx))
+	cmd.AddCommand(bundle.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift contains a command to roll out changes of the labels and taints of the nodes gradually.
+package drift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/drift"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Selector     string
+	AddLabels    []string
+	RemoveLabels []string
+	AddTaints    []string
+	RemoveTaints []string
+	Percent      float64
+	GroupBy      string
+	BatchSize    int
+	Interval     time.Duration
+}
+
+// NewCommand returns a new cobra.Command for generate drift
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "drift",
+		Short: "Gradually change the labels and taints of a percentage of the nodes in the cluster",
+		Long:  "Gradually change the labels and taints of a percentage of the nodes in the cluster, group by group and batch by batch, e.g. to simulate a rollout of an upgrade of the operating system zone by zone",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Selector, "selector", "l", "", "Label selector of the nodes")
+	cmd.Flags().StringArrayVar(&flags.AddLabels, "add-label", flags.AddLabels, "Label to add or update, e.g. os-version=2")
+	cmd.Flags().StringArrayVar(&flags.RemoveLabels, "remove-label", flags.RemoveLabels, "Key of the label to remove")
+	cmd.Flags().StringArrayVar(&flags.AddTaints, "add-taint", flags.AddTaints, "Taint to add or update, e.g. upgrade=true:NoSchedule")
+	cmd.Flags().StringArrayVar(&flags.RemoveTaints, "remove-taint", flags.RemoveTaints, "Key of the taint to remove")
+	cmd.Flags().Float64Var(&flags.Percent, "percent", 100, "Percentage of the nodes to change in each group")
+	cmd.Flags().StringVar(&flags.GroupBy, "group-by", "", "Label of the nodes to group them by, the groups are changed one after another, e.g. topology.kubernetes.io/zone")
+	cmd.Flags().IntVar(&flags.BatchSize, "batch-size", 0, "Number of the nodes changed at the same time, 0 means the whole group")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 30*time.Second, "Interval between the batches")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	change, err := parseChange(flags)
+	if err != nil {
+		return err
+	}
+	if flags.Percent <= 0 || flags.Percent > 100 {
+		return fmt.Errorf("--percent must be in (0, 100], got %v", flags.Percent)
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Change the labels and taints of %v%% of the nodes grouped by %q every %s", flags.Percent, flags.GroupBy, flags.Interval)
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := typedClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: flags.Selector,
+	})
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		return errors.New("no nodes found, create some nodes or change --selector")
+	}
+
+	waves := drift.Plan(nodes.Items, flags.GroupBy, flags.Percent, flags.BatchSize)
+	logger.Info("Rolling out the change of the nodes",
+		"nodes", len(nodes.Items),
+		"waves", len(waves),
+		"interval", flags.Interval,
+	)
+	result, err := drift.Rollout(ctx, typedClient.CoreV1(), waves, change, flags.Interval)
+	if err != nil {
+		return err
+	}
+	logger.Info("Rolled out the change of the nodes",
+		"changed", result.Changed,
+		"unchanged", result.Unchanged,
+		"failed", result.Failed,
+	)
+	if result.Failed != 0 {
+		return fmt.Errorf("failed to change %d nodes", result.Failed)
+	}
+	return nil
+}
+
+func parseChange(flags *flagpole) (drift.Change, error) {
+	change := drift.Change{
+		RemoveLabels: flags.RemoveLabels,
+		RemoveTaints: flags.RemoveTaints,
+	}
+	for _, l := range flags.AddLabels {
+		k, v, ok := strings.Cut(l, "=")
+		if !ok || k == "" {
+			return change, fmt.Errorf("invalid label %q, it must be in the form of key=value", l)
+		}
+		if change.AddLabels == nil {
+			change.AddLabels = map[string]string{}
+		}
+		change.AddLabels[k] = v
+	}
+	for _, t := range flags.AddTaints {
+		taint, err := drift.ParseTaint(t)
+		if err != nil {
+			return change, err
+		}
+		change.AddTaints = append(change.AddTaints, taint)
+	}
+	if change.IsEmpty() {
+		return change, errors.New("no change, specify --add-label, --remove-label, --add-taint or --remove-taint")
+	}
+	return change, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events contains a command to generate a storm of events in the cluster.
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/eventstorm"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Resource      string
+	Namespace     string
+	AllNamespaces bool
+	Selector      string
+	Types         string
+	Reasons       string
+	Component     string
+	Rate          float64
+	Count         uint64
+	Duration      time.Duration
+	Workers       int
+}
+
+// NewCommand returns a new cobra.Command for generate events
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "events",
+		Short: "Generate a storm of events about the objects in the cluster",
+		Long:  "Generate a storm of events about the objects in the cluster, with the weighted distributions of types and reasons at a given rate, to stress the event consumers and the event throttling of the apiserver",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Resource, "resource", "pods", "Resource of the objects which the events are about")
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "default", "Namespace of the objects")
+	cmd.Flags().BoolVarP(&flags.AllNamespaces, "all-namespaces", "A", false, "Use the objects in all namespaces")
+	cmd.Flags().StringVarP(&flags.Selector, "selector", "l", "", "Label selector of the objects")
+	cmd.Flags().StringVar(&flags.Types, "types", "Normal:9,Warning:1", "Weighted distribution of the types of the events")
+	cmd.Flags().StringVar(&flags.Reasons, "reasons", "Generated", "Weighted distribution of the reasons of the events, e.g. Started:5,BackOff:1")
+	cmd.Flags().StringVar(&flags.Component, "component", "kwokctl-event-storm", "Source component of the events")
+	cmd.Flags().Float64Var(&flags.Rate, "rate", 100, "Number of events per second, 0 means as fast as possible")
+	cmd.Flags().Uint64Var(&flags.Count, "count", 0, "Number of events to generate, 0 means no limit")
+	cmd.Flags().DurationVar(&flags.Duration, "duration", 10*time.Second, "Duration to generate events, 0 means no limit")
+	cmd.Flags().IntVar(&flags.Workers, "workers", 32, "Number of concurrent requests")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	types, err := scale.ParseDistribution("type=" + flags.Types)
+	if err != nil {
+		return err
+	}
+	reasons, err := scale.ParseDistribution("reason=" + flags.Reasons)
+	if err != nil {
+		return err
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Generate events about %s at %v events per second", flags.Resource, flags.Rate)
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	objects, err := listObjects(ctx, clientset, flags)
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return errors.New("no objects found, create some objects or change --resource, --namespace or --selector")
+	}
+
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	// The client-side rate limiter would cap the rate far below the storm.
+	restConfig.QPS = -1
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Generating events",
+		"objects", len(objects),
+		"rate", flags.Rate,
+		"count", flags.Count,
+		"duration", flags.Duration,
+	)
+	result, err := eventstorm.Generate(ctx, typedClient.CoreV1(), eventstorm.Config{
+		Objects:   objects,
+		Types:     types,
+		Reasons:   reasons,
+		Component: flags.Component,
+		Rate:      flags.Rate,
+		Count:     flags.Count,
+		Duration:  flags.Duration,
+		Workers:   flags.Workers,
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("Generated events",
+		"sent", result.Sent,
+		"failed", result.Failed,
+		"elapsed", result.Elapsed,
+		"rate", int64(result.Rate()),
+	)
+	return nil
+}
+
+func listObjects(ctx context.Context, clientset client.Clientset, flags *flagpole) ([]corev1.ObjectReference, error) {
+	restMapper, err := clientset.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := client.MappingFor(restMapper, flags.Resource)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: flags.Selector,
+	}
+	resource := dynamicClient.Resource(mapping.Resource)
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && !flags.AllNamespaces {
+		namespace = flags.Namespace
+	}
+	items, err := resource.Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := mapping.GroupVersionKind
+	objects := make([]corev1.ObjectReference, 0, len(items.Items))
+	for _, item := range items.Items {
+		objects = append(objects, corev1.ObjectReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  item.GetNamespace(),
+			Name:       item.GetName(),
+			UID:        item.GetUID(),
+		})
+	}
+	return objects, nil
+}
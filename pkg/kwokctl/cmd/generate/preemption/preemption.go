@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preemption contains a command to generate the pods across priority classes to trigger preemption.
+package preemption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/preemption"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Namespace string
+	Classes   []string
+	CPU       string
+	Memory    string
+	Rate      float64
+	Interval  time.Duration
+	Timeout   time.Duration
+}
+
+// NewCommand returns a new cobra.Command for generate preemption
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "preemption",
+		Short: "Generate the pods across priority classes to trigger preemption and measure it",
+		Long:  "Generate the pods across priority classes to trigger preemption and measure it, the pods arrive class by class from the lowest priority, and the preempted pods and the scheduling latency of each class are reported",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "default", "Namespace of the pods")
+	cmd.Flags().StringArrayVar(&flags.Classes, "class", []string{"low=100:10", "high=1000:5"}, "Priority class and the number of pods in the form of name=value:count")
+	cmd.Flags().StringVar(&flags.CPU, "cpu", "1", "CPU requests of each pod")
+	cmd.Flags().StringVar(&flags.Memory, "memory", "", "Memory requests of each pod")
+	cmd.Flags().Float64Var(&flags.Rate, "rate", 10, "Number of pods created per second, 0 means as fast as possible")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 10*time.Second, "Interval between the arrival of the classes")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", time.Minute, "Time to wait for the pods to be scheduled after they are created")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	conf := preemption.Config{
+		ID:        "preemption-" + strconv.FormatInt(time.Now().Unix(), 36),
+		Namespace: flags.Namespace,
+		Requests:  corev1.ResourceList{},
+		Rate:      flags.Rate,
+		Interval:  flags.Interval,
+		Timeout:   flags.Timeout,
+	}
+	for _, c := range flags.Classes {
+		class, err := preemption.ParseClass(c)
+		if err != nil {
+			return err
+		}
+		conf.Classes = append(conf.Classes, class)
+	}
+	if len(conf.Classes) == 0 {
+		return errors.New("no priority classes, specify --class")
+	}
+	for resourceName, value := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    flags.CPU,
+		corev1.ResourceMemory: flags.Memory,
+	} {
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s requests %q: %w", resourceName, value, err)
+		}
+		conf.Requests[resourceName] = q
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Generate pods of %d priority classes in %s to trigger preemption", len(conf.Classes), conf.Namespace)
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Generating pods to trigger preemption", "run", conf.ID, "namespace", conf.Namespace)
+	results, err := preemption.Run(ctx, typedClient, conf)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		logger.Info("Preemption of class",
+			"class", r.Class,
+			"value", r.Value,
+			"created", r.Created,
+			"scheduled", r.Scheduled,
+			"pending", r.Pending,
+			"preempted", r.Preempted,
+			"latencyP50", r.LatencyP50,
+			"latencyP99", r.LatencyP99,
+			"latencyMax", r.LatencyMax,
+		)
+	}
+	logger.Info("The pods can be deleted with the label", "selector", preemption.RunLabel+"="+conf.ID)
+	return nil
+}
@@ -22,10 +22,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
@@ -49,7 +53,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			return runE(cmd.Context(), flags)
 		},
 	}
-	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide)")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide, dot, mermaid, prometheus), dot and mermaid render the dependency graph of the components in the order they are started, prometheus prints the status and restarts of the components as metrics in the Prometheus text format")
 	return cmd
 }
 
@@ -69,7 +73,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
-	components, err := rt.ListComponents(ctx)
+	list, err := rt.ListComponents(ctx)
 	if err != nil {
 		return err
 	}
@@ -78,30 +82,25 @@ func runE(ctx context.Context, flags *flagpole) error {
 	default:
 		return fmt.Errorf("unknown output format %q", flags.Output)
 	case "name":
-		for _, component := range components {
+		for _, component := range list {
 			fmt.Println(component.Name)
 		}
 	case "wide":
 		records := [][]string{
-			{"NAME", "STATUS"},
+			{"NAME", "STATUS", "RESTARTS", "LAST EXIT"},
 		}
 
-		for _, component := range components {
+		for _, component := range list {
 			s, err := rt.InspectComponent(ctx, component.Name)
 			if err != nil {
-				records = append(records, []string{component.Name, "Error:" + err.Error()})
+				records = append(records, []string{component.Name, "Error:" + err.Error(), "", ""})
 				continue
 			}
-			switch s {
-			default:
-				records = append(records, []string{component.Name, "Unknown"})
-			case runtime.ComponentStatusReady:
-				records = append(records, []string{component.Name, "Ready"})
-			case runtime.ComponentStatusRunning:
-				records = append(records, []string{component.Name, "NotReady"})
-			case runtime.ComponentStatusStopped:
-				records = append(records, []string{component.Name, "Stopped"})
+			restarts, err := rt.InspectComponentRestarts(ctx, component.Name)
+			if err != nil {
+				logger.Warn("Failed to inspect restarts of component", "component", component.Name, "err", err)
 			}
+			records = append(records, []string{component.Name, formatStatus(s), strconv.Itoa(restarts.Count), formatLastExit(restarts)})
 		}
 
 		w := printers.NewTablePrinter(os.Stdout)
@@ -109,6 +108,41 @@ func runE(ctx context.Context, flags *flagpole) error {
 		if err != nil {
 			return err
 		}
+	case "prometheus":
+		return writeMetrics(ctx, os.Stdout, flags.Name, rt, list)
+	case "dot", "mermaid":
+		groups, err := components.GroupByLinks(list)
+		if err != nil {
+			return err
+		}
+		if flags.Output == "dot" {
+			return components.RenderDOT(os.Stdout, groups)
+		}
+		return components.RenderMermaid(os.Stdout, groups)
 	}
 	return nil
 }
+
+func formatStatus(s runtime.ComponentStatus) string {
+	switch s {
+	default:
+		return "Unknown"
+	case runtime.ComponentStatusReady:
+		return "Ready"
+	case runtime.ComponentStatusRunning:
+		return "NotReady"
+	case runtime.ComponentStatusStopped:
+		return "Stopped"
+	}
+}
+
+func formatLastExit(restarts runtime.ComponentRestarts) string {
+	if restarts.LastExitTime.IsZero() {
+		return "<none>"
+	}
+	reason := restarts.LastExitReason
+	if reason == "" {
+		reason = "Exited"
+	}
+	return fmt.Sprintf("%s(%d) %s ago", reason, restarts.LastExitCode, duration.HumanDuration(time.Since(restarts.LastExitTime)))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+type componentMetrics struct {
+	Name     string
+	Status   runtime.ComponentStatus
+	Restarts runtime.ComponentRestarts
+}
+
+func writeMetrics(ctx context.Context, w io.Writer, cluster string, rt runtime.Runtime, list []internalversion.Component) error {
+	logger := log.FromContext(ctx)
+	metrics := make([]componentMetrics, 0, len(list))
+	for _, component := range list {
+		s, err := rt.InspectComponent(ctx, component.Name)
+		if err != nil {
+			logger.Warn("Failed to inspect component", "component", component.Name, "err", err)
+		}
+		restarts, err := rt.InspectComponentRestarts(ctx, component.Name)
+		if err != nil {
+			logger.Warn("Failed to inspect restarts of component", "component", component.Name, "err", err)
+		}
+		metrics = append(metrics, componentMetrics{
+			Name:     component.Name,
+			Status:   s,
+			Restarts: restarts,
+		})
+	}
+	return renderMetrics(w, cluster, metrics)
+}
+
+// renderMetrics renders the metrics of the components in the Prometheus text format.
+func renderMetrics(w io.Writer, cluster string, metrics []componentMetrics) error {
+	buf := &strings.Builder{}
+
+	buf.WriteString("# HELP kwokctl_component_up Whether the component is running.\n")
+	buf.WriteString("# TYPE kwokctl_component_up gauge\n")
+	for _, m := range metrics {
+		up := 0
+		if m.Status == runtime.ComponentStatusRunning || m.Status == runtime.ComponentStatusReady {
+			up = 1
+		}
+		writeSample(buf, "kwokctl_component_up", cluster, m.Name, strconv.Itoa(up))
+	}
+
+	buf.WriteString("# HELP kwokctl_component_restarts_total The number of times the component has been restarted.\n")
+	buf.WriteString("# TYPE kwokctl_component_restarts_total counter\n")
+	for _, m := range metrics {
+		writeSample(buf, "kwokctl_component_restarts_total", cluster, m.Name, strconv.Itoa(m.Restarts.Count))
+	}
+
+	buf.WriteString("# HELP kwokctl_component_last_exit_code The exit code of the last exit of the component.\n")
+	buf.WriteString("# TYPE kwokctl_component_last_exit_code gauge\n")
+	for _, m := range metrics {
+		if m.Restarts.LastExitTime.IsZero() {
+			continue
+		}
+		writeSample(buf, "kwokctl_component_last_exit_code", cluster, m.Name, strconv.Itoa(m.Restarts.LastExitCode))
+	}
+
+	buf.WriteString("# HELP kwokctl_component_last_exit_timestamp_seconds The time of the last exit of the component in seconds since the epoch.\n")
+	buf.WriteString("# TYPE kwokctl_component_last_exit_timestamp_seconds gauge\n")
+	for _, m := range metrics {
+		if m.Restarts.LastExitTime.IsZero() {
+			continue
+		}
+		writeSample(buf, "kwokctl_component_last_exit_timestamp_seconds", cluster, m.Name, strconv.FormatInt(m.Restarts.LastExitTime.Unix(), 10))
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func writeSample(buf *strings.Builder, name, cluster, component, value string) {
+	fmt.Fprintf(buf, "%s{cluster=%s,component=%s} %s\n", name, strconv.Quote(cluster), strconv.Quote(component), value)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+func Test_renderMetrics(t *testing.T) {
+	metrics := []componentMetrics{
+		{
+			Name:   "etcd",
+			Status: runtime.ComponentStatusReady,
+		},
+		{
+			Name:   "kwok-controller",
+			Status: runtime.ComponentStatusStopped,
+			Restarts: runtime.ComponentRestarts{
+				Count:          3,
+				LastExitCode:   2,
+				LastExitReason: "Error",
+				LastExitTime:   time.Unix(1700000000, 0),
+			},
+		},
+	}
+	want := `# HELP kwokctl_component_up Whether the component is running.
+# TYPE kwokctl_component_up gauge
+kwokctl_component_up{cluster="kwok",component="etcd"} 1
+kwokctl_component_up{cluster="kwok",component="kwok-controller"} 0
+# HELP kwokctl_component_restarts_total The number of times the component has been restarted.
+# TYPE kwokctl_component_restarts_total counter
+kwokctl_component_restarts_total{cluster="kwok",component="etcd"} 0
+kwokctl_component_restarts_total{cluster="kwok",component="kwok-controller"} 3
+# HELP kwokctl_component_last_exit_code The exit code of the last exit of the component.
+# TYPE kwokctl_component_last_exit_code gauge
+kwokctl_component_last_exit_code{cluster="kwok",component="kwok-controller"} 2
+# HELP kwokctl_component_last_exit_timestamp_seconds The time of the last exit of the component in seconds since the epoch.
+# TYPE kwokctl_component_last_exit_timestamp_seconds gauge
+kwokctl_component_last_exit_timestamp_seconds{cluster="kwok",component="kwok-controller"} 1700000000
+`
+	buf := bytes.NewBuffer(nil)
+	err := renderMetrics(buf, "kwok", metrics)
+	if err != nil {
+		t.Fatalf("renderMetrics() error = %v", err)
+	}
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("renderMetrics() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // Package get defines a parent command for getting artifacts,
-// clusters and kubeconfig.
+// clusters, kubeconfig and resources.
 package get
 
 import (
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/clusters"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/resources"
 )
 
 // NewCommand returns a new cobra.Command for get
@@ -34,7 +35,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "get [command]",
-		Short: "Gets one of [artifacts, clusters, components, kubeconfig]",
+		Short: "Gets one of [artifacts, clusters, components, kubeconfig, resources]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -44,5 +45,6 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(components.NewCommand(ctx))
 	cmd.AddCommand(artifacts.NewCommand(ctx))
 	cmd.AddCommand(kubeconfig.NewCommand(ctx))
+	cmd.AddCommand(resources.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,318 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resources contains a command to summarize the resources of a cluster.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/pager"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+type flagpole struct {
+	Name   string
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for get resources
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:    cobra.NoArgs,
+		Use:     "resources",
+		Aliases: []string{"summary"},
+		Short:   "Summarize the resources of the cluster",
+		Long:    "Summarize the resources of the cluster, including the object counts per resource, the pod counts per namespace, the node Ready/NotReady counts and the etcd size",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Summary is the summary of the resources of a cluster.
+type Summary struct {
+	Resources  []ResourceCount  `json:"resources"`
+	Namespaces []NamespaceCount `json:"namespaces"`
+	Nodes      NodeCount        `json:"nodes"`
+	Etcd       *EtcdSize        `json:"etcd,omitempty"`
+}
+
+// ResourceCount is the count of objects of a resource.
+type ResourceCount struct {
+	Resource string `json:"resource"`
+	Count    int64  `json:"count"`
+}
+
+// NamespaceCount is the count of pods in a namespace.
+type NamespaceCount struct {
+	Namespace string `json:"namespace"`
+	Pods      int64  `json:"pods"`
+}
+
+// NodeCount is the count of nodes by ready condition.
+type NodeCount struct {
+	Ready    int64 `json:"ready"`
+	NotReady int64 `json:"notReady"`
+}
+
+// EtcdSize is the size of the etcd database.
+type EtcdSize struct {
+	DBSize      int64 `json:"dbSize"`
+	DBSizeInUse int64 `json:"dbSizeInUse"`
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("kubectl get --raw /apis")
+		dryrun.PrintMessage("kubectl get pods -A")
+		dryrun.PrintMessage("kubectl get nodes")
+		dryrun.PrintMessage("etcdctl endpoint status")
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := summarize(ctx, clientset)
+	if err != nil {
+		return err
+	}
+
+	etcdClient, err := rt.GetEtcdClient(ctx)
+	if err != nil {
+		logger.Warn("Failed to get etcd client", "error", err)
+	} else {
+		status, err := etcdClient.Status(ctx)
+		if err != nil {
+			logger.Warn("Failed to get etcd status", "error", err)
+		} else {
+			summary.Etcd = &EtcdSize{
+				DBSize:      status.DBSize,
+				DBSizeInUse: status.DBSizeInUse,
+			}
+		}
+	}
+
+	switch flags.Output {
+	default:
+		return fmt.Errorf("unknown output format %q", flags.Output)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	case "table":
+		return printTable(os.Stdout, summary)
+	}
+}
+
+func summarize(ctx context.Context, clientset client.Clientset) (*Summary, error) {
+	logger := log.FromContext(ctx)
+
+	discoveryClient, err := clientset.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resourceList.APIResources {
+			if !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(resource.Name)
+			list, err := dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{
+				Limit: 1,
+			})
+			if err != nil {
+				logger.Warn("Failed to list resource", "resource", gvr, "error", err)
+				continue
+			}
+			count := int64(len(list.Items))
+			if remaining := list.GetRemainingItemCount(); remaining != nil {
+				count += *remaining
+			}
+			summary.Resources = append(summary.Resources, ResourceCount{
+				Resource: gvr.GroupResource().String(),
+				Count:    count,
+			})
+		}
+	}
+	sort.Slice(summary.Resources, func(i, j int) bool {
+		return summary.Resources[i].Resource < summary.Resources[j].Resource
+	})
+
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]int64{}
+	podPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
+		return typedClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+	})
+	err = podPager.EachListItem(ctx, metav1.ListOptions{}, func(obj apiruntime.Object) error {
+		pod := obj.(*corev1.Pod)
+		namespaces[pod.Namespace]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for namespace, count := range namespaces {
+		summary.Namespaces = append(summary.Namespaces, NamespaceCount{
+			Namespace: namespace,
+			Pods:      count,
+		})
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		return summary.Namespaces[i].Namespace < summary.Namespaces[j].Namespace
+	})
+
+	nodePager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
+		return typedClient.CoreV1().Nodes().List(ctx, opts)
+	})
+	err = nodePager.EachListItem(ctx, metav1.ListOptions{}, func(obj apiruntime.Object) error {
+		node := obj.(*corev1.Node)
+		if isNodeReady(node) {
+			summary.Nodes.Ready++
+		} else {
+			summary.Nodes.NotReady++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func printTable(w io.Writer, summary *Summary) error {
+	records := [][]string{
+		{"RESOURCE", "COUNT"},
+	}
+	for _, r := range summary.Resources {
+		records = append(records, []string{r.Resource, format.String(r.Count)})
+	}
+	err := printers.NewTablePrinter(w).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w)
+
+	records = [][]string{
+		{"NAMESPACE", "PODS"},
+	}
+	for _, n := range summary.Namespaces {
+		records = append(records, []string{n.Namespace, format.String(n.Pods)})
+	}
+	err = printers.NewTablePrinter(w).WriteAll(records)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w)
+
+	records = [][]string{
+		{"NODES", "READY", "NOTREADY"},
+		{format.String(summary.Nodes.Ready + summary.Nodes.NotReady), format.String(summary.Nodes.Ready), format.String(summary.Nodes.NotReady)},
+	}
+	err = printers.NewTablePrinter(w).WriteAll(records)
+	if err != nil {
+		return err
+	}
+
+	if summary.Etcd != nil {
+		_, _ = fmt.Fprintln(w)
+		records = [][]string{
+			{"ETCD DB SIZE", "ETCD DB SIZE IN USE"},
+			{format.HumanSize(summary.Etcd.DBSize), format.HumanSize(summary.Etcd.DBSizeInUse)},
+		}
+		err = printers.NewTablePrinter(w).WriteAll(records)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prune implements the prune command
+package prune
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/sets"
+)
+
+type flagpole struct {
+	Kubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for pruning the kubeconfig
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "prune",
+		Short: "Remove the contexts, clusters and users of the clusters that no longer exist from the kubeconfig",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file to prune")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	kubeconfigPath, err := path.Expand(flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := runtime.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	exists := sets.NewSets[string]()
+	for _, cluster := range clusters {
+		exists.Insert(config.ClusterName(cluster))
+	}
+
+	logger := log.FromContext(ctx)
+	if dryrun.DryRun {
+		conf, err := kubeconfig.LoadFromFile(kubeconfigPath)
+		if err != nil {
+			return err
+		}
+		for _, name := range pruneContexts(conf, exists) {
+			dryrun.PrintMessage("# Remove context %s from %s", name, kubeconfigPath)
+		}
+		return nil
+	}
+
+	var pruned []string
+	err = kubeconfig.ModifyContext(kubeconfigPath, func(conf *clientcmdapi.Config) error {
+		pruned = pruneContexts(conf, exists)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range pruned {
+		logger.Info("Removed context from kubeconfig",
+			"context", name,
+			"kubeconfig", kubeconfigPath,
+		)
+	}
+	if len(pruned) == 0 {
+		logger.Info("No stale contexts found", "kubeconfig", kubeconfigPath)
+	}
+	return nil
+}
+
+// pruneContexts removes the context, cluster and user of the clusters of kwokctl that are not in exists,
+// and returns the names of the removed contexts.
+func pruneContexts(conf *clientcmdapi.Config, exists sets.Sets[string]) []string {
+	prefix := consts.ProjectName + "-"
+
+	var names []string
+	for name := range conf.Contexts {
+		if !strings.HasPrefix(name, prefix) || exists.Has(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// The kind runtime refers to the cluster and user added by kind
+		for _, ref := range []string{name, "kind-" + name} {
+			delete(conf.Clusters, ref)
+			delete(conf.AuthInfos, ref)
+		}
+		delete(conf.Contexts, name)
+		if conf.CurrentContext == name {
+			conf.CurrentContext = ""
+		}
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"sigs.k8s.io/kwok/pkg/utils/sets"
+)
+
+func TestPruneContexts(t *testing.T) {
+	conf := &clientcmdapi.Config{
+		CurrentContext: "kwok-stale",
+		Contexts: map[string]*clientcmdapi.Context{
+			"kwok-exists":    {Cluster: "kwok-exists", AuthInfo: "kwok-exists"},
+			"kwok-stale":     {Cluster: "kwok-stale", AuthInfo: "kwok-stale"},
+			"kwok-kind":      {Cluster: "kind-kwok-kind", AuthInfo: "kind-kwok-kind"},
+			"other":          {Cluster: "other", AuthInfo: "other"},
+			"kind-kwok-kind": {Cluster: "kind-kwok-kind", AuthInfo: "kind-kwok-kind"},
+		},
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"kwok-exists":    {},
+			"kwok-stale":     {},
+			"kind-kwok-kind": {},
+			"other":          {},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"kwok-exists":    {},
+			"kwok-stale":     {},
+			"kind-kwok-kind": {},
+			"other":          {},
+		},
+	}
+
+	got := pruneContexts(conf, sets.NewSets("kwok-exists"))
+	if diff := cmp.Diff([]string{"kwok-kind", "kwok-stale"}, got); diff != "" {
+		t.Errorf("pruneContexts() mismatch (-want +got):\n%s", diff)
+	}
+
+	want := &clientcmdapi.Config{
+		Contexts: map[string]*clientcmdapi.Context{
+			"kwok-exists":    {Cluster: "kwok-exists", AuthInfo: "kwok-exists"},
+			"other":          {Cluster: "other", AuthInfo: "other"},
+			"kind-kwok-kind": {Cluster: "kind-kwok-kind", AuthInfo: "kind-kwok-kind"},
+		},
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"kwok-exists": {},
+			"other":       {},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"kwok-exists": {},
+			"other":       {},
+		},
+	}
+	if diff := cmp.Diff(want, conf); diff != "" {
+		t.Errorf("kubeconfig mismatch (-want +got):\n%s", diff)
+	}
+}
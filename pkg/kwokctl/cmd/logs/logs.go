@@ -77,6 +77,10 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 			err = rt.AuditLogs(ctx, os.Stdout)
 		}
 	} else {
+		err = runtime.StartLazyComponent(ctx, rt, args[0])
+		if err != nil {
+			return err
+		}
 		if flags.Follow {
 			err = rt.LogsFollow(ctx, args[0], os.Stdout)
 		} else {
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricsproxy contains a command to serve the metrics of the components of a cluster on the host.
+package metricsproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name    string
+	Address string
+	Port    uint32
+}
+
+// NewCommand returns a new cobra.Command for the metrics proxy
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "metrics-proxy",
+		Short: "Serve the metrics of the components of the cluster on the host",
+		Long: `Serve the metrics of the components of the cluster on the host, with the TLS credentials of each component handled by kwokctl.
+The metrics of a component are served at /metrics-proxy/<component>,
+the targets for the HTTP service discovery of Prometheus are served at /metrics-proxy,
+and a Prometheus config scraping all of them is served at /metrics-proxy/prometheus.yaml.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Address, "address", utilsnet.LocalAddress, "Address to listen on")
+	cmd.Flags().Uint32Var(&flags.Port, "port", 0, "Port to listen on (default random)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	address := net.JoinHostPort(flags.Address, strconv.FormatUint(uint64(flags.Port), 10))
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Serve the metrics of the components on %s", address)
+		return nil
+	}
+
+	components, err := rt.ListComponents(ctx)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	proxyAddress := listener.Addr().String()
+	if host, port, err := net.SplitHostPort(proxyAddress); err == nil && net.ParseIP(host).IsUnspecified() {
+		proxyAddress = net.JoinHostPort(utilsnet.LocalAddress, port)
+	}
+
+	proxy := newMetricsProxy(flags.Name, proxyAddress)
+	for _, component := range components {
+		metric, err := runtime.HostMetric(component, rt.HostAddress())
+		if err != nil {
+			logger.Warn("Skip the metric of the component", "component", component.Name, "err", err)
+			continue
+		}
+		if metric == nil {
+			continue
+		}
+		err = proxy.AddTarget(component.Name, *metric)
+		if err != nil {
+			return err
+		}
+	}
+
+	svc := &http.Server{
+		ReadHeaderTimeout: 5 * time.Second,
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+		Handler: proxy,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = svc.Close()
+	}()
+
+	logger.Info("Serving the metrics of the components",
+		"components", proxy.Names(),
+		"targets", "http://"+proxyAddress+pathPrefix,
+		"prometheus", "http://"+proxyAddress+pathPrefix+"/"+prometheusConfigName,
+	)
+	err = svc.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the metrics proxy: %w", err)
+	}
+	return nil
+}
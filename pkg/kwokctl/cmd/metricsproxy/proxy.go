@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsproxy
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+const (
+	// pathPrefix is the path prefix of the metrics proxy.
+	pathPrefix = "/metrics-proxy"
+	// prometheusConfigName is the name of the Prometheus config served by the metrics proxy.
+	prometheusConfigName = "prometheus.yaml"
+)
+
+// metricsProxy serves the metrics of the components at the path of the component,
+// so that the metrics of all the runtimes can be scraped in the same way.
+type metricsProxy struct {
+	cluster string
+	address string
+	targets map[string]*target
+	mux     *http.ServeMux
+}
+
+type target struct {
+	metric internalversion.ComponentMetric
+	client *http.Client
+}
+
+// httpSDTarget is a target group of the HTTP service discovery of Prometheus.
+type httpSDTarget struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+func newMetricsProxy(cluster, address string) *metricsProxy {
+	p := &metricsProxy{
+		cluster: cluster,
+		address: address,
+		targets: map[string]*target{},
+		mux:     http.NewServeMux(),
+	}
+	p.mux.HandleFunc("GET "+pathPrefix, p.serveTargets)
+	p.mux.HandleFunc("GET "+pathPrefix+"/"+prometheusConfigName, p.servePrometheusConfig)
+	p.mux.HandleFunc("GET "+pathPrefix+"/{component}", p.serveMetrics)
+	return p
+}
+
+// AddTarget adds the metric of the component as it is reachable from the host.
+func (p *metricsProxy) AddTarget(name string, metric internalversion.ComponentMetric) error {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if metric.Scheme == "https" {
+		tlsConfig := &tls.Config{
+			//nolint:gosec
+			InsecureSkipVerify: metric.InsecureSkipVerify,
+		}
+		if metric.CertPath != "" && metric.KeyPath != "" {
+			cert, err := tls.LoadX509KeyPair(metric.CertPath, metric.KeyPath)
+			if err != nil {
+				return fmt.Errorf("failed to load the cert of the metric of %s: %w", name, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	p.targets[name] = &target{
+		metric: metric,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   10 * time.Second,
+		},
+	}
+	return nil
+}
+
+// Names returns the names of the components in order.
+func (p *metricsProxy) Names() []string {
+	names := make([]string, 0, len(p.targets))
+	for name := range p.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *metricsProxy) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(rw, r)
+}
+
+func (p *metricsProxy) serveTargets(rw http.ResponseWriter, _ *http.Request) {
+	names := p.Names()
+	groups := make([]httpSDTarget, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, httpSDTarget{
+			Targets: []string{p.address},
+			Labels: map[string]string{
+				"__metrics_path__": pathPrefix + "/" + name,
+				"cluster":          p.cluster,
+				"component":        name,
+			},
+		})
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(groups)
+}
+
+func (p *metricsProxy) servePrometheusConfig(rw http.ResponseWriter, _ *http.Request) {
+	names := p.Names()
+	proxied := make([]internalversion.Component, 0, len(names))
+	for _, name := range names {
+		proxied = append(proxied, internalversion.Component{
+			Name: name,
+			Metric: &internalversion.ComponentMetric{
+				Scheme: "http",
+				Host:   p.address,
+				Path:   pathPrefix + "/" + name,
+			},
+		})
+	}
+	conf, err := components.BuildPrometheus(components.BuildPrometheusConfig{
+		Components: proxied,
+	})
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/yaml")
+	_, _ = io.WriteString(rw, conf)
+}
+
+func (p *metricsProxy) serveMetrics(rw http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("component")
+	t, ok := p.targets[name]
+	if !ok {
+		http.Error(rw, fmt.Sprintf("component %q has no metric", name), http.StatusNotFound)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, t.metric.Scheme+"://"+t.metric.Host+t.metric.Path, nil)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if accept := r.Header.Get("Accept"); accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		logger := log.FromContext(r.Context())
+		logger.Warn("Failed to scrape the metric", "component", name, "err", err)
+		http.Error(rw, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		rw.Header().Set("Content-Type", contentType)
+	}
+	rw.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(rw, resp.Body)
+}
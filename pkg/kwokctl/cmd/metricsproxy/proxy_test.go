@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsproxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestMetricsProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(rw, r)
+			return
+		}
+		rw.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = io.WriteString(rw, "up 1\n")
+	}))
+	defer backend.Close()
+
+	proxy := newMetricsProxy("kwok", "127.0.0.1:10250")
+	err := proxy.AddTarget("etcd", internalversion.ComponentMetric{
+		Scheme: "http",
+		Host:   strings.TrimPrefix(backend.URL, "http://"),
+		Path:   "/metrics",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = proxy.AddTarget("kube-apiserver", internalversion.ComponentMetric{
+		Scheme: "http",
+		Host:   strings.TrimPrefix(backend.URL, "http://"),
+		Path:   "/not-found",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		proxy.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+		return rw
+	}
+
+	rw := serve("/metrics-proxy/etcd")
+	if rw.Code != http.StatusOK || rw.Body.String() != "up 1\n" {
+		t.Errorf("unexpected metrics of etcd %d %q", rw.Code, rw.Body.String())
+	}
+	if got := rw.Header().Get("Content-Type"); got != "text/plain; version=0.0.4" {
+		t.Errorf("unexpected content type %q", got)
+	}
+
+	rw = serve("/metrics-proxy/kube-apiserver")
+	if rw.Code != http.StatusNotFound {
+		t.Errorf("unexpected status of kube-apiserver %d", rw.Code)
+	}
+
+	rw = serve("/metrics-proxy/kube-scheduler")
+	if rw.Code != http.StatusNotFound {
+		t.Errorf("unexpected status of kube-scheduler %d", rw.Code)
+	}
+
+	rw = serve("/metrics-proxy")
+	var groups []httpSDTarget
+	err = json.Unmarshal(rw.Body.Bytes(), &groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []httpSDTarget{
+		{
+			Targets: []string{"127.0.0.1:10250"},
+			Labels: map[string]string{
+				"__metrics_path__": "/metrics-proxy/etcd",
+				"cluster":          "kwok",
+				"component":        "etcd",
+			},
+		},
+		{
+			Targets: []string{"127.0.0.1:10250"},
+			Labels: map[string]string{
+				"__metrics_path__": "/metrics-proxy/kube-apiserver",
+				"cluster":          "kwok",
+				"component":        "kube-apiserver",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, groups); diff != "" {
+		t.Errorf("unexpected targets (-want +got):\n%s", diff)
+	}
+
+	rw = serve("/metrics-proxy/prometheus.yaml")
+	conf := rw.Body.String()
+	for _, s := range []string{`job_name: "etcd"`, "metrics_path: /metrics-proxy/etcd", "- 127.0.0.1:10250"} {
+		if !strings.Contains(conf, s) {
+			t.Errorf("missing %q in the prometheus config:\n%s", s, conf)
+		}
+	}
+}
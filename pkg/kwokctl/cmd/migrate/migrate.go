@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrate contains a command to migrate a cluster to another runtime.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name       string
+	Runtime    string
+	Kubeconfig string
+	Filters    []string
+}
+
+// NewCommand returns a new cobra.Command for migrating a cluster to another runtime.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "migrate",
+		Short: "Migrate the cluster to another runtime",
+		Long:  "Migrate the cluster to another runtime, the resources are saved as a k8s format snapshot, then the cluster is recreated on the runtime with the same configuration and the snapshot is restored",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Runtime, "runtime", "", fmt.Sprintf("Runtime to migrate the cluster to (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file that the context of the cluster is updated in")
+	cmd.Flags().StringSliceVar(&flags.Filters, "filter", snapshot.Resources, "Filter the resources to migrate")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Runtime == "" {
+		return fmt.Errorf("runtime is required")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	var err error
+	if flags.Kubeconfig != "" {
+		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if conf.Options.Runtime == flags.Runtime {
+		logger.Info("Cluster is already on the runtime", "runtime", flags.Runtime)
+		return nil
+	}
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(flags.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", flags.Runtime)
+	}
+	newRt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", flags.Runtime, err)
+	}
+	err = newRt.Available(ctx)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", flags.Runtime, err)
+	}
+
+	// The configurations saved with the cluster, such as stages, are carried over to the new runtime.
+	objs, err := config.Load(ctx, rt.GetWorkdirPath(runtime.ConfigName))
+	if err != nil {
+		return err
+	}
+	ctx = config.NewContext(ctx, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs))
+
+	tmpDir, err := os.MkdirTemp("", "kwokctl-migrate-")
+	if err != nil {
+		return err
+	}
+	snapshotPath := path.Join(tmpDir, flags.Name+".yaml")
+
+	start := time.Now()
+	logger.Info("Saving the resources of the cluster", "path", snapshotPath)
+	err = rt.SnapshotSaveWithYAML(ctx, snapshotPath, runtime.SnapshotSaveWithYAMLConfig{
+		Filters: flags.Filters,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = rt.Down(ctx)
+	if err != nil {
+		return err
+	}
+	if flags.Kubeconfig != "" {
+		err = rt.RemoveContext(ctx, flags.Kubeconfig)
+		if err != nil {
+			logger.Error("Failed to remove context from kubeconfig", err,
+				"kubeconfig", flags.Kubeconfig,
+			)
+		}
+	}
+	err = rt.Uninstall(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The resources are kept if the cluster fails to be recreated, so that it can be restored manually.
+	err = recreate(ctx, newRt, conf, flags, snapshotPath)
+	if err != nil {
+		return fmt.Errorf("failed to migrate, the resources are kept in %q: %w", snapshotPath, err)
+	}
+
+	err = os.RemoveAll(tmpDir)
+	if err != nil {
+		logger.Warn("Failed to remove the temporary directory", "path", tmpDir, "err", err)
+	}
+	logger.Info("Cluster is migrated",
+		"from", conf.Options.Runtime,
+		"to", flags.Runtime,
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
+
+func recreate(ctx context.Context, rt runtime.Runtime, conf *internalversion.KwokctlConfiguration, flags *flagpole, snapshotPath string) error {
+	logger := log.FromContext(ctx)
+
+	// The components are laid out by the runtime, so they are rebuilt by the new runtime.
+	conf = conf.DeepCopy()
+	conf.Options.Runtime = flags.Runtime
+	conf.Components = nil
+
+	err := rt.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+	err = rt.Save(ctx)
+	if err != nil {
+		return err
+	}
+	err = rt.Install(ctx)
+	if err != nil {
+		return err
+	}
+	err = rt.Up(ctx)
+	if err != nil {
+		return err
+	}
+	err = rt.InitCRDs(ctx)
+	if err != nil {
+		return err
+	}
+	err = rt.InitCRs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if flags.Kubeconfig != "" {
+		err = rt.AddContext(ctx, flags.Kubeconfig)
+		if err != nil {
+			logger.Error("Failed to add context to kubeconfig", err,
+				"kubeconfig", flags.Kubeconfig,
+			)
+		}
+	}
+
+	logger.Info("Restoring the resources of the cluster", "path", snapshotPath)
+	return rt.SnapshotRestoreWithYAML(ctx, snapshotPath, runtime.SnapshotRestoreWithYAMLConfig{
+		Filters: flags.Filters,
+	})
+}
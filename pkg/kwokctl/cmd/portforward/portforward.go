@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package portforward contains a command to forward a local port to a component of a cluster.
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name    string
+	Address string
+}
+
+// NewCommand returns a new cobra.Command for port forwarding
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.RangeArgs(1, 2),
+		Use:   "port-forward [component] [[local-port:]port]",
+		Short: "Forward a local port to a component, the component with lazy start policy is started if it is not running",
+		Long:  "Forward a local port to a component, the port is the port of the component on the host and defaults to the first port of the component, the local port defaults to a random port",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Address, "address", utilsnet.LocalAddress, "Address to listen on")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	var localPort, port uint32
+	if len(args) > 1 {
+		var err error
+		localPort, port, err = parsePorts(args[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	componentName := args[0]
+	component, err := rt.GetComponent(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	if port == 0 {
+		for _, p := range component.Ports {
+			if p.HostPort != 0 {
+				port = p.HostPort
+				break
+			}
+		}
+		if port == 0 {
+			return fmt.Errorf("component %q has no port exposed on the host, please specify the port", componentName)
+		}
+	}
+
+	err = runtime.StartLazyComponent(ctx, rt, componentName)
+	if err != nil {
+		return err
+	}
+
+	target := net.JoinHostPort(rt.HostAddress(), strconv.FormatUint(uint64(port), 10))
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Forward %s to %s of %s", net.JoinHostPort(flags.Address, strconv.FormatUint(uint64(localPort), 10)), target, componentName)
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(flags.Address, strconv.FormatUint(uint64(localPort), 10)))
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	logger.Info("Forwarding",
+		"component", componentName,
+		"from", listener.Addr().String(),
+		"to", target,
+	)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go forward(ctx, conn, target)
+	}
+}
+
+// parsePorts parses the ports in the format of [local-port:]port.
+func parsePorts(s string) (localPort uint32, port uint32, err error) {
+	local, remote, ok := strings.Cut(s, ":")
+	if !ok {
+		remote, local = local, ""
+	}
+	if local != "" {
+		localPort, err = parsePort(local)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	port, err = parsePort(remote)
+	if err != nil {
+		return 0, 0, err
+	}
+	return localPort, port, nil
+}
+
+func parsePort(s string) (uint32, error) {
+	p, err := strconv.ParseUint(s, 10, 16)
+	if err != nil || p == 0 {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+	return uint32(p), nil
+}
+
+func forward(ctx context.Context, conn net.Conn, target string) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	logger := log.FromContext(ctx)
+	dialer := net.Dialer{}
+	remote, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		logger.Error("Failed to dial", err, "target", target)
+		return
+	}
+	defer func() {
+		_ = remote.Close()
+	}()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, remote)
+		done <- struct{}{}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package portforward
+
+import (
+	"testing"
+)
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		args          string
+		wantLocalPort uint32
+		wantPort      uint32
+		wantErr       bool
+	}{
+		{args: "9090", wantPort: 9090},
+		{args: "8080:9090", wantLocalPort: 8080, wantPort: 9090},
+		{args: ":9090", wantPort: 9090},
+		{args: "8080:", wantErr: true},
+		{args: "0", wantErr: true},
+		{args: "65536", wantErr: true},
+		{args: "http", wantErr: true},
+	}
+	for _, tt := range tests {
+		localPort, port, err := parsePorts(tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePorts(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if localPort != tt.wantLocalPort || port != tt.wantPort {
+			t.Errorf("parsePorts(%q) = %d, %d, want %d, %d", tt.args, localPort, port, tt.wantLocalPort, tt.wantPort)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package list contains a command to list the builtin presets of resources.
+package list
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+// NewCommand returns a new cobra.Command for presets list
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:    cobra.MaximumNArgs(1),
+		Use:     "list [node, pod]",
+		Aliases: []string{"ls"},
+		Short:   "List the builtin presets of resources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), args)
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, args []string) error {
+	kinds := scale.PresetKinds
+	if len(args) != 0 {
+		kinds = args
+	}
+
+	records := [][]string{
+		{"RESOURCE", "NAME", "DESCRIPTION"},
+	}
+	for _, kind := range kinds {
+		presets, err := scale.BuiltinPresets(kind)
+		if err != nil {
+			return err
+		}
+		for _, preset := range presets {
+			records = append(records, []string{kind, preset.Name, preset.Description})
+		}
+	}
+	return printers.NewTablePrinter(os.Stdout).WriteAll(records)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package show contains a command to show a builtin preset of resources.
+package show
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// NewCommand returns a new cobra.Command for presets show
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(2),
+		Use:   "show [node, pod] <name>",
+		Short: "Show the parameters of a builtin preset",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), args)
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, args []string) error {
+	presets, err := scale.BuiltinPresets(args[0])
+	if err != nil {
+		return err
+	}
+	preset, err := scale.FindPreset(presets, args[1])
+	if err != nil {
+		return err
+	}
+	return yaml.NewEncoder(os.Stdout).Encode(preset)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package renewcerts contains a command to renew the certs of a cluster.
+package renewcerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/pki"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name        string
+	RenewBefore time.Duration
+}
+
+// NewCommand returns a new cobra.Command for renewing the certs of a cluster.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "renew-certs",
+		Short: "Renew the certs of the cluster which are about to expire",
+		Long:  "Renew the certs of the cluster signed by its CA which expire within --renew-before, and restart the components using them. The CA, the keys and the SANs of the certs are kept, so the kubeconfigs are still valid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().DurationVar(&flags.RenewBefore, "renew-before", 30*24*time.Hour, "Renew the certs which expire within the duration")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The certs of kind are generated by kubeadm, and the ones of the kubernetes runtime are stored in the secrets
+	if components.GetRuntimeMode(conf.Options.Runtime) == components.RuntimeModeCluster ||
+		conf.Options.Runtime == consts.RuntimeTypeKubernetes {
+		return fmt.Errorf("renewing the certs is not supported by %s runtime", conf.Options.Runtime)
+	}
+
+	pkiPath := rt.GetWorkdirPath(runtime.PkiName)
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Renew the certs in %s which expire within %s", pkiPath, flags.RenewBefore)
+	} else {
+		renewed, err := pki.RenewCerts(pkiPath, time.Now().Add(flags.RenewBefore))
+		if err != nil {
+			return err
+		}
+		if len(renewed) == 0 {
+			logger.Info("No certs expire within the duration", "renewBefore", flags.RenewBefore)
+			return nil
+		}
+		logger.Info("Certs are renewed", "certs", renewed)
+	}
+
+	for _, component := range componentsUsingCerts(conf.Components,
+		pkiPath,
+		rt.GetWorkdirPath(runtime.InClusterKubeconfigName),
+		rt.GetWorkdirPath(runtime.InHostKubeconfigName),
+	) {
+		logger := logger.With("component", component.Name)
+		logger.Info("Restarting component")
+		err = rt.StopComponent(ctx, component.Name)
+		if err != nil {
+			return fmt.Errorf("failed to stop component %s: %w", component.Name, err)
+		}
+		err = rt.StartComponent(ctx, component.Name)
+		if err != nil {
+			return fmt.Errorf("failed to start component %s: %w", component.Name, err)
+		}
+	}
+	return nil
+}
+
+// componentsUsingCerts returns the components which refer to any of the paths in their args, volumes or envs,
+// i.e. the pki directory and the kubeconfigs pointing at the certs in it.
+func componentsUsingCerts(comps []internalversion.Component, paths ...string) []internalversion.Component {
+	refer := func(s string) bool {
+		for _, p := range paths {
+			if strings.Contains(s, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var out []internalversion.Component
+	for _, component := range comps {
+		using := false
+		for _, arg := range component.Args {
+			using = using || refer(arg)
+		}
+		for _, volume := range component.Volumes {
+			using = using || refer(volume.HostPath)
+		}
+		for _, env := range component.Envs {
+			using = using || refer(env.Value)
+		}
+		if using {
+			out = append(out, component)
+		}
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renewcerts
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_componentsUsingCerts(t *testing.T) {
+	comps := []internalversion.Component{
+		{
+			Name: "kube-apiserver",
+			Args: []string{"--tls-cert-file=/workdir/pki/admin.crt"},
+		},
+		{
+			Name: "kube-scheduler",
+			Volumes: []internalversion.Volume{
+				{HostPath: "/workdir/kubeconfig", MountPath: "/root/.kube/config"},
+			},
+		},
+		{
+			Name: "custom",
+			Envs: []internalversion.Env{
+				{Name: "CA_CERT", Value: "/workdir/pki/ca.crt"},
+			},
+		},
+		{
+			Name: "prometheus",
+			Args: []string{"--config.file=/workdir/prometheus.yaml"},
+		},
+	}
+
+	var got []string
+	for _, component := range componentsUsingCerts(comps, "/workdir/pki", "/workdir/kubeconfig") {
+		got = append(got, component.Name)
+	}
+	want := []string{"kube-apiserver", "kube-scheduler", "custom"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("componentsUsingCerts() = %v, want %v", got, want)
+	}
+}
@@ -19,23 +19,43 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/analyze"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/assert"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/attach"
 	conf "sigs.k8s.io/kwok/pkg/kwokctl/cmd/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/create"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/dashboard"
 	del "sigs.k8s.io/kwok/pkg/kwokctl/cmd/delete"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/describe"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/etcdctl"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/export"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/generate"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/hack"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/kubectl"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/logs"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/metricsproxy"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/migrate"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/portforward"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/presets"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/renewcerts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/run"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/scale"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/shell"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/start"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stats"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stop"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/supervise"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/top"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/upgradecomponent"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 )
@@ -52,26 +72,54 @@ func NewCommand(ctx context.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if dryrun.Format != "" && !dryrun.DryRun {
+				return fmt.Errorf("--dry-run-format requires --dry-run")
+			}
+			return dryrun.ValidateFormat(dryrun.Format)
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return dryrun.Flush()
+		},
 	}
 
 	cmd.PersistentFlags().StringVar(&config.DefaultCluster, "name", config.DefaultCluster, "cluster name")
 	cmd.PersistentFlags().BoolVar(&dryrun.DryRun, "dry-run", dryrun.DryRun, "Print the command that would be executed, but do not execute it")
+	cmd.PersistentFlags().StringVar(&dryrun.Format, "dry-run-format", dryrun.Format, fmt.Sprintf("Format of the output of --dry-run (%s), print the commands as they are executed if empty", strings.Join(dryrun.Formats, " or ")))
 	cmd.TraverseChildren = true
 
 	cmd.AddCommand(
 		conf.NewCommand(ctx),
 		create.NewCommand(ctx),
+		attach.NewCommand(ctx),
 		del.NewCommand(ctx),
 		get.NewCommand(ctx),
+		describe.NewCommand(ctx),
 		start.NewCommand(ctx),
 		stop.NewCommand(ctx),
 		kubectl.NewCommand(ctx),
+		kubeconfig.NewCommand(ctx),
 		etcdctl.NewCommand(ctx),
 		logs.NewCommand(ctx),
+		portforward.NewCommand(ctx),
+		metricsproxy.NewCommand(ctx),
 		scale.NewCommand(ctx),
+		presets.NewCommand(ctx),
+		run.NewCommand(ctx),
+		assert.NewCommand(ctx),
+		generate.NewCommand(ctx),
+		top.NewCommand(ctx),
+		stats.NewCommand(ctx),
+		analyze.NewCommand(ctx),
+		shell.NewCommand(ctx),
+		dashboard.NewCommand(ctx),
 		snapshot.NewCommand(ctx),
+		migrate.NewCommand(ctx),
 		export.NewCommand(ctx),
+		upgradecomponent.NewCommand(ctx),
+		renewcerts.NewCommand(ctx),
 		hack.NewCommand(ctx),
+		supervise.NewCommand(ctx),
 	)
 	return cmd
 }
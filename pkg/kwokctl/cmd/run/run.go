@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package run contains a command to run a scenario on a cluster.
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scenario"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Output        string
+	PrometheusURL string
+}
+
+// NewCommand returns a new cobra.Command for run
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "run [scenario.yaml]",
+		Short: "Run a scenario on the cluster",
+		Long:  "Run a scenario on the cluster, the phases of the scenario run kwokctl and kubectl commands, wait and assert the state of the cluster, and the run fails at the first failed step",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Output format of the results of the steps (json), they are logged if empty")
+	cmd.Flags().StringVar(&flags.PrometheusURL, "prometheus-url", "", "URL of the Prometheus of the assertions, defaults to the Prometheus of the cluster")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, scenarioPath string) error {
+	if flags.Output != "" && flags.Output != "json" {
+		return fmt.Errorf("unsupported output %q", flags.Output)
+	}
+	scenarioPath, err := path.Expand(scenarioPath)
+	if err != nil {
+		return err
+	}
+	data, err := file.Read(scenarioPath)
+	if err != nil {
+		return err
+	}
+	s, err := scenario.Load(data)
+	if err != nil {
+		return err
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if rt.IsDryRun() {
+		for _, phase := range s.Phases {
+			dryrun.PrintMessage("# Run phase %q with %d steps", phase.Name, len(phase.Steps))
+		}
+		return nil
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	runner, err := scenario.NewRuntimeRunner(ctx, scenario.RuntimeRunnerConfig{
+		Runtime:       rt,
+		Name:          flags.Name,
+		Kwokctl:       self,
+		Dir:           path.Dir(scenarioPath),
+		PrometheusURL: flags.PrometheusURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Running scenario", "scenario", s.Name, "phases", len(s.Phases))
+	results, err := scenario.Run(ctx, runner, s)
+	if flags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		e := encoder.Encode(results)
+		if e != nil {
+			return e
+		}
+	} else {
+		for _, result := range results {
+			if result.Passed {
+				logger.Info("Step passed", "phase", result.Phase, "step", result.Step, "elapsed", result.Elapsed)
+			} else {
+				logger.Warn("Step failed", "phase", result.Phase, "step", result.Step, "elapsed", result.Elapsed, "message", result.Message)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Scenario passed", "scenario", s.Name)
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"path"
 
 	"github.com/spf13/cobra"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 
 	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
@@ -34,6 +35,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/signals"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
@@ -44,6 +46,16 @@ type flagpole struct {
 	Namespace    string
 	Replicas     uint64
 	Params       []string
+	Preset       string
+	NamePattern  string
+	Zones        []string
+	Labels       []string
+	Annotations  []string
+	ObjectSize   string
+	MaxTotalSize string
+	Resume       bool
+
+	ScaleDownStrategy string
 }
 
 // NewCommand returns a new cobra.Command for scale resource.
@@ -52,8 +64,9 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Args:  cobra.RangeArgs(1, 2),
-		Use:   "scale [node, pod, ...] [name]",
+		Use:   "scale [node, pod, deployment, statefulset, job, service, workload, ...] [name]",
 		Short: "Scale a resource in cluster",
+		Long:  "Scale a resource in cluster, the pods of the deployments, statefulsets and jobs are created by the controllers in kube-controller-manager",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags.Name = config.DefaultCluster
 			return runE(cmd.Context(), flags, args)
@@ -63,6 +76,15 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().IntVar(&flags.SerialLength, "serial-length", 6, "Length of serial number")
 	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", flags.Namespace, "Namespace of resource to scale")
 	cmd.Flags().StringArrayVar(&flags.Params, "param", flags.Params, "Parameter to update")
+	cmd.Flags().StringVar(&flags.NamePattern, "name-pattern", flags.NamePattern, "Pattern of the names with the placeholders {name}, {index} and {zone}, e.g. node-{zone}-{index:05d}, it overrides --serial-length")
+	cmd.Flags().StringSliceVar(&flags.Zones, "zones", flags.Zones, "Zones assigned to the resources in round-robin, exposed as Zone in the template")
+	cmd.Flags().StringArrayVar(&flags.Labels, "label-distribution", flags.Labels, "Weighted distribution of the values of a label, e.g. team=a:50,b:30,c:20")
+	cmd.Flags().StringArrayVar(&flags.Annotations, "annotation-distribution", flags.Annotations, "Weighted distribution of the values of an annotation, e.g. owner=alice:1,bob:1")
+	cmd.Flags().StringVar(&flags.Preset, "preset", flags.Preset, "Preset of parameters to use, e.g. eks/m5.xlarge for node or web for workload, see 'kwokctl presets list'")
+	cmd.Flags().StringVar(&flags.ObjectSize, "object-size", flags.ObjectSize, "Size of each object, padded with a payload annotation to stress the pagination, watch cache and etcd, e.g. 64Ki, up to 256Ki")
+	cmd.Flags().StringVar(&flags.MaxTotalSize, "max-total-size", "1Gi", "Max total size of the objects padded by --object-size, to avoid exceeding the quota of etcd")
+	cmd.Flags().StringVar(&flags.ScaleDownStrategy, "scale-down-strategy", string(scale.ScaleDownNewestFirst), "Strategy to pick the objects to delete when the replicas are reduced, one of newest-first, random, by-zone (keeps the zones balanced) or cordon-drain (cordons the nodes and evicts their pods first, only for node)")
+	cmd.Flags().BoolVar(&flags.Resume, "resume", flags.Resume, "Resume the last scale of the resource recorded in the cluster, only the missing or extra objects are created or deleted")
 	return cmd
 }
 
@@ -83,21 +105,138 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 	}
 	resourceKind := args[0]
 	resourceName := resourceKind
+	if resourceKind == "workload" {
+		resourceName = flags.Preset
+	}
 	if len(args) == 2 {
 		resourceName = args[1]
 	}
 
+	recordPath := scale.RecordPath(rt.GetWorkdirPath(runtime.ScalesName), resourceKind, resourceName)
+	record, err := scale.LoadRecord(recordPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if flags.Resume {
+		if record == nil {
+			return fmt.Errorf("no scale of %s %s is recorded", resourceKind, resourceName)
+		}
+		resumeFlags(flags, record)
+		logger.Info("Resuming the last scale", "resource", resourceKind, "name", resourceName, "replicas", record.Replicas, "completed", record.Completed)
+	} else if record != nil && !record.Completed {
+		logger.Warn("The last scale of the resource was not completed, it is replaced by this one", "resource", resourceKind, "name", resourceName, "replicas", record.Replicas, "interrupted", record.Interrupted)
+	}
+	record = newRecord(flags, resourceKind, resourceName)
+
+	labels, err := scale.ParseDistributions(flags.Labels)
+	if err != nil {
+		return err
+	}
+	annotations, err := scale.ParseDistributions(flags.Annotations)
+	if err != nil {
+		return err
+	}
+
+	objectSize, err := parseSize(flags.ObjectSize)
+	if err != nil {
+		return fmt.Errorf("invalid object size: %w", err)
+	}
+	maxTotalSize, err := parseSize(flags.MaxTotalSize)
+	if err != nil {
+		return fmt.Errorf("invalid max total size: %w", err)
+	}
+	err = scale.CheckTotalSize(flags.Replicas, objectSize, maxTotalSize)
+	if err != nil {
+		return err
+	}
+
 	kubeconfigPath := rt.GetWorkdirPath(runtime.InHostKubeconfigName)
 	clientset, err := client.NewClientset("", kubeconfigPath)
 	if err != nil {
 		return err
 	}
 
+	// Record the intended population before scaling, so that the scale can be resumed if it is interrupted
+	if !dryrun.DryRun {
+		err = scale.SaveRecord(recordPath, record)
+		if err != nil {
+			return fmt.Errorf("failed to record the scale: %w", err)
+		}
+	}
+
+	err = scaleResource(ctx, clientset, flags, resourceKind, resourceName, labels, annotations, int(objectSize))
+	if err != nil {
+		if signals.IsInterrupted(ctx) && !dryrun.DryRun {
+			logger.Warn("The scale is interrupted, run it again with --resume to continue", "resource", resourceKind, "name", resourceName)
+			record.Interrupted = true
+			serr := scale.SaveRecord(recordPath, record)
+			if serr != nil {
+				logger.Error("Failed to record the interrupted scale", serr)
+			}
+		}
+		return err
+	}
+
+	if !dryrun.DryRun {
+		record.Completed = true
+		err = scale.SaveRecord(recordPath, record)
+		if err != nil {
+			return fmt.Errorf("failed to record the scale: %w", err)
+		}
+	}
+	return nil
+}
+
+// resumeFlags restores the flags of the last scale from its record.
+func resumeFlags(flags *flagpole, record *scale.Record) {
+	flags.Namespace = record.Namespace
+	flags.Replicas = record.Replicas
+	flags.SerialLength = record.SerialLength
+	flags.Params = record.Params
+	flags.Preset = record.Preset
+	flags.NamePattern = record.NamePattern
+	flags.Zones = record.Zones
+	flags.Labels = record.Labels
+	flags.Annotations = record.Annotations
+	flags.ObjectSize = record.ObjectSize
+	if record.ScaleDownStrategy != "" {
+		flags.ScaleDownStrategy = record.ScaleDownStrategy
+	}
+}
+
+// newRecord returns the record of the scale of the resource with the flags,
+// which is not completed until the scale is done.
+func newRecord(flags *flagpole, resourceKind, resourceName string) *scale.Record {
+	return &scale.Record{
+		Kind:         resourceKind,
+		Name:         resourceName,
+		Namespace:    flags.Namespace,
+		Replicas:     flags.Replicas,
+		SerialLength: flags.SerialLength,
+		Params:       flags.Params,
+		Preset:       flags.Preset,
+		NamePattern:  flags.NamePattern,
+		Zones:        flags.Zones,
+		Labels:       flags.Labels,
+		Annotations:  flags.Annotations,
+		ObjectSize:   flags.ObjectSize,
+
+		ScaleDownStrategy: flags.ScaleDownStrategy,
+	}
+}
+
+func scaleResource(ctx context.Context, clientset client.Clientset, flags *flagpole, resourceKind, resourceName string, labels, annotations []scale.Distribution, objectSize int) error {
+	if resourceKind == "workload" {
+		return scaleWorkload(ctx, clientset, flags, resourceName, labels, annotations, objectSize)
+	}
+
+	logger := log.FromContext(ctx)
 	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
 	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
 		return krc.Name == resourceKind
 	})
 	if !ok {
+		var err error
 		var resourceData string
 		switch resourceKind {
 		default:
@@ -106,6 +245,14 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 			resourceData = resource.DefaultPod
 		case "node":
 			resourceData = resource.DefaultNode
+		case "deployment":
+			resourceData = resource.DefaultDeployment
+		case "statefulset":
+			resourceData = resource.DefaultStatefulSet
+		case "job":
+			resourceData = resource.DefaultJob
+		case "service":
+			resourceData = resource.DefaultService
 		}
 
 		logger.Info("No resource found, use default resource", "resource", resourceKind)
@@ -115,7 +262,23 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 		}
 	}
 
-	parameters, err := scale.NewParameters(ctx, krc.Parameters, flags.Params)
+	rawParameters := krc.Parameters
+	if flags.Preset != "" {
+		presets, err := scale.BuiltinPresets(resourceKind)
+		if err != nil {
+			return err
+		}
+		preset, err := scale.FindPreset(presets, flags.Preset)
+		if err != nil {
+			return err
+		}
+		rawParameters, err = scale.MergeParameters(rawParameters, preset.Parameters)
+		if err != nil {
+			return err
+		}
+	}
+
+	parameters, err := scale.NewParameters(ctx, rawParameters, flags.Params)
 	if err != nil {
 		return err
 	}
@@ -127,10 +290,77 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 		Namespace:    flags.Namespace,
 		Replicas:     int(flags.Replicas),
 		SerialLength: flags.SerialLength,
+		NamePattern:  flags.NamePattern,
+		Zones:        flags.Zones,
+		Labels:       labels,
+		Annotations:  annotations,
+		ObjectSize:   objectSize,
 		DryRun:       dryrun.DryRun,
+
+		ScaleDownStrategy: scale.ScaleDownStrategy(flags.ScaleDownStrategy),
 	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// scaleWorkload scales all of the resources of a workload preset with the same name.
+func scaleWorkload(ctx context.Context, clientset client.Clientset, flags *flagpole, name string, labels, annotations []scale.Distribution, objectSize int) error {
+	if flags.Preset == "" {
+		return fmt.Errorf("preset is required for workload, see 'kwokctl presets list workload'")
+	}
+
+	presets, err := scale.BuiltinPresets("workload")
+	if err != nil {
+		return err
+	}
+	preset, err := scale.FindPreset(presets, flags.Preset)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := scale.NewParameters(ctx, preset.Parameters, flags.Params)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range preset.Resources {
+		err = scale.Scale(ctx, clientset, scale.Config{
+			Parameters:   parameters,
+			Template:     r.Template,
+			Name:         name,
+			Namespace:    flags.Namespace,
+			Replicas:     int(flags.Replicas),
+			SerialLength: flags.SerialLength,
+			NamePattern:  flags.NamePattern,
+			Zones:        flags.Zones,
+			Labels:       labels,
+			Annotations:  annotations,
+			ObjectSize:   objectSize,
+			DryRun:       dryrun.DryRun,
+
+			ScaleDownStrategy: scale.ScaleDownStrategy(flags.ScaleDownStrategy),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSize parses the size in bytes from a quantity, e.g. 64Ki.
+func parseSize(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	q, err := apiresource.ParseQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+	size, ok := q.AsInt64()
+	if !ok || size < 0 {
+		return 0, fmt.Errorf("size %q is out of range", s)
+	}
+	return size, nil
+}
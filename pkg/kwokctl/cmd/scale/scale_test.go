@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+)
+
+func TestResumeFlags(t *testing.T) {
+	interrupted := &flagpole{
+		Namespace:         "default",
+		Replicas:          1000,
+		SerialLength:      6,
+		Params:            []string{".allocatable.cpu=\"8\""},
+		NamePattern:       "node-{zone}-{index:05d}",
+		Zones:             []string{"a", "b"},
+		Labels:            []string{"team=a:1,b:1"},
+		ObjectSize:        "64Ki",
+		ScaleDownStrategy: string(scale.ScaleDownByZone),
+	}
+	record := newRecord(interrupted, "node", "node")
+	record.Interrupted = true
+
+	// Only --resume is set when the interrupted scale is resumed
+	flags := &flagpole{
+		Replicas:          1,
+		SerialLength:      6,
+		MaxTotalSize:      "1Gi",
+		Resume:            true,
+		ScaleDownStrategy: string(scale.ScaleDownNewestFirst),
+	}
+	resumeFlags(flags, record)
+
+	want := *interrupted
+	want.MaxTotalSize = "1Gi"
+	want.Resume = true
+	if diff := cmp.Diff(want, *flags); diff != "" {
+		t.Errorf("resumeFlags() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The default strategy is kept for the records saved before the strategy is recorded
+	record.ScaleDownStrategy = ""
+	flags = &flagpole{ScaleDownStrategy: string(scale.ScaleDownNewestFirst)}
+	resumeFlags(flags, record)
+	if flags.ScaleDownStrategy != string(scale.ScaleDownNewestFirst) {
+		t.Errorf("ScaleDownStrategy = %q, want %q", flags.ScaleDownStrategy, scale.ScaleDownNewestFirst)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shell contains a command to spawn a subshell scoped to a cluster.
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	kwokctlruntime "sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/envs"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name  string
+	Shell string
+}
+
+var (
+	// envClusterName is the environment variable of the cluster name in the subshell.
+	envClusterName = envs.EnvPrefix + "CLUSTER_NAME"
+	// envClusterWorkdir is the environment variable of the cluster workdir in the subshell.
+	envClusterWorkdir = envs.EnvPrefix + "CLUSTER_WORKDIR"
+)
+
+// NewCommand returns a new cobra.Command for spawning a subshell scoped to a cluster.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "shell",
+		Short: "Spawn a subshell scoped to the cluster",
+		Long:  "Spawn a subshell with KUBECONFIG and the kubectl context of the cluster set, the environment is restored when the subshell exits",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Shell, "shell", "", "Shell to spawn, defaults to $SHELL")
+	return cmd
+}
+
+func defaultShell() string {
+	shell := os.Getenv("SHELL")
+	if shell != "" {
+		return shell
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	return "sh"
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := kwokctlruntime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	if current := os.Getenv(envClusterName); current != "" {
+		logger.Warn("Already in a subshell of a cluster, nesting it", "current", current)
+	}
+
+	if flags.Shell == "" {
+		flags.Shell = defaultShell()
+	}
+
+	kubeconfigPath := rt.GetWorkdirPath(kwokctlruntime.InHostKubeconfigName)
+	env := []string{
+		"KUBECONFIG=" + kubeconfigPath,
+		envClusterName + "=" + flags.Name,
+		envClusterWorkdir + "=" + workdir,
+	}
+
+	binDir := rt.GetWorkdirPath("bin")
+	if file.Exists(binDir) {
+		env = append(env, "PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	}
+
+	prompt := "(" + name + ") "
+	args, promptEnv, cleanup, err := shellPrompt(flags.Shell, prompt)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	env = append(env, promptEnv...)
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("%s %s %s", strings.Join(env, " "), flags.Shell, strings.Join(args, " "))
+		return nil
+	}
+
+	logger.Info("Entering the subshell, type 'exit' to leave", "kubeconfig", kubeconfigPath)
+	ctx = exec.WithEnv(exec.WithStdIO(ctx), env)
+	_, err = exec.Command(ctx, flags.Shell, args...)
+	if err != nil {
+		var exitErr *osexec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+	}
+	logger.Info("Left the subshell")
+	return nil
+}
+
+// shellPrompt returns the args and the environment variables to prefix the prompt of the shell,
+// and a function to clean up the temporary files.
+func shellPrompt(shell string, prompt string) (args []string, env []string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	base := strings.TrimSuffix(path.Base(shell), ".exe")
+	switch base {
+	default:
+		return nil, []string{"PS1=" + prompt + os.Getenv("PS1")}, cleanup, nil
+	case "bash", "zsh":
+	}
+
+	dir, err := os.MkdirTemp("", "kwokctl-shell-")
+	if err != nil {
+		return nil, nil, cleanup, err
+	}
+	cleanup = func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	home, _ := os.UserHomeDir()
+	switch base {
+	case "bash":
+		rcfile := path.Join(dir, ".bashrc")
+		err = os.WriteFile(rcfile, []byte(bashrc(home, prompt)), 0640)
+		if err != nil {
+			cleanup()
+			return nil, nil, func() {}, err
+		}
+		return []string{"--rcfile", rcfile}, nil, cleanup, nil
+	default: // zsh
+		zdotdir := os.Getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = home
+		}
+		rcfile := path.Join(dir, ".zshrc")
+		err = os.WriteFile(rcfile, []byte(zshrc(zdotdir, prompt)), 0640)
+		if err != nil {
+			cleanup()
+			return nil, nil, func() {}, err
+		}
+		return nil, []string{"ZDOTDIR=" + dir}, cleanup, nil
+	}
+}
+
+// bashrc returns the rc file of bash which sources the one of the user and prefixes the prompt.
+func bashrc(home string, prompt string) string {
+	rc := shellQuote(path.Join(home, ".bashrc"))
+	return fmt.Sprintf("[ -f %[1]s ] && . %[1]s\nPS1=%[2]s\"${PS1}\"\n", rc, shellQuote(prompt))
+}
+
+// zshrc returns the rc file of zsh which restores ZDOTDIR, sources the one of the user and prefixes the prompt.
+func zshrc(zdotdir string, prompt string) string {
+	rc := shellQuote(path.Join(zdotdir, ".zshrc"))
+	return fmt.Sprintf("ZDOTDIR=%[1]s\n[ -f %[2]s ] && . %[2]s\nPROMPT=%[3]s\"${PROMPT}\"\n", shellQuote(zdotdir), rc, shellQuote(prompt))
+}
+
+// shellQuote quotes the string for POSIX shells, nothing in single quotes is expanded,
+// and the single quotes themselves are closed, escaped and reopened.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"os"
+	osexec "os/exec"
+	"runtime"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: `''`},
+		{input: "/home/user", want: `'/home/user'`},
+		{input: "(kwok-$USER) ", want: `'(kwok-$USER) '`},
+		{input: "a`b`\\c", want: "'a`b`\\c'"},
+		{input: "it's", want: `'it'\''s'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := shellQuote(tt.input); got != tt.want {
+				t.Errorf("shellQuote() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBashrc(t *testing.T) {
+	got := bashrc("/home/it's", "(kwok) ")
+	want := `[ -f '/home/it'\''s/.bashrc' ] && . '/home/it'\''s/.bashrc'
+PS1='(kwok) '"${PS1}"
+`
+	if got != want {
+		t.Errorf("bashrc() = %q, want %q", got, want)
+	}
+}
+
+func TestZshrc(t *testing.T) {
+	got := zshrc("/home/$user", "(kwok) ")
+	want := `ZDOTDIR='/home/$user'
+[ -f '/home/$user/.zshrc' ] && . '/home/$user/.zshrc'
+PROMPT='(kwok) '"${PROMPT}"
+`
+	if got != want {
+		t.Errorf("zshrc() = %q, want %q", got, want)
+	}
+}
+
+// TestBashrcSourced checks that the special characters of the paths and the prompt are kept as they are by a real shell.
+func TestBashrcSourced(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the paths of bash differ on windows")
+	}
+	bash, err := osexec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	home := path.Join(t.TempDir(), "a $HOME `b` \\c 'd'")
+	err = os.MkdirAll(home, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path.Join(home, ".bashrc"), []byte("SOURCED=yes\n"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcfile := path.Join(t.TempDir(), ".bashrc")
+	prompt := "($(kwok) `kwok` \\w 'kwok') "
+	err = os.WriteFile(rcfile, []byte(bashrc(home, prompt)), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := osexec.Command(bash, "--norc", "--noprofile", "-c", `PS1="$ "; . "$1"; printf '%s|%s' "$SOURCED" "$PS1"`, "bash", rcfile)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("bash error = %v, output %s", err, out)
+	}
+	if got, want := string(out), "yes|"+prompt+"$ "; got != want {
+		t.Errorf("bash output = %q, want %q", got, want)
+	}
+}
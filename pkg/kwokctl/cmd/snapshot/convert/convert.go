@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package convert is the convert of snapshots and recordings to the API versions of a kube version
+package convert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+type flagpole struct {
+	Name        string
+	Path        string
+	Output      string
+	KubeVersion string
+}
+
+// NewCommand returns a new cobra.Command to convert the snapshot.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "convert",
+		Short: "Convert the snapshot or recording of k8s format to the API versions of a kube version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the snapshot or recording")
+	cmd.Flags().StringVar(&flags.Output, "output", "", "Path to the converted snapshot or recording")
+	cmd.Flags().StringVar(&flags.KubeVersion, "kube-version", "", "Kubernetes version to convert to, defaults to the version of the cluster")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if flags.Output == "" {
+		return fmt.Errorf("output is required")
+	}
+	var err error
+	flags.Path, err = path.Expand(flags.Path)
+	if err != nil {
+		return err
+	}
+	flags.Output, err = path.Expand(flags.Output)
+	if err != nil {
+		return err
+	}
+	if !file.Exists(flags.Path) {
+		return fmt.Errorf("path %q does not exist", flags.Path)
+	}
+	if file.Exists(flags.Output) {
+		return fmt.Errorf("file %q already exists", flags.Output)
+	}
+
+	logger := log.FromContext(ctx)
+
+	if flags.KubeVersion == "" {
+		name := config.ClusterName(flags.Name)
+		workdir := path.Join(config.ClustersDir, flags.Name)
+		logger = logger.With("cluster", flags.Name)
+		ctx = log.NewContext(ctx, logger)
+
+		rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				logger.Warn("Cluster does not exist, specify the --kube-version")
+			}
+			return err
+		}
+		conf, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		flags.KubeVersion = conf.Options.KubeVersion
+	}
+
+	converter, err := snapshot.NewConverter(flags.KubeVersion)
+	if err != nil {
+		return err
+	}
+
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Convert %s to the API versions of %s into %s", flags.Path, flags.KubeVersion, flags.Output)
+		return nil
+	}
+
+	in, err := os.Open(flags.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	reader, err := file.Decompress(flags.Path, in)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	out, err := file.Open(flags.Output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	writer := file.Compress(flags.Output, out)
+	defer func() {
+		_ = writer.Close()
+	}()
+
+	err = converter.Convert(ctx, yaml.NewDecoder(reader), yaml.NewEncoder(writer))
+	if err != nil {
+		return err
+	}
+
+	report := converter.Report()
+	for _, key := range snapshot.SortedKeys(report.Rewritten) {
+		logger.Info("Rewritten", "conversion", key, "count", report.Rewritten[key])
+	}
+	for _, key := range snapshot.SortedKeys(report.Dropped) {
+		logger.Warn("Dropped removed API", "api", key, "count", report.Dropped[key])
+	}
+	logger.Info("Converted", "kubeVersion", flags.KubeVersion, "output", flags.Output)
+	return nil
+}
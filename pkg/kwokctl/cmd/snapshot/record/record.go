@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/path"
@@ -41,6 +42,7 @@ type flagpole struct {
 	Name     string
 	Path     string
 	Snapshot bool
+	Note     string
 }
 
 // NewCommand returns a new cobra.Command for cluster recording.
@@ -59,6 +61,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the recording")
 	cmd.Flags().BoolVar(&flags.Snapshot, "snapshot", false, "Only save the snapshot")
+	cmd.Flags().StringVar(&flags.Note, "note", "", "Note of the recording, which is shown in the snapshot list")
 	return cmd
 }
 
@@ -141,8 +144,17 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	entry := snapshot.InventoryEntry{
+		Path:              flags.Path,
+		Format:            "k8s",
+		KubeVersion:       conf.Options.KubeVersion,
+		CreationTimestamp: startTime,
+		Note:              flags.Note,
+	}
+
 	if flags.Snapshot {
 		logger.Info("Saved snapshot")
+		addToInventory(ctx, rt, entry)
 		return nil
 	}
 
@@ -154,5 +166,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	entry.Format = "recording"
+	addToInventory(ctx, rt, entry)
 	return nil
 }
+
+func addToInventory(ctx context.Context, rt runtime.Runtime, entry snapshot.InventoryEntry) {
+	if rt.IsDryRun() {
+		return
+	}
+	err := snapshot.AddToInventory(rt.GetWorkdirPath(snapshot.InventoryName), entry)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to add the recording to the inventory", "error", err)
+	}
+}
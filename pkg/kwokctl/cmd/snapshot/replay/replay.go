@@ -29,13 +29,15 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
-	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/signals"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
@@ -44,6 +46,7 @@ type flagpole struct {
 	Name     string
 	Path     string
 	Snapshot bool
+	Resume   bool
 }
 
 // NewCommand returns a new cobra.Command to replay the cluster as a recording.
@@ -62,17 +65,22 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the recording")
 	cmd.Flags().BoolVar(&flags.Snapshot, "snapshot", false, "Only restore the snapshot")
+	cmd.Flags().BoolVar(&flags.Resume, "resume", false, "Resume the last interrupted replay from where it stopped, the path defaults to the one of the interrupted replay")
 	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
 	name := config.ClusterName(flags.Name)
 	workdir := path.Join(config.ClustersDir, flags.Name)
-	if flags.Path == "" {
+	if flags.Path == "" && !flags.Resume {
 		return fmt.Errorf("path is required")
 	}
-	if !file.Exists(flags.Path) {
-		return fmt.Errorf("path %q does not exist", flags.Path)
+	if flags.Path != "" {
+		p, err := path.Expand(flags.Path)
+		if err != nil {
+			return err
+		}
+		flags.Path = p
 	}
 
 	logger := log.FromContext(ctx)
@@ -87,21 +95,40 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	checkpointsDir := rt.GetWorkdirPath(runtime.CheckpointsName)
+	last, err := checkpoint.Load(checkpointsDir, checkpoint.OperationReplay)
+	if err != nil {
+		return err
+	}
+	var replayed uint64
+	if flags.Resume {
+		replayed, err = resumeReplay(flags, last)
+		if err != nil {
+			return err
+		}
+		logger.Info("Resuming the interrupted replay", "path", flags.Path, "replayed", replayed)
+	} else if last != nil {
+		logger.Warn("The last replay was interrupted, it is replaced by this one", "path", last.Path, "replayed", last.Progress)
+	}
+	if !file.Exists(flags.Path) {
+		return fmt.Errorf("path %q does not exist", flags.Path)
+	}
+
 	conf, err := rt.Config(ctx)
 	if err != nil {
 		return err
 	}
 
-	components, err := rt.ListComponents(ctx)
+	stopped, err := rt.ListComponents(ctx)
 	if err != nil {
 		return err
 	}
 
-	components = slices.Filter(components, func(component internalversion.Component) bool {
-		return component.Name != consts.ComponentKubeApiserver && component.Name != consts.ComponentEtcd
+	stopped = slices.Filter(stopped, func(component internalversion.Component) bool {
+		return !components.IsKubeApiserverComponent(component.Name) && !components.IsEtcdComponent(component.Name)
 	})
 
-	for _, component := range components {
+	for _, component := range stopped {
 		err = rt.StopComponent(ctx, component.Name)
 		if err != nil {
 			logger.Error("Failed to stop component", err,
@@ -111,7 +138,8 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 
 	defer func() {
-		for _, component := range components {
+		ctx := context.WithoutCancel(ctx)
+		for _, component := range stopped {
 			err = rt.StartComponent(ctx, component.Name)
 			if err != nil {
 				logger.Error("Failed to start component", err,
@@ -139,6 +167,9 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if err != nil {
 		return err
 	}
+	if flags.Resume {
+		loader.Resume(replayed)
+	}
 
 	f, err := os.Open(flags.Path)
 	if err != nil {
@@ -175,9 +206,15 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if err != nil {
 		return err
 	}
+	if signals.IsInterrupted(ctx) {
+		// Nothing is replayed yet, so the snapshot has to be restored again from the start
+		logger.Warn("The replay is interrupted while restoring the snapshot, run it again without --resume")
+		return nil
+	}
 
 	if flags.Snapshot {
 		logger.Info("Restored snapshot")
+		removeCheckpoint(ctx, rt, checkpointsDir)
 		return nil
 	}
 
@@ -187,9 +224,50 @@ func runE(ctx context.Context, flags *flagpole) error {
 		defer cancel()
 	}
 	err = loader.Replay(ctx, decoder)
+	if signals.IsInterrupted(ctx) {
+		logger.Warn("The replay is interrupted, the stopped components are started again, run it again with --resume to continue")
+		if !rt.IsDryRun() {
+			cerr := checkpoint.Save(checkpointsDir, &checkpoint.Checkpoint{
+				Operation:       checkpoint.OperationReplay,
+				Path:            flags.Path,
+				Progress:        loader.Replayed(),
+				InterruptedTime: time.Now(),
+			})
+			if cerr != nil {
+				logger.Error("Failed to record the interrupted replay", cerr)
+			}
+		}
+		return err
+	}
 	if err != nil {
 		return err
 	}
 
+	removeCheckpoint(ctx, rt, checkpointsDir)
 	return nil
 }
+
+// resumeReplay returns the number of the patches replayed before the replay is interrupted,
+// and defaults the path to the one of the interrupted replay.
+func resumeReplay(flags *flagpole, last *checkpoint.Checkpoint) (uint64, error) {
+	if last == nil {
+		return 0, fmt.Errorf("no interrupted replay is recorded")
+	}
+	if flags.Path != "" && flags.Path != last.Path {
+		return 0, fmt.Errorf("the interrupted replay is of %q rather than %q", last.Path, flags.Path)
+	}
+	flags.Path = last.Path
+	return last.Progress, nil
+}
+
+// removeCheckpoint removes the checkpoint of the replay once it is done.
+func removeCheckpoint(ctx context.Context, rt runtime.Runtime, checkpointsDir string) {
+	if rt.IsDryRun() {
+		return
+	}
+	err := checkpoint.Remove(checkpointsDir, checkpoint.OperationReplay)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to remove the checkpoint of the replay", "error", err)
+	}
+}
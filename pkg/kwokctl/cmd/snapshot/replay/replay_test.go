@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+)
+
+func TestResumeReplay(t *testing.T) {
+	last := &checkpoint.Checkpoint{
+		Operation: checkpoint.OperationReplay,
+		Path:      "/tmp/recording.yaml",
+		Progress:  42,
+	}
+	tests := []struct {
+		name         string
+		path         string
+		last         *checkpoint.Checkpoint
+		wantPath     string
+		wantReplayed uint64
+		wantErr      bool
+	}{
+		{name: "not interrupted", last: nil, wantErr: true},
+		{name: "default path", last: last, wantPath: "/tmp/recording.yaml", wantReplayed: 42},
+		{name: "same path", path: "/tmp/recording.yaml", last: last, wantPath: "/tmp/recording.yaml", wantReplayed: 42},
+		{name: "other path", path: "/tmp/other.yaml", last: last, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &flagpole{Path: tt.path, Resume: true}
+			got, err := resumeReplay(flags, tt.last)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resumeReplay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.wantReplayed {
+				t.Errorf("resumeReplay() = %d, want %d", got, tt.wantReplayed)
+			}
+			if flags.Path != tt.wantPath {
+				t.Errorf("flags.Path = %q, want %q", flags.Path, tt.wantPath)
+			}
+		})
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -81,8 +83,33 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	// The snapshot is restored by the runtime in its own working directory or container
+	flags.Path, err = path.Expand(flags.Path)
+	if err != nil {
+		return err
+	}
+
+	err = checkInterrupted(rt.GetWorkdirPath(runtime.CheckpointsName), flags.Path)
+	if err != nil {
+		return err
+	}
+
 	switch flags.Format {
 	case "etcd":
+		conf, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		if components.IsKineBackend(conf.Options.EtcdBackend) {
+			return fmt.Errorf("etcd format is not supported by etcd backend %q, please use k8s format instead", conf.Options.EtcdBackend)
+		}
+		if len(conf.Options.EtcdEndpoints) != 0 {
+			return fmt.Errorf("etcd format is not supported by external etcd, please use k8s format instead")
+		}
+		err = checkRestore(ctx, rt, flags.Path)
+		if err != nil {
+			return err
+		}
 		err = rt.SnapshotRestore(ctx, flags.Path)
 		if err != nil {
 			return err
@@ -99,3 +126,52 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 	return nil
 }
+
+// checkInterrupted refuses the snapshot left by an interrupted save, which may be truncated.
+func checkInterrupted(checkpointsDir string, snapshotPath string) error {
+	last, err := checkpoint.Load(checkpointsDir, checkpoint.OperationSnapshotSave)
+	if err != nil {
+		return err
+	}
+	if last != nil && last.Path == snapshotPath {
+		return fmt.Errorf("the save of snapshot %q was interrupted, %s, please save it again", snapshotPath, last.Message)
+	}
+	return nil
+}
+
+// checkRestore checks the snapshot saved from a cluster on another runtime can be restored into the cluster.
+func checkRestore(ctx context.Context, rt runtime.Runtime, snapshotPath string) error {
+	source, err := snapshot.LoadMetadata(snapshotPath)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return nil
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	target := snapshot.Metadata{
+		Runtime:     conf.Options.Runtime,
+		KubeVersion: conf.Options.KubeVersion,
+		EtcdPrefix:  conf.Options.EtcdPrefix,
+	}
+	warnings, err := snapshot.CheckRestore(*source, target)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	if source.Runtime != target.Runtime {
+		logger.Info("Restoring the snapshot saved from another runtime",
+			"from", source.Runtime,
+			"to", target.Runtime,
+		)
+	}
+	for _, warning := range warnings {
+		logger.Warn(warning)
+	}
+	return nil
+}
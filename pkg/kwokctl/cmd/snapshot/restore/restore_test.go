@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+)
+
+func TestCheckInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	err := checkInterrupted(dir, "/tmp/snapshot.db")
+	if err != nil {
+		t.Fatalf("checkInterrupted() error = %v, want nil without an interrupted save", err)
+	}
+
+	err = checkpoint.Save(dir, &checkpoint.Checkpoint{
+		Operation: checkpoint.OperationSnapshotSave,
+		Path:      "/tmp/snapshot.db",
+		Message:   "the snapshot is truncated",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = checkInterrupted(dir, "/tmp/snapshot.db")
+	if err == nil {
+		t.Errorf("checkInterrupted() error = nil, want the interrupted snapshot refused")
+	}
+	err = checkInterrupted(dir, "/tmp/other.db")
+	if err != nil {
+		t.Errorf("checkInterrupted() error = %v, want nil for another snapshot", err)
+	}
+}
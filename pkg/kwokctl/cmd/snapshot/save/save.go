@@ -22,22 +22,28 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/signals"
 )
 
 type flagpole struct {
-	Name    string
-	Path    string
-	Format  string
-	Filters []string
+	Name       string
+	Path       string
+	Format     string
+	Filters    []string
+	Note       string
+	AsTemplate bool
 }
 
 // NewCommand returns a new cobra.Command for cluster snapshotting.
@@ -56,17 +62,25 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the snapshot")
 	cmd.Flags().StringVar(&flags.Format, "format", "etcd", "Format of the snapshot file (etcd, k8s)")
 	cmd.Flags().StringSliceVar(&flags.Filters, "filter", snapshot.Resources, "Filter the resources to save, only support for k8s format")
+	cmd.Flags().StringVar(&flags.Note, "note", "", "Note of the snapshot, which is shown in the snapshot list")
+	cmd.Flags().BoolVar(&flags.AsTemplate, "as-template", false, "Save the snapshot as an etcd data template, which can be used by 'kwokctl create cluster --etcd-template', the path defaults to the template named by the kube version")
 	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
 	name := config.ClusterName(flags.Name)
 	workdir := path.Join(config.ClustersDir, flags.Name)
-	if flags.Path == "" {
-		return fmt.Errorf("path is required")
-	}
-	if file.Exists(flags.Path) {
-		return fmt.Errorf("file %q already exists", flags.Path)
+	if flags.AsTemplate {
+		if flags.Format != "etcd" {
+			return fmt.Errorf("only etcd format can be saved as a template")
+		}
+	} else {
+		if flags.Path == "" {
+			return fmt.Errorf("path is required")
+		}
+		if file.Exists(flags.Path) {
+			return fmt.Errorf("file %q already exists", flags.Path)
+		}
 	}
 
 	logger := log.FromContext(ctx)
@@ -81,21 +95,131 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	if flags.AsTemplate && flags.Path == "" {
+		conf, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		flags.Path = snapshot.TemplatePath(config.TemplatesDir, conf.Options.KubeVersion)
+		if !rt.IsDryRun() {
+			err = file.MkdirAll(config.TemplatesDir)
+			if err != nil {
+				return err
+			}
+		}
+		logger.Info("Saving the etcd data template", "path", flags.Path)
+	}
+
+	// The snapshot is saved by the runtime in its own working directory or container
+	flags.Path, err = path.Expand(flags.Path)
+	if err != nil {
+		return err
+	}
+
+	checkpointsDir := rt.GetWorkdirPath(runtime.CheckpointsName)
+	last, err := checkpoint.Load(checkpointsDir, checkpoint.OperationSnapshotSave)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		logger.Warn("The last snapshot save was interrupted", "path", last.Path, "message", last.Message)
+	}
+
 	switch flags.Format {
 	case "etcd":
+		conf, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		if components.IsKineBackend(conf.Options.EtcdBackend) {
+			return fmt.Errorf("etcd format is not supported by etcd backend %q, please use k8s format instead", conf.Options.EtcdBackend)
+		}
+		if len(conf.Options.EtcdEndpoints) != 0 {
+			return fmt.Errorf("etcd format is not supported by external etcd, please use k8s format instead")
+		}
 		err = rt.SnapshotSave(ctx, flags.Path)
 		if err != nil {
+			recordInterrupted(ctx, rt, checkpointsDir, flags.Path)
 			return err
 		}
+		if !rt.IsDryRun() {
+			conf, err := rt.Config(ctx)
+			if err != nil {
+				return err
+			}
+			err = snapshot.SaveMetadata(flags.Path, snapshot.Metadata{
+				Runtime:     conf.Options.Runtime,
+				KubeVersion: conf.Options.KubeVersion,
+				EtcdPrefix:  conf.Options.EtcdPrefix,
+			})
+			if err != nil {
+				logger.Warn("Failed to save the metadata of the snapshot", "error", err)
+			}
+		}
 	case "k8s":
 		err = rt.SnapshotSaveWithYAML(ctx, flags.Path, runtime.SnapshotSaveWithYAMLConfig{
 			Filters: flags.Filters,
 		})
 		if err != nil {
+			recordInterrupted(ctx, rt, checkpointsDir, flags.Path)
 			return err
 		}
 	default:
 		return fmt.Errorf("unsupport format %q", flags.Format)
 	}
+
+	if !rt.IsDryRun() {
+		conf, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		err = snapshot.AddToInventory(rt.GetWorkdirPath(snapshot.InventoryName), snapshot.InventoryEntry{
+			Path:              flags.Path,
+			Format:            flags.Format,
+			KubeVersion:       conf.Options.KubeVersion,
+			CreationTimestamp: time.Now(),
+			Note:              flags.Note,
+		})
+		if err != nil {
+			logger.Warn("Failed to add the snapshot to the inventory", "error", err)
+		}
+
+		// The snapshot left by the interrupted save is replaced or already removed
+		if last != nil && (last.Path == flags.Path || !file.Exists(last.Path)) {
+			err = checkpoint.Remove(checkpointsDir, checkpoint.OperationSnapshotSave)
+			if err != nil {
+				logger.Warn("Failed to remove the checkpoint of the snapshot save", "error", err)
+			}
+		}
+	}
 	return nil
 }
+
+// recordInterrupted removes the snapshot left truncated by an interrupted save,
+// and records the interrupted save so that the snapshot isn't restored by mistake later
+// if it can't be removed.
+func recordInterrupted(ctx context.Context, rt runtime.Runtime, checkpointsDir string, snapshotPath string) {
+	if rt.IsDryRun() || !signals.IsInterrupted(ctx) {
+		return
+	}
+	logger := log.FromContext(ctx)
+	message := "the snapshot is removed"
+	if file.Exists(snapshotPath) {
+		err := file.Remove(snapshotPath)
+		if err != nil {
+			logger.Error("Failed to remove the interrupted snapshot", err, "path", snapshotPath)
+			message = fmt.Sprintf("the snapshot is truncated and failed to be removed: %v", err)
+		} else {
+			logger.Warn("Removed the interrupted snapshot", "path", snapshotPath)
+		}
+	}
+	err := checkpoint.Save(checkpointsDir, &checkpoint.Checkpoint{
+		Operation:       checkpoint.OperationSnapshotSave,
+		Path:            snapshotPath,
+		Message:         message,
+		InterruptedTime: time.Now(),
+	})
+	if err != nil {
+		logger.Error("Failed to record the interrupted snapshot save", err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package save
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/checkpoint"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/signals"
+)
+
+type fakeRuntime struct {
+	runtime.Runtime
+}
+
+func (fakeRuntime) IsDryRun() bool {
+	return false
+}
+
+func TestRecordInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	checkpointsDir := path.Join(dir, runtime.CheckpointsName)
+	snapshotPath := path.Join(dir, "snapshot.db")
+	err := file.Write(snapshotPath, []byte("truncated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordInterrupted(context.Background(), fakeRuntime{}, checkpointsDir, snapshotPath)
+	if !file.Exists(snapshotPath) {
+		t.Fatalf("the snapshot is removed, want it kept if the save fails without an interrupt")
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(signals.ErrInterrupted)
+	recordInterrupted(ctx, fakeRuntime{}, checkpointsDir, snapshotPath)
+	if file.Exists(snapshotPath) {
+		t.Errorf("the interrupted snapshot is not removed")
+	}
+	last, err := checkpoint.Load(checkpointsDir, checkpoint.OperationSnapshotSave)
+	if err != nil {
+		t.Fatalf("checkpoint.Load() error = %v", err)
+	}
+	if last == nil || last.Path != snapshotPath {
+		t.Errorf("checkpoint.Load() = %+v, want the interrupted save of %q", last, snapshotPath)
+	}
+}
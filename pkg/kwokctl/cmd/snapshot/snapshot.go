@@ -22,7 +22,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/convert"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/export"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/list"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/record"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/replay"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/restore"
@@ -34,7 +36,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "snapshot [command]",
-		Short: "Snapshot [save, restore, record, replay, export] one of cluster",
+		Short: "Snapshot [save, restore, record, replay, export, list, convert] one of cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -44,5 +46,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(export.NewCommand(ctx))
 	cmd.AddCommand(replay.NewCommand(ctx))
 	cmd.AddCommand(record.NewCommand(ctx))
+	cmd.AddCommand(list.NewCommand(ctx))
+	cmd.AddCommand(convert.NewCommand(ctx))
 	return cmd
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/fleet"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
@@ -35,6 +36,8 @@ type flagpole struct {
 	Name    string
 	Wait    time.Duration
 	Timeout time.Duration
+	All     bool
+	Workers int
 }
 
 // NewCommand returns a new cobra.Command for start cluster
@@ -52,16 +55,33 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 0, "Timeout for waiting for the cluster to be started")
 	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Start all clusters managed by kwokctl")
+	cmd.Flags().IntVar(&flags.Workers, "workers", 4, "Number of clusters to start concurrently with --all")
 
 	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
-	name := config.ClusterName(flags.Name)
-	workdir := path.Join(config.ClustersDir, flags.Name)
+	if !flags.All {
+		return startCluster(ctx, flags.Name, flags)
+	}
+
+	clusters, err := runtime.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = fleet.Run(ctx, clusters, flags.Workers, func(ctx context.Context, cluster string) error {
+		return startCluster(ctx, cluster, flags)
+	})
+	return err
+}
+
+func startCluster(ctx context.Context, clusterName string, flags *flagpole) error {
+	name := config.ClusterName(clusterName)
+	workdir := path.Join(config.ClustersDir, clusterName)
 
 	logger := log.FromContext(ctx)
-	logger = logger.With("cluster", flags.Name)
+	logger = logger.With("cluster", clusterName)
 	ctx = log.NewContext(ctx, logger)
 
 	gctx := ctx
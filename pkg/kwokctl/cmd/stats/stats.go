@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stats contains a command to display the usage of the host resources by the clusters.
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+type flagpole struct {
+	Name     string
+	All      bool
+	Watch    bool
+	Interval time.Duration
+	Output   string
+}
+
+// NewCommand returns a new cobra.Command for stats
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "stats",
+		Short: "Display the usage of the host resources by the cluster",
+		Long:  "Display the CPU and memory used by the components of the cluster and the disk used by its workdir on the host, to plan the capacity of the hosts shared by the clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Display the usage of all the clusters")
+	cmd.Flags().BoolVarP(&flags.Watch, "watch", "w", false, "Keep displaying the usage every interval")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 10*time.Second, "Interval of the usage with --watch")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Output format (json), json prints a line of each cluster per interval, which can be recorded")
+	return cmd
+}
+
+// clusterStats is the usage of the host resources by a cluster.
+type clusterStats struct {
+	Time        time.Time                `json:"time"`
+	Cluster     string                   `json:"cluster"`
+	Components  []runtime.ComponentUsage `json:"components"`
+	CPUPercent  float64                  `json:"cpuPercent"`
+	MemoryBytes uint64                   `json:"memoryBytes"`
+	DiskBytes   uint64                   `json:"diskBytes"`
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Output != "" && flags.Output != "json" {
+		return fmt.Errorf("unsupported output %q", flags.Output)
+	}
+
+	clusters := []string{flags.Name}
+	if flags.All {
+		var err error
+		clusters, err = runtime.ListClusters(ctx)
+		if err != nil {
+			return err
+		}
+		if len(clusters) == 0 {
+			return fmt.Errorf("no clusters found")
+		}
+	}
+
+	rts := make([]runtime.Runtime, 0, len(clusters))
+	for _, cluster := range clusters {
+		logger := log.FromContext(ctx)
+		logger = logger.With("cluster", cluster)
+
+		rt, err := runtime.DefaultRegistry.Load(log.NewContext(ctx, logger), config.ClusterName(cluster), path.Join(config.ClustersDir, cluster))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				logger.Warn("Cluster does not exist")
+			}
+			return err
+		}
+		if rt.IsDryRun() {
+			dryrun.PrintMessage("# Display the usage of the host resources by cluster %s", cluster)
+			return nil
+		}
+		rts = append(rts, rt)
+	}
+
+	for {
+		stats := make([]clusterStats, 0, len(rts))
+		for i, rt := range rts {
+			s, err := inspect(ctx, clusters[i], rt)
+			if err != nil {
+				return fmt.Errorf("cluster %s: %w", clusters[i], err)
+			}
+			stats = append(stats, s)
+		}
+		err := printStats(flags.Output, stats)
+		if err != nil {
+			return err
+		}
+
+		if !flags.Watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(flags.Interval):
+		}
+	}
+}
+
+func inspect(ctx context.Context, cluster string, rt runtime.Runtime) (clusterStats, error) {
+	usages, err := rt.InspectUsage(ctx)
+	if err != nil {
+		return clusterStats{}, err
+	}
+	s := clusterStats{
+		Time:       time.Now(),
+		Cluster:    cluster,
+		Components: usages,
+		DiskBytes:  dirSize(rt.GetWorkdirPath("")),
+	}
+	for _, usage := range usages {
+		s.CPUPercent += usage.CPUPercent
+		s.MemoryBytes += usage.MemoryBytes
+	}
+	return s, nil
+}
+
+func printStats(output string, stats []clusterStats) error {
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		for _, s := range stats {
+			err := encoder.Encode(s)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	records := [][]string{
+		{"CLUSTER", "COMPONENT", "CPU%", "MEMORY", "DISK"},
+	}
+	for _, s := range stats {
+		for _, usage := range s.Components {
+			records = append(records, []string{s.Cluster, usage.Name, formatPercent(usage.CPUPercent), formatBytes(usage.MemoryBytes), ""})
+		}
+		records = append(records, []string{s.Cluster, "TOTAL", formatPercent(s.CPUPercent), formatBytes(s.MemoryBytes), formatBytes(s.DiskBytes)})
+	}
+	return printers.NewTablePrinter(os.Stdout).WriteAll(records)
+}
+
+// dirSize returns the size of the files in the dir, the files that can't be read are skipped.
+func dirSize(dir string) uint64 {
+	var size uint64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size
+}
+
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%.1f%%", percent)
+}
+
+func formatBytes(bytes uint64) string {
+	return fmt.Sprintf("%dMi", bytes>>20)
+}
@@ -26,13 +26,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/fleet"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 )
 
 type flagpole struct {
-	Name string
+	Name    string
+	All     bool
+	Workers int
 }
 
 // NewCommand returns a new cobra.Command for stop cluster
@@ -48,15 +51,30 @@ func NewCommand(ctx context.Context) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Stop all clusters managed by kwokctl")
+	cmd.Flags().IntVar(&flags.Workers, "workers", 4, "Number of clusters to stop concurrently with --all")
 	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
-	name := config.ClusterName(flags.Name)
-	workdir := path.Join(config.ClustersDir, flags.Name)
+	if !flags.All {
+		return stopCluster(ctx, flags.Name)
+	}
+
+	clusters, err := runtime.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = fleet.Run(ctx, clusters, flags.Workers, stopCluster)
+	return err
+}
+
+func stopCluster(ctx context.Context, clusterName string) error {
+	name := config.ClusterName(clusterName)
+	workdir := path.Join(config.ClustersDir, clusterName)
 
 	logger := log.FromContext(ctx)
-	logger = logger.With("cluster", flags.Name)
+	logger = logger.With("cluster", clusterName)
 	ctx = log.NewContext(ctx, logger)
 
 	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
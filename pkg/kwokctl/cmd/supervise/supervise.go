@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supervise contains a command to run a component and restart it when it exits.
+package supervise
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/supervisor"
+)
+
+type flagpole struct {
+	State         string
+	RestartPolicy string
+}
+
+// NewCommand returns a new cobra.Command for supervising a component.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:   cobra.MinimumNArgs(1),
+		Use:    "supervise --state <path> -- <binary> [args...]",
+		Short:  "Run a component and restart it when it exits, used by the binary runtime",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVar(&flags.State, "state", "", "Path of the file the restarts of the component are written to")
+	cmd.Flags().StringVar(&flags.RestartPolicy, "restart-policy", string(internalversion.RestartPolicyAlways), "Policy to restart the component when it exits (always, on-failure, never)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	if flags.State == "" {
+		return fmt.Errorf("--state is required")
+	}
+	restartPolicy := internalversion.RestartPolicy(flags.RestartPolicy)
+	switch restartPolicy {
+	case internalversion.RestartPolicyAlways, internalversion.RestartPolicyOnFailure, internalversion.RestartPolicyNever:
+	default:
+		return fmt.Errorf("unknown restart policy %q", flags.RestartPolicy)
+	}
+	return supervisor.Run(ctx, supervisor.Config{
+		StatePath:     flags.State,
+		Name:          args[0],
+		Args:          args[1:],
+		RestartPolicy: restartPolicy,
+	})
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package top contains a command to display the simulated resource usage of nodes or pods.
+package top
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
+)
+
+type flagpole struct {
+	Name          string
+	Namespace     string
+	AllNamespaces bool
+}
+
+// NewCommand returns a new cobra.Command for top
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "top [nodes, pods]",
+		Short: "Display the simulated resource usage of nodes or pods",
+		Long:  "Display the simulated resource usage of nodes or pods, which is read from kwok-controller directly, so metrics-server is not required",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "default", "Namespace of pods")
+	cmd.Flags().BoolVarP(&flags.AllNamespaces, "all-namespaces", "A", false, "List the pods across all namespaces")
+	return cmd
+}
+
+// resourceUsageItem is the simulated resource usage of a node or a pod, served by kwok-controller.
+type resourceUsageItem struct {
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+	Usage     map[string]float64 `json:"usage"`
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	var target string
+	switch args[0] {
+	default:
+		return fmt.Errorf("resource %s is not supported, only nodes and pods are supported", args[0])
+	case "node", "nodes", "no":
+		target = "nodes"
+	case "pod", "pods", "po":
+		target = "pods"
+	}
+
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("kubectl get --raw /api/v1/nodes/<node>/proxy/usage/%s", target)
+		return nil
+	}
+
+	clientset, err := rt.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	typedClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := typedClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		return fmt.Errorf("no nodes found")
+	}
+
+	params := map[string]string{}
+	if target == "pods" && !flags.AllNamespaces {
+		params["namespace"] = flags.Namespace
+	}
+	items, err := getResourceUsage(ctx, typedClient, nodes.Items, target, params)
+	if err != nil {
+		return err
+	}
+
+	var records [][]string
+	switch target {
+	case "nodes":
+		records = nodesRecords(items, nodes.Items)
+	case "pods":
+		records = podsRecords(items, flags.AllNamespaces)
+	}
+
+	return printers.NewTablePrinter(os.Stdout).WriteAll(records)
+}
+
+// nodesRecords returns the table of the usage of the nodes, the percentages are in the allocatable resources of the nodes.
+func nodesRecords(items []resourceUsageItem, nodes []corev1.Node) [][]string {
+	allocatable := map[string]corev1.ResourceList{}
+	for _, node := range nodes {
+		allocatable[node.Name] = node.Status.Allocatable
+	}
+	records := [][]string{
+		{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%"},
+	}
+	for _, item := range items {
+		cpu := item.Usage["cpu"]
+		memory := item.Usage["memory"]
+		nodeAllocatable := allocatable[item.Name]
+		records = append(records, []string{
+			item.Name,
+			formatCPU(cpu),
+			formatPercent(cpu, nodeAllocatable.Cpu().AsApproximateFloat64()),
+			formatMemory(memory),
+			formatPercent(memory, nodeAllocatable.Memory().AsApproximateFloat64()),
+		})
+	}
+	return records
+}
+
+// podsRecords returns the table of the usage of the pods, with the namespaces if allNamespaces is true.
+func podsRecords(items []resourceUsageItem, allNamespaces bool) [][]string {
+	if allNamespaces {
+		records := [][]string{
+			{"NAMESPACE", "NAME", "CPU(cores)", "MEMORY(bytes)"},
+		}
+		for _, item := range items {
+			records = append(records, []string{item.Namespace, item.Name, formatCPU(item.Usage["cpu"]), formatMemory(item.Usage["memory"])})
+		}
+		return records
+	}
+	records := [][]string{
+		{"NAME", "CPU(cores)", "MEMORY(bytes)"},
+	}
+	for _, item := range items {
+		records = append(records, []string{item.Name, formatCPU(item.Usage["cpu"]), formatMemory(item.Usage["memory"])})
+	}
+	return records
+}
+
+// getResourceUsage gets the simulated resource usage from kwok-controller through the node proxy of kube-apiserver.
+// Any node managed by kwok-controller can serve the usage of all nodes and pods, so the nodes are tried one by one.
+func getResourceUsage(ctx context.Context, typedClient kubernetes.Interface, nodes []corev1.Node, target string, params map[string]string) ([]resourceUsageItem, error) {
+	logger := log.FromContext(ctx)
+
+	var errs []error
+	for _, node := range nodes {
+		req := typedClient.CoreV1().RESTClient().Get().
+			Resource("nodes").
+			Name(node.Name).
+			SubResource("proxy").
+			Suffix("usage", target)
+		for key, value := range params {
+			req = req.Param(key, value)
+		}
+		data, err := req.DoRaw(ctx)
+		if err != nil {
+			logger.Debug("Failed to get resource usage", "node", node.Name, "error", err)
+			errs = append(errs, err)
+			if len(errs) >= 3 {
+				break
+			}
+			continue
+		}
+
+		var items []resourceUsageItem
+		err = json.Unmarshal(data, &items)
+		if err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("failed to get resource usage from kwok-controller: %w", errors.Join(errs...))
+}
+
+func formatCPU(cores float64) string {
+	return fmt.Sprintf("%dm", int64(cores*1000))
+}
+
+func formatMemory(bytes float64) string {
+	return fmt.Sprintf("%dMi", int64(bytes/(1024*1024)))
+}
+
+func formatPercent(usage, allocatable float64) string {
+	if allocatable == 0 {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%d%%", int64(usage/allocatable*100))
+}
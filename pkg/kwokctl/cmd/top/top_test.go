@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "cpu", got: formatCPU(1.5), want: "1500m"},
+		{name: "cpu less than 1m", got: formatCPU(0.0004), want: "0m"},
+		{name: "cpu zero", got: formatCPU(0), want: "0m"},
+		{name: "memory", got: formatMemory(256 * 1024 * 1024), want: "256Mi"},
+		{name: "memory less than 1Mi", got: formatMemory(1024), want: "0Mi"},
+		{name: "percent", got: formatPercent(1, 4), want: "25%"},
+		{name: "percent rounded down", got: formatPercent(1, 3), want: "33%"},
+		{name: "percent over allocatable", got: formatPercent(6, 4), want: "150%"},
+		{name: "percent without allocatable", got: formatPercent(1, 0), want: "<unknown>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodesRecords(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-0"},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+	items := []resourceUsageItem{
+		{Name: "node-0", Usage: map[string]float64{"cpu": 1, "memory": 512 * 1024 * 1024}},
+		// node-1 has no usage
+		{Name: "node-1"},
+		// node-2 is not listed, so its allocatable is unknown
+		{Name: "node-2", Usage: map[string]float64{"cpu": 0.5, "memory": 128 * 1024 * 1024}},
+	}
+
+	got := nodesRecords(items, nodes)
+	want := [][]string{
+		{"NAME", "CPU(cores)", "CPU%", "MEMORY(bytes)", "MEMORY%"},
+		{"node-0", "1000m", "25%", "512Mi", "50%"},
+		{"node-1", "0m", "0%", "0Mi", "0%"},
+		{"node-2", "500m", "<unknown>", "128Mi", "<unknown>"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nodesRecords() = %v, want %v", got, want)
+	}
+}
+
+func TestPodsRecords(t *testing.T) {
+	items := []resourceUsageItem{
+		{Namespace: "default", Name: "pod-a", Usage: map[string]float64{"cpu": 0.25, "memory": 64 * 1024 * 1024}},
+		{Namespace: "kube-system", Name: "pod-b"},
+	}
+
+	tests := []struct {
+		name          string
+		allNamespaces bool
+		want          [][]string
+	}{
+		{
+			name: "namespace",
+			want: [][]string{
+				{"NAME", "CPU(cores)", "MEMORY(bytes)"},
+				{"pod-a", "250m", "64Mi"},
+				{"pod-b", "0m", "0Mi"},
+			},
+		},
+		{
+			name:          "all namespaces",
+			allNamespaces: true,
+			want: [][]string{
+				{"NAMESPACE", "NAME", "CPU(cores)", "MEMORY(bytes)"},
+				{"default", "pod-a", "250m", "64Mi"},
+				{"kube-system", "pod-b", "0m", "0Mi"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podsRecords(items, tt.allNamespaces)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("podsRecords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgradecomponent contains a command to upgrade a component of a running cluster.
+package upgradecomponent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+type flagpole struct {
+	Name    string
+	Version string
+	Image   string
+	Binary  string
+}
+
+// NewCommand returns a new cobra.Command for upgrading a component of a running cluster.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "upgrade-component [component]",
+		Short: "Upgrade a component of the cluster in place",
+		Long:  "Upgrade a component of the cluster in place, only the image or binary of the component is replaced, its configuration and the state of the cluster are kept. Only kwok-controller can be upgraded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&flags.Version, "version", "", "Version of kwok to upgrade the component to, the image or binary of the version is derived from the current one")
+	cmd.Flags().StringVar(&flags.Image, "image", "", "Image to upgrade the component to, only for docker/podman/nerdctl/kind/kind-podman runtime")
+	cmd.Flags().StringVar(&flags.Binary, "binary", "", "Binary to upgrade the component to, only for binary runtime")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, componentName string) error {
+	err := runtime.CheckUpgradeComponent(componentName)
+	if err != nil {
+		return err
+	}
+	if flags.Version == "" && flags.Image == "" && flags.Binary == "" {
+		return fmt.Errorf("one of --version, --image or --binary is required")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name, "component", componentName)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	options := conf.Options
+
+	upgrade := runtime.UpgradeComponentConfig{}
+	if flags.Version != "" {
+		upgrade.Version = version.AddPrefixV(flags.Version)
+	}
+	if options.Runtime == consts.RuntimeTypeBinary {
+		upgrade.Binary = flags.Binary
+		if upgrade.Binary == "" {
+			upgrade.Binary, err = replaceVersion(options.KwokControllerBinary, options.KwokVersion, upgrade.Version)
+			if err != nil {
+				return fmt.Errorf("%w, specify the binary with --binary", err)
+			}
+		}
+	} else {
+		upgrade.Image = flags.Image
+		if upgrade.Image == "" {
+			upgrade.Image, err = replaceVersion(options.KwokControllerImage, options.KwokVersion, upgrade.Version)
+			if err != nil {
+				return fmt.Errorf("%w, specify the image with --image", err)
+			}
+		}
+	}
+
+	logger.Info("Upgrading component",
+		"version", upgrade.Version,
+		"image", upgrade.Image,
+		"binary", upgrade.Binary,
+	)
+	err = rt.UpgradeComponent(ctx, componentName, upgrade)
+	if err != nil {
+		return err
+	}
+	logger.Info("Component is upgraded")
+	return nil
+}
+
+// replaceVersion replaces the version in the image or binary of the component.
+func replaceVersion(source, oldVersion, newVersion string) (string, error) {
+	if newVersion == "" {
+		return "", fmt.Errorf("version is required")
+	}
+	if oldVersion == "" || !strings.Contains(source, oldVersion) {
+		return "", fmt.Errorf("the version %q is not found in %q", oldVersion, source)
+	}
+	return strings.ReplaceAll(source, oldVersion, newVersion), nil
+}
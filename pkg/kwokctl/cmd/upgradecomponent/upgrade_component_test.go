@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecomponent
+
+import (
+	"testing"
+)
+
+func Test_replaceVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		oldVersion string
+		newVersion string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "image",
+			source:     "registry.k8s.io/kwok/kwok:v0.5.0",
+			oldVersion: "v0.5.0",
+			newVersion: "v0.6.0",
+			want:       "registry.k8s.io/kwok/kwok:v0.6.0",
+		},
+		{
+			name:       "binary",
+			source:     "https://github.com/kubernetes-sigs/kwok/releases/download/v0.5.0/kwok-linux-amd64",
+			oldVersion: "v0.5.0",
+			newVersion: "v0.6.0",
+			want:       "https://github.com/kubernetes-sigs/kwok/releases/download/v0.6.0/kwok-linux-amd64",
+		},
+		{
+			name:       "custom image",
+			source:     "example.com/kwok:latest",
+			oldVersion: "v0.5.0",
+			newVersion: "v0.6.0",
+			wantErr:    true,
+		},
+		{
+			name:       "no version",
+			source:     "registry.k8s.io/kwok/kwok:v0.5.0",
+			oldVersion: "v0.5.0",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceVersion(tt.source, tt.oldVersion, tt.newVersion)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("replaceVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("replaceVersion() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// BuildCloudControllerManagerComponentConfig is the configuration for building an external cloud-controller-manager component.
+type BuildCloudControllerManagerComponentConfig struct {
+	Runtime          string
+	ProjectName      string
+	Binary           string
+	Image            string
+	Version          version.Version
+	Workdir          string
+	BindAddress      string
+	Port             uint32
+	CloudProvider    string
+	CaCertPath       string
+	AdminCertPath    string
+	AdminKeyPath     string
+	KubeconfigPath   string
+	KubeFeatureGates string
+	Verbosity        log.Level
+	DisableQPSLimits bool
+}
+
+// BuildCloudControllerManagerComponent builds an external cloud-controller-manager component.
+// The command of the image is kept, as it differs between the cloud providers.
+func BuildCloudControllerManagerComponent(conf BuildCloudControllerManagerComponentConfig) (component internalversion.Component, err error) {
+	if conf.CloudProvider == "" {
+		return component, fmt.Errorf("the cloud provider of cloud-controller-manager is required")
+	}
+
+	cloudControllerManagerArgs := []string{
+		"--cloud-provider=" + conf.CloudProvider,
+		// The nodes are fake, so there are no routes to configure in the cloud
+		"--configure-cloud-routes=false",
+		"--authorization-always-allow-paths=/healthz,/readyz,/livez,/metrics",
+	}
+
+	if conf.KubeFeatureGates != "" {
+		cloudControllerManagerArgs = append(cloudControllerManagerArgs,
+			"--feature-gates="+conf.KubeFeatureGates,
+		)
+	}
+
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+	var metric *internalversion.ComponentMetric
+
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.KubeconfigPath,
+				MountPath: "/root/.kube/config",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.CaCertPath,
+				MountPath: "/etc/kubernetes/pki/ca.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminCertPath,
+				MountPath: "/etc/kubernetes/pki/admin.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminKeyPath,
+				MountPath: "/etc/kubernetes/pki/admin.key",
+				ReadOnly:  true,
+			},
+		)
+		cloudControllerManagerArgs = append(cloudControllerManagerArgs,
+			"--kubeconfig=/root/.kube/config",
+			"--authentication-kubeconfig=/root/.kube/config",
+			"--authorization-kubeconfig=/root/.kube/config",
+			"--bind-address="+conf.BindAddress,
+			"--secure-port=10258",
+		)
+		if conf.Port > 0 {
+			ports = append(
+				ports,
+				internalversion.Port{
+					HostPort: conf.Port,
+					Port:     10258,
+				},
+			)
+		}
+		metric = &internalversion.ComponentMetric{
+			Scheme:             "https",
+			Host:               conf.ProjectName + "-" + consts.ComponentCloudControllerManager + ":10258",
+			Path:               "/metrics",
+			CertPath:           "/etc/kubernetes/pki/admin.crt",
+			KeyPath:            "/etc/kubernetes/pki/admin.key",
+			InsecureSkipVerify: true,
+		}
+	} else {
+		cloudControllerManagerArgs = append(cloudControllerManagerArgs,
+			"--kubeconfig="+conf.KubeconfigPath,
+			"--authentication-kubeconfig="+conf.KubeconfigPath,
+			"--authorization-kubeconfig="+conf.KubeconfigPath,
+			"--bind-address="+conf.BindAddress,
+			"--secure-port="+format.String(conf.Port),
+		)
+		metric = &internalversion.ComponentMetric{
+			Scheme:             "https",
+			Host:               net.LocalAddress + ":" + format.String(conf.Port),
+			Path:               "/metrics",
+			CertPath:           conf.AdminCertPath,
+			KeyPath:            conf.AdminKeyPath,
+			InsecureSkipVerify: true,
+		}
+	}
+
+	if conf.DisableQPSLimits {
+		cloudControllerManagerArgs = append(cloudControllerManagerArgs,
+			"--kube-api-qps="+format.String(consts.DefaultUnlimitedQPS),
+			"--kube-api-burst="+format.String(consts.DefaultUnlimitedBurst),
+		)
+	}
+
+	if conf.Verbosity != log.LevelInfo {
+		cloudControllerManagerArgs = append(cloudControllerManagerArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentCloudControllerManager,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentKubeApiserver,
+		},
+		Volumes: volumes,
+		Args:    cloudControllerManagerArgs,
+		Ports:   ports,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+		Metric:  metric,
+	}, nil
+}
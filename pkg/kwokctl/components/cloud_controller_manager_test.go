@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestBuildCloudControllerManagerComponent(t *testing.T) {
+	tests := []struct {
+		name string
+		conf BuildCloudControllerManagerComponentConfig
+		want []string
+	}{
+		{
+			name: "docker",
+			conf: BuildCloudControllerManagerComponentConfig{
+				Runtime:        consts.RuntimeTypeDocker,
+				ProjectName:    "kwok-kwok",
+				Image:          "registry.k8s.io/cloud-provider-kind/cloud-controller-manager:v0.4.0",
+				BindAddress:    "0.0.0.0",
+				Port:           10258,
+				CloudProvider:  "kind",
+				KubeconfigPath: "/workdir/kubeconfig",
+			},
+			want: []string{
+				"--cloud-provider=kind",
+				"--kubeconfig=/root/.kube/config",
+				"--secure-port=10258",
+			},
+		},
+		{
+			name: "binary",
+			conf: BuildCloudControllerManagerComponentConfig{
+				Runtime:        consts.RuntimeTypeBinary,
+				Binary:         "/workdir/bin/cloud-controller-manager",
+				BindAddress:    "127.0.0.1",
+				Port:           32768,
+				CloudProvider:  "kind",
+				KubeconfigPath: "/workdir/kubeconfig.yaml",
+			},
+			want: []string{
+				"--cloud-provider=kind",
+				"--kubeconfig=/workdir/kubeconfig.yaml",
+				"--secure-port=32768",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, err := BuildCloudControllerManagerComponent(tt.conf)
+			if err != nil {
+				t.Fatalf("BuildCloudControllerManagerComponent() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !slices.Contains(component.Args, want) {
+					t.Errorf("Args = %v, want to contain %q", component.Args, want)
+				}
+			}
+			if len(component.Command) != 0 {
+				t.Errorf("Command = %v, want the command of the image to be kept", component.Command)
+			}
+		})
+	}
+
+	_, err := BuildCloudControllerManagerComponent(BuildCloudControllerManagerComponentConfig{
+		Runtime: consts.RuntimeTypeBinary,
+	})
+	if err == nil {
+		t.Errorf("BuildCloudControllerManagerComponent() error = nil, want an error without cloud provider")
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// Compose is a compose file.
+type Compose struct {
+	Name     string                    `json:"name"`
+	Services map[string]ComposeService `json:"services"`
+	Networks map[string]ComposeNetwork `json:"networks,omitempty"`
+}
+
+// ComposeService is a service of a compose file.
+type ComposeService struct {
+	ContainerName string   `json:"container_name"`
+	Image         string   `json:"image"`
+	PullPolicy    string   `json:"pull_policy,omitempty"`
+	Entrypoint    []string `json:"entrypoint,omitempty"`
+	Command       []string `json:"command,omitempty"`
+	User          string   `json:"user,omitempty"`
+	EnvFile       []string `json:"env_file,omitempty"`
+	Environment   []string `json:"environment,omitempty"`
+	Ports         []string `json:"ports,omitempty"`
+	Volumes       []string `json:"volumes,omitempty"`
+	DependsOn     []string `json:"depends_on,omitempty"`
+	Restart       string   `json:"restart,omitempty"`
+	Cpus          string   `json:"cpus,omitempty"`
+	MemLimit      string   `json:"mem_limit,omitempty"`
+}
+
+// ComposeNetwork is a network of a compose file.
+type ComposeNetwork struct {
+	Name string `json:"name"`
+}
+
+// ConvertToCompose converts the components to a compose file of the project.
+func ConvertToCompose(project string, components []internalversion.Component) (Compose, error) {
+	c := Compose{
+		Name:     project,
+		Services: map[string]ComposeService{},
+		Networks: map[string]ComposeNetwork{
+			"default": {
+				Name: project,
+			},
+		},
+	}
+	for _, component := range components {
+		if component.Image == "" {
+			return Compose{}, fmt.Errorf("component %q has no image", component.Name)
+		}
+
+		envs := []string{}
+		for _, env := range component.Envs {
+			envs = append(envs, env.Name+"="+env.Value)
+		}
+
+		ports := []string{}
+		for _, port := range component.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = internalversion.ProtocolTCP
+			}
+			ports = append(ports, format.String(port.HostPort)+":"+format.String(port.Port)+"/"+strings.ToLower(string(protocol)))
+		}
+
+		volumes := []string{}
+		for _, volume := range component.Volumes {
+			volumes = append(volumes, VolumeBind(volume))
+		}
+
+		cpus, memory, err := ContainerResources(component)
+		if err != nil {
+			return Compose{}, err
+		}
+
+		c.Services[component.Name] = ComposeService{
+			ContainerName: project + "-" + component.Name,
+			Image:         component.Image,
+			PullPolicy:    "missing",
+			Entrypoint:    component.Command,
+			Command:       component.Args,
+			User:          component.User,
+			EnvFile:       component.EnvFiles,
+			Environment:   envs,
+			Ports:         ports,
+			Volumes:       volumes,
+			DependsOn:     component.Links,
+			Restart:       ContainerRestart(component.RestartPolicy),
+			Cpus:          cpus,
+			MemLimit:      memory,
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestConvertToCompose(t *testing.T) {
+	components := []internalversion.Component{
+		{
+			Name:    "etcd",
+			Image:   "etcd:v1",
+			Command: []string{"etcd"},
+			Args:    []string{"--name=node0"},
+
+			CPULimit:    "500m",
+			MemoryLimit: "512Mi",
+		},
+		{
+			Name:  "kube-apiserver",
+			Image: "kube-apiserver:v1",
+			Links: []string{"etcd"},
+			Envs: []internalversion.Env{
+				{Name: "k1", Value: "v1"},
+			},
+			Ports: []internalversion.Port{
+				{Port: 6443, HostPort: 32766},
+			},
+			Volumes: []internalversion.Volume{
+				{HostPath: "/tmp/pki", MountPath: "/etc/kubernetes/pki", ReadOnly: true},
+			},
+		},
+	}
+	want := Compose{
+		Name: "kwok-test",
+		Services: map[string]ComposeService{
+			"etcd": {
+				ContainerName: "kwok-test-etcd",
+				Image:         "etcd:v1",
+				PullPolicy:    "missing",
+				Entrypoint:    []string{"etcd"},
+				Command:       []string{"--name=node0"},
+				Environment:   []string{},
+				Ports:         []string{},
+				Volumes:       []string{},
+				Restart:       "unless-stopped",
+				Cpus:          "0.5",
+				MemLimit:      "536870912",
+			},
+			"kube-apiserver": {
+				ContainerName: "kwok-test-kube-apiserver",
+				Image:         "kube-apiserver:v1",
+				PullPolicy:    "missing",
+				Environment:   []string{"k1=v1"},
+				Ports:         []string{"32766:6443/tcp"},
+				Volumes:       []string{"/tmp/pki:/etc/kubernetes/pki:ro"},
+				DependsOn:     []string{"etcd"},
+				Restart:       "unless-stopped",
+			},
+		},
+		Networks: map[string]ComposeNetwork{
+			"default": {Name: "kwok-test"},
+		},
+	}
+
+	got, err := ConvertToCompose("kwok-test", components)
+	if err != nil {
+		t.Fatalf("ConvertToCompose() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertToCompose() mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = ConvertToCompose("kwok-test", []internalversion.Component{{Name: "etcd", Binary: "etcd"}})
+	if err == nil {
+		t.Errorf("ConvertToCompose() expected error for component without image")
+	}
+
+	_, err = ConvertToCompose("kwok-test", []internalversion.Component{{Name: "etcd", Image: "etcd:v1", MemoryLimit: "512MB"}})
+	if err == nil {
+		t.Errorf("ConvertToCompose() expected error for component with invalid memory limit")
+	}
+}
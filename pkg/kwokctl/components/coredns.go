@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+
+	_ "embed"
+)
+
+//go:embed coredns_corefile.tpl
+var coreDNSCorefileTpl string
+
+var coreDNSCorefileTemplate = template.Must(template.New("coredns_corefile").Parse(coreDNSCorefileTpl))
+
+// CoreDNSClusterDomain is the domain of the cluster resolved by CoreDNS.
+const CoreDNSClusterDomain = "cluster.local"
+
+// BuildCoreDNSCorefileConfig is the configuration for building the Corefile of CoreDNS.
+type BuildCoreDNSCorefileConfig struct {
+	Runtime        string
+	Port           uint32
+	KubeconfigPath string
+}
+
+// BuildCoreDNSCorefile builds the Corefile of CoreDNS, which resolves the services and pods through the kube-apiserver.
+func BuildCoreDNSCorefile(conf BuildCoreDNSCorefileConfig) (string, error) {
+	port := conf.Port
+	kubeconfigPath := conf.KubeconfigPath
+	metricsAddress := ""
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		port = 53
+		kubeconfigPath = "/root/.kube/config"
+		metricsAddress = ":9153"
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := coreDNSCorefileTemplate.Execute(buf, map[string]any{
+		"Port":           port,
+		"MetricsAddress": metricsAddress,
+		"ClusterDomain":  CoreDNSClusterDomain,
+		"KubeconfigPath": kubeconfigPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("build coredns corefile error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildCoreDNSComponentConfig is the configuration for building a CoreDNS component.
+type BuildCoreDNSComponentConfig struct {
+	Runtime        string
+	ProjectName    string
+	Binary         string
+	Image          string
+	Version        version.Version
+	Workdir        string
+	Port           uint32
+	CorefilePath   string
+	CaCertPath     string
+	AdminCertPath  string
+	AdminKeyPath   string
+	KubeconfigPath string
+}
+
+// BuildCoreDNSComponent builds a CoreDNS component.
+func BuildCoreDNSComponent(conf BuildCoreDNSComponentConfig) (component internalversion.Component, err error) {
+	var coreDNSArgs []string
+
+	user := ""
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+	var metric *internalversion.ComponentMetric
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.CorefilePath,
+				MountPath: "/etc/coredns/Corefile",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.KubeconfigPath,
+				MountPath: "/root/.kube/config",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.CaCertPath,
+				MountPath: "/etc/kubernetes/pki/ca.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminCertPath,
+				MountPath: "/etc/kubernetes/pki/admin.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminKeyPath,
+				MountPath: "/etc/kubernetes/pki/admin.key",
+				ReadOnly:  true,
+			},
+		)
+		coreDNSArgs = append(coreDNSArgs,
+			"-conf=/etc/coredns/Corefile",
+		)
+		if conf.Port != 0 {
+			ports = []internalversion.Port{
+				{
+					HostPort: conf.Port,
+					Port:     53,
+					Protocol: internalversion.ProtocolUDP,
+				},
+				{
+					HostPort: conf.Port,
+					Port:     53,
+					Protocol: internalversion.ProtocolTCP,
+				},
+			}
+		}
+		metric = &internalversion.ComponentMetric{
+			Scheme: "http",
+			Host:   conf.ProjectName + "-" + consts.ComponentCoreDNS + ":9153",
+			Path:   "/metrics",
+		}
+		user = "root"
+	} else {
+		if conf.Port == 0 {
+			return component, fmt.Errorf("the port of coredns is required by %s runtime", conf.Runtime)
+		}
+		coreDNSArgs = append(coreDNSArgs,
+			"-conf="+conf.CorefilePath,
+		)
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentCoreDNS,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentKubeApiserver,
+		},
+		Command: []string{"/coredns"},
+		User:    user,
+		Ports:   ports,
+		Volumes: volumes,
+		Args:    coreDNSArgs,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		Metric:  metric,
+		WorkDir: conf.Workdir,
+	}, nil
+}
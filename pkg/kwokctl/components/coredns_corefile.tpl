@@ -0,0 +1,16 @@
+.:{{ .Port }} {
+  errors
+{{- if .MetricsAddress }}
+  prometheus {{ .MetricsAddress }}
+{{- end }}
+  kubernetes {{ .ClusterDomain }} in-addr.arpa ip6.arpa {
+    kubeconfig {{ .KubeconfigPath }}
+    pods insecure
+    fallthrough in-addr.arpa ip6.arpa
+    ttl 30
+  }
+  forward . /etc/resolv.conf
+  cache 30
+  loop
+  loadbalance
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestBuildCoreDNSCorefile(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    BuildCoreDNSCorefileConfig
+		want    []string
+		wantNot []string
+	}{
+		{
+			name: "docker",
+			conf: BuildCoreDNSCorefileConfig{
+				Runtime:        consts.RuntimeTypeDocker,
+				Port:           1053,
+				KubeconfigPath: "/workdir/kubeconfig",
+			},
+			want: []string{
+				".:53 {",
+				"prometheus :9153",
+				"kubernetes cluster.local in-addr.arpa ip6.arpa {",
+				"kubeconfig /root/.kube/config",
+			},
+		},
+		{
+			name: "binary",
+			conf: BuildCoreDNSCorefileConfig{
+				Runtime:        consts.RuntimeTypeBinary,
+				Port:           1053,
+				KubeconfigPath: "/workdir/kubeconfig.yaml",
+			},
+			want: []string{
+				".:1053 {",
+				"kubeconfig /workdir/kubeconfig.yaml",
+			},
+			wantNot: []string{
+				"prometheus",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildCoreDNSCorefile(tt.conf)
+			if err != nil {
+				t.Fatalf("BuildCoreDNSCorefile() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("BuildCoreDNSCorefile() = %s, want to contain %q", got, want)
+				}
+			}
+			for _, wantNot := range tt.wantNot {
+				if strings.Contains(got, wantNot) {
+					t.Errorf("BuildCoreDNSCorefile() = %s, want not to contain %q", got, wantNot)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildCoreDNSComponent(t *testing.T) {
+	component, err := BuildCoreDNSComponent(BuildCoreDNSComponentConfig{
+		Runtime:      consts.RuntimeTypeDocker,
+		ProjectName:  "kwok-kwok",
+		Image:        "registry.k8s.io/coredns/coredns:v1.11.1",
+		Port:         1053,
+		CorefilePath: "/workdir/Corefile",
+	})
+	if err != nil {
+		t.Fatalf("BuildCoreDNSComponent() error = %v", err)
+	}
+	if len(component.Ports) != 2 ||
+		component.Ports[0].Protocol != internalversion.ProtocolUDP ||
+		component.Ports[1].Protocol != internalversion.ProtocolTCP {
+		t.Errorf("Ports = %v, want 1053:53 for both UDP and TCP", component.Ports)
+	}
+
+	_, err = BuildCoreDNSComponent(BuildCoreDNSComponentConfig{
+		Runtime:      consts.RuntimeTypeBinary,
+		CorefilePath: "/workdir/Corefile",
+	})
+	if err == nil {
+		t.Errorf("BuildCoreDNSComponent() error = nil, want an error for binary runtime without port")
+	}
+}
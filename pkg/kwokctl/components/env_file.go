@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+// LoadEnvFiles loads the environment variables from the env files in order.
+// The format follows the --env-file flag of the container runtimes,
+// each line is KEY=VALUE, the lines starting with # are ignored,
+// and KEY alone takes the value from the environment of kwokctl.
+func LoadEnvFiles(paths []string) ([]internalversion.Env, error) {
+	envs := []internalversion.Env{}
+	for _, p := range paths {
+		data, err := file.Read(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file %q: %w", p, err)
+		}
+		e, err := parseEnvFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse env file %q: %w", p, err)
+		}
+		envs = append(envs, e...)
+	}
+	return envs, nil
+}
+
+func parseEnvFile(data []byte) ([]internalversion.Env, error) {
+	envs := []internalversion.Env{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimLeft(scanner.Text(), " \t")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(text, "=")
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid variable name %q on line %d", name, line)
+		}
+		if !ok {
+			value, ok = os.LookupEnv(name)
+			if !ok {
+				continue
+			}
+		}
+		envs = append(envs, internalversion.Env{
+			Name:  name,
+			Value: value,
+		})
+	}
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
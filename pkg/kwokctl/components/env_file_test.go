@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestLoadEnvFiles(t *testing.T) {
+	t.Setenv("KWOK_TEST_FROM_HOST", "host")
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	err := os.WriteFile(first, []byte(`# tracing backend
+OTEL_EXPORTER_OTLP_ENDPOINT=https://otel.example.com
+
+  OTEL_EXPORTER_OTLP_HEADERS=authorization=Bearer token
+KWOK_TEST_FROM_HOST
+KWOK_TEST_NOT_SET
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := filepath.Join(dir, "second.env")
+	err = os.WriteFile(second, []byte("EMPTY=\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadEnvFiles([]string{first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []internalversion.Env{
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "https://otel.example.com"},
+		{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "authorization=Bearer token"},
+		{Name: "KWOK_TEST_FROM_HOST", Value: "host"},
+		{Name: "EMPTY", Value: ""},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadEnvFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := filepath.Join(dir, "invalid.env")
+	err = os.WriteFile(invalid, []byte("INVALID NAME=value\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadEnvFiles([]string{invalid})
+	if err == nil {
+		t.Errorf("LoadEnvFiles() expected error for invalid variable name")
+	}
+
+	_, err = LoadEnvFiles([]string{filepath.Join(dir, "not-found.env")})
+	if err == nil {
+		t.Errorf("LoadEnvFiles() expected error for missing file")
+	}
+}
@@ -18,6 +18,7 @@ package components
 
 import (
 	"runtime"
+	"strings"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/consts"
@@ -40,6 +41,35 @@ type BuildEtcdComponentConfig struct {
 	Port        uint32
 	PeerPort    uint32
 	Verbosity   log.Level
+
+	// TracingAddress is the OTLP gRPC address which the traces are sent to, the tracing is disabled if it is empty.
+	TracingAddress string
+
+	// Index is the index of the member, and Replicas is the number of the members,
+	// the members are wired with peer TLS if there are more than one.
+	Index         uint32
+	Replicas      uint32
+	CaCertPath    string
+	AdminCertPath string
+	AdminKeyPath  string
+}
+
+// EtcdComponentName returns the name of the component of the etcd member with the index,
+// the first member keeps the name of etcd, so the other components can link to it as before.
+func EtcdComponentName(index uint32) string {
+	if index == 0 {
+		return consts.ComponentEtcd
+	}
+	return consts.ComponentEtcd + "-" + format.String(index)
+}
+
+// IsEtcdComponent returns true if the component is a member of etcd.
+func IsEtcdComponent(name string) bool {
+	return name == consts.ComponentEtcd || strings.HasPrefix(name, consts.ComponentEtcd+"-")
+}
+
+func etcdMemberName(index uint32) string {
+	return "node" + format.String(index)
 }
 
 // BuildEtcdComponent builds an etcd component.
@@ -58,8 +88,10 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 	var volumes []internalversion.Volume
 	var ports []internalversion.Port
 
+	name := EtcdComponentName(conf.Index)
+
 	etcdArgs := []string{
-		"--name=node0",
+		"--name=" + etcdMemberName(conf.Index),
 		"--auto-compaction-retention=1",
 		"--quota-backend-bytes=8589934592",
 	}
@@ -96,29 +128,66 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 				},
 			)
 		}
-		etcdArgs = append(etcdArgs,
-			"--initial-advertise-peer-urls=http://"+conf.BindAddress+":2380",
-			"--listen-peer-urls=http://"+conf.BindAddress+":2380",
-			"--advertise-client-urls=http://"+conf.BindAddress+":2379",
-			"--listen-client-urls=http://"+conf.BindAddress+":2379",
-			"--initial-cluster=node0=http://"+conf.BindAddress+":2380",
-		)
+		if conf.Replicas > 1 {
+			host := conf.ProjectName + "-" + name
+			initialCluster := make([]string, 0, conf.Replicas)
+			for i := uint32(0); i < conf.Replicas; i++ {
+				initialCluster = append(initialCluster, etcdMemberName(i)+"=https://"+conf.ProjectName+"-"+EtcdComponentName(i)+":2380")
+			}
+			volumes = append(volumes,
+				internalversion.Volume{
+					HostPath:  conf.CaCertPath,
+					MountPath: "/etc/kubernetes/pki/ca.crt",
+					ReadOnly:  true,
+				},
+				internalversion.Volume{
+					HostPath:  conf.AdminCertPath,
+					MountPath: "/etc/kubernetes/pki/admin.crt",
+					ReadOnly:  true,
+				},
+				internalversion.Volume{
+					HostPath:  conf.AdminKeyPath,
+					MountPath: "/etc/kubernetes/pki/admin.key",
+					ReadOnly:  true,
+				},
+			)
+			etcdArgs = append(etcdArgs,
+				"--initial-advertise-peer-urls=https://"+host+":2380",
+				"--listen-peer-urls=https://"+net.JoinHostPort(conf.BindAddress, 2380),
+				"--advertise-client-urls=http://"+host+":2379",
+				"--listen-client-urls=http://"+net.JoinHostPort(conf.BindAddress, 2379),
+				"--initial-cluster="+strings.Join(initialCluster, ","),
+				"--initial-cluster-state=new",
+				"--initial-cluster-token="+conf.ProjectName,
+				"--peer-client-cert-auth",
+				"--peer-trusted-ca-file=/etc/kubernetes/pki/ca.crt",
+				"--peer-cert-file=/etc/kubernetes/pki/admin.crt",
+				"--peer-key-file=/etc/kubernetes/pki/admin.key",
+			)
+		} else {
+			etcdArgs = append(etcdArgs,
+				"--initial-advertise-peer-urls=http://"+net.JoinHostPort(conf.BindAddress, 2380),
+				"--listen-peer-urls=http://"+net.JoinHostPort(conf.BindAddress, 2380),
+				"--advertise-client-urls=http://"+net.JoinHostPort(conf.BindAddress, 2379),
+				"--listen-client-urls=http://"+net.JoinHostPort(conf.BindAddress, 2379),
+				"--initial-cluster=node0=http://"+net.JoinHostPort(conf.BindAddress, 2380),
+			)
+		}
 
 		metric = &internalversion.ComponentMetric{
 			Scheme: "http",
-			Host:   conf.ProjectName + "-" + consts.ComponentEtcd + ":2379",
+			Host:   conf.ProjectName + "-" + name + ":2379",
 			Path:   "/metrics",
 		}
 	} else {
-		etcdPeerPortStr := format.String(conf.PeerPort)
 		etcdClientPortStr := format.String(conf.Port)
 		etcdArgs = append(etcdArgs,
 			"--data-dir="+conf.DataPath,
-			"--initial-advertise-peer-urls=http://"+conf.BindAddress+":"+etcdPeerPortStr,
-			"--listen-peer-urls=http://"+conf.BindAddress+":"+etcdPeerPortStr,
-			"--advertise-client-urls=http://"+conf.BindAddress+":"+etcdClientPortStr,
-			"--listen-client-urls=http://"+conf.BindAddress+":"+etcdClientPortStr,
-			"--initial-cluster=node0=http://"+conf.BindAddress+":"+etcdPeerPortStr,
+			"--initial-advertise-peer-urls=http://"+net.JoinHostPort(conf.BindAddress, conf.PeerPort),
+			"--listen-peer-urls=http://"+net.JoinHostPort(conf.BindAddress, conf.PeerPort),
+			"--advertise-client-urls=http://"+net.JoinHostPort(conf.BindAddress, conf.Port),
+			"--listen-client-urls=http://"+net.JoinHostPort(conf.BindAddress, conf.Port),
+			"--initial-cluster=node0=http://"+net.JoinHostPort(conf.BindAddress, conf.PeerPort),
 		)
 
 		metric = &internalversion.ComponentMetric{
@@ -138,6 +207,15 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 		}
 	}
 
+	// The sampling follows the parent spans, so the requests traced by kube-apiserver are traced in etcd as well
+	if conf.TracingAddress != "" && conf.Version.GTE(version.NewVersion(3, 5, 0)) {
+		etcdArgs = append(etcdArgs,
+			"--experimental-enable-distributed-tracing=true",
+			"--experimental-distributed-tracing-address="+conf.TracingAddress,
+			"--experimental-distributed-tracing-service-name="+name,
+		)
+	}
+
 	envs := []internalversion.Env{}
 	if runtime.GOARCH != "amd64" {
 		envs = append(envs, internalversion.Env{
@@ -147,7 +225,7 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 	}
 
 	return internalversion.Component{
-		Name:    consts.ComponentEtcd,
+		Name:    name,
 		Version: conf.Version.String(),
 		Volumes: volumes,
 		Command: []string{consts.ComponentEtcd},
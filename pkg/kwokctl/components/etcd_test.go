@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestEtcdComponentName(t *testing.T) {
+	for index, want := range []string{"etcd", "etcd-1", "etcd-2"} {
+		got := EtcdComponentName(uint32(index))
+		if got != want {
+			t.Errorf("EtcdComponentName(%d) = %q, want %q", index, got, want)
+		}
+		if !IsEtcdComponent(got) {
+			t.Errorf("IsEtcdComponent(%q) = false, want true", got)
+		}
+	}
+	if IsEtcdComponent("etcdctl") {
+		t.Errorf("IsEtcdComponent(%q) = true, want false", "etcdctl")
+	}
+}
+
+func TestBuildEtcdComponentMembers(t *testing.T) {
+	got, err := BuildEtcdComponent(BuildEtcdComponentConfig{
+		Runtime:       consts.RuntimeTypeDocker,
+		ProjectName:   "kwok-test",
+		Image:         "etcd:v3.5.11",
+		Version:       version.NewVersion(3, 5, 11),
+		BindAddress:   "0.0.0.0",
+		Verbosity:     log.LevelInfo,
+		Index:         1,
+		Replicas:      3,
+		CaCertPath:    "/pki/ca.crt",
+		AdminCertPath: "/pki/admin.crt",
+		AdminKeyPath:  "/pki/admin.key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != "etcd-1" {
+		t.Errorf("got name %q, want %q", got.Name, "etcd-1")
+	}
+	if len(got.Ports) != 0 {
+		t.Errorf("got ports %v, want none", got.Ports)
+	}
+
+	wantArgs := []string{
+		"--name=node1",
+		"--auto-compaction-retention=1",
+		"--quota-backend-bytes=8589934592",
+		"--data-dir=/etcd-data",
+		"--initial-advertise-peer-urls=https://kwok-test-etcd-1:2380",
+		"--listen-peer-urls=https://0.0.0.0:2380",
+		"--advertise-client-urls=http://kwok-test-etcd-1:2379",
+		"--listen-client-urls=http://0.0.0.0:2379",
+		"--initial-cluster=node0=https://kwok-test-etcd:2380,node1=https://kwok-test-etcd-1:2380,node2=https://kwok-test-etcd-2:2380",
+		"--initial-cluster-state=new",
+		"--initial-cluster-token=kwok-test",
+		"--peer-client-cert-auth",
+		"--peer-trusted-ca-file=/etc/kubernetes/pki/ca.crt",
+		"--peer-cert-file=/etc/kubernetes/pki/admin.crt",
+		"--peer-key-file=/etc/kubernetes/pki/admin.key",
+	}
+	if diff := cmp.Diff(wantArgs, got.Args); diff != "" {
+		t.Errorf("args mismatch (-want +got):\n%s", diff)
+	}
+
+	wantVolumes := []internalversion.Volume{
+		{HostPath: "/pki/ca.crt", MountPath: "/etc/kubernetes/pki/ca.crt", ReadOnly: true},
+		{HostPath: "/pki/admin.crt", MountPath: "/etc/kubernetes/pki/admin.crt", ReadOnly: true},
+		{HostPath: "/pki/admin.key", MountPath: "/etc/kubernetes/pki/admin.key", ReadOnly: true},
+	}
+	if diff := cmp.Diff(wantVolumes, got.Volumes); diff != "" {
+		t.Errorf("volumes mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+//go:embed grafana_datasources.yaml.tpl
+var grafanaDatasourcesYamlTpl string
+
+var grafanaDatasourcesYamlTemplate = template.Must(template.New("grafana_datasources").Parse(grafanaDatasourcesYamlTpl))
+
+// GrafanaDashboardsProvider is the provisioning of Grafana which loads the bundled dashboards.
+//
+//go:embed grafana_dashboards.yaml
+var GrafanaDashboardsProvider string
+
+//go:embed grafana_dashboards/*.json
+var grafanaDashboards embed.FS
+
+// BuildGrafanaDatasourcesConfig is the configuration for building the datasources provisioning of Grafana.
+type BuildGrafanaDatasourcesConfig struct {
+	PrometheusURL string
+}
+
+// BuildGrafanaDatasources builds the datasources provisioning of Grafana, which uses the Prometheus of the cluster.
+func BuildGrafanaDatasources(conf BuildGrafanaDatasourcesConfig) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	err := grafanaDatasourcesYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("build grafana datasources error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// GrafanaDashboards returns the bundled dashboards of Grafana by the file names.
+func GrafanaDashboards() (map[string][]byte, error) {
+	entries, err := fs.ReadDir(grafanaDashboards, "grafana_dashboards")
+	if err != nil {
+		return nil, err
+	}
+	dashboards := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		data, err := fs.ReadFile(grafanaDashboards, "grafana_dashboards/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		dashboards[entry.Name()] = data
+	}
+	return dashboards, nil
+}
+
+// BuildGrafanaComponentConfig is the configuration for building a grafana component.
+type BuildGrafanaComponentConfig struct {
+	Runtime          string
+	Image            string
+	Version          version.Version
+	Workdir          string
+	Port             uint32
+	ProvisioningPath string
+	DashboardsPath   string
+	Verbosity        log.Level
+}
+
+// BuildGrafanaComponent builds a grafana component.
+func BuildGrafanaComponent(conf BuildGrafanaComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeContainer {
+		return component, fmt.Errorf("grafana is not supported by %s runtime", conf.Runtime)
+	}
+
+	volumes := []internalversion.Volume{
+		{
+			HostPath:  conf.ProvisioningPath,
+			MountPath: "/etc/grafana/provisioning",
+			ReadOnly:  true,
+		},
+		{
+			HostPath:  conf.DashboardsPath,
+			MountPath: "/etc/grafana/dashboards",
+			ReadOnly:  true,
+		},
+	}
+	ports := []internalversion.Port{
+		{
+			HostPort: conf.Port,
+			Port:     3000,
+		},
+	}
+
+	// The cluster is local, so the dashboards are opened without login
+	envs := []internalversion.Env{
+		{
+			Name:  "GF_AUTH_ANONYMOUS_ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "GF_AUTH_ANONYMOUS_ORG_ROLE",
+			Value: "Admin",
+		},
+		{
+			Name:  "GF_AUTH_DISABLE_LOGIN_FORM",
+			Value: "true",
+		},
+		{
+			Name:  "GF_DASHBOARDS_DEFAULT_HOME_DASHBOARD_PATH",
+			Value: "/etc/grafana/dashboards/kwok.json",
+		},
+	}
+	if conf.Verbosity != log.LevelInfo {
+		envs = append(envs, internalversion.Env{
+			Name:  "GF_LOG_LEVEL",
+			Value: log.ToLogSeverityLevel(conf.Verbosity),
+		})
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentGrafana,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentPrometheus,
+		},
+		User:    "root",
+		Ports:   ports,
+		Volumes: volumes,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+		Envs:    envs,
+	}, nil
+}
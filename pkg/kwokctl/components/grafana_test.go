@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestBuildGrafanaDatasources(t *testing.T) {
+	got, err := BuildGrafanaDatasources(BuildGrafanaDatasourcesConfig{
+		PrometheusURL: "http://kwok-kwok-prometheus:9090",
+	})
+	if err != nil {
+		t.Fatalf("BuildGrafanaDatasources() error = %v", err)
+	}
+	if !strings.Contains(got, "url: http://kwok-kwok-prometheus:9090") {
+		t.Errorf("BuildGrafanaDatasources() = %s, want the url of the prometheus", got)
+	}
+}
+
+func TestGrafanaDashboards(t *testing.T) {
+	dashboards, err := GrafanaDashboards()
+	if err != nil {
+		t.Fatalf("GrafanaDashboards() error = %v", err)
+	}
+	if _, ok := dashboards["kwok.json"]; !ok {
+		t.Errorf("GrafanaDashboards() = %v, want the home dashboard kwok.json", dashboards)
+	}
+	for name, data := range dashboards {
+		var dashboard struct {
+			UID    string `json:"uid"`
+			Panels []any  `json:"panels"`
+		}
+		err := json.Unmarshal(data, &dashboard)
+		if err != nil {
+			t.Errorf("dashboard %s is invalid: %v", name, err)
+			continue
+		}
+		if dashboard.UID == "" || len(dashboard.Panels) == 0 {
+			t.Errorf("dashboard %s has no uid or panels", name)
+		}
+	}
+}
+
+func TestBuildGrafanaComponent(t *testing.T) {
+	component, err := BuildGrafanaComponent(BuildGrafanaComponentConfig{
+		Runtime:          consts.RuntimeTypeDocker,
+		Image:            "docker.io/grafana/grafana:11.1.0",
+		Port:             3000,
+		ProvisioningPath: "/workdir/grafana/provisioning",
+		DashboardsPath:   "/workdir/grafana/dashboards",
+	})
+	if err != nil {
+		t.Fatalf("BuildGrafanaComponent() error = %v", err)
+	}
+	if len(component.Links) != 1 || component.Links[0] != consts.ComponentPrometheus {
+		t.Errorf("Links = %v, want to link to prometheus", component.Links)
+	}
+
+	_, err = BuildGrafanaComponent(BuildGrafanaComponentConfig{
+		Runtime: consts.RuntimeTypeBinary,
+		Port:    3000,
+	})
+	if err == nil {
+		t.Errorf("BuildGrafanaComponent() error = nil, want an error for binary runtime")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// RenderDOT renders the groups of components from GroupByLinks as a graph in the DOT language,
+// each group is a cluster of the graph in the order they are started, and the soft links are dashed.
+func RenderDOT(w io.Writer, groups [][]internalversion.Component) error {
+	names := groupNames(groups)
+	buf := &strings.Builder{}
+	buf.WriteString("digraph components {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	for i, group := range groups {
+		_, _ = fmt.Fprintf(buf, "  subgraph cluster_%d {\n", i)
+		_, _ = fmt.Fprintf(buf, "    label=%q;\n", fmt.Sprintf("group %d", i))
+		for _, component := range group {
+			_, _ = fmt.Fprintf(buf, "    %q;\n", component.Name)
+		}
+		buf.WriteString("  }\n")
+	}
+	for _, group := range groups {
+		for _, component := range group {
+			for _, link := range component.Links {
+				_, _ = fmt.Fprintf(buf, "  %q -> %q;\n", link, component.Name)
+			}
+			for _, link := range component.SoftLinks {
+				if names.Has(link) {
+					_, _ = fmt.Fprintf(buf, "  %q -> %q [style=dashed];\n", link, component.Name)
+				}
+			}
+		}
+	}
+	buf.WriteString("}\n")
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// RenderMermaid renders the groups of components from GroupByLinks as a Mermaid flowchart,
+// each group is a subgraph of the flowchart in the order they are started, and the soft links are dotted.
+func RenderMermaid(w io.Writer, groups [][]internalversion.Component) error {
+	names := groupNames(groups)
+	buf := &strings.Builder{}
+	buf.WriteString("flowchart LR\n")
+	for i, group := range groups {
+		_, _ = fmt.Fprintf(buf, "  subgraph group%d [\"group %d\"]\n", i, i)
+		for _, component := range group {
+			_, _ = fmt.Fprintf(buf, "    %s[\"%s\"]\n", mermaidID(component.Name), component.Name)
+		}
+		buf.WriteString("  end\n")
+	}
+	for _, group := range groups {
+		for _, component := range group {
+			for _, link := range component.Links {
+				_, _ = fmt.Fprintf(buf, "  %s --> %s\n", mermaidID(link), mermaidID(component.Name))
+			}
+			for _, link := range component.SoftLinks {
+				if names.Has(link) {
+					_, _ = fmt.Fprintf(buf, "  %s -.-> %s\n", mermaidID(link), mermaidID(component.Name))
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func groupNames(groups [][]internalversion.Component) sets.String {
+	names := sets.NewString()
+	for _, group := range groups {
+		for _, component := range group {
+			names.Insert(component.Name)
+		}
+	}
+	return names
+}
+
+// mermaidID returns the name as an ID of a node of Mermaid, which only allows letters, digits and underscores.
+func mermaidID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestRenderGraph(t *testing.T) {
+	groups := [][]internalversion.Component{
+		{
+			{Name: "etcd"},
+		},
+		{
+			{Name: "kube-apiserver", Links: []string{"etcd"}},
+		},
+		{
+			{Name: "kwok-controller", Links: []string{"kube-apiserver"}},
+		},
+	}
+
+	wantDOT := `digraph components {
+  rankdir=LR;
+  subgraph cluster_0 {
+    label="group 0";
+    "etcd";
+  }
+  subgraph cluster_1 {
+    label="group 1";
+    "kube-apiserver";
+  }
+  subgraph cluster_2 {
+    label="group 2";
+    "kwok-controller";
+  }
+  "etcd" -> "kube-apiserver";
+  "kube-apiserver" -> "kwok-controller";
+}
+`
+	buf := &bytes.Buffer{}
+	err := RenderDOT(buf, groups)
+	if err != nil {
+		t.Fatalf("RenderDOT() error = %v", err)
+	}
+	if diff := cmp.Diff(wantDOT, buf.String()); diff != "" {
+		t.Errorf("RenderDOT() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantMermaid := `flowchart LR
+  subgraph group0 ["group 0"]
+    etcd["etcd"]
+  end
+  subgraph group1 ["group 1"]
+    kube_apiserver["kube-apiserver"]
+  end
+  subgraph group2 ["group 2"]
+    kwok_controller["kwok-controller"]
+  end
+  etcd --> kube_apiserver
+  kube_apiserver --> kwok_controller
+`
+	buf.Reset()
+	err = RenderMermaid(buf, groups)
+	if err != nil {
+		t.Fatalf("RenderMermaid() error = %v", err)
+	}
+	if diff := cmp.Diff(wantMermaid, buf.String()); diff != "" {
+		t.Errorf("RenderMermaid() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,27 @@
+global
+  log stdout format raw local0
+
+defaults
+  log global
+  mode tcp
+  option tcplog
+  option redispatch
+  retries 3
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+resolvers runtime
+  parse-resolv-conf
+  hold valid 1s
+
+frontend kube-apiserver
+  bind *:{{ .Port }}
+  default_backend kube-apiservers
+
+backend kube-apiservers
+  balance roundrobin
+  default-server check inter 1s fall 2 rise 1 resolvers runtime init-addr last,libc,none
+{{- range .Servers }}
+  server {{ . }} {{ $.ProjectName }}-{{ . }}:{{ $.Port }}
+{{- end }}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+const (
+	serviceClusterIPRangeIPv4 = "10.96.0.0/12"
+	serviceClusterIPRangeIPv6 = "fd00:10:96::/112"
+	podCIDRIPv6               = "fd00:10:244::1/64"
+)
+
+// ServiceClusterIPRange returns the CIDRs of the services of the IP family,
+// it is empty for ipv4 to keep the default of the kube-apiserver.
+func ServiceClusterIPRange(ipFamily string) string {
+	switch ipFamily {
+	case consts.IPFamilyIPv6:
+		return serviceClusterIPRangeIPv6
+	case consts.IPFamilyDual:
+		return serviceClusterIPRangeIPv4 + "," + serviceClusterIPRangeIPv6
+	}
+	return ""
+}
+
+// PodCIDR returns the CIDR of the IPs of the fake pods of the IP family,
+// it is empty to keep the default of the kwok-controller unless it is ipv6,
+// the fake pods of the dual-stack cluster get the IPs of the primary family only.
+func PodCIDR(ipFamily string) string {
+	if ipFamily == consts.IPFamilyIPv6 {
+		return podCIDRIPv6
+	}
+	return ""
+}
+
+// PublicAddress returns the address of the IP family which the components in the containers bind to.
+func PublicAddress(ipFamily string) string {
+	if ipFamily == consts.IPFamilyIPv6 {
+		return net.PublicIPv6Address
+	}
+	return net.PublicAddress
+}
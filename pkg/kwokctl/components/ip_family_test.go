@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestIPFamily(t *testing.T) {
+	tests := []struct {
+		ipFamily              string
+		serviceClusterIPRange string
+		podCIDR               string
+		publicAddress         string
+	}{
+		{
+			ipFamily:      consts.IPFamilyIPv4,
+			publicAddress: "0.0.0.0",
+		},
+		{
+			ipFamily:              consts.IPFamilyIPv6,
+			serviceClusterIPRange: "fd00:10:96::/112",
+			podCIDR:               "fd00:10:244::1/64",
+			publicAddress:         "::",
+		},
+		{
+			ipFamily:              consts.IPFamilyDual,
+			serviceClusterIPRange: "10.96.0.0/12,fd00:10:96::/112",
+			publicAddress:         "0.0.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ipFamily, func(t *testing.T) {
+			if got := ServiceClusterIPRange(tt.ipFamily); got != tt.serviceClusterIPRange {
+				t.Errorf("ServiceClusterIPRange() = %q, want %q", got, tt.serviceClusterIPRange)
+			}
+			if got := PodCIDR(tt.ipFamily); got != tt.podCIDR {
+				t.Errorf("PodCIDR() = %q, want %q", got, tt.podCIDR)
+			}
+			if got := PublicAddress(tt.ipFamily); got != tt.publicAddress {
+				t.Errorf("PublicAddress() = %q, want %q", got, tt.publicAddress)
+			}
+		})
+	}
+}
+
+func TestBuildKwokControllerComponentWithIPv6(t *testing.T) {
+	component := BuildKwokControllerComponent(BuildKwokControllerComponentConfig{
+		Runtime:     consts.RuntimeTypeBinary,
+		Version:     version.NewVersion(0, 7, 0),
+		BindAddress: "::",
+		Port:        10247,
+		CIDR:        PodCIDR(consts.IPFamilyIPv6),
+	})
+	for _, want := range []string{
+		"--server-address=[::]:10247",
+		"--cidr=fd00:10:244::1/64",
+	} {
+		if !slices.Contains(component.Args, want) {
+			t.Errorf("Args = %v, want %s", component.Args, want)
+		}
+	}
+}
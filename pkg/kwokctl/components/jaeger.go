@@ -53,11 +53,11 @@ func BuildJaegerComponent(conf BuildJaegerComponentConfig) (component internalve
 			},
 		}
 		jaegerArgs = append(jaegerArgs,
-			"--query.http-server.host-port="+conf.BindAddress+":16686",
+			"--query.http-server.host-port="+net.JoinHostPort(conf.BindAddress, 16686),
 		)
 	} else {
 		jaegerArgs = append(jaegerArgs,
-			"--query.http-server.host-port="+conf.BindAddress+":"+format.String(conf.Port),
+			"--query.http-server.host-port="+net.JoinHostPort(conf.BindAddress, conf.Port),
 			"--collector.otlp.grpc.host-port="+net.LocalAddress+":"+format.String(conf.OtlpGrpcPort),
 		)
 	}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"path"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+// BuildKineComponentConfig is the configuration for building a kine component.
+type BuildKineComponentConfig struct {
+	Runtime     string
+	Binary      string
+	Image       string
+	Version     string
+	ProjectName string
+	Backend     string
+	Endpoint    string
+	DataPath    string
+	Workdir     string
+	BindAddress string
+	Port        uint32
+	Verbosity   log.Level
+}
+
+// IsKineBackend returns true if the etcd backend is served by kine.
+func IsKineBackend(backend string) bool {
+	switch backend {
+	case consts.EtcdBackendKineSqlite,
+		consts.EtcdBackendKineMysql,
+		consts.EtcdBackendKinePostgres:
+		return true
+	}
+	return false
+}
+
+// BuildKineComponent builds a kine component that serves the etcd API in place of etcd.
+func BuildKineComponent(conf BuildKineComponentConfig) (component internalversion.Component, err error) {
+	exposePort := true
+	if conf.Port == 0 {
+		conf.Port = 2379
+		exposePort = false
+	}
+
+	var volumes []internalversion.Volume
+
+	dataPath := conf.DataPath
+	inContainer := GetRuntimeMode(conf.Runtime) != RuntimeModeNative
+	if inContainer {
+		dataPath = "/kine-data"
+		// Unlike etcd, the sqlite database is kept in the workdir, so it survives the containers
+		if conf.Backend == consts.EtcdBackendKineSqlite && conf.Endpoint == "" && conf.DataPath != "" {
+			volumes = append(volumes,
+				internalversion.Volume{
+					HostPath:  conf.DataPath,
+					MountPath: dataPath,
+				},
+			)
+		}
+	}
+
+	endpoint := conf.Endpoint
+	switch conf.Backend {
+	case consts.EtcdBackendKineSqlite:
+		if endpoint == "" {
+			endpoint = "sqlite://" + path.Join(dataPath, "state.db") + "?_journal=WAL&cache=shared"
+		}
+	case consts.EtcdBackendKineMysql, consts.EtcdBackendKinePostgres:
+		if endpoint == "" {
+			return component, fmt.Errorf("etcd backend %q requires a kine endpoint", conf.Backend)
+		}
+	default:
+		return component, fmt.Errorf("unsupported etcd backend %q", conf.Backend)
+	}
+
+	var ports []internalversion.Port
+	var metric *internalversion.ComponentMetric
+
+	kineArgs := []string{
+		"--endpoint=" + endpoint,
+	}
+
+	if inContainer {
+		if exposePort {
+			ports = append(
+				ports,
+				internalversion.Port{
+					HostPort: conf.Port,
+					Port:     2379,
+				},
+			)
+		}
+		kineArgs = append(kineArgs,
+			"--listen-address="+net.JoinHostPort(conf.BindAddress, 2379),
+			"--metrics-bind-address="+net.JoinHostPort(conf.BindAddress, 8080),
+		)
+
+		metric = &internalversion.ComponentMetric{
+			Scheme: "http",
+			Host:   conf.ProjectName + "-" + consts.ComponentEtcd + ":8080",
+			Path:   "/metrics",
+		}
+	} else {
+		kineArgs = append(kineArgs,
+			"--listen-address="+net.JoinHostPort(conf.BindAddress, conf.Port),
+			// Avoid conflicts with the default metrics port when running multiple clusters.
+			"--metrics-bind-address="+net.JoinHostPort(conf.BindAddress, 0),
+		)
+	}
+
+	if conf.Verbosity <= log.LevelDebug {
+		kineArgs = append(kineArgs, "--debug")
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentEtcd,
+		Version: conf.Version,
+		Volumes: volumes,
+		Command: []string{"kine"},
+		Args:    kineArgs,
+		Binary:  conf.Binary,
+		Ports:   ports,
+		Metric:  metric,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+	}, nil
+}
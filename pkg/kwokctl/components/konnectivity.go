@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// The ports of konnectivity-server in the containers,
+// kube-apiserver connects to the frontend port and konnectivity-agent connects to the agent port.
+const (
+	konnectivityServerFrontendPort = 8131
+	konnectivityServerAgentPort    = 8132
+)
+
+// BuildKonnectivityServerComponentConfig is the configuration for building a konnectivity-server component.
+type BuildKonnectivityServerComponentConfig struct {
+	Runtime       string
+	Image         string
+	Version       version.Version
+	Workdir       string
+	BindAddress   string
+	CaCertPath    string
+	AdminCertPath string
+	AdminKeyPath  string
+	Verbosity     log.Level
+}
+
+// BuildKonnectivityServerComponent builds a konnectivity-server component,
+// which accepts the HTTP CONNECT requests of kube-apiserver and tunnels them to konnectivity-agent.
+func BuildKonnectivityServerComponent(conf BuildKonnectivityServerComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeContainer {
+		return component, fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+
+	konnectivityServerArgs := []string{
+		"--mode=http-connect",
+		"--uds-name=",
+		"--bind-address=" + conf.BindAddress,
+		"--server-port=" + format.String(konnectivityServerFrontendPort),
+		"--server-ca-cert=/etc/kubernetes/pki/ca.crt",
+		"--server-cert=/etc/kubernetes/pki/admin.crt",
+		"--server-key=/etc/kubernetes/pki/admin.key",
+		"--agent-port=" + format.String(konnectivityServerAgentPort),
+		"--cluster-ca-cert=/etc/kubernetes/pki/ca.crt",
+		"--cluster-cert=/etc/kubernetes/pki/admin.crt",
+		"--cluster-key=/etc/kubernetes/pki/admin.key",
+		"--admin-port=8133",
+		"--health-port=8134",
+	}
+	if conf.Verbosity != log.LevelInfo {
+		konnectivityServerArgs = append(konnectivityServerArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKonnectivityServer,
+		Version: conf.Version.String(),
+		Command: []string{"/proxy-server"},
+		Volumes: konnectivityPkiVolumes(conf.CaCertPath, conf.AdminCertPath, conf.AdminKeyPath),
+		Args:    konnectivityServerArgs,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+	}, nil
+}
+
+// BuildKonnectivityAgentComponentConfig is the configuration for building a konnectivity-agent component.
+type BuildKonnectivityAgentComponentConfig struct {
+	Runtime       string
+	ProjectName   string
+	Image         string
+	Version       version.Version
+	Workdir       string
+	CaCertPath    string
+	AdminCertPath string
+	AdminKeyPath  string
+	Verbosity     log.Level
+}
+
+// BuildKonnectivityAgentComponent builds a konnectivity-agent component,
+// which connects to konnectivity-server and dials the targets in the cluster, e.g. kwok-controller.
+func BuildKonnectivityAgentComponent(conf BuildKonnectivityAgentComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeContainer {
+		return component, fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+
+	konnectivityAgentArgs := []string{
+		"--proxy-server-host=" + KonnectivityServerHost(conf.ProjectName),
+		"--proxy-server-port=" + format.String(konnectivityServerAgentPort),
+		"--ca-cert=/etc/kubernetes/pki/ca.crt",
+		"--agent-cert=/etc/kubernetes/pki/admin.crt",
+		"--agent-key=/etc/kubernetes/pki/admin.key",
+		"--admin-server-port=8133",
+		"--health-server-port=8134",
+	}
+	if conf.Verbosity != log.LevelInfo {
+		konnectivityAgentArgs = append(konnectivityAgentArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKonnectivityAgent,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentKonnectivityServer,
+		},
+		Command: []string{"/proxy-agent"},
+		Volumes: konnectivityPkiVolumes(conf.CaCertPath, conf.AdminCertPath, conf.AdminKeyPath),
+		Args:    konnectivityAgentArgs,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+	}, nil
+}
+
+// KonnectivityServerHost returns the host of konnectivity-server, which is in the SANs of the certs of the cluster.
+func KonnectivityServerHost(projectName string) string {
+	return projectName + "-" + consts.ComponentKonnectivityServer
+}
+
+// KonnectivityServerURL returns the URL of konnectivity-server which kube-apiserver connects to.
+func KonnectivityServerURL(projectName string) string {
+	return "https://" + KonnectivityServerHost(projectName) + ":" + format.String(konnectivityServerFrontendPort)
+}
+
+func konnectivityPkiVolumes(caCertPath, adminCertPath, adminKeyPath string) []internalversion.Volume {
+	return []internalversion.Volume{
+		{
+			HostPath:  caCertPath,
+			MountPath: "/etc/kubernetes/pki/ca.crt",
+			ReadOnly:  true,
+		},
+		{
+			HostPath:  adminCertPath,
+			MountPath: "/etc/kubernetes/pki/admin.crt",
+			ReadOnly:  true,
+		},
+		{
+			HostPath:  adminKeyPath,
+			MountPath: "/etc/kubernetes/pki/admin.key",
+			ReadOnly:  true,
+		},
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"slices"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestBuildKonnectivityAgentComponent(t *testing.T) {
+	component, err := BuildKonnectivityAgentComponent(BuildKonnectivityAgentComponentConfig{
+		Runtime:     consts.RuntimeTypeDocker,
+		ProjectName: "kwok-kwok",
+		Image:       "registry.k8s.io/kas-network-proxy/proxy-agent:v0.30.3",
+	})
+	if err != nil {
+		t.Fatalf("BuildKonnectivityAgentComponent() error = %v", err)
+	}
+	if !slices.Contains(component.Args, "--proxy-server-host=kwok-kwok-konnectivity-server") {
+		t.Errorf("Args = %v, want to connect to konnectivity-server", component.Args)
+	}
+	if len(component.Links) != 1 || component.Links[0] != consts.ComponentKonnectivityServer {
+		t.Errorf("Links = %v, want to link to konnectivity-server", component.Links)
+	}
+
+	_, err = BuildKonnectivityServerComponent(BuildKonnectivityServerComponentConfig{
+		Runtime: consts.RuntimeTypeBinary,
+	})
+	if err == nil {
+		t.Errorf("BuildKonnectivityServerComponent() error = nil, want an error for binary runtime")
+	}
+}
+
+func TestKonnectivityServerURL(t *testing.T) {
+	got := KonnectivityServerURL("kwok-kwok")
+	want := "https://kwok-kwok-konnectivity-server:8131"
+	if got != want {
+		t.Errorf("KonnectivityServerURL() = %q, want %q", got, want)
+	}
+}
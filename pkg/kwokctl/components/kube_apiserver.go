@@ -18,6 +18,7 @@ package components
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
@@ -40,6 +41,7 @@ type BuildKubeApiserverComponentConfig struct {
 	Port              uint32
 	EtcdAddress       string
 	EtcdPort          uint32
+	EtcdReplicas      uint32
 	KubeRuntimeConfig string
 	KubeFeatureGates  string
 	SecurePort        bool
@@ -47,13 +49,72 @@ type BuildKubeApiserverComponentConfig struct {
 	KubeAdmission     bool
 	AuditPolicyPath   string
 	AuditLogPath      string
-	CaCertPath        string
-	AdminCertPath     string
-	AdminKeyPath      string
-	Verbosity         log.Level
-	DisableQPSLimits  bool
-	TracingConfigPath string
-	EtcdPrefix        string
+
+	// AuditWebhookConfigPath is the kubeconfig of the backend which the audit events are sent to,
+	// and AuditWebhookComponent is the component of the backend if it is launched with the cluster.
+	AuditWebhookConfigPath string
+	AuditWebhookComponent  string
+	CaCertPath             string
+	AdminCertPath          string
+	AdminKeyPath           string
+	Verbosity              log.Level
+	DisableQPSLimits       bool
+	TracingConfigPath      string
+	EtcdPrefix             string
+
+	// ServiceClusterIPRange is the CIDRs of the services separated by comma,
+	// the default of the kube-apiserver is kept if it is empty.
+	ServiceClusterIPRange string
+
+	// TracingComponent is the component which the traces are sent to, Jaeger is used if it is empty.
+	TracingComponent string
+
+	// EgressSelectorConfigPath is the egress selector configuration which proxies the traffic to the cluster
+	// through konnectivity-server, only the container runtime is supported.
+	EgressSelectorConfigPath string
+
+	// ExternalCloudProvider is true if the cloud provider is an external cloud-controller-manager.
+	ExternalCloudProvider bool
+
+	// EtcdEndpoints is the endpoints of an external etcd, the kube-apiserver does not link to the etcd components if set.
+	EtcdEndpoints []string
+	EtcdCaFile    string
+	EtcdCertFile  string
+	EtcdKeyFile   string
+
+	// Index is the index of the kube-apiserver, the kube-apiservers beyond the first one
+	// are not exposed to the host but reached through the load balancer.
+	Index uint32
+}
+
+// KubeApiserverComponentName returns the name of the component of the kube-apiserver with the index,
+// the first one keeps the name of kube-apiserver, so the other components can link to it as before.
+func KubeApiserverComponentName(index uint32) string {
+	if index == 0 {
+		return consts.ComponentKubeApiserver
+	}
+	return consts.ComponentKubeApiserver + "-" + format.String(index)
+}
+
+// IsKubeApiserverComponent returns true if the component is a kube-apiserver or the load balancer of them.
+func IsKubeApiserverComponent(name string) bool {
+	if name == consts.ComponentKubeApiserver || name == consts.ComponentKubeApiserverLoadBalancer {
+		return true
+	}
+	index, ok := strings.CutPrefix(name, consts.ComponentKubeApiserver+"-")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(index, 10, 32)
+	return err == nil
+}
+
+// kubeApiserverContainerPort returns the port of the kube-apiserver in the container.
+func kubeApiserverContainerPort(securePort bool) uint32 {
+	if securePort {
+		return 6443
+	}
+	return 8080
 }
 
 // BuildKubeApiserverComponent builds a kube-apiserver component.
@@ -84,6 +145,19 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		)
 	}
 
+	if conf.ServiceClusterIPRange != "" {
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--service-cluster-ip-range="+conf.ServiceClusterIPRange,
+		)
+	}
+
+	// The --cloud-provider of kube-apiserver is removed in 1.33.0
+	if conf.ExternalCloudProvider && conf.Version.LT(version.NewVersion(1, 33, 0)) {
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--cloud-provider=external",
+		)
+	}
+
 	var featureGates []string
 	if conf.KubeFeatureGates != "" {
 		featureGates = append(featureGates, strings.Split(conf.KubeFeatureGates, ",")...)
@@ -121,9 +195,44 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 	var volumes []internalversion.Volume
 	var metric *internalversion.ComponentMetric
 
-	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+	name := KubeApiserverComponentName(conf.Index)
+
+	if len(conf.EtcdEndpoints) != 0 {
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--etcd-servers="+strings.Join(conf.EtcdEndpoints, ","),
+		)
+		for _, file := range []struct {
+			flag      string
+			hostPath  string
+			mountPath string
+		}{
+			{flag: "--etcd-cafile", hostPath: conf.EtcdCaFile, mountPath: "/etc/kubernetes/pki/etcd/ca.crt"},
+			{flag: "--etcd-certfile", hostPath: conf.EtcdCertFile, mountPath: "/etc/kubernetes/pki/etcd/client.crt"},
+			{flag: "--etcd-keyfile", hostPath: conf.EtcdKeyFile, mountPath: "/etc/kubernetes/pki/etcd/client.key"},
+		} {
+			if file.hostPath == "" {
+				continue
+			}
+			if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+				volumes = append(volumes,
+					internalversion.Volume{
+						HostPath:  file.hostPath,
+						MountPath: file.mountPath,
+						ReadOnly:  true,
+					},
+				)
+				kubeApiserverArgs = append(kubeApiserverArgs, file.flag+"="+file.mountPath)
+			} else {
+				kubeApiserverArgs = append(kubeApiserverArgs, file.flag+"="+file.hostPath)
+			}
+		}
+	} else if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		etcdServers := []string{"http://" + conf.EtcdAddress + ":2379"}
+		for i := uint32(1); i < conf.EtcdReplicas; i++ {
+			etcdServers = append(etcdServers, "http://"+conf.ProjectName+"-"+EtcdComponentName(i)+":2379")
+		}
 		kubeApiserverArgs = append(kubeApiserverArgs,
-			"--etcd-servers=http://"+conf.EtcdAddress+":2379",
+			"--etcd-servers="+strings.Join(etcdServers, ","),
 		)
 	} else {
 		kubeApiserverArgs = append(kubeApiserverArgs,
@@ -139,11 +248,13 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		}
 
 		if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
-			ports = []internalversion.Port{
-				{
-					HostPort: conf.Port,
-					Port:     6443,
-				},
+			if conf.Port != 0 {
+				ports = []internalversion.Port{
+					{
+						HostPort: conf.Port,
+						Port:     6443,
+					},
+				}
 			}
 			volumes = append(volumes,
 				internalversion.Volume{
@@ -176,7 +287,7 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 			)
 			metric = &internalversion.ComponentMetric{
 				Scheme:             "https",
-				Host:               conf.ProjectName + "-" + consts.ComponentKubeApiserver + ":6443",
+				Host:               conf.ProjectName + "-" + name + ":6443",
 				Path:               "/metrics",
 				CertPath:           "/etc/kubernetes/pki/admin.crt",
 				KeyPath:            "/etc/kubernetes/pki/admin.key",
@@ -206,11 +317,13 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		}
 	} else {
 		if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
-			ports = []internalversion.Port{
-				{
-					HostPort: conf.Port,
-					Port:     8080,
-				},
+			if conf.Port != 0 {
+				ports = []internalversion.Port{
+					{
+						HostPort: conf.Port,
+						Port:     8080,
+					},
+				}
 			}
 
 			kubeApiserverArgs = append(kubeApiserverArgs,
@@ -219,7 +332,7 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 			)
 			metric = &internalversion.ComponentMetric{
 				Scheme: "http",
-				Host:   conf.ProjectName + "-" + consts.ComponentKubeApiserver + ":8080",
+				Host:   conf.ProjectName + "-" + name + ":8080",
 				Path:   "/metrics",
 			}
 		} else {
@@ -259,6 +372,25 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 				"--audit-log-path="+conf.AuditLogPath,
 			)
 		}
+
+		if conf.AuditWebhookConfigPath != "" {
+			if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+				volumes = append(volumes,
+					internalversion.Volume{
+						HostPath:  conf.AuditWebhookConfigPath,
+						MountPath: "/etc/kubernetes/audit-webhook.yaml",
+						ReadOnly:  true,
+					},
+				)
+				kubeApiserverArgs = append(kubeApiserverArgs,
+					"--audit-webhook-config-file=/etc/kubernetes/audit-webhook.yaml",
+				)
+			} else {
+				kubeApiserverArgs = append(kubeApiserverArgs,
+					"--audit-webhook-config-file="+conf.AuditWebhookConfigPath,
+				)
+			}
+		}
 	}
 
 	if conf.TracingConfigPath != "" {
@@ -280,19 +412,51 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		}
 	}
 
+	if conf.EgressSelectorConfigPath != "" {
+		if GetRuntimeMode(conf.Runtime) != RuntimeModeContainer {
+			return component, fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+		}
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.EgressSelectorConfigPath,
+				MountPath: "/etc/kubernetes/egress-selector-config.yaml",
+				ReadOnly:  true,
+			},
+		)
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--egress-selector-config-file=/etc/kubernetes/egress-selector-config.yaml",
+		)
+	}
+
 	if conf.Verbosity != log.LevelInfo {
 		kubeApiserverArgs = append(kubeApiserverArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
 	}
 
 	envs := []internalversion.Env{}
 
-	links := []string{consts.ComponentEtcd}
+	var links []string
+	if len(conf.EtcdEndpoints) == 0 {
+		links = append(links, consts.ComponentEtcd)
+		for i := uint32(1); i < conf.EtcdReplicas; i++ {
+			links = append(links, EtcdComponentName(i))
+		}
+	}
+	if conf.AuditWebhookComponent != "" {
+		links = append(links, conf.AuditWebhookComponent)
+	}
 	if conf.TracingConfigPath != "" {
-		links = append(links, consts.ComponentJaeger)
+		if conf.TracingComponent != "" {
+			links = append(links, conf.TracingComponent)
+		} else {
+			links = append(links, consts.ComponentJaeger)
+		}
+	}
+	if conf.EgressSelectorConfigPath != "" {
+		links = append(links, consts.ComponentKonnectivityServer)
 	}
 
 	return internalversion.Component{
-		Name:    consts.ComponentKubeApiserver,
+		Name:    name,
 		Version: conf.Version.String(),
 		Links:   links,
 		Command: []string{consts.ComponentKubeApiserver},
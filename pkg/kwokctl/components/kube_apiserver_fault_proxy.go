@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwok/faultproxy"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// BuildKubeApiserverFaultProxyComponentConfig is the configuration for building the fault proxy of the kube-apiserver.
+type BuildKubeApiserverFaultProxyComponentConfig struct {
+	Runtime        string
+	ProjectName    string
+	Binary         string
+	Image          string
+	Version        version.Version
+	Workdir        string
+	BindAddress    string
+	Port           uint32
+	Faults         []string
+	CaCertPath     string
+	AdminCertPath  string
+	AdminKeyPath   string
+	KubeconfigPath string
+	Verbosity      log.Level
+}
+
+// BuildKubeApiserverFaultProxyComponent builds the fault proxy of the kube-apiserver,
+// which is the fault-proxy command of kwok injecting latency, errors and throttling into the requests.
+func BuildKubeApiserverFaultProxyComponent(conf BuildKubeApiserverFaultProxyComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) == RuntimeModeCluster {
+		return component, fmt.Errorf("the fault proxy of kube-apiserver is not supported by %s runtime", conf.Runtime)
+	}
+
+	_, err = faultproxy.ParseRules(conf.Faults)
+	if err != nil {
+		return component, err
+	}
+
+	faultProxyArgs := []string{
+		"fault-proxy",
+	}
+	for _, fault := range conf.Faults {
+		faultProxyArgs = append(faultProxyArgs, "--fault="+fault)
+	}
+
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.KubeconfigPath,
+				MountPath: "/root/.kube/config",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.CaCertPath,
+				MountPath: "/etc/kubernetes/pki/ca.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminCertPath,
+				MountPath: "/etc/kubernetes/pki/admin.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminKeyPath,
+				MountPath: "/etc/kubernetes/pki/admin.key",
+				ReadOnly:  true,
+			},
+		)
+		faultProxyArgs = append(faultProxyArgs,
+			"--kubeconfig=/root/.kube/config",
+			"--address="+net.JoinHostPort(conf.BindAddress, 8001),
+		)
+		ports = []internalversion.Port{
+			{
+				HostPort: conf.Port,
+				Port:     8001,
+			},
+		}
+	} else {
+		faultProxyArgs = append(faultProxyArgs,
+			"--kubeconfig="+conf.KubeconfigPath,
+			"--address="+net.JoinHostPort(conf.BindAddress, conf.Port),
+		)
+	}
+
+	if conf.Verbosity != log.LevelInfo {
+		faultProxyArgs = append(faultProxyArgs, "--v="+format.String(conf.Verbosity))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKubeApiserverFaultProxy,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentKubeApiserver,
+		},
+		Command: []string{"kwok"},
+		Volumes: volumes,
+		Args:    faultProxyArgs,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		Ports:   ports,
+		WorkDir: conf.Workdir,
+	}, nil
+}
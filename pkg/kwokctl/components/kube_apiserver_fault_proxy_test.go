@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestBuildKubeApiserverFaultProxyComponent(t *testing.T) {
+	component, err := BuildKubeApiserverFaultProxyComponent(BuildKubeApiserverFaultProxyComponentConfig{
+		Runtime:        consts.RuntimeTypeBinary,
+		Binary:         "/bin/kwok",
+		BindAddress:    "127.0.0.1",
+		Port:           8001,
+		Faults:         []string{"verb=list,resource=pods,throttle-rate=0.5", "delay=100ms"},
+		KubeconfigPath: "/workdir/kubeconfig.yaml",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverFaultProxyComponent() error = %v", err)
+	}
+	want := []string{
+		"fault-proxy",
+		"--fault=verb=list,resource=pods,throttle-rate=0.5",
+		"--fault=delay=100ms",
+		"--kubeconfig=/workdir/kubeconfig.yaml",
+		"--address=127.0.0.1:8001",
+	}
+	if diff := cmp.Diff(want, component.Args); diff != "" {
+		t.Errorf("Args mismatch (-want +got):\n%s", diff)
+	}
+
+	component, err = BuildKubeApiserverFaultProxyComponent(BuildKubeApiserverFaultProxyComponentConfig{
+		Runtime:        consts.RuntimeTypeDocker,
+		Image:          "registry.k8s.io/kwok/kwok:v0.6.0",
+		BindAddress:    "0.0.0.0",
+		Port:           32766,
+		KubeconfigPath: "/workdir/kubeconfig",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverFaultProxyComponent() error = %v", err)
+	}
+	if len(component.Ports) != 1 || component.Ports[0].HostPort != 32766 || component.Ports[0].Port != 8001 {
+		t.Errorf("Ports = %v, want 32766:8001", component.Ports)
+	}
+
+	_, err = BuildKubeApiserverFaultProxyComponent(BuildKubeApiserverFaultProxyComponentConfig{
+		Runtime: consts.RuntimeTypeKind,
+	})
+	if err == nil {
+		t.Errorf("BuildKubeApiserverFaultProxyComponent() error = nil, want an error for kind runtime")
+	}
+
+	_, err = BuildKubeApiserverFaultProxyComponent(BuildKubeApiserverFaultProxyComponentConfig{
+		Runtime: consts.RuntimeTypeBinary,
+		Faults:  []string{"error-rate=2"},
+	})
+	if err == nil {
+		t.Errorf("BuildKubeApiserverFaultProxyComponent() error = nil, want an error for invalid fault")
+	}
+}
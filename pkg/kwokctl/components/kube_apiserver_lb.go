@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+
+	_ "embed"
+)
+
+//go:embed haproxy_config.cfg.tpl
+var haproxyConfigTpl string
+
+var haproxyConfigTemplate = template.Must(template.New("haproxy_config").Parse(haproxyConfigTpl))
+
+// BuildKubeApiserverLoadBalancerConfigConfig is the configuration for building the config of the load balancer of the kube-apiservers.
+type BuildKubeApiserverLoadBalancerConfigConfig struct {
+	ProjectName string
+	Replicas    uint32
+	SecurePort  bool
+}
+
+// BuildKubeApiserverLoadBalancerConfig builds the haproxy config of the load balancer of the kube-apiservers.
+func BuildKubeApiserverLoadBalancerConfig(conf BuildKubeApiserverLoadBalancerConfigConfig) (string, error) {
+	servers := make([]string, 0, conf.Replicas)
+	for i := uint32(0); i < max(conf.Replicas, 1); i++ {
+		servers = append(servers, KubeApiserverComponentName(i))
+	}
+	buf := bytes.NewBuffer(nil)
+	err := haproxyConfigTemplate.Execute(buf, map[string]any{
+		"ProjectName": conf.ProjectName,
+		"Port":        kubeApiserverContainerPort(conf.SecurePort),
+		"Servers":     servers,
+	})
+	if err != nil {
+		return "", fmt.Errorf("build haproxy config error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildKubeApiserverLoadBalancerComponentConfig is the configuration for building the load balancer of the kube-apiservers.
+type BuildKubeApiserverLoadBalancerComponentConfig struct {
+	Runtime    string
+	Image      string
+	Version    string
+	Workdir    string
+	Port       uint32
+	SecurePort bool
+	Replicas   uint32
+	ConfigPath string
+}
+
+// BuildKubeApiserverLoadBalancerComponent builds the load balancer of the kube-apiservers,
+// which is haproxy forwarding the connections to the kube-apiservers that pass the health checks.
+func BuildKubeApiserverLoadBalancerComponent(conf BuildKubeApiserverLoadBalancerComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) == RuntimeModeNative {
+		return component, fmt.Errorf("the load balancer of kube-apiservers is not supported by %s runtime", conf.Runtime)
+	}
+
+	port := kubeApiserverContainerPort(conf.SecurePort)
+	links := make([]string, 0, conf.Replicas)
+	for i := uint32(0); i < max(conf.Replicas, 1); i++ {
+		links = append(links, KubeApiserverComponentName(i))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKubeApiserverLoadBalancer,
+		Version: conf.Version,
+		Links:   links,
+		Command: []string{"haproxy"},
+		Args: []string{
+			"-f",
+			"/usr/local/etc/haproxy/haproxy.cfg",
+		},
+		Ports: []internalversion.Port{
+			{
+				HostPort: conf.Port,
+				Port:     port,
+			},
+		},
+		Volumes: []internalversion.Volume{
+			{
+				HostPath:  conf.ConfigPath,
+				MountPath: "/usr/local/etc/haproxy/haproxy.cfg",
+				ReadOnly:  true,
+			},
+		},
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+	}, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestIsKubeApiserverComponent(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: consts.ComponentKubeApiserver, want: true},
+		{name: KubeApiserverComponentName(2), want: true},
+		{name: consts.ComponentKubeApiserverLoadBalancer, want: true},
+		{name: consts.ComponentKubeApiserverInsecureProxy, want: false},
+		{name: consts.ComponentKubeApiserverFaultProxy, want: false},
+		{name: consts.ComponentEtcd, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsKubeApiserverComponent(tt.name); got != tt.want {
+				t.Errorf("IsKubeApiserverComponent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildKubeApiserverLoadBalancerConfig(t *testing.T) {
+	got, err := BuildKubeApiserverLoadBalancerConfig(BuildKubeApiserverLoadBalancerConfigConfig{
+		ProjectName: "kwok-kwok",
+		Replicas:    3,
+		SecurePort:  true,
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverLoadBalancerConfig() error = %v", err)
+	}
+	for _, want := range []string{
+		"bind *:6443",
+		"server kube-apiserver kwok-kwok-kube-apiserver:6443",
+		"server kube-apiserver-1 kwok-kwok-kube-apiserver-1:6443",
+		"server kube-apiserver-2 kwok-kwok-kube-apiserver-2:6443",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("BuildKubeApiserverLoadBalancerConfig() = %s, want to contain %q", got, want)
+		}
+	}
+}
+
+func TestBuildKubeApiserverLoadBalancerComponent(t *testing.T) {
+	component, err := BuildKubeApiserverLoadBalancerComponent(BuildKubeApiserverLoadBalancerComponentConfig{
+		Runtime:    consts.RuntimeTypeDocker,
+		Image:      "docker.io/library/haproxy:3.0.2",
+		Port:       32766,
+		SecurePort: true,
+		Replicas:   2,
+		ConfigPath: "/workdir/haproxy.cfg",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverLoadBalancerComponent() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"kube-apiserver", "kube-apiserver-1"}, component.Links); diff != "" {
+		t.Errorf("Links mismatch (-want +got):\n%s", diff)
+	}
+	if len(component.Ports) != 1 || component.Ports[0].HostPort != 32766 || component.Ports[0].Port != 6443 {
+		t.Errorf("Ports = %v, want 32766:6443", component.Ports)
+	}
+
+	_, err = BuildKubeApiserverLoadBalancerComponent(BuildKubeApiserverLoadBalancerComponentConfig{
+		Runtime:  consts.RuntimeTypeBinary,
+		Replicas: 2,
+	})
+	if err == nil {
+		t.Errorf("BuildKubeApiserverLoadBalancerComponent() error = nil, want an error for binary runtime")
+	}
+}
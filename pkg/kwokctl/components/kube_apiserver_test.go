@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestBuildKubeApiserverComponentWithExternalEtcd(t *testing.T) {
+	tests := []struct {
+		name        string
+		runtime     string
+		wantArgs    []string
+		wantVolumes []internalversion.Volume
+	}{
+		{
+			name:    "binary",
+			runtime: consts.RuntimeTypeBinary,
+			wantArgs: []string{
+				"--etcd-servers=https://10.0.0.1:2379,https://10.0.0.2:2379",
+				"--etcd-cafile=/certs/ca.crt",
+				"--etcd-certfile=/certs/client.crt",
+				"--etcd-keyfile=/certs/client.key",
+			},
+		},
+		{
+			name:    "docker",
+			runtime: consts.RuntimeTypeDocker,
+			wantArgs: []string{
+				"--etcd-servers=https://10.0.0.1:2379,https://10.0.0.2:2379",
+				"--etcd-cafile=/etc/kubernetes/pki/etcd/ca.crt",
+				"--etcd-certfile=/etc/kubernetes/pki/etcd/client.crt",
+				"--etcd-keyfile=/etc/kubernetes/pki/etcd/client.key",
+			},
+			wantVolumes: []internalversion.Volume{
+				{HostPath: "/certs/ca.crt", MountPath: "/etc/kubernetes/pki/etcd/ca.crt", ReadOnly: true},
+				{HostPath: "/certs/client.crt", MountPath: "/etc/kubernetes/pki/etcd/client.crt", ReadOnly: true},
+				{HostPath: "/certs/client.key", MountPath: "/etc/kubernetes/pki/etcd/client.key", ReadOnly: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, err := BuildKubeApiserverComponent(BuildKubeApiserverComponentConfig{
+				Runtime:       tt.runtime,
+				ProjectName:   "kwok-kwok",
+				Version:       version.NewVersion(1, 30, 0),
+				Port:          32766,
+				EtcdAddress:   "127.0.0.1",
+				EtcdPrefix:    "/registry",
+				EtcdEndpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+				EtcdCaFile:    "/certs/ca.crt",
+				EtcdCertFile:  "/certs/client.crt",
+				EtcdKeyFile:   "/certs/client.key",
+			})
+			if err != nil {
+				t.Fatalf("BuildKubeApiserverComponent() error = %v", err)
+			}
+			for _, want := range tt.wantArgs {
+				if !slices.Contains(component.Args, want) {
+					t.Errorf("Args = %v, want to contain %q", component.Args, want)
+				}
+			}
+			if diff := cmp.Diff(tt.wantVolumes, component.Volumes); diff != "" {
+				t.Errorf("Volumes mismatch (-want +got):\n%s", diff)
+			}
+			if slices.Contains(component.Links, consts.ComponentEtcd) {
+				t.Errorf("Links = %v, want no link to etcd", component.Links)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// BuildKubeAuditSinkComponentConfig is the configuration for building the audit sink of the kube-apiserver.
+type BuildKubeAuditSinkComponentConfig struct {
+	Runtime     string
+	Binary      string
+	Image       string
+	Version     version.Version
+	Workdir     string
+	BindAddress string
+	Port        uint32
+	LogPath     string
+	Verbosity   log.Level
+}
+
+// BuildKubeAuditSinkComponent builds the audit sink of the kube-apiserver,
+// which is the audit-sink command of kwok storing the audit events sent by the webhook as JSON lines.
+func BuildKubeAuditSinkComponent(conf BuildKubeAuditSinkComponentConfig) (component internalversion.Component, err error) {
+	if GetRuntimeMode(conf.Runtime) == RuntimeModeCluster {
+		return component, fmt.Errorf("the audit sink of kube-apiserver is not supported by %s runtime", conf.Runtime)
+	}
+
+	auditSinkArgs := []string{
+		"audit-sink",
+	}
+
+	user := ""
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.LogPath,
+				MountPath: "/var/log/kubernetes/audit/audit-sink.jsonl",
+				ReadOnly:  false,
+			},
+		)
+		auditSinkArgs = append(auditSinkArgs,
+			"--address="+net.JoinHostPort(conf.BindAddress, 8080),
+			"--output=/var/log/kubernetes/audit/audit-sink.jsonl",
+		)
+		if conf.Port != 0 {
+			ports = []internalversion.Port{
+				{
+					HostPort: conf.Port,
+					Port:     8080,
+				},
+			}
+		}
+		user = "root"
+	} else {
+		if conf.Port == 0 {
+			return component, fmt.Errorf("the port of the audit sink is required by %s runtime", conf.Runtime)
+		}
+		auditSinkArgs = append(auditSinkArgs,
+			"--address="+net.JoinHostPort(conf.BindAddress, conf.Port),
+			"--output="+conf.LogPath,
+		)
+	}
+
+	if conf.Verbosity != log.LevelInfo {
+		auditSinkArgs = append(auditSinkArgs, "--v="+format.String(conf.Verbosity))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKubeAuditSink,
+		Version: conf.Version.String(),
+		Command: []string{"kwok"},
+		User:    user,
+		Volumes: volumes,
+		Args:    auditSinkArgs,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		Ports:   ports,
+		WorkDir: conf.Workdir,
+	}, nil
+}
+
+// KubeAuditSinkURL returns the URL of the audit sink, which the audit webhook of the kube-apiserver is sent to.
+func KubeAuditSinkURL(runtime string, projectName string, port uint32) string {
+	if GetRuntimeMode(runtime) != RuntimeModeNative {
+		return "http://" + projectName + "-" + consts.ComponentKubeAuditSink + ":8080"
+	}
+	return "http://" + net.LocalAddress + ":" + format.String(port)
+}
@@ -48,6 +48,7 @@ type BuildKubeControllerManagerComponentConfig struct {
 	NodeMonitorGracePeriodMilliseconds int64
 	Verbosity                          log.Level
 	DisableQPSLimits                   bool
+	ExternalCloudProvider              bool
 }
 
 // BuildKubeControllerManagerComponent builds a kube-controller-manager component.
@@ -60,6 +61,12 @@ func BuildKubeControllerManagerComponent(conf BuildKubeControllerManagerComponen
 		)
 	}
 
+	if conf.ExternalCloudProvider {
+		kubeControllerManagerArgs = append(kubeControllerManagerArgs,
+			"--cloud-provider=external",
+		)
+	}
+
 	if conf.NodeMonitorPeriodMilliseconds > 0 {
 		kubeControllerManagerArgs = append(kubeControllerManagerArgs,
 			"--node-monitor-period="+format.String(time.Duration(conf.NodeMonitorPeriodMilliseconds)*time.Millisecond),
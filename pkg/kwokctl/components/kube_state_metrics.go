@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// BuildKubeStateMetricsComponentConfig is the configuration for building a kube-state-metrics component.
+type BuildKubeStateMetricsComponentConfig struct {
+	Runtime        string
+	ProjectName    string
+	Binary         string
+	Image          string
+	Version        version.Version
+	Workdir        string
+	BindAddress    string
+	Port           uint32
+	TelemetryPort  uint32
+	CaCertPath     string
+	AdminCertPath  string
+	AdminKeyPath   string
+	KubeconfigPath string
+	Verbosity      log.Level
+}
+
+// BuildKubeStateMetricsComponent builds a kube-state-metrics component.
+func BuildKubeStateMetricsComponent(conf BuildKubeStateMetricsComponentConfig) (component internalversion.Component, err error) {
+	var kubeStateMetricsArgs []string
+
+	user := ""
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+	var metric *internalversion.ComponentMetric
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.KubeconfigPath,
+				MountPath: "/root/.kube/config",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.CaCertPath,
+				MountPath: "/etc/kubernetes/pki/ca.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminCertPath,
+				MountPath: "/etc/kubernetes/pki/admin.crt",
+				ReadOnly:  true,
+			},
+			internalversion.Volume{
+				HostPath:  conf.AdminKeyPath,
+				MountPath: "/etc/kubernetes/pki/admin.key",
+				ReadOnly:  true,
+			},
+		)
+		kubeStateMetricsArgs = append(kubeStateMetricsArgs,
+			"--kubeconfig=/root/.kube/config",
+			"--host="+conf.BindAddress,
+			"--port=8080",
+			"--telemetry-host="+conf.BindAddress,
+			"--telemetry-port=8081",
+		)
+		if conf.Port != 0 {
+			ports = []internalversion.Port{
+				{
+					HostPort: conf.Port,
+					Port:     8080,
+				},
+			}
+		}
+		metric = &internalversion.ComponentMetric{
+			Scheme: "http",
+			Host:   conf.ProjectName + "-" + consts.ComponentKubeStateMetrics + ":8080",
+			Path:   "/metrics",
+		}
+		user = "root"
+	} else {
+		if conf.Port == 0 || conf.TelemetryPort == 0 {
+			return component, fmt.Errorf("the ports of kube-state-metrics are required by %s runtime", conf.Runtime)
+		}
+		kubeStateMetricsArgs = append(kubeStateMetricsArgs,
+			"--kubeconfig="+conf.KubeconfigPath,
+			"--host="+conf.BindAddress,
+			"--port="+format.String(conf.Port),
+			"--telemetry-host="+net.LocalAddress,
+			"--telemetry-port="+format.String(conf.TelemetryPort),
+		)
+		metric = &internalversion.ComponentMetric{
+			Scheme: "http",
+			Host:   net.LocalAddress + ":" + format.String(conf.Port),
+			Path:   "/metrics",
+		}
+	}
+
+	if conf.Verbosity != log.LevelInfo {
+		kubeStateMetricsArgs = append(kubeStateMetricsArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKubeStateMetrics,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentKubeApiserver,
+		},
+		Command: []string{"/kube-state-metrics"},
+		User:    user,
+		Ports:   ports,
+		Volumes: volumes,
+		Args:    kubeStateMetricsArgs,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		Metric:  metric,
+		WorkDir: conf.Workdir,
+	}, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestBuildKubeStateMetricsComponent(t *testing.T) {
+	component, err := BuildKubeStateMetricsComponent(BuildKubeStateMetricsComponentConfig{
+		Runtime:        consts.RuntimeTypeDocker,
+		ProjectName:    "kwok-kwok",
+		Image:          "registry.k8s.io/kube-state-metrics/kube-state-metrics:v2.12.0",
+		BindAddress:    "0.0.0.0",
+		Port:           8080,
+		KubeconfigPath: "/workdir/kubeconfig",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeStateMetricsComponent() error = %v", err)
+	}
+	if component.Metric == nil || component.Metric.Host != "kwok-kwok-kube-state-metrics:8080" {
+		t.Errorf("Metric = %v, want to be scraped at kwok-kwok-kube-state-metrics:8080", component.Metric)
+	}
+
+	component, err = BuildKubeStateMetricsComponent(BuildKubeStateMetricsComponentConfig{
+		Runtime:        consts.RuntimeTypeBinary,
+		Binary:         "/workdir/bin/kube-state-metrics",
+		BindAddress:    "0.0.0.0",
+		Port:           32768,
+		TelemetryPort:  32769,
+		KubeconfigPath: "/workdir/kubeconfig.yaml",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeStateMetricsComponent() error = %v", err)
+	}
+	args := strings.Join(component.Args, " ")
+	for _, want := range []string{"--port=32768", "--telemetry-port=32769", "--kubeconfig=/workdir/kubeconfig.yaml"} {
+		if !strings.Contains(args, want) {
+			t.Errorf("Args = %v, want to contain %q", component.Args, want)
+		}
+	}
+
+	prometheus, err := BuildPrometheus(BuildPrometheusConfig{
+		Components: []internalversion.Component{component},
+	})
+	if err != nil {
+		t.Fatalf("BuildPrometheus() error = %v", err)
+	}
+	if !strings.Contains(prometheus, `job_name: "kube-state-metrics"`) {
+		t.Errorf("BuildPrometheus() = %s, want a scrape job of kube-state-metrics", prometheus)
+	}
+
+	_, err = BuildKubeStateMetricsComponent(BuildKubeStateMetricsComponentConfig{
+		Runtime: consts.RuntimeTypeBinary,
+		Port:    32768,
+	})
+	if err == nil {
+		t.Errorf("BuildKubeStateMetricsComponent() error = nil, want an error for binary runtime without telemetry port")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package components
 
 import (
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
@@ -40,28 +41,45 @@ type BuildKwokControllerComponentConfig struct {
 	ConfigPath                        string
 	KubeconfigPath                    string
 	CaCertPath                        string
+	CaKeyPath                         string
 	AdminCertPath                     string
 	AdminKeyPath                      string
 	NodeIP                            string
+	CIDR                              string
 	NodeName                          string
 	ManageNodesWithAnnotationSelector string
+	ManageNodesWithLabelSelector      string
 	Verbosity                         log.Level
 	NodeLeaseDurationSeconds          uint
+	TimeAcceleration                  float64
 	EnableCRDs                        []string
+
+	// EnableCSRSigner passes the key of the CA to sign the CertificateSigningRequests,
+	// it is opt-in as the key is mounted into the container of the kwok-controller.
+	EnableCSRSigner bool
 }
 
 // BuildKwokControllerComponent builds a kwok controller component.
 func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (component internalversion.Component) {
 	kwokControllerArgs := []string{}
-	if conf.ManageNodesWithAnnotationSelector == "" {
+	if conf.ManageNodesWithAnnotationSelector == "" && conf.ManageNodesWithLabelSelector == "" {
 		kwokControllerArgs = append(kwokControllerArgs,
 			"--manage-all-nodes=true",
 		)
 	} else {
 		kwokControllerArgs = append(kwokControllerArgs,
 			"--manage-all-nodes=false",
-			"--manage-nodes-with-annotation-selector="+conf.ManageNodesWithAnnotationSelector,
 		)
+		if conf.ManageNodesWithAnnotationSelector != "" {
+			kwokControllerArgs = append(kwokControllerArgs,
+				"--manage-nodes-with-annotation-selector="+conf.ManageNodesWithAnnotationSelector,
+			)
+		}
+		if conf.ManageNodesWithLabelSelector != "" {
+			kwokControllerArgs = append(kwokControllerArgs,
+				"--manage-nodes-with-label-selector="+conf.ManageNodesWithLabelSelector,
+			)
+		}
 	}
 
 	var volumes []internalversion.Volume
@@ -112,7 +130,7 @@ func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (comp
 			"--node-ip="+conf.NodeIP,
 			"--node-name="+conf.NodeName,
 			"--node-port=10247",
-			"--server-address="+conf.BindAddress+":10247",
+			"--server-address="+net.JoinHostPort(conf.BindAddress, 10247),
 			"--node-lease-duration-seconds="+format.String(conf.NodeLeaseDurationSeconds),
 		)
 	} else {
@@ -124,7 +142,7 @@ func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (comp
 			"--node-ip="+conf.NodeIP,
 			"--node-name="+conf.NodeName,
 			"--node-port="+format.String(conf.Port),
-			"--server-address="+conf.BindAddress+":"+format.String(conf.Port),
+			"--server-address="+net.JoinHostPort(conf.BindAddress, conf.Port),
 			"--node-lease-duration-seconds="+format.String(conf.NodeLeaseDurationSeconds),
 		)
 	}
@@ -155,10 +173,40 @@ func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (comp
 		}
 	}
 
+	// The CA of the cluster signs the CertificateSigningRequests, since v0.7.0
+	if conf.EnableCSRSigner && conf.CaKeyPath != "" && conf.Version.GE(version.NewVersion(0, 7, 0)) {
+		if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+			volumes = append(volumes,
+				internalversion.Volume{
+					HostPath:  conf.CaKeyPath,
+					MountPath: "/etc/kubernetes/pki/ca.key",
+					ReadOnly:  true,
+				},
+			)
+			kwokControllerArgs = append(kwokControllerArgs,
+				"--csr-signer-cert-file=/etc/kubernetes/pki/ca.crt",
+				"--csr-signer-key-file=/etc/kubernetes/pki/ca.key",
+			)
+		} else {
+			kwokControllerArgs = append(kwokControllerArgs,
+				"--csr-signer-cert-file="+conf.CaCertPath,
+				"--csr-signer-key-file="+conf.CaKeyPath,
+			)
+		}
+	}
+
+	if conf.CIDR != "" {
+		kwokControllerArgs = append(kwokControllerArgs, "--cidr="+conf.CIDR)
+	}
+
 	if conf.Verbosity != log.LevelInfo {
 		kwokControllerArgs = append(kwokControllerArgs, "--v="+format.String(conf.Verbosity))
 	}
 
+	if conf.TimeAcceleration != 0 && conf.TimeAcceleration != 1 {
+		kwokControllerArgs = append(kwokControllerArgs, "--time-acceleration="+strconv.FormatFloat(conf.TimeAcceleration, 'g', -1, 64))
+	}
+
 	if len(conf.EnableCRDs) != 0 {
 		kwokControllerArgs = append(kwokControllerArgs, "--enable-crds="+strings.Join(conf.EnableCRDs, ","))
 	}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestBuildKwokControllerComponentCSRSigner(t *testing.T) {
+	tests := []struct {
+		name            string
+		enableCSRSigner bool
+		want            bool
+	}{
+		{
+			name: "disabled by default",
+		},
+		{
+			name:            "enabled",
+			enableCSRSigner: true,
+			want:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := BuildKwokControllerComponent(BuildKwokControllerComponentConfig{
+				Runtime:         consts.RuntimeTypeDocker,
+				Version:         version.NewVersion(0, 7, 0),
+				CaCertPath:      "/workdir/pki/ca.crt",
+				CaKeyPath:       "/workdir/pki/ca.key",
+				EnableCSRSigner: tt.enableCSRSigner,
+			})
+
+			mounted := false
+			for _, volume := range component.Volumes {
+				if volume.HostPath == "/workdir/pki/ca.key" {
+					mounted = true
+				}
+			}
+			if mounted != tt.want {
+				t.Errorf("the key of the CA is mounted = %v, want %v", mounted, tt.want)
+			}
+
+			signing := strings.Contains(strings.Join(component.Args, " "), "--csr-signer-key-file=")
+			if signing != tt.want {
+				t.Errorf("Args = %v, want --csr-signer-key-file %v", component.Args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+//go:embed otel_collector_config.yaml.tpl
+var otelCollectorConfigYamlTpl string
+
+var otelCollectorConfigYamlTemplate = template.Must(template.New("otel_collector_config").Parse(otelCollectorConfigYamlTpl))
+
+// BuildOtelCollectorConfig is the configuration for building the config of otel-collector.
+type BuildOtelCollectorConfig struct {
+	OtlpGrpcAddress string
+	Exporters       []OtelCollectorExporter
+	LogLevel        string
+}
+
+// OtelCollectorExporter is an OTLP gRPC backend which otel-collector forwards the traces to.
+type OtelCollectorExporter struct {
+	Name     string
+	Endpoint string
+	Insecure bool
+}
+
+// NewOtelCollectorExporter returns an exporter to the endpoint,
+// the plain text is used unless the endpoint is given with the https scheme.
+func NewOtelCollectorExporter(name, endpoint string) OtelCollectorExporter {
+	return OtelCollectorExporter{
+		Name:     name,
+		Endpoint: endpoint,
+		Insecure: !strings.HasPrefix(endpoint, "https://"),
+	}
+}
+
+// BuildOtelCollector builds the config of otel-collector,
+// the traces are printed to the log if there is no exporter.
+func BuildOtelCollector(conf BuildOtelCollectorConfig) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	err := otelCollectorConfigYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("build otel-collector config error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildOtelCollectorComponentConfig is the configuration for building an otel-collector component.
+type BuildOtelCollectorComponentConfig struct {
+	Runtime    string
+	Binary     string
+	Image      string
+	Version    version.Version
+	Workdir    string
+	Port       uint32
+	ConfigPath string
+	Links      []string
+}
+
+// BuildOtelCollectorComponent builds an otel-collector component.
+func BuildOtelCollectorComponent(conf BuildOtelCollectorComponentConfig) (component internalversion.Component, err error) {
+	var otelCollectorArgs []string
+
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.ConfigPath,
+				MountPath: "/etc/otelcol/config.yaml",
+				ReadOnly:  true,
+			},
+		)
+		if conf.Port != 0 {
+			ports = []internalversion.Port{
+				{
+					HostPort: conf.Port,
+					Port:     4317,
+				},
+			}
+		}
+		otelCollectorArgs = append(otelCollectorArgs,
+			"--config=/etc/otelcol/config.yaml",
+		)
+	} else {
+		otelCollectorArgs = append(otelCollectorArgs,
+			"--config="+conf.ConfigPath,
+		)
+	}
+
+	return internalversion.Component{
+		Name:    consts.ComponentOtelCollector,
+		Version: conf.Version.String(),
+		Links:   conf.Links,
+		Command: []string{"/otelcol"},
+		Ports:   ports,
+		Volumes: volumes,
+		Args:    otelCollectorArgs,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+	}, nil
+}
+
+// OtelCollectorReceiverAddress returns the address of the OTLP gRPC receiver of otel-collector,
+// which the traces of the other components are sent to.
+func OtelCollectorReceiverAddress(runtime string, projectName string, port uint32) string {
+	if GetRuntimeMode(runtime) != RuntimeModeNative {
+		return projectName + "-" + consts.ComponentOtelCollector + ":4317"
+	}
+	return net.LocalAddress + ":" + format.String(port)
+}
+
+// OtelCollectorLogLevel returns the log level of otel-collector for the verbosity.
+func OtelCollectorLogLevel(verbosity log.Level) string {
+	if verbosity == log.LevelInfo {
+		return ""
+	}
+	return log.ToLogSeverityLevel(verbosity)
+}
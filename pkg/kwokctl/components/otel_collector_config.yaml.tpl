@@ -0,0 +1,36 @@
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: {{ .OtlpGrpcAddress }}
+exporters:
+{{- range .Exporters }}
+  otlp/{{ .Name }}:
+    endpoint: {{ .Endpoint }}
+{{- if .Insecure }}
+    tls:
+      insecure: true
+{{- end }}
+{{- end }}
+{{- if not .Exporters }}
+  debug: {}
+{{- end }}
+service:
+  telemetry:
+{{- if .LogLevel }}
+    logs:
+      level: {{ .LogLevel }}
+{{- end }}
+    metrics:
+      level: none
+  pipelines:
+    traces:
+      receivers:
+      - otlp
+      exporters:
+{{- range .Exporters }}
+      - otlp/{{ .Name }}
+{{- end }}
+{{- if not .Exporters }}
+      - debug
+{{- end }}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestBuildOtelCollector(t *testing.T) {
+	tests := []struct {
+		name string
+		conf BuildOtelCollectorConfig
+		want string
+	}{
+		{
+			name: "no exporters",
+			conf: BuildOtelCollectorConfig{
+				OtlpGrpcAddress: "127.0.0.1:32768",
+			},
+			want: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:32768
+exporters:
+  debug: {}
+service:
+  telemetry:
+    metrics:
+      level: none
+  pipelines:
+    traces:
+      receivers:
+      - otlp
+      exporters:
+      - debug
+`,
+		},
+		{
+			name: "jaeger and external",
+			conf: BuildOtelCollectorConfig{
+				OtlpGrpcAddress: "0.0.0.0:4317",
+				Exporters: []OtelCollectorExporter{
+					NewOtelCollectorExporter("jaeger", "kwok-kwok-jaeger:4317"),
+					NewOtelCollectorExporter("external", "https://otlp.example.com:4317"),
+				},
+				LogLevel: "debug",
+			},
+			want: `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+exporters:
+  otlp/jaeger:
+    endpoint: kwok-kwok-jaeger:4317
+    tls:
+      insecure: true
+  otlp/external:
+    endpoint: https://otlp.example.com:4317
+service:
+  telemetry:
+    logs:
+      level: debug
+    metrics:
+      level: none
+  pipelines:
+    traces:
+      receivers:
+      - otlp
+      exporters:
+      - otlp/jaeger
+      - otlp/external
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildOtelCollector(tt.conf)
+			if err != nil {
+				t.Fatalf("BuildOtelCollector() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("BuildOtelCollector() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildEtcdComponentWithTracing(t *testing.T) {
+	component, err := BuildEtcdComponent(BuildEtcdComponentConfig{
+		Runtime:        consts.RuntimeTypeDocker,
+		ProjectName:    "kwok-kwok",
+		Version:        version.NewVersion(3, 5, 11),
+		TracingAddress: OtelCollectorReceiverAddress(consts.RuntimeTypeDocker, "kwok-kwok", 0),
+	})
+	if err != nil {
+		t.Fatalf("BuildEtcdComponent() error = %v", err)
+	}
+	want := "--experimental-distributed-tracing-address=kwok-kwok-otel-collector:4317"
+	for _, arg := range component.Args {
+		if arg == want {
+			return
+		}
+	}
+	t.Errorf("Args = %v, want to contain %q", component.Args, want)
+}
@@ -67,11 +67,17 @@ func ConvertToPod(component internalversion.Component) corev1.Pod {
 				HostPath: &s,
 			},
 		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		volumeMount := corev1.VolumeMount{
 			Name:      name,
 			MountPath: v.MountPath,
 			ReadOnly:  v.ReadOnly,
-		})
+			SubPath:   v.SubPath,
+		}
+		if v.MountPropagation != "" {
+			m := corev1.MountPropagationMode(v.MountPropagation)
+			volumeMount.MountPropagation = &m
+		}
+		volumeMounts = append(volumeMounts, volumeMount)
 	}
 
 	p := corev1.Pod{
@@ -101,7 +107,7 @@ func ConvertToPod(component internalversion.Component) corev1.Pod {
 				RunAsGroup: format.Ptr[int64](0),
 				RunAsUser:  format.Ptr[int64](0),
 			},
-			RestartPolicy: corev1.RestartPolicyAlways,
+			RestartPolicy: PodRestartPolicy(component.RestartPolicy),
 			HostNetwork:   true,
 		},
 	}
@@ -138,11 +144,13 @@ func ConvertFromPod(p corev1.Pod) internalversion.Component {
 			continue
 		}
 		volumes = append(volumes, internalversion.Volume{
-			Name:      v.Name,
-			HostPath:  v.HostPath.Path,
-			PathType:  internalversion.HostPathType(format.ElemOrDefault(v.HostPath.Type)),
-			MountPath: vm.MountPath,
-			ReadOnly:  vm.ReadOnly,
+			Name:             v.Name,
+			HostPath:         v.HostPath.Path,
+			PathType:         internalversion.HostPathType(format.ElemOrDefault(v.HostPath.Type)),
+			MountPath:        vm.MountPath,
+			ReadOnly:         vm.ReadOnly,
+			SubPath:          vm.SubPath,
+			MountPropagation: internalversion.MountPropagationMode(format.ElemOrDefault(vm.MountPropagation)),
 		})
 	}
 
@@ -162,5 +170,7 @@ func ConvertFromPod(p corev1.Pod) internalversion.Component {
 		Ports:   ports,
 		Volumes: volumes,
 		Envs:    envs,
+
+		RestartPolicy: restartPolicyFromPod(p.Spec.RestartPolicy),
 	}
 }
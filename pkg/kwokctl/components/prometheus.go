@@ -74,12 +74,12 @@ func BuildPrometheusComponent(conf BuildPrometheusComponentConfig) (component in
 		}
 		prometheusArgs = append(prometheusArgs,
 			"--config.file=/etc/prometheus/prometheus.yaml",
-			"--web.listen-address="+conf.BindAddress+":9090",
+			"--web.listen-address="+net.JoinHostPort(conf.BindAddress, 9090),
 		)
 	} else {
 		prometheusArgs = append(prometheusArgs,
 			"--config.file="+conf.ConfigPath,
-			"--web.listen-address="+conf.BindAddress+":"+format.String(conf.Port),
+			"--web.listen-address="+net.JoinHostPort(conf.BindAddress, conf.Port),
 		)
 	}
 
@@ -99,12 +99,15 @@ func BuildPrometheusComponent(conf BuildPrometheusComponentConfig) (component in
 		Name:    consts.ComponentPrometheus,
 		Version: conf.Version.String(),
 		Links: []string{
-			consts.ComponentEtcd,
 			consts.ComponentKubeApiserver,
 			consts.ComponentKubeControllerManager,
 			consts.ComponentKubeScheduler,
 			consts.ComponentKwokController,
 		},
+		// The etcd is absent if an external etcd is used
+		SoftLinks: []string{
+			consts.ComponentEtcd,
+		},
 		Command: []string{consts.ComponentPrometheus},
 		Ports:   ports,
 		Volumes: volumes,
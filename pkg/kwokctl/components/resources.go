@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// ContainerResources returns the number of CPUs and the bytes of memory of the limits of the component
+// for the container runtimes and the compose file, e.g. "500m" is "0.5" CPUs and "512Mi" is "536870912" bytes,
+// the value is empty if the limit is not set.
+func ContainerResources(component internalversion.Component) (cpus string, memory string, err error) {
+	if component.CPULimit != "" {
+		q, err := resource.ParseQuantity(component.CPULimit)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid cpu limit %q of component %q: %w", component.CPULimit, component.Name, err)
+		}
+		cpus = strconv.FormatFloat(float64(q.MilliValue())/1000, 'f', -1, 64)
+	}
+	if component.MemoryLimit != "" {
+		q, err := resource.ParseQuantity(component.MemoryLimit)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid memory limit %q of component %q: %w", component.MemoryLimit, component.Name, err)
+		}
+		memory = format.String(q.Value())
+	}
+	return cpus, memory, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// ContainerRestart returns the restart policy of the container runtimes and the compose file for the component,
+// the container runtimes restart the containers with an exponential backoff.
+func ContainerRestart(policy internalversion.RestartPolicy) string {
+	switch policy {
+	case internalversion.RestartPolicyOnFailure:
+		return "on-failure"
+	case internalversion.RestartPolicyNever:
+		return "no"
+	default:
+		return "unless-stopped"
+	}
+}
+
+// PodRestartPolicy returns the restart policy of the pod for the component.
+func PodRestartPolicy(policy internalversion.RestartPolicy) corev1.RestartPolicy {
+	switch policy {
+	case internalversion.RestartPolicyOnFailure:
+		return corev1.RestartPolicyOnFailure
+	case internalversion.RestartPolicyNever:
+		return corev1.RestartPolicyNever
+	default:
+		return corev1.RestartPolicyAlways
+	}
+}
+
+// restartPolicyFromPod returns the restart policy of the component for the pod.
+func restartPolicyFromPod(policy corev1.RestartPolicy) internalversion.RestartPolicy {
+	switch policy {
+	case corev1.RestartPolicyOnFailure:
+		return internalversion.RestartPolicyOnFailure
+	case corev1.RestartPolicyNever:
+		return internalversion.RestartPolicyNever
+	default:
+		return ""
+	}
+}
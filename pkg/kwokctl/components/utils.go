@@ -17,7 +17,9 @@ limitations under the License.
 package components
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -31,8 +33,14 @@ var (
 	ErrBrokenLinks = fmt.Errorf("broken links dependency detected")
 )
 
-// GroupByLinks groups stages by links.
+// GroupByLinks groups components by links, the components in a group only link to the components in the previous groups.
+// The soft links are followed if the linked components exist and do not link back to the component.
 func GroupByLinks(components []internalversion.Component) ([][]internalversion.Component, error) {
+	links, err := resolveLinks(components)
+	if err != nil {
+		return nil, err
+	}
+
 	had := sets.NewString()
 	next := slices.Clone(components)
 	groups := [][]internalversion.Component{}
@@ -43,30 +51,137 @@ func GroupByLinks(components []internalversion.Component) ([][]internalversion.C
 		group := []internalversion.Component{}
 
 		for _, component := range current {
-			if len(component.Links) != 0 && !had.HasAll(component.Links...) {
+			if l := links[component.Name]; len(l) != 0 && !had.HasAll(l...) {
 				next = append(next, component)
 				continue
 			}
 			group = append(group, component)
 		}
 		if len(group) == 0 {
-			if len(next) != 0 {
-				next := slices.Map(next, func(component internalversion.Component) string {
-					return component.Name
-				})
-				return nil, fmt.Errorf("%w: %v", ErrBrokenLinks, next)
-			}
-		} else {
-			added := slices.Map(group, func(component internalversion.Component) string {
+			// Unreachable as the links are resolved without cycles
+			next := slices.Map(next, func(component internalversion.Component) string {
 				return component.Name
 			})
-			had.Insert(added...)
-			groups = append(groups, group)
+			return nil, fmt.Errorf("%w: %v", ErrBrokenLinks, next)
 		}
+		added := slices.Map(group, func(component internalversion.Component) string {
+			return component.Name
+		})
+		had.Insert(added...)
+		groups = append(groups, group)
 	}
 	return groups, nil
 }
 
+// resolveLinks returns the links of each component to follow,
+// it reports the missing links and the cycles of the links, and drops the soft links that are missing or make a cycle.
+func resolveLinks(components []internalversion.Component) (map[string][]string, error) {
+	names := sets.NewString()
+	for _, component := range components {
+		if names.Has(component.Name) {
+			return nil, fmt.Errorf("%w: duplicate component %q, rename one of them", ErrBrokenLinks, component.Name)
+		}
+		names.Insert(component.Name)
+	}
+
+	links := make(map[string][]string, len(components))
+	var errs []error
+	for _, component := range components {
+		for _, link := range component.Links {
+			if !names.Has(link) {
+				errs = append(errs, fmt.Errorf("component %q links to missing component %q, add the component %q or move it to the soft links", component.Name, link, link))
+				continue
+			}
+			links[component.Name] = append(links[component.Name], link)
+		}
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%w: %w", ErrBrokenLinks, errors.Join(errs...))
+	}
+
+	if cycle := findCycle(components, links); len(cycle) != 0 {
+		return nil, fmt.Errorf("%w: components link to each other in a cycle %s, remove one of the links or move it to the soft links", ErrBrokenLinks, strings.Join(cycle, " -> "))
+	}
+
+	for _, component := range components {
+		for _, link := range component.SoftLinks {
+			if !names.Has(link) || link == component.Name || slices.Contains(links[component.Name], link) {
+				continue
+			}
+			// Skip the soft link if the linked component already depends on the component
+			if isLinked(links, link, component.Name) {
+				continue
+			}
+			links[component.Name] = append(links[component.Name], link)
+		}
+	}
+	return links, nil
+}
+
+// findCycle returns the names of components in a cycle of the links, the first name is repeated at the end.
+func findCycle(components []internalversion.Component, links map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	stack := []string{}
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, link := range links[name] {
+			switch state[link] {
+			case visiting:
+				i := len(stack) - 1
+				for stack[i] != link {
+					i--
+				}
+				cycle := slices.Clone(stack[i:])
+				return append(cycle, link)
+			case unvisited:
+				if cycle := visit(link); len(cycle) != 0 {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, component := range components {
+		if state[component.Name] != unvisited {
+			continue
+		}
+		if cycle := visit(component.Name); len(cycle) != 0 {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// isLinked returns whether the component from links to the component to directly or indirectly.
+func isLinked(links map[string][]string, from, to string) bool {
+	had := sets.NewString()
+	next := []string{from}
+	for len(next) != 0 {
+		name := next[len(next)-1]
+		next = next[:len(next)-1]
+		if name == to {
+			return true
+		}
+		if had.Has(name) {
+			continue
+		}
+		had.Insert(name)
+		next = append(next, links[name]...)
+	}
+	return false
+}
+
 // The following runtime mode is classification of runtime for components.
 const (
 	RuntimeModeNative    = "native"
@@ -77,6 +192,7 @@ const (
 var (
 	runtimeTypeMap = map[string]string{
 		consts.RuntimeTypeBinary:      RuntimeModeNative,
+		consts.RuntimeTypeCrio:        RuntimeModeNative,
 		consts.RuntimeTypeDocker:      RuntimeModeContainer,
 		consts.RuntimeTypePodman:      RuntimeModeContainer,
 		consts.RuntimeTypeNerdctl:     RuntimeModeContainer,
@@ -87,6 +203,7 @@ var (
 		consts.RuntimeTypeKindNerdctl: RuntimeModeCluster,
 		consts.RuntimeTypeKindLima:    RuntimeModeCluster,
 		consts.RuntimeTypeKindFinch:   RuntimeModeCluster,
+		consts.RuntimeTypeKubernetes:  RuntimeModeContainer,
 	}
 )
 
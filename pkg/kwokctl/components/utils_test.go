@@ -17,6 +17,8 @@ limitations under the License.
 package components
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -74,6 +76,63 @@ func TestGroupByLinks(t *testing.T) {
 				{{Name: "prometheus", Links: []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "kwok-controller"}}},
 			},
 		},
+		{
+			name: "soft links",
+			args: args{
+				components: []internalversion.Component{
+					{
+						Name:      "jaeger",
+						SoftLinks: []string{"etcd", "missing"},
+					},
+					{
+						Name:      "etcd",
+						SoftLinks: []string{"jaeger"},
+					},
+					{
+						Name:      "kube-apiserver",
+						Links:     []string{"etcd"},
+						SoftLinks: []string{"jaeger"},
+					},
+				},
+			},
+			want: [][]internalversion.Component{
+				{{Name: "etcd", SoftLinks: []string{"jaeger"}}},
+				{{Name: "jaeger", SoftLinks: []string{"etcd", "missing"}}},
+				{{Name: "kube-apiserver", Links: []string{"etcd"}, SoftLinks: []string{"jaeger"}}},
+			},
+		},
+		{
+			name: "missing links",
+			args: args{
+				components: []internalversion.Component{
+					{
+						Name:  "kube-apiserver",
+						Links: []string{"etcd"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cycle links",
+			args: args{
+				components: []internalversion.Component{
+					{
+						Name:  "etcd",
+						Links: []string{"kwok-controller"},
+					},
+					{
+						Name:  "kube-apiserver",
+						Links: []string{"etcd"},
+					},
+					{
+						Name:  "kwok-controller",
+						Links: []string{"kube-apiserver"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,3 +147,27 @@ func TestGroupByLinks(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupByLinksDiagnostics(t *testing.T) {
+	_, err := GroupByLinks([]internalversion.Component{
+		{Name: "etcd", Links: []string{"kwok-controller"}},
+		{Name: "kube-apiserver", Links: []string{"etcd"}},
+		{Name: "kwok-controller", Links: []string{"kube-apiserver"}},
+	})
+	if !errors.Is(err, ErrBrokenLinks) {
+		t.Fatalf("GroupByLinks() error = %v, want %v", err, ErrBrokenLinks)
+	}
+	if want := "etcd -> kwok-controller -> kube-apiserver -> etcd"; !strings.Contains(err.Error(), want) {
+		t.Errorf("GroupByLinks() error = %v, want contains %q", err, want)
+	}
+
+	_, err = GroupByLinks([]internalversion.Component{
+		{Name: "kube-apiserver", Links: []string{"etcd"}},
+	})
+	if !errors.Is(err, ErrBrokenLinks) {
+		t.Fatalf("GroupByLinks() error = %v, want %v", err, ErrBrokenLinks)
+	}
+	if want := `component "kube-apiserver" links to missing component "etcd"`; !strings.Contains(err.Error(), want) {
+		t.Errorf("GroupByLinks() error = %v, want contains %q", err, want)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// VolumeHostPath returns the path on the host to be mounted by the volume, including the sub path.
+func VolumeHostPath(volume internalversion.Volume) string {
+	if volume.SubPath == "" {
+		return volume.HostPath
+	}
+	return path.Join(volume.HostPath, volume.SubPath)
+}
+
+// VolumeBind returns the bind of the volume in the form of "host:container[:options]",
+// as the --volume flag of the container runtimes and the volumes of the compose file.
+func VolumeBind(volume internalversion.Volume) string {
+	bind := VolumeHostPath(volume) + ":" + volume.MountPath
+	options := []string{}
+	if volume.ReadOnly {
+		options = append(options, "ro")
+	}
+	switch volume.MountPropagation {
+	case internalversion.MountPropagationNone:
+		options = append(options, "rprivate")
+	case internalversion.MountPropagationHostToContainer:
+		options = append(options, "rslave")
+	case internalversion.MountPropagationBidirectional:
+		options = append(options, "rshared")
+	}
+	if len(options) != 0 {
+		bind += ":" + strings.Join(options, ",")
+	}
+	return bind
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestVolumeBind(t *testing.T) {
+	tests := []struct {
+		name   string
+		volume internalversion.Volume
+		want   string
+	}{
+		{
+			name: "read write",
+			volume: internalversion.Volume{
+				HostPath:  "/data",
+				MountPath: "/var/data",
+			},
+			want: "/data:/var/data",
+		},
+		{
+			name: "read only",
+			volume: internalversion.Volume{
+				HostPath:  "/data",
+				MountPath: "/var/data",
+				ReadOnly:  true,
+			},
+			want: "/data:/var/data:ro",
+		},
+		{
+			name: "sub path and propagation",
+			volume: internalversion.Volume{
+				HostPath:         "/data",
+				SubPath:          "logs",
+				MountPath:        "/var/log",
+				ReadOnly:         true,
+				MountPropagation: internalversion.MountPropagationHostToContainer,
+			},
+			want: "/data/logs:/var/log:ro,rslave",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VolumeBind(tt.volume); got != tt.want {
+				t.Errorf("VolumeBind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift rolls out changes of the labels and taints of the nodes gradually,
+// to simulate the churn of the nodes such as an upgrade of the operating system.
+package drift
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// Change is the change of the labels and taints of a node.
+type Change struct {
+	// AddLabels is the labels to add or update.
+	AddLabels map[string]string
+	// RemoveLabels is the keys of the labels to remove.
+	RemoveLabels []string
+	// AddTaints is the taints to add or update, matched by the key and the effect.
+	AddTaints []corev1.Taint
+	// RemoveTaints is the keys of the taints to remove.
+	RemoveTaints []string
+}
+
+// IsEmpty returns true if the change does nothing.
+func (c Change) IsEmpty() bool {
+	return len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0 &&
+		len(c.AddTaints) == 0 && len(c.RemoveTaints) == 0
+}
+
+// Apply applies the change to the node and returns true if the node is changed.
+func (c Change) Apply(node *corev1.Node) bool {
+	changed := false
+	for k, v := range c.AddLabels {
+		if old, ok := node.Labels[k]; ok && old == v {
+			continue
+		}
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[k] = v
+		changed = true
+	}
+	for _, k := range c.RemoveLabels {
+		if _, ok := node.Labels[k]; ok {
+			delete(node.Labels, k)
+			changed = true
+		}
+	}
+
+	if len(c.RemoveTaints) != 0 {
+		taints := node.Spec.Taints[:0]
+		for _, taint := range node.Spec.Taints {
+			if slices.Contains(c.RemoveTaints, taint.Key) {
+				changed = true
+				continue
+			}
+			taints = append(taints, taint)
+		}
+		node.Spec.Taints = taints
+	}
+	for _, taint := range c.AddTaints {
+		found := false
+		for i, t := range node.Spec.Taints {
+			if t.Key != taint.Key || t.Effect != taint.Effect {
+				continue
+			}
+			found = true
+			if t.Value != taint.Value {
+				node.Spec.Taints[i].Value = taint.Value
+				changed = true
+			}
+			break
+		}
+		if !found {
+			node.Spec.Taints = append(node.Spec.Taints, taint)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ParseTaint parses a taint in the form of key[=value]:effect.
+func ParseTaint(s string) (corev1.Taint, error) {
+	kv, effect, ok := strings.Cut(s, ":")
+	if !ok {
+		return corev1.Taint{}, fmt.Errorf("invalid taint %q, the effect is required, e.g. key=value:NoSchedule", s)
+	}
+	switch e := corev1.TaintEffect(effect); e {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return corev1.Taint{}, fmt.Errorf("invalid effect %q of taint %q", effect, s)
+	}
+	key, value, _ := strings.Cut(kv, "=")
+	if key == "" {
+		return corev1.Taint{}, fmt.Errorf("invalid taint %q, the key is required", s)
+	}
+	return corev1.Taint{
+		Key:    key,
+		Value:  value,
+		Effect: corev1.TaintEffect(effect),
+	}, nil
+}
+
+// Wave is a batch of nodes changed at the same time.
+type Wave struct {
+	// Group is the value of the label which the nodes are grouped by.
+	Group string
+	// Nodes is the names of the nodes.
+	Nodes []string
+}
+
+// Plan selects the percent of the nodes in each group of the value of the label groupBy,
+// and splits them into the waves of batchSize nodes, group by group.
+// All nodes are in one group if groupBy is empty, and each group is a wave if batchSize is 0.
+func Plan(nodes []corev1.Node, groupBy string, percent float64, batchSize int) []Wave {
+	groups := map[string][]string{}
+	for _, node := range nodes {
+		group := ""
+		if groupBy != "" {
+			group = node.Labels[groupBy]
+		}
+		groups[group] = append(groups[group], node.Name)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	waves := []Wave{}
+	for _, key := range keys {
+		names := groups[key]
+		sort.Strings(names)
+		count := int(math.Ceil(float64(len(names)) * percent / 100))
+		if count > len(names) {
+			count = len(names)
+		}
+		names = names[:count]
+
+		size := batchSize
+		if size <= 0 {
+			size = len(names)
+		}
+		for len(names) != 0 {
+			n := size
+			if n > len(names) {
+				n = len(names)
+			}
+			waves = append(waves, Wave{
+				Group: key,
+				Nodes: names[:n],
+			})
+			names = names[n:]
+		}
+	}
+	return waves
+}
+
+// Result is the result of the rollout.
+type Result struct {
+	// Changed is the number of nodes changed.
+	Changed int
+	// Unchanged is the number of nodes that already have the change.
+	Unchanged int
+	// Failed is the number of nodes failed to change.
+	Failed int
+}
+
+// Rollout applies the change to the nodes wave by wave, waiting interval between the waves.
+func Rollout(ctx context.Context, cli typedcorev1.NodesGetter, waves []Wave, change Change, interval time.Duration) (Result, error) {
+	logger := log.FromContext(ctx)
+
+	var result Result
+	for i, wave := range waves {
+		if i != 0 && interval > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		logger.Info("Rolling out wave",
+			"wave", i+1,
+			"waves", len(waves),
+			"group", wave.Group,
+			"nodes", len(wave.Nodes),
+		)
+		for _, name := range wave.Nodes {
+			changed, err := apply(ctx, cli, name, change)
+			if err != nil {
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
+				logger.Warn("Failed to change node", "node", name, "err", err)
+				result.Failed++
+				continue
+			}
+			if changed {
+				result.Changed++
+			} else {
+				result.Unchanged++
+			}
+		}
+	}
+	return result, nil
+}
+
+func apply(ctx context.Context, cli typedcorev1.NodesGetter, name string, change Change) (changed bool, err error) {
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := cli.Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		changed = change.Apply(node)
+		if !changed {
+			return nil
+		}
+		_, err = cli.Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+	return changed, err
+}
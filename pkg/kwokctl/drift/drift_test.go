@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNode(name, zone string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"zone": zone,
+			},
+		},
+	}
+}
+
+func TestParseTaint(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    corev1.Taint
+		wantErr bool
+	}{
+		{
+			in:   "upgrade=true:NoSchedule",
+			want: corev1.Taint{Key: "upgrade", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+		},
+		{
+			in:   "upgrade:NoExecute",
+			want: corev1.Taint{Key: "upgrade", Effect: corev1.TaintEffectNoExecute},
+		},
+		{
+			in:      "upgrade=true",
+			wantErr: true,
+		},
+		{
+			in:      "upgrade=true:Unknown",
+			wantErr: true,
+		},
+		{
+			in:      "=true:NoSchedule",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTaint(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTaint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTaint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeApply(t *testing.T) {
+	change := Change{
+		AddLabels:    map[string]string{"os": "v2"},
+		RemoveLabels: []string{"zone"},
+		AddTaints:    []corev1.Taint{{Key: "upgrade", Value: "true", Effect: corev1.TaintEffectNoSchedule}},
+		RemoveTaints: []string{"old"},
+	}
+
+	node := newNode("node-0", "a")
+	node.Spec.Taints = []corev1.Taint{{Key: "old", Effect: corev1.TaintEffectNoSchedule}}
+	if !change.Apply(node) {
+		t.Fatalf("expected the node to be changed")
+	}
+	if !reflect.DeepEqual(node.Labels, map[string]string{"os": "v2"}) {
+		t.Errorf("unexpected labels %v", node.Labels)
+	}
+	if !reflect.DeepEqual(node.Spec.Taints, change.AddTaints) {
+		t.Errorf("unexpected taints %v", node.Spec.Taints)
+	}
+	if change.Apply(node) {
+		t.Errorf("expected the node to be unchanged")
+	}
+}
+
+func TestPlan(t *testing.T) {
+	nodes := []corev1.Node{
+		*newNode("node-b-1", "b"),
+		*newNode("node-a-1", "a"),
+		*newNode("node-a-0", "a"),
+		*newNode("node-b-0", "b"),
+		*newNode("node-a-2", "a"),
+		*newNode("node-a-3", "a"),
+	}
+
+	got := Plan(nodes, "zone", 50, 1)
+	want := []Wave{
+		{Group: "a", Nodes: []string{"node-a-0"}},
+		{Group: "a", Nodes: []string{"node-a-1"}},
+		{Group: "b", Nodes: []string{"node-b-0"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Plan() = %v, want %v", got, want)
+	}
+
+	got = Plan(nodes, "", 100, 0)
+	if len(got) != 1 || len(got[0].Nodes) != len(nodes) {
+		t.Errorf("Plan() = %v, want all nodes in one wave", got)
+	}
+}
+
+func TestRollout(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset(newNode("node-0", "a"), newNode("node-1", "b"))
+	change := Change{
+		AddTaints: []corev1.Taint{{Key: "upgrade", Effect: corev1.TaintEffectNoSchedule}},
+	}
+	waves := []Wave{
+		{Group: "a", Nodes: []string{"node-0"}},
+		{Group: "b", Nodes: []string{"node-1", "node-2"}},
+	}
+
+	result, err := Rollout(ctx, cli.CoreV1(), waves, change, 0)
+	if err != nil {
+		t.Fatalf("Rollout() error = %v", err)
+	}
+	if result != (Result{Changed: 2, Failed: 1}) {
+		t.Errorf("Rollout() = %+v", result)
+	}
+
+	node, err := cli.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(node.Spec.Taints) != 1 || node.Spec.Taints[0].Key != "upgrade" {
+		t.Errorf("unexpected taints %v", node.Spec.Taints)
+	}
+}
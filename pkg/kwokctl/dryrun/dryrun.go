@@ -24,13 +24,17 @@ import (
 	"strings"
 )
 
-var stdout = os.Stdout
+var stdout io.Writer = os.Stdout
 
 // DryRun is a flag to indicate whether the program is running in dry-run mode.
 var DryRun bool
 
 // PrintMessage prints the message to stdout.
 func PrintMessage(format string, a ...any) {
+	if Format != "" {
+		record(Step{Command: fmt.Sprintf(format, a...)})
+		return
+	}
 	_, _ = fmt.Fprintf(stdout, format+"\n", a...)
 }
 
@@ -66,6 +70,11 @@ func (d *dryRunWriter) Close() error {
 		return nil
 	}
 
+	if Format != "" && d.w == stdout {
+		record(Step{File: d.name, Content: buf})
+		return nil
+	}
+
 	line := strings.TrimSpace(buf)
 	if !strings.Contains(line, "\n") {
 		_, _ = fmt.Fprintf(d.w, "echo %s >%s\n", line, d.name)
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// The formats of the output of dry-run.
+const (
+	// FormatScript prints a self-contained shell script.
+	FormatScript = "script"
+	// FormatCompose prints a compose file of the components.
+	FormatCompose = "compose"
+	// FormatMarkdown prints a Markdown runbook.
+	FormatMarkdown = "markdown"
+)
+
+// Formats is the supported formats of the output of dry-run.
+var Formats = []string{FormatScript, FormatCompose, FormatMarkdown}
+
+// Format is the format of the output of dry-run,
+// the commands are printed as they are executed if it is empty,
+// otherwise they are recorded and printed by Flush.
+var Format string
+
+// Step is a recorded step of dry-run.
+type Step struct {
+	// Command is the command to execute, a command starting with '#' is a comment.
+	Command string
+	// File is the name of the file to write.
+	File string
+	// Content is the content of the file to write.
+	Content string
+}
+
+var (
+	stepsMut    sync.Mutex
+	steps       []Step
+	composeFile []byte
+)
+
+func record(step Step) {
+	stepsMut.Lock()
+	defer stepsMut.Unlock()
+	steps = append(steps, step)
+}
+
+// SetComposeFile sets the compose file printed in compose format.
+func SetComposeFile(content []byte) {
+	stepsMut.Lock()
+	defer stepsMut.Unlock()
+	composeFile = content
+}
+
+// ValidateFormat validates the format of the output of dry-run.
+func ValidateFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported dry-run format %q, must be one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Flush prints the recorded steps in the format to stdout.
+func Flush() error {
+	return flush(stdout)
+}
+
+func flush(w io.Writer) error {
+	stepsMut.Lock()
+	defer stepsMut.Unlock()
+
+	var err error
+	switch Format {
+	case "":
+		return nil
+	case FormatScript:
+		err = renderScript(w, steps)
+	case FormatMarkdown:
+		err = renderMarkdown(w, steps)
+	case FormatCompose:
+		if len(composeFile) == 0 {
+			return fmt.Errorf("no compose file is generated, the %q format is only supported by creating a cluster with docker/podman/nerdctl/lima/finch runtime", FormatCompose)
+		}
+		_, err = w.Write(composeFile)
+	default:
+		return ValidateFormat(Format)
+	}
+	steps = nil
+	composeFile = nil
+	return err
+}
+
+func renderScript(w io.Writer, steps []Step) error {
+	buf := &strings.Builder{}
+	buf.WriteString("#!/usr/bin/env bash\n")
+	buf.WriteString("# Generated by kwokctl --dry-run\n\n")
+	buf.WriteString("set -o errexit\nset -o nounset\nset -o pipefail\n\n")
+	for _, step := range steps {
+		writeShell(buf, step)
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func writeShell(buf *strings.Builder, step Step) {
+	if step.File == "" {
+		buf.WriteString(step.Command)
+		buf.WriteString("\n")
+		return
+	}
+	line := strings.TrimSpace(step.Content)
+	if !strings.Contains(line, "\n") {
+		_, _ = fmt.Fprintf(buf, "echo %s >%s\n", line, step.File)
+		return
+	}
+	_, _ = fmt.Fprintf(buf, "cat <<EOF >%s\n%s\nEOF\n", step.File, step.Content)
+}
+
+func renderMarkdown(w io.Writer, steps []Step) error {
+	buf := &strings.Builder{}
+	buf.WriteString("# Runbook\n\n")
+	buf.WriteString("Generated by kwokctl --dry-run, run the steps in order.\n")
+
+	inBlock := false
+	closeBlock := func() {
+		if inBlock {
+			buf.WriteString("```\n")
+			inBlock = false
+		}
+	}
+	for _, step := range steps {
+		switch {
+		case step.File != "":
+			closeBlock()
+			_, _ = fmt.Fprintf(buf, "\nWrite `%s`:\n\n", step.File)
+			_, _ = fmt.Fprintf(buf, "```\n%s\n```\n", strings.TrimSuffix(step.Content, "\n"))
+		case strings.HasPrefix(step.Command, "#"):
+			closeBlock()
+			_, _ = fmt.Fprintf(buf, "\n%s\n", strings.TrimSpace(strings.TrimLeft(step.Command, "#")))
+		default:
+			if !inBlock {
+				buf.WriteString("\n```bash\n")
+				inBlock = true
+			}
+			buf.WriteString(step.Command)
+			buf.WriteString("\n")
+		}
+	}
+	closeBlock()
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
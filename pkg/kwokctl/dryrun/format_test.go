@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFlush(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "script",
+			format: FormatScript,
+			want: `#!/usr/bin/env bash
+# Generated by kwokctl --dry-run
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# Create directory
+mkdir -p /tmp/kwok
+echo value >/tmp/kwok/file
+`,
+		},
+		{
+			name:   "markdown",
+			format: FormatMarkdown,
+			want: "# Runbook\n\n" +
+				"Generated by kwokctl --dry-run, run the steps in order.\n\n" +
+				"Create directory\n\n" +
+				"```bash\nmkdir -p /tmp/kwok\n```\n\n" +
+				"Write `/tmp/kwok/file`:\n\n" +
+				"```\nvalue\n```\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			oldStdout := stdout
+			Format = tt.format
+			stdout = &buf
+			defer func() {
+				Format = ""
+				stdout = oldStdout
+			}()
+
+			PrintMessage("# Create directory")
+			PrintMessage("mkdir -p %s", "/tmp/kwok")
+			w := NewCatToFileWriter("/tmp/kwok/file")
+			_, _ = w.Write([]byte("value\n"))
+			_ = w.Close()
+
+			if buf.Len() != 0 {
+				t.Fatalf("expected nothing printed before flush, got %q", buf.String())
+			}
+
+			err := Flush()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFlushComposeWithoutFile(t *testing.T) {
+	Format = FormatCompose
+	defer func() {
+		Format = ""
+	}()
+
+	err := flush(&bytes.Buffer{})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -40,6 +40,9 @@ type Client interface {
 
 	// Put is a method that sets a key-value pair on the etcd server.
 	Put(ctx context.Context, prefix string, value []byte, opOpts ...OpOption) error
+
+	// Status is a method that returns the status of the etcd server.
+	Status(ctx context.Context) (*Status, error)
 }
 
 // client is the etcd client.
@@ -399,6 +402,32 @@ func (c *client) Put(ctx context.Context, prefix string, value []byte, opOpts ..
 	return nil
 }
 
+func (c *client) Status(ctx context.Context) (*Status, error) {
+	endpoints := c.client.Endpoints()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints")
+	}
+	resp, err := c.client.Status(ctx, endpoints[0])
+	if err != nil {
+		return nil, err
+	}
+	return &Status{
+		Version:     resp.Version,
+		DBSize:      resp.DbSize,
+		DBSizeInUse: resp.DbSizeInUse,
+	}, nil
+}
+
+// Status is the status of the etcd server.
+type Status struct {
+	// Version is the version of the etcd server.
+	Version string
+	// DBSize is the size of the backend database physically allocated, in bytes.
+	DBSize int64
+	// DBSizeInUse is the size of the backend database logically in use, in bytes.
+	DBSizeInUse int64
+}
+
 // KeyValue is the key-value pair.
 type KeyValue struct {
 	Key       []byte
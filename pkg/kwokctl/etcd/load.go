@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -60,6 +61,12 @@ type Loader struct {
 
 	handle *recording.Handle
 	clock  clock.Clock
+
+	// resumed is true if the loader resumes an interrupted replay,
+	// the resources of the snapshot and the first skip patches are already in the cluster.
+	resumed  bool
+	skip     uint64
+	replayed atomic.Uint64
 }
 
 // NewLoader creates a new snapshot Loader.
@@ -110,6 +117,19 @@ func (l *Loader) AllowHandle(ctx context.Context) context.CancelFunc {
 	}
 }
 
+// Resume makes the loader resume a replay interrupted after the given number of patches,
+// the resources of the snapshot and the replayed patches are skipped rather than applied again.
+func (l *Loader) Resume(replayed uint64) {
+	l.resumed = true
+	l.skip = replayed
+}
+
+// Replayed returns the number of the patches replayed, including the skipped ones,
+// which is recorded to resume the replay if it is interrupted.
+func (l *Loader) Replayed() uint64 {
+	return l.replayed.Load()
+}
+
 // Load loads the resources to cluster
 func (l *Loader) Load(ctx context.Context, decoder *yaml.Decoder) error {
 	logger := log.FromContext(ctx)
@@ -131,6 +151,10 @@ func (l *Loader) Load(ctx context.Context, decoder *yaml.Decoder) error {
 			break
 		}
 
+		if l.resumed {
+			continue
+		}
+
 		err = l.applyResource(ctx, obj)
 		if err != nil {
 			logger.Warn("failed to apply resource",
@@ -197,6 +221,10 @@ func (l *Loader) Replay(ctx context.Context, decoder *yaml.Decoder) error {
 }
 
 func (l *Loader) handleResourcePatch(ctx context.Context, resourcePatch *recording.ResourcePatch, dur *time.Duration) {
+	if l.skipReplayed(resourcePatch, dur) {
+		return
+	}
+
 	d := resourcePatch.DurationNanosecond - *dur
 	switch {
 	case d > 0:
@@ -246,6 +274,7 @@ func (l *Loader) handleResourcePatch(ctx context.Context, resourcePatch *recordi
 
 	start := l.clock.Now()
 	l.applyResourcePatch(ctx, resourcePatch)
+	l.replayed.Add(1)
 	past := l.clock.Since(start)
 	if past > 0 {
 		if l.handle != nil {
@@ -255,6 +284,17 @@ func (l *Loader) handleResourcePatch(ctx context.Context, resourcePatch *recordi
 	}
 }
 
+// skipReplayed skips the patch if it is replayed before the interrupt,
+// and moves the replay time to it so that the next patch is replayed without waiting again.
+func (l *Loader) skipReplayed(resourcePatch *recording.ResourcePatch, dur *time.Duration) bool {
+	if l.replayed.Load() >= l.skip {
+		return false
+	}
+	l.replayed.Add(1)
+	*dur = resourcePatch.DurationNanosecond
+	return true
+}
+
 func (l *Loader) handlePause(ctx context.Context) {
 	for l.handle.IsPause() {
 		if err := ctx.Err(); err != nil {
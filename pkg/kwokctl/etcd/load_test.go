@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const resumedRecording = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+---
+apiVersion: action.kwok.x-k8s.io/v1alpha1
+kind: ResourcePatch
+resource:
+  version: v1
+  resource: configmaps
+target:
+  name: cm
+  namespace: default
+durationNanosecond: 1000000
+method: delete
+---
+apiVersion: action.kwok.x-k8s.io/v1alpha1
+kind: ResourcePatch
+resource:
+  version: v1
+  resource: configmaps
+target:
+  name: cm
+  namespace: default
+durationNanosecond: 2000000
+method: delete
+`
+
+// The loader has no clients, so it panics if it applies anything rather than skipping it.
+func TestLoaderResume(t *testing.T) {
+	ctx := context.Background()
+	l := &Loader{
+		tracksData: map[schema.GroupVersionResource]map[log.ObjectRef]json.RawMessage{},
+		clock:      clock.RealClock{},
+	}
+	l.Resume(2)
+
+	decoder := yaml.NewDecoder(strings.NewReader(resumedRecording))
+	err := l.Load(ctx, decoder)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	err = l.Replay(ctx, decoder)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if got := l.Replayed(); got != 2 {
+		t.Errorf("Replayed() = %d, want 2", got)
+	}
+}
+
+func TestLoaderSkipReplayed(t *testing.T) {
+	l := &Loader{}
+	l.Resume(1)
+
+	var dur time.Duration
+	if !l.skipReplayed(&recording.ResourcePatch{DurationNanosecond: 3 * time.Second}, &dur) {
+		t.Fatalf("skipReplayed() = false, want the replayed patch to be skipped")
+	}
+	if dur != 3*time.Second {
+		t.Errorf("dur = %v, want the replay time moved to the skipped patch", dur)
+	}
+	if l.skipReplayed(&recording.ResourcePatch{DurationNanosecond: 4 * time.Second}, &dur) {
+		t.Errorf("skipReplayed() = true, want the patch after the interrupt to be replayed")
+	}
+	if dur != 3*time.Second {
+		t.Errorf("dur = %v, want it unchanged for the replayed patch", dur)
+	}
+	if got := l.Replayed(); got != 1 {
+		t.Errorf("Replayed() = %d, want 1", got)
+	}
+}
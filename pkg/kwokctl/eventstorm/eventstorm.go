@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventstorm generates a configurable volume of events to stress the event consumers.
+package eventstorm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// Config is the configuration of the events to generate.
+type Config struct {
+	// Objects is the objects which the events are about, they are used in round-robin.
+	Objects []corev1.ObjectReference
+	// Types is the distribution of the types of the events.
+	Types scale.Distribution
+	// Reasons is the distribution of the reasons of the events.
+	Reasons scale.Distribution
+	// Component is the source component of the events.
+	Component string
+	// Rate is the number of events per second, 0 means as fast as possible.
+	Rate float64
+	// Count is the number of events to generate, 0 means no limit.
+	Count uint64
+	// Duration is the duration to generate events, 0 means no limit.
+	Duration time.Duration
+	// Workers is the number of concurrent requests.
+	Workers int
+}
+
+// Result is the result of the generation.
+type Result struct {
+	// Sent is the number of events created.
+	Sent uint64
+	// Failed is the number of events failed to create.
+	Failed uint64
+	// Elapsed is the time spent.
+	Elapsed time.Duration
+}
+
+// Rate returns the actual number of events created per second.
+func (r Result) Rate() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Sent) / r.Elapsed.Seconds()
+}
+
+// tick is the interval of pacing the events.
+const tick = 10 * time.Millisecond
+
+// Generate creates the events until the count or the duration is reached or the context is canceled.
+func Generate(ctx context.Context, cli typedcorev1.EventsGetter, conf Config) (Result, error) {
+	if len(conf.Objects) == 0 {
+		return Result{}, fmt.Errorf("no involved objects")
+	}
+	if conf.Count == 0 && conf.Duration == 0 {
+		return Result{}, fmt.Errorf("either count or duration must be set")
+	}
+	if conf.Workers <= 0 {
+		conf.Workers = 1
+	}
+
+	logger := log.FromContext(ctx)
+
+	if conf.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, conf.Duration)
+		defer cancel()
+	}
+
+	// prefix keeps the names unique across runs
+	prefix := strconv.FormatInt(time.Now().UnixNano(), 16)
+
+	var result Result
+	indexes := make(chan uint64, conf.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < conf.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				event := newEvent(conf, prefix, index)
+				_, err := cli.Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{})
+				if err != nil {
+					if ctx.Err() != nil {
+						continue
+					}
+					if atomic.AddUint64(&result.Failed, 1) == 1 {
+						logger.Warn("Failed to create event", "err", err)
+					}
+					continue
+				}
+				atomic.AddUint64(&result.Sent, 1)
+			}
+		}()
+	}
+
+	start := time.Now()
+	produce(ctx, indexes, conf.Rate, conf.Count, start)
+	close(indexes)
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+	return result, nil
+}
+
+// produce sends the indexes of the events to generate, paced by the rate.
+func produce(ctx context.Context, indexes chan<- uint64, rate float64, count uint64, start time.Time) {
+	var next uint64
+	send := func(until uint64) bool {
+		for ; next < until; next++ {
+			if count != 0 && next >= count {
+				return false
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case indexes <- next:
+			}
+		}
+		return true
+	}
+
+	if rate <= 0 {
+		send(^uint64(0))
+		return
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		if !send(uint64(time.Since(start).Seconds() * rate)) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func newEvent(conf Config, prefix string, index uint64) *corev1.Event {
+	obj := conf.Objects[index%uint64(len(conf.Objects))]
+	name := fmt.Sprintf("%s.%s%x", obj.Name, prefix, index)
+	namespace := obj.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	now := metav1.Now()
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		InvolvedObject: obj,
+		Type:           conf.Types.Pick(name),
+		Reason:         conf.Reasons.Pick(name),
+		Message:        fmt.Sprintf("Generated event %d", index),
+		Source: corev1.EventSource{
+			Component: conf.Component,
+		},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+)
+
+func testConfig(t *testing.T) Config {
+	types, err := scale.ParseDistribution("type=Normal:9,Warning:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reasons, err := scale.ParseDistribution("reason=Started,BackOff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Config{
+		Objects: []corev1.ObjectReference{
+			{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "pod-0"},
+			{APIVersion: "v1", Kind: "Pod", Namespace: "kube-system", Name: "pod-1"},
+		},
+		Types:     types,
+		Reasons:   reasons,
+		Component: "test",
+		Workers:   4,
+	}
+}
+
+func TestGenerateCount(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset()
+	conf := testConfig(t)
+	conf.Count = 100
+
+	result, err := Generate(ctx, cli.CoreV1(), conf)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if result.Sent != 100 || result.Failed != 0 {
+		t.Fatalf("Generate() = %+v, want 100 sent", result)
+	}
+
+	events, err := cli.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Items) != 100 {
+		t.Fatalf("got %d events, want 100", len(events.Items))
+	}
+	types := map[string]int{}
+	for _, event := range events.Items {
+		types[event.Type]++
+		if event.Namespace != event.InvolvedObject.Namespace {
+			t.Errorf("event %s is in namespace %s, want %s", event.Name, event.Namespace, event.InvolvedObject.Namespace)
+		}
+		if event.Source.Component != "test" {
+			t.Errorf("event %s has source %q, want test", event.Name, event.Source.Component)
+		}
+	}
+	if types[corev1.EventTypeNormal] <= types[corev1.EventTypeWarning] {
+		t.Errorf("got types %v, want mostly Normal", types)
+	}
+}
+
+func TestGenerateRate(t *testing.T) {
+	conf := testConfig(t)
+	conf.Rate = 1000
+	conf.Duration = 300 * time.Millisecond
+
+	result, err := Generate(context.Background(), fake.NewSimpleClientset().CoreV1(), conf)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if result.Sent < 150 || result.Sent > 400 {
+		t.Errorf("Generate() sent %d events in %s, want about 300", result.Sent, result.Elapsed)
+	}
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	conf := testConfig(t)
+	_, err := Generate(context.Background(), fake.NewSimpleClientset().CoreV1(), conf)
+	if err == nil {
+		t.Errorf("Generate() without count and duration error = nil, want error")
+	}
+}
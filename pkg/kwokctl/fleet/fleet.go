@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fleet runs operations on multiple clusters concurrently.
+package fleet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// Result is the result of an operation on a cluster.
+type Result struct {
+	// Name is the name of the cluster.
+	Name string
+	// Err is the error of the operation, nil if it succeeded.
+	Err error
+	// Elapsed is the time taken by the operation.
+	Elapsed time.Duration
+}
+
+// MemberNames returns the names of the clusters of a fleet, which are the name with the index suffix.
+func MemberNames(name string, count int) []string {
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		names = append(names, fmt.Sprintf("%s-%d", name, i))
+	}
+	return names
+}
+
+// Run runs the operation on the clusters with a pool of workers,
+// logs the progress as each cluster is done and returns the results in the order of the names,
+// the returned error aggregates the errors of all failed clusters.
+func Run(ctx context.Context, names []string, workers int, fun func(ctx context.Context, name string) error) ([]Result, error) {
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(names) {
+		workers = len(names)
+	}
+
+	logger := log.FromContext(ctx)
+	results := make([]Result, len(names))
+
+	var (
+		mut    sync.Mutex
+		done   int
+		failed int
+	)
+	start := time.Now()
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				name := names[i]
+				s := time.Now()
+				err := fun(ctx, name)
+				results[i] = Result{
+					Name:    name,
+					Err:     err,
+					Elapsed: time.Since(s),
+				}
+
+				mut.Lock()
+				done++
+				if err != nil {
+					failed++
+				}
+				logger.Info("Progress",
+					"done", done,
+					"failed", failed,
+					"total", len(names),
+					"elapsed", time.Since(start),
+				)
+				mut.Unlock()
+			}
+		}()
+	}
+
+	for i := range names {
+		if ctx.Err() != nil {
+			results[i] = Result{Name: names[i], Err: ctx.Err()}
+			continue
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	errs := []error{}
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("cluster %q: %w", result.Name, result.Err))
+		}
+	}
+	if len(errs) != 0 {
+		return results, fmt.Errorf("%d of %d clusters failed: %w", len(errs), len(names), errors.Join(errs...))
+	}
+	return results, nil
+}
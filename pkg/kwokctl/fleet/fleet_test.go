@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+
+	var running, maxRunning int32
+	results, err := Run(context.Background(), names, 2, func(ctx context.Context, name string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if name == "b" || name == "d" {
+			return fmt.Errorf("failed %s", name)
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+	for _, want := range []string{`2 of 5 clusters failed`, `cluster "b": failed b`, `cluster "d": failed d`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Run() error = %v, want containing %q", err, want)
+		}
+	}
+	if maxRunning > 2 {
+		t.Errorf("Run() ran %d operations concurrently, want at most 2", maxRunning)
+	}
+
+	if len(results) != len(names) {
+		t.Fatalf("Run() got %d results, want %d", len(results), len(names))
+	}
+	for i, result := range results {
+		if result.Name != names[i] {
+			t.Errorf("Run() result %d is %q, want %q", i, result.Name, names[i])
+		}
+		if failed := result.Err != nil; failed != (result.Name == "b" || result.Name == "d") {
+			t.Errorf("Run() result of %q has error %v", result.Name, result.Err)
+		}
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var called int32
+	results, err := Run(ctx, []string{"a", "b"}, 1, func(ctx context.Context, name string) error {
+		atomic.AddInt32(&called, 1)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Run() error = nil, want error")
+	}
+	if called != 0 {
+		t.Errorf("Run() called the operation %d times after canceled", called)
+	}
+	if len(results) != 2 || results[0].Err == nil || results[1].Err == nil {
+		t.Errorf("Run() results = %v, want all canceled", results)
+	}
+}
+
+func TestMemberNames(t *testing.T) {
+	got := MemberNames("fleet", 3)
+	want := []string{"fleet-0", "fleet-1", "fleet-2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("MemberNames() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+const ociScheme = "oci://"
+
+// Fetch fetches the content from the source,
+// the source can be an OCI reference with oci:// prefix, an http(s) URL or a local file.
+func Fetch(ctx context.Context, src string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(src, ociScheme):
+		ref, err := ParseReference(strings.TrimPrefix(src, ociScheme))
+		if err != nil {
+			return nil, err
+		}
+		return fetchOCI(ctx, ref)
+	case strings.HasPrefix(src, "http://"), strings.HasPrefix(src, "https://"):
+		return fetchHTTP(ctx, src, nil)
+	default:
+		return file.Read(src)
+	}
+}
+
+// Reference is a reference to an OCI artifact.
+type Reference struct {
+	// Registry is the host of the registry.
+	Registry string
+	// Repository is the name of the repository.
+	Repository string
+	// Reference is the tag or the digest of the artifact.
+	Reference string
+}
+
+// ParseReference parses the OCI reference in the form of <registry>/<repository>[:<tag>|@<digest>].
+func ParseReference(s string) (Reference, error) {
+	registry, repository, ok := strings.Cut(s, "/")
+	if !ok || registry == "" || repository == "" {
+		return Reference{}, fmt.Errorf("invalid OCI reference %q, expected <registry>/<repository>[:<tag>|@<digest>]", s)
+	}
+
+	ref := Reference{
+		Registry:   registry,
+		Repository: repository,
+		Reference:  "latest",
+	}
+	if repo, digest, ok := strings.Cut(repository, "@"); ok {
+		ref.Repository = repo
+		ref.Reference = digest
+	} else if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		ref.Repository = repository[:i]
+		ref.Reference = repository[i+1:]
+	}
+	if ref.Repository == "" || ref.Reference == "" {
+		return Reference{}, fmt.Errorf("invalid OCI reference %q", s)
+	}
+	return ref, nil
+}
+
+func (r Reference) baseURL() string {
+	scheme := "https"
+	host := r.Registry
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+	if host == "localhost" || host == "127.0.0.1" {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Registry + "/v2/" + r.Repository
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// fetchOCI fetches the layers of the OCI artifact and joins them as a multi-document YAML.
+func fetchOCI(ctx context.Context, ref Reference) ([]byte, error) {
+	base := ref.baseURL()
+	header := http.Header{
+		"Accept": manifestMediaTypes,
+	}
+	data, err := fetchHTTP(ctx, base+"/manifests/"+ref.Reference, header)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(ref.Reference, "sha256:") {
+		err = VerifyDigest(data, ref.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: %w", err)
+		}
+	}
+
+	var manifest ociManifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("no layers in %s/%s", ref.Registry, ref.Repository)
+	}
+
+	var contents []string
+	for _, layer := range manifest.Layers {
+		blob, err := fetchHTTP(ctx, base+"/blobs/"+layer.Digest, nil)
+		if err != nil {
+			return nil, err
+		}
+		err = VerifyDigest(blob, layer.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("layer: %w", err)
+		}
+		contents = append(contents, string(blob))
+	}
+	return []byte(strings.Join(contents, "\n---\n")), nil
+}
+
+// fetchHTTP gets the content of the URL,
+// an anonymous token is requested if the server asks for a bearer token.
+func fetchHTTP(ctx context.Context, u string, header http.Header) ([]byte, error) {
+	logger := log.FromContext(ctx)
+	logger.Debug("Fetch", "uri", u)
+
+	resp, err := doGet(ctx, u, header)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		token, err := anonymousToken(ctx, challenge)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", u, err)
+		}
+		header = header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set("Authorization", "Bearer "+token)
+		resp, err = doGet(ctx, u, header)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func doGet(ctx context.Context, u string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", version.DefaultUserAgent())
+	return http.DefaultClient.Do(req)
+}
+
+// anonymousToken requests an anonymous token with the bearer challenge of the registry.
+func anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	values := url.Values{}
+	realm := ""
+	for _, param := range splitParams(params) {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		k = strings.TrimSpace(k)
+		v = strings.Trim(strings.TrimSpace(v), `"`)
+		if k == "realm" {
+			realm = v
+		} else {
+			values.Set(k, v)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("no realm in authentication challenge %q", challenge)
+	}
+
+	u := realm
+	if len(values) != 0 {
+		u += "?" + values.Encode()
+	}
+	resp, err := doGet(ctx, u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get token from %s: %s", realm, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("no token from %s", realm)
+}
+
+// splitParams splits the comma separated parameters, ignoring the commas in quotes.
+func splitParams(s string) []string {
+	var params []string
+	quoted := false
+	start := 0
+	for i, c := range s {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, s[start:])
+}
+
+// FetchWithCache fetches the content from the source and returns it with its digest.
+// If the digest is pinned, the content is verified against it and the cached content is used if present.
+func FetchWithCache(ctx context.Context, cacheDir, src, digest string) ([]byte, string, error) {
+	if digest != "" {
+		data, err := file.Read(CachePath(cacheDir, digest))
+		if err == nil && VerifyDigest(data, digest) == nil {
+			return data, digest, nil
+		}
+	}
+
+	data, err := Fetch(ctx, src)
+	if err != nil {
+		return nil, "", err
+	}
+	if digest != "" {
+		err = VerifyDigest(data, digest)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", src, err)
+		}
+	} else {
+		digest = Digest(data)
+	}
+
+	cache := CachePath(cacheDir, digest)
+	err = file.MkdirAll(path.Dir(cache))
+	if err != nil {
+		return nil, "", err
+	}
+	err = file.Write(cache, data)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, digest, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Reference
+		wantErr bool
+	}{
+		{
+			in:   "ghcr.io/kwok/stages:fast",
+			want: Reference{Registry: "ghcr.io", Repository: "kwok/stages", Reference: "fast"},
+		},
+		{
+			in:   "localhost:5000/stages",
+			want: Reference{Registry: "localhost:5000", Repository: "stages", Reference: "latest"},
+		},
+		{
+			in:   "ghcr.io/kwok/stages@sha256:abc",
+			want: Reference{Registry: "ghcr.io", Repository: "kwok/stages", Reference: "sha256:abc"},
+		},
+		{
+			in:      "stages",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseReference(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchWithCache(t *testing.T) {
+	content := []byte("kind: Stage\napiVersion: kwok.x-k8s.io/v1alpha1\nmetadata:\n  name: foo\n")
+	layerDigest := Digest(content)
+	manifest, _ := json.Marshal(ociManifest{
+		Layers: []ociDescriptor{{MediaType: "application/yaml", Digest: layerDigest}},
+	})
+
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/token" {
+			_, _ = fmt.Fprint(w, `{"token":"anonymous"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:stages:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/stages/manifests/fast":
+			_, _ = w.Write(manifest)
+		case "/v2/stages/blobs/" + layerDigest:
+			_, _ = w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	src := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/stages:fast"
+
+	data, digest, err := FetchWithCache(ctx, cacheDir, src, "")
+	if err != nil {
+		t.Fatalf("FetchWithCache() error = %v", err)
+	}
+	if string(data) != string(content) || digest != layerDigest {
+		t.Fatalf("FetchWithCache() = %q, %s, want %q, %s", data, digest, content, layerDigest)
+	}
+
+	requests = 0
+	_, _, err = FetchWithCache(ctx, cacheDir, src, digest)
+	if err != nil {
+		t.Fatalf("FetchWithCache() with pinned digest error = %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("FetchWithCache() with pinned digest sent %d requests, want cached", requests)
+	}
+
+	_, _, err = FetchWithCache(ctx, t.TempDir(), src, Digest([]byte("other")))
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("FetchWithCache() with wrong digest error = %v, want digest mismatch", err)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imports
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// IndexName is the name of the file in the workdir that records the imports.
+const IndexName = "imports.yaml"
+
+// Import is a configuration imported from a source.
+type Import struct {
+	// Kind is the kind of the imported objects.
+	Kind string `json:"kind"`
+	// Source is the URL or the OCI reference the objects are imported from.
+	Source string `json:"source"`
+	// Digest is the digest of the imported content.
+	Digest string `json:"digest"`
+	// Names is the names of the imported objects.
+	Names []string `json:"names,omitempty"`
+	// ImportTimestamp is the time when the objects are imported.
+	ImportTimestamp time.Time `json:"importTimestamp"`
+}
+
+// LoadIndex loads the index of imports, an empty index is returned if it does not exist.
+func LoadIndex(indexPath string) ([]Import, error) {
+	data, err := file.Read(indexPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var imports []Import
+	err = yaml.Unmarshal(data, &imports)
+	if err != nil {
+		return nil, err
+	}
+	return imports, nil
+}
+
+// AddToIndex adds the import to the index of imports,
+// the import with the same kind and source is replaced.
+func AddToIndex(indexPath string, imp Import) error {
+	imports, err := LoadIndex(indexPath)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, e := range imports {
+		if e.Kind == imp.Kind && e.Source == imp.Source {
+			imports[i] = imp
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		imports = append(imports, imp)
+	}
+
+	data, err := yaml.Marshal(imports)
+	if err != nil {
+		return err
+	}
+	err = file.MkdirAll(path.Dir(indexPath))
+	if err != nil {
+		return err
+	}
+	return file.Write(indexPath, data)
+}
+
+// Digest returns the sha256 digest of the content.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// VerifyDigest verifies the content matches the digest.
+func VerifyDigest(data []byte, digest string) error {
+	if !strings.HasPrefix(digest, "sha256:") {
+		return fmt.Errorf("unsupported digest %q, only sha256 is supported", digest)
+	}
+	if got := Digest(data); got != digest {
+		return fmt.Errorf("digest mismatch, got %s, want %s", got, digest)
+	}
+	return nil
+}
+
+// CachePath returns the path of the content with the digest in the cache.
+func CachePath(cacheDir string, digest string) string {
+	return path.Join(cacheDir, "imports", strings.Replace(digest, ":", "-", 1)+".yaml")
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"strings"
+)
+
+// apiRemoval is the APIs removed in a release of Kubernetes,
+// which are in the format of the runtime config, group/version or group/version/resource.
+type apiRemoval struct {
+	Release int
+	APIs    []string
+}
+
+// apiRemovals is the APIs removed in the releases of Kubernetes.
+// https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var apiRemovals = []apiRemoval{
+	{
+		Release: 16,
+		APIs: []string{
+			"apps/v1beta1",
+			"apps/v1beta2",
+			"extensions/v1beta1/daemonsets",
+			"extensions/v1beta1/deployments",
+			"extensions/v1beta1/replicasets",
+			"extensions/v1beta1/networkpolicies",
+			"extensions/v1beta1/podsecuritypolicies",
+		},
+	},
+	{
+		Release: 22,
+		APIs: []string{
+			"admissionregistration.k8s.io/v1beta1",
+			"apiextensions.k8s.io/v1beta1",
+			"apiregistration.k8s.io/v1beta1",
+			"authentication.k8s.io/v1beta1",
+			"authorization.k8s.io/v1beta1",
+			"certificates.k8s.io/v1beta1",
+			"coordination.k8s.io/v1beta1",
+			"extensions/v1beta1",
+			"networking.k8s.io/v1beta1",
+			"rbac.authorization.k8s.io/v1beta1",
+			"scheduling.k8s.io/v1beta1",
+			"storage.k8s.io/v1beta1/csidrivers",
+			"storage.k8s.io/v1beta1/csinodes",
+			"storage.k8s.io/v1beta1/storageclasses",
+			"storage.k8s.io/v1beta1/volumeattachments",
+		},
+	},
+	{
+		Release: 25,
+		APIs: []string{
+			"batch/v1beta1",
+			"discovery.k8s.io/v1beta1",
+			"events.k8s.io/v1beta1",
+			"autoscaling/v2beta1",
+			"policy/v1beta1",
+			"node.k8s.io/v1beta1",
+		},
+	},
+	{
+		Release: 26,
+		APIs: []string{
+			"flowcontrol.apiserver.k8s.io/v1beta1",
+			"autoscaling/v2beta2",
+		},
+	},
+	{
+		Release: 27,
+		APIs: []string{
+			"storage.k8s.io/v1beta1",
+		},
+	},
+	{
+		Release: 29,
+		APIs: []string{
+			"flowcontrol.apiserver.k8s.io/v1beta2",
+		},
+	},
+	{
+		Release: 32,
+		APIs: []string{
+			"flowcontrol.apiserver.k8s.io/v1beta3",
+		},
+	},
+}
+
+// GetRemovedAPIs returns the APIs served by the release of Kubernetes but removed by the target release.
+func GetRemovedAPIs(release, target int) []string {
+	apis := []string{}
+	for _, removal := range apiRemovals {
+		if removal.Release > release && removal.Release <= target {
+			apis = append(apis, removal.APIs...)
+		}
+	}
+	return apis
+}
+
+// GetEmulateRemovalsRuntimeConfig returns the runtime configuration to disable the APIs
+// served by the release of Kubernetes but removed by the target release.
+func GetEmulateRemovalsRuntimeConfig(release, target int) string {
+	apis := GetRemovedAPIs(release, target)
+	configs := make([]string, 0, len(apis))
+	for _, api := range apis {
+		configs = append(configs, api+"=false")
+	}
+	return strings.Join(configs, ",")
+}
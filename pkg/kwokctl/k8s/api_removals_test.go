@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+)
+
+func TestGetEmulateRemovalsRuntimeConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		release  int
+		target   int
+		expected string
+	}{
+		{"Same release", 30, 30, ""},
+		{"Older target", 30, 25, ""},
+		{"No removals", 30, 31, ""},
+		{"One removal", 30, 33, "flowcontrol.apiserver.k8s.io/v1beta3=false"},
+		{"Several removals", 25, 27, "flowcontrol.apiserver.k8s.io/v1beta1=false,autoscaling/v2beta2=false,storage.k8s.io/v1beta1=false"},
+		{"Resources of a group version", 21, 22, "admissionregistration.k8s.io/v1beta1=false,apiextensions.k8s.io/v1beta1=false,apiregistration.k8s.io/v1beta1=false,authentication.k8s.io/v1beta1=false,authorization.k8s.io/v1beta1=false,certificates.k8s.io/v1beta1=false,coordination.k8s.io/v1beta1=false,extensions/v1beta1=false,networking.k8s.io/v1beta1=false,rbac.authorization.k8s.io/v1beta1=false,scheduling.k8s.io/v1beta1=false,storage.k8s.io/v1beta1/csidrivers=false,storage.k8s.io/v1beta1/csinodes=false,storage.k8s.io/v1beta1/storageclasses=false,storage.k8s.io/v1beta1/volumeattachments=false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetEmulateRemovalsRuntimeConfig(tt.release, tt.target)
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
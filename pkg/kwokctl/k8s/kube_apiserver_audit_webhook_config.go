@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	_ "embed"
+)
+
+//go:embed kube_apiserver_audit_webhook_config.yaml.tpl
+var kubeApiserverAuditWebhookConfigYamlTpl string
+
+var kubeApiserverAuditWebhookConfigYamlTemplate = template.Must(template.New("kube_apiserver_audit_webhook_config").Parse(kubeApiserverAuditWebhookConfigYamlTpl))
+
+// BuildKubeApiserverAuditWebhookConfig builds the kubeconfig of the audit webhook backend from the given parameters.
+func BuildKubeApiserverAuditWebhookConfig(conf BuildKubeApiserverAuditWebhookConfigParam) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	err := kubeApiserverAuditWebhookConfigYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("build apiserverAuditWebhookConfig error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildKubeApiserverAuditWebhookConfigParam is the configuration for BuildKubeApiserverAuditWebhookConfig.
+type BuildKubeApiserverAuditWebhookConfigParam struct {
+	Server string
+}
@@ -0,0 +1,11 @@
+apiVersion: v1
+kind: Config
+clusters:
+- name: audit-webhook
+  cluster:
+    server: {{ .Server }}
+contexts:
+- name: audit-webhook
+  context:
+    cluster: audit-webhook
+current-context: audit-webhook
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestBuildKubeApiserverAuditWebhookConfig(t *testing.T) {
+	data, err := BuildKubeApiserverAuditWebhookConfig(BuildKubeApiserverAuditWebhookConfigParam{
+		Server: "http://kwok-kwok-kube-audit-sink:8080",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverAuditWebhookConfig() error = %v", err)
+	}
+
+	config, err := clientcmd.Load([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to load the kubeconfig: %v", err)
+	}
+	cluster, ok := config.Clusters[config.Contexts[config.CurrentContext].Cluster]
+	if !ok {
+		t.Fatalf("no cluster of the current context in %s", data)
+	}
+	if cluster.Server != "http://kwok-kwok-kube-audit-sink:8080" {
+		t.Errorf("server = %q, want %q", cluster.Server, "http://kwok-kwok-kube-audit-sink:8080")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	_ "embed"
+)
+
+//go:embed kube_apiserver_egress_selector_config.yaml.tpl
+var kubeApiserverEgressSelectorConfigYamlTpl string
+
+var kubeApiserverEgressSelectorConfigYamlTemplate = template.Must(template.New("kube_apiserver_egress_selector_config").Parse(kubeApiserverEgressSelectorConfigYamlTpl))
+
+// BuildKubeApiserverEgressSelectorConfig builds the egress selector configuration of kube-apiserver,
+// the traffic to the cluster is sent to the konnectivity-server by HTTP CONNECT over mTLS.
+func BuildKubeApiserverEgressSelectorConfig(conf BuildKubeApiserverEgressSelectorConfigParam) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	err := kubeApiserverEgressSelectorConfigYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("build apiserverEgressSelectorConfig error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildKubeApiserverEgressSelectorConfigParam is the configuration for BuildKubeApiserverEgressSelectorConfig.
+type BuildKubeApiserverEgressSelectorConfigParam struct {
+	URL        string
+	CaCertPath string
+	CertPath   string
+	KeyPath    string
+}
@@ -0,0 +1,13 @@
+apiVersion: apiserver.k8s.io/v1beta1
+kind: EgressSelectorConfiguration
+egressSelections:
+- name: cluster
+  connection:
+    proxyProtocol: HTTPConnect
+    transport:
+      tcp:
+        url: {{ .URL }}
+        tlsConfig:
+          caBundle: {{ .CaCertPath }}
+          clientCert: {{ .CertPath }}
+          clientKey: {{ .KeyPath }}
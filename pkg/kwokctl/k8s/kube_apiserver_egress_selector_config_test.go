@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBuildKubeApiserverEgressSelectorConfig(t *testing.T) {
+	data, err := BuildKubeApiserverEgressSelectorConfig(BuildKubeApiserverEgressSelectorConfigParam{
+		URL:        "https://kwok-kwok-konnectivity-server:8131",
+		CaCertPath: "/etc/kubernetes/pki/ca.crt",
+		CertPath:   "/etc/kubernetes/pki/admin.crt",
+		KeyPath:    "/etc/kubernetes/pki/admin.key",
+	})
+	if err != nil {
+		t.Fatalf("BuildKubeApiserverEgressSelectorConfig() error = %v", err)
+	}
+
+	var config apiserverv1beta1.EgressSelectorConfiguration
+	err = yaml.UnmarshalStrict([]byte(data), &config)
+	if err != nil {
+		t.Fatalf("failed to unmarshal the egress selector configuration: %v", err)
+	}
+	if len(config.EgressSelections) != 1 || config.EgressSelections[0].Name != "cluster" {
+		t.Fatalf("egressSelections = %v, want only the cluster", config.EgressSelections)
+	}
+	connection := config.EgressSelections[0].Connection
+	if connection.ProxyProtocol != apiserverv1beta1.ProtocolHTTPConnect {
+		t.Errorf("proxyProtocol = %q, want %q", connection.ProxyProtocol, apiserverv1beta1.ProtocolHTTPConnect)
+	}
+	if connection.Transport == nil || connection.Transport.TCP == nil {
+		t.Fatalf("transport = %v, want tcp", connection.Transport)
+	}
+	tcp := connection.Transport.TCP
+	if tcp.URL != "https://kwok-kwok-konnectivity-server:8131" {
+		t.Errorf("url = %q, want %q", tcp.URL, "https://kwok-kwok-konnectivity-server:8131")
+	}
+	if tcp.TLSConfig == nil || tcp.TLSConfig.ClientCert != "/etc/kubernetes/pki/admin.crt" {
+		t.Errorf("tlsConfig = %v, want the admin cert", tcp.TLSConfig)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"os"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/kwok/pkg/utils/slices"
@@ -47,14 +49,34 @@ var (
 
 // GeneratePki generates the pki for kwokctl
 func GeneratePki(pkiPath string, sans ...string) error {
+	return GeneratePkiWithCA(pkiPath, "", "", sans...)
+}
+
+// GeneratePkiWithCA generates the pki for kwokctl with an existing CA, which is copied into the pkiPath,
+// a new CA is generated if the caCertPath is empty.
+func GeneratePkiWithCA(pkiPath, caCertPath, caKeyPath string, sans ...string) error {
 	now := time.Now()
 	notBefore := now.Add(-24 * time.Hour).UTC()
 	notAfter := now.Add(CertificateValidity).UTC()
 
-	// Generate CA
-	caCert, caKey, err := GenerateCA("kwok-ca", notBefore, notAfter)
-	if err != nil {
-		return fmt.Errorf("failed to generate CA: %w", err)
+	var caCert *x509.Certificate
+	var caKey crypto.Signer
+	var err error
+	if caCertPath == "" {
+		// Generate CA
+		caCert, caKey, err = GenerateCA("kwok-ca", notBefore, notAfter)
+		if err != nil {
+			return fmt.Errorf("failed to generate CA: %w", err)
+		}
+	} else {
+		caCert, caKey, err = ReadCertAndKeyFile(caCertPath, caKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to read CA: %w", err)
+		}
+		if !caCert.IsCA {
+			return fmt.Errorf("the certificate %s is not a CA", caCertPath)
+		}
+		notBefore, notAfter = withinCA(caCert, notBefore, notAfter)
 	}
 	err = WriteCertAndKey(pkiPath, "ca", caCert, caKey)
 	if err != nil {
@@ -77,6 +99,71 @@ func GeneratePki(pkiPath string, sans ...string) error {
 	return nil
 }
 
+// RenewCerts renews the certs in the pkiPath signed by its CA which expire before the deadline,
+// the keys, subjects and SANs of the certs are kept, and the names of the renewed ones are returned.
+func RenewCerts(pkiPath string, deadline time.Time) (renewed []string, err error) {
+	caCert, caKey, err := ReadCertAndKey(pkiPath, "ca")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA: %w", err)
+	}
+
+	now := time.Now()
+	if !caCert.NotAfter.After(now) {
+		return nil, fmt.Errorf("the CA has expired at %s, the certs cannot be renewed", caCert.NotAfter)
+	}
+	notBefore, notAfter := withinCA(caCert, now.Add(-24*time.Hour).UTC(), now.Add(CertificateValidity).UTC())
+
+	entries, err := os.ReadDir(pkiPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".crt")
+		if !ok || entry.IsDir() || name == "ca" {
+			continue
+		}
+		cert, key, err := ReadCertAndKey(pkiPath, name)
+		if err != nil {
+			return renewed, fmt.Errorf("failed to read %s cert and key: %w", name, err)
+		}
+		if cert.NotAfter.After(deadline) || cert.CheckSignatureFrom(caCert) != nil {
+			continue
+		}
+
+		cert, err = NewSignedCert(CertConfig{
+			CommonName:   cert.Subject.CommonName,
+			Organization: cert.Subject.Organization,
+			AltNames: AltNames{
+				DNSNames: cert.DNSNames,
+				IPs:      cert.IPAddresses,
+			},
+			Usages:    cert.ExtKeyUsage,
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		}, key, caCert, caKey, false)
+		if err != nil {
+			return renewed, fmt.Errorf("failed to renew %s cert: %w", name, err)
+		}
+		err = writeCert(pkiPath, name, cert)
+		if err != nil {
+			return renewed, fmt.Errorf("failed to write %s cert: %w", name, err)
+		}
+		renewed = append(renewed, name)
+	}
+	return renewed, nil
+}
+
+// withinCA returns the validity period trimmed to the one of the CA, as the certs signed by it cannot outlive it.
+func withinCA(caCert *x509.Certificate, notBefore, notAfter time.Time) (time.Time, time.Time) {
+	if notBefore.Before(caCert.NotBefore) {
+		notBefore = caCert.NotBefore
+	}
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	return notBefore, notAfter
+}
+
 // GenerateCA generates a CA certificate and key.
 func GenerateCA(cn string, notBefore, notAfter time.Time) (cert *x509.Certificate, key crypto.Signer, err error) {
 	return NewCertificateAuthority(CertConfig{
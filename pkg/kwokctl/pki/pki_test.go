@@ -17,6 +17,7 @@ limitations under the License.
 package pki
 
 import (
+	"crypto"
 	"fmt"
 	"testing"
 	"time"
@@ -44,3 +45,90 @@ func TestGeneratePki(t *testing.T) {
 
 	_ = EncodeCertToPEM(cert)
 }
+
+func TestGeneratePkiWithCA(t *testing.T) {
+	now := time.Now()
+	caCert, caKey, err := GenerateCA("custom-ca", now.Add(-time.Hour).UTC(), now.Add(24*time.Hour).UTC())
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to generate CA: %w", err))
+	}
+	caDir := t.TempDir()
+	err = WriteCertAndKey(caDir, "custom-ca", caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkiPath := t.TempDir()
+	err = GeneratePkiWithCA(pkiPath, pathForCert(caDir, "custom-ca"), pathForKey(caDir, "custom-ca"))
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to generate pki: %w", err))
+	}
+
+	cert, _, err := ReadCertAndKey(pkiPath, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		t.Errorf("admin cert is not signed by the CA: %v", err)
+	}
+	if cert.NotAfter.After(caCert.NotAfter) {
+		t.Errorf("admin cert expires at %s, want not after the CA %s", cert.NotAfter, caCert.NotAfter)
+	}
+
+	err = GeneratePkiWithCA(t.TempDir(), pathForCert(pkiPath, "admin"), pathForKey(pkiPath, "admin"))
+	if err == nil {
+		t.Errorf("GeneratePkiWithCA() error = nil, want an error for a cert which is not a CA")
+	}
+}
+
+func TestRenewCerts(t *testing.T) {
+	now := time.Now()
+	pkiPath := t.TempDir()
+
+	caCert, caKey, err := GenerateCA("kwok-ca", now.Add(-time.Hour).UTC(), now.Add(CertificateValidity).UTC())
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to generate CA: %w", err))
+	}
+	err = WriteCertAndKey(pkiPath, "ca", caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, key, err := GenerateSignCert("kwok-admin", caCert, caKey, now.Add(-time.Hour).UTC(), now.Add(time.Hour).UTC(), DefaultGroups, DefaultAltNames)
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to generate admin cert and key: %w", err))
+	}
+	err = WriteCertAndKey(pkiPath, "admin", cert, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renewed, err := RenewCerts(pkiPath, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(renewed) != 0 {
+		t.Errorf("RenewCerts() = %v, want no certs renewed before they expire", renewed)
+	}
+
+	renewed, err = RenewCerts(pkiPath, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(renewed) != 1 || renewed[0] != "admin" {
+		t.Fatalf("RenewCerts() = %v, want the admin cert renewed", renewed)
+	}
+
+	got, gotKey, err := ReadCertAndKey(pkiPath, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.NotAfter.After(now.Add(24 * time.Hour)) {
+		t.Errorf("renewed cert expires at %s", got.NotAfter)
+	}
+	if got.Subject.CommonName != cert.Subject.CommonName || len(got.IPAddresses) != len(cert.IPAddresses) || len(got.DNSNames) != len(cert.DNSNames) {
+		t.Errorf("renewed cert %v, want the same subject and SANs as %v", got.Subject, cert.Subject)
+	}
+	if !gotKey.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(key.Public()) {
+		t.Errorf("renewed cert has a different key")
+	}
+}
@@ -42,6 +42,8 @@ const (
 	ECPrivateKeyBlockType = "EC PRIVATE KEY"
 	// RSAPrivateKeyBlockType is a possible value for pem.Block.Type.
 	RSAPrivateKeyBlockType = "RSA PRIVATE KEY"
+	// PrivateKeyBlockType is a possible value for pem.Block.Type of a PKCS#8 key.
+	PrivateKeyBlockType = "PRIVATE KEY"
 
 	// CertificateValidity is the validity period of a certificate.
 	CertificateValidity = 100 * 365 * 24 * time.Hour
@@ -222,9 +224,28 @@ func ReadCertAndKey(pkiPath string, name string) (*x509.Certificate, crypto.Sign
 	return cert, key, nil
 }
 
+// ReadCertAndKeyFile reads certificate and key from the specified files
+func ReadCertAndKeyFile(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error) {
+	cert, err := readCertFile(certPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read certificate: %w", err)
+	}
+
+	key, err := readKeyFile(keyPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read key: %w", err)
+	}
+
+	return cert, key, nil
+}
+
 // readCert reads certificate from the specified location
 func readCert(pkiPath, name string) (*x509.Certificate, error) {
-	certificatePath := pathForCert(pkiPath, name)
+	return readCertFile(pathForCert(pkiPath, name))
+}
+
+// readCertFile reads certificate from the specified file
+func readCertFile(certificatePath string) (*x509.Certificate, error) {
 	certBytes, err := readFile(certificatePath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read certificate from file %s: %w", certificatePath, err)
@@ -234,7 +255,11 @@ func readCert(pkiPath, name string) (*x509.Certificate, error) {
 
 // readKey reads key from the specified location
 func readKey(pkiPath, name string) (crypto.Signer, error) {
-	keyPath := pathForKey(pkiPath, name)
+	return readKeyFile(pathForKey(pkiPath, name))
+}
+
+// readKeyFile reads key from the specified file
+func readKeyFile(keyPath string) (crypto.Signer, error) {
 	keyBytes, err := readFile(keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read key from file %s: %w", keyPath, err)
@@ -262,6 +287,16 @@ func decodeKeyPEM(keyPEM []byte) (crypto.Signer, error) {
 		return x509.ParsePKCS1PrivateKey(block.Bytes)
 	case ECPrivateKeyBlockType:
 		return x509.ParseECPrivateKey(block.Bytes)
+	case PrivateKeyBlockType:
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
 	}
 	return nil, fmt.Errorf("unsupported key type %q", block.Type)
 }
@@ -0,0 +1,326 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preemption generates the pods across priority classes
+// in an arrival pattern that triggers preemption, and measures the preemption.
+package preemption
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+const (
+	// RunLabel is the label of the pods with the id of the run.
+	RunLabel = "kwok.x-k8s.io/preemption-run"
+	// ClassLabel is the label of the pods with the name of the priority class.
+	ClassLabel = "kwok.x-k8s.io/preemption-class"
+)
+
+// Class is a priority class of the pods to generate.
+type Class struct {
+	// Name is the name of the priority class.
+	Name string
+	// Value is the priority of the priority class.
+	Value int32
+	// Count is the number of the pods to create.
+	Count int
+}
+
+// ParseClass parses the class in the format of name=value:count.
+func ParseClass(s string) (Class, error) {
+	name, rest, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return Class{}, fmt.Errorf("invalid class %q, expected name=value:count", s)
+	}
+	value, count, ok := strings.Cut(rest, ":")
+	if !ok {
+		return Class{}, fmt.Errorf("invalid class %q, expected name=value:count", s)
+	}
+	v, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return Class{}, fmt.Errorf("invalid value of class %q: %w", s, err)
+	}
+	c, err := strconv.Atoi(count)
+	if err != nil || c < 0 {
+		return Class{}, fmt.Errorf("invalid count of class %q", s)
+	}
+	return Class{
+		Name:  name,
+		Value: int32(v),
+		Count: c,
+	}, nil
+}
+
+// Config is the configuration of the scenario.
+type Config struct {
+	// ID is the id of the run, it is the prefix of the names of the pods.
+	ID string
+	// Namespace is the namespace of the pods.
+	Namespace string
+	// Classes is the priority classes, the pods arrive class by class from the lowest priority.
+	Classes []Class
+	// Requests is the resource requests of each pod.
+	Requests corev1.ResourceList
+	// Rate is the number of pods created per second, 0 means as fast as possible.
+	Rate float64
+	// Interval is the interval between the arrival of the classes.
+	Interval time.Duration
+	// Timeout is the time to wait for the pods to be scheduled after they are created.
+	Timeout time.Duration
+}
+
+// Result is the result of a priority class.
+type Result struct {
+	// Class is the name of the priority class.
+	Class string
+	// Value is the priority of the priority class.
+	Value int32
+	// Created is the number of pods created.
+	Created int
+	// Scheduled is the number of pods scheduled and not preempted.
+	Scheduled int
+	// Pending is the number of pods not scheduled yet.
+	Pending int
+	// Preempted is the number of pods preempted.
+	Preempted int
+	// LatencyP50 is the median latency from the creation to the scheduling of the pods.
+	LatencyP50 time.Duration
+	// LatencyP99 is the 99th percentile latency from the creation to the scheduling of the pods.
+	LatencyP99 time.Duration
+	// LatencyMax is the maximum latency from the creation to the scheduling of the pods.
+	LatencyMax time.Duration
+}
+
+// Run creates the priority classes and the pods, and waits for them to be scheduled or preempted.
+func Run(ctx context.Context, cli kubernetes.Interface, conf Config) ([]Result, error) {
+	logger := log.FromContext(ctx)
+
+	classes := append([]Class{}, conf.Classes...)
+	sort.SliceStable(classes, func(i, j int) bool {
+		return classes[i].Value < classes[j].Value
+	})
+
+	for _, class := range classes {
+		err := ensurePriorityClass(ctx, cli, class)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created := map[string]int{}
+	for i, class := range classes {
+		if i != 0 && conf.Interval > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(conf.Interval):
+			}
+		}
+		logger.Info("Creating pods", "class", class.Name, "value", class.Value, "count", class.Count)
+		for index := 0; index < class.Count; index++ {
+			if index != 0 && conf.Rate > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(time.Duration(float64(time.Second) / conf.Rate)):
+				}
+			}
+			_, err := cli.CoreV1().Pods(conf.Namespace).Create(ctx, newPod(conf, class, index), metav1.CreateOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create pod of class %s: %w", class.Name, err)
+			}
+			created[class.Name]++
+		}
+	}
+
+	timeoutCtx := ctx
+	if conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		timeoutCtx, cancel = context.WithTimeout(ctx, conf.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		pods, err := cli.CoreV1().Pods(conf.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: RunLabel + "=" + conf.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		results := Summarize(classes, created, pods.Items)
+		pending := 0
+		for _, r := range results {
+			pending += r.Pending
+		}
+		if pending == 0 {
+			return results, nil
+		}
+		select {
+		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Warn("Timed out waiting for the pods to be scheduled", "pending", pending)
+			return results, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func ensurePriorityClass(ctx context.Context, cli kubernetes.Interface, class Class) error {
+	pc, err := cli.SchedulingV1().PriorityClasses().Get(ctx, class.Name, metav1.GetOptions{})
+	if err == nil {
+		if pc.Value != class.Value {
+			return fmt.Errorf("priority class %s already exists with value %d, not %d", class.Name, pc.Value, class.Value)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+	_, err = cli.SchedulingV1().PriorityClasses().Create(ctx, &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: class.Name,
+		},
+		Value: class.Value,
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func newPod(conf Config, class Class, index int) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%d", conf.ID, class.Name, index),
+			Namespace: conf.Namespace,
+			Labels: map[string]string{
+				RunLabel:   conf.ID,
+				ClassLabel: class.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			PriorityClassName: class.Name,
+			Containers: []corev1.Container{
+				{
+					Name:  "container",
+					Image: "image",
+					Resources: corev1.ResourceRequirements{
+						Requests: conf.Requests,
+					},
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+		},
+	}
+}
+
+// Summarize summarizes the pods of each class, the created pods that no longer exist are preempted.
+func Summarize(classes []Class, created map[string]int, pods []corev1.Pod) []Result {
+	results := make([]Result, 0, len(classes))
+	for _, class := range classes {
+		r := Result{
+			Class:   class.Name,
+			Value:   class.Value,
+			Created: created[class.Name],
+		}
+		latencies := []time.Duration{}
+		existing := 0
+		for _, pod := range pods {
+			if pod.Labels[ClassLabel] != class.Name {
+				continue
+			}
+			existing++
+			if isPreempted(&pod) {
+				r.Preempted++
+				continue
+			}
+			scheduled, ok := scheduledCondition(&pod)
+			if !ok {
+				r.Pending++
+				continue
+			}
+			r.Scheduled++
+			latencies = append(latencies, scheduled.LastTransitionTime.Sub(pod.CreationTimestamp.Time))
+		}
+		if existing < r.Created {
+			r.Preempted += r.Created - existing
+		}
+
+		sort.Slice(latencies, func(i, j int) bool {
+			return latencies[i] < latencies[j]
+		})
+		r.LatencyP50 = percentile(latencies, 0.5)
+		r.LatencyP99 = percentile(latencies, 0.99)
+		r.LatencyMax = percentile(latencies, 1)
+		results = append(results, r)
+	}
+	return results
+}
+
+func isPreempted(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return true
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.DisruptionTarget && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func scheduledCondition(pod *corev1.Pod) (corev1.PodCondition, bool) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionTrue {
+			return cond, true
+		}
+	}
+	return corev1.PodCondition{}, false
+}
+
+// percentile returns the percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
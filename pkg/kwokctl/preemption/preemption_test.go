@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseClass(t *testing.T) {
+	got, err := ParseClass("high=1000:5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Class{Name: "high", Value: 1000, Count: 5}
+	if got != want {
+		t.Errorf("ParseClass() = %v, want %v", got, want)
+	}
+
+	for _, s := range []string{"high", "high=1000", "=1000:5", "high=x:5", "high=1000:-1"} {
+		if _, err := ParseClass(s); err == nil {
+			t.Errorf("ParseClass(%q) expected error", s)
+		}
+	}
+}
+
+func newTestPod(name, class string, created time.Time, scheduledAfter time.Duration) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            map[string]string{ClassLabel: class},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	if scheduledAfter >= 0 {
+		pod.Status.Conditions = []corev1.PodCondition{
+			{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(created.Add(scheduledAfter)),
+			},
+		}
+	}
+	return pod
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Now()
+	classes := []Class{
+		{Name: "low", Value: 100, Count: 3},
+		{Name: "high", Value: 1000, Count: 2},
+	}
+	created := map[string]int{"low": 3, "high": 2}
+
+	disrupted := newTestPod("low-1", "low", now, time.Second)
+	disrupted.Status.Conditions = append(disrupted.Status.Conditions, corev1.PodCondition{
+		Type:   corev1.DisruptionTarget,
+		Status: corev1.ConditionTrue,
+	})
+	pods := []corev1.Pod{
+		newTestPod("low-0", "low", now, time.Second),
+		disrupted,
+		newTestPod("high-0", "high", now, 2*time.Second),
+		newTestPod("high-1", "high", now, -1),
+	}
+
+	got := Summarize(classes, created, pods)
+	want := []Result{
+		{Class: "low", Value: 100, Created: 3, Scheduled: 1, Preempted: 2, LatencyP50: time.Second, LatencyP99: time.Second, LatencyMax: time.Second},
+		{Class: "high", Value: 1000, Created: 2, Scheduled: 1, Pending: 1, LatencyP50: 2 * time.Second, LatencyP99: 2 * time.Second, LatencyMax: 2 * time.Second},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	cli := fake.NewSimpleClientset()
+	conf := Config{
+		ID:        "test",
+		Namespace: "default",
+		Classes: []Class{
+			{Name: "high", Value: 1000, Count: 1},
+			{Name: "low", Value: 100, Count: 2},
+		},
+		Timeout: time.Millisecond,
+	}
+
+	results, err := Run(ctx, cli, conf)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Class != "low" || results[0].Pending != 2 || results[1].Pending != 1 {
+		t.Errorf("Run() = %+v", results)
+	}
+
+	pc, err := cli.SchedulingV1().PriorityClasses().Get(ctx, "high", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc.Value != 1000 {
+		t.Errorf("unexpected value of priority class %d", pc.Value)
+	}
+
+	conf.Classes[0].Value = 10
+	_, err = Run(ctx, cli, conf)
+	if err == nil {
+		t.Errorf("expected error of the conflicting priority class")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// componentArg is an argument of a component,
+// it is either a flag with or without a value, or a positional argument.
+type componentArg struct {
+	// raw is the argument as it is passed to the component.
+	raw string
+	// key is the name of the flag without the leading dashes, it is empty for a positional argument.
+	key string
+}
+
+// parseComponentArgs parses the arguments of a component in their order,
+// everything after the "--" terminator is positional.
+func parseComponentArgs(args []string) []componentArg {
+	out := make([]componentArg, 0, len(args))
+	terminated := false
+	for _, arg := range args {
+		a := componentArg{raw: arg}
+		if !terminated {
+			switch {
+			case arg == "--":
+				terminated = true
+			case strings.HasPrefix(arg, "-") && arg != "-":
+				key := strings.TrimLeft(arg, "-")
+				key, _, _ = strings.Cut(key, "=")
+				a.key = key
+			}
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// applyComponentPatchArgs applies the extra args of a patch to the arguments of a component.
+// The flags of the patch replace all the occurrences of the same flag in place of the first one,
+// so a flag repeated in the patch stays repeated, and the new flags are added before the "--" terminator.
+// The other flags, with or without a value, and the positional arguments keep their order.
+func applyComponentPatchArgs(args []string, extraArgs []internalversion.ExtraArgs) []string {
+	if len(extraArgs) == 0 {
+		return args
+	}
+
+	patches := map[string][]string{}
+	keys := []string{}
+	for _, a := range extraArgs {
+		if _, ok := patches[a.Key]; !ok {
+			keys = append(keys, a.Key)
+		}
+		patches[a.Key] = append(patches[a.Key], "--"+a.Key+"="+a.Value)
+	}
+
+	parsed := parseComponentArgs(args)
+	out := make([]string, 0, len(args)+len(extraArgs))
+	applied := map[string]bool{}
+	terminator := -1
+	for _, a := range parsed {
+		if a.key == "" {
+			if a.raw == "--" && terminator < 0 {
+				terminator = len(out)
+			}
+			out = append(out, a.raw)
+			continue
+		}
+		values, ok := patches[a.key]
+		if !ok {
+			out = append(out, a.raw)
+			continue
+		}
+		if !applied[a.key] {
+			applied[a.key] = true
+			out = append(out, values...)
+		}
+	}
+
+	var added []string
+	for _, key := range keys {
+		if !applied[key] {
+			added = append(added, patches[key]...)
+		}
+	}
+	if len(added) == 0 {
+		return out
+	}
+	if terminator < 0 {
+		return append(out, added...)
+	}
+	return append(out[:terminator], append(added, out[terminator:]...)...)
+}
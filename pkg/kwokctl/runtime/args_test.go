@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestApplyComponentPatchArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		extraArgs []internalversion.ExtraArgs
+		want      []string
+	}{
+		{
+			name: "no patch",
+			args: []string{"--b=1", "--a=2"},
+			want: []string{"--b=1", "--a=2"},
+		},
+		{
+			name: "replace in place",
+			args: []string{"--b=1", "--a=2", "--c=3"},
+			extraArgs: []internalversion.ExtraArgs{
+				{Key: "a", Value: "4"},
+			},
+			want: []string{"--b=1", "--a=4", "--c=3"},
+		},
+		{
+			name: "keep flags without values and positional args",
+			args: []string{"serve", "--verbose", "-v=4", "--a=1", "data"},
+			extraArgs: []internalversion.ExtraArgs{
+				{Key: "a", Value: "2"},
+				{Key: "d", Value: "3"},
+			},
+			want: []string{"serve", "--verbose", "-v=4", "--a=2", "data", "--d=3"},
+		},
+		{
+			name: "repeated flags",
+			args: []string{"--x=1", "--feature=a", "--y=2", "--feature=b"},
+			extraArgs: []internalversion.ExtraArgs{
+				{Key: "feature", Value: "c"},
+				{Key: "feature", Value: "d"},
+			},
+			want: []string{"--x=1", "--feature=c", "--feature=d", "--y=2"},
+		},
+		{
+			name: "add before terminator",
+			args: []string{"--a=1", "--", "--a=positional"},
+			extraArgs: []internalversion.ExtraArgs{
+				{Key: "a", Value: "2"},
+				{Key: "b", Value: "3"},
+			},
+			want: []string{"--a=2", "--b=3", "--", "--a=positional"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyComponentPatchArgs(tt.args, tt.extraArgs)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("applyComponentPatchArgs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,585 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attach
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+const (
+	// manifestsName is the name of the manifests of the kwok-controller in the existing cluster.
+	manifestsName = "attach.yaml"
+
+	// manageNodesWithAnnotationSelector selects the nodes created by kwokctl,
+	// so the real nodes of the existing cluster are never touched.
+	manageNodesWithAnnotationSelector = "kwok.x-k8s.io/node=fake"
+)
+
+// Cluster is an implementation of Runtime for attaching the kwok-controller to an existing cluster
+type Cluster struct {
+	*runtime.Cluster
+}
+
+// NewCluster creates a new Runtime for attaching the kwok-controller to an existing cluster
+func NewCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster: runtime.NewCluster(name, workdir),
+	}, nil
+}
+
+// Available checks whether the runtime is available.
+func (c *Cluster) Available(ctx context.Context) error {
+	if c.IsDryRun() {
+		return nil
+	}
+	_, err := c.KubectlPath(ctx)
+	return err
+}
+
+// isLocal returns true if the kwok-controller runs as a local process.
+func (c *Cluster) isLocal(ctx context.Context) (bool, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false, err
+	}
+	return config.Options.AttachLocal, nil
+}
+
+// saveKubeconfig saves the current context of the kubeconfig of the existing cluster into the workdir with the credentials inlined,
+// so the subsequent commands of the cluster keep working after the kubeconfig is changed.
+func (c *Cluster) saveKubeconfig(ctx context.Context, kubeconfigPath string) error {
+	kubeconfigPath, err := path.Expand(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigBuf := bytes.NewBuffer(nil)
+	err = c.Kubectl(exec.WithWriteTo(ctx, kubeconfigBuf), "--kubeconfig", kubeconfigPath, "config", "view", "--minify=true", "--raw=true", "--flatten=true")
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig %s: %w", kubeconfigPath, err)
+	}
+
+	return c.WriteFileWithMode(c.GetWorkdirPath(runtime.InHostKubeconfigName), kubeconfigBuf.Bytes(), 0600)
+}
+
+// Install installs the cluster
+func (c *Cluster) Install(ctx context.Context) error {
+	err := c.Cluster.Install(ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	if conf.AttachKubeconfig == "" {
+		return fmt.Errorf("the kubeconfig of the existing cluster is required by the %s runtime", consts.RuntimeTypeAttach)
+	}
+	err = c.saveKubeconfig(ctx, conf.AttachKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	var component internalversion.Component
+	if conf.AttachLocal {
+		component, err = c.buildLocalKwokController(ctx, config)
+	} else {
+		component = c.buildKwokController(ctx, config)
+	}
+	if err != nil {
+		return err
+	}
+	runtime.ApplyComponentPatches(&component, config.ComponentsPatches)
+	config.Components = append(config.Components, component)
+
+	err = c.SetConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	return c.Save(ctx)
+}
+
+// kwokControllerArgs returns the args of the kwok-controller shared by the local process and the pod.
+func kwokControllerArgs(ctx context.Context, conf *internalversion.KwokctlConfigurationOptions) []string {
+	args := []string{
+		"--manage-all-nodes=false",
+		"--manage-nodes-with-annotation-selector=" + manageNodesWithAnnotationSelector,
+		"--node-lease-duration-seconds=" + format.String(conf.NodeLeaseDurationSeconds),
+	}
+
+	logger := log.FromContext(ctx)
+	if verbosity := logger.Level(); verbosity != log.LevelInfo {
+		args = append(args, "--v="+format.String(verbosity))
+	}
+	if conf.TimeAcceleration != 0 && conf.TimeAcceleration != 1 {
+		args = append(args, "--time-acceleration="+strconv.FormatFloat(conf.TimeAcceleration, 'g', -1, 64))
+	}
+	if len(conf.EnableCRDs) != 0 {
+		args = append(args, "--enable-crds="+strings.Join(conf.EnableCRDs, ","))
+	}
+	return args
+}
+
+// buildKwokController builds the kwok-controller running in a Deployment of the existing cluster,
+// which connects to the cluster with its ServiceAccount.
+func (c *Cluster) buildKwokController(ctx context.Context, config *internalversion.KwokctlConfiguration) internalversion.Component {
+	conf := &config.Options
+	args := append(kwokControllerArgs(ctx, conf),
+		"--kubeconfig=",
+		"--config="+configMountPath,
+		"--node-ip=$(POD_IP)",
+		"--node-port="+format.String(podPort),
+		"--server-address="+net.PublicAddress+":"+format.String(podPort),
+	)
+	return internalversion.Component{
+		Name:    consts.ComponentKwokController,
+		Image:   conf.KwokControllerImage,
+		Command: []string{"kwok"},
+		Args:    args,
+		WorkDir: c.Workdir(),
+	}
+}
+
+// buildLocalKwokController builds the kwok-controller running as a local process,
+// which connects to the cluster with the kubeconfig saved in the workdir.
+func (c *Cluster) buildLocalKwokController(ctx context.Context, config *internalversion.KwokctlConfiguration) (internalversion.Component, error) {
+	conf := &config.Options
+	kwokControllerPath, err := c.EnsureBinary(ctx, consts.ComponentKwokController, conf.KwokControllerBinary)
+	if err != nil {
+		return internalversion.Component{}, err
+	}
+
+	if conf.KwokControllerPort == 0 {
+		conf.KwokControllerPort, err = net.GetUnusedPort(ctx, runtime.GetUsedPorts(ctx))
+		if err != nil {
+			return internalversion.Component{}, err
+		}
+	}
+
+	args := append(kwokControllerArgs(ctx, conf),
+		"--kubeconfig="+c.GetWorkdirPath(runtime.InHostKubeconfigName),
+		"--config="+c.GetWorkdirPath(runtime.ConfigName),
+		"--node-ip="+net.LocalAddress,
+		"--node-port="+format.String(conf.KwokControllerPort),
+		"--server-address="+net.LocalAddress+":"+format.String(conf.KwokControllerPort),
+	)
+	return internalversion.Component{
+		Name:   consts.ComponentKwokController,
+		Binary: kwokControllerPath,
+		Args:   args,
+		Metric: &internalversion.ComponentMetric{
+			Scheme: "http",
+			Host:   net.LocalAddress + ":" + format.String(conf.KwokControllerPort),
+			Path:   "/metrics",
+		},
+		WorkDir: c.Workdir(),
+	}, nil
+}
+
+// applyManifests deploys the kwok-controller with its config into the existing cluster.
+func (c *Cluster) applyManifests(ctx context.Context) error {
+	component, err := c.GetComponent(ctx, consts.ComponentKwokController)
+	if err != nil {
+		return err
+	}
+
+	var kwokConfig []byte
+	if !c.IsDryRun() {
+		kwokConfig, err = os.ReadFile(c.GetWorkdirPath(runtime.ConfigName))
+		if err != nil {
+			return err
+		}
+	}
+
+	manifests, err := buildManifests(c.Name(), component, kwokConfig)
+	if err != nil {
+		return err
+	}
+	manifestsPath := c.GetWorkdirPath(manifestsName)
+	err = c.WriteFileWithMode(manifestsPath, manifests, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = c.KubectlInCluster(ctx, "apply", "--filename", manifestsPath)
+	if err != nil {
+		return fmt.Errorf("failed to deploy %s: %w", consts.ComponentKwokController, err)
+	}
+	return nil
+}
+
+// Uninstall uninstalls the cluster, the existing cluster is left as it is except for the kwok-controller.
+func (c *Cluster) Uninstall(ctx context.Context) error {
+	return c.Cluster.Uninstall(ctx)
+}
+
+// Up starts the kwok-controller.
+func (c *Cluster) Up(ctx context.Context) error {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return err
+	}
+	if local {
+		return c.StartComponent(ctx, consts.ComponentKwokController)
+	}
+	return c.applyManifests(ctx)
+}
+
+// Down stops the kwok-controller and removes it from the existing cluster,
+// the nodes and pods it managed are left in the cluster.
+func (c *Cluster) Down(ctx context.Context) error {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return err
+	}
+	if local {
+		return c.StopComponent(ctx, consts.ComponentKwokController)
+	}
+
+	manifestsPath := c.GetWorkdirPath(manifestsName)
+	if !c.IsDryRun() && !file.Exists(manifestsPath) {
+		return nil
+	}
+	err = c.KubectlInCluster(ctx, "delete", "--filename", manifestsPath, "--ignore-not-found")
+	if err != nil {
+		return fmt.Errorf("failed to remove %s: %w", consts.ComponentKwokController, err)
+	}
+	return nil
+}
+
+// Detach stops the kwok-controller, the workdir is kept to attach it again.
+func (c *Cluster) Detach(ctx context.Context) error {
+	return c.Down(ctx)
+}
+
+// Attach starts the kwok-controller again.
+func (c *Cluster) Attach(ctx context.Context) error {
+	return c.Up(ctx)
+}
+
+// Start starts the kwok-controller
+func (c *Cluster) Start(ctx context.Context) error {
+	return c.StartComponent(ctx, consts.ComponentKwokController)
+}
+
+// Stop stops the kwok-controller
+func (c *Cluster) Stop(ctx context.Context) error {
+	return c.StopComponent(ctx, consts.ComponentKwokController)
+}
+
+// StartComponent starts a component in the cluster
+func (c *Cluster) StartComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if component.Binary != "" {
+		err = c.ForkExec(ctx, component.WorkDir, component.Binary, component.Args...)
+	} else {
+		err = c.KubectlInCluster(ctx, "scale", "deployment", resourceName(c.Name()), "--namespace", namespace, "--replicas=1")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return nil
+}
+
+// StopComponent stops a component in the cluster
+func (c *Cluster) StopComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if component.Binary != "" {
+		err = c.ForkExecKill(ctx, component.WorkDir, component.Binary)
+	} else {
+		err = c.KubectlInCluster(ctx, "scale", "deployment", resourceName(c.Name()), "--namespace", namespace, "--replicas=0")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// UpgradeComponent is not supported, the kwok-controller is attached again with the new binary or image instead.
+func (c *Cluster) UpgradeComponent(_ context.Context, _ string, _ runtime.UpgradeComponentConfig) error {
+	return runtime.ErrComponentUpgradeNotSupported
+}
+
+func (c *Cluster) getDeployment(ctx context.Context) (*appsv1.Deployment, error) {
+	buf := bytes.NewBuffer(nil)
+	err := c.KubectlInCluster(exec.WithWriteTo(ctx, buf), "get", "deployment", resourceName(c.Name()), "--namespace", namespace, "--ignore-not-found", "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+
+	var deployment appsv1.Deployment
+	err = json.Unmarshal(buf.Bytes(), &deployment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the deployment of %s: %w", consts.ComponentKwokController, err)
+	}
+	return &deployment, nil
+}
+
+// InspectComponent returns the status of the component
+func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentStatusReady, nil
+	}
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentStatusUnknown, err
+	}
+
+	if component.Binary != "" {
+		if !c.ForkExecIsRunning(ctx, component.WorkDir, component.Binary) {
+			return runtime.ComponentStatusStopped, nil
+		}
+		return runtime.ComponentStatusReady, nil
+	}
+
+	deployment, err := c.getDeployment(ctx)
+	if err != nil {
+		return runtime.ComponentStatusUnknown, err
+	}
+	if deployment == nil || deployment.Status.Replicas == 0 {
+		return runtime.ComponentStatusStopped, nil
+	}
+	if deployment.Status.ReadyReplicas == 0 {
+		return runtime.ComponentStatusRunning, nil
+	}
+	return runtime.ComponentStatusReady, nil
+}
+
+// InspectComponentRestarts returns the restarts of the component, which are not tracked by the attach runtime.
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+	return runtime.ComponentRestarts{}, nil
+}
+
+// InspectUsage returns the usage of the host resources by the local kwok-controller
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	component, err := c.GetComponent(ctx, consts.ComponentKwokController)
+	if err != nil {
+		return nil, err
+	}
+	if component.Binary == "" {
+		return nil, fmt.Errorf("the usage of %s in the existing cluster is not supported, use kubectl top instead", consts.ComponentKwokController)
+	}
+
+	pids := map[string]int{}
+	if c.ForkExecIsRunning(ctx, component.WorkDir, component.Binary) {
+		pid, err := runtime.ForkExecPid(component.WorkDir, component.Binary)
+		if err == nil {
+			pids[component.Name] = pid
+		}
+	}
+	return runtime.InspectProcessesUsage(ctx, pids)
+}
+
+// Ready returns true if the existing cluster is ready and the kwok-controller is ready
+func (c *Cluster) Ready(ctx context.Context) (bool, error) {
+	s, err := c.InspectComponent(ctx, consts.ComponentKwokController)
+	if err != nil {
+		return false, err
+	}
+	if s != runtime.ComponentStatusReady {
+		return false, nil
+	}
+	return c.Cluster.Ready(ctx)
+}
+
+func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if component.Binary != "" {
+		logs := c.GetLogPath(path.OnlyName(component.Binary) + ".log")
+		if c.IsDryRun() {
+			dryrun.PrintMessage("cat %s", logs)
+			return nil
+		}
+		if follow {
+			return exec.Exec(exec.WithAllWriteTo(ctx, out), "tail", "-f", logs)
+		}
+		f, err := os.Open(logs)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", logs, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		_, err = io.Copy(out, f)
+		return err
+	}
+
+	args := []string{"logs", "--namespace", namespace}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, "deployment/"+resourceName(c.Name()))
+	return c.KubectlInCluster(exec.WithAllWriteTo(ctx, out), args...)
+}
+
+// Logs returns the logs of the specified component.
+func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, false)
+}
+
+// LogsFollow follows the logs of the component
+func (c *Cluster) LogsFollow(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, true)
+}
+
+// CollectLogs collects the config and the logs of the kwok-controller into the directory.
+func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
+	logger := log.FromContext(ctx)
+
+	kwokConfigPath := path.Join(dir, "kwok.yaml")
+	if file.Exists(kwokConfigPath) {
+		return fmt.Errorf("%s already exists", kwokConfigPath)
+	}
+
+	if err := c.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create tmp directory: %w", err)
+	}
+	logger.Info("Exporting logs", "dir", dir)
+
+	err := c.CopyFile(c.GetWorkdirPath(runtime.ConfigName), kwokConfigPath)
+	if err != nil {
+		return err
+	}
+
+	componentsDir := path.Join(dir, "components")
+	err = c.MkdirAll(componentsDir)
+	if err != nil {
+		return err
+	}
+
+	logPath := path.Join(componentsDir, consts.ComponentKwokController+".log")
+	f, err := c.OpenFile(logPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = f.Close()
+		if err != nil {
+			logger.Error("Failed to close file", err)
+		}
+	}()
+	return c.Logs(ctx, consts.ComponentKwokController, f)
+}
+
+// ListBinaries list binaries in the cluster
+func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	if !conf.AttachLocal {
+		return []string{
+			conf.KubectlBinary,
+		}, nil
+	}
+	return []string{
+		conf.KubectlBinary,
+		conf.KwokControllerBinary,
+	}, nil
+}
+
+// ListImages list images in the cluster
+func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	if conf.AttachLocal {
+		return []string{}, nil
+	}
+	return []string{
+		conf.KwokControllerImage,
+	}, nil
+}
+
+// EtcdctlInCluster is not supported, the etcd of the existing cluster is not managed by kwokctl
+func (c *Cluster) EtcdctlInCluster(_ context.Context, _ ...string) error {
+	return fmt.Errorf("etcdctl is not supported by the %s runtime", consts.RuntimeTypeAttach)
+}
+
+// SnapshotSave is not supported, use the k8s format instead
+func (c *Cluster) SnapshotSave(_ context.Context, _ string) error {
+	return fmt.Errorf("the snapshot of etcd is not supported by the %s runtime, use the k8s format instead", consts.RuntimeTypeAttach)
+}
+
+// SnapshotRestore is not supported, use the k8s format instead
+func (c *Cluster) SnapshotRestore(_ context.Context, _ string) error {
+	return fmt.Errorf("the snapshot of etcd is not supported by the %s runtime, use the k8s format instead", consts.RuntimeTypeAttach)
+}
+
+// InitCRs initializes the CRs, the stages are in the config of the kwok-controller.
+func (c *Cluster) InitCRs(_ context.Context) error {
+	return nil
+}
+
+// WaitReady waits for the kwok-controller to be ready.
+func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if c.IsDryRun() {
+		return nil
+	}
+	return c.Cluster.WaitReady(ctx, timeout)
+}
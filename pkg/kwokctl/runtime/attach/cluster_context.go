@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attach
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+)
+
+// AddContext add the context of cluster to kubeconfig,
+// it reuses the cluster and the user of the kubeconfig saved when attaching.
+func (c *Cluster) AddContext(_ context.Context, kubeconfigPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Add context %s to %s", c.Name(), kubeconfigPath)
+		return nil
+	}
+
+	saved, err := kubeconfig.LoadFromFile(c.GetWorkdirPath(runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+	current, ok := saved.Contexts[saved.CurrentContext]
+	if !ok {
+		return fmt.Errorf("context %q not found in the kubeconfig of %s", saved.CurrentContext, c.Name())
+	}
+
+	kubeConfig := &kubeconfig.Config{
+		Cluster: saved.Clusters[current.Cluster],
+		User:    saved.AuthInfos[current.AuthInfo],
+		Context: current.DeepCopy(),
+	}
+	kubeConfig.Context.Cluster = c.Name()
+	if kubeConfig.User != nil {
+		kubeConfig.Context.AuthInfo = c.Name()
+	} else {
+		kubeConfig.Context.AuthInfo = ""
+	}
+	if kubeConfig.Cluster == nil {
+		return fmt.Errorf("cluster %q not found in the kubeconfig of %s", current.Cluster, c.Name())
+	}
+
+	err = kubeconfig.AddContext(kubeconfigPath, c.Name(), kubeConfig)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveContext remove the context of cluster from kubeconfig
+func (c *Cluster) RemoveContext(_ context.Context, kubeconfigPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Remove context %s from %s", c.Name(), kubeconfigPath)
+		return nil
+	}
+
+	err := kubeconfig.RemoveContext(kubeconfigPath, c.Name())
+	if err != nil {
+		return err
+	}
+	return nil
+}
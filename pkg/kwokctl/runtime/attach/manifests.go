@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attach
+
+import (
+	"bytes"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"sigs.k8s.io/kwok/kustomize/rbac"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const (
+	// namespace is the namespace of the kwok-controller in the existing cluster, the same as the kustomize deployment.
+	namespace = "kube-system"
+
+	// instanceLabel is the label of the name of the cluster on the resources of the kwok-controller.
+	instanceLabel = "app.kubernetes.io/instance"
+	// componentLabel is the label of the name of the component on the resources of the kwok-controller.
+	componentLabel = "app.kubernetes.io/name"
+
+	// configKey is the key of the config of the kwok-controller in the secret.
+	configKey = "kwok.yaml"
+	// configMountPath is the path the config of the kwok-controller is mounted at in the pod.
+	configMountPath = "/root/.kwok/kwok.yaml"
+	// podPort is the port of the kwok-controller in the pod.
+	podPort = 10247
+)
+
+// resourceName returns the name of the resources of the kwok-controller of the cluster,
+// which are cluster-scoped or in kube-system, so it is prefixed with the name of the cluster.
+func resourceName(name string) string {
+	return name + "-" + consts.ComponentKwokController
+}
+
+func labels(name string) map[string]string {
+	return map[string]string{
+		instanceLabel:  name,
+		componentLabel: consts.ComponentKwokController,
+	}
+}
+
+// buildRBAC builds the ServiceAccount of the kwok-controller and binds it to the ClusterRole of the kustomize deployment.
+func buildRBAC(name string) ([]any, error) {
+	var role rbacv1.ClusterRole
+	err := yaml.Unmarshal(rbac.ClusterRole, &role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the cluster role: %w", err)
+	}
+	role.TypeMeta = metav1.TypeMeta{
+		Kind:       "ClusterRole",
+		APIVersion: "rbac.authorization.k8s.io/v1",
+	}
+	role.ObjectMeta = metav1.ObjectMeta{
+		Name:   resourceName(name),
+		Labels: labels(name),
+	}
+
+	serviceAccount := corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(name),
+			Namespace: namespace,
+			Labels:    labels(name),
+		},
+	}
+
+	binding := rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resourceName(name),
+			Labels: labels(name),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     resourceName(name),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      resourceName(name),
+				Namespace: namespace,
+			},
+		},
+	}
+	return []any{serviceAccount, role, binding}, nil
+}
+
+// buildConfigSecret builds the Secret with the config of the kwok-controller.
+func buildConfigSecret(name string, kwokConfig []byte) corev1.Secret {
+	return corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(name),
+			Namespace: namespace,
+			Labels:    labels(name),
+		},
+		Data: map[string][]byte{
+			configKey: kwokConfig,
+		},
+	}
+}
+
+// buildDeployment builds the Deployment of the kwok-controller,
+// which connects to the cluster it runs in with its ServiceAccount.
+func buildDeployment(name string, component internalversion.Component) appsv1.Deployment {
+	probe := func(initialDelaySeconds, timeoutSeconds, periodSeconds, failureThreshold int32) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/healthz",
+					Port:   intstr.FromInt32(podPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+			InitialDelaySeconds: initialDelaySeconds,
+			TimeoutSeconds:      timeoutSeconds,
+			PeriodSeconds:       periodSeconds,
+			FailureThreshold:    failureThreshold,
+		}
+	}
+
+	envs := []corev1.EnvVar{
+		{
+			Name: "POD_IP",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		},
+	}
+	for _, env := range component.Envs {
+		envs = append(envs, corev1.EnvVar{
+			Name:  env.Name,
+			Value: env.Value,
+		})
+	}
+
+	return appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName(name),
+			Namespace: namespace,
+			Labels:    labels(name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: format.Ptr(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels(name),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels(name),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: resourceName(name),
+					RestartPolicy:      corev1.RestartPolicyAlways,
+					Containers: []corev1.Container{
+						{
+							Name:            consts.ComponentKwokController,
+							Image:           component.Image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         component.Command,
+							Args:            component.Args,
+							Env:             envs,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "config",
+									MountPath: configMountPath,
+									SubPath:   configKey,
+									ReadOnly:  true,
+								},
+							},
+							StartupProbe:   probe(2, 2, 10, 3),
+							LivenessProbe:  probe(30, 10, 60, 10),
+							ReadinessProbe: probe(2, 2, 20, 5),
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: resourceName(name),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildManifests builds the resources of the kwok-controller in the existing cluster.
+func buildManifests(name string, component internalversion.Component, kwokConfig []byte) ([]byte, error) {
+	objs, err := buildRBAC(name)
+	if err != nil {
+		return nil, err
+	}
+	objs = append(objs,
+		buildConfigSecret(name, kwokConfig),
+		buildDeployment(name, component),
+	)
+
+	buf := bytes.NewBuffer(nil)
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the manifests: %w", err)
+		}
+		_, _ = buf.WriteString("---\n")
+		_, _ = buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attach
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestBuildRBAC(t *testing.T) {
+	objs, err := buildRBAC("kwok-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("unexpected objects %v", objs)
+	}
+
+	role := objs[1].(rbacv1.ClusterRole)
+	if role.Name != "kwok-test-kwok-controller" {
+		t.Errorf("unexpected role name %q", role.Name)
+	}
+	if len(role.Rules) == 0 {
+		t.Errorf("unexpected empty rules of the role")
+	}
+
+	binding := objs[2].(rbacv1.ClusterRoleBinding)
+	if binding.RoleRef.Name != role.Name {
+		t.Errorf("unexpected role ref %q", binding.RoleRef.Name)
+	}
+	want := []rbacv1.Subject{
+		{
+			Kind:      "ServiceAccount",
+			Name:      "kwok-test-kwok-controller",
+			Namespace: "kube-system",
+		},
+	}
+	if diff := cmp.Diff(want, binding.Subjects); diff != "" {
+		t.Errorf("unexpected subjects (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildDeployment(t *testing.T) {
+	component := internalversion.Component{
+		Name:    "kwok-controller",
+		Image:   "registry.k8s.io/kwok/kwok:v0.6.0",
+		Command: []string{"kwok"},
+		Args: []string{
+			"--kubeconfig=",
+			"--node-ip=$(POD_IP)",
+		},
+		Envs: []internalversion.Env{
+			{
+				Name:  "TZ",
+				Value: "UTC",
+			},
+		},
+	}
+
+	deployment := buildDeployment("kwok-test", component)
+	if deployment.Name != "kwok-test-kwok-controller" || deployment.Namespace != "kube-system" {
+		t.Errorf("unexpected deployment %s/%s", deployment.Namespace, deployment.Name)
+	}
+	if diff := cmp.Diff(deployment.Spec.Selector.MatchLabels, deployment.Spec.Template.Labels); diff != "" {
+		t.Errorf("selector does not match the pods (-want +got):\n%s", diff)
+	}
+
+	spec := deployment.Spec.Template.Spec
+	if spec.ServiceAccountName != "kwok-test-kwok-controller" {
+		t.Errorf("unexpected service account %q", spec.ServiceAccountName)
+	}
+	if spec.Volumes[0].Secret == nil || spec.Volumes[0].Secret.SecretName != "kwok-test-kwok-controller" {
+		t.Errorf("unexpected volumes %v", spec.Volumes)
+	}
+
+	container := spec.Containers[0]
+	wantMounts := []corev1.VolumeMount{
+		{
+			Name:      "config",
+			MountPath: "/root/.kwok/kwok.yaml",
+			SubPath:   "kwok.yaml",
+			ReadOnly:  true,
+		},
+	}
+	if diff := cmp.Diff(wantMounts, container.VolumeMounts); diff != "" {
+		t.Errorf("unexpected volume mounts (-want +got):\n%s", diff)
+	}
+
+	gotEnvs := []string{}
+	for _, env := range container.Env {
+		gotEnvs = append(gotEnvs, env.Name)
+	}
+	if diff := cmp.Diff([]string{"POD_IP", "TZ"}, gotEnvs); diff != "" {
+		t.Errorf("unexpected envs (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildManifests(t *testing.T) {
+	got, err := buildManifests("kwok-test", internalversion.Component{}, []byte("kind: KwokConfiguration\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kind := range []string{"ServiceAccount", "ClusterRole", "ClusterRoleBinding", "Secret", "Deployment"} {
+		if !bytes.Contains(got, []byte("kind: "+kind+"\n")) {
+			t.Errorf("missing %s in the manifests:\n%s", kind, got)
+		}
+	}
+}
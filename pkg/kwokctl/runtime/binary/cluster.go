@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/kwokctl/supervisor"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/file"
@@ -82,11 +83,14 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		if len(conf.KubeApiserverCertSANs) != 0 {
 			sans = append(sans, conf.KubeApiserverCertSANs...)
 		}
+		if len(conf.DNSNames) != 0 {
+			sans = append(sans, conf.DNSNames...)
+		}
 		err = c.MkdirAll(pkiPath)
 		if err != nil {
 			return fmt.Errorf("failed to create pki dir: %w", err)
 		}
-		err = c.GeneratePki(pkiPath, sans...)
+		err = c.GeneratePki(pkiPath, conf.CaCert, conf.CaKey, sans...)
 		if err != nil {
 			return fmt.Errorf("failed to generate pki: %w", err)
 		}
@@ -107,6 +111,15 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 	}
 
 	etcdDataPath := c.GetWorkdirPath(runtime.EtcdDataDirName)
+	if conf.EtcdTemplate != "" {
+		// Pre-seed the data of etcd before it starts, so that the bootstrap objects don't have to be created again.
+		err := c.Etcdctl(ctx, "snapshot", "restore", conf.EtcdTemplate, "--data-dir", etcdDataPath)
+		if err != nil {
+			return fmt.Errorf("failed to restore etcd template: %w", err)
+		}
+		return nil
+	}
+
 	err := c.MkdirAll(etcdDataPath)
 	if err != nil {
 		return fmt.Errorf("failed to mkdir etcd data path: %w", err)
@@ -140,6 +153,7 @@ type env struct {
 	auditPolicyPath         string
 	workdir                 string
 	caCertPath              string
+	caKeyPath               string
 	adminKeyPath            string
 	adminCertPath           string
 	scheme                  string
@@ -169,6 +183,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 	etcdDataPath := c.GetWorkdirPath(runtime.EtcdDataDirName)
 	pkiPath := c.GetWorkdirPath(runtime.PkiName)
 	caCertPath := path.Join(pkiPath, "ca.crt")
+	caKeyPath := path.Join(pkiPath, "ca.key")
 	adminKeyPath := path.Join(pkiPath, "admin.key")
 	adminCertPath := path.Join(pkiPath, "admin.crt")
 	auditLogPath := ""
@@ -196,6 +211,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		auditPolicyPath:         auditPolicyPath,
 		workdir:                 workdir,
 		caCertPath:              caCertPath,
+		caKeyPath:               caKeyPath,
 		adminKeyPath:            adminKeyPath,
 		adminCertPath:           adminCertPath,
 		scheme:                  scheme,
@@ -222,6 +238,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.checkInitSystem(ctx)
+	if err != nil {
+		return err
+	}
+
 	dirs := []string{
 		"pids",
 		"logs",
@@ -239,6 +260,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.preInstall(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.setup(ctx, env)
 	if err != nil {
 		return err
@@ -255,6 +281,28 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	// The port of otel-collector is required before etcd and kube-apiserver, which send the traces to it
+	if env.kwokctlConfig.Options.EnableOtelCollector {
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&env.kwokctlConfig.Options.OtelCollectorPort,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The port of the audit sink is required before kube-apiserver, which sends the audit events to it
+	if env.kwokctlConfig.Options.EnableKubeAuditSink {
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&env.kwokctlConfig.Options.KubeAuditSinkPort,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = c.addEtcd(ctx, env)
 	if err != nil {
 		return err
@@ -270,11 +318,26 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addKubeApiserverFaultProxy(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeAuditSink(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addKubeControllerManager(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addCloudControllerManager(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addKubeScheduler(ctx, env)
 	if err != nil {
 		return err
@@ -290,16 +353,36 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addCoreDNS(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeStateMetrics(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addPrometheus(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addJaeger(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addOtelCollector(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.setupPrometheusConfig(ctx, env)
 	if err != nil {
 		return err
@@ -316,6 +399,19 @@ func (c *Cluster) Install(ctx context.Context) error {
 func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("multiple members of etcd are not supported by %s runtime", conf.Runtime)
+	}
+
+	// The kube-apiserver connects to the external etcd directly
+	if len(conf.EtcdEndpoints) != 0 {
+		return nil
+	}
+
+	if components.IsKineBackend(conf.EtcdBackend) {
+		return c.addKine(ctx, env)
+	}
+
 	// Configure the etcd
 	etcdPath, err := c.EnsureBinary(ctx, consts.ComponentEtcd, conf.EtcdBinary)
 	if err != nil {
@@ -327,28 +423,71 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
+	tracingAddress := ""
+	if conf.EnableOtelCollector {
+		tracingAddress = components.OtelCollectorReceiverAddress(conf.Runtime, c.Name(), conf.OtelCollectorPort)
+	}
+
 	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+		Runtime:        conf.Runtime,
+		ProjectName:    c.Name(),
+		Workdir:        env.workdir,
+		Binary:         etcdPath,
+		Version:        etcdVersion,
+		BindAddress:    conf.BindAddress,
+		DataPath:       env.etcdDataPath,
+		Port:           conf.EtcdPort,
+		PeerPort:       conf.EtcdPeerPort,
+		Verbosity:      env.verbosity,
+		TracingAddress: tracingAddress,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	return nil
+}
+
+func (c *Cluster) addKine(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kine as the etcd
+	kinePath, err := c.EnsureBinary(ctx, "kine", conf.KineBinary)
+	if err != nil {
+		return err
+	}
+
+	kineComponent, err := components.BuildKineComponent(components.BuildKineComponentConfig{
 		Runtime:     conf.Runtime,
 		ProjectName: c.Name(),
 		Workdir:     env.workdir,
-		Binary:      etcdPath,
-		Version:     etcdVersion,
+		Binary:      kinePath,
+		Version:     conf.KineVersion,
+		Backend:     conf.EtcdBackend,
+		Endpoint:    conf.KineEndpoint,
 		BindAddress: conf.BindAddress,
 		DataPath:    env.etcdDataPath,
 		Port:        conf.EtcdPort,
-		PeerPort:    conf.EtcdPeerPort,
 		Verbosity:   env.verbosity,
 	})
 	if err != nil {
 		return err
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kineComponent)
 	return nil
 }
 
 func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
+	if conf.EnableKonnectivity {
+		return fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("multiple kube-apiservers are not supported by %s runtime", conf.Runtime)
+	}
+
 	// Configure the kube-apiserver
 	kubeApiserverPath, err := c.EnsureBinary(ctx, consts.ComponentKubeApiserver, conf.KubeApiserverBinary)
 	if err != nil {
@@ -360,7 +499,37 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
+	kubeApiserverAuditWebhookConfigPath := ""
+	kubeApiserverAuditWebhookComponent := ""
+	if conf.KubeAuditWebhook != "" || conf.EnableKubeAuditSink {
+		if conf.KubeAuditPolicy == "" {
+			return fmt.Errorf("the audit webhook requires --kube-audit-policy")
+		}
+		if conf.KubeAuditWebhook != "" && conf.EnableKubeAuditSink {
+			return fmt.Errorf("--kube-audit-webhook and --enable-kube-audit-sink cannot be set at the same time")
+		}
+
+		server := conf.KubeAuditWebhook
+		if conf.EnableKubeAuditSink {
+			server = components.KubeAuditSinkURL(conf.Runtime, c.Name(), conf.KubeAuditSinkPort)
+			kubeApiserverAuditWebhookComponent = consts.ComponentKubeAuditSink
+		}
+		kubeApiserverAuditWebhookConfigData, err := k8s.BuildKubeApiserverAuditWebhookConfig(k8s.BuildKubeApiserverAuditWebhookConfigParam{
+			Server: server,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate kubeApiserverAuditWebhookConfig yaml: %w", err)
+		}
+		kubeApiserverAuditWebhookConfigPath = c.GetWorkdirPath(runtime.AuditWebhookConfigName)
+
+		err = c.WriteFile(kubeApiserverAuditWebhookConfigPath, []byte(kubeApiserverAuditWebhookConfigData))
+		if err != nil {
+			return fmt.Errorf("failed to write kubeApiserverAuditWebhookConfig yaml: %w", err)
+		}
+	}
+
 	kubeApiserverTracingConfigPath := ""
+	kubeApiserverTracingComponent := ""
 	if conf.JaegerPort != 0 {
 		err = c.setupPorts(ctx,
 			env.usedPorts,
@@ -369,9 +538,15 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		if err != nil {
 			return err
 		}
-
+	}
+	if conf.JaegerPort != 0 || conf.EnableOtelCollector {
+		endpoint := net.LocalAddress + ":" + format.String(conf.JaegerOtlpGrpcPort)
+		if conf.EnableOtelCollector {
+			endpoint = components.OtelCollectorReceiverAddress(conf.Runtime, c.Name(), conf.OtelCollectorPort)
+			kubeApiserverTracingComponent = consts.ComponentOtelCollector
+		}
 		kubeApiserverTracingConfigData, err := k8s.BuildKubeApiserverTracingConfig(k8s.BuildKubeApiserverTracingConfigParam{
-			Endpoint: net.LocalAddress + ":" + format.String(conf.JaegerOtlpGrpcPort),
+			Endpoint: endpoint,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to generate kubeApiserverTracingConfig yaml: %w", err)
@@ -385,29 +560,38 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 	}
 
 	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Runtime:           conf.Runtime,
-		ProjectName:       c.Name(),
-		Workdir:           env.workdir,
-		Binary:            kubeApiserverPath,
-		Version:           kubeApiserverVersion,
-		BindAddress:       conf.BindAddress,
-		Port:              conf.KubeApiserverPort,
-		EtcdAddress:       net.LocalAddress,
-		EtcdPort:          conf.EtcdPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		EtcdPrefix:        conf.EtcdPrefix,
+		Runtime:                conf.Runtime,
+		ProjectName:            c.Name(),
+		Workdir:                env.workdir,
+		Binary:                 kubeApiserverPath,
+		Version:                kubeApiserverVersion,
+		BindAddress:            conf.BindAddress,
+		Port:                   conf.KubeApiserverPort,
+		EtcdAddress:            net.LocalAddress,
+		EtcdPort:               conf.EtcdPort,
+		KubeRuntimeConfig:      conf.KubeRuntimeConfig,
+		KubeFeatureGates:       conf.KubeFeatureGates,
+		SecurePort:             conf.SecurePort,
+		KubeAuthorization:      conf.KubeAuthorization,
+		KubeAdmission:          conf.KubeAdmission,
+		AuditPolicyPath:        env.auditPolicyPath,
+		AuditLogPath:           env.auditLogPath,
+		AuditWebhookConfigPath: kubeApiserverAuditWebhookConfigPath,
+		AuditWebhookComponent:  kubeApiserverAuditWebhookComponent,
+		CaCertPath:             env.caCertPath,
+		AdminCertPath:          env.adminCertPath,
+		AdminKeyPath:           env.adminKeyPath,
+		Verbosity:              env.verbosity,
+		DisableQPSLimits:       conf.DisableQPSLimits,
+		TracingConfigPath:      kubeApiserverTracingConfigPath,
+		TracingComponent:       kubeApiserverTracingComponent,
+		EtcdPrefix:             conf.EtcdPrefix,
+		ServiceClusterIPRange:  components.ServiceClusterIPRange(conf.IPFamily),
+		EtcdEndpoints:          conf.EtcdEndpoints,
+		EtcdCaFile:             conf.EtcdCaFile,
+		EtcdCertFile:           conf.EtcdCertFile,
+		EtcdKeyFile:            conf.EtcdKeyFile,
+		ExternalCloudProvider:  conf.CloudProvider != "",
 	})
 	if err != nil {
 		return err
@@ -447,6 +631,83 @@ func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addKubeApiserverFaultProxy(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if len(conf.KubeApiserverFaults) != 0 {
+		kwokPath, err := c.EnsureBinary(ctx, consts.ComponentKwokController, conf.KwokControllerBinary)
+		if err != nil {
+			return err
+		}
+
+		kwokVersion, err := c.ParseVersionFromBinary(ctx, kwokPath)
+		if err != nil {
+			return err
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeApiserverFaultProxyPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		faultProxyComponent, err := components.BuildKubeApiserverFaultProxyComponent(components.BuildKubeApiserverFaultProxyComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Binary:         kwokPath,
+			Version:        kwokVersion,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.KubeApiserverFaultProxyPort,
+			Faults:         conf.KubeApiserverFaults,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, faultProxyComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeAuditSink(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeAuditSink {
+		kwokPath, err := c.EnsureBinary(ctx, consts.ComponentKwokController, conf.KwokControllerBinary)
+		if err != nil {
+			return err
+		}
+
+		kwokVersion, err := c.ParseVersionFromBinary(ctx, kwokPath)
+		if err != nil {
+			return err
+		}
+
+		auditSinkComponent, err := components.BuildKubeAuditSinkComponent(components.BuildKubeAuditSinkComponentConfig{
+			Runtime:     conf.Runtime,
+			Workdir:     env.workdir,
+			Binary:      kwokPath,
+			Version:     kwokVersion,
+			BindAddress: net.LocalAddress,
+			Port:        conf.KubeAuditSinkPort,
+			LogPath:     c.GetLogPath(runtime.AuditSinkLogName),
+			Verbosity:   env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, auditSinkComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -489,6 +750,7 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
 			Verbosity:                          env.verbosity,
 			DisableQPSLimits:                   conf.DisableQPSLimits,
+			ExternalCloudProvider:              conf.CloudProvider != "",
 		})
 		if err != nil {
 			return err
@@ -498,6 +760,57 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 	return nil
 }
 
+func (c *Cluster) addCloudControllerManager(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the external cloud-controller-manager
+	if conf.CloudProvider != "" {
+		if conf.CloudControllerManagerBinary == "" {
+			return fmt.Errorf("the binary of cloud-controller-manager is required for the cloud provider %q", conf.CloudProvider)
+		}
+		cloudControllerManagerPath, err := c.EnsureBinary(ctx, consts.ComponentCloudControllerManager, conf.CloudControllerManagerBinary)
+		if err != nil {
+			return err
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.CloudControllerManagerPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		cloudControllerManagerVersion, err := c.ParseVersionFromBinary(ctx, cloudControllerManagerPath)
+		if err != nil {
+			return err
+		}
+
+		cloudControllerManagerComponent, err := components.BuildCloudControllerManagerComponent(components.BuildCloudControllerManagerComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Binary:           cloudControllerManagerPath,
+			Version:          cloudControllerManagerVersion,
+			BindAddress:      conf.BindAddress,
+			Port:             conf.CloudControllerManagerPort,
+			CloudProvider:    conf.CloudProvider,
+			CaCertPath:       env.caCertPath,
+			AdminCertPath:    env.adminCertPath,
+			AdminKeyPath:     env.adminKeyPath,
+			KubeconfigPath:   env.inClusterKubeconfigPath,
+			KubeFeatureGates: conf.KubeFeatureGates,
+			Verbosity:        env.verbosity,
+			DisableQPSLimits: conf.DisableQPSLimits,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, cloudControllerManagerComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -581,12 +894,16 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		ConfigPath:               env.kwokConfigPath,
 		KubeconfigPath:           env.inClusterKubeconfigPath,
 		CaCertPath:               env.caCertPath,
+		CaKeyPath:                env.caKeyPath,
+		EnableCSRSigner:          conf.EnableCSRSigner,
 		AdminCertPath:            env.adminCertPath,
 		AdminKeyPath:             env.adminKeyPath,
 		NodeName:                 "localhost",
 		Verbosity:                env.verbosity,
 		NodeLeaseDurationSeconds: conf.NodeLeaseDurationSeconds,
+		TimeAcceleration:         conf.TimeAcceleration,
 		EnableCRDs:               conf.EnableCRDs,
+		CIDR:                     components.PodCIDR(conf.IPFamily),
 	})
 	if err != nil {
 		return err
@@ -639,6 +956,114 @@ func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addCoreDNS(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableCoreDNS {
+		coreDNSPath, err := c.EnsureBinary(ctx, consts.ComponentCoreDNS, conf.CoreDNSBinary)
+		if err != nil {
+			return err
+		}
+
+		coreDNSVersion, err := c.ParseVersionFromBinary(ctx, coreDNSPath)
+		if err != nil {
+			return err
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.CoreDNSPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		corefileData, err := components.BuildCoreDNSCorefile(components.BuildCoreDNSCorefileConfig{
+			Runtime:        conf.Runtime,
+			Port:           conf.CoreDNSPort,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		corefilePath := c.GetWorkdirPath(runtime.CoreDNSCorefile)
+		err = c.WriteFileWithMode(corefilePath, []byte(corefileData), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write coredns corefile: %w", err)
+		}
+
+		coreDNSComponent, err := components.BuildCoreDNSComponent(components.BuildCoreDNSComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Binary:         coreDNSPath,
+			Version:        coreDNSVersion,
+			Port:           conf.CoreDNSPort,
+			CorefilePath:   corefilePath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, coreDNSComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeStateMetrics(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeStateMetrics {
+		if conf.KubeStateMetricsBinary == "" {
+			return fmt.Errorf("the binary of kube-state-metrics is required by %s runtime", conf.Runtime)
+		}
+
+		kubeStateMetricsPath, err := c.EnsureBinary(ctx, consts.ComponentKubeStateMetrics, conf.KubeStateMetricsBinary)
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsVersion, err := c.ParseVersionFromBinary(ctx, kubeStateMetricsPath)
+		if err != nil {
+			return err
+		}
+
+		var telemetryPort uint32
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeStateMetricsPort,
+			&telemetryPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsComponent, err := components.BuildKubeStateMetricsComponent(components.BuildKubeStateMetricsComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Binary:         kubeStateMetricsPath,
+			Version:        kubeStateMetricsVersion,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.KubeStateMetricsPort,
+			TelemetryPort:  telemetryPort,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeStateMetricsComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -694,6 +1119,15 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addGrafana(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		return fmt.Errorf("grafana is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
 func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 
@@ -727,21 +1161,97 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 	return nil
 }
 
+func (c *Cluster) addOtelCollector(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if !conf.EnableOtelCollector {
+		if conf.OtlpEndpoint != "" {
+			return fmt.Errorf("otlp endpoint requires otel-collector, please set --enable-otel-collector")
+		}
+		return nil
+	}
+
+	otelCollectorPath, err := c.EnsureBinary(ctx, consts.ComponentOtelCollector, conf.OtelCollectorBinary)
+	if err != nil {
+		return err
+	}
+
+	otelCollectorVersion, err := c.ParseVersionFromBinary(ctx, otelCollectorPath)
+	if err != nil {
+		return err
+	}
+
+	var links []string
+	var exporters []components.OtelCollectorExporter
+	if conf.JaegerPort != 0 {
+		links = append(links, consts.ComponentJaeger)
+		exporters = append(exporters, components.NewOtelCollectorExporter(consts.ComponentJaeger, net.LocalAddress+":"+format.String(conf.JaegerOtlpGrpcPort)))
+	}
+	if conf.OtlpEndpoint != "" {
+		exporters = append(exporters, components.NewOtelCollectorExporter("external", conf.OtlpEndpoint))
+	}
+
+	otelCollectorData, err := components.BuildOtelCollector(components.BuildOtelCollectorConfig{
+		OtlpGrpcAddress: components.OtelCollectorReceiverAddress(conf.Runtime, c.Name(), conf.OtelCollectorPort),
+		Exporters:       exporters,
+		LogLevel:        components.OtelCollectorLogLevel(env.verbosity),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate otel-collector yaml: %w", err)
+	}
+	otelCollectorConfigPath := c.GetWorkdirPath(runtime.OtelCollectorConfig)
+
+	err = c.WriteFile(otelCollectorConfigPath, []byte(otelCollectorData))
+	if err != nil {
+		return fmt.Errorf("failed to write otel-collector yaml: %w", err)
+	}
+
+	otelCollectorComponent, err := components.BuildOtelCollectorComponent(components.BuildOtelCollectorComponentConfig{
+		Runtime:    conf.Runtime,
+		Workdir:    env.workdir,
+		Binary:     otelCollectorPath,
+		Version:    otelCollectorVersion,
+		Port:       conf.OtelCollectorPort,
+		ConfigPath: otelCollectorConfigPath,
+		Links:      links,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, otelCollectorComponent)
+	return nil
+}
+
+func (c *Cluster) preInstall(_ context.Context, env *env) error {
+	patches, err := runtime.ExpandComponentPatchesFiles(env.kwokctlConfig.ComponentsPatches, false)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.ComponentsPatches = patches
+	return nil
+}
+
 func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 
+	err := runtime.AddExtraComponents(env.kwokctlConfig, env.workdir, false)
+	if err != nil {
+		return err
+	}
+
 	for i := range env.kwokctlConfig.Components {
 		runtime.ApplyComponentPatches(&env.kwokctlConfig.Components[i], env.kwokctlConfig.ComponentsPatches)
 	}
 
 	// Setup kubeconfig
 	inClusterKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
-		ProjectName:  c.Name(),
-		SecurePort:   conf.SecurePort,
-		Address:      env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
-		CACrtPath:    env.caCertPath,
-		AdminCrtPath: env.adminCertPath,
-		AdminKeyPath: env.adminKeyPath,
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
+		CACrtPath:     env.caCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
 	}))
 	if err != nil {
 		return err
@@ -781,6 +1291,13 @@ func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 
 // Uninstall uninstalls the cluster.
 func (c *Cluster) Uninstall(ctx context.Context) error {
+	if c.isSystemd(ctx) {
+		err := c.systemdUninstall(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := c.Cluster.Uninstall(ctx)
 	if err != nil {
 		return err
@@ -789,6 +1306,9 @@ func (c *Cluster) Uninstall(ctx context.Context) error {
 }
 
 func (c *Cluster) isRunning(ctx context.Context, component internalversion.Component) bool {
+	if c.isSystemd(ctx) {
+		return c.systemdIsActive(ctx, component)
+	}
 	return c.ForkExecIsRunning(ctx, component.WorkDir, component.Binary)
 }
 
@@ -800,8 +1320,23 @@ func (c *Cluster) startComponent(ctx context.Context, component internalversion.
 		return nil
 	}
 
-	if len(component.Envs) > 0 {
-		ctx = exec.WithEnv(ctx, slices.Map(component.Envs, func(c internalversion.Env) string {
+	envs := component.Envs
+	if len(component.EnvFiles) > 0 {
+		envsFromFiles, err := components.LoadEnvFiles(component.EnvFiles)
+		if err != nil {
+			return err
+		}
+		// The environment variables in Envs take precedence, so they are set after the ones from the files.
+		envs = append(envsFromFiles, envs...)
+	}
+
+	if c.isSystemd(ctx) {
+		logger.Debug("Starting component unit")
+		return c.systemdStart(ctx, component, envs)
+	}
+
+	if len(envs) > 0 {
+		ctx = exec.WithEnv(ctx, slices.Map(envs, func(c internalversion.Env) string {
 			return fmt.Sprintf("%s=%s", c.Name, c.Value)
 		}))
 	}
@@ -822,12 +1357,24 @@ func (c *Cluster) startComponent(ctx context.Context, component internalversion.
 		ctx = exec.WithUser(ctx, &uid, &gid)
 	}
 
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
 	logger.Debug("Starting component")
+	if config.Options.SuperviseComponents || component.RestartPolicy != "" {
+		return c.ForkExecSupervised(ctx, component.WorkDir, component.RestartPolicy, component.Binary, component.Args...)
+	}
 	return c.ForkExec(ctx, component.WorkDir, component.Binary, component.Args...)
 }
 
 func (c *Cluster) startComponents(ctx context.Context) error {
 	err := c.ForeachComponents(ctx, false, true, func(ctx context.Context, component internalversion.Component) error {
+		if runtime.IsLazyComponent(component) {
+			log.FromContext(ctx).Debug("Skip starting lazy component", "component", component.Name)
+			return nil
+		}
 		return c.startComponent(ctx, component)
 	})
 	if err != nil {
@@ -844,6 +1391,9 @@ func (c *Cluster) stopComponent(ctx context.Context, component internalversion.C
 		return nil
 	}
 	logger.Debug("Stopping component")
+	if c.isSystemd(ctx) {
+		return c.systemdStop(ctx, component)
+	}
 	return c.ForkExecKill(ctx, component.WorkDir, component.Binary)
 }
 
@@ -867,6 +1417,16 @@ func (c *Cluster) Down(ctx context.Context) error {
 	return c.stop(ctx)
 }
 
+// Detach stops the cluster, the processes and the data of etcd are all in the workdir
+func (c *Cluster) Detach(ctx context.Context) error {
+	return c.stop(ctx)
+}
+
+// Attach starts the cluster from the workdir
+func (c *Cluster) Attach(ctx context.Context) error {
+	return c.start(ctx)
+}
+
 // Start starts the cluster
 func (c *Cluster) Start(ctx context.Context) error {
 	return c.start(ctx)
@@ -979,6 +1539,54 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	return nil
 }
 
+// UpgradeComponent replaces the binary of the component and restarts it with the same configuration
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string, conf runtime.UpgradeComponentConfig) error {
+	err := runtime.CheckUpgradeComponent(name)
+	if err != nil {
+		return err
+	}
+	if conf.Binary == "" {
+		return fmt.Errorf("binary of %s is required to upgrade it", name)
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Download the new binary next to the current one first,
+	// so that the running component is kept if it fails.
+	newBinaryPath := component.Binary + ".new"
+	err = c.DownloadWithCache(ctx, config.Options.CacheDir, conf.Binary, newBinaryPath, 0750, config.Options.QuietPull)
+	if err != nil {
+		return err
+	}
+	ver, err := c.ParseVersionFromBinary(ctx, newBinaryPath)
+	if err != nil {
+		return err
+	}
+
+	err = c.StopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	err = c.RenameFile(newBinaryPath, component.Binary)
+	if err != nil {
+		return err
+	}
+
+	component.Version = ver.String()
+	err = c.SaveUpgradedComponent(ctx, component, conf)
+	if err != nil {
+		return err
+	}
+	return c.StartComponent(ctx, name)
+}
+
 // Logs returns the logs of the specified component.
 func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
 	_, err := c.GetComponent(ctx, name)
@@ -1123,7 +1731,7 @@ func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	}
 	conf := &config.Options
 
-	return []string{
+	binaries := []string{
 		conf.EtcdBinary,
 		conf.KubeApiserverBinary,
 		conf.KubeControllerManagerBinary,
@@ -1132,7 +1740,20 @@ func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 		conf.PrometheusBinary,
 		conf.MetricsServerBinary,
 		conf.KubectlBinary,
-	}, nil
+	}
+	if components.IsKineBackend(conf.EtcdBackend) {
+		binaries = append(binaries, conf.KineBinary)
+	}
+	if conf.EnableCoreDNS {
+		binaries = append(binaries, conf.CoreDNSBinary)
+	}
+	if conf.EnableKubeStateMetrics && conf.KubeStateMetricsBinary != "" {
+		binaries = append(binaries, conf.KubeStateMetricsBinary)
+	}
+	if conf.EnableOtelCollector {
+		binaries = append(binaries, conf.OtelCollectorBinary)
+	}
+	return binaries, nil
 }
 
 // ListImages list images in the cluster
@@ -1167,6 +1788,64 @@ func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.Co
 	return runtime.ComponentStatusReady, nil
 }
 
+// InspectComponentRestarts returns the restarts of the component
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+
+	if c.isSystemd(ctx) {
+		return c.systemdRestarts(ctx, component)
+	}
+
+	// The restarts are only recorded if the component is supervised
+	state, err := supervisor.ReadState(runtime.ForkExecRestartsPath(component.WorkDir, component.Binary))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return runtime.ComponentRestarts{}, nil
+		}
+		return runtime.ComponentRestarts{}, err
+	}
+
+	return runtime.ComponentRestarts{
+		Count:          state.Restarts,
+		LastExitCode:   state.LastExitCode,
+		LastExitReason: state.LastExitReason,
+		LastExitTime:   state.LastExitTime,
+	}, nil
+}
+
+// InspectUsage returns the usage of the host resources by the running components
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pids := map[string]int{}
+	for _, component := range config.Components {
+		if !c.isRunning(ctx, component) {
+			continue
+		}
+		var pid int
+		if c.isSystemd(ctx) {
+			pid, err = c.systemdPid(ctx, component)
+		} else {
+			// The supervisor is counted with the component, as the component is its child process
+			pid, err = runtime.ForkExecPid(component.WorkDir, component.Binary)
+		}
+		if err != nil {
+			continue
+		}
+		pids[component.Name] = pid
+	}
+	return runtime.InspectProcessesUsage(ctx, pids)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	config, err := c.Config(ctx)
@@ -1176,6 +1855,9 @@ func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 
 	// TODO: Only the necessary components are checked for readiness.
 	for _, component := range config.Components {
+		if runtime.IsLazyComponent(component) {
+			continue
+		}
 		s, _ := c.InspectComponent(ctx, component.Name)
 		if s != runtime.ComponentStatusReady {
 			return false, nil
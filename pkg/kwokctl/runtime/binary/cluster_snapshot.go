@@ -51,6 +51,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		}
 	}
 	defer func() {
+		ctx := context.WithoutCancel(ctx)
 		for _, component := range components {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
@@ -133,6 +134,7 @@ func (c *Cluster) SnapshotRestoreWithYAML(ctx context.Context, path string, conf
 		}
 	}
 	defer func() {
+		ctx := context.WithoutCancel(ctx)
 		for _, component := range components {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
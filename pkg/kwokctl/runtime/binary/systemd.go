@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	rt "runtime"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// systemdUnitName returns the name of the systemd unit of the component.
+func systemdUnitName(cluster, component string) string {
+	return "kwok-" + cluster + "-" + component + ".service"
+}
+
+// systemdUserMode returns true if the units are managed by the user's service manager instead of the system one.
+func systemdUserMode() bool {
+	return os.Getuid() != 0
+}
+
+// systemdUnitDir returns the directory the units are written to.
+func systemdUnitDir(userMode bool) string {
+	if userMode {
+		return path.Join(path.Home(), ".config", "systemd", "user")
+	}
+	return "/etc/systemd/system"
+}
+
+// systemdQuote quotes a value for the unit file,
+// the specifiers are escaped and so are the variables if escapeVars is true.
+func systemdQuote(s string, escapeVars bool) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "%", "%%")
+	if escapeVars {
+		s = strings.ReplaceAll(s, "$", "$$")
+	}
+	return `"` + s + `"`
+}
+
+// systemdRestart returns the Restart= setting for the restart policy, the components are always restarted by default.
+func systemdRestart(policy internalversion.RestartPolicy) string {
+	switch policy {
+	case internalversion.RestartPolicyOnFailure:
+		return "on-failure"
+	case internalversion.RestartPolicyNever:
+		return "no"
+	default:
+		return "always"
+	}
+}
+
+// buildSystemdUnit builds the unit file of the component.
+func buildSystemdUnit(cluster string, component internalversion.Component, envs []internalversion.Env, userMode bool) string {
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString("[Unit]\n")
+	fmt.Fprintf(buf, "Description=kwok cluster %s component %s\n", cluster, component.Name)
+	for _, link := range component.Links {
+		unit := systemdUnitName(cluster, link)
+		fmt.Fprintf(buf, "After=%s\n", unit)
+		fmt.Fprintf(buf, "Wants=%s\n", unit)
+	}
+	// The soft links only order the units, they don't pull in the linked components.
+	for _, link := range component.SoftLinks {
+		fmt.Fprintf(buf, "After=%s\n", systemdUnitName(cluster, link))
+	}
+
+	buf.WriteString("\n[Service]\n")
+	buf.WriteString("Type=simple\n")
+	fmt.Fprintf(buf, "WorkingDirectory=%s\n", component.WorkDir)
+	for _, env := range envs {
+		fmt.Fprintf(buf, "Environment=%s\n", systemdQuote(env.Name+"="+env.Value, false))
+	}
+	if component.User != "" && !userMode {
+		fmt.Fprintf(buf, "User=%s\n", component.User)
+	}
+	args := make([]string, 0, len(component.Args)+1)
+	args = append(args, systemdQuote(component.Binary, true))
+	for _, arg := range component.Args {
+		args = append(args, systemdQuote(arg, true))
+	}
+	fmt.Fprintf(buf, "ExecStart=%s\n", strings.Join(args, " "))
+	fmt.Fprintf(buf, "Restart=%s\n", systemdRestart(component.RestartPolicy))
+	buf.WriteString("RestartSec=1\n")
+	// Keep the logs where the forked components write them, so that the logs subcommands work the same way.
+	logPath := path.Join(component.WorkDir, "logs", path.OnlyName(component.Binary)+".log")
+	fmt.Fprintf(buf, "StandardOutput=append:%s\n", logPath)
+	fmt.Fprintf(buf, "StandardError=append:%s\n", logPath)
+
+	buf.WriteString("\n[Install]\n")
+	if userMode {
+		buf.WriteString("WantedBy=default.target\n")
+	} else {
+		buf.WriteString("WantedBy=multi-user.target\n")
+	}
+	return buf.String()
+}
+
+// parseSystemdShow parses the output of systemctl show into the properties.
+func parseSystemdShow(out string) map[string]string {
+	props := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		props[key] = value
+	}
+	return props
+}
+
+func (c *Cluster) isSystemd(ctx context.Context) bool {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false
+	}
+	return config.Options.InitSystem == consts.InitSystemSystemd
+}
+
+func (c *Cluster) checkInitSystem(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	switch config.Options.InitSystem {
+	case "":
+	case consts.InitSystemSystemd:
+		if rt.GOOS != "linux" {
+			return fmt.Errorf("init system %s is only supported on linux", consts.InitSystemSystemd)
+		}
+	default:
+		return fmt.Errorf("unsupported init system %q", config.Options.InitSystem)
+	}
+	return nil
+}
+
+func (c *Cluster) systemctl(ctx context.Context, args ...string) error {
+	if systemdUserMode() {
+		args = append([]string{"--user"}, args...)
+	}
+	return c.Exec(ctx, "systemctl", args...)
+}
+
+func (c *Cluster) systemdShow(ctx context.Context, component internalversion.Component, props ...string) (map[string]string, error) {
+	args := []string{"show", systemdUnitName(c.Name(), component.Name)}
+	for _, prop := range props {
+		args = append(args, "--property", prop)
+	}
+	buf := bytes.NewBuffer(nil)
+	err := c.systemctl(exec.WithWriteTo(ctx, buf), args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseSystemdShow(buf.String()), nil
+}
+
+func (c *Cluster) systemdIsActive(ctx context.Context, component internalversion.Component) bool {
+	if c.IsDryRun() {
+		return false
+	}
+	return c.systemctl(ctx, "is-active", "--quiet", systemdUnitName(c.Name(), component.Name)) == nil
+}
+
+func (c *Cluster) systemdStart(ctx context.Context, component internalversion.Component, envs []internalversion.Env) error {
+	userMode := systemdUserMode()
+	dir := systemdUnitDir(userMode)
+	err := c.MkdirAll(dir)
+	if err != nil {
+		return err
+	}
+	unit := systemdUnitName(c.Name(), component.Name)
+	err = c.WriteFile(path.Join(dir, unit), []byte(buildSystemdUnit(c.Name(), component, envs, userMode)))
+	if err != nil {
+		return err
+	}
+	err = c.systemctl(ctx, "daemon-reload")
+	if err != nil {
+		return err
+	}
+	// The unit is enabled, so that the component is started again after the host reboots.
+	return c.systemctl(ctx, "enable", "--now", unit)
+}
+
+func (c *Cluster) systemdStop(ctx context.Context, component internalversion.Component) error {
+	return c.systemctl(ctx, "disable", "--now", systemdUnitName(c.Name(), component.Name))
+}
+
+func (c *Cluster) systemdRestarts(ctx context.Context, component internalversion.Component) (runtime.ComponentRestarts, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentRestarts{}, nil
+	}
+	props, err := c.systemdShow(ctx, component, "NRestarts", "ExecMainStatus", "Result")
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+	restarts := runtime.ComponentRestarts{}
+	restarts.Count, _ = strconv.Atoi(props["NRestarts"])
+	restarts.LastExitCode, _ = strconv.Atoi(props["ExecMainStatus"])
+	if result := props["Result"]; result != "" && result != "success" {
+		restarts.LastExitReason = result
+	}
+	return restarts, nil
+}
+
+func (c *Cluster) systemdPid(ctx context.Context, component internalversion.Component) (int, error) {
+	props, err := c.systemdShow(ctx, component, "MainPID")
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(props["MainPID"])
+	if err != nil {
+		return 0, err
+	}
+	if pid == 0 {
+		return 0, fmt.Errorf("unit %s has no main process", systemdUnitName(c.Name(), component.Name))
+	}
+	return pid, nil
+}
+
+// systemdUninstall disables and removes the units of the components.
+func (c *Cluster) systemdUninstall(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	logger := log.FromContext(ctx)
+	dir := systemdUnitDir(systemdUserMode())
+	for _, component := range config.Components {
+		unit := systemdUnitName(c.Name(), component.Name)
+		unitPath := path.Join(dir, unit)
+		if _, err := os.Stat(unitPath); err != nil && !c.IsDryRun() {
+			continue
+		}
+		err = c.systemctl(ctx, "disable", "--now", unit)
+		if err != nil {
+			logger.Warn("Failed to disable unit", "unit", unit, "err", err)
+		}
+		err = c.Remove(unitPath)
+		if err != nil {
+			return err
+		}
+	}
+	return c.systemctl(ctx, "daemon-reload")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestBuildSystemdUnit(t *testing.T) {
+	component := internalversion.Component{
+		Name:          "kube-apiserver",
+		Links:         []string{"etcd"},
+		SoftLinks:     []string{"jaeger"},
+		WorkDir:       "/root/.kwok/clusters/kwok",
+		Binary:        "/root/.kwok/clusters/kwok/bin/kube-apiserver",
+		Args:          []string{"--etcd-prefix=/registry", "--token=100%$x"},
+		RestartPolicy: internalversion.RestartPolicyOnFailure,
+		User:          "kwok",
+	}
+	envs := []internalversion.Env{
+		{Name: "GODEBUG", Value: `a="1"`},
+	}
+
+	want := `[Unit]
+Description=kwok cluster kwok component kube-apiserver
+After=kwok-kwok-etcd.service
+Wants=kwok-kwok-etcd.service
+After=kwok-kwok-jaeger.service
+
+[Service]
+Type=simple
+WorkingDirectory=/root/.kwok/clusters/kwok
+Environment="GODEBUG=a=\"1\""
+User=kwok
+ExecStart="/root/.kwok/clusters/kwok/bin/kube-apiserver" "--etcd-prefix=/registry" "--token=100%%$$x"
+Restart=on-failure
+RestartSec=1
+StandardOutput=append:/root/.kwok/clusters/kwok/logs/kube-apiserver.log
+StandardError=append:/root/.kwok/clusters/kwok/logs/kube-apiserver.log
+
+[Install]
+WantedBy=multi-user.target
+`
+	got := buildSystemdUnit("kwok", component, envs, false)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected unit (-want +got):\n%s", diff)
+	}
+
+	got = buildSystemdUnit("kwok", component, envs, true)
+	if want := "WantedBy=default.target\n"; got[len(got)-len(want):] != want {
+		t.Errorf("expected user unit to be wanted by default.target, got:\n%s", got)
+	}
+}
+
+func TestParseSystemdShow(t *testing.T) {
+	got := parseSystemdShow("NRestarts=3\nExecMainStatus=1\nResult=exit-code\n\n")
+	want := map[string]string{
+		"NRestarts":      "3",
+		"ExecMainStatus": "1",
+		"Result":         "exit-code",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected properties (-want +got):\n%s", diff)
+	}
+}
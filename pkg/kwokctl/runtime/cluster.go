@@ -28,12 +28,10 @@ import (
 	"github.com/nxadm/tail"
 
 	"sigs.k8s.io/kwok/kustomize/crd"
-	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
-	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
-	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/config/lifecycle"
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
@@ -41,6 +39,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/version"
@@ -57,11 +56,23 @@ var (
 	PkiName                 = "pki"
 	ManifestsName           = "manifests"
 	Prometheus              = "prometheus.yaml"
+	GrafanaProvisioning     = "grafana/provisioning"
+	GrafanaDashboards       = "grafana/dashboards"
 	KindName                = "kind.yaml"
 	AuditPolicyName         = "audit.yaml"
 	AuditLogName            = "audit.log"
+	AuditSinkLogName        = "audit-sink.jsonl"
+	AuditWebhookConfigName  = "audit-webhook.yaml"
 	SchedulerConfigName     = "scheduler.yaml"
 	ApiserverTracingConfig  = "apiserver-tracing-config.yaml"
+	OtelCollectorConfig     = "otel-collector.yaml"
+	ApiserverEgressSelector = "apiserver-egress-selector-config.yaml"
+	ApiserverLoadBalancer   = "haproxy.cfg"
+	CoreDNSCorefile         = "Corefile"
+	DetachedEtcdName        = "etcd-detached.db"
+	ScalesName              = "scales"
+	KubeconfigsName         = "kubeconfigs"
+	CheckpointsName         = "checkpoints"
 )
 
 // Cluster is the cluster
@@ -177,35 +188,62 @@ func (c *Cluster) Save(ctx context.Context) error {
 	kwokConfigs := config.FilterWithTypeFromContext[*internalversion.KwokConfiguration](ctx)
 	objs = appendIntoInternalObjects(objs, kwokConfigs...)
 
-	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.StageKind) &&
-		conf.Options.Runtime != consts.RuntimeTypeKind &&
-		conf.Options.Runtime != consts.RuntimeTypeKindPodman &&
-		conf.Options.Runtime != consts.RuntimeTypeKindNerdctl &&
-		conf.Options.Runtime != consts.RuntimeTypeKindLima &&
-		conf.Options.Runtime != consts.RuntimeTypeKindFinch &&
-		len(config.FilterWithTypeFromContext[*internalversion.Stage](ctx)) == 0 {
-		defaultStages, err := c.getDefaultStages(conf.Options.NodeStatusUpdateFrequencyMilliseconds, conf.Options.NodeLeaseDurationSeconds != 0)
-		if err != nil {
-			return err
-		}
-		objs = appendIntoInternalObjects(objs, defaultStages...)
-	}
-
 	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.StageKind) {
-		if conf.Options.Runtime != consts.RuntimeTypeKind &&
-			conf.Options.Runtime != consts.RuntimeTypeKindPodman &&
-			conf.Options.Runtime != consts.RuntimeTypeKindNerdctl &&
-			conf.Options.Runtime != consts.RuntimeTypeKindLima &&
-			conf.Options.Runtime != consts.RuntimeTypeKindFinch &&
-			len(config.FilterWithTypeFromContext[*internalversion.Stage](ctx)) == 0 {
-			defaultStages, err := c.getDefaultStages(conf.Options.NodeStatusUpdateFrequencyMilliseconds, conf.Options.NodeLeaseDurationSeconds != 0)
-			if err != nil {
-				return err
-			}
-			objs = appendIntoInternalObjects(objs, defaultStages...)
-		} else {
-			stages := config.FilterWithTypeFromContext[*internalversion.Stage](ctx)
+		stages := config.FilterWithTypeFromContext[*internalversion.Stage](ctx)
+		if len(stages) != 0 {
 			objs = appendIntoInternalObjects(objs, stages...)
+		} else {
+			if conf.Options.Runtime != consts.RuntimeTypeKind &&
+				conf.Options.Runtime != consts.RuntimeTypeKindPodman &&
+				conf.Options.Runtime != consts.RuntimeTypeKindNerdctl &&
+				conf.Options.Runtime != consts.RuntimeTypeKindLima &&
+				conf.Options.Runtime != consts.RuntimeTypeKindFinch {
+				defaultStages, err := c.getDefaultStages(conf.Options.NodeStatusUpdateFrequencyMilliseconds, conf.Options.NodeLeaseDurationSeconds != 0)
+				if err != nil {
+					return err
+				}
+				objs = appendIntoInternalObjects(objs, defaultStages...)
+
+				if conf.Options.CloudProvider != "" {
+					cloudProviderStages, err := lifecycle.NodeCloudProviderStages()
+					if err != nil {
+						return err
+					}
+					objs = appendIntoInternalObjects(objs, cloudProviderStages...)
+				}
+			}
+
+			// The kwok-controller uses the fast pod stages if there is no pod stage,
+			// so only the explicitly selected lifecycle is saved.
+			if conf.Options.Lifecycle != "" {
+				podStages, err := lifecycle.PodStages(conf.Options.Lifecycle)
+				if err != nil {
+					return err
+				}
+				objs = appendIntoInternalObjects(objs, podStages...)
+			}
+
+			if conf.Options.EnableLoadBalancer {
+				serviceStages, err := lifecycle.ServiceStages()
+				if err != nil {
+					return err
+				}
+				objs = appendIntoInternalObjects(objs, serviceStages...)
+
+				ingressStages, err := lifecycle.IngressStages()
+				if err != nil {
+					return err
+				}
+				objs = appendIntoInternalObjects(objs, ingressStages...)
+			}
+
+			if conf.Options.EnableKubeProxy {
+				kubeProxyStages, err := lifecycle.KubeProxyStages()
+				if err != nil {
+					return err
+				}
+				objs = appendIntoInternalObjects(objs, kubeProxyStages...)
+			}
 		}
 	}
 
@@ -264,6 +302,11 @@ func (c *Cluster) Save(ctx context.Context) error {
 		objs = appendIntoInternalObjects(objs, stages...)
 	}
 
+	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.PauseKind) {
+		pauses := config.FilterWithTypeFromContext[*internalversion.Pause](ctx)
+		objs = appendIntoInternalObjects(objs, pauses...)
+	}
+
 	return config.Save(ctx, c.GetWorkdirPath(ConfigName), objs)
 }
 
@@ -275,25 +318,13 @@ func appendIntoInternalObjects[T config.InternalObject](objs []config.InternalOb
 }
 
 func (c *Cluster) getDefaultStages(updateFrequency int64, lease bool) ([]config.InternalObject, error) {
-	objs := []config.InternalObject{}
-
-	nodeInitStage, err := config.UnmarshalWithType[*internalversion.Stage](nodefast.DefaultNodeInit)
-	if err != nil {
-		return nil, err
-	}
-	objs = append(objs, nodeInitStage)
-
-	rawHeartbeat := nodeheartbeat.DefaultNodeHeartbeat
-	if lease {
-		rawHeartbeat = nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease
-	}
-
-	nodeHeartbeatStage, err := config.UnmarshalWithType[*internalversion.Stage](rawHeartbeat)
+	stages, err := lifecycle.NodeStages(lease)
 	if err != nil {
 		return nil, err
 	}
 
 	if updateFrequency > 0 {
+		nodeHeartbeatStage := stages[len(stages)-1]
 		durationMilliseconds := format.ElemOrDefault(nodeHeartbeatStage.Spec.Delay.DurationMilliseconds)
 		jitterDurationMilliseconds := format.ElemOrDefault(nodeHeartbeatStage.Spec.Delay.JitterDurationMilliseconds)
 		if updateFrequency > durationMilliseconds {
@@ -306,8 +337,7 @@ func (c *Cluster) getDefaultStages(updateFrequency int64, lease bool) ([]config.
 		nodeHeartbeatStage.Spec.Delay.JitterDurationMilliseconds = format.Ptr(jitterDurationMilliseconds)
 	}
 
-	objs = append(objs, nodeHeartbeatStage)
-	return objs, nil
+	return appendIntoInternalObjects([]config.InternalObject{}, stages...), nil
 }
 
 func (c *Cluster) KubectlPath(ctx context.Context) (string, error) {
@@ -548,6 +578,11 @@ func (c *Cluster) IsDryRun() bool {
 	return c.dryRun
 }
 
+// HostAddress returns the address of the host where the ports of the components are exposed
+func (c *Cluster) HostAddress() string {
+	return net.LocalAddress
+}
+
 // InitCRDs initializes the CRDs.
 func (c *Cluster) InitCRDs(ctx context.Context) error {
 	config, err := c.Config(ctx)
@@ -614,4 +649,5 @@ var crdDefines = map[string][]byte{
 	v1alpha1.ResourceUsageKind:        crd.ResourceUsage,
 	v1alpha1.ClusterResourceUsageKind: crd.ClusterResourceUsage,
 	v1alpha1.MetricKind:               crd.Metric,
+	v1alpha1.PauseKind:                crd.Pause,
 }
@@ -18,15 +18,20 @@ package runtime
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
@@ -138,6 +143,21 @@ func (c *Cluster) SnapshotRestoreWithYAML(ctx context.Context, path string, conf
 	return nil
 }
 
+// RestoreDetached restores the data of etcd saved into the workdir when the cluster is detached with restore,
+// and removes it after, it does nothing if there is no data saved.
+func (c *Cluster) RestoreDetached(ctx context.Context, restore func(ctx context.Context, path string) error) error {
+	snapshotPath := c.GetWorkdirPath(DetachedEtcdName)
+	if !c.IsDryRun() && !file.Exists(snapshotPath) {
+		return nil
+	}
+
+	err := restore(ctx, snapshotPath)
+	if err != nil {
+		return fmt.Errorf("failed to restore the data of etcd: %w", err)
+	}
+	return c.Remove(snapshotPath)
+}
+
 // GetEtcdClient returns the etcd client of cluster
 func (c *Cluster) GetEtcdClient(ctx context.Context) (etcd.Client, error) {
 	config, err := c.Config(ctx)
@@ -146,7 +166,48 @@ func (c *Cluster) GetEtcdClient(ctx context.Context) (etcd.Client, error) {
 	}
 	conf := &config.Options
 
+	if len(conf.EtcdEndpoints) != 0 {
+		tlsConfig, err := externalEtcdTLSConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		return etcd.NewClient(etcd.ClientConfig{
+			Endpoints: conf.EtcdEndpoints,
+			TLS:       tlsConfig,
+		})
+	}
+
 	return etcd.NewClient(etcd.ClientConfig{
 		Endpoints: []string{"http://" + net.LocalAddress + ":" + format.String(conf.EtcdPort)},
 	})
 }
+
+// externalEtcdTLSConfig returns the TLS config to access the external etcd, or nil if no certificate is given.
+func externalEtcdTLSConfig(conf *internalversion.KwokctlConfigurationOptions) (*tls.Config, error) {
+	if conf.EtcdCaFile == "" && conf.EtcdCertFile == "" && conf.EtcdKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if conf.EtcdCertFile != "" || conf.EtcdKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.EtcdCertFile, conf.EtcdKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if conf.EtcdCaFile != "" {
+		ca, err := os.ReadFile(conf.EtcdCaFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse the CA certificate %q", conf.EtcdCaFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
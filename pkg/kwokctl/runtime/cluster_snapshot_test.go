@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+func TestRestoreDetached(t *testing.T) {
+	ctx := context.Background()
+	c := NewCluster("kwok-test", t.TempDir())
+	c.dryRun = false
+	snapshotPath := c.GetWorkdirPath(DetachedEtcdName)
+
+	var restored []string
+	restore := func(ctx context.Context, path string) error {
+		restored = append(restored, path)
+		return nil
+	}
+
+	err := c.RestoreDetached(ctx, restore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 0 {
+		t.Fatalf("expected no restore without the detached data, got %v", restored)
+	}
+
+	err = os.WriteFile(snapshotPath, []byte("snapshot"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.RestoreDetached(ctx, func(ctx context.Context, path string) error {
+		return errors.New("etcd is not ready")
+	})
+	if err == nil {
+		t.Fatal("expected the error of the restore")
+	}
+	if !file.Exists(snapshotPath) {
+		t.Fatal("expected the detached data to be kept after a failed restore")
+	}
+
+	err = c.RestoreDetached(ctx, restore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 1 || restored[0] != snapshotPath {
+		t.Fatalf("expected the restore of %s, got %v", snapshotPath, restored)
+	}
+	if file.Exists(snapshotPath) {
+		t.Fatal("expected the detached data to be removed after the restore")
+	}
+}
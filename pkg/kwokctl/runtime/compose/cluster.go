@@ -51,6 +51,11 @@ type Cluster struct {
 
 	isNerdctl               bool
 	canNerdctlUnlessStopped *bool
+
+	podmanMachine        *bool
+	podmanMachineWorkdir *bool
+
+	rootless *bool
 }
 
 // NewDockerCluster creates a new Runtime for docker.
@@ -101,7 +106,11 @@ func (c *Cluster) Available(ctx context.Context) error {
 	if c.IsDryRun() {
 		return nil
 	}
-	return c.Exec(ctx, c.runtime, "version")
+	err := c.Exec(ctx, c.runtime, "version")
+	if err != nil && c.runtime == consts.RuntimeTypeFinch {
+		return fmt.Errorf("finch is not available, the VM of finch may need to be started by 'finch vm start': %w", err)
+	}
+	return err
 }
 
 func (c *Cluster) setup(ctx context.Context, env *env) error {
@@ -121,11 +130,31 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		if len(conf.KubeApiserverCertSANs) != 0 {
 			sans = append(sans, conf.KubeApiserverCertSANs...)
 		}
+		if len(conf.DNSNames) != 0 {
+			sans = append(sans, conf.DNSNames...)
+		}
+		if host := c.remoteHost(); host != "" {
+			sans = append(sans, host)
+		}
+		// The kube-apiservers are reached through the load balancer
+		if conf.KubeApiserverReplicas > 1 {
+			sans = append(sans, c.Name()+"-"+consts.ComponentKubeApiserverLoadBalancer)
+		}
+		// The kube-apiserver verifies the konnectivity-server with the admin cert
+		if conf.EnableKonnectivity {
+			sans = append(sans, components.KonnectivityServerHost(c.Name()))
+		}
+		// The members of etcd verify each other with the admin cert
+		if conf.EtcdReplicas > 1 {
+			for i := uint32(0); i < conf.EtcdReplicas; i++ {
+				sans = append(sans, c.Name()+"-"+components.EtcdComponentName(i))
+			}
+		}
 		err = c.MkdirAll(env.pkiPath)
 		if err != nil {
 			return fmt.Errorf("failed to create pki dir: %w", err)
 		}
-		err = c.GeneratePki(env.pkiPath, sans...)
+		err = c.GeneratePki(env.pkiPath, conf.CaCert, conf.CaKey, sans...)
 		if err != nil {
 			return fmt.Errorf("failed to generate pki: %w", err)
 		}
@@ -182,6 +211,7 @@ type env struct {
 	auditPolicyPath               string
 	workdir                       string
 	caCertPath                    string
+	caKeyPath                     string
 	adminKeyPath                  string
 	adminCertPath                 string
 	inClusterPkiPath              string
@@ -214,6 +244,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 
 	workdir := c.Workdir()
 	caCertPath := path.Join(pkiPath, "ca.crt")
+	caKeyPath := path.Join(pkiPath, "ca.key")
 	adminKeyPath := path.Join(pkiPath, "admin.key")
 	adminCertPath := path.Join(pkiPath, "admin.crt")
 	inClusterPkiPath := "/etc/kubernetes/pki/"
@@ -246,6 +277,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		auditPolicyPath:               auditPolicyPath,
 		workdir:                       workdir,
 		caCertPath:                    caCertPath,
+		caKeyPath:                     caKeyPath,
 		adminKeyPath:                  adminKeyPath,
 		adminCertPath:                 adminCertPath,
 		inClusterPkiPath:              inClusterPkiPath,
@@ -265,6 +297,8 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	c.checkFinchWorkdir(ctx)
+
 	env, err := c.env(ctx)
 	if err != nil {
 		return err
@@ -289,6 +323,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.remapPrivilegedPorts(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addEtcd(ctx, env)
 	if err != nil {
 		return err
@@ -299,16 +338,36 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addKonnectivity(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addKubectlProxy(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addKubeApiserverFaultProxy(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeAuditSink(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addKubeControllerManager(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addCloudControllerManager(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addKubeScheduler(ctx, env)
 	if err != nil {
 		return err
@@ -324,16 +383,36 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addCoreDNS(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeStateMetrics(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addPrometheus(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addJaeger(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addOtelCollector(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addDashboard(ctx, env)
 	if err != nil {
 		return err
@@ -355,6 +434,18 @@ func (c *Cluster) Install(ctx context.Context) error {
 func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
+	// The kube-apiserver connects to the external etcd directly
+	if len(conf.EtcdEndpoints) != 0 {
+		return nil
+	}
+
+	if components.IsKineBackend(conf.EtcdBackend) {
+		if conf.EtcdReplicas > 1 {
+			return fmt.Errorf("multiple members of etcd are not supported by etcd backend %q", conf.EtcdBackend)
+		}
+		return c.addKine(ctx, env)
+	}
+
 	// Configure the etcd
 	err = c.EnsureImage(ctx, c.runtime, conf.EtcdImage)
 	if err != nil {
@@ -365,21 +456,69 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
-	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+	tracingAddress := ""
+	if conf.EnableOtelCollector {
+		tracingAddress = components.OtelCollectorReceiverAddress(conf.Runtime, c.Name(), conf.OtelCollectorPort)
+	}
+
+	replicas := max(conf.EtcdReplicas, 1)
+	for i := uint32(0); i < replicas; i++ {
+		// Only the first member is exposed to the host
+		port := conf.EtcdPort
+		if i != 0 {
+			port = 0
+		}
+		etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.EtcdImage,
+			Version:        etcdVersion,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
+			Port:           port,
+			DataPath:       env.etcdDataPath,
+			Verbosity:      env.verbosity,
+			Index:          i,
+			Replicas:       replicas,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			TracingAddress: tracingAddress,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKine(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kine as the etcd
+	err = c.EnsureImage(ctx, c.runtime, conf.KineImage)
+	if err != nil {
+		return err
+	}
+
+	kineComponent, err := components.BuildKineComponent(components.BuildKineComponentConfig{
 		Runtime:     conf.Runtime,
 		ProjectName: c.Name(),
 		Workdir:     env.workdir,
-		Image:       conf.EtcdImage,
-		Version:     etcdVersion,
-		BindAddress: net.PublicAddress,
-		Port:        conf.EtcdPort,
+		Image:       conf.KineImage,
+		Version:     conf.KineVersion,
+		Backend:     conf.EtcdBackend,
+		Endpoint:    conf.KineEndpoint,
+		BindAddress: components.PublicAddress(conf.IPFamily),
 		DataPath:    env.etcdDataPath,
+		Port:        conf.EtcdPort,
 		Verbosity:   env.verbosity,
 	})
 	if err != nil {
 		return err
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kineComponent)
 	return nil
 }
 
@@ -396,10 +535,45 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
+	kubeApiserverAuditWebhookConfigPath := ""
+	kubeApiserverAuditWebhookComponent := ""
+	if conf.KubeAuditWebhook != "" || conf.EnableKubeAuditSink {
+		if conf.KubeAuditPolicy == "" {
+			return fmt.Errorf("the audit webhook requires --kube-audit-policy")
+		}
+		if conf.KubeAuditWebhook != "" && conf.EnableKubeAuditSink {
+			return fmt.Errorf("--kube-audit-webhook and --enable-kube-audit-sink cannot be set at the same time")
+		}
+
+		server := conf.KubeAuditWebhook
+		if conf.EnableKubeAuditSink {
+			server = components.KubeAuditSinkURL(conf.Runtime, c.Name(), conf.KubeAuditSinkPort)
+			kubeApiserverAuditWebhookComponent = consts.ComponentKubeAuditSink
+		}
+		kubeApiserverAuditWebhookConfigData, err := k8s.BuildKubeApiserverAuditWebhookConfig(k8s.BuildKubeApiserverAuditWebhookConfigParam{
+			Server: server,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate kubeApiserverAuditWebhookConfig yaml: %w", err)
+		}
+		kubeApiserverAuditWebhookConfigPath = c.GetWorkdirPath(runtime.AuditWebhookConfigName)
+
+		err = c.WriteFile(kubeApiserverAuditWebhookConfigPath, []byte(kubeApiserverAuditWebhookConfigData))
+		if err != nil {
+			return fmt.Errorf("failed to write kubeApiserverAuditWebhookConfig yaml: %w", err)
+		}
+	}
+
 	kubeApiserverTracingConfigPath := ""
-	if conf.JaegerPort != 0 {
+	kubeApiserverTracingComponent := ""
+	if conf.JaegerPort != 0 || conf.EnableOtelCollector {
+		endpoint := c.Name() + "-jaeger:4317"
+		if conf.EnableOtelCollector {
+			endpoint = components.OtelCollectorReceiverAddress(conf.Runtime, c.Name(), conf.OtelCollectorPort)
+			kubeApiserverTracingComponent = consts.ComponentOtelCollector
+		}
 		kubeApiserverTracingConfigData, err := k8s.BuildKubeApiserverTracingConfig(k8s.BuildKubeApiserverTracingConfigParam{
-			Endpoint: c.Name() + "-jaeger:4317",
+			Endpoint: endpoint,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to generate kubeApiserverTracingConfig yaml: %w", err)
@@ -412,35 +586,185 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		}
 	}
 
-	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Runtime:           conf.Runtime,
-		ProjectName:       c.Name(),
-		Workdir:           env.workdir,
-		Image:             conf.KubeApiserverImage,
-		Version:           kubeApiserverVersion,
-		BindAddress:       net.PublicAddress,
-		Port:              conf.KubeApiserverPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		EtcdPort:          conf.EtcdPort,
-		EtcdAddress:       c.Name() + "-etcd",
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		EtcdPrefix:        conf.EtcdPrefix,
+	kubeApiserverEgressSelectorConfigPath := ""
+	if conf.EnableKonnectivity {
+		if !conf.SecurePort {
+			return fmt.Errorf("konnectivity requires --secure-port")
+		}
+		kubeApiserverEgressSelectorConfigData, err := k8s.BuildKubeApiserverEgressSelectorConfig(k8s.BuildKubeApiserverEgressSelectorConfigParam{
+			URL:        components.KonnectivityServerURL(c.Name()),
+			CaCertPath: "/etc/kubernetes/pki/ca.crt",
+			CertPath:   "/etc/kubernetes/pki/admin.crt",
+			KeyPath:    "/etc/kubernetes/pki/admin.key",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate kubeApiserverEgressSelectorConfig yaml: %w", err)
+		}
+		kubeApiserverEgressSelectorConfigPath = c.GetWorkdirPath(runtime.ApiserverEgressSelector)
+
+		err = c.WriteFile(kubeApiserverEgressSelectorConfigPath, []byte(kubeApiserverEgressSelectorConfigData))
+		if err != nil {
+			return fmt.Errorf("failed to write kubeApiserverEgressSelectorConfig yaml: %w", err)
+		}
+	}
+
+	replicas := max(conf.KubeApiserverReplicas, 1)
+	for i := uint32(0); i < replicas; i++ {
+		// The kube-apiservers are exposed to the host by the load balancer if there are more than one
+		port := conf.KubeApiserverPort
+		if replicas > 1 {
+			port = 0
+		}
+		kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+			Runtime:                  conf.Runtime,
+			ProjectName:              c.Name(),
+			Workdir:                  env.workdir,
+			Image:                    conf.KubeApiserverImage,
+			Version:                  kubeApiserverVersion,
+			BindAddress:              components.PublicAddress(conf.IPFamily),
+			Port:                     port,
+			KubeRuntimeConfig:        conf.KubeRuntimeConfig,
+			KubeFeatureGates:         conf.KubeFeatureGates,
+			SecurePort:               conf.SecurePort,
+			KubeAuthorization:        conf.KubeAuthorization,
+			KubeAdmission:            conf.KubeAdmission,
+			AuditPolicyPath:          env.auditPolicyPath,
+			AuditLogPath:             env.auditLogPath,
+			AuditWebhookConfigPath:   kubeApiserverAuditWebhookConfigPath,
+			AuditWebhookComponent:    kubeApiserverAuditWebhookComponent,
+			CaCertPath:               env.caCertPath,
+			AdminCertPath:            env.adminCertPath,
+			AdminKeyPath:             env.adminKeyPath,
+			EtcdPort:                 conf.EtcdPort,
+			EtcdReplicas:             conf.EtcdReplicas,
+			EtcdAddress:              c.Name() + "-etcd",
+			Verbosity:                env.verbosity,
+			DisableQPSLimits:         conf.DisableQPSLimits,
+			TracingConfigPath:        kubeApiserverTracingConfigPath,
+			TracingComponent:         kubeApiserverTracingComponent,
+			EgressSelectorConfigPath: kubeApiserverEgressSelectorConfigPath,
+			EtcdPrefix:               conf.EtcdPrefix,
+			ServiceClusterIPRange:    components.ServiceClusterIPRange(conf.IPFamily),
+			EtcdEndpoints:            conf.EtcdEndpoints,
+			EtcdCaFile:               conf.EtcdCaFile,
+			EtcdCertFile:             conf.EtcdCertFile,
+			EtcdKeyFile:              conf.EtcdKeyFile,
+			ExternalCloudProvider:    conf.CloudProvider != "",
+			Index:                    i,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	}
+
+	if replicas > 1 {
+		return c.addKubeApiserverLoadBalancer(ctx, env)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeApiserverLoadBalancer(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	err = c.EnsureImage(ctx, c.runtime, conf.HaproxyImage)
+	if err != nil {
+		return err
+	}
+
+	configData, err := components.BuildKubeApiserverLoadBalancerConfig(components.BuildKubeApiserverLoadBalancerConfigConfig{
+		ProjectName: c.Name(),
+		Replicas:    conf.KubeApiserverReplicas,
+		SecurePort:  conf.SecurePort,
+	})
+	if err != nil {
+		return err
+	}
+	configPath := c.GetWorkdirPath(runtime.ApiserverLoadBalancer)
+	err = c.WriteFileWithMode(configPath, []byte(configData), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write haproxy config: %w", err)
+	}
+
+	lbComponent, err := components.BuildKubeApiserverLoadBalancerComponent(components.BuildKubeApiserverLoadBalancerComponentConfig{
+		Runtime:    conf.Runtime,
+		Image:      conf.HaproxyImage,
+		Version:    conf.HaproxyVersion,
+		Workdir:    env.workdir,
+		Port:       conf.KubeApiserverPort,
+		SecurePort: conf.SecurePort,
+		Replicas:   conf.KubeApiserverReplicas,
+		ConfigPath: configPath,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, lbComponent)
+	return nil
+}
+
+// kubeApiserverHost returns the name of the component the other components reach the kube-apiserver with.
+func kubeApiserverHost(conf *internalversion.KwokctlConfigurationOptions) string {
+	if conf.KubeApiserverReplicas > 1 {
+		return consts.ComponentKubeApiserverLoadBalancer
+	}
+	return consts.ComponentKubeApiserver
+}
+
+func (c *Cluster) addKonnectivity(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if !conf.EnableKonnectivity {
+		return nil
+	}
+
+	err = c.EnsureImage(ctx, c.runtime, conf.KonnectivityServerImage)
+	if err != nil {
+		return err
+	}
+	konnectivityServerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KonnectivityServerImage, "")
+	if err != nil {
+		return err
+	}
+	konnectivityServerComponent, err := components.BuildKonnectivityServerComponent(components.BuildKonnectivityServerComponentConfig{
+		Runtime:       conf.Runtime,
+		Workdir:       env.workdir,
+		Image:         conf.KonnectivityServerImage,
+		Version:       konnectivityServerVersion,
+		BindAddress:   components.PublicAddress(conf.IPFamily),
+		CaCertPath:    env.caCertPath,
+		AdminCertPath: env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
+		Verbosity:     env.verbosity,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, konnectivityServerComponent)
+
+	err = c.EnsureImage(ctx, c.runtime, conf.KonnectivityAgentImage)
+	if err != nil {
+		return err
+	}
+	konnectivityAgentVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KonnectivityAgentImage, "")
+	if err != nil {
+		return err
+	}
+	konnectivityAgentComponent, err := components.BuildKonnectivityAgentComponent(components.BuildKonnectivityAgentComponentConfig{
+		Runtime:       conf.Runtime,
+		ProjectName:   c.Name(),
+		Workdir:       env.workdir,
+		Image:         conf.KonnectivityAgentImage,
+		Version:       konnectivityAgentVersion,
+		CaCertPath:    env.caCertPath,
+		AdminCertPath: env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
+		Verbosity:     env.verbosity,
 	})
 	if err != nil {
 		return err
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, konnectivityAgentComponent)
 	return nil
 }
 
@@ -459,7 +783,7 @@ func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
 			ProjectName:    c.Name(),
 			Workdir:        env.workdir,
 			Image:          conf.KubectlImage,
-			BindAddress:    net.PublicAddress,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
 			Port:           conf.KubeApiserverInsecurePort,
 			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
 			CaCertPath:     env.caCertPath,
@@ -475,6 +799,90 @@ func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addKubeApiserverFaultProxy(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if len(conf.KubeApiserverFaults) != 0 {
+		err = c.EnsureImage(ctx, c.runtime, conf.KwokControllerImage)
+		if err != nil {
+			return err
+		}
+
+		kwokVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KwokControllerImage, "kwok")
+		if err != nil {
+			return err
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeApiserverFaultProxyPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		faultProxyComponent, err := components.BuildKubeApiserverFaultProxyComponent(components.BuildKubeApiserverFaultProxyComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.KwokControllerImage,
+			Version:        kwokVersion,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
+			Port:           conf.KubeApiserverFaultProxyPort,
+			Faults:         conf.KubeApiserverFaults,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, faultProxyComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeAuditSink(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeAuditSink {
+		err = c.EnsureImage(ctx, c.runtime, conf.KwokControllerImage)
+		if err != nil {
+			return err
+		}
+
+		kwokVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KwokControllerImage, "kwok")
+		if err != nil {
+			return err
+		}
+
+		// The log is created before it's mounted, so that it's not created as a directory
+		auditSinkLogPath := c.GetLogPath(runtime.AuditSinkLogName)
+		err = c.CreateFile(auditSinkLogPath)
+		if err != nil {
+			return err
+		}
+
+		auditSinkComponent, err := components.BuildKubeAuditSinkComponent(components.BuildKubeAuditSinkComponentConfig{
+			Runtime:     conf.Runtime,
+			Workdir:     env.workdir,
+			Image:       conf.KwokControllerImage,
+			Version:     kwokVersion,
+			BindAddress: components.PublicAddress(conf.IPFamily),
+			Port:        conf.KubeAuditSinkPort,
+			LogPath:     auditSinkLogPath,
+			Verbosity:   env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, auditSinkComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -495,7 +903,7 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 			Workdir:                            env.workdir,
 			Image:                              conf.KubeControllerManagerImage,
 			Version:                            kubeControllerManagerVersion,
-			BindAddress:                        net.PublicAddress,
+			BindAddress:                        components.PublicAddress(conf.IPFamily),
 			Port:                               conf.KubeControllerManagerPort,
 			SecurePort:                         conf.SecurePort,
 			CaCertPath:                         env.caCertPath,
@@ -506,6 +914,7 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 			KubeFeatureGates:                   conf.KubeFeatureGates,
 			Verbosity:                          env.verbosity,
 			DisableQPSLimits:                   conf.DisableQPSLimits,
+			ExternalCloudProvider:              conf.CloudProvider != "",
 			NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
 			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
 		})
@@ -517,6 +926,48 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 	return nil
 }
 
+func (c *Cluster) addCloudControllerManager(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the external cloud-controller-manager
+	if conf.CloudProvider != "" {
+		if conf.CloudControllerManagerImage == "" {
+			return fmt.Errorf("the image of cloud-controller-manager is required for the cloud provider %q", conf.CloudProvider)
+		}
+		err = c.EnsureImage(ctx, c.runtime, conf.CloudControllerManagerImage)
+		if err != nil {
+			return err
+		}
+		cloudControllerManagerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.CloudControllerManagerImage, "")
+		if err != nil {
+			return err
+		}
+
+		cloudControllerManagerComponent, err := components.BuildCloudControllerManagerComponent(components.BuildCloudControllerManagerComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Image:            conf.CloudControllerManagerImage,
+			Version:          cloudControllerManagerVersion,
+			BindAddress:      components.PublicAddress(conf.IPFamily),
+			Port:             conf.CloudControllerManagerPort,
+			CloudProvider:    conf.CloudProvider,
+			CaCertPath:       env.caCertPath,
+			AdminCertPath:    env.adminCertPath,
+			AdminKeyPath:     env.adminKeyPath,
+			KubeconfigPath:   env.inClusterOnHostKubeconfigPath,
+			KubeFeatureGates: conf.KubeFeatureGates,
+			Verbosity:        env.verbosity,
+			DisableQPSLimits: conf.DisableQPSLimits,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, cloudControllerManagerComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -546,7 +997,7 @@ func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 			Workdir:          env.workdir,
 			Image:            conf.KubeSchedulerImage,
 			Version:          kubeSchedulerVersion,
-			BindAddress:      net.PublicAddress,
+			BindAddress:      components.PublicAddress(conf.IPFamily),
 			Port:             conf.KubeSchedulerPort,
 			SecurePort:       conf.SecurePort,
 			CaCertPath:       env.caCertPath,
@@ -580,7 +1031,7 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
-	logVolumes := runtime.GetLogVolumes(ctx)
+	logVolumes := runtime.GetLogVolumes(ctx, env.kwokctlConfig.Options.LogVolumes)
 
 	kwokControllerComponent := components.BuildKwokControllerComponent(components.BuildKwokControllerComponentConfig{
 		Runtime:                  conf.Runtime,
@@ -588,17 +1039,21 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		Workdir:                  env.workdir,
 		Image:                    conf.KwokControllerImage,
 		Version:                  kwokControllerVersion,
-		BindAddress:              net.PublicAddress,
+		BindAddress:              components.PublicAddress(conf.IPFamily),
 		Port:                     conf.KwokControllerPort,
 		ConfigPath:               env.kwokConfigPath,
 		KubeconfigPath:           env.inClusterOnHostKubeconfigPath,
 		CaCertPath:               env.caCertPath,
+		CaKeyPath:                env.caKeyPath,
+		EnableCSRSigner:          conf.EnableCSRSigner,
 		AdminCertPath:            env.adminCertPath,
 		AdminKeyPath:             env.adminKeyPath,
 		NodeName:                 c.Name() + "-kwok-controller",
 		Verbosity:                env.verbosity,
 		NodeLeaseDurationSeconds: conf.NodeLeaseDurationSeconds,
+		TimeAcceleration:         conf.TimeAcceleration,
 		EnableCRDs:               conf.EnableCRDs,
+		CIDR:                     components.PodCIDR(conf.IPFamily),
 	})
 	kwokControllerComponent.Volumes = append(kwokControllerComponent.Volumes, logVolumes...)
 
@@ -642,29 +1097,114 @@ func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
-func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
+func (c *Cluster) addCoreDNS(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
-	// Configure the prometheus
-	if conf.PrometheusPort != 0 {
-		prometheusData, err := components.BuildPrometheus(components.BuildPrometheusConfig{
-			Components: env.kwokctlConfig.Components,
-		})
+	if conf.EnableCoreDNS {
+		err = c.EnsureImage(ctx, c.runtime, conf.CoreDNSImage)
 		if err != nil {
-			return fmt.Errorf("failed to generate prometheus yaml: %w", err)
+			return err
 		}
-		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
 
-		// We don't need to check the permissions of the prometheus config file,
-		// because it's working in a non-root container.
-		err = c.WriteFileWithMode(prometheusConfigPath, []byte(prometheusData), 0644)
+		coreDNSVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.CoreDNSImage, "")
 		if err != nil {
-			return fmt.Errorf("failed to write prometheus yaml: %w", err)
+			return err
 		}
-	}
 
-	return nil
-}
+		corefileData, err := components.BuildCoreDNSCorefile(components.BuildCoreDNSCorefileConfig{
+			Runtime:        conf.Runtime,
+			Port:           conf.CoreDNSPort,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		corefilePath := c.GetWorkdirPath(runtime.CoreDNSCorefile)
+		err = c.WriteFileWithMode(corefilePath, []byte(corefileData), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write coredns corefile: %w", err)
+		}
+
+		coreDNSComponent, err := components.BuildCoreDNSComponent(components.BuildCoreDNSComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.CoreDNSImage,
+			Version:        coreDNSVersion,
+			Port:           conf.CoreDNSPort,
+			CorefilePath:   corefilePath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, coreDNSComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeStateMetrics(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeStateMetrics {
+		err = c.EnsureImage(ctx, c.runtime, conf.KubeStateMetricsImage)
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KubeStateMetricsImage, "")
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsComponent, err := components.BuildKubeStateMetricsComponent(components.BuildKubeStateMetricsComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.KubeStateMetricsImage,
+			Version:        kubeStateMetricsVersion,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.KubeStateMetricsPort,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeStateMetricsComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the prometheus
+	if conf.PrometheusPort != 0 {
+		prometheusData, err := components.BuildPrometheus(components.BuildPrometheusConfig{
+			Components: env.kwokctlConfig.Components,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate prometheus yaml: %w", err)
+		}
+		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
+
+		// We don't need to check the permissions of the prometheus config file,
+		// because it's working in a non-root container.
+		err = c.WriteFileWithMode(prometheusConfigPath, []byte(prometheusData), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write prometheus yaml: %w", err)
+		}
+	}
+
+	return nil
+}
 
 func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
@@ -688,7 +1228,7 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 			Workdir:       env.workdir,
 			Image:         conf.PrometheusImage,
 			Version:       prometheusVersion,
-			BindAddress:   net.PublicAddress,
+			BindAddress:   components.PublicAddress(conf.IPFamily),
 			Port:          conf.PrometheusPort,
 			ConfigPath:    prometheusConfigPath,
 			AdminCertPath: env.adminCertPath,
@@ -703,6 +1243,92 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addGrafana(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		if conf.PrometheusPort == 0 {
+			return fmt.Errorf("grafana requires prometheus, please set --prometheus-port")
+		}
+
+		err = c.EnsureImage(ctx, c.runtime, conf.GrafanaImage)
+		if err != nil {
+			return err
+		}
+
+		grafanaVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.GrafanaImage, "")
+		if err != nil {
+			return err
+		}
+
+		provisioningPath := c.GetWorkdirPath(runtime.GrafanaProvisioning)
+		dashboardsPath := c.GetWorkdirPath(runtime.GrafanaDashboards)
+		err = c.setupGrafanaConfig(provisioningPath, dashboardsPath)
+		if err != nil {
+			return err
+		}
+
+		grafanaComponent, err := components.BuildGrafanaComponent(components.BuildGrafanaComponentConfig{
+			Runtime:          conf.Runtime,
+			Workdir:          env.workdir,
+			Image:            conf.GrafanaImage,
+			Version:          grafanaVersion,
+			Port:             conf.GrafanaPort,
+			ProvisioningPath: provisioningPath,
+			DashboardsPath:   dashboardsPath,
+			Verbosity:        env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, grafanaComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) setupGrafanaConfig(provisioningPath, dashboardsPath string) error {
+	datasources, err := components.BuildGrafanaDatasources(components.BuildGrafanaDatasourcesConfig{
+		PrometheusURL: "http://" + c.Name() + "-" + consts.ComponentPrometheus + ":9090",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate grafana datasources: %w", err)
+	}
+
+	dashboards, err := components.GrafanaDashboards()
+	if err != nil {
+		return fmt.Errorf("failed to read grafana dashboards: %w", err)
+	}
+
+	for _, dir := range []string{
+		path.Join(provisioningPath, "datasources"),
+		path.Join(provisioningPath, "dashboards"),
+		dashboardsPath,
+	} {
+		err = c.MkdirAll(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	// We don't need to check the permissions of the grafana config files,
+	// because they're read in the container.
+	err = c.WriteFileWithMode(path.Join(provisioningPath, "datasources", "prometheus.yaml"), []byte(datasources), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write grafana datasources: %w", err)
+	}
+	err = c.WriteFileWithMode(path.Join(provisioningPath, "dashboards", "kwok.yaml"), []byte(components.GrafanaDashboardsProvider), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write grafana dashboards provider: %w", err)
+	}
+	for name, data := range dashboards {
+		err = c.WriteFileWithMode(path.Join(dashboardsPath, name), data, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write grafana dashboard %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 func (c *Cluster) addDashboard(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -722,7 +1348,7 @@ func (c *Cluster) addDashboard(ctx context.Context, env *env) (err error) {
 			Workdir:        env.workdir,
 			Image:          conf.DashboardImage,
 			Version:        dashboardVersion,
-			BindAddress:    net.PublicAddress,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
 			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
 			CaCertPath:     env.caCertPath,
 			AdminCertPath:  env.adminCertPath,
@@ -779,7 +1405,7 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 			Workdir:     env.workdir,
 			Image:       conf.JaegerImage,
 			Version:     jaegerVersion,
-			BindAddress: net.PublicAddress,
+			BindAddress: components.PublicAddress(conf.IPFamily),
 			Port:        conf.JaegerPort,
 			Verbosity:   env.verbosity,
 		})
@@ -791,7 +1417,76 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addOtelCollector(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if !conf.EnableOtelCollector {
+		if conf.OtlpEndpoint != "" {
+			return fmt.Errorf("otlp endpoint requires otel-collector, please set --enable-otel-collector")
+		}
+		return nil
+	}
+
+	err = c.EnsureImage(ctx, c.runtime, conf.OtelCollectorImage)
+	if err != nil {
+		return err
+	}
+
+	otelCollectorVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.OtelCollectorImage, "")
+	if err != nil {
+		return err
+	}
+
+	var links []string
+	var exporters []components.OtelCollectorExporter
+	if conf.JaegerPort != 0 {
+		links = append(links, consts.ComponentJaeger)
+		exporters = append(exporters, components.NewOtelCollectorExporter(consts.ComponentJaeger, c.Name()+"-jaeger:4317"))
+	}
+	if conf.OtlpEndpoint != "" {
+		exporters = append(exporters, components.NewOtelCollectorExporter("external", conf.OtlpEndpoint))
+	}
+
+	otelCollectorData, err := components.BuildOtelCollector(components.BuildOtelCollectorConfig{
+		OtlpGrpcAddress: net.JoinHostPort(components.PublicAddress(conf.IPFamily), 4317),
+		Exporters:       exporters,
+		LogLevel:        components.OtelCollectorLogLevel(env.verbosity),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate otel-collector yaml: %w", err)
+	}
+	otelCollectorConfigPath := c.GetWorkdirPath(runtime.OtelCollectorConfig)
+
+	// We don't need to check the permissions of the otel-collector config file,
+	// because it's working in a non-root container.
+	err = c.WriteFileWithMode(otelCollectorConfigPath, []byte(otelCollectorData), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write otel-collector yaml: %w", err)
+	}
+
+	otelCollectorComponent, err := components.BuildOtelCollectorComponent(components.BuildOtelCollectorComponentConfig{
+		Runtime:    conf.Runtime,
+		Workdir:    env.workdir,
+		Image:      conf.OtelCollectorImage,
+		Version:    otelCollectorVersion,
+		Port:       conf.OtelCollectorPort,
+		ConfigPath: otelCollectorConfigPath,
+		Links:      links,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, otelCollectorComponent)
+	return nil
+}
+
 func (c *Cluster) preInstall(_ context.Context, env *env) error {
+	patches, err := runtime.ExpandComponentPatchesFiles(env.kwokctlConfig.ComponentsPatches, true)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.ComponentsPatches = patches
+
 	for i, patch := range env.kwokctlConfig.ComponentsPatches {
 		if len(patch.ExtraVolumes) == 0 {
 			continue
@@ -809,30 +1504,49 @@ func (c *Cluster) preInstall(_ context.Context, env *env) error {
 func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 
+	err := runtime.AddExtraComponents(env.kwokctlConfig, env.workdir, true)
+	if err != nil {
+		return err
+	}
+	for _, component := range env.kwokctlConfig.ExtraComponents {
+		err = c.EnsureImage(ctx, c.runtime, component.Image)
+		if err != nil {
+			return err
+		}
+	}
+
 	for i := range env.kwokctlConfig.Components {
 		runtime.ApplyComponentPatches(&env.kwokctlConfig.Components[i], env.kwokctlConfig.ComponentsPatches)
+
+		// The limits are checked before the containers are created
+		_, _, err = components.ContainerResources(env.kwokctlConfig.Components[i])
+		if err != nil {
+			return err
+		}
 	}
 
 	// Setup kubeconfig
 	kubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
-		ProjectName:  c.Name(),
-		SecurePort:   conf.SecurePort,
-		Address:      env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
-		CACrtPath:    env.caCertPath,
-		AdminCrtPath: env.adminCertPath,
-		AdminKeyPath: env.adminKeyPath,
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + c.hostPort(conf.KubeApiserverPort),
+		CACrtPath:     env.caCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
 	}))
 	if err != nil {
 		return err
 	}
 
 	inClusterKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
-		ProjectName:  c.Name(),
-		SecurePort:   conf.SecurePort,
-		Address:      env.scheme + "://" + c.Name() + "-kube-apiserver:" + format.String(env.inClusterPort),
-		CACrtPath:    env.inClusterCaCertPath,
-		AdminCrtPath: env.inClusterAdminCertPath,
-		AdminKeyPath: env.inClusterAdminKeyPath,
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + c.Name() + "-" + kubeApiserverHost(conf) + ":" + format.String(env.inClusterPort),
+		CACrtPath:     env.inClusterCaCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.inClusterAdminCertPath,
+		AdminKeyPath:  env.inClusterAdminKeyPath,
 	}))
 	if err != nil {
 		return err
@@ -879,6 +1593,19 @@ func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 
 // Uninstall uninstalls the cluster.
 func (c *Cluster) Uninstall(ctx context.Context) error {
+	err := c.uninstall(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Cluster.Uninstall(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) uninstall(ctx context.Context) error {
 	err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
 		err := c.deleteComponents(ctx)
 		return err == nil, err
@@ -894,12 +1621,84 @@ func (c *Cluster) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return nil
+}
 
-	err = c.Cluster.Uninstall(ctx)
+// Detach saves the data of etcd into the workdir, and deletes the containers and network of the cluster.
+func (c *Cluster) Detach(ctx context.Context) error {
+	config, err := c.Config(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if config.Options.EtcdReplicas > 1 {
+		return fmt.Errorf("keeping the data is not supported with %d members of etcd", config.Options.EtcdReplicas)
+	}
+
+	// The data of kine is kept in the workdir or in the external database,
+	// and the data of an external etcd is kept by itself
+	if components.IsKineBackend(config.Options.EtcdBackend) || len(config.Options.EtcdEndpoints) != 0 {
+		err = c.stop(ctx)
+		if err != nil {
+			return err
+		}
+		return c.uninstall(ctx)
+	}
+
+	// The data of etcd is in the container, so it needs to be running to save it
+	err = c.StartComponent(ctx, consts.ComponentEtcd)
+	if err != nil {
+		return err
+	}
+
+	err = c.SnapshotSave(ctx, c.GetWorkdirPath(runtime.DetachedEtcdName))
+	if err != nil {
+		return fmt.Errorf("failed to save the data of etcd: %w", err)
+	}
+
+	err = c.stop(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.uninstall(ctx)
+}
+
+// Attach recreates the containers and network of the cluster, and restores the data of etcd from the workdir.
+func (c *Cluster) Attach(ctx context.Context) error {
+	images, err := c.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		err = c.EnsureImage(ctx, c.runtime, image)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.createNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		err = c.createComponents(ctx)
+		return err == nil, err
+	},
+		wait.WithContinueOnError(5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = c.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.RestoreDetached(ctx, c.SnapshotRestore)
 }
 
 // Up starts the cluster.
@@ -1012,6 +1811,53 @@ func (c *Cluster) StopComponent(ctx context.Context, componentName string) error
 	return c.stopComponent(ctx, componentName)
 }
 
+// UpgradeComponent replaces the image of the component and recreates it with the same configuration
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string, conf runtime.UpgradeComponentConfig) error {
+	err := runtime.CheckUpgradeComponent(name)
+	if err != nil {
+		return err
+	}
+	if conf.Image == "" {
+		return fmt.Errorf("image of %s is required to upgrade it", name)
+	}
+
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.EnsureImage(ctx, c.runtime, conf.Image)
+	if err != nil {
+		return err
+	}
+	ver, err := c.ParseVersionFromImage(ctx, c.runtime, conf.Image, "kwok")
+	if err != nil {
+		return err
+	}
+
+	err = c.stopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	err = c.deleteComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	component.Image = conf.Image
+	component.Version = ver.String()
+	err = c.SaveUpgradedComponent(ctx, component, conf)
+	if err != nil {
+		return err
+	}
+
+	err = c.createComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	return c.startComponent(ctx, name)
+}
+
 func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
 	args := []string{"logs"}
 	if follow {
@@ -1144,7 +1990,7 @@ func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
 	}
 	conf := &config.Options
 
-	return []string{
+	images := []string{
 		conf.EtcdImage,
 		conf.KubeApiserverImage,
 		conf.KubeControllerManagerImage,
@@ -1152,7 +1998,29 @@ func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
 		conf.KwokControllerImage,
 		conf.PrometheusImage,
 		conf.MetricsServerImage,
-	}, nil
+	}
+	if components.IsKineBackend(conf.EtcdBackend) {
+		images = append(images, conf.KineImage)
+	}
+	if conf.EnableCoreDNS {
+		images = append(images, conf.CoreDNSImage)
+	}
+	if conf.EnableKubeStateMetrics {
+		images = append(images, conf.KubeStateMetricsImage)
+	}
+	if conf.GrafanaPort != 0 {
+		images = append(images, conf.GrafanaImage)
+	}
+	if conf.EnableOtelCollector {
+		images = append(images, conf.OtelCollectorImage)
+	}
+	if conf.EnableKonnectivity {
+		images = append(images, conf.KonnectivityServerImage, conf.KonnectivityAgentImage)
+	}
+	for _, component := range config.ExtraComponents {
+		images = append(images, component.Image)
+	}
+	return images, nil
 }
 
 // EtcdctlInCluster implements the ectdctl subcommand
@@ -1177,6 +2045,37 @@ func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.Co
 	return runtime.ComponentStatusReady, nil
 }
 
+// InspectComponentRestarts returns the restarts of the component
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentRestarts{}, nil
+	}
+	return c.inspectComponentRestarts(ctx, name)
+}
+
+// InspectUsage returns the usage of the host resources by the running components
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	err := c.checkRootlessUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := map[string]string{}
+	for _, component := range config.Components {
+		if running, _ := c.inspectComponent(ctx, component.Name); running {
+			containers[c.Name()+"-"+component.Name] = component.Name
+		}
+	}
+	return c.InspectContainersUsage(ctx, c.runtime, containers)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	config, err := c.Config(ctx)
@@ -1186,6 +2085,9 @@ func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 
 	// TODO: Only the necessary components are checked for readiness.
 	for _, component := range config.Components {
+		if runtime.IsLazyComponent(component) {
+			continue
+		}
 		s, _ := c.InspectComponent(ctx, component.Name)
 		if s != runtime.ComponentStatusReady {
 			return false, nil
@@ -65,7 +65,7 @@ func (c *Cluster) AddContext(ctx context.Context, kubeconfigPath string) error {
 		caCertPath := path.Join(pkiPath, "ca.crt")
 
 		kubeConfig.Cluster = &clientcmdapi.Cluster{
-			Server: scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
+			Server: scheme + "://" + c.hostPort(conf.KubeApiserverPort),
 		}
 		if conf.SecurePort {
 			kubeConfig.Cluster.CertificateAuthority = caCertPath
@@ -18,6 +18,7 @@ package compose
 
 import (
 	"context"
+	"fmt"
 
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
@@ -56,6 +57,13 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 	}
 	conf := &config.Options
 
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("restoring the etcd snapshot is not supported with %d members of etcd, please use k8s format instead", conf.EtcdReplicas)
+	}
+	if conf.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("restoring the etcd snapshot is not supported with %d kube-apiservers, please use k8s format instead", conf.KubeApiserverReplicas)
+	}
+
 	logger := log.FromContext(ctx)
 	// Restore snapshot to host temporary directory
 	etcdDataTmp := c.GetWorkdirPath("etcd-data")
@@ -84,6 +92,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 			}
 		}
 		defer func() {
+			ctx := context.WithoutCancel(ctx)
 			for _, component := range components {
 				err := c.StartComponent(ctx, component)
 				if err != nil {
@@ -123,6 +132,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 			logger.Error("Failed to stop kube-apiserver", err)
 		}
 		defer func() {
+			ctx := context.WithoutCancel(ctx)
 			err = c.StartComponent(ctx, consts.ComponentKubeApiserver)
 			if err != nil {
 				logger.Error("Failed to start kube-apiserver", err)
@@ -146,6 +156,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 			}
 		}
 		defer func() {
+			ctx := context.WithoutCancel(ctx)
 			components := []string{
 				consts.ComponentEtcd,
 				consts.ComponentKubeApiserver,
@@ -202,6 +213,7 @@ func (c *Cluster) SnapshotRestoreWithYAML(ctx context.Context, path string, conf
 		}
 	}
 	defer func() {
+		ctx := context.WithoutCancel(ctx)
 		for _, component := range components {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// checkFinchWorkdir warns if the workdir is not shared with the VM of finch,
+// which only shares the home directory by default, so the volumes of the components can't be mounted.
+func (c *Cluster) checkFinchWorkdir(ctx context.Context) {
+	if c.runtime != consts.RuntimeTypeFinch || c.IsDryRun() {
+		return
+	}
+
+	workdir := c.Workdir()
+	if isSubPath(path.Home(), workdir) {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Warn("The workdir is not in the home directory which is shared with the VM of finch, "+
+		"add it to the additional_directories of ~/.finch/finch.yaml and restart the VM if the components fail to mount the volumes",
+		"workdir", workdir,
+	)
+}
+
+// isSubPath returns whether the path is the dir or in the dir.
+func isSubPath(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+)
+
+func TestIsSubPath(t *testing.T) {
+	tests := []struct {
+		dir  string
+		path string
+		want bool
+	}{
+		{dir: "/Users/kwok", path: "/Users/kwok", want: true},
+		{dir: "/Users/kwok", path: "/Users/kwok/.kwok/clusters/kwok", want: true},
+		{dir: "/Users/kwok", path: "/Users/kwok2/.kwok", want: false},
+		{dir: "/Users/kwok", path: "/tmp/kwok", want: false},
+		{dir: "/Users/kwok", path: "/Users/kwok/..kwok", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isSubPath(tt.dir, tt.path); got != tt.want {
+				t.Errorf("isSubPath(%q, %q) = %v, want %v", tt.dir, tt.path, got, tt.want)
+			}
+		})
+	}
+}
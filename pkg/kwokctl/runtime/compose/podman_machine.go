@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// podmanMachineDir is the directory in the podman machine where the host paths not shared with it are synced to.
+const podmanMachineDir = "/var/tmp/kwokctl"
+
+// isPodmanMachine returns whether the podman is a client of a podman machine,
+// the VM which runs the podman service on macOS and Windows and is connected over ssh to a local port.
+func (c *Cluster) isPodmanMachine(ctx context.Context) (bool, error) {
+	if c.runtime != consts.RuntimeTypePodman || c.IsDryRun() {
+		return false, nil
+	}
+
+	if c.podmanMachine != nil {
+		return *c.podmanMachine, nil
+	}
+
+	// The published ports of a podman machine are forwarded to the local machine,
+	// so a connection to another host is handled as a remote daemon.
+	if c.remoteHost() != "" {
+		c.podmanMachine = format.Ptr(false)
+		return false, nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "info", "--format", "{{ .Host.ServiceIsRemote }}")
+	if err != nil {
+		return false, fmt.Errorf("failed to get podman info: %w", err)
+	}
+	c.podmanMachine = format.Ptr(strings.TrimSpace(buf.String()) == "true")
+	return *c.podmanMachine, nil
+}
+
+// isWorkdirInPodmanMachine returns whether the workdir is shared with the podman machine at the same path,
+// which is the case for the home directory on macOS by default.
+func (c *Cluster) isWorkdirInPodmanMachine(ctx context.Context) bool {
+	if c.podmanMachineWorkdir != nil {
+		return *c.podmanMachineWorkdir
+	}
+
+	err := c.Exec(ctx, c.runtime, "machine", "ssh", "test", "-d", podmanMachinePath(c.Workdir(), false))
+	c.podmanMachineWorkdir = format.Ptr(err == nil)
+	return *c.podmanMachineWorkdir
+}
+
+// translatePodmanMachineVolumes translates the host paths of the volumes to the paths in the podman machine,
+// the host paths which are not shared with the machine are synced into it via podman machine ssh.
+func (c *Cluster) translatePodmanMachineVolumes(ctx context.Context, volumes []internalversion.Volume) ([]internalversion.Volume, error) {
+	machine, err := c.isPodmanMachine(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !machine {
+		return volumes, nil
+	}
+
+	shared := c.isWorkdirInPodmanMachine(ctx)
+	translated := make([]internalversion.Volume, 0, len(volumes))
+	synced := []internalversion.Volume{}
+	for _, volume := range volumes {
+		if volume.HostPath == "" {
+			translated = append(translated, volume)
+			continue
+		}
+		if shared {
+			volume.HostPath = podmanMachinePath(volume.HostPath, false)
+			translated = append(translated, volume)
+			continue
+		}
+
+		hostPath := components.VolumeHostPath(volume)
+		machinePath := podmanMachinePath(hostPath, true)
+		synced = append(synced, internalversion.Volume{
+			HostPath:  hostPath,
+			MountPath: machinePath,
+		})
+		volume.HostPath = machinePath
+		volume.SubPath = ""
+		translated = append(translated, volume)
+	}
+
+	if len(synced) != 0 {
+		err = c.syncPodmanMachineVolumes(ctx, synced)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return translated, nil
+}
+
+// syncPodmanMachineVolumes copies the host paths of the volumes to their mount paths in the podman machine.
+func (c *Cluster) syncPodmanMachineVolumes(ctx context.Context, volumes []internalversion.Volume) error {
+	logger := log.FromContext(ctx)
+	buf := bytes.NewBuffer(nil)
+	err := tarVolumes(buf, volumes)
+	if err != nil {
+		return fmt.Errorf("failed to archive the volumes: %w", err)
+	}
+
+	logger.Debug("Syncing volumes into the podman machine", "dir", podmanMachineDir)
+	return c.Exec(exec.WithReadFrom(ctx, buf), c.runtime, "machine", "ssh", "tar", "-x", "-f", "-", "-C", "/")
+}
+
+// podmanMachinePath returns the path in the podman machine for the path on the local machine,
+// the path is placed under the podmanMachineDir if it is synced rather than shared.
+func podmanMachinePath(hostPath string, synced bool) string {
+	volume := filepath.VolumeName(hostPath)
+	p := filepath.ToSlash(strings.TrimPrefix(hostPath, volume))
+	if synced {
+		return path.Join(podmanMachineDir, p)
+	}
+	if volume != "" {
+		// The podman machine on Windows mounts the drive C: at /mnt/c
+		p = path.Join("/mnt", strings.ToLower(strings.TrimSuffix(volume, ":")), p)
+	}
+	return p
+}
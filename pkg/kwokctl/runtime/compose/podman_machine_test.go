@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+)
+
+func TestPodmanMachinePath(t *testing.T) {
+	tests := []struct {
+		hostPath string
+		synced   bool
+		want     string
+	}{
+		{
+			hostPath: "/Users/kwok/.kwok/clusters/kwok/pki",
+			want:     "/Users/kwok/.kwok/clusters/kwok/pki",
+		},
+		{
+			hostPath: "/Users/kwok/.kwok/clusters/kwok/pki",
+			synced:   true,
+			want:     "/var/tmp/kwokctl/Users/kwok/.kwok/clusters/kwok/pki",
+		},
+		{
+			hostPath: "/tmp/kwok/kubeconfig.yaml",
+			synced:   true,
+			want:     "/var/tmp/kwokctl/tmp/kwok/kubeconfig.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := podmanMachinePath(tt.hostPath, tt.synced); got != tt.want {
+				t.Errorf("podmanMachinePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+// remoteHost returns the host of the daemon if it is on a remote machine,
+// which is specified by the DOCKER_HOST for docker or the CONTAINER_HOST for podman
+// in the form of tcp://host:port or ssh://[user@]host[:port], or empty if the daemon is local.
+func (c *Cluster) remoteHost() string {
+	switch c.runtime {
+	case consts.RuntimeTypeDocker:
+		return parseRemoteHost(os.Getenv("DOCKER_HOST"))
+	case consts.RuntimeTypePodman:
+		return parseRemoteHost(os.Getenv("CONTAINER_HOST"))
+	default:
+		return ""
+	}
+}
+
+func parseRemoteHost(daemonHost string) string {
+	if daemonHost == "" {
+		return ""
+	}
+	u, err := url.Parse(daemonHost)
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "tcp", "ssh", "http", "https":
+	default:
+		return ""
+	}
+	host := u.Hostname()
+	switch host {
+	case "", "localhost", utilsnet.LocalAddress, "::1":
+		return ""
+	}
+	return host
+}
+
+// HostAddress returns the address of the host where the ports of the components are exposed,
+// it is the host of the docker daemon if it is on a remote machine.
+func (c *Cluster) HostAddress() string {
+	if host := c.remoteHost(); host != "" {
+		return host
+	}
+	return utilsnet.LocalAddress
+}
+
+// hostPort returns the address of the port exposed on the host.
+func (c *Cluster) hostPort(port uint32) string {
+	return net.JoinHostPort(c.HostAddress(), format.String(port))
+}
+
+// copyVolumes copies the host paths of the volumes into the container,
+// as the paths on the host of kwokctl are not visible to a remote daemon.
+func (c *Cluster) copyVolumes(ctx context.Context, containerName string, volumes []internalversion.Volume) error {
+	logger := log.FromContext(ctx)
+	buf := bytes.NewBuffer(nil)
+	if !c.IsDryRun() {
+		err := tarVolumes(buf, volumes)
+		if err != nil {
+			return fmt.Errorf("failed to archive the volumes of %s: %w", containerName, err)
+		}
+	}
+
+	logger.Debug("Copying volumes into the remote container", "container", containerName)
+	return c.Exec(exec.WithReadFrom(ctx, buf), c.runtime, "cp", "-", containerName+":/")
+}
+
+// tarVolumes writes the host paths of the volumes into a tar stream rooted at their mount paths,
+// a host path which does not exist is created as an empty directory.
+func tarVolumes(w io.Writer, volumes []internalversion.Volume) error {
+	tw := tar.NewWriter(w)
+	for _, volume := range volumes {
+		mountPath := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(volume.MountPath)), "/")
+		if mountPath == "" || mountPath == "." {
+			return fmt.Errorf("invalid mount path %q", volume.MountPath)
+		}
+
+		hostPath := components.VolumeHostPath(volume)
+		_, err := os.Stat(hostPath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			err = tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     mountPath + "/",
+				Mode:     0o755,
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(hostPath, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(hostPath, p)
+			if err != nil {
+				return err
+			}
+			name := mountPath
+			if rel != "." {
+				name = mountPath + "/" + filepath.ToSlash(rel)
+			}
+
+			switch {
+			case d.IsDir():
+				return tw.WriteHeader(&tar.Header{
+					Typeflag: tar.TypeDir,
+					Name:     name + "/",
+					Mode:     int64(info.Mode().Perm()),
+					ModTime:  info.ModTime(),
+				})
+			case info.Mode().IsRegular():
+				err = tw.WriteHeader(&tar.Header{
+					Typeflag: tar.TypeReg,
+					Name:     name,
+					Mode:     int64(info.Mode().Perm()),
+					Size:     info.Size(),
+					ModTime:  info.ModTime(),
+				})
+				if err != nil {
+					return err
+				}
+				f, err := os.Open(p)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					_ = f.Close()
+				}()
+				_, err = io.Copy(tw, f)
+				return err
+			default:
+				// Skip sockets, devices and symlinks, which can't be copied to another machine
+				return nil
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestParseRemoteHost(t *testing.T) {
+	tests := []struct {
+		dockerHost string
+		want       string
+	}{
+		{dockerHost: "", want: ""},
+		{dockerHost: "unix:///var/run/docker.sock", want: ""},
+		{dockerHost: "npipe:////./pipe/docker_engine", want: ""},
+		{dockerHost: "tcp://127.0.0.1:2375", want: ""},
+		{dockerHost: "tcp://localhost:2375", want: ""},
+		{dockerHost: "tcp://192.168.1.10:2376", want: "192.168.1.10"},
+		{dockerHost: "ssh://user@builder.example.com", want: "builder.example.com"},
+		{dockerHost: "ssh://builder.example.com:2222", want: "builder.example.com"},
+		{dockerHost: "tcp://[fd00::1]:2375", want: "fd00::1"},
+		{dockerHost: "ssh://core@127.0.0.1:52134/run/user/501/podman/podman.sock", want: ""},
+		{dockerHost: "ssh://root@podman.example.com/run/podman/podman.sock", want: "podman.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dockerHost, func(t *testing.T) {
+			if got := parseRemoteHost(tt.dockerHost); got != tt.want {
+				t.Errorf("parseRemoteHost() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTarVolumes(t *testing.T) {
+	dir := t.TempDir()
+	pki := filepath.Join(dir, "pki")
+	err := os.MkdirAll(pki, 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(pki, "ca.crt"), []byte("ca"), 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	err = os.WriteFile(kubeconfig, []byte("config"), 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = tarVolumes(buf, []internalversion.Volume{
+		{HostPath: pki, MountPath: "/etc/kubernetes/pki/"},
+		{HostPath: kubeconfig, MountPath: "/root/.kube/config"},
+		{HostPath: filepath.Join(dir, "logs"), MountPath: "/var/log/kwok"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{}
+	tr := tar.NewReader(buf)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[hdr.Name] = string(data)
+	}
+	want := map[string]string{
+		"etc/kubernetes/pki/":       "",
+		"etc/kubernetes/pki/ca.crt": "ca",
+		"root/.kube/config":         "config",
+		"var/log/kwok/":             "",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("tarVolumes() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+// maxPrivilegedPort is the max port which can't be published by a rootless daemon,
+// unless net.ipv4.ip_unprivileged_port_start is lowered on the host.
+const maxPrivilegedPort = 1023
+
+// isRootless returns whether the daemon of the runtime runs in the rootless mode.
+func (c *Cluster) isRootless(ctx context.Context) (bool, error) {
+	if c.rootless != nil {
+		return *c.rootless, nil
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false, err
+	}
+	if config.Options.Rootless || c.IsDryRun() {
+		c.rootless = format.Ptr(config.Options.Rootless)
+		return *c.rootless, nil
+	}
+
+	var infoFormat string
+	switch c.runtime {
+	case consts.RuntimeTypeDocker, consts.RuntimeTypeNerdctl:
+		infoFormat = "{{ json .SecurityOptions }}"
+	case consts.RuntimeTypePodman:
+		infoFormat = "{{ .Host.Security.Rootless }}"
+	default:
+		// The daemons of lima and finch run in their VMs
+		c.rootless = format.Ptr(false)
+		return false, nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "info", "--format", infoFormat)
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s info: %w", c.runtime, err)
+	}
+	c.rootless = format.Ptr(isRootlessInfo(c.runtime, buf.String()))
+	return *c.rootless, nil
+}
+
+// isRootlessInfo returns whether the output of the info of the runtime reports the rootless mode.
+func isRootlessInfo(runtime, info string) bool {
+	info = strings.TrimSpace(info)
+	if runtime == consts.RuntimeTypePodman {
+		return info == "true"
+	}
+	return strings.Contains(info, "name=rootless")
+}
+
+// remapPrivilegedPorts remaps the privileged ports to be published to unused ports,
+// as a rootless daemon can't bind them on the host.
+func (c *Cluster) remapPrivilegedPorts(ctx context.Context, env *env) error {
+	rootless, err := c.isRootless(ctx)
+	if err != nil {
+		return err
+	}
+	if !rootless {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	conf := &env.kwokctlConfig.Options
+	ports := []struct {
+		flag string
+		port *uint32
+	}{
+		{"kube-apiserver-port", &conf.KubeApiserverPort},
+		{"kube-apiserver-insecure-port", &conf.KubeApiserverInsecurePort},
+		{"etcd-port", &conf.EtcdPort},
+		{"kube-controller-manager-port", &conf.KubeControllerManagerPort},
+		{"kube-scheduler-port", &conf.KubeSchedulerPort},
+		{"controller-port", &conf.KwokControllerPort},
+		{"metrics-server-port", &conf.MetricsServerPort},
+		{"coredns-port", &conf.CoreDNSPort},
+		{"cloud-controller-manager-port", &conf.CloudControllerManagerPort},
+		{"kube-state-metrics-port", &conf.KubeStateMetricsPort},
+		{"prometheus-port", &conf.PrometheusPort},
+		{"grafana-port", &conf.GrafanaPort},
+		{"otel-collector-port", &conf.OtelCollectorPort},
+		{"kube-audit-sink-port", &conf.KubeAuditSinkPort},
+		{"jaeger-port", &conf.JaegerPort},
+		{"dashboard-port", &conf.DashboardPort},
+	}
+	for _, p := range ports {
+		if *p.port == 0 || *p.port > maxPrivilegedPort {
+			continue
+		}
+		remapped, err := net.GetUnusedPort(ctx, env.usedPorts)
+		if err != nil {
+			return err
+		}
+		logger.Warn("The privileged port can't be published by the rootless daemon, remapped to an unused port",
+			"flag", p.flag,
+			"port", *p.port,
+			"remapped", remapped,
+		)
+		*p.port = remapped
+	}
+	return nil
+}
+
+// rootlessComponent adjusts the component for the rootless daemon,
+// the mount propagation needs the mount namespace of the host which is not owned by the daemon,
+// and only the root in the container is mapped to the user of the daemon who owns the files of the workdir.
+func rootlessComponent(component internalversion.Component) internalversion.Component {
+	volumes := make([]internalversion.Volume, 0, len(component.Volumes))
+	for _, volume := range component.Volumes {
+		volume.MountPropagation = ""
+		volumes = append(volumes, volume)
+	}
+	component.Volumes = volumes
+	if component.User == "" {
+		component.User = "root"
+	}
+	return component
+}
+
+// checkRootlessUsage returns an error if the usage of the containers can't be read from the rootless daemon,
+// which needs the cgroup v2 with the controllers delegated to the user.
+func (c *Cluster) checkRootlessUsage(ctx context.Context) error {
+	rootless, err := c.isRootless(ctx)
+	if err != nil || !rootless {
+		return err
+	}
+	if c.runtime != consts.RuntimeTypeDocker && c.runtime != consts.RuntimeTypeNerdctl {
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "info", "--format", "{{ .CgroupDriver }}")
+	if err != nil {
+		return fmt.Errorf("failed to get %s info: %w", c.runtime, err)
+	}
+	if strings.TrimSpace(buf.String()) == "none" {
+		return fmt.Errorf("the usage of the containers is not available from the rootless %s without the cgroup v2, "+
+			"see https://rootlesscontaine.rs/getting-started/common/cgroup2/", c.runtime)
+	}
+	return nil
+}
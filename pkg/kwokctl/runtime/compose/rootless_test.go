@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestIsRootlessInfo(t *testing.T) {
+	tests := []struct {
+		runtime string
+		info    string
+		want    bool
+	}{
+		{runtime: consts.RuntimeTypeDocker, info: `["name=seccomp,profile=builtin","name=rootless","name=cgroupns"]` + "\n", want: true},
+		{runtime: consts.RuntimeTypeDocker, info: `["name=seccomp,profile=builtin","name=cgroupns"]` + "\n", want: false},
+		{runtime: consts.RuntimeTypeNerdctl, info: `["name=seccomp,profile=default","name=rootless"]`, want: true},
+		{runtime: consts.RuntimeTypePodman, info: "true\n", want: true},
+		{runtime: consts.RuntimeTypePodman, info: "false\n", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime+" "+tt.info, func(t *testing.T) {
+			if got := isRootlessInfo(tt.runtime, tt.info); got != tt.want {
+				t.Errorf("isRootlessInfo(%q, %q) = %v, want %v", tt.runtime, tt.info, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootlessComponent(t *testing.T) {
+	component := internalversion.Component{
+		Name: "prometheus",
+		Volumes: []internalversion.Volume{
+			{
+				HostPath:         "/var/log/pods",
+				MountPath:        "/var/log/pods",
+				MountPropagation: internalversion.MountPropagationHostToContainer,
+			},
+		},
+	}
+	want := internalversion.Component{
+		Name: "prometheus",
+		User: "root",
+		Volumes: []internalversion.Volume{
+			{
+				HostPath:  "/var/log/pods",
+				MountPath: "/var/log/pods",
+			},
+		},
+	}
+	got := rootlessComponent(component)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rootlessComponent() mismatch (-want +got):\n%s", diff)
+	}
+	if component.Volumes[0].MountPropagation == "" {
+		t.Errorf("rootlessComponent() modified the volumes of the component")
+	}
+
+	component.User = "65534"
+	if got := rootlessComponent(component); got.User != "65534" {
+		t.Errorf("rootlessComponent() overrode the user %q", got.User)
+	}
+}
@@ -22,9 +22,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/format"
@@ -48,9 +51,16 @@ func (c *Cluster) createNetwork(ctx context.Context) error {
 			return nil
 		}
 	}
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
 	args := []string{
 		"network", "create", network,
 	}
+	if conf.Options.IPFamily == consts.IPFamilyIPv6 || conf.Options.IPFamily == consts.IPFamilyDual {
+		args = append(args, "--ipv6")
+	}
 	args = append(args, c.labelArgs()...)
 	logger.Debug("Creating network")
 	return c.Exec(ctx, c.runtime, args...)
@@ -132,17 +142,20 @@ func (c *Cluster) isCanNerdctlUnlessStopped(ctx context.Context) (bool, error) {
 
 	var canNerdctlUnlessStopped *bool
 	logger := log.FromContext(ctx)
-	nerdctlVersion, err := c.ParseVersionFromBinary(ctx, c.runtime)
-	if err != nil {
-		logger.Warn("Failed to parse nerdctl version", "err", err)
-	} else if nerdctlVersion.LE(version.NewVersion(1, 3, 0)) {
-		canNerdctlUnlessStopped = format.Ptr(false)
-		logger = logger.With("nerdctlCheck", nerdctlVersion)
+	// The version of finch is not the version of the nerdctl it bundles, so only the help is checked
+	if c.runtime != consts.RuntimeTypeFinch {
+		nerdctlVersion, err := c.ParseVersionFromBinary(ctx, c.runtime)
+		if err != nil {
+			logger.Warn("Failed to parse nerdctl version", "err", err)
+		} else if nerdctlVersion.LE(version.NewVersion(1, 3, 0)) {
+			canNerdctlUnlessStopped = format.Ptr(false)
+			logger = logger.With("nerdctlCheck", nerdctlVersion)
+		}
 	}
 
 	if canNerdctlUnlessStopped == nil {
 		buf := bytes.NewBuffer(nil)
-		err = c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "create", "--help")
+		err := c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "create", "--help")
 		if err != nil {
 			return false, fmt.Errorf("canNerdctlUnlessStopped failed: %w", err)
 		}
@@ -200,6 +213,14 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 		return fmt.Errorf("component %s not found", componentName)
 	}
 
+	rootless, err := c.isRootless(ctx)
+	if err != nil {
+		return err
+	}
+	if rootless {
+		component = rootlessComponent(component)
+	}
+
 	args := []string{"create",
 		"--name=" + c.Name() + "-" + componentName,
 		"--pull=never",
@@ -219,33 +240,60 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 		args = append(args, "--user="+component.User)
 	}
 
+	cpus, memory, err := components.ContainerResources(component)
+	if err != nil {
+		return err
+	}
+	if cpus != "" {
+		args = append(args, "--cpus="+cpus)
+	}
+	if memory != "" {
+		args = append(args, "--memory="+memory)
+	}
+
+	links := slices.Clone(component.Links)
+	for _, link := range component.SoftLinks {
+		if slices.Contains(links, link) {
+			continue
+		}
+		// The soft link is skipped if the linked component is absent
+		_, exist := slices.Find(conf.Components, func(component internalversion.Component) bool {
+			return component.Name == link
+		})
+		if exist {
+			links = append(links, link)
+		}
+	}
+
 	switch c.runtime {
 	case consts.RuntimeTypeDocker:
-		for _, link := range component.Links {
+		for _, link := range links {
 			args = append(args, "--link="+c.Name()+"-"+link)
 		}
 	case consts.RuntimeTypePodman:
-		for _, link := range component.Links {
+		for _, link := range links {
 			args = append(args, "--requires="+c.Name()+"-"+link)
 		}
 	default:
 		// Nerdctl does not support --link and --requires
 	}
 
+	restart := components.ContainerRestart(component.RestartPolicy)
 	switch c.runtime {
 	case consts.RuntimeTypeDocker, consts.RuntimeTypePodman:
-		args = append(args, "--restart=unless-stopped")
+		args = append(args, "--restart="+restart)
 	default:
 		if c.isNerdctl {
-			canNerdctlUnlessStopped, err := c.isCanNerdctlUnlessStopped(ctx)
-			if err != nil {
-				logger.Error("Failed to check unless-stopped support", err)
-			}
-			if canNerdctlUnlessStopped {
-				args = append(args, "--restart=unless-stopped")
-			} else {
-				args = append(args, "--restart=always")
+			if restart == "unless-stopped" {
+				canNerdctlUnlessStopped, err := c.isCanNerdctlUnlessStopped(ctx)
+				if err != nil {
+					logger.Error("Failed to check unless-stopped support", err)
+				}
+				if !canNerdctlUnlessStopped {
+					restart = "always"
+				}
 			}
+			args = append(args, "--restart="+restart)
 		}
 	}
 
@@ -258,12 +306,22 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 		}
 		args = append(args, "--publish="+format.String(port.HostPort)+":"+format.String(port.Port)+"/"+strings.ToLower(string(protocol)))
 	}
+	// The paths on the host are not visible to a remote daemon, so they are copied into the container after it is created
+	remote := c.remoteHost() != ""
+	if !remote {
+		component.Volumes, err = c.translatePodmanMachineVolumes(ctx, component.Volumes)
+		if err != nil {
+			return err
+		}
+	}
 	for _, volume := range component.Volumes {
-		if volume.ReadOnly {
-			args = append(args, "--volume="+volume.HostPath+":"+volume.MountPath+":ro")
-		} else {
-			args = append(args, "--volume="+volume.HostPath+":"+volume.MountPath)
+		if remote && volume.HostPath != "" {
+			continue
 		}
+		args = append(args, "--volume="+components.VolumeBind(volume))
+	}
+	for _, envFile := range component.EnvFiles {
+		args = append(args, "--env-file="+envFile)
 	}
 	for _, env := range component.Envs {
 		args = append(args, "--env="+env.Name+"="+env.Value)
@@ -273,7 +331,20 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 	args = append(args, component.Args...)
 
 	logger.Debug("Creating component")
-	return c.Exec(ctx, c.runtime, args...)
+	err = c.Exec(ctx, c.runtime, args...)
+	if err != nil {
+		return err
+	}
+
+	if remote {
+		volumes := slices.Filter(component.Volumes, func(volume internalversion.Volume) bool {
+			return volume.HostPath != ""
+		})
+		if len(volumes) != 0 {
+			return c.copyVolumes(ctx, c.Name()+"-"+componentName, volumes)
+		}
+	}
+	return nil
 }
 
 func (c *Cluster) createComponents(ctx context.Context) error {
@@ -327,14 +398,19 @@ func (c *Cluster) deleteComponents(ctx context.Context) error {
 }
 
 type inspectStatus struct {
-	State struct {
-		Running bool
+	RestartCount int
+	State        struct {
+		Running    bool
+		OOMKilled  bool
+		ExitCode   int
+		Error      string
+		FinishedAt string
 	}
 }
 
-func checkInspect(raw []byte) (bool, error) {
+func parseInspect(raw []byte) (inspectStatus, error) {
 	if len(raw) == 0 {
-		return false, fmt.Errorf("empty inspect result")
+		return inspectStatus{}, fmt.Errorf("empty inspect result")
 	}
 	raw = bytes.TrimSpace(raw)
 	switch raw[0] {
@@ -343,39 +419,90 @@ func checkInspect(raw []byte) (bool, error) {
 
 		err := json.Unmarshal(raw, &tmp)
 		if err != nil {
-			return false, fmt.Errorf("failed to unmarshal inspect result: %w", err)
+			return inspectStatus{}, fmt.Errorf("failed to unmarshal inspect result: %w", err)
 		}
 
-		return tmp.State.Running, nil
+		return tmp, nil
 	case '[':
 		var tmp []inspectStatus
 		err := json.Unmarshal(raw, &tmp)
 		if err != nil {
-			return false, fmt.Errorf("failed to unmarshal inspect result: %w", err)
+			return inspectStatus{}, fmt.Errorf("failed to unmarshal inspect result: %w", err)
 		}
 
 		if len(tmp) == 0 {
-			return false, nil
+			return inspectStatus{}, nil
 		}
-		return tmp[0].State.Running, nil
+		return tmp[0], nil
 	default:
-		return false, fmt.Errorf("unexpected inspect result: %s", raw)
+		return inspectStatus{}, fmt.Errorf("unexpected inspect result: %s", raw)
 	}
 }
 
-func (c *Cluster) inspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+func checkInspect(raw []byte) (bool, error) {
+	status, err := parseInspect(raw)
+	if err != nil {
+		return false, err
+	}
+	return status.State.Running, nil
+}
+
+func checkInspectRestarts(raw []byte) (runtime.ComponentRestarts, error) {
+	status, err := parseInspect(raw)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+
+	restarts := runtime.ComponentRestarts{
+		Count:        status.RestartCount,
+		LastExitCode: status.State.ExitCode,
+	}
+
+	// The finished time is zero if the container has never exited
+	if status.State.FinishedAt != "" {
+		finishedAt, err := time.Parse(time.RFC3339Nano, status.State.FinishedAt)
+		if err == nil && finishedAt.Year() > 1 {
+			restarts.LastExitTime = finishedAt
+		}
+	}
+	if restarts.LastExitTime.IsZero() {
+		return runtime.ComponentRestarts{Count: restarts.Count}, nil
+	}
+
+	switch {
+	case status.State.OOMKilled:
+		restarts.LastExitReason = "OOMKilled"
+	case status.State.Error != "":
+		restarts.LastExitReason = status.State.Error
+	case status.State.ExitCode != 0:
+		restarts.LastExitReason = "Error"
+	default:
+		restarts.LastExitReason = "Completed"
+	}
+	return restarts, nil
+}
+
+func (c *Cluster) inspect(ctx context.Context, componentName string) ([]byte, error) {
 	buf := bytes.NewBuffer(nil)
 	args := []string{"inspect", c.Name() + "-" + componentName}
 
 	args = append(args, "--format={{ json . }}")
 
 	err := c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, args...)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Cluster) inspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+	raw, err := c.inspect(ctx, componentName)
 	if err != nil {
 		// TODO: check if component exists or other error
 		return false, false
 	}
 
-	running, err = checkInspect(buf.Bytes())
+	running, err = checkInspect(raw)
 	if err != nil {
 		logger := log.FromContext(ctx)
 		logger.Warn("Failed to check inspect result", "err", err)
@@ -384,6 +511,14 @@ func (c *Cluster) inspectComponent(ctx context.Context, componentName string) (r
 	return running, true
 }
 
+func (c *Cluster) inspectComponentRestarts(ctx context.Context, componentName string) (runtime.ComponentRestarts, error) {
+	raw, err := c.inspect(ctx, componentName)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+	return checkInspectRestarts(raw)
+}
+
 func (c *Cluster) startComponent(ctx context.Context, componentName string) error {
 	logger := log.FromContext(ctx)
 	logger = logger.With("component", componentName)
@@ -433,6 +568,10 @@ func (c *Cluster) startComponent(ctx context.Context, componentName string) erro
 
 func (c *Cluster) startComponents(ctx context.Context) error {
 	err := c.ForeachComponents(ctx, false, true, func(ctx context.Context, component internalversion.Component) error {
+		if runtime.IsLazyComponent(component) {
+			log.FromContext(ctx).Debug("Skip starting lazy component", "component", component.Name)
+			return nil
+		}
 		return c.startComponent(ctx, component.Name)
 	})
 	if err != nil {
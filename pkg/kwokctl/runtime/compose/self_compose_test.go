@@ -18,6 +18,9 @@ package compose
 
 import (
 	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 )
 
 func Test_checkInspect(t *testing.T) {
@@ -100,3 +103,59 @@ func Test_checkInspect(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkInspectRestarts(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []byte
+		want    runtime.ComponentRestarts
+		wantErr bool
+	}{
+		{
+			name: "never exited",
+			raw:  []byte(`{"RestartCount":0,"State":{"Running":true,"FinishedAt":"0001-01-01T00:00:00Z"}}`),
+			want: runtime.ComponentRestarts{},
+		},
+		{
+			name: "exited with error",
+			raw:  []byte(`[{"RestartCount":2,"State":{"Running":true,"ExitCode":1,"FinishedAt":"2024-01-02T03:04:05.123456789Z"}}]`),
+			want: runtime.ComponentRestarts{
+				Count:          2,
+				LastExitCode:   1,
+				LastExitReason: "Error",
+				LastExitTime:   time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC),
+			},
+		},
+		{
+			name: "oom killed",
+			raw:  []byte(`{"RestartCount":1,"State":{"OOMKilled":true,"ExitCode":137,"FinishedAt":"2024-01-02T03:04:05Z"}}`),
+			want: runtime.ComponentRestarts{
+				Count:          1,
+				LastExitCode:   137,
+				LastExitReason: "OOMKilled",
+				LastExitTime:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name:    "invalid",
+			raw:     []byte(`invalid`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkInspectRestarts(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkInspectRestarts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.LastExitTime.Equal(tt.want.LastExitTime) {
+				t.Errorf("checkInspectRestarts() LastExitTime = %v, want %v", got.LastExitTime, tt.want.LastExitTime)
+			}
+			got.LastExitTime = tt.want.LastExitTime
+			if got != tt.want {
+				t.Errorf("checkInspectRestarts() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -46,6 +46,12 @@ type Runtime interface {
 	// Uninstall the cluster
 	Uninstall(ctx context.Context) error
 
+	// Detach stop the cluster and remove it from the runtime, but keep the data in the workdir
+	Detach(ctx context.Context) error
+
+	// Attach recreate the cluster in the runtime from the data kept in the workdir and start it
+	Attach(ctx context.Context) error
+
 	// Up start the cluster
 	Up(ctx context.Context) error
 
@@ -73,6 +79,15 @@ type Runtime interface {
 	// InspectComponent inspect the component
 	InspectComponent(ctx context.Context, name string) (ComponentStatus, error)
 
+	// InspectComponentRestarts inspect the restarts of the component
+	InspectComponentRestarts(ctx context.Context, name string) (ComponentRestarts, error)
+
+	// InspectUsage inspect the usage of the host resources by the running components
+	InspectUsage(ctx context.Context) ([]ComponentUsage, error)
+
+	// UpgradeComponent replace the image or binary of the component and restart it with the same configuration
+	UpgradeComponent(ctx context.Context, name string, conf UpgradeComponentConfig) error
+
 	// Ready check the cluster is ready
 	Ready(ctx context.Context) (bool, error)
 
@@ -139,6 +154,9 @@ type Runtime interface {
 	// IsDryRun returns true if the runtime is in dry-run mode
 	IsDryRun() bool
 
+	// HostAddress returns the address of the host where the ports of the components are exposed
+	HostAddress() string
+
 	// GetClientset returns the clientset of cluster
 	GetClientset(ctx context.Context) (client.Clientset, error)
 
@@ -154,6 +172,16 @@ type SnapshotRestoreWithYAMLConfig struct {
 	Filters []string
 }
 
+// UpgradeComponentConfig is the configuration to upgrade a component
+type UpgradeComponentConfig struct {
+	// Version is the version of kwok the component is upgraded to
+	Version string
+	// Image is the new image of the component, only for the container runtimes
+	Image string
+	// Binary is the new binary of the component, only for the binary runtime
+	Binary string
+}
+
 type ComponentStatus uint64
 
 const (
@@ -162,3 +190,25 @@ const (
 	ComponentStatusRunning
 	ComponentStatusReady
 )
+
+// ComponentRestarts is the restarts of a component
+type ComponentRestarts struct {
+	// Count is the number of times the component has been restarted
+	Count int
+	// LastExitCode is the exit code of the last exit of the component
+	LastExitCode int
+	// LastExitReason is the reason of the last exit of the component
+	LastExitReason string
+	// LastExitTime is the time of the last exit of the component, zero if it has never exited
+	LastExitTime time.Time
+}
+
+// ComponentUsage is the usage of the host resources by a component
+type ComponentUsage struct {
+	// Name is the name of the component
+	Name string `json:"name"`
+	// CPUPercent is the CPU usage in the percent of one CPU
+	CPUPercent float64 `json:"cpuPercent"`
+	// MemoryBytes is the memory usage in bytes
+	MemoryBytes uint64 `json:"memoryBytes"`
+}
@@ -0,0 +1,1759 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/sets"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const configsDirName = "crio"
+
+// Cluster is an implementation of Runtime for CRI-O
+type Cluster struct {
+	*runtime.Cluster
+
+	runtime string
+}
+
+// NewCluster creates a new Runtime for CRI-O
+func NewCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster: runtime.NewCluster(name, workdir),
+		runtime: "crictl",
+	}, nil
+}
+
+// Available  checks whether the runtime is available.
+func (c *Cluster) Available(ctx context.Context) error {
+	if c.IsDryRun() {
+		return nil
+	}
+	return c.Exec(ctx, c.runtime, "version")
+}
+
+func (c *Cluster) setup(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	pkiPath := c.GetWorkdirPath(runtime.PkiName)
+	if !file.Exists(pkiPath) {
+		sans := []string{}
+		ips, err := net.GetAllIPs()
+		if err != nil {
+			logger := log.FromContext(ctx)
+			logger.Warn("failed to get all ips", "err", err)
+		} else {
+			sans = append(sans, ips...)
+		}
+		if len(conf.KubeApiserverCertSANs) != 0 {
+			sans = append(sans, conf.KubeApiserverCertSANs...)
+		}
+		if len(conf.DNSNames) != 0 {
+			sans = append(sans, conf.DNSNames...)
+		}
+		err = c.MkdirAll(pkiPath)
+		if err != nil {
+			return fmt.Errorf("failed to create pki dir: %w", err)
+		}
+		err = c.GeneratePki(pkiPath, conf.CaCert, conf.CaKey, sans...)
+		if err != nil {
+			return fmt.Errorf("failed to generate pki: %w", err)
+		}
+	}
+
+	if conf.KubeAuditPolicy != "" {
+		auditLogPath := c.GetLogPath(runtime.AuditLogName)
+		err := c.CreateFile(auditLogPath)
+		if err != nil {
+			return err
+		}
+
+		auditPolicyPath := c.GetWorkdirPath(runtime.AuditPolicyName)
+		err = c.CopyFile(conf.KubeAuditPolicy, auditPolicyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	etcdDataPath := c.GetWorkdirPath(runtime.EtcdDataDirName)
+	if conf.EtcdTemplate != "" {
+		// Pre-seed the data of etcd before it starts, so that the bootstrap objects don't have to be created again.
+		err := c.Etcdctl(ctx, "snapshot", "restore", conf.EtcdTemplate, "--data-dir", etcdDataPath)
+		if err != nil {
+			return fmt.Errorf("failed to restore etcd template: %w", err)
+		}
+		return nil
+	}
+
+	err := c.MkdirAll(etcdDataPath)
+	if err != nil {
+		return fmt.Errorf("failed to mkdir etcd data path: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Cluster) setupPorts(ctx context.Context, used sets.Sets[uint32], ports ...*uint32) error {
+	for _, port := range ports {
+		if port != nil && *port == 0 {
+			p, err := net.GetUnusedPort(ctx, used)
+			if err != nil {
+				return err
+			}
+			*port = p
+		}
+	}
+	return nil
+}
+
+type env struct {
+	kwokctlConfig           *internalversion.KwokctlConfiguration
+	verbosity               log.Level
+	inClusterKubeconfigPath string
+	kubeconfigPath          string
+	etcdDataPath            string
+	kwokConfigPath          string
+	pkiPath                 string
+	auditLogPath            string
+	auditPolicyPath         string
+	workdir                 string
+	caCertPath              string
+	caKeyPath               string
+	adminKeyPath            string
+	adminCertPath           string
+	scheme                  string
+	usedPorts               sets.Sets[uint32]
+}
+
+func (c *Cluster) env(ctx context.Context) (*env, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "http"
+	if config.Options.SecurePort {
+		scheme = "https"
+	}
+
+	workdir := c.Workdir()
+
+	kubeconfigPath := c.GetWorkdirPath(runtime.InHostKubeconfigName)
+	inClusterKubeconfigPath := c.GetWorkdirPath(runtime.InClusterKubeconfigName)
+	if config.Options.KubeApiserverInsecurePort == 0 {
+		inClusterKubeconfigPath = kubeconfigPath
+	}
+
+	kwokConfigPath := c.GetWorkdirPath(runtime.ConfigName)
+	etcdDataPath := c.GetWorkdirPath(runtime.EtcdDataDirName)
+	pkiPath := c.GetWorkdirPath(runtime.PkiName)
+	caCertPath := path.Join(pkiPath, "ca.crt")
+	caKeyPath := path.Join(pkiPath, "ca.key")
+	adminKeyPath := path.Join(pkiPath, "admin.key")
+	adminCertPath := path.Join(pkiPath, "admin.crt")
+	auditLogPath := ""
+	auditPolicyPath := ""
+
+	if config.Options.KubeAuditPolicy != "" {
+		auditLogPath = c.GetLogPath(runtime.AuditLogName)
+		auditPolicyPath = c.GetWorkdirPath(runtime.AuditPolicyName)
+	}
+
+	logger := log.FromContext(ctx)
+	verbosity := logger.Level()
+
+	usedPorts := runtime.GetUsedPorts(ctx)
+
+	return &env{
+		kwokctlConfig:           config,
+		verbosity:               verbosity,
+		inClusterKubeconfigPath: inClusterKubeconfigPath,
+		kubeconfigPath:          kubeconfigPath,
+		etcdDataPath:            etcdDataPath,
+		kwokConfigPath:          kwokConfigPath,
+		pkiPath:                 pkiPath,
+		auditLogPath:            auditLogPath,
+		auditPolicyPath:         auditPolicyPath,
+		workdir:                 workdir,
+		caCertPath:              caCertPath,
+		caKeyPath:               caKeyPath,
+		adminKeyPath:            adminKeyPath,
+		adminCertPath:           adminCertPath,
+		scheme:                  scheme,
+		usedPorts:               usedPorts,
+	}, nil
+}
+
+// Install installs the cluster
+func (c *Cluster) Install(ctx context.Context) error {
+	err := c.Cluster.Install(ctx)
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{
+		"logs",
+		configsDirName,
+	}
+
+	for _, dir := range dirs {
+		err = c.MkdirAll(c.GetWorkdirPath(dir))
+		if err != nil {
+			return err
+		}
+	}
+
+	env, err := c.env(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.preInstall(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setup(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setupPorts(ctx,
+		env.usedPorts,
+		&env.kwokctlConfig.Options.EtcdPeerPort,
+		&env.kwokctlConfig.Options.EtcdPort,
+		&env.kwokctlConfig.Options.KubeApiserverPort,
+		&env.kwokctlConfig.Options.KwokControllerPort,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = c.addEtcd(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeApiserver(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubectlProxy(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeControllerManager(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeScheduler(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKwokController(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addMetricsServer(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addCoreDNS(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeStateMetrics(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addPrometheus(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addJaeger(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addOtelCollector(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setupPrometheusConfig(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.finishInstall(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("multiple members of etcd are not supported by %s runtime", conf.Runtime)
+	}
+
+	// The kube-apiserver connects to the external etcd directly
+	if len(conf.EtcdEndpoints) != 0 {
+		return nil
+	}
+
+	if components.IsKineBackend(conf.EtcdBackend) {
+		return c.addKine(ctx, env)
+	}
+
+	// Configure the etcd
+	err = c.ensureImage(ctx, conf.EtcdImage)
+	if err != nil {
+		return err
+	}
+
+	etcdVersion := c.parseVersionFromImage(ctx, conf.EtcdImage)
+
+	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Image:       conf.EtcdImage,
+		Version:     etcdVersion,
+		BindAddress: conf.BindAddress,
+		DataPath:    env.etcdDataPath,
+		Port:        conf.EtcdPort,
+		PeerPort:    conf.EtcdPeerPort,
+		Verbosity:   env.verbosity,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	return nil
+}
+
+func (c *Cluster) addKine(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kine as the etcd
+	err = c.ensureImage(ctx, conf.KineImage)
+	if err != nil {
+		return err
+	}
+
+	kineComponent, err := components.BuildKineComponent(components.BuildKineComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Image:       conf.KineImage,
+		Version:     conf.KineVersion,
+		Backend:     conf.EtcdBackend,
+		Endpoint:    conf.KineEndpoint,
+		BindAddress: conf.BindAddress,
+		DataPath:    env.etcdDataPath,
+		Port:        conf.EtcdPort,
+		Verbosity:   env.verbosity,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kineComponent)
+	return nil
+}
+
+func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.KubeAuditWebhook != "" || conf.EnableKubeAuditSink {
+		return fmt.Errorf("the audit webhook is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.EnableKonnectivity {
+		return fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.IPFamily != consts.IPFamilyIPv4 {
+		return fmt.Errorf("ip family %s is not supported by %s runtime", conf.IPFamily, conf.Runtime)
+	}
+
+	if conf.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("multiple kube-apiservers are not supported by %s runtime", conf.Runtime)
+	}
+
+	// Configure the kube-apiserver
+	err = c.ensureImage(ctx, conf.KubeApiserverImage)
+	if err != nil {
+		return err
+	}
+
+	kubeApiserverVersion := c.parseVersionFromImage(ctx, conf.KubeApiserverImage)
+
+	kubeApiserverTracingConfigPath := ""
+	if conf.JaegerPort != 0 {
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.JaegerOtlpGrpcPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		kubeApiserverTracingConfigData, err := k8s.BuildKubeApiserverTracingConfig(k8s.BuildKubeApiserverTracingConfigParam{
+			Endpoint: net.LocalAddress + ":" + format.String(conf.JaegerOtlpGrpcPort),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate kubeApiserverTracingConfig yaml: %w", err)
+		}
+		kubeApiserverTracingConfigPath = c.GetWorkdirPath(runtime.ApiserverTracingConfig)
+
+		err = c.WriteFile(kubeApiserverTracingConfigPath, []byte(kubeApiserverTracingConfigData))
+		if err != nil {
+			return fmt.Errorf("failed to write kubeApiserverTracingConfig yaml: %w", err)
+		}
+	}
+
+	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+		Runtime:           conf.Runtime,
+		ProjectName:       c.Name(),
+		Workdir:           env.workdir,
+		Image:             conf.KubeApiserverImage,
+		Version:           kubeApiserverVersion,
+		BindAddress:       conf.BindAddress,
+		Port:              conf.KubeApiserverPort,
+		EtcdAddress:       net.LocalAddress,
+		EtcdPort:          conf.EtcdPort,
+		KubeRuntimeConfig: conf.KubeRuntimeConfig,
+		KubeFeatureGates:  conf.KubeFeatureGates,
+		SecurePort:        conf.SecurePort,
+		KubeAuthorization: conf.KubeAuthorization,
+		KubeAdmission:     conf.KubeAdmission,
+		AuditPolicyPath:   env.auditPolicyPath,
+		AuditLogPath:      env.auditLogPath,
+		CaCertPath:        env.caCertPath,
+		AdminCertPath:     env.adminCertPath,
+		AdminKeyPath:      env.adminKeyPath,
+		Verbosity:         env.verbosity,
+		DisableQPSLimits:  conf.DisableQPSLimits,
+		TracingConfigPath: kubeApiserverTracingConfigPath,
+		EtcdPrefix:        conf.EtcdPrefix,
+		EtcdEndpoints:     conf.EtcdEndpoints,
+		EtcdCaFile:        conf.EtcdCaFile,
+		EtcdCertFile:      conf.EtcdCertFile,
+		EtcdKeyFile:       conf.EtcdKeyFile,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	return nil
+}
+
+func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kubectl
+	if conf.KubeApiserverInsecurePort != 0 {
+		err = c.ensureImage(ctx, conf.KubectlImage)
+		if err != nil {
+			return err
+		}
+
+		kubectlProxyComponent, err := components.BuildKubectlProxyComponent(components.BuildKubectlProxyComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.KubectlImage,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.KubeApiserverInsecurePort,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubectlProxyComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kube-controller-manager
+	if !conf.DisableKubeControllerManager {
+		err = c.ensureImage(ctx, conf.KubeControllerManagerImage)
+		if err != nil {
+			return err
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeControllerManagerPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		kubeControllerManagerVersion := c.parseVersionFromImage(ctx, conf.KubeControllerManagerImage)
+
+		kubeControllerManagerComponent, err := components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
+			Runtime:                            conf.Runtime,
+			ProjectName:                        c.Name(),
+			Workdir:                            env.workdir,
+			Image:                              conf.KubeControllerManagerImage,
+			Version:                            kubeControllerManagerVersion,
+			BindAddress:                        conf.BindAddress,
+			Port:                               conf.KubeControllerManagerPort,
+			SecurePort:                         conf.SecurePort,
+			CaCertPath:                         env.caCertPath,
+			AdminCertPath:                      env.adminCertPath,
+			AdminKeyPath:                       env.adminKeyPath,
+			KubeAuthorization:                  conf.KubeAuthorization,
+			KubeconfigPath:                     env.inClusterKubeconfigPath,
+			KubeFeatureGates:                   conf.KubeFeatureGates,
+			NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
+			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
+			Verbosity:                          env.verbosity,
+			DisableQPSLimits:                   conf.DisableQPSLimits,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeControllerManagerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kube-scheduler
+	if !conf.DisableKubeScheduler {
+		err = c.ensureImage(ctx, conf.KubeSchedulerImage)
+		if err != nil {
+			return err
+		}
+
+		schedulerConfigPath := ""
+		if conf.KubeSchedulerConfig != "" {
+			schedulerConfigPath = c.GetWorkdirPath(runtime.SchedulerConfigName)
+			err = c.CopySchedulerConfig(conf.KubeSchedulerConfig, schedulerConfigPath, env.inClusterKubeconfigPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeSchedulerPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		kubeSchedulerVersion := c.parseVersionFromImage(ctx, conf.KubeSchedulerImage)
+
+		kubeSchedulerComponent, err := components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Image:            conf.KubeSchedulerImage,
+			Version:          kubeSchedulerVersion,
+			BindAddress:      conf.BindAddress,
+			Port:             conf.KubeSchedulerPort,
+			SecurePort:       conf.SecurePort,
+			CaCertPath:       env.caCertPath,
+			AdminCertPath:    env.adminCertPath,
+			AdminKeyPath:     env.adminKeyPath,
+			ConfigPath:       schedulerConfigPath,
+			KubeconfigPath:   env.inClusterKubeconfigPath,
+			KubeFeatureGates: conf.KubeFeatureGates,
+			Verbosity:        env.verbosity,
+			DisableQPSLimits: conf.DisableQPSLimits,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeSchedulerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kwok-controller
+	err = c.ensureImage(ctx, conf.KwokControllerImage)
+	if err != nil {
+		return err
+	}
+
+	kwokControllerVersion := c.parseVersionFromImage(ctx, conf.KwokControllerImage)
+
+	kwokControllerComponent := components.BuildKwokControllerComponent(components.BuildKwokControllerComponentConfig{
+		Runtime:                  conf.Runtime,
+		ProjectName:              c.Name(),
+		Workdir:                  env.workdir,
+		Image:                    conf.KwokControllerImage,
+		Version:                  kwokControllerVersion,
+		BindAddress:              conf.BindAddress,
+		Port:                     conf.KwokControllerPort,
+		ConfigPath:               env.kwokConfigPath,
+		KubeconfigPath:           env.inClusterKubeconfigPath,
+		CaCertPath:               env.caCertPath,
+		CaKeyPath:                env.caKeyPath,
+		EnableCSRSigner:          conf.EnableCSRSigner,
+		AdminCertPath:            env.adminCertPath,
+		AdminKeyPath:             env.adminKeyPath,
+		NodeName:                 "localhost",
+		Verbosity:                env.verbosity,
+		NodeLeaseDurationSeconds: conf.NodeLeaseDurationSeconds,
+		TimeAcceleration:         conf.TimeAcceleration,
+		EnableCRDs:               conf.EnableCRDs,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kwokControllerComponent)
+	return nil
+}
+
+func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableMetricsServer {
+		err = c.ensureImage(ctx, conf.MetricsServerImage)
+		if err != nil {
+			return err
+		}
+
+		metricsServerVersion := c.parseVersionFromImage(ctx, conf.MetricsServerImage)
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.MetricsServerPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		metricsServerComponent, err := components.BuildMetricsServerComponent(components.BuildMetricsServerComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.MetricsServerImage,
+			Version:        metricsServerVersion,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.MetricsServerPort,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, metricsServerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addCoreDNS(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableCoreDNS {
+		err = c.ensureImage(ctx, conf.CoreDNSImage)
+		if err != nil {
+			return err
+		}
+
+		coreDNSVersion := c.parseVersionFromImage(ctx, conf.CoreDNSImage)
+
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.CoreDNSPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		corefileData, err := components.BuildCoreDNSCorefile(components.BuildCoreDNSCorefileConfig{
+			Runtime:        conf.Runtime,
+			Port:           conf.CoreDNSPort,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		corefilePath := c.GetWorkdirPath(runtime.CoreDNSCorefile)
+		err = c.WriteFileWithMode(corefilePath, []byte(corefileData), 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write coredns corefile: %w", err)
+		}
+
+		coreDNSComponent, err := components.BuildCoreDNSComponent(components.BuildCoreDNSComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.CoreDNSImage,
+			Version:        coreDNSVersion,
+			Port:           conf.CoreDNSPort,
+			CorefilePath:   corefilePath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, coreDNSComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeStateMetrics(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeStateMetrics {
+		err = c.ensureImage(ctx, conf.KubeStateMetricsImage)
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsVersion := c.parseVersionFromImage(ctx, conf.KubeStateMetricsImage)
+
+		var telemetryPort uint32
+		err = c.setupPorts(ctx,
+			env.usedPorts,
+			&conf.KubeStateMetricsPort,
+			&telemetryPort,
+		)
+		if err != nil {
+			return err
+		}
+
+		kubeStateMetricsComponent, err := components.BuildKubeStateMetricsComponent(components.BuildKubeStateMetricsComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.KubeStateMetricsImage,
+			Version:        kubeStateMetricsVersion,
+			BindAddress:    conf.BindAddress,
+			Port:           conf.KubeStateMetricsPort,
+			TelemetryPort:  telemetryPort,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterKubeconfigPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeStateMetricsComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the prometheus
+	if conf.PrometheusPort != 0 {
+		prometheusData, err := components.BuildPrometheus(components.BuildPrometheusConfig{
+			Components: env.kwokctlConfig.Components,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate prometheus yaml: %w", err)
+		}
+		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
+		err = c.WriteFile(prometheusConfigPath, []byte(prometheusData))
+		if err != nil {
+			return fmt.Errorf("failed to write prometheus yaml: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the prometheus
+	if conf.PrometheusPort != 0 {
+		err = c.ensureImage(ctx, conf.PrometheusImage)
+		if err != nil {
+			return err
+		}
+
+		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
+
+		prometheusVersion := c.parseVersionFromImage(ctx, conf.PrometheusImage)
+
+		prometheusComponent, err := components.BuildPrometheusComponent(components.BuildPrometheusComponentConfig{
+			Runtime:     conf.Runtime,
+			Workdir:     env.workdir,
+			Image:       conf.PrometheusImage,
+			Version:     prometheusVersion,
+			BindAddress: conf.BindAddress,
+			Port:        conf.PrometheusPort,
+			ConfigPath:  prometheusConfigPath,
+			Verbosity:   env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, prometheusComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addGrafana(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		return fmt.Errorf("grafana is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the jaeger
+	if conf.JaegerPort != 0 {
+		err = c.ensureImage(ctx, conf.JaegerImage)
+		if err != nil {
+			return err
+		}
+
+		jaegerVersion := c.parseVersionFromImage(ctx, conf.JaegerImage)
+
+		jaegerComponent, err := components.BuildJaegerComponent(components.BuildJaegerComponentConfig{
+			Runtime:      conf.Runtime,
+			Workdir:      env.workdir,
+			Image:        conf.JaegerImage,
+			Version:      jaegerVersion,
+			BindAddress:  conf.BindAddress,
+			Port:         conf.JaegerPort,
+			OtlpGrpcPort: conf.JaegerOtlpGrpcPort,
+			Verbosity:    env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, jaegerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addOtelCollector(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableOtelCollector || conf.OtlpEndpoint != "" {
+		return fmt.Errorf("otel-collector is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) preInstall(_ context.Context, env *env) error {
+	patches, err := runtime.ExpandComponentPatchesFiles(env.kwokctlConfig.ComponentsPatches, true)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.ComponentsPatches = patches
+	return nil
+}
+
+func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	err := runtime.AddExtraComponents(env.kwokctlConfig, env.workdir, true)
+	if err != nil {
+		return err
+	}
+
+	for i := range env.kwokctlConfig.Components {
+		runtime.ApplyComponentPatches(&env.kwokctlConfig.Components[i], env.kwokctlConfig.ComponentsPatches)
+	}
+
+	// Setup kubeconfig
+	inClusterKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
+		CACrtPath:     env.caCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
+	}))
+	if err != nil {
+		return err
+	}
+	err = c.WriteFile(env.inClusterKubeconfigPath, inClusterKubeconfigData)
+	if err != nil {
+		return err
+	}
+
+	if conf.KubeApiserverInsecurePort != 0 {
+		kubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
+			ProjectName: c.Name(),
+			SecurePort:  false,
+			Address:     "http://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverInsecurePort),
+		}))
+		if err != nil {
+			return err
+		}
+		err = c.WriteFile(env.kubeconfigPath, kubeconfigData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Save config
+	err = c.SetConfig(ctx, env.kwokctlConfig)
+	if err != nil {
+		return err
+	}
+	err = c.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Uninstall uninstalls the cluster.
+func (c *Cluster) Uninstall(ctx context.Context) error {
+	err := c.removeComponents(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Cluster.Uninstall(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureImage pulls the image if it doesn't exist in CRI-O.
+func (c *Cluster) ensureImage(ctx context.Context, image string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s pull %s", c.runtime, image)
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	err := exec.Exec(ctx, c.runtime, "inspecti", image)
+	if err == nil {
+		logger.Debug("Image already exists",
+			"image", image,
+		)
+		return nil
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stderr
+	if config.Options.QuietPull {
+		out = nil
+	}
+	return exec.Exec(exec.WithAllWriteTo(ctx, out), c.runtime, "pull", image)
+}
+
+// parseVersionFromImage parses the version from the tag of the image,
+// as CRI-O can't run a one-off container to ask the binary for its version,
+// the unknown version is used if the tag is not a version.
+func (c *Cluster) parseVersionFromImage(ctx context.Context, image string) version.Version {
+	if c.IsDryRun() {
+		return version.Unknown
+	}
+
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	ver, err := version.ParseVersion(tag)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Debug("Failed to parse version from image tag, use unknown version",
+			"image", image,
+			"err", err,
+		)
+		return version.Unknown
+	}
+	return ver
+}
+
+// output runs crictl and returns the trimmed output,
+// on dry-run the output is captured in a shell variable instead.
+func (c *Cluster) output(ctx context.Context, variable string, args ...string) (string, error) {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s=$(%s)", variable, runtime.FormatExec(ctx, c.runtime, args...))
+		return "${" + variable + "}", nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := exec.Exec(exec.WithWriteTo(ctx, buf), c.runtime, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func (c *Cluster) listIDs(ctx context.Context, args ...string) ([]string, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+
+	out, err := c.output(ctx, "", args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// podIDs returns the IDs of the pod sandboxes of the component,
+// all the pod sandboxes of the cluster are returned if the name is empty.
+func (c *Cluster) podIDs(ctx context.Context, name string) ([]string, error) {
+	args := []string{"pods", "-q", "--label", clusterLabel + "=" + c.Name()}
+	if name != "" {
+		args = append(args, "--label", componentLabel+"="+name)
+	}
+	return c.listIDs(ctx, args...)
+}
+
+// containerID returns the ID of the container of the component.
+func (c *Cluster) containerID(ctx context.Context, name string) (string, error) {
+	ids, err := c.listIDs(ctx, "ps", "-a", "-q", "--label", clusterLabel+"="+c.Name(), "--label", componentLabel+"="+name)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("container of %s not found", name)
+	}
+	return ids[0], nil
+}
+
+func (c *Cluster) inspect(ctx context.Context, name string) (running bool, pid int, err error) {
+	id, err := c.containerID(ctx, name)
+	if err != nil {
+		return false, 0, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = exec.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "inspect", id)
+	if err != nil {
+		return false, 0, err
+	}
+	return parseInspect(buf.Bytes())
+}
+
+func (c *Cluster) isRunning(ctx context.Context, component internalversion.Component) bool {
+	if c.IsDryRun() {
+		return false
+	}
+
+	running, _, err := c.inspect(ctx, component.Name)
+	if err != nil {
+		return false
+	}
+	return running
+}
+
+func (c *Cluster) startComponent(ctx context.Context, component internalversion.Component) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", component.Name)
+	if c.isRunning(ctx, component) {
+		logger.Debug("Component already started")
+		return nil
+	}
+
+	// The pod sandbox is recreated each time, so that the changes of the component take effect.
+	err := c.removeComponent(ctx, component)
+	if err != nil {
+		return err
+	}
+
+	envs := component.Envs
+	if len(component.EnvFiles) > 0 {
+		envsFromFiles, err := components.LoadEnvFiles(component.EnvFiles)
+		if err != nil {
+			return err
+		}
+		// The environment variables in Envs take precedence, so they are set after the ones from the files.
+		envs = append(envsFromFiles, envs...)
+	}
+
+	podConfig, err := buildPodSandboxConfig(c.Name(), component, c.GetWorkdirPath("logs"))
+	if err != nil {
+		return err
+	}
+	containerConfig, err := buildContainerConfig(c.Name(), component, envs, c.Workdir())
+	if err != nil {
+		return err
+	}
+
+	podConfigPath := c.GetWorkdirPath(path.Join(configsDirName, component.Name+"-pod.json"))
+	err = c.WriteFile(podConfigPath, podConfig)
+	if err != nil {
+		return err
+	}
+	containerConfigPath := c.GetWorkdirPath(path.Join(configsDirName, component.Name+"-container.json"))
+	err = c.WriteFile(containerConfigPath, containerConfig)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Starting component")
+	podID, err := c.output(ctx, "POD_ID", "runp", podConfigPath)
+	if err != nil {
+		return err
+	}
+	containerID, err := c.output(ctx, "CONTAINER_ID", "create", podID, containerConfigPath, podConfigPath)
+	if err != nil {
+		return err
+	}
+	return c.Exec(ctx, c.runtime, "start", containerID)
+}
+
+func (c *Cluster) startComponents(ctx context.Context) error {
+	err := c.ForeachComponents(ctx, false, true, func(ctx context.Context, component internalversion.Component) error {
+		if runtime.IsLazyComponent(component) {
+			log.FromContext(ctx).Debug("Skip starting lazy component", "component", component.Name)
+			return nil
+		}
+		return c.startComponent(ctx, component)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// stopComponent stops the pod sandbox of the component, the container is kept for its logs.
+func (c *Cluster) stopComponent(ctx context.Context, component internalversion.Component) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", component.Name)
+	if !c.IsDryRun() && !c.isRunning(ctx, component) {
+		logger.Debug("Component already stopped")
+		return nil
+	}
+
+	ids, err := c.podIDs(ctx, component.Name)
+	if err != nil {
+		return err
+	}
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s stopp $(%s pods -q --label %s=%s --label %s=%s)", c.runtime, c.runtime, clusterLabel, c.Name(), componentLabel, component.Name)
+		return nil
+	}
+
+	logger.Debug("Stopping component")
+	for _, id := range ids {
+		err = c.Exec(ctx, c.runtime, "stopp", id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) stopComponents(ctx context.Context) error {
+	err := c.ForeachComponents(ctx, true, true, func(ctx context.Context, component internalversion.Component) error {
+		return c.stopComponent(ctx, component)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// removeComponent removes the pod sandbox and the container of the component.
+func (c *Cluster) removeComponent(ctx context.Context, component internalversion.Component) error {
+	ids, err := c.podIDs(ctx, component.Name)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		err = c.Exec(ctx, c.runtime, "rmp", "-f", id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeComponents removes all the pod sandboxes of the cluster.
+func (c *Cluster) removeComponents(ctx context.Context) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s rmp -f $(%s pods -q --label %s=%s)", c.runtime, c.runtime, clusterLabel, c.Name())
+		return nil
+	}
+
+	ids, err := c.podIDs(ctx, "")
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		err = c.Exec(ctx, c.runtime, "rmp", "-f", id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Up starts the cluster.
+func (c *Cluster) Up(ctx context.Context) error {
+	return c.start(ctx)
+}
+
+// Down stops the cluster and removes the pod sandboxes of the components
+func (c *Cluster) Down(ctx context.Context) error {
+	return c.removeComponents(ctx)
+}
+
+// Detach removes the pod sandboxes of the components, the data of etcd is kept in the workdir
+func (c *Cluster) Detach(ctx context.Context) error {
+	return c.removeComponents(ctx)
+}
+
+// Attach starts the cluster from the workdir
+func (c *Cluster) Attach(ctx context.Context) error {
+	return c.start(ctx)
+}
+
+// Start starts the cluster
+func (c *Cluster) Start(ctx context.Context) error {
+	return c.start(ctx)
+}
+
+// Stop stops the cluster
+func (c *Cluster) Stop(ctx context.Context) error {
+	return c.stop(ctx)
+}
+
+func (c *Cluster) start(ctx context.Context) error {
+	err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		err := c.startComponents(ctx)
+		return err == nil, err
+	},
+		wait.WithContinueOnError(5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsDryRun() {
+		logger := log.FromContext(ctx)
+		err = c.waitServed(ctx, 2*time.Minute)
+		if err != nil {
+			logger.Warn("Cluster is not served yet", "err", err)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) served(ctx context.Context) (bool, error) {
+	err := c.KubectlInCluster(ctx, "get", "--raw", "/version")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Cluster) waitServed(ctx context.Context, timeout time.Duration) error {
+	var (
+		err     error
+		waitErr error
+		ready   bool
+	)
+	logger := log.FromContext(ctx)
+	waitErr = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, err = c.served(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not served yet",
+				"err", err,
+			)
+		}
+		return ready, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithInterval(time.Second/5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+func (c *Cluster) stop(ctx context.Context) error {
+	err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		err := c.stopComponents(ctx)
+		return err == nil, err
+	},
+		wait.WithContinueOnError(5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StartComponent starts a component in the cluster
+func (c *Cluster) StartComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.startComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return nil
+}
+
+// StopComponent stops a component in the cluster
+func (c *Cluster) StopComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.stopComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// UpgradeComponent replaces the image of the component and recreates it with the same configuration
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string, conf runtime.UpgradeComponentConfig) error {
+	err := runtime.CheckUpgradeComponent(name)
+	if err != nil {
+		return err
+	}
+	if conf.Image == "" {
+		return fmt.Errorf("image of %s is required to upgrade it", name)
+	}
+
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Pull the new image first, so that the running component is kept if it fails.
+	err = c.ensureImage(ctx, conf.Image)
+	if err != nil {
+		return err
+	}
+	ver := c.parseVersionFromImage(ctx, conf.Image)
+
+	err = c.removeComponent(ctx, component)
+	if err != nil {
+		return err
+	}
+
+	component.Image = conf.Image
+	component.Version = ver.String()
+	err = c.SaveUpgradedComponent(ctx, component, conf)
+	if err != nil {
+		return err
+	}
+	return c.StartComponent(ctx, name)
+}
+
+func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if c.IsDryRun() {
+		args := []string{"logs"}
+		if follow {
+			args = append(args, "-f")
+		}
+		args = append(args, fmt.Sprintf("$(%s ps -a -q --label %s=%s --label %s=%s)", c.runtime, clusterLabel, c.Name(), componentLabel, name))
+		if file, ok := dryrun.IsCatToFileWriter(out); ok && !follow {
+			dryrun.PrintMessage("%s >%s", runtime.FormatExec(ctx, c.runtime, args...), file)
+		} else {
+			dryrun.PrintMessage("%s", runtime.FormatExec(ctx, c.runtime, args...))
+		}
+		return nil
+	}
+
+	id, err := c.containerID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, id)
+	err = c.Exec(exec.WithAllWriteTo(ctx, out), c.runtime, args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Logs returns the logs of the specified component.
+func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, false)
+}
+
+// LogsFollow follows the logs of the component
+func (c *Cluster) LogsFollow(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, true)
+}
+
+// CollectLogs returns the logs of the specified component.
+func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
+	logger := log.FromContext(ctx)
+
+	kwokConfigPath := path.Join(dir, "kwok.yaml")
+	if file.Exists(kwokConfigPath) {
+		return fmt.Errorf("%s already exists", kwokConfigPath)
+	}
+
+	if err := c.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create tmp directory: %w", err)
+	}
+	logger.Info("Exporting logs", "dir", dir)
+
+	err := c.CopyFile(c.GetWorkdirPath(runtime.ConfigName), kwokConfigPath)
+	if err != nil {
+		return err
+	}
+
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	componentsDir := path.Join(dir, "components")
+	err = c.MkdirAll(componentsDir)
+	if err != nil {
+		return err
+	}
+
+	infoPath := path.Join(dir, consts.RuntimeTypeCrio+"-info.txt")
+	err = c.WriteToPath(ctx, infoPath, []string{c.runtime, "info"})
+	if err != nil {
+		return err
+	}
+
+	for _, component := range conf.Components {
+		logPath := path.Join(componentsDir, component.Name+".log")
+		f, err := c.OpenFile(logPath)
+		if err != nil {
+			logger.Error("Failed to open file", err)
+			continue
+		}
+		if err = c.Logs(ctx, component.Name, f); err != nil {
+			logger.Error("Failed to get log", err)
+		}
+		if err = f.Close(); err != nil {
+			logger.Error("Failed to close file", err)
+			if err = c.Remove(logPath); err != nil {
+				logger.Error("Failed to remove file", err)
+			}
+		}
+	}
+	if conf.Options.KubeAuditPolicy != "" {
+		src := c.GetLogPath(runtime.AuditLogName)
+		dest := path.Join(componentsDir, runtime.AuditLogName)
+		if err = c.CopyFile(src, dest); err != nil {
+			logger.Error("Failed to copy file", err)
+		}
+	}
+
+	return nil
+}
+
+// ListBinaries list binaries in the cluster
+func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	return []string{
+		conf.KubectlBinary,
+	}, nil
+}
+
+// ListImages list images in the cluster
+func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	images := []string{
+		conf.EtcdImage,
+		conf.KubeApiserverImage,
+		conf.KubeControllerManagerImage,
+		conf.KubeSchedulerImage,
+		conf.KwokControllerImage,
+		conf.PrometheusImage,
+		conf.MetricsServerImage,
+	}
+	if components.IsKineBackend(conf.EtcdBackend) {
+		images = append(images, conf.KineImage)
+	}
+	if conf.EnableCoreDNS {
+		images = append(images, conf.CoreDNSImage)
+	}
+	if conf.EnableKubeStateMetrics {
+		images = append(images, conf.KubeStateMetricsImage)
+	}
+	for _, component := range config.ExtraComponents {
+		images = append(images, component.Image)
+	}
+	return images, nil
+}
+
+// EtcdctlInCluster implements the ectdctl subcommand
+func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+	return c.Etcdctl(ctx, append([]string{"--endpoints", net.LocalAddress + ":" + format.String(conf.EtcdPort)}, args...)...)
+}
+
+// InspectComponent returns the status of the component
+func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentStatusUnknown, err
+	}
+
+	running := c.isRunning(ctx, component)
+	if !running {
+		return runtime.ComponentStatusStopped, nil
+	}
+
+	// TODO: check if the component is ready
+
+	return runtime.ComponentStatusReady, nil
+}
+
+// InspectComponentRestarts returns the restarts of the component
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+
+	// The components are not restarted by CRI-O without the kubelet
+	return runtime.ComponentRestarts{}, nil
+}
+
+// InspectUsage returns the usage of the host resources by the running components
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pids := map[string]int{}
+	for _, component := range config.Components {
+		running, pid, err := c.inspect(ctx, component.Name)
+		if err != nil || !running || pid == 0 {
+			continue
+		}
+		pids[component.Name] = pid
+	}
+	return runtime.InspectProcessesUsage(ctx, pids)
+}
+
+// Ready returns true if the cluster is ready
+func (c *Cluster) Ready(ctx context.Context) (bool, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// TODO: Only the necessary components are checked for readiness.
+	for _, component := range config.Components {
+		if runtime.IsLazyComponent(component) {
+			continue
+		}
+		s, _ := c.InspectComponent(ctx, component.Name)
+		if s != runtime.ComponentStatusReady {
+			return false, nil
+		}
+	}
+
+	return c.Cluster.Ready(ctx)
+}
+
+// WaitReady waits for the cluster to be ready.
+func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if c.IsDryRun() {
+		return nil
+	}
+
+	var (
+		err     error
+		waitErr error
+		ready   bool
+	)
+	logger := log.FromContext(ctx)
+	waitErr = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, err = c.Ready(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not ready",
+				"err", err,
+			)
+		}
+		return ready, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithContinueOnError(10),
+		wait.WithInterval(time.Second/2),
+	)
+	if err != nil {
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+// InitCRs initializes the CRs.
+func (c *Cluster) InitCRs(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := config.Options
+
+	if c.IsDryRun() {
+		if conf.EnableMetricsServer {
+			dryrun.PrintMessage("# Set up apiservice for metrics server")
+		}
+
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if conf.EnableMetricsServer {
+		apiservice, err := components.BuildMetricsServerAPIService(components.BuildMetricsServerAPIServiceConfig{
+			Port:         conf.MetricsServerPort,
+			ExternalName: "localhost",
+		})
+		if err != nil {
+			return err
+		}
+		_, _ = buf.WriteString(apiservice)
+		_, _ = buf.WriteString("---\n")
+	}
+
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	clientset, err := c.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+
+	loader, err := snapshot.NewLoader(snapshot.LoadConfig{
+		Clientset: clientset,
+		NoFilers:  true,
+	})
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(buf)
+
+	return loader.Load(ctx, decoder)
+}
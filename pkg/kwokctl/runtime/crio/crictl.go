@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+const (
+	clusterLabel   = "kwok.x-k8s.io/cluster"
+	componentLabel = "kwok.x-k8s.io/component"
+
+	podNamespace = "kwok"
+
+	// namespaceModeNode is the NamespaceMode of CRI that shares the namespace with the host.
+	namespaceModeNode = 2
+
+	propagationPrivate         = 0
+	propagationHostToContainer = 1
+	propagationBidirectional   = 2
+)
+
+// The following types are the subset of the CRI types used by crictl to create pod sandboxes and containers.
+
+type podSandboxConfig struct {
+	Metadata     podSandboxMetadata    `json:"metadata"`
+	LogDirectory string                `json:"log_directory,omitempty"`
+	Labels       map[string]string     `json:"labels,omitempty"`
+	Linux        linuxPodSandboxConfig `json:"linux"`
+}
+
+type podSandboxMetadata struct {
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+	Namespace string `json:"namespace"`
+}
+
+type linuxPodSandboxConfig struct {
+	SecurityContext linuxSecurityContext `json:"security_context"`
+}
+
+type linuxSecurityContext struct {
+	NamespaceOptions namespaceOption `json:"namespace_options"`
+	RunAsUsername    string          `json:"run_as_username,omitempty"`
+}
+
+type namespaceOption struct {
+	Network int `json:"network"`
+}
+
+type containerConfig struct {
+	Metadata   containerMetadata    `json:"metadata"`
+	Image      imageSpec            `json:"image"`
+	Command    []string             `json:"command,omitempty"`
+	Args       []string             `json:"args,omitempty"`
+	WorkingDir string               `json:"working_dir,omitempty"`
+	Envs       []keyValue           `json:"envs,omitempty"`
+	Mounts     []mount              `json:"mounts,omitempty"`
+	Labels     map[string]string    `json:"labels,omitempty"`
+	LogPath    string               `json:"log_path,omitempty"`
+	Linux      linuxContainerConfig `json:"linux"`
+}
+
+type containerMetadata struct {
+	Name string `json:"name"`
+}
+
+type imageSpec struct {
+	Image string `json:"image"`
+}
+
+type keyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type mount struct {
+	ContainerPath string `json:"container_path"`
+	HostPath      string `json:"host_path"`
+	Readonly      bool   `json:"readonly,omitempty"`
+	Propagation   int    `json:"propagation,omitempty"`
+}
+
+type linuxContainerConfig struct {
+	SecurityContext linuxSecurityContext `json:"security_context"`
+}
+
+// podName returns the name of the pod sandbox of the component.
+func podName(clusterName, componentName string) string {
+	return clusterName + "-" + componentName
+}
+
+func labels(clusterName, componentName string) map[string]string {
+	return map[string]string{
+		clusterLabel:   clusterName,
+		componentLabel: componentName,
+	}
+}
+
+// buildPodSandboxConfig builds the pod sandbox of the component, it uses the network of the host
+// so that the components reach each other in the same way as the binary runtime.
+func buildPodSandboxConfig(clusterName string, component internalversion.Component, logDir string) ([]byte, error) {
+	name := podName(clusterName, component.Name)
+	conf := podSandboxConfig{
+		Metadata: podSandboxMetadata{
+			Name:      name,
+			UID:       name,
+			Namespace: podNamespace,
+		},
+		LogDirectory: logDir,
+		Labels:       labels(clusterName, component.Name),
+		Linux: linuxPodSandboxConfig{
+			SecurityContext: linuxSecurityContext{
+				NamespaceOptions: namespaceOption{
+					Network: namespaceModeNode,
+				},
+			},
+		},
+	}
+	return json.MarshalIndent(conf, "", "  ")
+}
+
+// buildContainerConfig builds the container of the component,
+// the workdir of the cluster is mounted at the same path as on the host, as the arguments of the component refer to it.
+func buildContainerConfig(clusterName string, component internalversion.Component, envs []internalversion.Env, workdir string) ([]byte, error) {
+	if component.Image == "" {
+		return nil, fmt.Errorf("component %q has no image", component.Name)
+	}
+
+	mounts := []mount{
+		{
+			ContainerPath: workdir,
+			HostPath:      workdir,
+		},
+	}
+	for _, volume := range component.Volumes {
+		mounts = append(mounts, mount{
+			ContainerPath: volume.MountPath,
+			HostPath:      components.VolumeHostPath(volume),
+			Readonly:      volume.ReadOnly,
+			Propagation:   propagation(volume.MountPropagation),
+		})
+	}
+
+	conf := containerConfig{
+		Metadata: containerMetadata{
+			Name: component.Name,
+		},
+		Image: imageSpec{
+			Image: component.Image,
+		},
+		Command:    component.Command,
+		Args:       component.Args,
+		WorkingDir: component.WorkDir,
+		Envs: slices.Map(envs, func(env internalversion.Env) keyValue {
+			return keyValue{
+				Key:   env.Name,
+				Value: env.Value,
+			}
+		}),
+		Mounts:  mounts,
+		Labels:  labels(clusterName, component.Name),
+		LogPath: component.Name + ".log",
+		Linux: linuxContainerConfig{
+			SecurityContext: linuxSecurityContext{
+				NamespaceOptions: namespaceOption{
+					Network: namespaceModeNode,
+				},
+				RunAsUsername: component.User,
+			},
+		},
+	}
+	return json.MarshalIndent(conf, "", "  ")
+}
+
+func propagation(mode internalversion.MountPropagationMode) int {
+	switch mode {
+	case internalversion.MountPropagationHostToContainer:
+		return propagationHostToContainer
+	case internalversion.MountPropagationBidirectional:
+		return propagationBidirectional
+	default:
+		return propagationPrivate
+	}
+}
+
+// containerInspect is the subset of the output of crictl inspect.
+type containerInspect struct {
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+	Info struct {
+		Pid int `json:"pid"`
+	} `json:"info"`
+}
+
+const containerStateRunning = "CONTAINER_RUNNING"
+
+func parseInspect(raw []byte) (running bool, pid int, err error) {
+	var inspect containerInspect
+	err = json.Unmarshal(raw, &inspect)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to parse inspect result: %w", err)
+	}
+	return inspect.Status.State == containerStateRunning, inspect.Info.Pid, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crio
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_buildPodSandboxConfig(t *testing.T) {
+	raw, err := buildPodSandboxConfig("kwok", internalversion.Component{Name: "etcd"}, "/workdir/logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got podSandboxConfig
+	err = json.Unmarshal(raw, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := podSandboxConfig{
+		Metadata: podSandboxMetadata{
+			Name:      "kwok-etcd",
+			UID:       "kwok-etcd",
+			Namespace: podNamespace,
+		},
+		LogDirectory: "/workdir/logs",
+		Labels: map[string]string{
+			clusterLabel:   "kwok",
+			componentLabel: "etcd",
+		},
+		Linux: linuxPodSandboxConfig{
+			SecurityContext: linuxSecurityContext{
+				NamespaceOptions: namespaceOption{
+					Network: namespaceModeNode,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildPodSandboxConfig() got = %+v, want %+v", got, want)
+	}
+}
+
+func Test_buildContainerConfig(t *testing.T) {
+	type args struct {
+		component internalversion.Component
+		envs      []internalversion.Env
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    containerConfig
+		wantErr bool
+	}{
+		{
+			name: "no image",
+			args: args{
+				component: internalversion.Component{
+					Name: "etcd",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "component",
+			args: args{
+				component: internalversion.Component{
+					Name:    "etcd",
+					Image:   "registry.k8s.io/etcd:3.5.11-0",
+					Command: []string{"etcd"},
+					Args:    []string{"--data-dir=/workdir/etcd"},
+					WorkDir: "/workdir",
+					User:    "nobody",
+					Volumes: []internalversion.Volume{
+						{
+							HostPath:         "/host/secret",
+							MountPath:        "/etc/secret",
+							ReadOnly:         true,
+							MountPropagation: internalversion.MountPropagationHostToContainer,
+						},
+					},
+				},
+				envs: []internalversion.Env{
+					{Name: "FOO", Value: "bar"},
+				},
+			},
+			want: containerConfig{
+				Metadata: containerMetadata{
+					Name: "etcd",
+				},
+				Image: imageSpec{
+					Image: "registry.k8s.io/etcd:3.5.11-0",
+				},
+				Command:    []string{"etcd"},
+				Args:       []string{"--data-dir=/workdir/etcd"},
+				WorkingDir: "/workdir",
+				Envs: []keyValue{
+					{Key: "FOO", Value: "bar"},
+				},
+				Mounts: []mount{
+					{
+						ContainerPath: "/workdir",
+						HostPath:      "/workdir",
+					},
+					{
+						ContainerPath: "/etc/secret",
+						HostPath:      "/host/secret",
+						Readonly:      true,
+						Propagation:   propagationHostToContainer,
+					},
+				},
+				Labels: map[string]string{
+					clusterLabel:   "kwok",
+					componentLabel: "etcd",
+				},
+				LogPath: "etcd.log",
+				Linux: linuxContainerConfig{
+					SecurityContext: linuxSecurityContext{
+						NamespaceOptions: namespaceOption{
+							Network: namespaceModeNode,
+						},
+						RunAsUsername: "nobody",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := buildContainerConfig("kwok", tt.args.component, tt.args.envs, "/workdir")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildContainerConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got containerConfig
+			err = json.Unmarshal(raw, &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildContainerConfig() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseInspect(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         []byte
+		wantRunning bool
+		wantPid     int
+		wantErr     bool
+	}{
+		{
+			name:        "running",
+			raw:         []byte(`{"status":{"state":"CONTAINER_RUNNING"},"info":{"pid":42}}`),
+			wantRunning: true,
+			wantPid:     42,
+		},
+		{
+			name: "exited",
+			raw:  []byte(`{"status":{"state":"CONTAINER_EXITED"},"info":{}}`),
+		},
+		{
+			name:    "invalid",
+			raw:     []byte(`not json`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			running, pid, err := parseInspect(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseInspect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if running != tt.wantRunning {
+				t.Errorf("parseInspect() running = %v, want %v", running, tt.wantRunning)
+			}
+			if pid != tt.wantPid {
+				t.Errorf("parseInspect() pid = %v, want %v", pid, tt.wantPid)
+			}
+		})
+	}
+}
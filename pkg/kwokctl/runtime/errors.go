@@ -23,4 +23,7 @@ import (
 var (
 	// ErrComponentNotFound is returned when a component is not found
 	ErrComponentNotFound = fmt.Errorf("component not found")
+
+	// ErrComponentUpgradeNotSupported is returned when a component can not be upgraded
+	ErrComponentUpgradeNotSupported = fmt.Errorf("component upgrade not supported")
 )
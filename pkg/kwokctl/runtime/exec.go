@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
@@ -39,7 +40,31 @@ import (
 // ForkExec forks a new process and execs the given command.
 // The process will be terminated when the context is canceled.
 func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ...string) error {
-	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+	return c.forkExec(ctx, dir, path.OnlyName(name), name, args...)
+}
+
+// ForkExecSupervised forks a supervisor process which execs the given command and restarts it by the restart policy when it exits,
+// the restarts are recorded in the file returned by ForkExecRestartsPath.
+func (c *Cluster) ForkExecSupervised(ctx context.Context, dir string, restartPolicy internalversion.RestartPolicy, name string, args ...string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get executable: %w", err)
+	}
+	if restartPolicy == "" {
+		restartPolicy = internalversion.RestartPolicyAlways
+	}
+	supervisorArgs := []string{"supervise", "--state", ForkExecRestartsPath(dir, name), "--restart-policy", string(restartPolicy), "--", name}
+	supervisorArgs = append(supervisorArgs, args...)
+	return c.forkExec(ctx, dir, path.OnlyName(name), self, supervisorArgs...)
+}
+
+// ForkExecRestartsPath returns the path of the file the restarts of the supervised process are recorded in.
+func ForkExecRestartsPath(dir string, name string) string {
+	return path.Join(dir, "pids", path.OnlyName(name)+".restarts.json")
+}
+
+func (c *Cluster) forkExec(ctx context.Context, dir string, pidName string, name string, args ...string) error {
+	pidPath := path.Join(dir, "pids", pidName+".pid")
 	if file.Exists(pidPath) {
 		pidData, err := os.ReadFile(pidPath)
 		if err == nil {
@@ -53,7 +78,7 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 	}
 	ctx = exec.WithDir(ctx, dir)
 	ctx = exec.WithFork(ctx, true)
-	logPath := path.Join(dir, "logs", path.OnlyName(name)+".log")
+	logPath := path.Join(dir, "logs", pidName+".log")
 	logFile, err := c.OpenFile(logPath)
 	if err != nil {
 		return fmt.Errorf("open log file %s: %w", logPath, err)
@@ -141,6 +166,15 @@ func (c *Cluster) ForkExecIsRunning(ctx context.Context, dir string, name string
 	return exec.IsRunning(pid)
 }
 
+// ForkExecPid returns the pid of the process forked by ForkExec.
+func ForkExecPid(dir string, name string) (int, error) {
+	pidData, err := os.ReadFile(path.Join(dir, "pids", path.OnlyName(name)+".pid"))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(pidData))
+}
+
 // EnsureImage ensures the image exists.
 func (c *Cluster) EnsureImage(ctx context.Context, command string, image string) error {
 	if c.IsDryRun() {
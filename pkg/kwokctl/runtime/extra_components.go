@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// AddExtraComponents appends the user-defined components to the components of the cluster,
+// so that they are started, stopped and health-checked along with the built-in components.
+// The components run from their images if inContainer is true, otherwise from their binaries,
+// and the relative paths of their binaries and volumes are expanded.
+func AddExtraComponents(conf *internalversion.KwokctlConfiguration, workdir string, inContainer bool) error {
+	for _, component := range conf.ExtraComponents {
+		if component.Name == "" {
+			return fmt.Errorf("the name of the extra component is required")
+		}
+		if _, ok := slices.Find(conf.Components, func(c internalversion.Component) bool {
+			return c.Name == component.Name
+		}); ok {
+			return fmt.Errorf("the extra component %q conflicts with an existing component", component.Name)
+		}
+
+		component := *component.DeepCopy()
+		if inContainer {
+			if component.Image == "" {
+				return fmt.Errorf("the image of the extra component %q is required", component.Name)
+			}
+		} else {
+			if component.Binary == "" {
+				return fmt.Errorf("the binary of the extra component %q is required", component.Name)
+			}
+			binary, err := path.Expand(component.Binary)
+			if err != nil {
+				return err
+			}
+			component.Binary = binary
+		}
+
+		volumes, err := ExpandVolumesHostPaths(component.Volumes)
+		if err != nil {
+			return fmt.Errorf("failed to expand host volumes for %q component: %w", component.Name, err)
+		}
+		component.Volumes = volumes
+
+		if component.WorkDir == "" {
+			component.WorkDir = workdir
+		}
+		conf.Components = append(conf.Components, component)
+	}
+	return nil
+}
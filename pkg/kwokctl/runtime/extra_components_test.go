@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestAddExtraComponents(t *testing.T) {
+	tests := []struct {
+		name        string
+		components  []internalversion.Component
+		extra       []internalversion.Component
+		inContainer bool
+		wantErr     bool
+	}{
+		{
+			name: "container",
+			extra: []internalversion.Component{
+				{Name: "mock-cloud", Image: "docker.io/library/nginx:1.27", Links: []string{"kube-apiserver"}},
+			},
+			inContainer: true,
+		},
+		{
+			name: "binary",
+			extra: []internalversion.Component{
+				{Name: "mock-cloud", Binary: "/usr/local/bin/mock-cloud"},
+			},
+		},
+		{
+			name: "no image",
+			extra: []internalversion.Component{
+				{Name: "mock-cloud", Binary: "/usr/local/bin/mock-cloud"},
+			},
+			inContainer: true,
+			wantErr:     true,
+		},
+		{
+			name: "no name",
+			extra: []internalversion.Component{
+				{Image: "docker.io/library/nginx:1.27"},
+			},
+			inContainer: true,
+			wantErr:     true,
+		},
+		{
+			name:       "conflict",
+			components: []internalversion.Component{{Name: "kube-apiserver"}},
+			extra: []internalversion.Component{
+				{Name: "kube-apiserver", Image: "docker.io/library/nginx:1.27"},
+			},
+			inContainer: true,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &internalversion.KwokctlConfiguration{
+				Components:      tt.components,
+				ExtraComponents: tt.extra,
+			}
+			err := AddExtraComponents(conf, "/workdir", tt.inContainer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddExtraComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(conf.Components) != len(tt.components)+len(tt.extra) {
+				t.Fatalf("Components = %v, want the extra components appended", conf.Components)
+			}
+			got := conf.Components[len(conf.Components)-1]
+			if got.WorkDir != "/workdir" {
+				t.Errorf("WorkDir = %q, want %q", got.WorkDir, "/workdir")
+			}
+		})
+	}
+}
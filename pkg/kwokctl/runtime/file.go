@@ -45,14 +45,18 @@ func (c *Cluster) DownloadWithCache(ctx context.Context, cacheDir, src, dest str
 	return file.DownloadWithCache(ctx, cacheDir, src, dest, mode, quiet)
 }
 
-// GeneratePki generates the pki for kwokctl
-func (c *Cluster) GeneratePki(pkiPath string, sans ...string) error {
+// GeneratePki generates the pki for kwokctl, signed by the existing CA if the caCertPath is not empty
+func (c *Cluster) GeneratePki(pkiPath, caCertPath, caKeyPath string, sans ...string) error {
 	if c.IsDryRun() {
-		dryrun.PrintMessage("# Generate PKI to %s", pkiPath)
+		if caCertPath != "" {
+			dryrun.PrintMessage("# Generate PKI to %s with CA %s", pkiPath, caCertPath)
+		} else {
+			dryrun.PrintMessage("# Generate PKI to %s", pkiPath)
+		}
 		return nil
 	}
 
-	return pki.GeneratePki(pkiPath, sans...)
+	return pki.GeneratePkiWithCA(pkiPath, caCertPath, caKeyPath, sans...)
 }
 
 // CreateFile creates a file.
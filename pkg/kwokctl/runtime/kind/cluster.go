@@ -21,7 +21,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +43,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/sets"
@@ -57,6 +60,17 @@ type Cluster struct {
 	runtime string
 }
 
+const (
+	// kwokControllerShardPrefix is the prefix of the names of the kwok-controllers on the workers.
+	kwokControllerShardPrefix = consts.ComponentKwokController + "-shard-"
+
+	// The paths of the kubeconfig and the pki used by the kwok-controllers on the workers, which are in the workdir.
+	workerKubeconfigPath = "/etc/kwok/kubeconfig"
+	workerCaCertPath     = "/etc/kwok/pki/ca.crt"
+	workerAdminCertPath  = "/etc/kwok/pki/admin.crt"
+	workerAdminKeyPath   = "/etc/kwok/pki/admin.key"
+)
+
 // NewDockerCluster creates a new Runtime for kind with docker
 func NewDockerCluster(name, workdir string) (runtime.Runtime, error) {
 	return &Cluster{
@@ -121,11 +135,14 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		if len(conf.KubeApiserverCertSANs) != 0 {
 			sans = append(sans, conf.KubeApiserverCertSANs...)
 		}
+		if len(conf.DNSNames) != 0 {
+			sans = append(sans, conf.DNSNames...)
+		}
 		err = c.MkdirAll(pkiPath)
 		if err != nil {
 			return fmt.Errorf("failed to create pki dir: %w", err)
 		}
-		err = c.GeneratePki(pkiPath, sans...)
+		err = c.GeneratePki(pkiPath, conf.CaCert, conf.CaKey, sans...)
 		if err != nil {
 			return fmt.Errorf("failed to generate pki: %w", err)
 		}
@@ -303,16 +320,36 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addCoreDNS(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeStateMetrics(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addPrometheus(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.addJaeger(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.addOtelCollector(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.setupPrometheusConfig(ctx, env)
 	if err != nil {
 		return err
@@ -380,7 +417,7 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 	kubeApiserverTracingConfigPath := ""
 	if conf.JaegerPort != 0 {
 		kubeApiserverTracingConfigData, err := k8s.BuildKubeApiserverTracingConfig(k8s.BuildKubeApiserverTracingConfigParam{
-			Endpoint: conf.BindAddress + ":4317",
+			Endpoint: net.JoinHostPort(conf.BindAddress, 4317),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to generate kubeApiserverTracingConfig yaml: %w", err)
@@ -416,36 +453,27 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 	kubeSchedulerComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKubeScheduler)
 	kubeControllerManagerComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKubeControllerManager)
 	kwokControllerComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKwokController)
-	for _, patch := range env.kwokctlConfig.ComponentsPatches {
-		switch patch.Name {
-		case consts.ComponentEtcd:
-			args := filterDuplicatedExtraArgs(ctx, etcdComponentPatches.ExtraArgs, patch.ExtraArgs)
-			etcdComponentPatches.ExtraArgs = args
-		case consts.ComponentKubeApiserver:
-			args := filterDuplicatedExtraArgs(ctx, kubeApiserverComponentPatches.ExtraArgs, patch.ExtraArgs)
-			kubeApiserverComponentPatches.ExtraArgs = args
-		case consts.ComponentKubeScheduler:
-			args := filterDuplicatedExtraArgs(ctx, kubeSchedulerComponentPatches.ExtraArgs, patch.ExtraArgs)
-			kubeSchedulerComponentPatches.ExtraArgs = args
-		case consts.ComponentKubeControllerManager:
-			args := filterDuplicatedExtraArgs(ctx, kubeControllerManagerComponentPatches.ExtraArgs, patch.ExtraArgs)
-			kubeControllerManagerComponentPatches.ExtraArgs = args
-		case consts.ComponentKwokController:
-			args := filterDuplicatedExtraArgs(ctx, kwokControllerComponentPatches.ExtraArgs, patch.ExtraArgs)
-			kwokControllerComponentPatches.ExtraArgs = args
-		}
-	}
-	extraLogVolumes := runtime.GetLogVolumes(ctx)
+	etcdComponentPatches.ExtraArgs = filterDuplicatedExtraArgs(ctx, nil, etcdComponentPatches.ExtraArgs)
+	kubeApiserverComponentPatches.ExtraArgs = filterDuplicatedExtraArgs(ctx, nil, kubeApiserverComponentPatches.ExtraArgs)
+	kubeSchedulerComponentPatches.ExtraArgs = filterDuplicatedExtraArgs(ctx, nil, kubeSchedulerComponentPatches.ExtraArgs)
+	kubeControllerManagerComponentPatches.ExtraArgs = filterDuplicatedExtraArgs(ctx, nil, kubeControllerManagerComponentPatches.ExtraArgs)
+	kwokControllerComponentPatches.ExtraArgs = filterDuplicatedExtraArgs(ctx, nil, kwokControllerComponentPatches.ExtraArgs)
+	extraLogVolumes := runtime.GetLogVolumes(ctx, env.kwokctlConfig.Options.LogVolumes)
 	kwokControllerExtraVolumes := kwokControllerComponentPatches.ExtraVolumes
 	kwokControllerExtraVolumes = append(kwokControllerExtraVolumes, extraLogVolumes...)
 	if len(etcdComponentPatches.ExtraEnvs) > 0 ||
 		len(kubeApiserverComponentPatches.ExtraEnvs) > 0 ||
 		len(kubeSchedulerComponentPatches.ExtraEnvs) > 0 ||
-		len(kubeControllerManagerComponentPatches.ExtraEnvs) > 0 {
-		logger.Warn("extraEnvs config in etcd, kube-apiserver, kube-scheduler or kube-controller-manager is not supported in kind")
+		len(kubeControllerManagerComponentPatches.ExtraEnvs) > 0 ||
+		len(etcdComponentPatches.ExtraEnvFiles) > 0 ||
+		len(kubeApiserverComponentPatches.ExtraEnvFiles) > 0 ||
+		len(kubeSchedulerComponentPatches.ExtraEnvFiles) > 0 ||
+		len(kubeControllerManagerComponentPatches.ExtraEnvFiles) > 0 {
+		logger.Warn("extraEnvs, extraEnvFiles and envName of extraSecrets config in etcd, kube-apiserver, kube-scheduler or kube-controller-manager is not supported in kind")
 	}
 	kindYaml, err := BuildKind(BuildKindConfig{
 		BindAddress:                   conf.BindAddress,
+		IPFamily:                      conf.IPFamily,
 		KubeApiserverPort:             conf.KubeApiserverPort,
 		KubeApiserverInsecurePort:     conf.KubeApiserverInsecurePort,
 		EtcdPort:                      conf.EtcdPort,
@@ -473,6 +501,8 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 		PrometheusExtraVolumes:        prometheusPatches.ExtraVolumes,
 		DisableQPSLimits:              conf.DisableQPSLimits,
 		KubeVersion:                   kubeVersion,
+		Workers:                       conf.KindWorkers,
+		RealWorkers:                   conf.KindRealWorkers,
 	})
 	if err != nil {
 		return err
@@ -505,6 +535,18 @@ func filterDuplicatedExtraArgs(ctx context.Context, extraArgs, passedExtraArgs [
 }
 
 func (c *Cluster) addEtcd(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EtcdBackend != "" && conf.EtcdBackend != consts.EtcdBackendEtcd {
+		return fmt.Errorf("etcd backend %q is not supported by kind runtime", conf.EtcdBackend)
+	}
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("multiple members of etcd are not supported by %s runtime", conf.Runtime)
+	}
+	if len(conf.EtcdEndpoints) != 0 {
+		return fmt.Errorf("external etcd is not supported by %s runtime", conf.Runtime)
+	}
+
 	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, internalversion.Component{
 		Name: consts.ComponentEtcd,
 		Metric: &internalversion.ComponentMetric{
@@ -520,6 +562,19 @@ func (c *Cluster) addEtcd(_ context.Context, env *env) (err error) {
 }
 
 func (c *Cluster) addKubeApiserver(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.KubeAuditWebhook != "" || conf.EnableKubeAuditSink {
+		return fmt.Errorf("the audit webhook is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.EnableKonnectivity {
+		return fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+	if conf.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("multiple kube-apiservers are not supported by %s runtime", conf.Runtime)
+	}
+
 	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, internalversion.Component{
 		Name: consts.ComponentKubeApiserver,
 		Metric: &internalversion.ComponentMetric{
@@ -549,7 +604,7 @@ func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
 			ProjectName:    c.Name(),
 			Workdir:        env.workdir,
 			Image:          conf.KubectlImage,
-			BindAddress:    net.PublicAddress,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
 			Port:           conf.KubeApiserverInsecurePort,
 			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
 			CaCertPath:     env.caCertPath,
@@ -562,6 +617,10 @@ func (c *Cluster) addKubectlProxy(ctx context.Context, env *env) (err error) {
 		}
 
 		runtime.ApplyComponentPatches(&kubectlProxyComponent, env.kwokctlConfig.ComponentsPatches)
+		err = inlineEnvFiles(&kubectlProxyComponent)
+		if err != nil {
+			return err
+		}
 
 		dashboardPod, err := yaml.Marshal(components.ConvertToPod(kubectlProxyComponent))
 		if err != nil {
@@ -626,19 +685,25 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		return err
 	}
 
-	logVolumes := runtime.GetLogVolumes(ctx)
+	logVolumes := runtime.GetLogVolumes(ctx, env.kwokctlConfig.Options.LogVolumes)
 	logVolumes = slices.Map(logVolumes, func(v internalversion.Volume) internalversion.Volume {
 		v.HostPath = path.Join("/var/components/controller", v.HostPath)
 		return v
 	})
 
+	// The nodes with the shard label are managed by the kwok-controllers on the workers
+	manageNodesWithLabelSelector := ""
+	if conf.KindWorkers != 0 {
+		manageNodesWithLabelSelector = "!" + consts.ShardLabel
+	}
+
 	kwokControllerComponent := components.BuildKwokControllerComponent(components.BuildKwokControllerComponentConfig{
 		Runtime:                           conf.Runtime,
 		ProjectName:                       c.Name(),
 		Workdir:                           env.workdir,
 		Image:                             conf.KwokControllerImage,
 		Version:                           kwokControllerVersion,
-		BindAddress:                       net.PublicAddress,
+		BindAddress:                       components.PublicAddress(conf.IPFamily),
 		Port:                              conf.KwokControllerPort,
 		ConfigPath:                        env.kwokConfigPath,
 		KubeconfigPath:                    env.inClusterOnHostKubeconfigPath,
@@ -648,15 +713,100 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		NodeIP:                            "$(POD_IP)",
 		NodeName:                          "kwok-controller.kube-system.svc",
 		ManageNodesWithAnnotationSelector: "kwok.x-k8s.io/node=fake",
+		ManageNodesWithLabelSelector:      manageNodesWithLabelSelector,
 		Verbosity:                         env.verbosity,
 		NodeLeaseDurationSeconds:          40,
+		TimeAcceleration:                  conf.TimeAcceleration,
 		EnableCRDs:                        conf.EnableCRDs,
+		CIDR:                              components.PodCIDR(conf.IPFamily),
 	})
 	kwokControllerComponent.Volumes = append(kwokControllerComponent.Volumes, logVolumes...)
 
 	runtime.ApplyComponentPatches(&kwokControllerComponent, env.kwokctlConfig.ComponentsPatches)
+	err = inlineEnvFiles(&kwokControllerComponent)
+	if err != nil {
+		return err
+	}
+
+	err = c.writeKwokControllerPod(kwokControllerComponent, path.Join(c.GetWorkdirPath(runtime.ManifestsName), consts.ComponentKwokController+".yaml"))
+	if err != nil {
+		return err
+	}
+
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kwokControllerComponent)
+
+	if conf.KindWorkers == 0 {
+		return nil
+	}
+
+	// The workers have no admin.conf, so they connect to the control plane with the kubeconfig in the workdir
+	workerKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
+		ProjectName:  c.Name(),
+		SecurePort:   true,
+		Address:      "https://" + c.getClusterName() + ":6443",
+		CACrtPath:    workerCaCertPath,
+		AdminCrtPath: workerAdminCertPath,
+		AdminKeyPath: workerAdminKeyPath,
+	}))
+	if err != nil {
+		return err
+	}
+	err = c.WriteFile(c.GetWorkdirPath(runtime.InClusterKubeconfigName), workerKubeconfigData)
+	if err != nil {
+		return err
+	}
+
+	for i := uint(0); i < conf.KindWorkers; i++ {
+		shard := strconv.FormatUint(uint64(i), 10)
+		shardComponent := components.BuildKwokControllerComponent(components.BuildKwokControllerComponentConfig{
+			Runtime:                           conf.Runtime,
+			ProjectName:                       c.Name(),
+			Workdir:                           env.workdir,
+			Image:                             conf.KwokControllerImage,
+			Version:                           kwokControllerVersion,
+			BindAddress:                       components.PublicAddress(conf.IPFamily),
+			ConfigPath:                        env.kwokConfigPath,
+			KubeconfigPath:                    workerKubeconfigPath,
+			CaCertPath:                        workerCaCertPath,
+			AdminCertPath:                     workerAdminCertPath,
+			AdminKeyPath:                      workerAdminKeyPath,
+			NodeIP:                            "$(POD_IP)",
+			NodeName:                          kwokControllerShardPrefix + shard + ".kube-system.svc",
+			ManageNodesWithAnnotationSelector: "kwok.x-k8s.io/node=fake",
+			ManageNodesWithLabelSelector:      consts.ShardLabel + "=" + shard,
+			Verbosity:                         env.verbosity,
+			NodeLeaseDurationSeconds:          40,
+			TimeAcceleration:                  conf.TimeAcceleration,
+			EnableCRDs:                        conf.EnableCRDs,
+			CIDR:                              components.PodCIDR(conf.IPFamily),
+		})
+		shardComponent.Volumes = append(shardComponent.Volumes, logVolumes...)
+
+		// The patches of the kwok-controller are applied to the shards as well
+		runtime.ApplyComponentPatches(&shardComponent, env.kwokctlConfig.ComponentsPatches)
+		err = inlineEnvFiles(&shardComponent)
+		if err != nil {
+			return err
+		}
+		shardComponent.Name = kwokControllerShardPrefix + shard
 
-	pod := components.ConvertToPod(kwokControllerComponent)
+		manifestsPath := workerManifestsPath(c.Workdir(), i)
+		err = c.MkdirAll(manifestsPath)
+		if err != nil {
+			return err
+		}
+		err = c.writeKwokControllerPod(shardComponent, path.Join(manifestsPath, shardComponent.Name+".yaml"))
+		if err != nil {
+			return err
+		}
+
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, shardComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) writeKwokControllerPod(component internalversion.Component, manifestPath string) error {
+	pod := components.ConvertToPod(component)
 	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
 		Name: "POD_IP",
 		ValueFrom: &corev1.EnvVarSource{
@@ -669,12 +819,10 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to marshal kwok controller pod: %w", err)
 	}
-	err = c.WriteFile(path.Join(c.GetWorkdirPath(runtime.ManifestsName), consts.ComponentKwokController+".yaml"), kwokControllerPod)
+	err = c.WriteFile(manifestPath, kwokControllerPod)
 	if err != nil {
 		return fmt.Errorf("failed to write: %w", err)
 	}
-
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kwokControllerComponent)
 	return nil
 }
 
@@ -696,7 +844,7 @@ func (c *Cluster) addDashboard(ctx context.Context, env *env) (err error) {
 			Workdir:        env.workdir,
 			Image:          conf.DashboardImage,
 			Version:        dashboardVersion,
-			BindAddress:    net.PublicAddress,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
 			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
 			CaCertPath:     env.caCertPath,
 			AdminCertPath:  env.adminCertPath,
@@ -710,6 +858,10 @@ func (c *Cluster) addDashboard(ctx context.Context, env *env) (err error) {
 		}
 
 		runtime.ApplyComponentPatches(&dashboardComponent, env.kwokctlConfig.ComponentsPatches)
+		err = inlineEnvFiles(&dashboardComponent)
+		if err != nil {
+			return err
+		}
 
 		dashboardPod, err := yaml.Marshal(components.ConvertToPod(dashboardComponent))
 		if err != nil {
@@ -771,7 +923,7 @@ func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
 			Workdir:        env.workdir,
 			Image:          conf.MetricsServerImage,
 			Version:        metricsServerVersion,
-			BindAddress:    net.PublicAddress,
+			BindAddress:    components.PublicAddress(conf.IPFamily),
 			Port:           443,
 			CaCertPath:     env.caCertPath,
 			AdminCertPath:  env.adminCertPath,
@@ -797,6 +949,24 @@ func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addCoreDNS(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableCoreDNS {
+		return fmt.Errorf("coredns is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeStateMetrics(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeStateMetrics {
+		return fmt.Errorf("kube-state-metrics is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
 func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -835,7 +1005,7 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 			Workdir:       env.workdir,
 			Image:         conf.PrometheusImage,
 			Version:       prometheusVersion,
-			BindAddress:   net.PublicAddress,
+			BindAddress:   components.PublicAddress(conf.IPFamily),
 			Port:          9090,
 			ConfigPath:    "/var/components/prometheus/etc/prometheus/prometheus.yaml",
 			AdminCertPath: env.adminCertPath,
@@ -860,6 +1030,10 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 		)
 
 		runtime.ApplyComponentPatches(&prometheusComponent, env.kwokctlConfig.ComponentsPatches)
+		err = inlineEnvFiles(&prometheusComponent)
+		if err != nil {
+			return err
+		}
 
 		prometheusPod, err := yaml.Marshal(components.ConvertToPod(prometheusComponent))
 		if err != nil {
@@ -875,6 +1049,15 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addGrafana(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		return fmt.Errorf("grafana is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
 func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
@@ -893,7 +1076,7 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 			Workdir:     env.workdir,
 			Image:       conf.JaegerImage,
 			Version:     jaegerVersion,
-			BindAddress: net.PublicAddress,
+			BindAddress: components.PublicAddress(conf.IPFamily),
 			Port:        16686,
 			Verbosity:   env.verbosity,
 		})
@@ -902,6 +1085,10 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 		}
 
 		runtime.ApplyComponentPatches(&jaegerComponent, env.kwokctlConfig.ComponentsPatches)
+		err = inlineEnvFiles(&jaegerComponent)
+		if err != nil {
+			return err
+		}
 
 		jaegerPod, err := yaml.Marshal(components.ConvertToPod(jaegerComponent))
 		if err != nil {
@@ -917,7 +1104,41 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
 	return nil
 }
 
+func (c *Cluster) addOtelCollector(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableOtelCollector || conf.OtlpEndpoint != "" {
+		return fmt.Errorf("otel-collector is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+// inlineEnvFiles loads the env files of the component into its envs,
+// since the pods in the kind node cannot read the env files on the host.
+func inlineEnvFiles(component *internalversion.Component) error {
+	if len(component.EnvFiles) == 0 {
+		return nil
+	}
+	envs, err := components.LoadEnvFiles(component.EnvFiles)
+	if err != nil {
+		return err
+	}
+	component.Envs = append(envs, component.Envs...)
+	component.EnvFiles = nil
+	return nil
+}
+
 func (c *Cluster) preInstall(_ context.Context, env *env) error {
+	if len(env.kwokctlConfig.ExtraComponents) != 0 {
+		return fmt.Errorf("extra components are not supported by %s runtime", env.kwokctlConfig.Options.Runtime)
+	}
+
+	patches, err := runtime.ExpandComponentPatchesFiles(env.kwokctlConfig.ComponentsPatches, true)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.ComponentsPatches = patches
+
 	for i, patch := range env.kwokctlConfig.ComponentsPatches {
 		if len(patch.ExtraVolumes) == 0 {
 			continue
@@ -1028,8 +1249,13 @@ func (c *Cluster) Up(ctx context.Context) error {
 		return err
 	}
 
-	// Cordoning the node to prevent fake pods from being scheduled on it
-	err = c.Kubectl(ctx, "cordon", c.getClusterName())
+	// Cordoning the nodes to prevent fake pods from being scheduled on them,
+	// the real workers are left schedulable to run the pods.
+	nodeNames, err := c.getComponentNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.Kubectl(ctx, append([]string{"cordon"}, nodeNames...)...)
 	if err != nil {
 		logger.Error("Failed cordon node", err)
 	}
@@ -1171,6 +1397,36 @@ func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.Co
 	return runtime.ComponentStatusReady, nil
 }
 
+// InspectComponentRestarts returns the restarts of the component
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentRestarts{}, nil
+	}
+	return c.inspectComponentRestarts(ctx, name)
+}
+
+// InspectUsage returns the usage of the host resources by the cluster,
+// the components share the container of the node, so only the node is reported.
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	containers := map[string]string{
+		c.getClusterName(): "control-plane",
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint(0); i < config.Options.KindWorkers; i++ {
+		containers[c.getWorkerName(i)] = "worker-" + strconv.FormatUint(uint64(i), 10)
+	}
+	for i := uint(0); i < config.Options.KindRealWorkers; i++ {
+		containers[c.getWorkerName(config.Options.KindWorkers+i)] = "real-worker-" + strconv.FormatUint(uint64(i), 10)
+	}
+	return c.InspectContainersUsage(ctx, c.runtime, containers)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	ok, err := c.Cluster.Ready(ctx)
@@ -1212,9 +1468,39 @@ func (c *Cluster) Down(ctx context.Context) error {
 	return nil
 }
 
+// Detach saves the data of etcd into the workdir, and deletes the kind cluster.
+func (c *Cluster) Detach(ctx context.Context) error {
+	// The data of etcd is in the node container, so it needs to be running to save it
+	err := c.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.SnapshotSave(ctx, c.GetWorkdirPath(runtime.DetachedEtcdName))
+	if err != nil {
+		return fmt.Errorf("failed to save the data of etcd: %w", err)
+	}
+
+	return c.Down(ctx)
+}
+
+// Attach recreates the kind cluster, and restores the data of etcd from the workdir.
+func (c *Cluster) Attach(ctx context.Context) error {
+	err := c.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.RestoreDetached(ctx, c.SnapshotRestore)
+}
+
 // Start starts the cluster
 func (c *Cluster) Start(ctx context.Context) error {
-	err := c.Exec(ctx, c.runtime, "start", c.getClusterName())
+	nodeNames, err := c.getNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.Exec(ctx, c.runtime, append([]string{"start"}, nodeNames...)...)
 	if err != nil {
 		return err
 	}
@@ -1223,7 +1509,11 @@ func (c *Cluster) Start(ctx context.Context) error {
 
 // Stop stops the cluster
 func (c *Cluster) Stop(ctx context.Context) error {
-	err := c.Exec(ctx, c.runtime, "stop", c.getClusterName())
+	nodeNames, err := c.getNodeNames(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.Exec(ctx, c.runtime, append([]string{"stop"}, nodeNames...)...)
 	if err != nil {
 		return err
 	}
@@ -1255,7 +1545,7 @@ func (c *Cluster) StartComponent(ctx context.Context, name string) error {
 	}
 
 	logger.Debug("Starting component")
-	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(), "mv", "/etc/kubernetes/"+name+".yaml.bak", "/etc/kubernetes/manifests/"+name+".yaml")
+	err := c.Exec(ctx, c.runtime, "exec", c.getComponentNodeName(name), "mv", "/etc/kubernetes/"+name+".yaml.bak", "/etc/kubernetes/manifests/"+name+".yaml")
 	if err != nil {
 		return err
 	}
@@ -1284,7 +1574,7 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	}
 
 	logger.Debug("Stopping component")
-	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(), "mv", "/etc/kubernetes/manifests/"+name+".yaml", "/etc/kubernetes/"+name+".yaml.bak")
+	err := c.Exec(ctx, c.runtime, "exec", c.getComponentNodeName(name), "mv", "/etc/kubernetes/manifests/"+name+".yaml", "/etc/kubernetes/"+name+".yaml.bak")
 	if err != nil {
 		return err
 	}
@@ -1298,6 +1588,79 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	return c.waitComponentReady(ctx, name, false, 120*time.Second)
 }
 
+// UpgradeComponent replaces the image of the component in its static pod, the pod is recreated by the kubelet
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string, conf runtime.UpgradeComponentConfig) error {
+	err := runtime.CheckUpgradeComponent(name)
+	if err != nil {
+		return err
+	}
+	if conf.Image == "" {
+		return fmt.Errorf("image of %s is required to upgrade it", name)
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	manifestPath := path.Join(c.GetWorkdirPath(runtime.ManifestsName), name+".yaml")
+	if !c.IsDryRun() && !file.Exists(manifestPath) {
+		return fmt.Errorf("component %s is stopped, start it before upgrading", name)
+	}
+
+	err = c.EnsureImage(ctx, c.runtime, conf.Image)
+	if err != nil {
+		return err
+	}
+	ver, err := c.ParseVersionFromImage(ctx, c.runtime, conf.Image, "kwok")
+	if err != nil {
+		return err
+	}
+
+	kindPath, err := c.preDownloadKind(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.loadImages(ctx, kindPath, []string{conf.Image}, config.Options.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Replace the image of %s with %s", manifestPath, conf.Image)
+	} else {
+		raw, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return err
+		}
+		var pod corev1.Pod
+		err = yaml.Unmarshal(raw, &pod)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", manifestPath, err)
+		}
+		if len(pod.Spec.Containers) == 0 {
+			return fmt.Errorf("no container in %s", manifestPath)
+		}
+		pod.Spec.Containers[0].Image = conf.Image
+		raw, err = yaml.Marshal(pod)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", manifestPath, err)
+		}
+		err = c.WriteFile(manifestPath, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	component.Image = conf.Image
+	component.Version = ver.String()
+	return c.SaveUpgradedComponent(ctx, component, conf)
+}
+
 // waitComponentReady waits for a component to be ready
 func (c *Cluster) waitComponentReady(ctx context.Context, name string, wantReady bool, timeout time.Duration) error {
 	var (
@@ -1334,25 +1697,29 @@ func (c *Cluster) waitComponentReady(ctx context.Context, name string, wantReady
 	return nil
 }
 
-func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool, running bool, exist bool, err error) {
+func (c *Cluster) getComponentPod(ctx context.Context, name string) (*corev1.Pod, error) {
 	clientset, err := c.GetClientset(ctx)
 	if err != nil {
-		return false, false, false, err
+		return nil, err
 	}
 
 	restConfig, err := clientset.ToRESTConfig()
 	if err != nil {
-		return false, false, false, err
+		return nil, err
 	}
 
 	typedClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return false, false, false, err
+		return nil, err
 	}
 
-	pod, err := typedClient.CoreV1().
+	return typedClient.CoreV1().
 		Pods(metav1.NamespaceSystem).
 		Get(ctx, c.getComponentName(name), metav1.GetOptions{})
+}
+
+func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool, running bool, exist bool, err error) {
+	pod, err := c.getComponentPod(ctx, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, false, false, nil
@@ -1375,13 +1742,80 @@ func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool
 	return true, true, true, nil
 }
 
+func (c *Cluster) inspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	pod, err := c.getComponentPod(ctx, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return runtime.ComponentRestarts{}, nil
+		}
+		return runtime.ComponentRestarts{}, err
+	}
+
+	restarts := runtime.ComponentRestarts{}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		restarts.Count += int(containerStatus.RestartCount)
+		terminated := containerStatus.LastTerminationState.Terminated
+		if terminated == nil || !terminated.FinishedAt.After(restarts.LastExitTime) {
+			continue
+		}
+		restarts.LastExitCode = int(terminated.ExitCode)
+		restarts.LastExitReason = terminated.Reason
+		restarts.LastExitTime = terminated.FinishedAt.Time
+	}
+	return restarts, nil
+}
+
 func (c *Cluster) getClusterName() string {
 	return c.Name() + "-control-plane"
 }
 
+// getWorkerName returns the name of the worker node of kind with the index.
+func (c *Cluster) getWorkerName(index uint) string {
+	if index == 0 {
+		return c.Name() + "-worker"
+	}
+	return c.Name() + "-worker" + strconv.FormatUint(uint64(index+1), 10)
+}
+
+// getNodeNames returns the names of all the nodes of kind.
+func (c *Cluster) getNodeNames(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{c.getClusterName()}
+	for i := uint(0); i < config.Options.KindWorkers+config.Options.KindRealWorkers; i++ {
+		names = append(names, c.getWorkerName(i))
+	}
+	return names, nil
+}
+
+// getComponentNodeNames returns the names of the nodes of kind which run the components, without the real workers.
+func (c *Cluster) getComponentNodeNames(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{c.getClusterName()}
+	for i := uint(0); i < config.Options.KindWorkers; i++ {
+		names = append(names, c.getWorkerName(i))
+	}
+	return names, nil
+}
+
+// getComponentNodeName returns the name of the node of kind where the component runs.
+func (c *Cluster) getComponentNodeName(name string) string {
+	if shard, ok := strings.CutPrefix(name, kwokControllerShardPrefix); ok {
+		index, err := strconv.ParseUint(shard, 10, 0)
+		if err == nil {
+			return c.getWorkerName(uint(index))
+		}
+	}
+	return c.getClusterName()
+}
+
 func (c *Cluster) getComponentName(name string) string {
-	clusterName := c.getClusterName()
-	return name + "-" + clusterName
+	return name + "-" + c.getComponentNodeName(name)
 }
 
 func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
@@ -74,6 +74,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		}
 	}
 	defer func() {
+		ctx := context.WithoutCancel(ctx)
 		for _, component := range components {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
@@ -140,6 +141,7 @@ func (c *Cluster) SnapshotRestoreWithYAML(ctx context.Context, path string, conf
 		}
 	}
 	defer func() {
+		ctx := context.WithoutCancel(ctx)
 		for _, component := range components {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
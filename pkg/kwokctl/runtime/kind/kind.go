@@ -26,11 +26,13 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	kindv1alpha4 "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kind/config/kind/v1alpha4"
 	kubeadmv1beta3 "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kind/config/kubeadm/v1beta3"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
@@ -292,8 +294,19 @@ type BuildKindConfig struct {
 	PrometheusExtraVolumes        []internalversion.Volume
 
 	BindAddress      string
+	IPFamily         string
 	DisableQPSLimits bool
 	KubeVersion      version.Version
+
+	// Workers is the number of the worker nodes, each of which runs a kwok-controller of a shard.
+	Workers uint
+	// RealWorkers is the number of the real worker nodes after the workers, which run the pods with their kubelets.
+	RealWorkers uint
+}
+
+// workerManifestsPath returns the path of the directory of the static pods of the worker on the host.
+func workerManifestsPath(workdir string, index uint) string {
+	return path.Join(workdir, "workers", strconv.FormatUint(uint64(index), 10), "manifests")
 }
 
 func buildKindConfigV1alpha4(conf BuildKindConfig) (*kindv1alpha4.Cluster, error) {
@@ -363,51 +376,27 @@ func buildKindConfigV1alpha4(conf BuildKindConfig) (*kindv1alpha4.Cluster, error
 	}
 
 	for _, vol := range conf.EtcdExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/etcd%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/etcd"))
 	}
 
 	for _, vol := range conf.ApiserverExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/apiserver%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/apiserver"))
 	}
 
 	for _, vol := range conf.ControllerManagerExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/controller-manager%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/controller-manager"))
 	}
 
 	for _, vol := range conf.SchedulerExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/scheduler%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/scheduler"))
 	}
 
 	for _, vol := range conf.KwokControllerExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/controller%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/controller"))
 	}
 
 	for _, vol := range conf.PrometheusExtraVolumes {
-		extraMounts = append(extraMounts, kindv1alpha4.Mount{
-			HostPath:      vol.HostPath,
-			ContainerPath: fmt.Sprintf("/var/components/prometheus%s", vol.MountPath),
-			Readonly:      vol.ReadOnly,
-		})
+		extraMounts = append(extraMounts, kindMount(vol, "/var/components/prometheus"))
 	}
 
 	featureGates := map[string]bool{}
@@ -451,6 +440,41 @@ func buildKindConfigV1alpha4(conf BuildKindConfig) (*kindv1alpha4.Cluster, error
 		},
 	}
 
+	// The IP family of kind defaults to ipv4, it is set only for the others to keep the config as before
+	if conf.IPFamily == consts.IPFamilyIPv6 || conf.IPFamily == consts.IPFamilyDual {
+		c.Networking.IPFamily = kindv1alpha4.ClusterIPFamily(conf.IPFamily)
+	}
+
+	// The pki is mounted into the workdir instead of /etc/kubernetes/pki, which is written by kubeadm on joining
+	for i := uint(0); i < conf.Workers; i++ {
+		workerExtraMounts := []kindv1alpha4.Mount{
+			{
+				HostPath:      conf.Workdir,
+				ContainerPath: "/etc/kwok/",
+			},
+			{
+				HostPath:      workerManifestsPath(conf.Workdir, i),
+				ContainerPath: "/etc/kubernetes/manifests",
+			},
+		}
+		for _, vol := range conf.KwokControllerExtraVolumes {
+			workerExtraMounts = append(workerExtraMounts, kindMount(vol, "/var/components/controller"))
+		}
+		c.Nodes = append(c.Nodes, kindv1alpha4.Node{
+			Role:        kindv1alpha4.WorkerRole,
+			ExtraMounts: workerExtraMounts,
+		})
+	}
+
+	for i := uint(0); i < conf.RealWorkers; i++ {
+		c.Nodes = append(c.Nodes, kindv1alpha4.Node{
+			Role: kindv1alpha4.WorkerRole,
+			Labels: map[string]string{
+				consts.NodeTypeLabel: "real",
+			},
+		})
+	}
+
 	return &c, nil
 }
 
@@ -540,3 +564,14 @@ func buildKubeadmConfigV1beta3(conf BuildKindConfig) (*kubeadmv1beta3.ClusterCon
 
 	return &c, nil
 }
+
+// kindMount returns the mount of the node for the volume of a component,
+// the volume is mounted into the node under the prefix and then mounted into the component.
+func kindMount(vol internalversion.Volume, prefix string) kindv1alpha4.Mount {
+	return kindv1alpha4.Mount{
+		HostPath:      components.VolumeHostPath(vol),
+		ContainerPath: prefix + vol.MountPath,
+		Readonly:      vol.ReadOnly,
+		Propagation:   kindv1alpha4.MountPropagation(vol.MountPropagation),
+	}
+}
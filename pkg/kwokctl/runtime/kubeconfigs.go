@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// RecordKubeconfig records the path of a kubeconfig that the context of the cluster is added to,
+// so it can be cleaned up when the cluster is deleted.
+func RecordKubeconfig(workdir string, kubeconfigPath string) error {
+	recorded, err := RecordedKubeconfigs(workdir)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(recorded, kubeconfigPath) {
+		return nil
+	}
+	recordPath := path.Join(workdir, KubeconfigsName)
+	if !file.Exists(recordPath) {
+		return file.Write(recordPath, []byte(kubeconfigPath+"\n"))
+	}
+	return file.Append(recordPath, []byte(kubeconfigPath+"\n"))
+}
+
+// RecordedKubeconfigs returns the paths of the kubeconfigs recorded by RecordKubeconfig.
+func RecordedKubeconfigs(workdir string) ([]string, error) {
+	data, err := file.Read(path.Join(workdir, KubeconfigsName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, nil
+}
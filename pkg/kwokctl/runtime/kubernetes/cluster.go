@@ -0,0 +1,1538 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/sets"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const (
+	// hostKubeconfigName is the name of the kubeconfig of the cluster the components are deployed into.
+	hostKubeconfigName = "host-kubeconfig.yaml"
+	// manifestsName is the name of the manifests of the components.
+	manifestsName = "kubernetes.yaml"
+	// portForwardsDirName is the name of the directory of the port-forward processes.
+	portForwardsDirName = "port-forwards"
+)
+
+// Cluster is an implementation of Runtime for an existing Kubernetes cluster
+type Cluster struct {
+	*runtime.Cluster
+}
+
+// NewCluster creates a new Runtime for an existing Kubernetes cluster
+func NewCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster: runtime.NewCluster(name, workdir),
+	}, nil
+}
+
+// Available  checks whether the runtime is available.
+func (c *Cluster) Available(ctx context.Context) error {
+	if c.IsDryRun() {
+		return nil
+	}
+	return c.Kubectl(ctx, "version")
+}
+
+// hostKubectl runs kubectl in the namespace of the cluster in the cluster the components are deployed into.
+func (c *Cluster) hostKubectl(ctx context.Context, args ...string) error {
+	return c.Kubectl(ctx, append([]string{"--kubeconfig", c.GetWorkdirPath(hostKubeconfigName), "--namespace", c.Name()}, args...)...)
+}
+
+// saveHostKubeconfig saves the current context of kubectl,
+// so that the components are still managed in the same cluster after the current context is changed.
+func (c *Cluster) saveHostKubeconfig(ctx context.Context) error {
+	kubeconfigBuf := bytes.NewBuffer(nil)
+	err := c.Kubectl(exec.WithWriteTo(ctx, kubeconfigBuf), "config", "view", "--minify=true", "--raw=true")
+	if err != nil {
+		return err
+	}
+
+	return c.WriteFile(c.GetWorkdirPath(hostKubeconfigName), kubeconfigBuf.Bytes())
+}
+
+func (c *Cluster) setup(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+	if !file.Exists(env.pkiPath) {
+		apiserverName := c.Name() + "-kube-apiserver"
+		sans := []string{
+			apiserverName,
+			apiserverName + "." + c.Name(),
+			apiserverName + "." + c.Name() + ".svc",
+			apiserverName + "." + c.Name() + ".svc.cluster.local",
+			c.Name() + "-kwok-controller",
+		}
+		ips, err := net.GetAllIPs()
+		if err != nil {
+			logger := log.FromContext(ctx)
+			logger.Warn("failed to get all ips", "err", err)
+		} else {
+			sans = append(sans, ips...)
+		}
+		if len(conf.KubeApiserverCertSANs) != 0 {
+			sans = append(sans, conf.KubeApiserverCertSANs...)
+		}
+		if len(conf.DNSNames) != 0 {
+			sans = append(sans, conf.DNSNames...)
+		}
+		err = c.MkdirAll(env.pkiPath)
+		if err != nil {
+			return fmt.Errorf("failed to create pki dir: %w", err)
+		}
+		err = c.GeneratePki(env.pkiPath, conf.CaCert, conf.CaKey, sans...)
+		if err != nil {
+			return fmt.Errorf("failed to generate pki: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cluster) setupPorts(ctx context.Context, used sets.Sets[uint32], ports ...*uint32) error {
+	for _, port := range ports {
+		if port != nil && *port == 0 {
+			p, err := net.GetUnusedPort(ctx, used)
+			if err != nil {
+				return err
+			}
+			*port = p
+		}
+	}
+	return nil
+}
+
+type env struct {
+	kwokctlConfig                 *internalversion.KwokctlConfiguration
+	verbosity                     log.Level
+	inClusterOnHostKubeconfigPath string
+	inClusterKubeconfig           string
+	kubeconfigPath                string
+	kwokConfigPath                string
+	pkiPath                       string
+	workdir                       string
+	caCertPath                    string
+	caKeyPath                     string
+	adminKeyPath                  string
+	adminCertPath                 string
+	inClusterCaCertPath           string
+	inClusterAdminKeyPath         string
+	inClusterAdminCertPath        string
+	inClusterPort                 uint32
+	scheme                        string
+	usedPorts                     sets.Sets[uint32]
+}
+
+func (c *Cluster) env(ctx context.Context) (*env, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inClusterOnHostKubeconfigPath := c.GetWorkdirPath(runtime.InClusterKubeconfigName)
+	inClusterKubeconfig := "/root/.kube/config"
+	kubeconfigPath := c.GetWorkdirPath(runtime.InHostKubeconfigName)
+	kwokConfigPath := c.GetWorkdirPath(runtime.ConfigName)
+	pkiPath := c.GetWorkdirPath(runtime.PkiName)
+
+	workdir := c.Workdir()
+	caCertPath := path.Join(pkiPath, "ca.crt")
+	caKeyPath := path.Join(pkiPath, "ca.key")
+	adminKeyPath := path.Join(pkiPath, "admin.key")
+	adminCertPath := path.Join(pkiPath, "admin.crt")
+	inClusterPkiPath := "/etc/kubernetes/pki/"
+	inClusterCaCertPath := path.Join(inClusterPkiPath, "ca.crt")
+	inClusterAdminKeyPath := path.Join(inClusterPkiPath, "admin.key")
+	inClusterAdminCertPath := path.Join(inClusterPkiPath, "admin.crt")
+
+	inClusterPort := uint32(8080)
+	scheme := "http"
+	if config.Options.SecurePort {
+		scheme = "https"
+		inClusterPort = 6443
+	}
+
+	logger := log.FromContext(ctx)
+	verbosity := logger.Level()
+
+	usedPorts := runtime.GetUsedPorts(ctx)
+
+	return &env{
+		kwokctlConfig:                 config,
+		verbosity:                     verbosity,
+		inClusterOnHostKubeconfigPath: inClusterOnHostKubeconfigPath,
+		inClusterKubeconfig:           inClusterKubeconfig,
+		kubeconfigPath:                kubeconfigPath,
+		kwokConfigPath:                kwokConfigPath,
+		pkiPath:                       pkiPath,
+		workdir:                       workdir,
+		caCertPath:                    caCertPath,
+		caKeyPath:                     caKeyPath,
+		adminKeyPath:                  adminKeyPath,
+		adminCertPath:                 adminCertPath,
+		inClusterCaCertPath:           inClusterCaCertPath,
+		inClusterAdminKeyPath:         inClusterAdminKeyPath,
+		inClusterAdminCertPath:        inClusterAdminCertPath,
+		inClusterPort:                 inClusterPort,
+		scheme:                        scheme,
+		usedPorts:                     usedPorts,
+	}, nil
+}
+
+// Install installs the cluster
+func (c *Cluster) Install(ctx context.Context) error {
+	err := c.Cluster.Install(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.saveHostKubeconfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	env, err := c.env(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.preInstall(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setup(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setupPorts(ctx,
+		env.usedPorts,
+		&env.kwokctlConfig.Options.KubeApiserverPort,
+		&env.kwokctlConfig.Options.EtcdPort,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = c.addEtcd(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeApiserver(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeControllerManager(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeScheduler(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKwokController(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addMetricsServer(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addCoreDNS(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addKubeStateMetrics(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addPrometheus(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addJaeger(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addOtelCollector(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.addDashboard(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.setupPrometheusConfig(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.finishInstall(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("multiple members of etcd are not supported by %s runtime", conf.Runtime)
+	}
+	if len(conf.EtcdEndpoints) != 0 {
+		return fmt.Errorf("external etcd is not supported by %s runtime", conf.Runtime)
+	}
+
+	if components.IsKineBackend(conf.EtcdBackend) {
+		return c.addKine(ctx, env)
+	}
+
+	// Configure the etcd
+	etcdVersion := c.parseVersionFromImage(ctx, conf.EtcdImage)
+
+	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Image:       conf.EtcdImage,
+		Version:     etcdVersion,
+		BindAddress: net.PublicAddress,
+		Port:        conf.EtcdPort,
+		Verbosity:   env.verbosity,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+	return nil
+}
+
+func (c *Cluster) addKine(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kine as the etcd
+
+	kineComponent, err := components.BuildKineComponent(components.BuildKineComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Image:       conf.KineImage,
+		Version:     conf.KineVersion,
+		Backend:     conf.EtcdBackend,
+		Endpoint:    conf.KineEndpoint,
+		BindAddress: net.PublicAddress,
+		Port:        conf.EtcdPort,
+		Verbosity:   env.verbosity,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kineComponent)
+	return nil
+}
+
+func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.KubeAuditWebhook != "" || conf.EnableKubeAuditSink {
+		return fmt.Errorf("the audit webhook is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.EnableKonnectivity {
+		return fmt.Errorf("konnectivity is not supported by %s runtime", conf.Runtime)
+	}
+
+	if conf.IPFamily != consts.IPFamilyIPv4 {
+		return fmt.Errorf("ip family %s is not supported by %s runtime", conf.IPFamily, conf.Runtime)
+	}
+
+	if conf.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("multiple kube-apiservers are not supported by %s runtime", conf.Runtime)
+	}
+
+	// Configure the kube-apiserver
+	kubeApiserverVersion := c.parseVersionFromImage(ctx, conf.KubeApiserverImage)
+
+	kubeApiserverTracingConfigPath := ""
+	if conf.JaegerPort != 0 {
+		kubeApiserverTracingConfigData, err := k8s.BuildKubeApiserverTracingConfig(k8s.BuildKubeApiserverTracingConfigParam{
+			Endpoint: c.Name() + "-jaeger:4317",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate kubeApiserverTracingConfig yaml: %w", err)
+		}
+		kubeApiserverTracingConfigPath = c.GetWorkdirPath(runtime.ApiserverTracingConfig)
+
+		err = c.WriteFile(kubeApiserverTracingConfigPath, []byte(kubeApiserverTracingConfigData))
+		if err != nil {
+			return fmt.Errorf("failed to write kubeApiserverTracingConfig yaml: %w", err)
+		}
+	}
+
+	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+		Runtime:           conf.Runtime,
+		ProjectName:       c.Name(),
+		Workdir:           env.workdir,
+		Image:             conf.KubeApiserverImage,
+		Version:           kubeApiserverVersion,
+		BindAddress:       net.PublicAddress,
+		Port:              conf.KubeApiserverPort,
+		KubeRuntimeConfig: conf.KubeRuntimeConfig,
+		KubeFeatureGates:  conf.KubeFeatureGates,
+		SecurePort:        conf.SecurePort,
+		KubeAuthorization: conf.KubeAuthorization,
+		KubeAdmission:     conf.KubeAdmission,
+		CaCertPath:        env.caCertPath,
+		AdminCertPath:     env.adminCertPath,
+		AdminKeyPath:      env.adminKeyPath,
+		EtcdPort:          conf.EtcdPort,
+		EtcdAddress:       c.Name() + "-etcd",
+		Verbosity:         env.verbosity,
+		DisableQPSLimits:  conf.DisableQPSLimits,
+		TracingConfigPath: kubeApiserverTracingConfigPath,
+		EtcdPrefix:        conf.EtcdPrefix,
+	})
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	return nil
+}
+
+func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kube-controller-manager
+	if !conf.DisableKubeControllerManager {
+		kubeControllerManagerVersion := c.parseVersionFromImage(ctx, conf.KubeControllerManagerImage)
+
+		kubeControllerManagerComponent, err := components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
+			Runtime:                            conf.Runtime,
+			ProjectName:                        c.Name(),
+			Workdir:                            env.workdir,
+			Image:                              conf.KubeControllerManagerImage,
+			Version:                            kubeControllerManagerVersion,
+			BindAddress:                        net.PublicAddress,
+			Port:                               conf.KubeControllerManagerPort,
+			SecurePort:                         conf.SecurePort,
+			CaCertPath:                         env.caCertPath,
+			AdminCertPath:                      env.adminCertPath,
+			AdminKeyPath:                       env.adminKeyPath,
+			KubeAuthorization:                  conf.KubeAuthorization,
+			KubeconfigPath:                     env.inClusterOnHostKubeconfigPath,
+			KubeFeatureGates:                   conf.KubeFeatureGates,
+			Verbosity:                          env.verbosity,
+			DisableQPSLimits:                   conf.DisableQPSLimits,
+			NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
+			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeControllerManagerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kube-scheduler
+	if !conf.DisableKubeScheduler {
+		schedulerConfigPath := ""
+		if conf.KubeSchedulerConfig != "" {
+			schedulerConfigPath = c.GetWorkdirPath(runtime.SchedulerConfigName)
+			err = c.CopySchedulerConfig(conf.KubeSchedulerConfig, schedulerConfigPath, env.inClusterKubeconfig)
+			if err != nil {
+				return err
+			}
+		}
+
+		kubeSchedulerVersion := c.parseVersionFromImage(ctx, conf.KubeSchedulerImage)
+
+		kubeSchedulerComponent, err := components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Image:            conf.KubeSchedulerImage,
+			Version:          kubeSchedulerVersion,
+			BindAddress:      net.PublicAddress,
+			Port:             conf.KubeSchedulerPort,
+			SecurePort:       conf.SecurePort,
+			CaCertPath:       env.caCertPath,
+			AdminCertPath:    env.adminCertPath,
+			AdminKeyPath:     env.adminKeyPath,
+			ConfigPath:       schedulerConfigPath,
+			KubeconfigPath:   env.inClusterOnHostKubeconfigPath,
+			KubeFeatureGates: conf.KubeFeatureGates,
+			Verbosity:        env.verbosity,
+			DisableQPSLimits: conf.DisableQPSLimits,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeSchedulerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the kwok-controller
+	kwokControllerVersion := c.parseVersionFromImage(ctx, conf.KwokControllerImage)
+
+	logVolumes := runtime.GetLogVolumes(ctx, env.kwokctlConfig.Options.LogVolumes)
+
+	kwokControllerComponent := components.BuildKwokControllerComponent(components.BuildKwokControllerComponentConfig{
+		Runtime:                  conf.Runtime,
+		ProjectName:              c.Name(),
+		Workdir:                  env.workdir,
+		Image:                    conf.KwokControllerImage,
+		Version:                  kwokControllerVersion,
+		BindAddress:              net.PublicAddress,
+		Port:                     conf.KwokControllerPort,
+		ConfigPath:               env.kwokConfigPath,
+		KubeconfigPath:           env.inClusterOnHostKubeconfigPath,
+		CaCertPath:               env.caCertPath,
+		CaKeyPath:                env.caKeyPath,
+		EnableCSRSigner:          conf.EnableCSRSigner,
+		AdminCertPath:            env.adminCertPath,
+		AdminKeyPath:             env.adminKeyPath,
+		NodeIP:                   "$(POD_IP)",
+		NodeName:                 c.Name() + "-kwok-controller",
+		Verbosity:                env.verbosity,
+		NodeLeaseDurationSeconds: conf.NodeLeaseDurationSeconds,
+		TimeAcceleration:         conf.TimeAcceleration,
+		EnableCRDs:               conf.EnableCRDs,
+	})
+	kwokControllerComponent.Volumes = append(kwokControllerComponent.Volumes, logVolumes...)
+
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kwokControllerComponent)
+	return nil
+}
+
+func (c *Cluster) addMetricsServer(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableMetricsServer {
+		metricsServerVersion := c.parseVersionFromImage(ctx, conf.MetricsServerImage)
+
+		metricsServerComponent, err := components.BuildMetricsServerComponent(components.BuildMetricsServerComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.MetricsServerImage,
+			Version:        metricsServerVersion,
+			BindAddress:    net.PublicAddress,
+			Port:           conf.MetricsServerPort,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+			Verbosity:      env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, metricsServerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addCoreDNS(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableCoreDNS {
+		return fmt.Errorf("coredns is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) addKubeStateMetrics(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableKubeStateMetrics {
+		return fmt.Errorf("kube-state-metrics is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) setupPrometheusConfig(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the prometheus
+	if conf.PrometheusPort != 0 {
+		prometheusData, err := components.BuildPrometheus(components.BuildPrometheusConfig{
+			Components: env.kwokctlConfig.Components,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate prometheus yaml: %w", err)
+		}
+		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
+
+		err = c.WriteFile(prometheusConfigPath, []byte(prometheusData))
+		if err != nil {
+			return fmt.Errorf("failed to write prometheus yaml: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the prometheus
+	if conf.PrometheusPort != 0 {
+		prometheusVersion := c.parseVersionFromImage(ctx, conf.PrometheusImage)
+
+		prometheusConfigPath := c.GetWorkdirPath(runtime.Prometheus)
+
+		prometheusComponent, err := components.BuildPrometheusComponent(components.BuildPrometheusComponentConfig{
+			Runtime:       conf.Runtime,
+			Workdir:       env.workdir,
+			Image:         conf.PrometheusImage,
+			Version:       prometheusVersion,
+			BindAddress:   net.PublicAddress,
+			Port:          conf.PrometheusPort,
+			ConfigPath:    prometheusConfigPath,
+			AdminCertPath: env.adminCertPath,
+			AdminKeyPath:  env.adminKeyPath,
+			Verbosity:     env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, prometheusComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addGrafana(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		return fmt.Errorf("grafana is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) addDashboard(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.DashboardPort != 0 {
+		dashboardVersion := c.parseVersionFromImage(ctx, conf.DashboardImage)
+
+		dashboardComponent, err := components.BuildDashboardComponent(components.BuildDashboardComponentConfig{
+			Runtime:        conf.Runtime,
+			ProjectName:    c.Name(),
+			Workdir:        env.workdir,
+			Image:          conf.DashboardImage,
+			Version:        dashboardVersion,
+			BindAddress:    net.PublicAddress,
+			KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+			CaCertPath:     env.caCertPath,
+			AdminCertPath:  env.adminCertPath,
+			AdminKeyPath:   env.adminKeyPath,
+			Port:           conf.DashboardPort,
+			Banner:         fmt.Sprintf("Welcome to %s", c.Name()),
+			EnableMetrics:  conf.EnableMetricsServer,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, dashboardComponent)
+
+		if conf.EnableMetricsServer {
+			dashboardMetricsScraperComponent, err := components.BuildDashboardMetricsScraperComponent(components.BuildDashboardMetricsScraperComponentConfig{
+				Runtime:        conf.Runtime,
+				Workdir:        env.workdir,
+				Image:          conf.DashboardMetricsScraperImage,
+				KubeconfigPath: env.inClusterOnHostKubeconfigPath,
+				CaCertPath:     env.caCertPath,
+				AdminCertPath:  env.adminCertPath,
+				AdminKeyPath:   env.adminKeyPath,
+			})
+			if err != nil {
+				return err
+			}
+			env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, dashboardMetricsScraperComponent)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) addJaeger(ctx context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the jaeger
+	if conf.JaegerPort != 0 {
+		jaegerVersion := c.parseVersionFromImage(ctx, conf.JaegerImage)
+
+		jaegerComponent, err := components.BuildJaegerComponent(components.BuildJaegerComponentConfig{
+			Runtime:     conf.Runtime,
+			Workdir:     env.workdir,
+			Image:       conf.JaegerImage,
+			Version:     jaegerVersion,
+			BindAddress: net.PublicAddress,
+			Port:        conf.JaegerPort,
+			Verbosity:   env.verbosity,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, jaegerComponent)
+	}
+	return nil
+}
+
+func (c *Cluster) addOtelCollector(_ context.Context, env *env) (err error) {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.EnableOtelCollector || conf.OtlpEndpoint != "" {
+		return fmt.Errorf("otel-collector is not supported by %s runtime", conf.Runtime)
+	}
+	return nil
+}
+
+func (c *Cluster) preInstall(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+	logger := log.FromContext(ctx)
+
+	if len(env.kwokctlConfig.ExtraComponents) != 0 {
+		return fmt.Errorf("extra components are not supported by %s runtime", conf.Runtime)
+	}
+
+	// The pods can't write back to the workdir, and the kubectl proxy is not needed as the port-forward does the same
+	if conf.KubeAuditPolicy != "" {
+		logger.Warn("The audit policy is not supported by the kubernetes runtime, ignore it",
+			"auditPolicy", conf.KubeAuditPolicy,
+		)
+		conf.KubeAuditPolicy = ""
+	}
+	if conf.KubeApiserverInsecurePort != 0 {
+		logger.Warn("The insecure port of kube-apiserver is not supported by the kubernetes runtime, ignore it",
+			"port", conf.KubeApiserverInsecurePort,
+		)
+		conf.KubeApiserverInsecurePort = 0
+	}
+
+	patches, err := runtime.ExpandComponentPatchesFiles(env.kwokctlConfig.ComponentsPatches, true)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.ComponentsPatches = patches
+
+	for i, patch := range env.kwokctlConfig.ComponentsPatches {
+		if len(patch.ExtraVolumes) == 0 {
+			continue
+		}
+		volumes, err := runtime.ExpandVolumesHostPaths(patch.ExtraVolumes)
+		if err != nil {
+			return fmt.Errorf("failed to expand host volumes for %q component: %w", patch.Name, err)
+		}
+		env.kwokctlConfig.ComponentsPatches[i].ExtraVolumes = volumes
+	}
+	return nil
+}
+
+func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	for i := range env.kwokctlConfig.Components {
+		runtime.ApplyComponentPatches(&env.kwokctlConfig.Components[i], env.kwokctlConfig.ComponentsPatches)
+	}
+
+	// Setup kubeconfig
+	kubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
+		CACrtPath:     env.caCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.adminCertPath,
+		AdminKeyPath:  env.adminKeyPath,
+	}))
+	if err != nil {
+		return err
+	}
+
+	inClusterKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
+		ProjectName:   c.Name(),
+		SecurePort:    conf.SecurePort,
+		Address:       env.scheme + "://" + c.Name() + "-kube-apiserver:" + format.String(env.inClusterPort),
+		CACrtPath:     env.inClusterCaCertPath,
+		TLSServerName: runtime.TLSServerName(conf),
+		AdminCrtPath:  env.inClusterAdminCertPath,
+		AdminKeyPath:  env.inClusterAdminKeyPath,
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Save config
+	err = c.WriteFile(env.kubeconfigPath, kubeconfigData)
+	if err != nil {
+		return err
+	}
+
+	err = c.WriteFile(env.inClusterOnHostKubeconfigPath, inClusterKubeconfigData)
+	if err != nil {
+		return err
+	}
+
+	err = c.SetConfig(ctx, env.kwokctlConfig)
+	if err != nil {
+		return err
+	}
+	err = c.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseVersionFromImage returns the version from the tag of the image,
+// as the image is pulled by the nodes of the cluster and not on the host,
+// the unknown version is used if the tag is not a version.
+func (c *Cluster) parseVersionFromImage(ctx context.Context, image string) version.Version {
+	if c.IsDryRun() {
+		return version.Unknown
+	}
+
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	ver, err := version.ParseVersion(tag)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Debug("Failed to parse version from image tag, use unknown version",
+			"image", image,
+			"err", err,
+		)
+		return version.Unknown
+	}
+	return ver
+}
+
+// Uninstall uninstalls the cluster.
+func (c *Cluster) Uninstall(ctx context.Context) error {
+	err := c.uninstall(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Cluster.Uninstall(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// uninstall deletes the namespace of the cluster with all the components in it.
+func (c *Cluster) uninstall(ctx context.Context) error {
+	err := c.stopPortForwards(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsDryRun() && !file.Exists(c.GetWorkdirPath(hostKubeconfigName)) {
+		return nil
+	}
+
+	err = c.hostKubectl(ctx, "delete", "namespace", c.Name(), "--ignore-not-found")
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", c.Name(), err)
+	}
+	return nil
+}
+
+// Up starts the cluster.
+func (c *Cluster) Up(ctx context.Context) error {
+	err := c.hostKubectl(ctx, "create", "namespace", c.Name())
+	if err != nil {
+		return fmt.Errorf("failed to create namespace %s: %w", c.Name(), err)
+	}
+
+	return c.start(ctx)
+}
+
+// Down stops the cluster and deletes the namespace of the cluster
+func (c *Cluster) Down(ctx context.Context) error {
+	return c.uninstall(ctx)
+}
+
+// Start starts the cluster
+func (c *Cluster) Start(ctx context.Context) error {
+	return c.start(ctx)
+}
+
+// Stop stops the cluster
+func (c *Cluster) Stop(ctx context.Context) error {
+	err := c.stopPortForwards(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.hostKubectl(ctx, "scale", "deployment", "--selector", instanceLabel+"="+c.Name(), "--replicas=0")
+}
+
+func (c *Cluster) start(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The files are read only once by the pods, so the secret is recreated with the current files of the workdir
+	secretName := filesSecretName(c.Name())
+	err = c.hostKubectl(ctx, "delete", "secret", secretName, "--ignore-not-found")
+	if err != nil {
+		return err
+	}
+	files := workdirFiles(c.Workdir(), config.Components)
+	err = c.hostKubectl(ctx, append([]string{"create", "secret", "generic", secretName}, fromFileArgs(files)...)...)
+	if err != nil {
+		return fmt.Errorf("failed to create secret %s: %w", secretName, err)
+	}
+
+	manifests, err := buildManifests(c.Name(), c.Workdir(), config.Components)
+	if err != nil {
+		return err
+	}
+	manifestsPath := c.GetWorkdirPath(manifestsName)
+	err = c.WriteFile(manifestsPath, manifests)
+	if err != nil {
+		return err
+	}
+	err = c.hostKubectl(ctx, "apply", "--filename", manifestsPath)
+	if err != nil {
+		return fmt.Errorf("failed to apply %s: %w", manifestsPath, err)
+	}
+
+	for _, name := range []string{consts.ComponentEtcd, consts.ComponentKubeApiserver} {
+		err = c.hostKubectl(ctx, "rollout", "status", "deployment/"+resourceName(c.Name(), name), "--timeout=2m")
+		if err != nil {
+			return fmt.Errorf("failed to wait for %s: %w", name, err)
+		}
+	}
+
+	for _, component := range config.Components {
+		if runtime.IsLazyComponent(component) {
+			continue
+		}
+		err = c.startPortForward(ctx, component)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.IsDryRun() {
+		logger := log.FromContext(ctx)
+		err = c.waitServed(ctx, 2*time.Minute)
+		if err != nil {
+			logger.Warn("Cluster is not served yet", "err", err)
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) served(ctx context.Context) (bool, error) {
+	err := c.KubectlInCluster(ctx, "get", "--raw", "/version")
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Cluster) waitServed(ctx context.Context, timeout time.Duration) error {
+	var (
+		err     error
+		waitErr error
+		ready   bool
+	)
+	logger := log.FromContext(ctx)
+	waitErr = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, err = c.served(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not served yet",
+				"err", err,
+			)
+		}
+		return ready, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithInterval(time.Second/5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+// portForwardDir returns the directory of the port-forward process of the component.
+func (c *Cluster) portForwardDir(name string) string {
+	return c.GetWorkdirPath(path.Join(portForwardsDirName, name))
+}
+
+// startPortForward forwards the host ports of the component to its pod,
+// the port-forward is restarted by the supervisor when the pod is recreated.
+func (c *Cluster) startPortForward(ctx context.Context, component internalversion.Component) error {
+	ports := []string{}
+	for _, port := range component.Ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		ports = append(ports, format.String(port.HostPort)+":"+format.String(port.Port))
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	kubectlPath, err := c.KubectlPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	dir := c.portForwardDir(component.Name)
+	err = c.MkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"--kubeconfig", c.GetWorkdirPath(hostKubeconfigName),
+		"--namespace", c.Name(),
+		"port-forward",
+		"--address", conf.BindAddress,
+		"deployment/" + resourceName(c.Name(), component.Name),
+	}
+	args = append(args, ports...)
+	return c.ForkExecSupervised(ctx, dir, internalversion.RestartPolicyAlways, kubectlPath, args...)
+}
+
+// stopPortForward stops the port-forward process of the component.
+func (c *Cluster) stopPortForward(ctx context.Context, component internalversion.Component) error {
+	kubectlPath, err := c.KubectlPath(ctx)
+	if err != nil {
+		return err
+	}
+	return c.ForkExecKill(ctx, c.portForwardDir(component.Name), kubectlPath)
+}
+
+func (c *Cluster) stopPortForwards(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	for _, component := range config.Components {
+		err = c.stopPortForward(ctx, component)
+		if err != nil {
+			return fmt.Errorf("failed to stop the port-forward of %s: %w", component.Name, err)
+		}
+	}
+	return nil
+}
+
+// StartComponent starts a component in the cluster
+func (c *Cluster) StartComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.hostKubectl(ctx, "scale", "deployment/"+resourceName(c.Name(), name), "--replicas=1")
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return c.startPortForward(ctx, component)
+}
+
+// StopComponent stops a component in the cluster
+func (c *Cluster) StopComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.stopPortForward(ctx, component)
+	if err != nil {
+		return err
+	}
+	err = c.hostKubectl(ctx, "scale", "deployment/"+resourceName(c.Name(), name), "--replicas=0")
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// UpgradeComponent replaces the image of the component in its deployment, the pod is recreated by the cluster
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string, conf runtime.UpgradeComponentConfig) error {
+	err := runtime.CheckUpgradeComponent(name)
+	if err != nil {
+		return err
+	}
+	if conf.Image == "" {
+		return fmt.Errorf("image of %s is required to upgrade it", name)
+	}
+
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	ver := c.parseVersionFromImage(ctx, conf.Image)
+
+	err = c.hostKubectl(ctx, "set", "image", "deployment/"+resourceName(c.Name(), name), name+"="+conf.Image)
+	if err != nil {
+		return err
+	}
+
+	component.Image = conf.Image
+	component.Version = ver.String()
+	return c.SaveUpgradedComponent(ctx, component, conf)
+}
+
+// getComponentPods returns the pods of the component which are not being deleted.
+func (c *Cluster) getComponentPods(ctx context.Context, name string) ([]corev1.Pod, error) {
+	buf := bytes.NewBuffer(nil)
+	err := c.hostKubectl(exec.WithWriteTo(ctx, buf), "get", "pods", "--selector", componentSelector(c.Name(), name), "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var list corev1.PodList
+	err = json.Unmarshal(buf.Bytes(), &list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the pods of %s: %w", name, err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool, running bool, err error) {
+	pods, err := c.getComponentPods(ctx, name)
+	if err != nil {
+		return false, false, err
+	}
+	if len(pods) == 0 {
+		return false, false, nil
+	}
+
+	pod := pods[0]
+	if pod.Status.Phase != corev1.PodRunning {
+		return false, false, nil
+	}
+	if pod.Status.ContainerStatuses == nil {
+		return false, true, nil
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false, true, nil
+		}
+	}
+
+	return true, true, nil
+}
+
+// InspectComponent returns the status of the component
+func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentStatusReady, nil
+	}
+	ready, running, err := c.inspectComponent(ctx, name)
+	if err != nil {
+		return runtime.ComponentStatusUnknown, err
+	}
+	if !running {
+		return runtime.ComponentStatusStopped, nil
+	}
+	if !ready {
+		return runtime.ComponentStatusRunning, nil
+	}
+	return runtime.ComponentStatusReady, nil
+}
+
+// InspectComponentRestarts returns the restarts of the component
+func (c *Cluster) InspectComponentRestarts(ctx context.Context, name string) (runtime.ComponentRestarts, error) {
+	if c.IsDryRun() {
+		return runtime.ComponentRestarts{}, nil
+	}
+	pods, err := c.getComponentPods(ctx, name)
+	if err != nil {
+		return runtime.ComponentRestarts{}, err
+	}
+
+	restarts := runtime.ComponentRestarts{}
+	for _, pod := range pods {
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			restarts.Count += int(containerStatus.RestartCount)
+			terminated := containerStatus.LastTerminationState.Terminated
+			if terminated == nil || !terminated.FinishedAt.After(restarts.LastExitTime) {
+				continue
+			}
+			restarts.LastExitCode = int(terminated.ExitCode)
+			restarts.LastExitReason = terminated.Reason
+			restarts.LastExitTime = terminated.FinishedAt.Time
+		}
+	}
+	return restarts, nil
+}
+
+// InspectUsage returns the usage of the resources by the pods of the running components,
+// it requires the metrics API in the cluster the components are deployed into.
+func (c *Cluster) InspectUsage(ctx context.Context) ([]runtime.ComponentUsage, error) {
+	if c.IsDryRun() {
+		return nil, nil
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []runtime.ComponentUsage{}
+	for _, component := range config.Components {
+		buf := bytes.NewBuffer(nil)
+		err := c.hostKubectl(exec.WithWriteTo(ctx, buf), "top", "pods", "--no-headers", "--selector", componentSelector(c.Name(), component.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the usage of %s, the metrics API is required: %w", component.Name, err)
+		}
+		usage, ok, err := parseTopPods(buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		usage.Name = component.Name
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, "deployment/"+resourceName(c.Name(), name))
+	if c.IsDryRun() && !follow {
+		if file, ok := dryrun.IsCatToFileWriter(out); ok {
+			dryrun.PrintMessage("%s >%s", runtime.FormatExec(ctx, "kubectl", args...), file)
+			return nil
+		}
+	}
+
+	err = c.hostKubectl(exec.WithAllWriteTo(ctx, out), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Logs returns the logs of the specified component.
+func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, false)
+}
+
+// LogsFollow follows the logs of the component
+func (c *Cluster) LogsFollow(ctx context.Context, name string, out io.Writer) error {
+	return c.logs(ctx, name, out, true)
+}
+
+// CollectLogs returns the logs of the specified component.
+func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
+	logger := log.FromContext(ctx)
+
+	kwokConfigPath := path.Join(dir, "kwok.yaml")
+	if file.Exists(kwokConfigPath) {
+		return fmt.Errorf("%s already exists", kwokConfigPath)
+	}
+
+	if err := c.MkdirAll(dir); err != nil {
+		return fmt.Errorf("failed to create tmp directory: %w", err)
+	}
+	logger.Info("Exporting logs", "dir", dir)
+
+	err := c.CopyFile(c.GetWorkdirPath(runtime.ConfigName), kwokConfigPath)
+	if err != nil {
+		return err
+	}
+
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	componentsDir := path.Join(dir, "components")
+	err = c.MkdirAll(componentsDir)
+	if err != nil {
+		return err
+	}
+
+	infoPath := path.Join(dir, consts.RuntimeTypeKubernetes+"-info.txt")
+	kubectlPath, err := c.KubectlPath(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.WriteToPath(ctx, infoPath, []string{kubectlPath, "--kubeconfig", c.GetWorkdirPath(hostKubeconfigName), "version"})
+	if err != nil {
+		return err
+	}
+
+	for _, component := range conf.Components {
+		logPath := path.Join(componentsDir, component.Name+".log")
+		f, err := c.OpenFile(logPath)
+		if err != nil {
+			logger.Error("Failed to open file", err)
+			continue
+		}
+		if err = c.Logs(ctx, component.Name, f); err != nil {
+			logger.Error("Failed to get log", err)
+		}
+		if err = f.Close(); err != nil {
+			logger.Error("Failed to close file", err)
+			if err = c.Remove(logPath); err != nil {
+				logger.Error("Failed to remove file", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListBinaries list binaries in the cluster
+func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	return []string{
+		conf.KubectlBinary,
+	}, nil
+}
+
+// ListImages list images in the cluster
+func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conf := &config.Options
+
+	images := []string{
+		conf.EtcdImage,
+		conf.KubeApiserverImage,
+		conf.KubeControllerManagerImage,
+		conf.KubeSchedulerImage,
+		conf.KwokControllerImage,
+		conf.PrometheusImage,
+		conf.MetricsServerImage,
+	}
+	if components.IsKineBackend(conf.EtcdBackend) {
+		images = append(images, conf.KineImage)
+	}
+	return images, nil
+}
+
+// EtcdctlInCluster implements the ectdctl subcommand
+func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+	return c.Etcdctl(ctx, append([]string{"--endpoints", net.LocalAddress + ":" + format.String(conf.EtcdPort)}, args...)...)
+}
+
+// Ready returns true if the cluster is ready
+func (c *Cluster) Ready(ctx context.Context) (bool, error) {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// TODO: Only the necessary components are checked for readiness.
+	for _, component := range config.Components {
+		if runtime.IsLazyComponent(component) {
+			continue
+		}
+		s, _ := c.InspectComponent(ctx, component.Name)
+		if s != runtime.ComponentStatusReady {
+			return false, nil
+		}
+	}
+
+	return c.Cluster.Ready(ctx)
+}
+
+// WaitReady waits for the cluster to be ready.
+func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if c.IsDryRun() {
+		return nil
+	}
+
+	var (
+		err     error
+		waitErr error
+		ready   bool
+	)
+	logger := log.FromContext(ctx)
+	waitErr = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, err = c.Ready(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not ready",
+				"err", err,
+			)
+		}
+		return ready, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithContinueOnError(10),
+		wait.WithInterval(time.Second/2),
+	)
+	if err != nil {
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+// InitCRs initializes the CRs.
+func (c *Cluster) InitCRs(ctx context.Context) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := config.Options
+
+	if c.IsDryRun() {
+		if conf.EnableMetricsServer {
+			dryrun.PrintMessage("# Set up apiservice for metrics server")
+		}
+
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if conf.EnableMetricsServer {
+		apiservice, err := components.BuildMetricsServerAPIService(components.BuildMetricsServerAPIServiceConfig{
+			Port:         4443,
+			ExternalName: c.Name() + "-metrics-server",
+		})
+		if err != nil {
+			return err
+		}
+		_, _ = buf.WriteString(apiservice)
+		_, _ = buf.WriteString("---\n")
+	}
+
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	clientset, err := c.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+
+	loader, err := snapshot.NewLoader(snapshot.LoadConfig{
+		Clientset: clientset,
+		NoFilers:  true,
+	})
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(buf)
+
+	return loader.Load(ctx, decoder)
+}
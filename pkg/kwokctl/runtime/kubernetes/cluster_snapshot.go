@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+// detachedName is the name of the snapshot of the resources saved on detach.
+const detachedName = "detached.yaml"
+
+// SnapshotSave save the snapshot of cluster
+func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
+	err := c.EtcdctlInCluster(ctx, "snapshot", "save", path)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SnapshotRestore restore the snapshot of cluster
+func (c *Cluster) SnapshotRestore(_ context.Context, _ string) error {
+	// The data of etcd is in the pod, there is no data directory on the host to restore it into
+	return fmt.Errorf("restoring the snapshot of etcd is not supported by the %s runtime, use the k8s format instead", consts.RuntimeTypeKubernetes)
+}
+
+// SnapshotSaveWithYAML save the snapshot of cluster
+func (c *Cluster) SnapshotSaveWithYAML(ctx context.Context, path string, conf runtime.SnapshotSaveWithYAMLConfig) error {
+	err := c.Cluster.SnapshotSaveWithYAML(ctx, path, conf)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SnapshotRestoreWithYAML restore the snapshot of cluster
+func (c *Cluster) SnapshotRestoreWithYAML(ctx context.Context, path string, conf runtime.SnapshotRestoreWithYAMLConfig) error {
+	logger := log.FromContext(ctx)
+	components := []string{
+		consts.ComponentKubeScheduler,
+		consts.ComponentKubeControllerManager,
+		consts.ComponentKwokController,
+	}
+	for _, component := range components {
+		err := c.StopComponent(ctx, component)
+		if err != nil {
+			logger.Error("Failed to stop", err, "component", component)
+		}
+	}
+	defer func() {
+		ctx := context.WithoutCancel(ctx)
+		for _, component := range components {
+			err := c.StartComponent(ctx, component)
+			if err != nil {
+				logger.Error("Failed to start", err, "component", component)
+			}
+		}
+	}()
+
+	err := c.Cluster.SnapshotRestoreWithYAML(ctx, path, conf)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Detach saves the resources of the cluster into the workdir, and deletes the namespace of the cluster.
+func (c *Cluster) Detach(ctx context.Context) error {
+	// The data of etcd is lost with the pod, so the resources are saved instead of the snapshot of etcd
+	err := c.SnapshotSaveWithYAML(ctx, c.GetWorkdirPath(detachedName), runtime.SnapshotSaveWithYAMLConfig{
+		Filters: snapshot.Resources,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save the resources of the cluster: %w", err)
+	}
+
+	return c.Down(ctx)
+}
+
+// Attach recreates the namespace of the cluster, and restores the resources of the cluster from the workdir.
+func (c *Cluster) Attach(ctx context.Context) error {
+	err := c.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshotPath := c.GetWorkdirPath(detachedName)
+	if !c.IsDryRun() && !file.Exists(snapshotPath) {
+		return nil
+	}
+
+	err = c.SnapshotRestoreWithYAML(ctx, snapshotPath, runtime.SnapshotRestoreWithYAMLConfig{
+		Filters: snapshot.Resources,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore the resources of the cluster: %w", err)
+	}
+	return c.Remove(snapshotPath)
+}
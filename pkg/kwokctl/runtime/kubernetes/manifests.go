@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const (
+	// instanceLabel is the label of the name of the cluster on the resources of the components.
+	instanceLabel = "app.kubernetes.io/instance"
+	// componentLabel is the label of the name of the component on the resources of the components.
+	componentLabel = "app.kubernetes.io/name"
+
+	// filesVolumeName is the name of the volume of the secret with the files of the workdir.
+	filesVolumeName = "files"
+)
+
+// resourceName returns the name of the Deployment and the Service of the component,
+// which is the same as the name of the container of the component in the container runtimes,
+// so the components reach each other by the same hostnames.
+func resourceName(name, component string) string {
+	return name + "-" + component
+}
+
+// filesSecretName returns the name of the secret with the files of the workdir.
+func filesSecretName(name string) string {
+	return name + "-files"
+}
+
+// componentSelector returns the label selector of the pods of the component.
+func componentSelector(name, component string) string {
+	return instanceLabel + "=" + name + "," + componentLabel + "=" + component
+}
+
+// fileKey returns the key in the secret of a file in the workdir,
+// false if the path is not in the workdir.
+func fileKey(workdir, hostPath string) (string, bool) {
+	if hostPath == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(workdir, hostPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "_"), true
+}
+
+// workdirFiles returns the files in the workdir mounted by the components, keyed by their keys in the secret.
+func workdirFiles(workdir string, cs []internalversion.Component) map[string]string {
+	files := map[string]string{}
+	for _, component := range cs {
+		for _, volume := range component.Volumes {
+			key, ok := fileKey(workdir, volume.HostPath)
+			if !ok {
+				continue
+			}
+			files[key] = volume.HostPath
+		}
+	}
+	return files
+}
+
+// fromFileArgs returns the --from-file args of kubectl to create the secret with the files.
+func fromFileArgs(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return slices.Map(keys, func(key string) string {
+		return "--from-file=" + key + "=" + files[key]
+	})
+}
+
+// buildDeployment builds the Deployment of the component,
+// the files in the workdir are mounted from the secret, and the other host paths are mounted from the nodes.
+func buildDeployment(name, workdir string, component internalversion.Component) appsv1.Deployment {
+	pod := components.ConvertToPod(component)
+	spec := pod.Spec
+	spec.HostNetwork = false
+	spec.RestartPolicy = corev1.RestartPolicyAlways
+	spec.EnableServiceLinks = format.Ptr(false)
+	// The components connect to the simulated cluster, not the host cluster
+	spec.AutomountServiceAccountToken = format.Ptr(false)
+
+	container := &spec.Containers[0]
+	container.ImagePullPolicy = corev1.PullIfNotPresent
+	for i := range container.Ports {
+		container.Ports[i].HostPort = 0
+	}
+
+	// The volumes and their mounts of the pod are in the same order as the volumes of the component
+	volumes := []corev1.Volume{}
+	mountFiles := false
+	for i, volume := range component.Volumes {
+		key, ok := fileKey(workdir, volume.HostPath)
+		if !ok {
+			volumes = append(volumes, spec.Volumes[i])
+			continue
+		}
+		container.VolumeMounts[i] = corev1.VolumeMount{
+			Name:      filesVolumeName,
+			MountPath: volume.MountPath,
+			SubPath:   key,
+			ReadOnly:  true,
+		}
+		mountFiles = true
+	}
+	if mountFiles {
+		volumes = append(volumes, corev1.Volume{
+			Name: filesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: filesSecretName(name),
+				},
+			},
+		})
+	}
+	spec.Volumes = volumes
+
+	if _, ok := slices.Find(component.Args, func(arg string) bool {
+		return strings.Contains(arg, "$(POD_IP)")
+	}); ok {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: "POD_IP",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		})
+	}
+
+	// The lazy components are scaled up when they are first accessed
+	replicas := int32(1)
+	if runtime.IsLazyComponent(component) {
+		replicas = 0
+	}
+
+	labels := map[string]string{
+		instanceLabel:  name,
+		componentLabel: component.Name,
+	}
+	return appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resourceName(name, component.Name),
+			Labels: labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			// The old pod is stopped before the new one is started, so that two etcd never run at the same time
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: spec,
+			},
+		},
+	}
+}
+
+// buildService builds the headless Service of the component,
+// the name resolves to the pod of the component with all its ports, as the hostname of the container in the container runtimes.
+func buildService(name string, component internalversion.Component) corev1.Service {
+	labels := map[string]string{
+		instanceLabel:  name,
+		componentLabel: component.Name,
+	}
+	ports := make([]corev1.ServicePort, 0, len(component.Ports))
+	for _, port := range component.Ports {
+		portName := port.Name
+		if portName == "" {
+			portName = "port-" + format.String(port.Port)
+		}
+		ports = append(ports, corev1.ServicePort{
+			Name:     portName,
+			Port:     int32(port.Port),
+			Protocol: corev1.Protocol(port.Protocol),
+		})
+	}
+	return corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   resourceName(name, component.Name),
+			Labels: labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  labels,
+			Ports:     ports,
+			// The components have to reach etcd and kube-apiserver before they are ready
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// buildManifests builds the Deployments and the Services of the components.
+func buildManifests(name, workdir string, cs []internalversion.Component) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	for _, component := range cs {
+		objs := []any{
+			buildDeployment(name, workdir, component),
+			buildService(name, component),
+		}
+		for _, obj := range objs {
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal the manifests of %s: %w", component.Name, err)
+			}
+			_, _ = buf.WriteString("---\n")
+			_, _ = buf.Write(data)
+		}
+	}
+	return buf.Bytes(), nil
+}
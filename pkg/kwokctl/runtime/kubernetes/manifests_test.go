@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestFileKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostPath string
+		want     string
+		wantOk   bool
+	}{
+		{
+			name:     "file in workdir",
+			hostPath: "/workdir/kwok.yaml",
+			want:     "kwok.yaml",
+			wantOk:   true,
+		},
+		{
+			name:     "file in sub directory",
+			hostPath: "/workdir/pki/admin.crt",
+			want:     "pki_admin.crt",
+			wantOk:   true,
+		},
+		{
+			name:     "workdir",
+			hostPath: "/workdir",
+		},
+		{
+			name:     "file out of workdir",
+			hostPath: "/var/log/kwok",
+		},
+		{
+			name:     "sibling of workdir",
+			hostPath: "/workdir-other/kwok.yaml",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := fileKey("/workdir", tt.hostPath)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("fileKey() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestBuildDeployment(t *testing.T) {
+	component := internalversion.Component{
+		Name:  "kwok-controller",
+		Image: "registry.k8s.io/kwok/kwok:v0.6.0",
+		Args: []string{
+			"--node-ip=$(POD_IP)",
+		},
+		Ports: []internalversion.Port{
+			{
+				HostPort: 10247,
+				Port:     10247,
+			},
+		},
+		Volumes: []internalversion.Volume{
+			{
+				HostPath:  "/workdir/kwok.yaml",
+				MountPath: "/root/.kwok/kwok.yaml",
+				ReadOnly:  true,
+			},
+			{
+				HostPath:  "/var/log/kwok",
+				MountPath: "/var/log/kwok",
+			},
+		},
+	}
+
+	deployment := buildDeployment("kwok-test", "/workdir", component)
+	if deployment.Name != "kwok-test-kwok-controller" {
+		t.Errorf("unexpected name %q", deployment.Name)
+	}
+	if got := *deployment.Spec.Replicas; got != 1 {
+		t.Errorf("unexpected replicas %d", got)
+	}
+
+	spec := deployment.Spec.Template.Spec
+	if spec.HostNetwork {
+		t.Errorf("unexpected host network")
+	}
+
+	wantVolumes := []string{"volume-1", filesVolumeName}
+	gotVolumes := []string{}
+	for _, volume := range spec.Volumes {
+		gotVolumes = append(gotVolumes, volume.Name)
+	}
+	if diff := cmp.Diff(wantVolumes, gotVolumes); diff != "" {
+		t.Errorf("unexpected volumes (-want +got):\n%s", diff)
+	}
+
+	container := spec.Containers[0]
+	wantMounts := []corev1.VolumeMount{
+		{
+			Name:      filesVolumeName,
+			MountPath: "/root/.kwok/kwok.yaml",
+			SubPath:   "kwok.yaml",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "volume-1",
+			MountPath: "/var/log/kwok",
+		},
+	}
+	if diff := cmp.Diff(wantMounts, container.VolumeMounts); diff != "" {
+		t.Errorf("unexpected volume mounts (-want +got):\n%s", diff)
+	}
+
+	for _, port := range container.Ports {
+		if port.HostPort != 0 {
+			t.Errorf("unexpected host port %d", port.HostPort)
+		}
+	}
+
+	if len(container.Env) != 1 || container.Env[0].Name != "POD_IP" {
+		t.Errorf("unexpected env %v", container.Env)
+	}
+}
+
+func TestFromFileArgs(t *testing.T) {
+	got := fromFileArgs(map[string]string{
+		"pki_ca.crt": "/workdir/pki/ca.crt",
+		"kubeconfig": "/workdir/kubeconfig",
+	})
+	want := []string{
+		"--from-file=kubeconfig=/workdir/kubeconfig",
+		"--from-file=pki_ca.crt=/workdir/pki/ca.crt",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected args (-want +got):\n%s", diff)
+	}
+}
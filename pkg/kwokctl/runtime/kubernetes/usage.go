@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+// parseTopPods parses the output of kubectl top pods without headers, e.g. "kwok-kwok-etcd-6d4b75cb6d-x2x9q 12m 34Mi",
+// the usages of all the pods are summed up, false if there is no pod.
+func parseTopPods(raw []byte) (runtime.ComponentUsage, bool, error) {
+	usage := runtime.ComponentUsage{}
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return runtime.ComponentUsage{}, false, fmt.Errorf("unexpected line of top pods %q", scanner.Text())
+		}
+		cpu, err := resource.ParseQuantity(fields[1])
+		if err != nil {
+			return runtime.ComponentUsage{}, false, fmt.Errorf("parse cpu %q: %w", fields[1], err)
+		}
+		memory, err := resource.ParseQuantity(fields[2])
+		if err != nil {
+			return runtime.ComponentUsage{}, false, fmt.Errorf("parse memory %q: %w", fields[2], err)
+		}
+		// 1000m is one CPU, which is 100 percent
+		usage.CPUPercent += float64(cpu.MilliValue()) / 10
+		usage.MemoryBytes += uint64(memory.Value())
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return runtime.ComponentUsage{}, false, err
+	}
+	return usage, found, nil
+}
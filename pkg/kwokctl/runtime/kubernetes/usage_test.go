@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+func TestParseTopPods(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    runtime.ComponentUsage
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			raw:  "",
+		},
+		{
+			name: "one pod",
+			raw:  "kwok-kwok-etcd-6d4b75cb6d-x2x9q   25m   34Mi\n",
+			want: runtime.ComponentUsage{
+				CPUPercent:  2.5,
+				MemoryBytes: 34 * 1024 * 1024,
+			},
+			wantOk: true,
+		},
+		{
+			name: "recreating pods",
+			raw:  "kwok-kwok-etcd-6d4b75cb6d-x2x9q   1   1Gi\nkwok-kwok-etcd-6d4b75cb6d-b8v7n   500m   1Gi\n",
+			want: runtime.ComponentUsage{
+				CPUPercent:  150,
+				MemoryBytes: 2 * 1024 * 1024 * 1024,
+			},
+			wantOk: true,
+		},
+		{
+			name:    "unexpected line",
+			raw:     "error: Metrics API not available\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid cpu",
+			raw:     "kwok-kwok-etcd-6d4b75cb6d-x2x9q   x   34Mi\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := parseTopPods([]byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTopPods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("parseTopPods() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseTopPods() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// GetLogVolumes returns volumes for Logs and ClusterLogs resource.
+// The directories are filtered by the allowed and denied paths of the options,
+// and the directories nested in another one are mounted by the parent directory.
+func GetLogVolumes(ctx context.Context, options *internalversion.LogVolumes) []internalversion.Volume {
+	logs := config.FilterWithTypeFromContext[*internalversion.Logs](ctx)
+	clusterLogs := config.FilterWithTypeFromContext[*internalversion.ClusterLogs](ctx)
+	attaches := config.FilterWithTypeFromContext[*internalversion.Attach](ctx)
+	clusterAttaches := config.FilterWithTypeFromContext[*internalversion.ClusterAttach](ctx)
+
+	// Mount log dirs
+	mountDirs := map[string]struct{}{}
+	for _, log := range logs {
+		for _, l := range log.Spec.Logs {
+			mountDirs[path.Dir(l.LogsFile)] = struct{}{}
+			if l.PreviousLogsFile != "" {
+				mountDirs[path.Dir(l.PreviousLogsFile)] = struct{}{}
+			}
+		}
+	}
+
+	for _, cl := range clusterLogs {
+		for _, l := range cl.Spec.Logs {
+			mountDirs[path.Dir(l.LogsFile)] = struct{}{}
+			if l.PreviousLogsFile != "" {
+				mountDirs[path.Dir(l.PreviousLogsFile)] = struct{}{}
+			}
+		}
+	}
+
+	for _, attach := range attaches {
+		for _, a := range attach.Spec.Attaches {
+			mountDirs[path.Dir(a.LogsFile)] = struct{}{}
+		}
+	}
+
+	for _, ca := range clusterAttaches {
+		for _, a := range ca.Spec.Attaches {
+			mountDirs[path.Dir(a.LogsFile)] = struct{}{}
+		}
+	}
+
+	if options == nil {
+		options = &internalversion.LogVolumes{}
+	}
+
+	logsDirs := maps.Keys(mountDirs)
+	sort.Strings(logsDirs)
+
+	volumes := make([]internalversion.Volume, 0, len(logsDirs))
+	mounted := []string{}
+	for _, logsDir := range logsDirs {
+		if len(options.AllowedPaths) != 0 && !underAnyPath(logsDir, options.AllowedPaths) {
+			continue
+		}
+		if underAnyPath(logsDir, options.DeniedPaths) {
+			continue
+		}
+		// The sorted parent directory is mounted before the nested one
+		if underAnyPath(logsDir, mounted) {
+			continue
+		}
+		mounted = append(mounted, logsDir)
+
+		volume := internalversion.Volume{
+			Name:      fmt.Sprintf("log-volume-%d", len(volumes)),
+			HostPath:  logsDir,
+			MountPath: logsDir,
+			PathType:  internalversion.HostPathDirectoryOrCreate,
+			ReadOnly:  !options.ReadWrite,
+		}
+		if mount, ok := findLogVolumeMount(logsDir, options.Mounts); ok {
+			if mount.ReadWrite != nil {
+				volume.ReadOnly = !*mount.ReadWrite
+			}
+			volume.MountPropagation = mount.MountPropagation
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// findLogVolumeMount returns the options of the mount of the directory,
+// the options of the closest parent directory are used if the directory has no options.
+func findLogVolumeMount(dir string, mounts []internalversion.LogVolumeMount) (internalversion.LogVolumeMount, bool) {
+	var found internalversion.LogVolumeMount
+	ok := false
+	for _, mount := range mounts {
+		if !underPath(dir, mount.Path) {
+			continue
+		}
+		if !ok || len(path.Clean(mount.Path)) > len(path.Clean(found.Path)) {
+			found = mount
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// underAnyPath returns whether the path is one of the parents or is under one of them.
+func underAnyPath(p string, parents []string) bool {
+	for _, parent := range parents {
+		if underPath(p, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// underPath returns whether the path is the parent or is under it.
+func underPath(p, parent string) bool {
+	p = path.Clean(p)
+	parent = strings.TrimRight(path.Clean(parent), "/\\")
+	if p == parent {
+		return true
+	}
+	return strings.HasPrefix(p, parent+"/") || strings.HasPrefix(p, parent+"\\")
+}
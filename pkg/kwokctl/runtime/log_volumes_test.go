@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestGetLogVolumes(t *testing.T) {
+	ctx := config.NewContext(context.Background(), []config.InternalObject{
+		&internalversion.ClusterLogs{
+			Spec: internalversion.ClusterLogsSpec{
+				Logs: []internalversion.Log{
+					{LogsFile: "/var/log/pods/a.log"},
+					{LogsFile: "/var/log/pods/nested/b.log"},
+					{LogsFile: "/var/log/generated/c.log"},
+					{LogsFile: "/tmp/d.log"},
+					{LogsFile: "/secret/e.log"},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		options *internalversion.LogVolumes
+		want    []internalversion.Volume
+	}{
+		{
+			name: "default",
+			want: []internalversion.Volume{
+				{Name: "log-volume-0", HostPath: "/secret", MountPath: "/secret", PathType: internalversion.HostPathDirectoryOrCreate, ReadOnly: true},
+				{Name: "log-volume-1", HostPath: "/tmp", MountPath: "/tmp", PathType: internalversion.HostPathDirectoryOrCreate, ReadOnly: true},
+				{Name: "log-volume-2", HostPath: "/var/log/generated", MountPath: "/var/log/generated", PathType: internalversion.HostPathDirectoryOrCreate, ReadOnly: true},
+				{Name: "log-volume-3", HostPath: "/var/log/pods", MountPath: "/var/log/pods", PathType: internalversion.HostPathDirectoryOrCreate, ReadOnly: true},
+			},
+		},
+		{
+			name: "allowed and denied paths with mounts",
+			options: &internalversion.LogVolumes{
+				AllowedPaths: []string{"/var/log", "/secret"},
+				DeniedPaths:  []string{"/secret/"},
+				Mounts: []internalversion.LogVolumeMount{
+					{Path: "/var/log/generated", ReadWrite: format.Ptr(true), MountPropagation: internalversion.MountPropagationHostToContainer},
+				},
+			},
+			want: []internalversion.Volume{
+				{Name: "log-volume-0", HostPath: "/var/log/generated", MountPath: "/var/log/generated", PathType: internalversion.HostPathDirectoryOrCreate, MountPropagation: internalversion.MountPropagationHostToContainer},
+				{Name: "log-volume-1", HostPath: "/var/log/pods", MountPath: "/var/log/pods", PathType: internalversion.HostPathDirectoryOrCreate, ReadOnly: true},
+			},
+		},
+		{
+			name: "read write",
+			options: &internalversion.LogVolumes{
+				ReadWrite:    true,
+				AllowedPaths: []string{"/tmp"},
+			},
+			want: []internalversion.Volume{
+				{Name: "log-volume-0", HostPath: "/tmp", MountPath: "/tmp", PathType: internalversion.HostPathDirectoryOrCreate},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetLogVolumes(ctx, tt.options)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetLogVolumes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// HostMetric returns the metric of the component as it is reachable from the host,
+// the metric of a component in a container is addressed in the network of the containers,
+// so its port and its cert files are mapped to the ones exposed on the host.
+// It returns nil if the component has no metric.
+func HostMetric(component internalversion.Component, hostAddress string) (*internalversion.ComponentMetric, error) {
+	if component.Metric == nil {
+		return nil, nil
+	}
+	metric := *component.Metric
+	if component.Binary != "" {
+		return &metric, nil
+	}
+	if component.Image == "" {
+		return nil, fmt.Errorf("metric of %s is not reachable from the host", component.Name)
+	}
+
+	_, portStr, err := net.SplitHostPort(metric.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host of the metric of %s: %w", component.Name, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port of the metric of %s: %w", component.Name, err)
+	}
+	hostPort := uint32(0)
+	for _, p := range component.Ports {
+		if p.Port == uint32(port) {
+			hostPort = p.HostPort
+			break
+		}
+	}
+	if hostPort == 0 {
+		return nil, fmt.Errorf("port %d of the metric of %s is not exposed on the host", port, component.Name)
+	}
+	metric.Host = net.JoinHostPort(hostAddress, strconv.FormatUint(uint64(hostPort), 10))
+
+	metric.CertPath, err = hostPathOf(component, metric.CertPath)
+	if err != nil {
+		return nil, err
+	}
+	metric.KeyPath, err = hostPathOf(component, metric.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return &metric, nil
+}
+
+// hostPathOf returns the path on the host of the file mounted into the container of the component.
+func hostPathOf(component internalversion.Component, mountPath string) (string, error) {
+	if mountPath == "" {
+		return "", nil
+	}
+	for _, volume := range component.Volumes {
+		if volume.MountPath == mountPath {
+			return volume.HostPath, nil
+		}
+	}
+	return "", fmt.Errorf("file %s of the metric of %s is not mounted from the host", mountPath, component.Name)
+}
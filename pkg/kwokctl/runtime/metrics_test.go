@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestHostMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		component internalversion.Component
+		want      *internalversion.ComponentMetric
+		wantErr   bool
+	}{
+		{
+			name: "no metric",
+			component: internalversion.Component{
+				Name:   "etcd",
+				Binary: "/bin/etcd",
+			},
+		},
+		{
+			name: "binary",
+			component: internalversion.Component{
+				Name:   "etcd",
+				Binary: "/bin/etcd",
+				Metric: &internalversion.ComponentMetric{
+					Scheme: "http",
+					Host:   "127.0.0.1:32765",
+					Path:   "/metrics",
+				},
+			},
+			want: &internalversion.ComponentMetric{
+				Scheme: "http",
+				Host:   "127.0.0.1:32765",
+				Path:   "/metrics",
+			},
+		},
+		{
+			name: "container",
+			component: internalversion.Component{
+				Name:  "kube-apiserver",
+				Image: "registry.k8s.io/kube-apiserver:v1.30.2",
+				Ports: []internalversion.Port{
+					{Port: 6443, HostPort: 32766},
+				},
+				Volumes: []internalversion.Volume{
+					{HostPath: "/workdir/pki/admin.crt", MountPath: "/etc/kubernetes/pki/admin.crt"},
+					{HostPath: "/workdir/pki/admin.key", MountPath: "/etc/kubernetes/pki/admin.key"},
+				},
+				Metric: &internalversion.ComponentMetric{
+					Scheme:             "https",
+					Host:               "kwok-kwok-kube-apiserver:6443",
+					Path:               "/metrics",
+					CertPath:           "/etc/kubernetes/pki/admin.crt",
+					KeyPath:            "/etc/kubernetes/pki/admin.key",
+					InsecureSkipVerify: true,
+				},
+			},
+			want: &internalversion.ComponentMetric{
+				Scheme:             "https",
+				Host:               "192.168.0.2:32766",
+				Path:               "/metrics",
+				CertPath:           "/workdir/pki/admin.crt",
+				KeyPath:            "/workdir/pki/admin.key",
+				InsecureSkipVerify: true,
+			},
+		},
+		{
+			name: "container without host port",
+			component: internalversion.Component{
+				Name:  "kube-scheduler",
+				Image: "registry.k8s.io/kube-scheduler:v1.30.2",
+				Metric: &internalversion.ComponentMetric{
+					Scheme: "http",
+					Host:   "kwok-kwok-kube-scheduler:10251",
+					Path:   "/metrics",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container without mounted cert",
+			component: internalversion.Component{
+				Name:  "kube-apiserver",
+				Image: "registry.k8s.io/kube-apiserver:v1.30.2",
+				Ports: []internalversion.Port{
+					{Port: 6443, HostPort: 32766},
+				},
+				Metric: &internalversion.ComponentMetric{
+					Scheme:   "https",
+					Host:     "kwok-kwok-kube-apiserver:6443",
+					Path:     "/metrics",
+					CertPath: "/etc/kubernetes/pki/admin.crt",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "inside the node of kind",
+			component: internalversion.Component{
+				Name: "etcd",
+				Metric: &internalversion.ComponentMetric{
+					Scheme: "https",
+					Host:   "127.0.0.1:2379",
+					Path:   "/metrics",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HostMetric(tt.component, "192.168.0.2")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HostMetric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("HostMetric() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
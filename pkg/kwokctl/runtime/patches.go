@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"path"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// MatchComponentPatch returns whether the name of a patch matches the name of a component.
+// The name of the patch is a comma-separated list of component names or glob patterns,
+// e.g. "kube-*" or "kube-apiserver,kube-controller-manager".
+func MatchComponentPatch(patchName, componentName string) bool {
+	for _, pattern := range strings.Split(patchName, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == componentName {
+			return true
+		}
+		if ok, _ := path.Match(pattern, componentName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchVolume returns whether the volume has the name or the mount path.
+func matchVolume(volume internalversion.Volume, nameOrMountPath string) bool {
+	return (volume.Name != "" && volume.Name == nameOrMountPath) || volume.MountPath == nameOrMountPath
+}
+
+// applyComponentPatchVolumes removes the volumes of a component by their names or mount paths,
+// then overrides the volumes with the same name or mount path as the extra volumes in place, and adds the others.
+func applyComponentPatchVolumes(volumes []internalversion.Volume, removeVolumes []string, extraVolumes []internalversion.Volume) []internalversion.Volume {
+	if len(removeVolumes) == 0 && len(extraVolumes) == 0 {
+		return volumes
+	}
+
+	out := make([]internalversion.Volume, 0, len(volumes)+len(extraVolumes))
+	for _, volume := range volumes {
+		removed := false
+		for _, remove := range removeVolumes {
+			if matchVolume(volume, remove) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			out = append(out, volume)
+		}
+	}
+
+	for _, extra := range extraVolumes {
+		index := -1
+		for i, volume := range out {
+			if (extra.Name != "" && matchVolume(volume, extra.Name)) ||
+				(extra.MountPath != "" && volume.MountPath == extra.MountPath) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			out = append(out, extra)
+			continue
+		}
+
+		existing := out[index]
+		if extra.Name == "" {
+			extra.Name = existing.Name
+		}
+		if extra.HostPath == "" {
+			extra.HostPath = existing.HostPath
+		}
+		if extra.MountPath == "" {
+			extra.MountPath = existing.MountPath
+		}
+		if extra.PathType == "" {
+			extra.PathType = existing.PathType
+		}
+		out[index] = extra
+	}
+	return out
+}
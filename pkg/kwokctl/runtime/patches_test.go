@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestMatchComponentPatch(t *testing.T) {
+	tests := []struct {
+		patchName     string
+		componentName string
+		want          bool
+	}{
+		{patchName: "kube-apiserver", componentName: "kube-apiserver", want: true},
+		{patchName: "kube-apiserver", componentName: "kube-scheduler", want: false},
+		{patchName: "kube-*", componentName: "kube-scheduler", want: true},
+		{patchName: "kube-*", componentName: "kwok-controller", want: false},
+		{patchName: "kube-apiserver, kube-controller-manager", componentName: "kube-controller-manager", want: true},
+		{patchName: "etcd,kwok-*", componentName: "kwok-controller", want: true},
+		{patchName: "etcd,kwok-*", componentName: "kube-apiserver", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.patchName+"/"+tt.componentName, func(t *testing.T) {
+			if got := MatchComponentPatch(tt.patchName, tt.componentName); got != tt.want {
+				t.Errorf("MatchComponentPatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetComponentPatches(t *testing.T) {
+	conf := &internalversion.KwokctlConfiguration{
+		ComponentsPatches: []internalversion.ComponentPatches{
+			{
+				Name: "kube-*",
+				ExtraArgs: []internalversion.ExtraArgs{
+					{Key: "v", Value: "4"},
+				},
+			},
+			{
+				Name: "kube-apiserver,etcd",
+				ExtraEnvs: []internalversion.Env{
+					{Name: "HTTPS_PROXY", Value: "http://proxy"},
+				},
+				StartPolicy: internalversion.StartPolicyLazy,
+				MemoryLimit: "2Gi",
+			},
+		},
+	}
+	want := internalversion.ComponentPatches{
+		Name: "kube-apiserver",
+		ExtraArgs: []internalversion.ExtraArgs{
+			{Key: "v", Value: "4"},
+		},
+		ExtraEnvs: []internalversion.Env{
+			{Name: "HTTPS_PROXY", Value: "http://proxy"},
+		},
+		StartPolicy: internalversion.StartPolicyLazy,
+		MemoryLimit: "2Gi",
+	}
+	got := GetComponentPatches(conf, "kube-apiserver")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetComponentPatches() mismatch (-want +got):\n%s", diff)
+	}
+
+	component := internalversion.Component{
+		Name: "etcd",
+		Args: []string{"--log-level=info"},
+	}
+	ApplyComponentPatches(&component, conf.ComponentsPatches)
+	if len(component.Envs) != 1 || len(component.Args) != 1 || component.MemoryLimit != "2Gi" {
+		t.Errorf("ApplyComponentPatches() = %v, want only the envs and the limit of the matching patch", component)
+	}
+}
+
+func TestApplyComponentPatchVolumes(t *testing.T) {
+	volumes := []internalversion.Volume{
+		{HostPath: "/pki/ca.crt", MountPath: "/etc/kubernetes/pki/ca.crt", ReadOnly: true},
+		{Name: "audit", HostPath: "/audit", MountPath: "/var/log/audit"},
+		{HostPath: "/etcd", MountPath: "/var/lib/etcd"},
+	}
+	got := applyComponentPatchVolumes(volumes,
+		[]string{"audit"},
+		[]internalversion.Volume{
+			{MountPath: "/var/lib/etcd", ReadOnly: true, SubPath: "data"},
+			{Name: "extra", HostPath: "/extra", MountPath: "/extra", MountPropagation: internalversion.MountPropagationBidirectional},
+		},
+	)
+	want := []internalversion.Volume{
+		{HostPath: "/pki/ca.crt", MountPath: "/etc/kubernetes/pki/ca.crt", ReadOnly: true},
+		{HostPath: "/etcd", MountPath: "/var/lib/etcd", ReadOnly: true, SubPath: "data"},
+		{Name: "extra", HostPath: "/extra", MountPath: "/extra", MountPropagation: internalversion.MountPropagationBidirectional},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("applyComponentPatchVolumes() mismatch (-want +got):\n%s", diff)
+	}
+}
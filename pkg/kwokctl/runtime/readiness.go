@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+// requiredComponents is the components that the cluster does not work without.
+var requiredComponents = map[string]struct{}{
+	consts.ComponentEtcd:                  {},
+	consts.ComponentKubeApiserver:         {},
+	consts.ComponentKubeControllerManager: {},
+	consts.ComponentKubeScheduler:         {},
+	consts.ComponentKwokController:        {},
+}
+
+// IsOptionalComponent returns whether the cluster works without the component.
+func IsOptionalComponent(name string) bool {
+	_, ok := requiredComponents[name]
+	return !ok
+}
+
+// ReadinessSummary is the summary of waiting for the components to be ready.
+type ReadinessSummary struct {
+	// Ready is the components that are ready.
+	Ready []string
+	// TimedOut is the components that are not ready within the readiness timeout.
+	TimedOut []string
+	// Skipped is the optional components that are stopped as they are not ready.
+	Skipped []string
+}
+
+// WaitComponentsReady waits for each component to be ready within its readiness timeout or the default timeout,
+// the component is restarted up to its readiness retries, and the policy decides what to do if it is still not ready.
+func WaitComponentsReady(ctx context.Context, rt Runtime, policy internalversion.ReadinessFailurePolicy, defaultTimeout time.Duration) (ReadinessSummary, error) {
+	summary := ReadinessSummary{}
+	if rt.IsDryRun() {
+		return summary, nil
+	}
+
+	components, err := rt.ListComponents(ctx)
+	if err != nil {
+		return summary, err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, component := range components {
+		if IsLazyComponent(component) {
+			continue
+		}
+
+		timeout := time.Duration(component.ReadinessTimeoutMilliseconds) * time.Millisecond
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		if timeout <= 0 {
+			continue
+		}
+
+		ready, err := waitComponentReady(ctx, rt, component, timeout)
+		if err != nil {
+			return summary, err
+		}
+		if ready {
+			summary.Ready = append(summary.Ready, component.Name)
+			continue
+		}
+
+		logger.Warn("Component is not ready",
+			"component", component.Name,
+			"timeout", timeout,
+			"retries", component.ReadinessRetries,
+		)
+		switch policy {
+		case internalversion.ReadinessFailurePolicyContinue:
+			summary.TimedOut = append(summary.TimedOut, component.Name)
+		case internalversion.ReadinessFailurePolicySkipOptional:
+			if !IsOptionalComponent(component.Name) {
+				summary.TimedOut = append(summary.TimedOut, component.Name)
+				return summary, fmt.Errorf("required component %q is not ready within %s", component.Name, timeout)
+			}
+			err = rt.StopComponent(ctx, component.Name)
+			if err != nil {
+				logger.Error("Failed to stop component", err,
+					"component", component.Name,
+				)
+			}
+			summary.Skipped = append(summary.Skipped, component.Name)
+		default:
+			summary.TimedOut = append(summary.TimedOut, component.Name)
+			return summary, fmt.Errorf("component %q is not ready within %s", component.Name, timeout)
+		}
+	}
+	return summary, nil
+}
+
+func waitComponentReady(ctx context.Context, rt Runtime, component internalversion.Component, timeout time.Duration) (bool, error) {
+	logger := log.FromContext(ctx)
+	for attempt := uint(0); ; attempt++ {
+		err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+			status, err := rt.InspectComponent(ctx, component.Name)
+			if err != nil {
+				logger.Debug("Component is not ready",
+					"component", component.Name,
+					"err", err,
+				)
+			}
+			return status == ComponentStatusReady, nil
+		}, wait.WithTimeout(timeout), wait.WithImmediate())
+		if err == nil {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if attempt >= component.ReadinessRetries {
+			return false, nil
+		}
+
+		logger.Warn("Restarting component as it is not ready",
+			"component", component.Name,
+			"attempt", attempt+1,
+			"timeout", timeout,
+		)
+		err = rt.StopComponent(ctx, component.Name)
+		if err != nil {
+			logger.Error("Failed to stop component", err,
+				"component", component.Name,
+			)
+		}
+		err = rt.StartComponent(ctx, component.Name)
+		if err != nil {
+			return false, err
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+type fakeReadinessRuntime struct {
+	Runtime
+	components []internalversion.Component
+	// readyAfterStarts is the number of starts after which the component is ready, negative means never.
+	readyAfterStarts map[string]int
+	starts           map[string]int
+	stopped          []string
+}
+
+func (f *fakeReadinessRuntime) IsDryRun() bool {
+	return false
+}
+
+func (f *fakeReadinessRuntime) ListComponents(ctx context.Context) ([]internalversion.Component, error) {
+	return f.components, nil
+}
+
+func (f *fakeReadinessRuntime) InspectComponent(ctx context.Context, name string) (ComponentStatus, error) {
+	n := f.readyAfterStarts[name]
+	if n < 0 || f.starts[name] < n {
+		return ComponentStatusRunning, nil
+	}
+	return ComponentStatusReady, nil
+}
+
+func (f *fakeReadinessRuntime) StartComponent(ctx context.Context, name string) error {
+	f.starts[name]++
+	return nil
+}
+
+func (f *fakeReadinessRuntime) StopComponent(ctx context.Context, name string) error {
+	f.stopped = append(f.stopped, name)
+	return nil
+}
+
+func TestWaitComponentsReady(t *testing.T) {
+	components := []internalversion.Component{
+		{Name: consts.ComponentEtcd},
+		{Name: consts.ComponentKubeApiserver, ReadinessRetries: 1},
+		{Name: consts.ComponentPrometheus, ReadinessTimeoutMilliseconds: 10},
+		{Name: consts.ComponentJaeger, StartPolicy: internalversion.StartPolicyLazy},
+	}
+	tests := []struct {
+		name             string
+		policy           internalversion.ReadinessFailurePolicy
+		readyAfterStarts map[string]int
+		want             ReadinessSummary
+		wantStopped      []string
+		wantErr          bool
+	}{
+		{
+			name: "all ready",
+			want: ReadinessSummary{
+				Ready: []string{consts.ComponentEtcd, consts.ComponentKubeApiserver, consts.ComponentPrometheus},
+			},
+		},
+		{
+			name: "ready after retry",
+			readyAfterStarts: map[string]int{
+				consts.ComponentKubeApiserver: 1,
+			},
+			want: ReadinessSummary{
+				Ready: []string{consts.ComponentEtcd, consts.ComponentKubeApiserver, consts.ComponentPrometheus},
+			},
+			wantStopped: []string{consts.ComponentKubeApiserver},
+		},
+		{
+			name: "abort",
+			readyAfterStarts: map[string]int{
+				consts.ComponentPrometheus: -1,
+			},
+			want: ReadinessSummary{
+				Ready:    []string{consts.ComponentEtcd, consts.ComponentKubeApiserver},
+				TimedOut: []string{consts.ComponentPrometheus},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "continue",
+			policy: internalversion.ReadinessFailurePolicyContinue,
+			readyAfterStarts: map[string]int{
+				consts.ComponentEtcd:       -1,
+				consts.ComponentPrometheus: -1,
+			},
+			want: ReadinessSummary{
+				Ready:    []string{consts.ComponentKubeApiserver},
+				TimedOut: []string{consts.ComponentEtcd, consts.ComponentPrometheus},
+			},
+		},
+		{
+			name:   "skip optional",
+			policy: internalversion.ReadinessFailurePolicySkipOptional,
+			readyAfterStarts: map[string]int{
+				consts.ComponentPrometheus: -1,
+			},
+			want: ReadinessSummary{
+				Ready:   []string{consts.ComponentEtcd, consts.ComponentKubeApiserver},
+				Skipped: []string{consts.ComponentPrometheus},
+			},
+			wantStopped: []string{consts.ComponentPrometheus},
+		},
+		{
+			name:   "skip optional with required",
+			policy: internalversion.ReadinessFailurePolicySkipOptional,
+			readyAfterStarts: map[string]int{
+				consts.ComponentEtcd: -1,
+			},
+			want: ReadinessSummary{
+				TimedOut: []string{consts.ComponentEtcd},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &fakeReadinessRuntime{
+				components:       components,
+				readyAfterStarts: tt.readyAfterStarts,
+				starts:           map[string]int{},
+			}
+			got, err := WaitComponentsReady(context.Background(), rt, tt.policy, 10*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WaitComponentsReady() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("WaitComponentsReady() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStopped, rt.stopped); diff != "" {
+				t.Errorf("stopped components mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
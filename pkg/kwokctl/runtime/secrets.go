@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"os"
+	goruntime "runtime"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// ExpandComponentPatchesFiles expands the relative paths of the env files and the secret files of the patches,
+// and checks that the secret files are not accessible by the group and others.
+// The secret files are turned into read-only volumes if the components run in containers,
+// and the environment variables named by the secret files are set to the paths in the components.
+func ExpandComponentPatchesFiles(patches []internalversion.ComponentPatches, inContainer bool) ([]internalversion.ComponentPatches, error) {
+	out := make([]internalversion.ComponentPatches, 0, len(patches))
+	for _, patch := range patches {
+		if len(patch.ExtraEnvFiles) != 0 {
+			envFiles := make([]string, 0, len(patch.ExtraEnvFiles))
+			for _, envFile := range patch.ExtraEnvFiles {
+				p, err := path.Expand(envFile)
+				if err != nil {
+					return nil, err
+				}
+				envFiles = append(envFiles, p)
+			}
+			patch.ExtraEnvFiles = envFiles
+		}
+
+		if len(patch.ExtraSecrets) != 0 {
+			// Copy to avoid modifying the volumes and envs shared with the original patch.
+			patch.ExtraVolumes = append([]internalversion.Volume{}, patch.ExtraVolumes...)
+			patch.ExtraEnvs = append([]internalversion.Env{}, patch.ExtraEnvs...)
+			for _, secret := range patch.ExtraSecrets {
+				hostPath, err := path.Expand(secret.HostPath)
+				if err != nil {
+					return nil, err
+				}
+				err = checkSecretFile(hostPath)
+				if err != nil {
+					return nil, fmt.Errorf("invalid secret for %q component: %w", patch.Name, err)
+				}
+
+				secretPath := hostPath
+				if inContainer {
+					mountPath := secret.MountPath
+					if mountPath == "" {
+						mountPath = hostPath
+					}
+					patch.ExtraVolumes = append(patch.ExtraVolumes, internalversion.Volume{
+						HostPath:  hostPath,
+						MountPath: mountPath,
+						ReadOnly:  true,
+						PathType:  internalversion.HostPathFile,
+					})
+					secretPath = mountPath
+				}
+				if secret.EnvName != "" {
+					patch.ExtraEnvs = append(patch.ExtraEnvs, internalversion.Env{
+						Name:  secret.EnvName,
+						Value: secretPath,
+					})
+				}
+			}
+			patch.ExtraSecrets = nil
+		}
+		out = append(out, patch)
+	}
+	return out, nil
+}
+
+// checkSecretFile checks the secret file is a regular file and not accessible by the group and others.
+func checkSecretFile(name string) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("secret file %q is not a regular file", name)
+	}
+	// The permission bits are not meaningful on Windows.
+	if goruntime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("secret file %q is accessible by the group or others with mode %s, restrict it with chmod 600", name, info.Mode().Perm())
+	}
+	return nil
+}
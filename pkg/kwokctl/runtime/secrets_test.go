@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestExpandComponentPatchesFiles(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "token")
+	err := os.WriteFile(secret, []byte("token"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patches := []internalversion.ComponentPatches{
+		{
+			Name:          "kwok-controller",
+			ExtraEnvFiles: []string{filepath.Join(dir, "tracing.env")},
+			ExtraSecrets: []internalversion.SecretFile{
+				{
+					HostPath:  secret,
+					MountPath: "/etc/kwok/token",
+					EnvName:   "TOKEN_FILE",
+				},
+			},
+		},
+	}
+
+	got, err := ExpandComponentPatchesFiles(patches, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []internalversion.ComponentPatches{
+		{
+			Name:          "kwok-controller",
+			ExtraEnvFiles: []string{filepath.Join(dir, "tracing.env")},
+			ExtraVolumes: []internalversion.Volume{
+				{
+					HostPath:  secret,
+					MountPath: "/etc/kwok/token",
+					ReadOnly:  true,
+					PathType:  internalversion.HostPathFile,
+				},
+			},
+			ExtraEnvs: []internalversion.Env{
+				{Name: "TOKEN_FILE", Value: "/etc/kwok/token"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExpandComponentPatchesFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = ExpandComponentPatchesFiles(patches, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []internalversion.ComponentPatches{
+		{
+			Name:          "kwok-controller",
+			ExtraEnvFiles: []string{filepath.Join(dir, "tracing.env")},
+			ExtraVolumes:  []internalversion.Volume{},
+			ExtraEnvs: []internalversion.Env{
+				{Name: "TOKEN_FILE", Value: secret},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExpandComponentPatchesFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	err = os.Chmod(secret, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ExpandComponentPatchesFiles(patches, true)
+	if err == nil {
+		t.Errorf("ExpandComponentPatchesFiles() expected error for the secret file readable by others")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// SaveUpgradedComponent replaces the component in the configuration of the cluster with the upgraded one and saves it.
+func (c *Cluster) SaveUpgradedComponent(ctx context.Context, component internalversion.Component, conf UpgradeComponentConfig) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	config = config.DeepCopy()
+
+	index := -1
+	for i, com := range config.Components {
+		if com.Name == component.Name {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrComponentNotFound, component.Name)
+	}
+	config.Components[index] = component
+
+	if component.Name == consts.ComponentKwokController {
+		if conf.Version != "" {
+			config.Options.KwokVersion = conf.Version
+		}
+		if conf.Image != "" {
+			config.Options.KwokControllerImage = conf.Image
+		}
+		if conf.Binary != "" {
+			config.Options.KwokControllerBinary = conf.Binary
+		}
+	}
+
+	err = c.SetConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	return c.Save(ctx)
+}
+
+// CheckUpgradeComponent returns an error if the component can not be upgraded.
+func CheckUpgradeComponent(name string) error {
+	if !slices.Contains(upgradableComponents, name) {
+		return fmt.Errorf("%w: %s", ErrComponentUpgradeNotSupported, name)
+	}
+	return nil
+}
+
+var upgradableComponents = []string{
+	consts.ComponentKwokController,
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+// usageSampleInterval is the interval between the two samples of the CPU time of the processes.
+const usageSampleInterval = time.Second
+
+// InspectProcessesUsage returns the usage of the processes by the name of the components,
+// the CPU usage is the average over a second.
+func InspectProcessesUsage(ctx context.Context, pids map[string]int) ([]ComponentUsage, error) {
+	before := map[string]time.Duration{}
+	for name, pid := range pids {
+		cpu, _, err := exec.ProcessUsage(pid)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", name, err)
+		}
+		before[name] = cpu
+	}
+	start := time.Now()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(usageSampleInterval):
+	}
+
+	elapsed := time.Since(start)
+	usages := make([]ComponentUsage, 0, len(pids))
+	for name, pid := range pids {
+		cpu, memory, err := exec.ProcessUsage(pid)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", name, err)
+		}
+		usages = append(usages, ComponentUsage{
+			Name:        name,
+			CPUPercent:  float64(cpu-before[name]) / float64(elapsed) * 100,
+			MemoryBytes: memory,
+		})
+	}
+	sortUsages(usages)
+	return usages, nil
+}
+
+// InspectContainersUsage returns the usage of the containers by the name of the components,
+// containers is the map of the name of the container to the name of the component.
+func (c *Cluster) InspectContainersUsage(ctx context.Context, command string, containers map[string]string) ([]ComponentUsage, error) {
+	if len(containers) == 0 {
+		return nil, nil
+	}
+	args := []string{"stats", "--no-stream", "--format={{ json . }}"}
+	for container := range containers {
+		args = append(args, container)
+	}
+	buf := bytes.NewBuffer(nil)
+	err := c.Exec(exec.WithWriteTo(ctx, buf), command, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := parseContainersStats(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	usages := make([]ComponentUsage, 0, len(stats))
+	for _, stat := range stats {
+		name, ok := containers[stat.Name]
+		if !ok {
+			continue
+		}
+		stat.ComponentUsage.Name = name
+		usages = append(usages, stat.ComponentUsage)
+	}
+	sortUsages(usages)
+	return usages, nil
+}
+
+type containerStats struct {
+	ComponentUsage
+	// Name is the name of the container
+	Name string
+}
+
+// parseContainersStats parses the lines of the stats of docker, podman or nerdctl.
+func parseContainersStats(raw []byte) ([]containerStats, error) {
+	var stats []containerStats
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var s struct {
+			Name     string `json:"Name"`
+			CPUPerc  string `json:"CPUPerc"`
+			MemUsage string `json:"MemUsage"`
+		}
+		err := json.Unmarshal(line, &s)
+		if err != nil {
+			return nil, fmt.Errorf("parse stats %q: %w", line, err)
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s.CPUPerc), "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse cpu %q: %w", s.CPUPerc, err)
+		}
+		// The usage is followed by the limit, e.g. "12.5MiB / 7.7GiB"
+		usage, _, _ := strings.Cut(s.MemUsage, "/")
+		memory, err := parseBytes(strings.TrimSpace(usage))
+		if err != nil {
+			return nil, fmt.Errorf("parse memory %q: %w", s.MemUsage, err)
+		}
+		stats = append(stats, containerStats{
+			ComponentUsage: ComponentUsage{
+				CPUPercent:  cpu,
+				MemoryBytes: memory,
+			},
+			Name: s.Name,
+		})
+	}
+	return stats, scanner.Err()
+}
+
+var byteUnits = map[string]float64{
+	"B":   1,
+	"kB":  1e3,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+	"TiB": 1 << 40,
+}
+
+// parseBytes parses the human readable size in the decimal or the binary units, e.g. 12.5MiB or 13.1MB.
+func parseBytes(s string) (uint64, error) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, err
+	}
+	unit, ok := byteUnits[strings.TrimSpace(s[i:])]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit of size %q", s)
+	}
+	return uint64(value * unit), nil
+}
+
+func sortUsages(usages []ComponentUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+}
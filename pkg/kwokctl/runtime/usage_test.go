@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseContainersStats(t *testing.T) {
+	raw := []byte(`{"BlockIO":"0B / 0B","CPUPerc":"12.50%","Container":"a1","ID":"a1","MemPerc":"1.00%","MemUsage":"64MiB / 7.7GiB","Name":"kwok-kwok-etcd","NetIO":"0B / 0B","PIDs":"12"}
+{"CPUPerc":"0.3%","MemUsage":"1.5GB / 8GB","Name":"kwok-kwok-kube-apiserver"}
+`)
+	got, err := parseContainersStats(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []containerStats{
+		{Name: "kwok-kwok-etcd", ComponentUsage: ComponentUsage{CPUPercent: 12.5, MemoryBytes: 64 << 20}},
+		{Name: "kwok-kwok-kube-apiserver", ComponentUsage: ComponentUsage{CPUPercent: 0.3, MemoryBytes: 1.5e9}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseContainersStats() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]uint64{
+		"0B":      0,
+		"512KiB":  512 << 10,
+		"13.1MB":  13100000,
+		"1.5GiB":  3 << 29,
+		"100.2kB": 100200,
+	}
+	for s, want := range tests {
+		got, err := parseBytes(s)
+		if err != nil {
+			t.Errorf("parseBytes(%q) error %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseBytes(%q) = %d, want %d", s, got, want)
+		}
+	}
+	for _, s := range []string{"", "MiB", "12XB"} {
+		if _, err := parseBytes(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
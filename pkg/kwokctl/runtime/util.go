@@ -18,15 +18,12 @@ package runtime
 
 import (
 	"context"
-	"fmt"
-	"sort"
 
 	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
-	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/components"
-	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
@@ -94,11 +91,41 @@ func (c *Cluster) ForeachComponents(ctx context.Context, reverse, order bool, fu
 	return nil
 }
 
-// GetComponentPatches returns the patches for a component.
+// GetComponentPatches returns the patches for a component,
+// all the patches matching the component are merged in their order.
 func GetComponentPatches(conf *internalversion.KwokctlConfiguration, componentName string) internalversion.ComponentPatches {
-	componentPatches, _ := slices.Find(conf.ComponentsPatches, func(patch internalversion.ComponentPatches) bool {
-		return patch.Name == componentName
-	})
+	componentPatches := internalversion.ComponentPatches{
+		Name: componentName,
+	}
+	for _, patch := range conf.ComponentsPatches {
+		if !MatchComponentPatch(patch.Name, componentName) {
+			continue
+		}
+		componentPatches.ExtraArgs = append(componentPatches.ExtraArgs, patch.ExtraArgs...)
+		componentPatches.ExtraVolumes = append(componentPatches.ExtraVolumes, patch.ExtraVolumes...)
+		componentPatches.RemoveVolumes = append(componentPatches.RemoveVolumes, patch.RemoveVolumes...)
+		componentPatches.ExtraEnvs = append(componentPatches.ExtraEnvs, patch.ExtraEnvs...)
+		componentPatches.ExtraEnvFiles = append(componentPatches.ExtraEnvFiles, patch.ExtraEnvFiles...)
+		componentPatches.ExtraSecrets = append(componentPatches.ExtraSecrets, patch.ExtraSecrets...)
+		if patch.StartPolicy != "" {
+			componentPatches.StartPolicy = patch.StartPolicy
+		}
+		if patch.RestartPolicy != "" {
+			componentPatches.RestartPolicy = patch.RestartPolicy
+		}
+		if patch.ReadinessTimeoutMilliseconds != 0 {
+			componentPatches.ReadinessTimeoutMilliseconds = patch.ReadinessTimeoutMilliseconds
+		}
+		if patch.ReadinessRetries != 0 {
+			componentPatches.ReadinessRetries = patch.ReadinessRetries
+		}
+		if patch.CPULimit != "" {
+			componentPatches.CPULimit = patch.CPULimit
+		}
+		if patch.MemoryLimit != "" {
+			componentPatches.MemoryLimit = patch.MemoryLimit
+		}
+	}
 	return componentPatches
 }
 
@@ -110,22 +137,72 @@ func ApplyComponentPatches(component *internalversion.Component, patches []inter
 }
 
 func applyComponentPatch(component *internalversion.Component, patch internalversion.ComponentPatches) {
-	if patch.Name != component.Name {
+	if !MatchComponentPatch(patch.Name, component.Name) {
 		return
 	}
 
-	component.Volumes = append(component.Volumes, patch.ExtraVolumes...)
+	component.Volumes = applyComponentPatchVolumes(component.Volumes, patch.RemoveVolumes, patch.ExtraVolumes)
 	component.Envs = append(component.Envs, patch.ExtraEnvs...)
+	component.EnvFiles = append(component.EnvFiles, patch.ExtraEnvFiles...)
+	if patch.StartPolicy != "" {
+		component.StartPolicy = patch.StartPolicy
+	}
+	if patch.RestartPolicy != "" {
+		component.RestartPolicy = patch.RestartPolicy
+	}
+	if patch.ReadinessTimeoutMilliseconds != 0 {
+		component.ReadinessTimeoutMilliseconds = patch.ReadinessTimeoutMilliseconds
+	}
+	if patch.ReadinessRetries != 0 {
+		component.ReadinessRetries = patch.ReadinessRetries
+	}
+	if patch.CPULimit != "" {
+		component.CPULimit = patch.CPULimit
+	}
+	if patch.MemoryLimit != "" {
+		component.MemoryLimit = patch.MemoryLimit
+	}
+
+	component.Args = applyComponentPatchArgs(component.Args, patch.ExtraArgs)
+}
+
+// IsLazyComponent returns whether the component is started only when it is first accessed.
+func IsLazyComponent(component internalversion.Component) bool {
+	return component.StartPolicy == internalversion.StartPolicyLazy
+}
+
+// StartLazyComponent starts the component if it is lazy and not running yet.
+func StartLazyComponent(ctx context.Context, rt Runtime, name string) error {
+	component, err := rt.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !IsLazyComponent(component) {
+		return nil
+	}
 
-	for _, a := range patch.ExtraArgs {
-		component.Args = append(component.Args, fmt.Sprintf("--%s=%s", a.Key, a.Value))
+	status, err := rt.InspectComponent(ctx, name)
+	if err != nil {
+		return err
 	}
+	if status == ComponentStatusRunning || status == ComponentStatusReady {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Starting lazy component", "component", name)
+	return rt.StartComponent(ctx, name)
 }
 
 // ExpandVolumesHostPaths expands relative paths specified in volumes to absolute paths
 func ExpandVolumesHostPaths(volumes []internalversion.Volume) ([]internalversion.Volume, error) {
 	result := make([]internalversion.Volume, 0, len(volumes))
 	for _, v := range volumes {
+		// The host path is inherited from the overridden volume if empty
+		if v.HostPath == "" {
+			result = append(result, v)
+			continue
+		}
 		hostPath, err := path.Expand(v.HostPath)
 		if err != nil {
 			return nil, err
@@ -136,51 +213,11 @@ func ExpandVolumesHostPaths(volumes []internalversion.Volume) ([]internalversion
 	return result, nil
 }
 
-// GetLogVolumes returns volumes for Logs and ClusterLogs resource.
-func GetLogVolumes(ctx context.Context) []internalversion.Volume {
-	logs := config.FilterWithTypeFromContext[*internalversion.Logs](ctx)
-	clusterLogs := config.FilterWithTypeFromContext[*internalversion.ClusterLogs](ctx)
-	attaches := config.FilterWithTypeFromContext[*internalversion.Attach](ctx)
-	clusterAttaches := config.FilterWithTypeFromContext[*internalversion.ClusterAttach](ctx)
-
-	// Mount log dirs
-	mountDirs := map[string]struct{}{}
-	for _, log := range logs {
-		for _, l := range log.Spec.Logs {
-			mountDirs[path.Dir(l.LogsFile)] = struct{}{}
-		}
-	}
-
-	for _, cl := range clusterLogs {
-		for _, l := range cl.Spec.Logs {
-			mountDirs[path.Dir(l.LogsFile)] = struct{}{}
-		}
-	}
-
-	for _, attach := range attaches {
-		for _, a := range attach.Spec.Attaches {
-			mountDirs[path.Dir(a.LogsFile)] = struct{}{}
-		}
-	}
-
-	for _, ca := range clusterAttaches {
-		for _, a := range ca.Spec.Attaches {
-			mountDirs[path.Dir(a.LogsFile)] = struct{}{}
-		}
-	}
-
-	logsDirs := maps.Keys(mountDirs)
-	sort.Strings(logsDirs)
-
-	volumes := make([]internalversion.Volume, 0, len(logsDirs))
-	for i, logsDir := range logsDirs {
-		volumes = append(volumes, internalversion.Volume{
-			Name:      fmt.Sprintf("log-volume-%d", i),
-			HostPath:  logsDir,
-			MountPath: logsDir,
-			PathType:  internalversion.HostPathDirectoryOrCreate,
-			ReadOnly:  true,
-		})
+// TLSServerName returns the name to verify the certificate of the apiserver with in the kubeconfig,
+// it is the first of the DNS names, or empty if there are none.
+func TLSServerName(conf *internalversion.KwokctlConfigurationOptions) string {
+	if len(conf.DNSNames) == 0 {
+		return ""
 	}
-	return volumes
+	return conf.DNSNames[0]
 }
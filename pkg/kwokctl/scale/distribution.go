@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Distribution is a weighted distribution of the values of a label or an annotation.
+type Distribution struct {
+	Key    string
+	Values []WeightedValue
+}
+
+// WeightedValue is a value with its weight in a distribution.
+type WeightedValue struct {
+	Value  string
+	Weight int
+}
+
+// ParseDistribution parses the distribution in the format of key=value:weight,value:weight,
+// e.g. team=a:50,b:30,c:20, the weight defaults to 1.
+func ParseDistribution(s string) (Distribution, error) {
+	key, values, ok := strings.Cut(s, "=")
+	if !ok || key == "" || values == "" {
+		return Distribution{}, fmt.Errorf("invalid distribution %q, expected key=value:weight,value:weight", s)
+	}
+
+	d := Distribution{
+		Key: key,
+	}
+	for _, item := range strings.Split(values, ",") {
+		value, weight, ok := strings.Cut(item, ":")
+		w := 1
+		if ok {
+			var err error
+			w, err = strconv.Atoi(strings.TrimSuffix(weight, "%"))
+			if err != nil {
+				return Distribution{}, fmt.Errorf("invalid weight %q of distribution %q: %w", weight, s, err)
+			}
+			if w < 0 {
+				return Distribution{}, fmt.Errorf("invalid weight %q of distribution %q: must not be negative", weight, s)
+			}
+		}
+		d.Values = append(d.Values, WeightedValue{
+			Value:  value,
+			Weight: w,
+		})
+	}
+	if d.total() == 0 {
+		return Distribution{}, fmt.Errorf("invalid distribution %q: the total weight must be greater than 0", s)
+	}
+	return d, nil
+}
+
+// ParseDistributions parses the distributions.
+func ParseDistributions(ss []string) ([]Distribution, error) {
+	out := make([]Distribution, 0, len(ss))
+	for _, s := range ss {
+		d, err := ParseDistribution(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (d Distribution) total() int {
+	total := 0
+	for _, v := range d.Values {
+		total += v.Weight
+	}
+	return total
+}
+
+// Pick picks a value for the object with the name,
+// the same value is picked for the same name so that the re-runs generate the same objects.
+func (d Distribution) Pick(name string) string {
+	n := randomOf(name+"/"+d.Key, d.total())
+	for _, v := range d.Values {
+		if n < v.Weight {
+			return v.Value
+		}
+		n -= v.Weight
+	}
+	return d.Values[len(d.Values)-1].Value
+}
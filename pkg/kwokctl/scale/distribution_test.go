@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Distribution
+		wantErr bool
+	}{
+		{
+			input: "team=a:50,b:30%,c:20",
+			want: Distribution{
+				Key: "team",
+				Values: []WeightedValue{
+					{Value: "a", Weight: 50},
+					{Value: "b", Weight: 30},
+					{Value: "c", Weight: 20},
+				},
+			},
+		},
+		{
+			input: "tier=web,batch",
+			want: Distribution{
+				Key: "tier",
+				Values: []WeightedValue{
+					{Value: "web", Weight: 1},
+					{Value: "batch", Weight: 1},
+				},
+			},
+		},
+		{
+			input:   "team",
+			wantErr: true,
+		},
+		{
+			input:   "team=a:x",
+			wantErr: true,
+		},
+		{
+			input:   "team=a:0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDistribution(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDistribution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseDistribution() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDistributionPick(t *testing.T) {
+	d, err := ParseDistribution("team=a:50,b:30,c:20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const total = 10000
+	counts := map[string]int{}
+	for i := 0; i < total; i++ {
+		name := fmt.Sprintf("node-%06d", i)
+		value := d.Pick(name)
+		if again := d.Pick(name); again != value {
+			t.Fatalf("Pick(%q) is not deterministic, %q != %q", name, value, again)
+		}
+		counts[value]++
+	}
+
+	for _, v := range d.Values {
+		want := total * v.Weight / 100
+		got := counts[v.Value]
+		if got < want*9/10 || got > want*11/10 {
+			t.Errorf("value %q is picked %d times, want about %d", v.Value, got, want)
+		}
+	}
+}
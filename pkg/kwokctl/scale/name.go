@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+)
+
+var namePatternRegexp = regexp.MustCompile(`\{(\w+)(?::([^}]*))?\}`)
+
+// namePattern is a pattern to generate the names of the objects, e.g. node-{zone}-{index:05d}.
+// The placeholders are {name} for the name, {index} for the index and {zone} for the zone,
+// the format of the placeholder follows the verbs of fmt.
+type namePattern struct {
+	pattern string
+}
+
+func newNamePattern(pattern string) (*namePattern, error) {
+	for _, match := range namePatternRegexp.FindAllStringSubmatch(pattern, -1) {
+		switch match[1] {
+		case "name", "index", "zone":
+		default:
+			return nil, fmt.Errorf("unknown placeholder %q in name pattern %q", match[0], pattern)
+		}
+	}
+	return &namePattern{
+		pattern: pattern,
+	}, nil
+}
+
+// HasIndex returns true if the pattern contains the index.
+func (p *namePattern) HasIndex() bool {
+	for _, match := range namePatternRegexp.FindAllStringSubmatch(p.pattern, -1) {
+		if match[1] == "index" {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the object.
+func (p *namePattern) Name(name string, index int, zone string) string {
+	return namePatternRegexp.ReplaceAllStringFunc(p.pattern, func(s string) string {
+		match := namePatternRegexp.FindStringSubmatch(s)
+		verb := match[2]
+		var value any
+		switch match[1] {
+		case "name":
+			value = name
+			if verb == "" {
+				verb = "s"
+			}
+		case "index":
+			value = index
+			if verb == "" {
+				verb = "d"
+			}
+		case "zone":
+			value = zone
+			if verb == "" {
+				verb = "s"
+			}
+		}
+		return fmt.Sprintf("%"+verb, value)
+	})
+}
+
+// zoneOf returns the zone of the object with the index, the zones are assigned in round-robin.
+func zoneOf(zones []string, index int) string {
+	if len(zones) == 0 {
+		return ""
+	}
+	return zones[index%len(zones)]
+}
+
+// randomOf returns a deterministic random number in [0, n) for the object with the name,
+// so that the re-runs generate the same objects.
+func randomOf(name string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int(h.Sum64() % uint64(n))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"testing"
+)
+
+func TestNamePattern(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		name      string
+		index     int
+		zone      string
+		want      string
+		wantIndex bool
+		wantErr   bool
+	}{
+		{
+			pattern:   "node-{zone}-{index:05d}",
+			name:      "node",
+			index:     12,
+			zone:      "us-east-1a",
+			want:      "node-us-east-1a-00012",
+			wantIndex: true,
+		},
+		{
+			pattern:   "{name}-{index}",
+			name:      "pod",
+			index:     3,
+			want:      "pod-3",
+			wantIndex: true,
+		},
+		{
+			pattern: "{name}-fixed",
+			name:    "pod",
+			want:    "pod-fixed",
+		},
+		{
+			pattern: "{name}-{unknown}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p, err := newNamePattern(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newNamePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := p.Name(tt.name, tt.index, tt.zone); got != tt.want {
+				t.Errorf("Name() = %q, want %q", got, tt.want)
+			}
+			if got := p.HasIndex(); got != tt.wantIndex {
+				t.Errorf("HasIndex() = %v, want %v", got, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestZoneOf(t *testing.T) {
+	zones := []string{"a", "b", "c"}
+	for i, want := range []string{"a", "b", "c", "a"} {
+		if got := zoneOf(zones, i); got != want {
+			t.Errorf("zoneOf(%d) = %q, want %q", i, got, want)
+		}
+	}
+	if got := zoneOf(nil, 1); got != "" {
+		t.Errorf("zoneOf() = %q, want empty", got)
+	}
+}
+
+func TestRandomOf(t *testing.T) {
+	for _, name := range []string{"node-000000", "node-000001", "pod-a"} {
+		got := randomOf(name, 10)
+		if got < 0 || got >= 10 {
+			t.Errorf("randomOf(%q) = %d, out of range", name, got)
+		}
+		if again := randomOf(name, 10); again != got {
+			t.Errorf("randomOf(%q) is not deterministic, %d != %d", name, got, again)
+		}
+	}
+	if got := randomOf("node", 0); got != 0 {
+		t.Errorf("randomOf() = %d, want 0", got)
+	}
+}
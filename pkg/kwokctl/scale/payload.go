@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// payloadAnnotationKey is the annotation used to pad the objects to the object size.
+	payloadAnnotationKey = "kwok.x-k8s.io/kwokctl-scale-payload"
+
+	// MaxObjectSize is the max size of an object padded by the payload annotation,
+	// which is bounded by the total size of annotations validated by the kube-apiserver.
+	// https://github.com/kubernetes/kubernetes/blob/v1.30.2/staging/src/k8s.io/apimachinery/pkg/api/validation/objectmeta.go#L36
+	MaxObjectSize = 256 * 1024
+
+	// ListPageSize is the page size used by the client-go pager when no limit is set,
+	// which is a reference to tune the replicas for stressing the pagination.
+	ListPageSize = 500
+)
+
+// padObject pads the object with the payload annotation until its JSON encoding reaches the size.
+// It does nothing if the object is already larger than the size.
+func padObject(u *unstructured.Unstructured, size int) error {
+	if size <= 0 {
+		return nil
+	}
+	if size > MaxObjectSize {
+		return fmt.Errorf("object size %d exceeds the max object size %d", size, MaxObjectSize)
+	}
+
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[payloadAnnotationKey] = ""
+	u.SetAnnotations(annotations)
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	// Each character of the payload adds exactly one byte to the JSON encoding.
+	pad := size - len(data)
+	if pad <= 0 {
+		return nil
+	}
+	annotations[payloadAnnotationKey] = strings.Repeat("x", pad)
+	u.SetAnnotations(annotations)
+	return nil
+}
+
+// CheckTotalSize returns an error if the total size of the objects exceeds the max total size.
+func CheckTotalSize(replicas uint64, objectSize, maxTotalSize int64) error {
+	if objectSize <= 0 || maxTotalSize <= 0 {
+		return nil
+	}
+	if objectSize > MaxObjectSize {
+		return fmt.Errorf("object size %d exceeds the max object size %d", objectSize, MaxObjectSize)
+	}
+	total := replicas * uint64(objectSize)
+	if total/uint64(objectSize) != replicas || total > uint64(maxTotalSize) {
+		return fmt.Errorf("total size of %d objects of %d bytes exceeds the max total size %d", replicas, objectSize, maxTotalSize)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPadObject(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{
+			name: "padded",
+			size: 4096,
+			want: 4096,
+		},
+		{
+			name: "max",
+			size: MaxObjectSize,
+			want: MaxObjectSize,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion("v1")
+			u.SetKind("ConfigMap")
+			u.SetName("test")
+			u.SetAnnotations(map[string]string{"foo": "bar"})
+
+			err := padObject(u, tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != tt.want {
+				t.Errorf("padObject() size = %d, want %d", len(data), tt.want)
+			}
+			if u.GetAnnotations()["foo"] != "bar" {
+				t.Errorf("padObject() dropped the annotations")
+			}
+		})
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetName("test")
+	err := padObject(u, MaxObjectSize+1)
+	if err == nil {
+		t.Errorf("padObject() expected error for the size over the max")
+	}
+}
+
+func TestCheckTotalSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		replicas     uint64
+		objectSize   int64
+		maxTotalSize int64
+		wantErr      bool
+	}{
+		{
+			name:         "no object size",
+			replicas:     1000000,
+			maxTotalSize: 1024,
+		},
+		{
+			name:         "within",
+			replicas:     1000,
+			objectSize:   1024,
+			maxTotalSize: 1024 * 1024,
+		},
+		{
+			name:         "exceeded",
+			replicas:     1025,
+			objectSize:   1024,
+			maxTotalSize: 1024 * 1024,
+			wantErr:      true,
+		},
+		{
+			name:         "object too large",
+			replicas:     1,
+			objectSize:   MaxObjectSize + 1,
+			maxTotalSize: 1024 * 1024,
+			wantErr:      true,
+		},
+		{
+			name:         "overflow",
+			replicas:     1 << 60,
+			objectSize:   1 << 10,
+			maxTotalSize: 1 << 62,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTotalSize(tt.replicas, tt.objectSize, tt.maxTotalSize)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckTotalSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
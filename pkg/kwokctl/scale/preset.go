@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// Preset is a named set of parameters for a resource.
+type Preset struct {
+	// Name is the name of the preset.
+	Name string `json:"name"`
+	// Description is the description of the preset.
+	Description string `json:"description,omitempty"`
+	// Parameters is the parameters to be merged into the resource parameters.
+	Parameters json.RawMessage `json:"parameters"`
+	// Resources is the resources created for each replica, only for the workload.
+	Resources []PresetResource `json:"resources,omitempty"`
+}
+
+// PresetResource is a resource of a preset.
+type PresetResource struct {
+	// Template is the template of the resource, rendered with the parameters of the preset.
+	Template string `json:"template"`
+}
+
+// LoadPresets loads the presets from the data.
+func LoadPresets(data string) ([]Preset, error) {
+	var presets []Preset
+	err := yaml.Unmarshal([]byte(data), &presets)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal presets error: %w", err)
+	}
+	return presets, nil
+}
+
+// PresetKinds is the kinds of resources that have builtin presets.
+var PresetKinds = []string{"node", "pod", "workload"}
+
+// BuiltinPresets returns the builtin presets of the kind of resource.
+func BuiltinPresets(kind string) ([]Preset, error) {
+	switch kind {
+	case "node":
+		return LoadPresets(resource.NodePresets)
+	case "pod":
+		return LoadPresets(resource.PodPresets)
+	case "workload":
+		return LoadPresets(resource.WorkloadPresets)
+	default:
+		return nil, fmt.Errorf("resource %s does not support presets", kind)
+	}
+}
+
+// FindPreset finds the preset with the name.
+func FindPreset(presets []Preset, name string) (Preset, error) {
+	preset, ok := slices.Find(presets, func(preset Preset) bool {
+		return preset.Name == name
+	})
+	if !ok {
+		return Preset{}, fmt.Errorf("preset %q is not exists", name)
+	}
+	return preset, nil
+}
+
+// MergeParameters merges the preset parameters into the raw parameters.
+// The maps are merged recursively, and the other values in the preset override the raw values.
+func MergeParameters(raw json.RawMessage, preset json.RawMessage) (json.RawMessage, error) {
+	var base any
+	err := json.Unmarshal(raw, &base)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal params error: %w", err)
+	}
+
+	var overlay any
+	err = json.Unmarshal(preset, &overlay)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal preset params error: %w", err)
+	}
+
+	return json.Marshal(mergeValue(base, overlay))
+}
+
+func mergeValue(base, overlay any) any {
+	baseMap, ok := base.(map[string]any)
+	if !ok {
+		return overlay
+	}
+	overlayMap, ok := overlay.(map[string]any)
+	if !ok {
+		return overlay
+	}
+
+	for key, value := range overlayMap {
+		baseMap[key] = mergeValue(baseMap[key], value)
+	}
+	return baseMap
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+func TestMergeParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		preset string
+		want   string
+	}{
+		{
+			name:   "override",
+			raw:    `{"a":1,"b":"x"}`,
+			preset: `{"a":2}`,
+			want:   `{"a":2,"b":"x"}`,
+		},
+		{
+			name:   "nested",
+			raw:    `{"allocatable":{"cpu":32,"memory":"256Gi"},"labels":{}}`,
+			preset: `{"allocatable":{"cpu":"4"},"labels":{"foo":"bar"}}`,
+			want:   `{"allocatable":{"cpu":"4","memory":"256Gi"},"labels":{"foo":"bar"}}`,
+		},
+		{
+			name:   "replace list",
+			raw:    `{"containers":[{"name":"a"},{"name":"b"}]}`,
+			preset: `{"containers":[{"name":"c"}]}`,
+			want:   `{"containers":[{"name":"c"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeParameters(json.RawMessage(tt.raw), json.RawMessage(tt.preset))
+			if err != nil {
+				t.Fatalf("MergeParameters() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("MergeParameters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodePresets(t *testing.T) {
+	presets, err := BuiltinPresets("node")
+	if err != nil {
+		t.Fatalf("BuiltinPresets() error = %v", err)
+	}
+	if len(presets) == 0 {
+		t.Fatal("no presets found")
+	}
+
+	krc, err := config.UnmarshalWithType[*internalversion.KwokctlResource](resource.DefaultNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Name":    func() string { return "node-000000" },
+		"Index":   func() int { return 0 },
+		"Zone":    func() string { return "" },
+		"AddCIDR": utilsnet.AddCIDR,
+	})
+
+	names := map[string]struct{}{}
+	for _, preset := range presets {
+		t.Run(preset.Name, func(t *testing.T) {
+			if _, ok := names[preset.Name]; ok {
+				t.Fatalf("duplicate preset %q", preset.Name)
+			}
+			names[preset.Name] = struct{}{}
+
+			raw, err := MergeParameters(krc.Parameters, preset.Parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var param any
+			err = json.Unmarshal(raw, &param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := renderer.ToJSON(krc.Template, param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var node corev1.Node
+			err = json.Unmarshal(data, &node)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if node.Labels[corev1.LabelInstanceTypeStable] == "" {
+				t.Errorf("missing label %s", corev1.LabelInstanceTypeStable)
+			}
+			if node.Annotations["kwok.x-k8s.io/hourly-price"] == "" {
+				t.Errorf("missing annotation %s", "kwok.x-k8s.io/hourly-price")
+			}
+			if node.Status.Allocatable.Cpu().Cmp(*node.Status.Capacity.Cpu()) > 0 {
+				t.Errorf("allocatable cpu %s is greater than capacity %s", node.Status.Allocatable.Cpu(), node.Status.Capacity.Cpu())
+			}
+			if node.Status.Allocatable.Memory().Cmp(*node.Status.Capacity.Memory()) > 0 {
+				t.Errorf("allocatable memory %s is greater than capacity %s", node.Status.Allocatable.Memory(), node.Status.Capacity.Memory())
+			}
+			if _, ok := node.Status.Allocatable["nvidia.com/gpu"]; ok && len(node.Spec.Taints) == 0 {
+				t.Errorf("node with gpu should be tainted")
+			}
+		})
+	}
+}
+
+func TestPodPresets(t *testing.T) {
+	presets, err := BuiltinPresets("pod")
+	if err != nil {
+		t.Fatalf("BuiltinPresets() error = %v", err)
+	}
+	if len(presets) == 0 {
+		t.Fatal("no presets found")
+	}
+
+	krc, err := config.UnmarshalWithType[*internalversion.KwokctlResource](resource.DefaultPod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Name":      func() string { return "pod-000000" },
+		"Namespace": func() string { return "" },
+		"Index":     func() int { return 0 },
+	})
+
+	names := map[string]struct{}{}
+	for _, preset := range presets {
+		t.Run(preset.Name, func(t *testing.T) {
+			if _, ok := names[preset.Name]; ok {
+				t.Fatalf("duplicate preset %q", preset.Name)
+			}
+			names[preset.Name] = struct{}{}
+
+			raw, err := MergeParameters(krc.Parameters, preset.Parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var param any
+			err = json.Unmarshal(raw, &param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := renderer.ToJSON(krc.Template, param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var pod corev1.Pod
+			err = json.Unmarshal(data, &pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(pod.Spec.Containers) == 0 {
+				t.Fatal("no containers")
+			}
+			for _, container := range pod.Spec.Containers {
+				if container.Resources.Requests.Cpu().IsZero() {
+					t.Errorf("container %s does not request cpu", container.Name)
+				}
+			}
+			if _, ok := pod.Spec.Containers[0].Resources.Requests["nvidia.com/gpu"]; ok && len(pod.Spec.Tolerations) == 0 {
+				t.Errorf("pod with gpu should tolerate the gpu taint")
+			}
+		})
+	}
+}
+
+func TestBuiltinPresetsUnsupported(t *testing.T) {
+	_, err := BuiltinPresets("deployment")
+	if err == nil {
+		t.Fatal("expected error for unsupported resource")
+	}
+}
+
+func TestWorkloadPresets(t *testing.T) {
+	presets, err := BuiltinPresets("workload")
+	if err != nil {
+		t.Fatalf("BuiltinPresets() error = %v", err)
+	}
+	if len(presets) == 0 {
+		t.Fatal("no presets found")
+	}
+
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Name":      func() string { return "workload-000000" },
+		"Namespace": func() string { return "" },
+		"Index":     func() int { return 0 },
+	})
+
+	decoder := scheme.Codecs.UniversalDeserializer()
+	for _, preset := range presets {
+		t.Run(preset.Name, func(t *testing.T) {
+			if len(preset.Resources) == 0 {
+				t.Fatal("no resources")
+			}
+
+			var param any
+			err = json.Unmarshal(preset.Parameters, &param)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kinds := map[string]struct{}{}
+			for _, r := range preset.Resources {
+				data, err := renderer.ToJSON(r.Template, param)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				obj, gvk, err := decoder.Decode(data, nil, nil)
+				if err != nil {
+					t.Fatalf("failed to decode %s: %v", data, err)
+				}
+				if _, ok := kinds[gvk.Kind]; ok {
+					t.Errorf("duplicate kind %s, the resources of a replica share the same name", gvk.Kind)
+				}
+				kinds[gvk.Kind] = struct{}{}
+
+				meta, ok := obj.(metav1.Object)
+				if !ok {
+					t.Fatalf("%T is not an object", obj)
+				}
+				if meta.GetName() != "workload-000000" {
+					t.Errorf("unexpected name %q", meta.GetName())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// Record is the intended population of a resource recorded in the cluster,
+// which is used to resume an interrupted scale.
+type Record struct {
+	Kind         string   `json:"kind"`
+	Name         string   `json:"name"`
+	Namespace    string   `json:"namespace,omitempty"`
+	Replicas     uint64   `json:"replicas"`
+	SerialLength int      `json:"serialLength,omitempty"`
+	Params       []string `json:"params,omitempty"`
+	Preset       string   `json:"preset,omitempty"`
+	NamePattern  string   `json:"namePattern,omitempty"`
+	Zones        []string `json:"zones,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+	Annotations  []string `json:"annotations,omitempty"`
+	ObjectSize   string   `json:"objectSize,omitempty"`
+	// ScaleDownStrategy is the strategy to pick the objects to delete.
+	ScaleDownStrategy string `json:"scaleDownStrategy,omitempty"`
+	// Completed is true if all of the objects have been created or deleted.
+	Completed bool `json:"completed"`
+	// Interrupted is true if the scale is interrupted before it is completed.
+	Interrupted bool `json:"interrupted,omitempty"`
+}
+
+// RecordPath returns the path of the record of the resource in the directory.
+func RecordPath(dir, kind, name string) string {
+	replacer := strings.NewReplacer("/", "_", `\`, "_")
+	return path.Join(dir, replacer.Replace(kind)+"."+replacer.Replace(name)+".json")
+}
+
+// LoadRecord loads the record from the path.
+func LoadRecord(p string) (*Record, error) {
+	data, err := file.Read(p)
+	if err != nil {
+		return nil, err
+	}
+	var record Record
+	err = json.Unmarshal(data, &record)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal scale record %s: %w", p, err)
+	}
+	return &record, nil
+}
+
+// SaveRecord saves the record to the path.
+func SaveRecord(p string, record *Record) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = file.MkdirAll(path.Dir(p))
+	if err != nil {
+		return err
+	}
+	return file.Write(p, data)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	p := RecordPath(dir, "workload", "eks/m5.xlarge")
+	if want := filepath.Join(dir, "workload.eks_m5.xlarge.json"); p != want {
+		t.Errorf("RecordPath() = %q, want %q", p, want)
+	}
+
+	record := &Record{
+		Kind:        "node",
+		Name:        "node",
+		Replicas:    1000,
+		Params:      []string{".allocatable.cpu=\"8\""},
+		NamePattern: "node-{zone}-{index:05d}",
+		Zones:       []string{"a", "b"},
+	}
+	err := SaveRecord(p, record)
+	if err != nil {
+		t.Fatalf("SaveRecord() error = %v", err)
+	}
+	got, err := LoadRecord(p)
+	if err != nil {
+		t.Fatalf("LoadRecord() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, record) {
+		t.Errorf("LoadRecord() = %+v, want %+v", got, record)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+func TestDefaultWorkloadResources(t *testing.T) {
+	params := []string{
+		`.containers[0].resources = {"requests": {"cpu": "100m"}, "limits": {"memory": "1Gi"}}`,
+		`.tolerations = [{"key": "foo", "operator": "Exists"}]`,
+		`.topologySpread = [{"topologyKey": "kubernetes.io/hostname"}]`,
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		params   []string
+		podSpec  func(obj any) *corev1.PodSpec
+		validate func(t *testing.T, obj any)
+	}{
+		{
+			name:   "deployment",
+			data:   resource.DefaultDeployment,
+			params: params,
+			podSpec: func(obj any) *corev1.PodSpec {
+				return &obj.(*appsv1.Deployment).Spec.Template.Spec
+			},
+		},
+		{
+			name:   "statefulset",
+			data:   resource.DefaultStatefulSet,
+			params: params,
+			podSpec: func(obj any) *corev1.PodSpec {
+				return &obj.(*appsv1.StatefulSet).Spec.Template.Spec
+			},
+		},
+		{
+			name:   "job",
+			data:   resource.DefaultJob,
+			params: params,
+			podSpec: func(obj any) *corev1.PodSpec {
+				return &obj.(*batchv1.Job).Spec.Template.Spec
+			},
+		},
+		{
+			name:   "service",
+			data:   resource.DefaultService,
+			params: []string{`.selector = {"app": "foo"}`},
+			validate: func(t *testing.T, obj any) {
+				svc := obj.(*corev1.Service)
+				if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != 80 {
+					t.Errorf("unexpected ports %v", svc.Spec.Ports)
+				}
+				if svc.Spec.Selector["app"] != "foo" {
+					t.Errorf("unexpected selector %v", svc.Spec.Selector)
+				}
+			},
+		},
+	}
+
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Name":      func() string { return "foo-000000" },
+		"Namespace": func() string { return "" },
+		"Index":     func() int { return 0 },
+	})
+	decoder := scheme.Codecs.UniversalDeserializer()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			krc, err := config.UnmarshalWithType[*internalversion.KwokctlResource](tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, params := range [][]string{nil, tt.params} {
+				param, err := NewParameters(context.Background(), krc.Parameters, params)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				data, err := renderer.ToJSON(krc.Template, param)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				obj, _, err := decoder.Decode(data, nil, nil)
+				if err != nil {
+					t.Fatalf("failed to decode %s: %v", data, err)
+				}
+
+				if tt.validate != nil && params != nil {
+					tt.validate(t, obj)
+				}
+				if tt.podSpec == nil {
+					continue
+				}
+				spec := tt.podSpec(obj)
+				if len(spec.Containers) != 1 {
+					t.Fatalf("unexpected containers %v", spec.Containers)
+				}
+				if params == nil {
+					continue
+				}
+				if spec.Containers[0].Resources.Requests.Cpu().String() != "100m" {
+					t.Errorf("unexpected requests %v", spec.Containers[0].Resources.Requests)
+				}
+				if spec.Containers[0].Resources.Limits.Memory().String() != "1Gi" {
+					t.Errorf("unexpected limits %v", spec.Containers[0].Resources.Limits)
+				}
+				if len(spec.Tolerations) != 1 || spec.Tolerations[0].Operator != corev1.TolerationOpExists {
+					t.Errorf("unexpected tolerations %v", spec.Tolerations)
+				}
+				if len(spec.TopologySpreadConstraints) != 1 ||
+					spec.TopologySpreadConstraints[0].MaxSkew != 1 ||
+					spec.TopologySpreadConstraints[0].WhenUnsatisfiable != corev1.ScheduleAnyway ||
+					spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels["app"] != "foo-000000" {
+					t.Errorf("unexpected topology spread constraints %v", spec.TopologySpreadConstraints)
+				}
+			}
+		})
+	}
+}
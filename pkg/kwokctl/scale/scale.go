@@ -50,12 +50,34 @@ type Config struct {
 	Namespace    string
 	Replicas     int
 	SerialLength int
-	DryRun       bool
+	// NamePattern is the pattern of the names, e.g. node-{zone}-{index:05d}, it overrides the SerialLength.
+	NamePattern string
+	// Zones is the zones assigned to the objects in round-robin, which is exposed by Zone in the template.
+	Zones []string
+	// Labels is the distributions of the values of the labels.
+	Labels []Distribution
+	// Annotations is the distributions of the values of the annotations.
+	Annotations []Distribution
+	// ObjectSize is the size in bytes which the objects are padded to with a payload annotation.
+	ObjectSize int
+	// ScaleDownStrategy is the strategy to pick the objects to delete when the replicas are reduced.
+	ScaleDownStrategy ScaleDownStrategy
+	DryRun            bool
 }
 
 // Scale scales a resource in a cluster.
 func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
-	if conf.SerialLength == 0 && conf.Replicas > 1 {
+	var pattern *namePattern
+	if conf.NamePattern != "" {
+		p, err := newNamePattern(conf.NamePattern)
+		if err != nil {
+			return err
+		}
+		if !p.HasIndex() && conf.Replicas > 1 {
+			return fmt.Errorf("name pattern must contain {index} when replicas is greater than 1")
+		}
+		pattern = p
+	} else if conf.SerialLength == 0 && conf.Replicas > 1 {
 		return fmt.Errorf("serial length must be greater than 0 when replicas is greater than 1")
 	}
 
@@ -74,6 +96,12 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		"Index": func() int {
 			return index
 		},
+		"Zone": func() string {
+			return zoneOf(conf.Zones, index)
+		},
+		"Random": func(n int) int {
+			return randomOf(name, n)
+		},
 		"AddCIDR": utilsnet.AddCIDR,
 	})
 	data, err := renderer.ToJSON(conf.Template, param)
@@ -81,8 +109,24 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		return err
 	}
 
+	if conf.ObjectSize > MaxObjectSize {
+		return fmt.Errorf("object size %d exceeds the max object size %d", conf.ObjectSize, MaxObjectSize)
+	}
+
+	switch conf.ScaleDownStrategy {
+	case "", ScaleDownNewestFirst, ScaleDownRandom, ScaleDownByZone, ScaleDownCordonDrain:
+	default:
+		return fmt.Errorf("unknown scale-down strategy %q, supported: %v", conf.ScaleDownStrategy, ScaleDownStrategies)
+	}
+
 	if conf.DryRun {
 		dryrun.PrintMessage("# Scale resource %s to %d replicas", conf.Name, conf.Replicas)
+		if conf.ScaleDownStrategy != "" {
+			dryrun.PrintMessage("# Scale down with the %s strategy", conf.ScaleDownStrategy)
+		}
+		if conf.ObjectSize > 0 {
+			dryrun.PrintMessage("# Pad each object to %d bytes", conf.ObjectSize)
+		}
 		dryrun.PrintMessage("# Resource example: %s", string(data))
 		return nil
 	}
@@ -119,6 +163,13 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 
 	logger := log.FromContext(ctx)
 	logger = logger.With("name", conf.Name, "replicas", conf.Replicas, "resource", gvr.Resource)
+	if conf.ObjectSize > 0 {
+		logger = logger.With("objectSize", conf.ObjectSize)
+		logger.Info("Pad objects",
+			"totalSize", conf.Replicas*conf.ObjectSize,
+			"listPages", (conf.Replicas+ListPageSize-1)/ListPageSize,
+		)
+	}
 
 	var ri dynamic.ResourceInterface = nri
 
@@ -131,97 +182,23 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	}
 
 	start := time.Now()
-	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
-		return ri.List(ctx, opts)
-	})
-	deleteCount := 0
-	objs := make([]softInfo, 0, conf.Replicas)
-	sorted := false
-	err = listPager.EachListItem(ctx, metav1.ListOptions{
-		LabelSelector: labelNameKey + "=" + conf.Name,
-	}, func(raw apiruntime.Object) error {
-		obj := raw.(*unstructured.Unstructured)
-
-		// If list is not full, append it.
-		if len(objs) < cap(objs) {
-			objs = append(objs, softInfo{
-				Name:              obj.GetName(),
-				CreationTimestamp: obj.GetCreationTimestamp(),
-			})
-			return nil
-		}
-
-		// List is full, sort it.
-		if !sorted {
-			sort.Slice(objs, func(i, j int) bool {
-				itime := objs[i].CreationTimestamp
-				jtime := objs[j].CreationTimestamp
-				return itime.Before(&jtime)
-			})
-			sorted = true
-		}
-
-		deleteCount++
-
-		if len(objs) == 0 {
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
-			return nil
-		}
-
-		// New object is newer than the end object, delete the new object.
-		endObj := objs[len(objs)-1]
-		if endObj.Less(obj.GetCreationTimestamp(), obj.GetName()) {
-			// Delete the last object.
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
-			return nil
-		}
-
-		// Delete the end object.
-		err = ri.Delete(ctx, endObj.Name, metav1.DeleteOptions{})
-		if err != nil {
-			logger.Error("Delete resource", err)
-		}
-
-		// Find the index of the new object to be inserted.
-		index, _ := sort.Find(len(objs), func(i int) int {
-			if objs[i].Less(obj.GetCreationTimestamp(), obj.GetName()) {
-				return -1
-			}
-			return 1
-		})
-
-		if index == len(objs) {
-			// Delete the last object.
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
-			return nil
-		}
-		// Insert the new object.
-		copy(objs[index+1:], objs[index:len(objs)-1])
-		objs[index] = softInfo{
-			Name:              obj.GetName(),
-			CreationTimestamp: obj.GetCreationTimestamp(),
-		}
-		return nil
-	})
+	var objs []softInfo
+	var deleteCount int
+	if conf.ScaleDownStrategy == "" || conf.ScaleDownStrategy == ScaleDownNewestFirst {
+		objs, deleteCount, err = scaleDownNewestFirst(log.NewContext(ctx, logger), ri, conf)
+	} else {
+		objs, deleteCount, err = scaleDown(log.NewContext(ctx, logger), dynamicClient, ri, gvr, conf)
+	}
 	if err != nil {
 		return err
 	}
 
 	if deleteCount > 0 {
-		logger.Info("Deleted resources", "counter", deleteCount, "elapsed", time.Since(start))
+		logger.Info("Deleted resources", "counter", deleteCount, "strategy", conf.ScaleDownStrategy, "elapsed", time.Since(start))
 		return nil
 	}
 
-	if len(objs) == cap(objs) {
+	if len(objs) == conf.Replicas {
 		logger.Info("Nothing to do")
 		return nil
 	}
@@ -239,7 +216,11 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	buf := bytes.NewBuffer(nil)
 	gen := newResourceGenerator(func(_ int) ([]byte, error) {
 		for {
-			name = generateSerialNumber(conf.Name, index, conf.SerialLength)
+			if pattern != nil {
+				name = pattern.Name(conf.Name, index, zoneOf(conf.Zones, index))
+			} else {
+				name = generateSerialNumber(conf.Name, index, conf.SerialLength)
+			}
 			_, ok := has[name]
 			if !ok {
 				break
@@ -266,11 +247,30 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		if labels == nil {
 			labels = map[string]string{}
 		}
+		for _, d := range conf.Labels {
+			labels[d.Key] = d.Pick(name)
+		}
 		labels[labelNameKey] = conf.Name
 		u.SetLabels(labels)
+
+		if len(conf.Annotations) != 0 {
+			annotations := u.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			for _, d := range conf.Annotations {
+				annotations[d.Key] = d.Pick(name)
+			}
+			u.SetAnnotations(annotations)
+		}
 		u.SetNamespace(namespace)
 		u.SetName(name)
 
+		err = padObject(u, conf.ObjectSize)
+		if err != nil {
+			return nil, err
+		}
+
 		buf.Reset()
 		_, _ = buf.WriteString("---\n")
 		encoder := yaml.NewEncoder(buf)
@@ -300,6 +300,98 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	return nil
 }
 
+// scaleDownNewestFirst deletes the newest objects over the replicas while listing them page by page,
+// only the oldest objects up to the replicas are held in memory.
+func scaleDownNewestFirst(ctx context.Context, ri dynamic.ResourceInterface, conf Config) ([]softInfo, int, error) {
+	logger := log.FromContext(ctx)
+	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
+		return ri.List(ctx, opts)
+	})
+	deleteCount := 0
+	objs := make([]softInfo, 0, conf.Replicas)
+	sorted := false
+	var err error
+	err = listPager.EachListItem(ctx, metav1.ListOptions{
+		LabelSelector: labelNameKey + "=" + conf.Name,
+	}, func(raw apiruntime.Object) error {
+		obj := raw.(*unstructured.Unstructured)
+
+		// If list is not full, append it.
+		if len(objs) < cap(objs) {
+			objs = append(objs, softInfo{
+				Name:              obj.GetName(),
+				CreationTimestamp: obj.GetCreationTimestamp(),
+			})
+			return nil
+		}
+
+		// List is full, sort it.
+		if !sorted {
+			sort.Slice(objs, func(i, j int) bool {
+				itime := objs[i].CreationTimestamp
+				jtime := objs[j].CreationTimestamp
+				return itime.Before(&jtime)
+			})
+			sorted = true
+		}
+
+		deleteCount++
+
+		if len(objs) == 0 {
+			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				logger.Error("Delete resource", err)
+			}
+			return nil
+		}
+
+		// New object is newer than the end object, delete the new object.
+		endObj := objs[len(objs)-1]
+		if endObj.Less(obj.GetCreationTimestamp(), obj.GetName()) {
+			// Delete the last object.
+			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				logger.Error("Delete resource", err)
+			}
+			return nil
+		}
+
+		// Delete the end object.
+		err = ri.Delete(ctx, endObj.Name, metav1.DeleteOptions{})
+		if err != nil {
+			logger.Error("Delete resource", err)
+		}
+
+		// Find the index of the new object to be inserted.
+		index, _ := sort.Find(len(objs), func(i int) int {
+			if objs[i].Less(obj.GetCreationTimestamp(), obj.GetName()) {
+				return -1
+			}
+			return 1
+		})
+
+		if index == len(objs) {
+			// Delete the last object.
+			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+			if err != nil {
+				logger.Error("Delete resource", err)
+			}
+			return nil
+		}
+		// Insert the new object.
+		copy(objs[index+1:], objs[index:len(objs)-1])
+		objs[index] = softInfo{
+			Name:              obj.GetName(),
+			CreationTimestamp: obj.GetCreationTimestamp(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return objs, deleteCount, nil
+}
+
 // NewParameters parses the parameters.
 func NewParameters(ctx context.Context, raw json.RawMessage, params []string) (any, error) {
 	var param any
@@ -333,6 +425,7 @@ var (
 type softInfo struct {
 	Name              string
 	CreationTimestamp metav1.Time
+	Zone              string
 }
 
 func (s softInfo) Less(creationTimestamp metav1.Time, name string) bool {
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/pager"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+// ScaleDownStrategy is the strategy to pick the objects to delete when the replicas are reduced.
+type ScaleDownStrategy string
+
+const (
+	// ScaleDownNewestFirst deletes the newest objects first.
+	ScaleDownNewestFirst ScaleDownStrategy = "newest-first"
+	// ScaleDownRandom deletes the objects at random.
+	ScaleDownRandom ScaleDownStrategy = "random"
+	// ScaleDownByZone deletes the newest objects of the zone with the most objects first,
+	// so the objects are kept balanced over the zones, the newer object is deleted first between the zones of the same size.
+	ScaleDownByZone ScaleDownStrategy = "by-zone"
+	// ScaleDownCordonDrain cordons the newest nodes first and evicts their pods before deleting them.
+	ScaleDownCordonDrain ScaleDownStrategy = "cordon-drain"
+)
+
+// ScaleDownStrategies is the list of the supported scale-down strategies.
+var ScaleDownStrategies = []ScaleDownStrategy{
+	ScaleDownNewestFirst,
+	ScaleDownRandom,
+	ScaleDownByZone,
+	ScaleDownCordonDrain,
+}
+
+const (
+	zoneLabelKey = "topology.kubernetes.io/zone"
+
+	// drainTimeout is the max duration to wait for the pods of a node to be evicted,
+	// the eviction is retried while it is blocked by a PodDisruptionBudget.
+	drainTimeout = 2 * time.Minute
+)
+
+var (
+	podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+)
+
+// pickScaleDown splits the objects into the ones to keep and the ones to delete in order with the strategy.
+func pickScaleDown(strategy ScaleDownStrategy, objs []softInfo, replicas int) (kept, deleted []softInfo) {
+	if len(objs) <= replicas {
+		return objs, nil
+	}
+
+	sorted := make([]softInfo, len(objs))
+	copy(sorted, objs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Less(sorted[j].CreationTimestamp, sorted[j].Name)
+	})
+
+	switch strategy {
+	case ScaleDownRandom:
+		rand.Shuffle(len(sorted), func(i, j int) {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		})
+		return sorted[:replicas], reverse(sorted[replicas:])
+	case ScaleDownByZone:
+		zones := map[string][]softInfo{}
+		for _, obj := range sorted {
+			zones[obj.Zone] = append(zones[obj.Zone], obj)
+		}
+		names := make([]string, 0, len(zones))
+		for zone := range zones {
+			names = append(names, zone)
+		}
+		sort.Strings(names)
+
+		for i := len(sorted) - replicas; i > 0; i-- {
+			largest := names[0]
+			for _, zone := range names[1:] {
+				switch n, m := len(zones[zone]), len(zones[largest]); {
+				case n > m:
+					largest = zone
+				case n == m && n != 0:
+					newest := zones[zone][n-1]
+					if !newest.Less(zones[largest][m-1].CreationTimestamp, zones[largest][m-1].Name) {
+						largest = zone
+					}
+				}
+			}
+			objs := zones[largest]
+			deleted = append(deleted, objs[len(objs)-1])
+			zones[largest] = objs[:len(objs)-1]
+		}
+		for _, zone := range names {
+			kept = append(kept, zones[zone]...)
+		}
+		return kept, deleted
+	default:
+		return sorted[:replicas], reverse(sorted[replicas:])
+	}
+}
+
+func reverse(objs []softInfo) []softInfo {
+	out := make([]softInfo, 0, len(objs))
+	for i := len(objs) - 1; i >= 0; i-- {
+		out = append(out, objs[i])
+	}
+	return out
+}
+
+// scaleDown deletes the objects picked by the strategy of the config and returns the ones kept.
+func scaleDown(ctx context.Context, dynamicClient dynamic.Interface, ri dynamic.ResourceInterface, gvr schema.GroupVersionResource, conf Config) ([]softInfo, int, error) {
+	if conf.ScaleDownStrategy == ScaleDownCordonDrain && gvr.Resource != "nodes" {
+		return nil, 0, fmt.Errorf("scale-down strategy %q is only supported by nodes", conf.ScaleDownStrategy)
+	}
+
+	// All the objects are held in memory, as the strategies pick the objects from all of them
+	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
+		return ri.List(ctx, opts)
+	})
+	objs := []softInfo{}
+	err := listPager.EachListItem(ctx, metav1.ListOptions{
+		LabelSelector: labelNameKey + "=" + conf.Name,
+	}, func(raw apiruntime.Object) error {
+		obj := raw.(*unstructured.Unstructured)
+		objs = append(objs, softInfo{
+			Name:              obj.GetName(),
+			CreationTimestamp: obj.GetCreationTimestamp(),
+			Zone:              obj.GetLabels()[zoneLabelKey],
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	kept, deleted := pickScaleDown(conf.ScaleDownStrategy, objs, conf.Replicas)
+
+	logger := log.FromContext(ctx)
+	for _, obj := range deleted {
+		if conf.ScaleDownStrategy == ScaleDownCordonDrain {
+			err = drainNode(ctx, dynamicClient, ri, obj.Name)
+			if err != nil {
+				logger.Error("Drain node", err, "node", obj.Name)
+			}
+		}
+		err = ri.Delete(ctx, obj.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Error("Delete resource", err)
+		}
+	}
+	return kept, len(deleted), nil
+}
+
+// drainNode cordons the node and evicts the pods on it except the ones of the DaemonSets, as kubectl drain does,
+// the pods are deleted gracefully by the eviction and finished by kwok.
+func drainNode(ctx context.Context, dynamicClient dynamic.Interface, nodes dynamic.ResourceInterface, name string) error {
+	logger := log.FromContext(ctx)
+	_, err := nodes.Patch(ctx, name, types.MergePatchType, []byte(`{"spec":{"unschedulable":true}}`), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to cordon node: %w", err)
+	}
+
+	pods, err := dynamicClient.Resource(podsGVR).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if isDaemonSetPod(pod) || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		err = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+			err := evictPod(ctx, dynamicClient, pod.GetNamespace(), pod.GetName())
+			if err == nil || apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			if apierrors.IsTooManyRequests(err) {
+				logger.Debug("Eviction is blocked by a disruption budget, retrying", "pod", log.KObj(&pod))
+				return false, nil
+			}
+			return false, err
+		},
+			wait.WithImmediate(),
+			wait.WithInterval(time.Second),
+			wait.WithTimeout(drainTimeout),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to evict pod %s/%s: %w", pod.GetNamespace(), pod.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func evictPod(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) error {
+	eviction := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "policy/v1",
+			"kind":       "Eviction",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}
+	_, err := dynamicClient.Resource(podsGVR).Namespace(namespace).Create(ctx, eviction, metav1.CreateOptions{}, "eviction")
+	return err
+}
+
+func isDaemonSetPod(pod unstructured.Unstructured) bool {
+	for _, ref := range pod.GetOwnerReferences() {
+		if ref.Kind == "DaemonSet" && ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPickScaleDown(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	obj := func(name string, minute int, zone string) softInfo {
+		return softInfo{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(base.Add(time.Duration(minute) * time.Minute)),
+			Zone:              zone,
+		}
+	}
+	names := func(objs []softInfo) []string {
+		out := []string{}
+		for _, o := range objs {
+			out = append(out, o.Name)
+		}
+		return out
+	}
+	objs := []softInfo{
+		obj("a-0", 0, "a"),
+		obj("a-1", 1, "a"),
+		obj("a-2", 2, "a"),
+		obj("a-3", 3, "a"),
+		obj("b-0", 4, "b"),
+		obj("b-1", 5, "b"),
+		obj("c-0", 6, "c"),
+	}
+
+	tests := []struct {
+		name        string
+		strategy    ScaleDownStrategy
+		replicas    int
+		wantKept    []string
+		wantDeleted []string
+	}{
+		{
+			name:        "newest-first",
+			strategy:    ScaleDownNewestFirst,
+			replicas:    4,
+			wantKept:    []string{"a-0", "a-1", "a-2", "a-3"},
+			wantDeleted: []string{"c-0", "b-1", "b-0"},
+		},
+		{
+			name:        "cordon-drain",
+			strategy:    ScaleDownCordonDrain,
+			replicas:    6,
+			wantKept:    []string{"a-0", "a-1", "a-2", "a-3", "b-0", "b-1"},
+			wantDeleted: []string{"c-0"},
+		},
+		{
+			name:        "by-zone",
+			strategy:    ScaleDownByZone,
+			replicas:    4,
+			wantKept:    []string{"a-0", "a-1", "b-0", "c-0"},
+			wantDeleted: []string{"a-3", "a-2", "b-1"},
+		},
+		{
+			name:        "nothing to delete",
+			strategy:    ScaleDownByZone,
+			replicas:    10,
+			wantKept:    names(objs),
+			wantDeleted: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, deleted := pickScaleDown(tt.strategy, objs, tt.replicas)
+			if diff := cmp.Diff(tt.wantKept, names(kept)); diff != "" {
+				t.Errorf("kept mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDeleted, names(deleted)); diff != "" {
+				t.Errorf("deleted mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		kept, deleted := pickScaleDown(ScaleDownRandom, objs, 3)
+		if len(kept) != 3 || len(deleted) != 4 {
+			t.Fatalf("expected 3 kept and 4 deleted, got %v and %v", names(kept), names(deleted))
+		}
+		all := append(names(kept), names(deleted)...)
+		sort.Strings(all)
+		if diff := cmp.Diff(names(objs), all); diff != "" {
+			t.Errorf("objects mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+// RuntimeRunnerConfig is the configuration of the runner of a cluster.
+type RuntimeRunnerConfig struct {
+	// Runtime is the runtime of the cluster.
+	Runtime runtime.Runtime
+	// Name is the name of the cluster.
+	Name string
+	// Kwokctl is the path of the kwokctl binary.
+	Kwokctl string
+	// Dir is the working directory of the commands.
+	Dir string
+	// PrometheusURL is the url of the Prometheus, it defaults to the Prometheus of the cluster.
+	PrometheusURL string
+}
+
+// NewRuntimeRunner returns a runner which runs the steps on the cluster of the runtime.
+func NewRuntimeRunner(ctx context.Context, conf RuntimeRunnerConfig) (Runner, error) {
+	clientset, err := conf.Runtime.GetClientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if conf.PrometheusURL == "" {
+		config, err := conf.Runtime.Config(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if config.Options.PrometheusPort != 0 {
+			conf.PrometheusURL = "http://" + net.LocalAddress + ":" + format.String(config.Options.PrometheusPort)
+		}
+	}
+	return &runtimeRunner{
+		conf:      conf,
+		clientset: clientset,
+	}, nil
+}
+
+type runtimeRunner struct {
+	conf      RuntimeRunnerConfig
+	clientset client.Clientset
+}
+
+func (r *runtimeRunner) Kwokctl(ctx context.Context, args []string) error {
+	ctx = exec.WithDir(exec.WithStdIO(ctx), r.conf.Dir)
+	return exec.Exec(ctx, r.conf.Kwokctl, append([]string{"--name", r.conf.Name}, args...)...)
+}
+
+func (r *runtimeRunner) Kubectl(ctx context.Context, args []string) error {
+	ctx = exec.WithDir(exec.WithStdIO(ctx), r.conf.Dir)
+	return r.conf.Runtime.KubectlInCluster(ctx, args...)
+}
+
+func (r *runtimeRunner) Count(ctx context.Context, a Assertion) (count int, total int, err error) {
+	restMapper, err := r.clientset.ToRESTMapper()
+	if err != nil {
+		return 0, 0, err
+	}
+	mapping, err := client.MappingFor(restMapper, a.Resource)
+	if err != nil {
+		return 0, 0, err
+	}
+	dynamicClient, err := r.clientset.ToDynamicClient()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	namespace := ""
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace = a.Namespace
+	}
+	list, err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: a.Selector,
+		FieldSelector: a.FieldSelector,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	total = len(list.Items)
+	if a.Condition == "" {
+		return total, total, nil
+	}
+	for _, item := range list.Items {
+		if hasCondition(item, a.Condition) {
+			count++
+		}
+	}
+	return count, total, nil
+}
+
+func hasCondition(obj unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == conditionType && cond["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *runtimeRunner) Query(ctx context.Context, query string) (float64, error) {
+	if r.conf.PrometheusURL == "" {
+		return 0, fmt.Errorf("no prometheus, create the cluster with --prometheus-port")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.conf.PrometheusURL+"/api/v1/query?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var body prometheusResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode the response of prometheus: %w", err)
+	}
+	return body.value(query)
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// value returns the value of a scalar or a vector of one sample.
+func (p prometheusResponse) value(query string) (float64, error) {
+	if p.Status != "success" {
+		return 0, fmt.Errorf("failed to query %s: %s", query, p.Error)
+	}
+
+	var sample []interface{}
+	switch p.Data.ResultType {
+	case "scalar":
+		err := json.Unmarshal(p.Data.Result, &sample)
+		if err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		err := json.Unmarshal(p.Data.Result, &vector)
+		if err != nil {
+			return 0, err
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("expected one sample of %s, got %d", query, len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q of %s", p.Data.ResultType, query)
+	}
+
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample of %s", query)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample of %s", query)
+	}
+	return strconv.ParseFloat(s, 64)
+}
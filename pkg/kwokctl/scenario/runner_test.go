@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPrometheusResponseValue(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    float64
+		wantErr bool
+	}{
+		{data: `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.25"]}}`, want: 0.25},
+		{data: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"3"]}]}}`, want: 3},
+		{data: `{"status":"success","data":{"resultType":"vector","result":[]}}`, wantErr: true},
+		{data: `{"status":"error","error":"bad query"}`, wantErr: true},
+		{data: `{"status":"success","data":{"resultType":"matrix","result":[]}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var resp prometheusResponse
+		if err := json.Unmarshal([]byte(tt.data), &resp); err != nil {
+			t.Fatal(err)
+		}
+		got, err := resp.value("q")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("value(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("value(%s) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
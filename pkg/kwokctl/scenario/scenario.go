@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scenario runs the declarative scenarios of the experiments on a cluster.
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// Scenario is the phases of an experiment on a cluster.
+type Scenario struct {
+	// Name is the name of the scenario.
+	Name string `json:"name,omitempty"`
+	// Phases is the phases run one after another.
+	Phases []Phase `json:"phases"`
+}
+
+// Phase is a named group of steps.
+type Phase struct {
+	// Name is the name of the phase.
+	Name string `json:"name"`
+	// Steps is the steps run one after another.
+	Steps []Step `json:"steps"`
+}
+
+// Step is a single action of a phase, exactly one of the actions must be set.
+type Step struct {
+	// Name is the name of the step.
+	Name string `json:"name,omitempty"`
+	// Kwokctl is the arguments of a kwokctl command run on the cluster,
+	// e.g. the scale of the resources or the injection of the chaos.
+	Kwokctl []string `json:"kwokctl,omitempty"`
+	// Kubectl is the arguments of a kubectl command run on the cluster.
+	Kubectl []string `json:"kubectl,omitempty"`
+	// Wait is the duration to wait.
+	Wait *metav1.Duration `json:"wait,omitempty"`
+	// Assert is the assertion on the number of the objects in the cluster.
+	Assert *Assertion `json:"assert,omitempty"`
+}
+
+// Assertion asserts the number of the objects in the cluster or the value of a Prometheus query,
+// exactly one of the resource and the prometheus must be set.
+type Assertion struct {
+	// Resource is the resource of the objects, e.g. pods or nodes.
+	Resource string `json:"resource,omitempty"`
+	// Namespace is the namespace of the objects, empty means all namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is the label selector of the objects.
+	Selector string `json:"selector,omitempty"`
+	// FieldSelector is the field selector of the objects, e.g. status.phase=Running.
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// Condition is the type of the condition, only the objects with the condition true are counted, e.g. Ready.
+	Condition string `json:"condition,omitempty"`
+	// All asserts all the matched objects have the condition, and there is at least one of them.
+	All bool `json:"all,omitempty"`
+	// Count is the exact number of the objects.
+	Count *int `json:"count,omitempty"`
+	// MinCount is the minimum number of the objects.
+	MinCount *int `json:"minCount,omitempty"`
+	// MaxCount is the maximum number of the objects.
+	MaxCount *int `json:"maxCount,omitempty"`
+	// Prometheus is the query of the Prometheus of the cluster.
+	Prometheus *PrometheusAssertion `json:"prometheus,omitempty"`
+	// Timeout is the time to wait for the assertion to pass, 0 means it is checked once.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// PrometheusAssertion asserts the value of a Prometheus query.
+type PrometheusAssertion struct {
+	// Query is the query of a single value, e.g. the p99 latency of the binding.
+	Query string `json:"query"`
+	// Min is the minimum of the value.
+	Min *float64 `json:"min,omitempty"`
+	// Max is the maximum of the value.
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Load loads the scenario from the data.
+func Load(data []byte) (*Scenario, error) {
+	s := &Scenario{}
+	err := yaml.Unmarshal(data, s)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal scenario error: %w", err)
+	}
+	err = s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks the phases and the steps of the scenario.
+func (s *Scenario) Validate() error {
+	if len(s.Phases) == 0 {
+		return errors.New("scenario has no phases")
+	}
+	for i, phase := range s.Phases {
+		if phase.Name == "" {
+			return fmt.Errorf("phase %d has no name", i)
+		}
+		for j, step := range phase.Steps {
+			actions := 0
+			if len(step.Kwokctl) != 0 {
+				actions++
+			}
+			if len(step.Kubectl) != 0 {
+				actions++
+			}
+			if step.Wait != nil {
+				actions++
+			}
+			if step.Assert != nil {
+				actions++
+				err := step.Assert.Validate()
+				if err != nil {
+					return fmt.Errorf("phase %q step %d: %w", phase.Name, j, err)
+				}
+			}
+			if actions != 1 {
+				return fmt.Errorf("phase %q step %d: exactly one of kwokctl, kubectl, wait and assert must be set", phase.Name, j)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the assertion.
+func (a *Assertion) Validate() error {
+	switch {
+	case a.Resource != "" && a.Prometheus != nil:
+		return errors.New("assert has both resource and prometheus")
+	case a.Prometheus != nil:
+		if a.Prometheus.Query == "" {
+			return errors.New("assert has no prometheus query")
+		}
+		if a.Prometheus.Min == nil && a.Prometheus.Max == nil {
+			return errors.New("assert has no min or max of the prometheus query")
+		}
+	case a.Resource != "":
+		if a.All && a.Condition == "" {
+			return errors.New("assert all requires the condition")
+		}
+		if !a.All && a.Count == nil && a.MinCount == nil && a.MaxCount == nil {
+			return errors.New("assert has no all, count, minCount or maxCount")
+		}
+	default:
+		return errors.New("assert has no resource or prometheus")
+	}
+	return nil
+}
+
+// Runner runs the actions of the steps on a cluster.
+type Runner interface {
+	// Kwokctl runs a kwokctl command on the cluster.
+	Kwokctl(ctx context.Context, args []string) error
+	// Kubectl runs a kubectl command on the cluster.
+	Kubectl(ctx context.Context, args []string) error
+	// Count returns the number of the objects with the condition of the assertion,
+	// and the total number of the objects matched by the assertion.
+	Count(ctx context.Context, assertion Assertion) (count int, total int, err error)
+	// Query returns the value of a Prometheus query.
+	Query(ctx context.Context, query string) (float64, error)
+}
+
+// StepResult is the result of a step.
+type StepResult struct {
+	// Phase is the name of the phase.
+	Phase string `json:"phase"`
+	// Step is the name of the step.
+	Step string `json:"step"`
+	// Passed is true if the step passed.
+	Passed bool `json:"passed"`
+	// Elapsed is the time spent.
+	Elapsed time.Duration `json:"elapsed"`
+	// Message is the reason of the failure.
+	Message string `json:"message,omitempty"`
+}
+
+// Run runs the phases of the scenario, and stops at the first failed step.
+func Run(ctx context.Context, runner Runner, s *Scenario) ([]StepResult, error) {
+	logger := log.FromContext(ctx)
+
+	results := []StepResult{}
+	for _, phase := range s.Phases {
+		logger.Info("Running phase", "phase", phase.Name)
+		for i, step := range phase.Steps {
+			name := step.Name
+			if name == "" {
+				name = fmt.Sprintf("%d", i)
+			}
+			start := time.Now()
+			err := runStep(ctx, runner, step)
+			result := StepResult{
+				Phase:   phase.Name,
+				Step:    name,
+				Passed:  err == nil,
+				Elapsed: time.Since(start),
+			}
+			if err != nil {
+				result.Message = err.Error()
+			}
+			results = append(results, result)
+			if err != nil {
+				return results, fmt.Errorf("phase %q step %q failed: %w", phase.Name, name, err)
+			}
+		}
+	}
+	return results, nil
+}
+
+func runStep(ctx context.Context, runner Runner, step Step) error {
+	switch {
+	case len(step.Kwokctl) != 0:
+		return runner.Kwokctl(ctx, step.Kwokctl)
+	case len(step.Kubectl) != 0:
+		return runner.Kubectl(ctx, step.Kubectl)
+	case step.Wait != nil:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(step.Wait.Duration):
+		}
+		return nil
+	case step.Assert != nil:
+		return Assert(ctx, runner, *step.Assert)
+	}
+	return nil
+}
+
+// assertInterval is the interval of checking an assertion until it passes.
+const assertInterval = time.Second
+
+// Assert checks the assertion until it passes or the timeout expires.
+func Assert(ctx context.Context, runner Runner, a Assertion) error {
+	deadline := time.Now().Add(a.Timeout.Duration)
+	for {
+		err := check(ctx, runner, a)
+		if err == nil || !time.Now().Before(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(assertInterval):
+		}
+	}
+}
+
+func check(ctx context.Context, runner Runner, a Assertion) error {
+	if a.Prometheus != nil {
+		value, err := runner.Query(ctx, a.Prometheus.Query)
+		if err != nil {
+			return err
+		}
+		return checkValue(*a.Prometheus, value)
+	}
+	count, total, err := runner.Count(ctx, a)
+	if err != nil {
+		return err
+	}
+	return checkCount(a, count, total)
+}
+
+func checkValue(p PrometheusAssertion, value float64) error {
+	if p.Min != nil && value < *p.Min {
+		return fmt.Errorf("expected %s to be at least %v, got %v", p.Query, *p.Min, value)
+	}
+	if p.Max != nil && value > *p.Max {
+		return fmt.Errorf("expected %s to be at most %v, got %v", p.Query, *p.Max, value)
+	}
+	return nil
+}
+
+func checkCount(a Assertion, count, total int) error {
+	if a.All {
+		if total == 0 {
+			return fmt.Errorf("expected all %s to be %s, got no %s", a.Resource, a.Condition, a.Resource)
+		}
+		if count != total {
+			return fmt.Errorf("expected all %s to be %s, got %d of %d", a.Resource, a.Condition, count, total)
+		}
+	}
+	if a.Count != nil && count != *a.Count {
+		return fmt.Errorf("expected %d %s, got %d", *a.Count, a.Resource, count)
+	}
+	if a.MinCount != nil && count < *a.MinCount {
+		return fmt.Errorf("expected at least %d %s, got %d", *a.MinCount, a.Resource, count)
+	}
+	if a.MaxCount != nil && count > *a.MaxCount {
+		return fmt.Errorf("expected at most %d %s, got %d", *a.MaxCount, a.Resource, count)
+	}
+	return nil
+}
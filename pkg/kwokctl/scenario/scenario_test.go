@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeRunner struct {
+	calls []string
+	count int
+}
+
+func (f *fakeRunner) Kwokctl(ctx context.Context, args []string) error {
+	f.calls = append(f.calls, "kwokctl "+strings.Join(args, " "))
+	f.count += 10
+	return nil
+}
+
+func (f *fakeRunner) Kubectl(ctx context.Context, args []string) error {
+	f.calls = append(f.calls, "kubectl "+strings.Join(args, " "))
+	return nil
+}
+
+func (f *fakeRunner) Count(ctx context.Context, a Assertion) (int, int, error) {
+	f.calls = append(f.calls, "count "+a.Resource)
+	return f.count, f.count, nil
+}
+
+func (f *fakeRunner) Query(ctx context.Context, query string) (float64, error) {
+	f.calls = append(f.calls, "query "+query)
+	return 0.2, nil
+}
+
+const testScenario = `
+name: test
+phases:
+- name: scale
+  steps:
+  - kwokctl: [scale, node, --replicas, "10"]
+  - wait: 1ms
+  - name: nodes
+    assert:
+      resource: nodes
+      count: 10
+- name: chaos
+  steps:
+  - kubectl: [apply, -f, chaos.yaml]
+  - assert:
+      prometheus:
+        query: latency
+        max: 0.5
+  - assert:
+      resource: nodes
+      minCount: 20
+`
+
+func TestRun(t *testing.T) {
+	s, err := Load([]byte(testScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &fakeRunner{}
+	results, err := Run(context.Background(), runner, s)
+	if err == nil {
+		t.Fatal("expected the scenario to fail")
+	}
+	if !strings.Contains(err.Error(), `phase "chaos" step "2" failed: expected at least 20 nodes, got 10`) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	want := []string{
+		"kwokctl scale node --replicas 10",
+		"count nodes",
+		"kubectl apply -f chaos.yaml",
+		"query latency",
+		"count nodes",
+	}
+	if !reflect.DeepEqual(runner.calls, want) {
+		t.Errorf("calls = %v, want %v", runner.calls, want)
+	}
+	if len(results) != 6 || results[2].Step != "nodes" || !results[4].Passed || results[5].Passed {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []string{
+		`phases: []`,
+		`phases: [{steps: [{wait: 1s}]}]`,
+		`phases: [{name: a, steps: [{wait: 1s, kubectl: [get, pods]}]}]`,
+		`phases: [{name: a, steps: [{}]}]`,
+		`phases: [{name: a, steps: [{assert: {resource: pods}}]}]`,
+		`phases: [{name: a, steps: [{assert: {resource: pods, all: true}}]}]`,
+		`phases: [{name: a, steps: [{assert: {prometheus: {query: up}}}]}]`,
+		`phases: [{name: a, steps: [{assert: {resource: pods, count: 1, prometheus: {query: up, min: 1}}}]}]`,
+	}
+	for _, data := range tests {
+		if _, err := Load([]byte(data)); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+func TestCheckCount(t *testing.T) {
+	a := Assertion{Resource: "pods", Condition: "Ready", All: true}
+	if err := checkCount(a, 3, 3); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := checkCount(a, 2, 3); err == nil || err.Error() != "expected all pods to be Ready, got 2 of 3" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := checkCount(a, 0, 0); err == nil {
+		t.Error("expected error for no pods")
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	minValue, maxValue := 1.0, 2.0
+	p := PrometheusAssertion{Query: "up", Min: &minValue, Max: &maxValue}
+	for value, wantErr := range map[float64]bool{0.5: true, 1: false, 2: false, 2.5: true} {
+		if err := checkValue(p, value); (err != nil) != wantErr {
+			t.Errorf("checkValue(%v) = %v, want error %v", value, err, wantErr)
+		}
+	}
+}
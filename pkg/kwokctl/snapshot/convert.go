@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// apiMigration is an API version removed in a minor version of Kubernetes.
+type apiMigration struct {
+	GroupVersion string
+	Kind         string
+	Resource     string
+	// RemovedIn is the minor version of Kubernetes 1.x that the API version is removed in.
+	RemovedIn uint64
+	// Replacement is the API version the objects are rewritten to,
+	// it is empty if the schema is changed and the objects are dropped.
+	Replacement string
+}
+
+// apiMigrations is the API versions removed in Kubernetes,
+// see https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var apiMigrations = []apiMigration{
+	{"extensions/v1beta1", "DaemonSet", "daemonsets", 16, "apps/v1"},
+	{"extensions/v1beta1", "Deployment", "deployments", 16, "apps/v1"},
+	{"extensions/v1beta1", "ReplicaSet", "replicasets", 16, "apps/v1"},
+	{"extensions/v1beta1", "NetworkPolicy", "networkpolicies", 16, "networking.k8s.io/v1"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", 16, ""},
+	{"apps/v1beta1", "Deployment", "deployments", 16, "apps/v1"},
+	{"apps/v1beta1", "StatefulSet", "statefulsets", 16, "apps/v1"},
+	{"apps/v1beta2", "DaemonSet", "daemonsets", 16, "apps/v1"},
+	{"apps/v1beta2", "Deployment", "deployments", 16, "apps/v1"},
+	{"apps/v1beta2", "ReplicaSet", "replicasets", 16, "apps/v1"},
+	{"apps/v1beta2", "StatefulSet", "statefulsets", 16, "apps/v1"},
+
+	{"extensions/v1beta1", "Ingress", "ingresses", 22, ""},
+	{"networking.k8s.io/v1beta1", "Ingress", "ingresses", 22, ""},
+	{"networking.k8s.io/v1beta1", "IngressClass", "ingressclasses", 22, "networking.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "clusterroles", 22, "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "clusterrolebindings", 22, "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "roles", 22, "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "rolebindings", 22, "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "priorityclasses", 22, "scheduling.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", "Lease", "leases", 22, "coordination.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "storageclasses", 22, "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "volumeattachments", 22, "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "csidrivers", 22, "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSINode", "csinodes", 22, "storage.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "apiservices", 22, "apiregistration.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "customresourcedefinitions", 22, ""},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "mutatingwebhookconfigurations", 22, ""},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "validatingwebhookconfigurations", 22, ""},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "certificatesigningrequests", 22, ""},
+
+	{"batch/v1beta1", "CronJob", "cronjobs", 25, "batch/v1"},
+	{"policy/v1beta1", "PodDisruptionBudget", "poddisruptionbudgets", 25, "policy/v1"},
+	{"policy/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", 25, ""},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "runtimeclasses", 25, "node.k8s.io/v1"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "endpointslices", 25, ""},
+	{"events.k8s.io/v1beta1", "Event", "events", 25, ""},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "horizontalpodautoscalers", 25, ""},
+
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", 26, "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "flowschemas", 26, ""},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "prioritylevelconfigurations", 26, ""},
+
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity", "csistoragecapacities", 27, "storage.k8s.io/v1"},
+
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "flowschemas", 29, ""},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "prioritylevelconfigurations", 29, ""},
+}
+
+// ConvertReport is the report of the conversion of a snapshot.
+type ConvertReport struct {
+	// Rewritten is the number of the objects rewritten to another API version, keyed by the conversion.
+	Rewritten map[string]int
+	// Dropped is the number of the objects dropped as the API version is removed, keyed by the API version and kind.
+	Dropped map[string]int
+}
+
+// Converter rewrites the objects of a snapshot or a recording to the API versions served by a Kubernetes version.
+type Converter struct {
+	minor  uint64
+	report ConvertReport
+}
+
+// NewConverter creates a new Converter to the Kubernetes version.
+func NewConverter(kubeVersion string) (*Converter, error) {
+	v, err := version.ParseVersion(kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kube version %q: %w", kubeVersion, err)
+	}
+	if v.Major != 1 {
+		return nil, fmt.Errorf("unsupported kube version %q", kubeVersion)
+	}
+	return &Converter{
+		minor: v.Minor,
+		report: ConvertReport{
+			Rewritten: map[string]int{},
+			Dropped:   map[string]int{},
+		},
+	}, nil
+}
+
+// Report returns the report of the conversion.
+func (c *Converter) Report() ConvertReport {
+	return c.report
+}
+
+// Convert reads the objects from the decoder, and writes the converted objects into the encoder.
+func (c *Converter) Convert(ctx context.Context, decoder *yaml.Decoder, encoder *yaml.Encoder) error {
+	logger := log.FromContext(ctx)
+	return decoder.DecodeToUnstructured(func(obj *unstructured.Unstructured) error {
+		keep, err := c.convert(obj)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			logger.Debug("Dropped",
+				"apiVersion", obj.GetAPIVersion(),
+				"kind", obj.GetKind(),
+				"name", log.KObj(obj),
+			)
+			return nil
+		}
+		return encoder.Encode(obj)
+	})
+}
+
+func (c *Converter) convert(obj *unstructured.Unstructured) (bool, error) {
+	if obj.GetKind() == recording.ResourcePatchType.Kind && obj.GetAPIVersion() == recording.ResourcePatchType.APIVersion {
+		return c.convertResourcePatch(obj)
+	}
+
+	m, ok := c.lookup(func(m apiMigration) bool {
+		return m.GroupVersion == obj.GetAPIVersion() && m.Kind == obj.GetKind()
+	})
+	if !ok {
+		return true, nil
+	}
+	if m.Replacement == "" {
+		c.report.Dropped[m.GroupVersion+" "+m.Kind]++
+		return false, nil
+	}
+	obj.SetAPIVersion(m.Replacement)
+	c.report.Rewritten[m.GroupVersion+" "+m.Kind+" -> "+m.Replacement]++
+	return true, nil
+}
+
+func (c *Converter) convertResourcePatch(obj *unstructured.Unstructured) (bool, error) {
+	group, _, err := unstructured.NestedString(obj.Object, "resource", "group")
+	if err != nil {
+		return false, err
+	}
+	ver, _, err := unstructured.NestedString(obj.Object, "resource", "version")
+	if err != nil {
+		return false, err
+	}
+	resource, _, err := unstructured.NestedString(obj.Object, "resource", "resource")
+	if err != nil {
+		return false, err
+	}
+	gv := schema.GroupVersion{Group: group, Version: ver}.String()
+
+	m, ok := c.lookup(func(m apiMigration) bool {
+		return m.GroupVersion == gv && m.Resource == resource
+	})
+	if !ok {
+		return true, nil
+	}
+	if m.Replacement == "" {
+		c.report.Dropped[m.GroupVersion+" "+m.Kind]++
+		return false, nil
+	}
+
+	replacement, err := schema.ParseGroupVersion(m.Replacement)
+	if err != nil {
+		return false, err
+	}
+	err = unstructured.SetNestedField(obj.Object, replacement.Group, "resource", "group")
+	if err != nil {
+		return false, err
+	}
+	err = unstructured.SetNestedField(obj.Object, replacement.Version, "resource", "version")
+	if err != nil {
+		return false, err
+	}
+
+	// The template of the create method is the whole object
+	if _, ok, _ := unstructured.NestedString(obj.Object, "template", "apiVersion"); ok {
+		err = unstructured.SetNestedField(obj.Object, m.Replacement, "template", "apiVersion")
+		if err != nil {
+			return false, err
+		}
+	}
+	c.report.Rewritten[m.GroupVersion+" "+m.Kind+" -> "+m.Replacement]++
+	return true, nil
+}
+
+func (c *Converter) lookup(match func(m apiMigration) bool) (apiMigration, bool) {
+	for _, m := range apiMigrations {
+		if m.RemovedIn <= c.minor && match(m) {
+			return m, true
+		}
+	}
+	return apiMigration{}, false
+}
+
+// SortedKeys returns the keys of the counts in order.
+func SortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
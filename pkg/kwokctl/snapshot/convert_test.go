@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+func TestConverter(t *testing.T) {
+	input := `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: foo
+---
+apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: bar
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: baz
+---
+apiVersion: action.kwok.x-k8s.io/v1alpha1
+kind: ResourcePatch
+resource:
+  group: batch
+  resource: cronjobs
+  version: v1beta1
+method: create
+target:
+  name: qux
+template:
+  apiVersion: batch/v1beta1
+  kind: CronJob
+  metadata:
+    name: qux
+`
+	tests := []struct {
+		name          string
+		kubeVersion   string
+		want          []string
+		wantRewritten map[string]int
+		wantDropped   map[string]int
+	}{
+		{
+			name:          "old version",
+			kubeVersion:   "v1.15.0",
+			want:          []string{"extensions/v1beta1", "policy/v1beta1", "v1", "action.kwok.x-k8s.io/v1alpha1"},
+			wantRewritten: map[string]int{},
+			wantDropped:   map[string]int{},
+		},
+		{
+			name:        "new version",
+			kubeVersion: "v1.30.0",
+			want:        []string{"apps/v1", "v1", "action.kwok.x-k8s.io/v1alpha1"},
+			wantRewritten: map[string]int{
+				"extensions/v1beta1 Deployment -> apps/v1": 1,
+				"batch/v1beta1 CronJob -> batch/v1":        1,
+			},
+			wantDropped: map[string]int{
+				"policy/v1beta1 PodSecurityPolicy": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter, err := NewConverter(tt.kubeVersion)
+			if err != nil {
+				t.Fatalf("NewConverter() error = %v", err)
+			}
+			buf := bytes.NewBuffer(nil)
+			err = converter.Convert(context.Background(), yaml.NewDecoder(strings.NewReader(input)), yaml.NewEncoder(buf))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+
+			var got []string
+			err = yaml.NewDecoder(buf).DecodeToUnstructured(func(obj *unstructured.Unstructured) error {
+				got = append(got, obj.GetAPIVersion())
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("DecodeToUnstructured() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Convert() mismatch (-want +got):\n%s", diff)
+			}
+
+			report := converter.Report()
+			if diff := cmp.Diff(tt.wantRewritten, report.Rewritten); diff != "" {
+				t.Errorf("Report().Rewritten mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDropped, report.Dropped); diff != "" {
+				t.Errorf("Report().Dropped mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
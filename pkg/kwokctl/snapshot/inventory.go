@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// InventoryName is the name of the file in the workdir of the cluster that records the saved snapshots.
+const InventoryName = "snapshots.yaml"
+
+// InventoryEntry is a snapshot or a recording saved from the cluster.
+type InventoryEntry struct {
+	// Path is the absolute path of the snapshot file.
+	Path string `json:"path"`
+	// Format is the format of the snapshot file, etcd, k8s or recording.
+	Format string `json:"format"`
+	// KubeVersion is the version of Kubernetes of the cluster when the snapshot is saved.
+	KubeVersion string `json:"kubeVersion,omitempty"`
+	// CreationTimestamp is the time when the snapshot is saved.
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	// Note is the note set when the snapshot is saved.
+	Note string `json:"note,omitempty"`
+}
+
+// LoadInventory loads the inventory of snapshots, an empty inventory is returned if it does not exist.
+func LoadInventory(inventoryPath string) ([]InventoryEntry, error) {
+	data, err := file.Read(inventoryPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []InventoryEntry
+	err = yaml.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// AddToInventory adds the snapshot to the inventory of snapshots,
+// the entry with the same path is replaced.
+func AddToInventory(inventoryPath string, entry InventoryEntry) error {
+	p, err := path.Expand(entry.Path)
+	if err != nil {
+		return err
+	}
+	entry.Path = p
+
+	entries, err := LoadInventory(inventoryPath)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, e := range entries {
+		if e.Path == entry.Path {
+			entries[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+
+	data, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return file.Write(inventoryPath, data)
+}
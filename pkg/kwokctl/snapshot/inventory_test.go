@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+func TestInventory(t *testing.T) {
+	dir := t.TempDir()
+	inventoryPath := path.Join(dir, InventoryName)
+
+	entries, err := LoadInventory(inventoryPath)
+	if err != nil {
+		t.Fatalf("LoadInventory() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("LoadInventory() = %v, want empty", entries)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	a := InventoryEntry{
+		Path:              path.Join(dir, "a.db"),
+		Format:            "etcd",
+		KubeVersion:       "v1.30.0",
+		CreationTimestamp: now,
+	}
+	b := InventoryEntry{
+		Path:              path.Join(dir, "b.yaml"),
+		Format:            "k8s",
+		KubeVersion:       "v1.30.0",
+		CreationTimestamp: now,
+		Note:              "before",
+	}
+	for _, entry := range []InventoryEntry{a, b} {
+		err = AddToInventory(inventoryPath, entry)
+		if err != nil {
+			t.Fatalf("AddToInventory() error = %v", err)
+		}
+	}
+
+	b.Note = "after"
+	err = AddToInventory(inventoryPath, b)
+	if err != nil {
+		t.Fatalf("AddToInventory() error = %v", err)
+	}
+
+	entries, err = LoadInventory(inventoryPath)
+	if err != nil {
+		t.Fatalf("LoadInventory() error = %v", err)
+	}
+	if diff := cmp.Diff([]InventoryEntry{a, b}, entries); diff != "" {
+		t.Errorf("LoadInventory() mismatch (-want +got):\n%s", diff)
+	}
+}
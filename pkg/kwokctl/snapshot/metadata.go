@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// MetadataSuffix is the suffix of the file next to an etcd snapshot that records where it is saved from.
+const MetadataSuffix = ".meta.yaml"
+
+// Metadata is the information of the cluster an etcd snapshot is saved from.
+type Metadata struct {
+	// Runtime is the runtime of the cluster.
+	Runtime string `json:"runtime"`
+	// KubeVersion is the version of Kubernetes of the cluster.
+	KubeVersion string `json:"kubeVersion,omitempty"`
+	// EtcdPrefix is the prefix of the keys in etcd.
+	EtcdPrefix string `json:"etcdPrefix,omitempty"`
+}
+
+// SaveMetadata saves the metadata next to the snapshot.
+func SaveMetadata(snapshotPath string, meta Metadata) error {
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return file.Write(snapshotPath+MetadataSuffix, data)
+}
+
+// LoadMetadata loads the metadata next to the snapshot, nil is returned if it does not exist.
+func LoadMetadata(snapshotPath string) (*Metadata, error) {
+	data, err := file.Read(snapshotPath + MetadataSuffix)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	meta := &Metadata{}
+	err = yaml.Unmarshal(data, meta)
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// CheckRestore checks whether the snapshot saved from the source can be restored into the target,
+// the returned warnings are the differences that may leave some objects out of place after restored.
+func CheckRestore(source, target Metadata) (warnings []string, err error) {
+	if source.EtcdPrefix != "" && target.EtcdPrefix != "" && source.EtcdPrefix != target.EtcdPrefix {
+		return nil, fmt.Errorf("the snapshot is saved with etcd prefix %q, but the cluster uses %q", source.EtcdPrefix, target.EtcdPrefix)
+	}
+	if source.KubeVersion != "" && target.KubeVersion != "" && source.KubeVersion != target.KubeVersion {
+		warnings = append(warnings, fmt.Sprintf("the snapshot is saved from kube version %s, but the cluster is %s", source.KubeVersion, target.KubeVersion))
+	}
+	if isKindRuntime(source.Runtime) != isKindRuntime(target.Runtime) {
+		warnings = append(warnings, fmt.Sprintf("the snapshot is saved from runtime %s, the objects of the nodes and system components of it are restored as well, use the k8s format to restore the resources only", source.Runtime))
+	}
+	return warnings, nil
+}
+
+// isKindRuntime returns whether the runtime runs the cluster with kind, which has a real node and system components.
+func isKindRuntime(runtime string) bool {
+	return runtime == consts.RuntimeTypeKind || strings.HasPrefix(runtime, consts.RuntimeTypeKind+"-")
+}
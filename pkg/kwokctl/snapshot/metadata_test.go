@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+func TestMetadata(t *testing.T) {
+	snapshotPath := path.Join(t.TempDir(), "snapshot.db")
+
+	meta, err := LoadMetadata(snapshotPath)
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+	if meta != nil {
+		t.Fatalf("LoadMetadata() = %v, want nil", meta)
+	}
+
+	want := Metadata{
+		Runtime:     "docker",
+		KubeVersion: "v1.30.0",
+		EtcdPrefix:  "/registry",
+	}
+	err = SaveMetadata(snapshotPath, want)
+	if err != nil {
+		t.Fatalf("SaveMetadata() error = %v", err)
+	}
+	meta, err = LoadMetadata(snapshotPath)
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+	if diff := cmp.Diff(want, *meta); diff != "" {
+		t.Errorf("LoadMetadata() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCheckRestore(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       Metadata
+		target       Metadata
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name:   "docker to binary",
+			source: Metadata{Runtime: "docker", KubeVersion: "v1.30.0", EtcdPrefix: "/registry"},
+			target: Metadata{Runtime: "binary", KubeVersion: "v1.30.0", EtcdPrefix: "/registry"},
+		},
+		{
+			name:         "kind to binary",
+			source:       Metadata{Runtime: "kind-podman", KubeVersion: "v1.30.0", EtcdPrefix: "/registry"},
+			target:       Metadata{Runtime: "binary", KubeVersion: "v1.29.0", EtcdPrefix: "/registry"},
+			wantWarnings: 2,
+		},
+		{
+			name:    "different etcd prefix",
+			source:  Metadata{Runtime: "docker", EtcdPrefix: "/registry"},
+			target:  Metadata{Runtime: "binary", EtcdPrefix: "/kwok"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := CheckRestore(tt.source, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckRestore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("CheckRestore() warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
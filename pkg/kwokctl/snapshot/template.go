@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// TemplateExt is the extension of the etcd data templates.
+const TemplateExt = ".db"
+
+// TemplatePath returns the path of the etcd data template with the name in the templates directory.
+func TemplatePath(templatesDir, name string) string {
+	return path.Join(templatesDir, name+TemplateExt)
+}
+
+// ResolveTemplate returns the path of the etcd data template,
+// the template is either a path of an etcd snapshot or a name of a template in the templates directory.
+func ResolveTemplate(templatesDir, template string) (string, error) {
+	if strings.ContainsAny(template, `/\`) || strings.HasSuffix(template, TemplateExt) {
+		p, err := path.Expand(template)
+		if err != nil {
+			return "", err
+		}
+		if !file.Exists(p) {
+			return "", fmt.Errorf("etcd template %q does not exist", template)
+		}
+		return p, nil
+	}
+
+	p := TemplatePath(templatesDir, template)
+	if !file.Exists(p) {
+		return "", fmt.Errorf("etcd template %q does not exist in %q, save one with 'kwokctl snapshot save --as-template'", template, templatesDir)
+	}
+	return p, nil
+}
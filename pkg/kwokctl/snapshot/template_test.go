@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"os"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+func TestResolveTemplate(t *testing.T) {
+	dir := t.TempDir()
+	named := TemplatePath(dir, "v1.30.0")
+	err := os.WriteFile(named, []byte("data"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := path.Join(dir, "other.db")
+	err = os.WriteFile(other, []byte("data"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{template: "v1.30.0", want: named},
+		{template: other, want: other},
+		{template: "v1.29.0", wantErr: true},
+		{template: path.Join(dir, "missing.db"), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveTemplate(dir, tt.template)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
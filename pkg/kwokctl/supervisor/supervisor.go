@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supervisor restarts a process when it exits and records its restarts.
+package supervisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+// State is the restarts of a supervised process.
+type State struct {
+	// Restarts is the number of times the process has been restarted.
+	Restarts int `json:"restarts"`
+	// LastExitCode is the exit code of the last exit, -1 if it was killed by a signal.
+	LastExitCode int `json:"lastExitCode,omitempty"`
+	// LastExitReason is the reason of the last exit.
+	LastExitReason string `json:"lastExitReason,omitempty"`
+	// LastExitTime is the time of the last exit.
+	LastExitTime time.Time `json:"lastExitTime,omitempty"`
+}
+
+// Config is the configuration of the supervisor.
+type Config struct {
+	// StatePath is the path of the file the State is written to.
+	StatePath string
+	// Name is the name of the process to run.
+	Name string
+	// Args is the arguments of the process.
+	Args []string
+	// RestartPolicy is the policy to restart the process when it exits, it is always restarted if empty.
+	RestartPolicy internalversion.RestartPolicy
+	// InitialBackoff is the delay before the first restart, it doubles on each restart.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay before a restart.
+	MaxBackoff time.Duration
+	// ResetAfter resets the backoff if the process has run longer than it.
+	ResetAfter time.Duration
+}
+
+// Run runs the process and restarts it whenever it exits until the context is done,
+// the process is killed when the context is done.
+func Run(ctx context.Context, conf Config) error {
+	if conf.InitialBackoff <= 0 {
+		conf.InitialBackoff = time.Second
+	}
+	if conf.MaxBackoff <= 0 {
+		conf.MaxBackoff = 30 * time.Second
+	}
+	if conf.ResetAfter <= 0 {
+		conf.ResetAfter = 10 * time.Minute
+	}
+
+	logger := log.FromContext(ctx)
+	state := State{}
+	err := WriteState(conf.StatePath, state)
+	if err != nil {
+		return err
+	}
+
+	backoff := conf.InitialBackoff
+	for first := true; ; first = false {
+		if !first {
+			state.Restarts++
+			err = WriteState(conf.StatePath, state)
+			if err != nil {
+				return err
+			}
+		}
+
+		start := time.Now()
+		exitCode, reason, err := runOnce(ctx, conf.Name, conf.Args)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		state.LastExitCode = exitCode
+		state.LastExitReason = reason
+		state.LastExitTime = time.Now()
+		err = WriteState(conf.StatePath, state)
+		if err != nil {
+			return err
+		}
+
+		if !shouldRestart(conf.RestartPolicy, exitCode) {
+			logger.Info("Process exited, not restarting",
+				"name", conf.Name,
+				"exitCode", exitCode,
+				"reason", reason,
+				"restartPolicy", conf.RestartPolicy,
+			)
+			return nil
+		}
+
+		if time.Since(start) > conf.ResetAfter {
+			backoff = conf.InitialBackoff
+		}
+		logger.Warn("Process exited, restarting",
+			"name", conf.Name,
+			"exitCode", exitCode,
+			"reason", reason,
+			"backoff", backoff,
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > conf.MaxBackoff {
+			backoff = conf.MaxBackoff
+		}
+	}
+}
+
+func shouldRestart(policy internalversion.RestartPolicy, exitCode int) bool {
+	switch policy {
+	case internalversion.RestartPolicyNever:
+		return false
+	case internalversion.RestartPolicyOnFailure:
+		return exitCode != 0
+	default:
+		return true
+	}
+}
+
+// runOnce runs the process until it exits or the context is done,
+// and returns the exit code and reason, the error is only returned if the supervisor can't continue.
+func runOnce(ctx context.Context, name string, args []string) (int, string, error) {
+	cmd := osexec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Start()
+	if err != nil {
+		var execErr *osexec.Error
+		if errors.As(err, &execErr) {
+			return 0, "", fmt.Errorf("start %s: %w", name, err)
+		}
+		return -1, err.Error(), nil
+	}
+
+	err = exec.KillWithCurrentProcess(cmd.Process.Pid)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return 0, "", fmt.Errorf("bind %s to the supervisor: %w", name, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		<-done
+		return 0, "", nil
+	case err = <-done:
+	}
+
+	if err == nil {
+		return 0, "Completed", nil
+	}
+	var exitErr *osexec.ExitError
+	if !errors.As(err, &exitErr) {
+		return -1, err.Error(), nil
+	}
+	exitCode := exitErr.ExitCode()
+	if exitCode < 0 {
+		return exitCode, exitErr.String(), nil
+	}
+	return exitCode, "Error", nil
+}
+
+// ReadState reads the State of a supervised process.
+func ReadState(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return state, fmt.Errorf("unmarshal state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// WriteState writes the State of a supervised process.
+func WriteState(path string, state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, data, 0640)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
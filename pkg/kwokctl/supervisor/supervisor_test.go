@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supervisor
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on windows")
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(ctx, Config{
+			StatePath:      statePath,
+			Name:           "sh",
+			Args:           []string{"-c", "exit 3"},
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+		})
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		state, err := ReadState(statePath)
+		if err == nil && state.Restarts >= 2 {
+			if state.LastExitCode != 3 || state.LastExitReason != "Error" || state.LastExitTime.IsZero() {
+				t.Errorf("unexpected state %+v", state)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("process is not restarted, state %+v, err %v", state, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+func TestRunNotFound(t *testing.T) {
+	err := Run(context.Background(), Config{
+		StatePath: filepath.Join(t.TempDir(), "state"),
+		Name:      "kwok-supervisor-not-found",
+	})
+	if err == nil {
+		t.Errorf("Run() error = nil, want error")
+	}
+}
+
+func TestRunRestartPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on windows")
+	}
+
+	tests := []struct {
+		name          string
+		restartPolicy internalversion.RestartPolicy
+		script        string
+		wantExitCode  int
+	}{
+		{
+			name:          "on-failure completed",
+			restartPolicy: internalversion.RestartPolicyOnFailure,
+			script:        "exit 0",
+			wantExitCode:  0,
+		},
+		{
+			name:          "never failed",
+			restartPolicy: internalversion.RestartPolicyNever,
+			script:        "exit 4",
+			wantExitCode:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statePath := filepath.Join(t.TempDir(), "state")
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			err := Run(ctx, Config{
+				StatePath:      statePath,
+				Name:           "sh",
+				Args:           []string{"-c", tt.script},
+				RestartPolicy:  tt.restartPolicy,
+				InitialBackoff: 10 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if ctx.Err() != nil {
+				t.Fatalf("process is restarted")
+			}
+			state, err := ReadState(statePath)
+			if err != nil {
+				t.Fatalf("ReadState() error = %v", err)
+			}
+			if state.Restarts != 0 || state.LastExitCode != tt.wantExitCode {
+				t.Errorf("unexpected state %+v", state)
+			}
+		})
+	}
+}
@@ -26,10 +26,27 @@ import (
 // InitFlags initializes the flags for the log.
 func InitFlags(ctx context.Context, flags *pflag.FlagSet) (context.Context, *Logger) {
 	var level levelFlagValue
+	var quiet bool
+	var eventsOutput string
 	flags.VarP(&level, "v", "v", "number for the log level verbosity (DEBUG, INFO, WARN, ERROR) or (-4, 0, 4, 8)")
+	flags.BoolVar(&quiet, "quiet", false, "Only output the errors")
+	flags.StringVar(&eventsOutput, "events-output", "text", "Output format of the progress events (text, json), the json format emits newline-delimited JSON events")
 	_ = flags.Parse(os.Args[1:])
 	l := Level(level)
-	logger := NewLogger(os.Stderr, l)
+	if quiet && l < LevelError {
+		l = LevelError
+	}
+
+	var logger *Logger
+	switch eventsOutput {
+	case "json":
+		logger = NewJSONLogger(os.Stderr, l)
+	default:
+		logger = NewLogger(os.Stderr, l)
+		if eventsOutput != "text" {
+			logger.Warn("Unknown events output, fallback to text", "output", eventsOutput)
+		}
+	}
 	return NewContext(ctx, logger), logger
 }
 
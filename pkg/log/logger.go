@@ -69,6 +69,16 @@ func NewLogger(w io.Writer, level Level) *Logger {
 		}
 	}
 
+	return NewJSONLogger(w, level)
+}
+
+// NewJSONLogger returns a new Logger that writes newline-delimited JSON to w,
+// even if w is a terminal.
+func NewJSONLogger(w io.Writer, level Level) *Logger {
+	if w == nil {
+		return noop
+	}
+
 	handler := &slog.HandlerOptions{
 		AddSource: true,
 		Level:     level,
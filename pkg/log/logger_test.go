@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestNewJSONLogger(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	logger := NewJSONLogger(buf, LevelInfo)
+	logger.Debug("Hidden")
+	logger.Info("Creating cluster", "cluster", "kwok")
+	logger.Warn("Something", "count", 1)
+
+	var events []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		event := map[string]any{}
+		err := json.Unmarshal(scanner.Bytes(), &event)
+		if err != nil {
+			t.Fatalf("line %q is not json: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0]["msg"] != "Creating cluster" || events[0]["level"] != "INFO" || events[0]["cluster"] != "kwok" {
+		t.Errorf("unexpected event %v", events[0])
+	}
+	if events[1]["msg"] != "Something" || events[1]["level"] != "WARN" || events[1]["count"] != float64(1) {
+		t.Errorf("unexpected event %v", events[1])
+	}
+}
@@ -47,6 +47,7 @@ var (
 		corev1.PodStatus{},
 		corev1.Container{},
 		metav1.ObjectMeta{},
+		metav1.OwnerReference{},
 		Quantity{},
 		ResourceList{},
 	}
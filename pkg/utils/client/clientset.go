@@ -46,6 +46,8 @@ type Clientset interface {
 type clientset struct {
 	masterURL       string
 	kubeconfigPath  string
+	contextName     string
+	impersonate     *rest.ImpersonationConfig
 	restConfig      *rest.Config
 	discoveryClient discovery.CachedDiscoveryInterface
 	restMapper      meta.RESTMapper
@@ -53,8 +55,6 @@ type clientset struct {
 	dynamicClient   dynamic.Interface
 
 	impersonationCache map[string]dynamic.Interface
-
-	opts []Option
 }
 
 // Option is a function that configures a clientset.
@@ -63,18 +63,28 @@ type Option func(*clientset)
 // WithImpersonate sets the impersonation config.
 func WithImpersonate(impersonateConfig rest.ImpersonationConfig) Option {
 	return func(c *clientset) {
-		c.restConfig.Impersonate = impersonateConfig
+		c.impersonate = &impersonateConfig
+	}
+}
+
+// WithContext sets the context of the kubeconfig to use instead of the current one.
+func WithContext(name string) Option {
+	return func(c *clientset) {
+		c.contextName = name
 	}
 }
 
 // NewClientset creates a new clientset.
 func NewClientset(masterURL, kubeconfigPath string, opts ...Option) (Clientset, error) {
-	return &clientset{
+	c := &clientset{
 		masterURL:          masterURL,
 		kubeconfigPath:     kubeconfigPath,
-		opts:               opts,
 		impersonationCache: map[string]dynamic.Interface{},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 // ToRESTConfig returns a REST config.
@@ -101,11 +111,10 @@ func (g *clientset) ToRESTConfig() (*rest.Config, error) {
 		restConfig.RateLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
 		restConfig.UserAgent = version.DefaultUserAgent()
 		restConfig.NegotiatedSerializer = unstructuredscheme.NewUnstructuredNegotiatedSerializer()
-		g.restConfig = restConfig
-
-		for _, opt := range g.opts {
-			opt(g)
+		if g.impersonate != nil {
+			restConfig.Impersonate = *g.impersonate
 		}
+		g.restConfig = restConfig
 	}
 	return g.restConfig, nil
 }
@@ -147,7 +156,7 @@ func (g *clientset) ToRawKubeConfigLoader() clientcmd.ClientConfig {
 	if g.clientConfig == nil {
 		g.clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			&clientcmd.ClientConfigLoadingRules{ExplicitPath: g.kubeconfigPath},
-			&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: g.masterURL}})
+			&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: g.masterURL}, CurrentContext: g.contextName})
 	}
 	return g.clientConfig
 }
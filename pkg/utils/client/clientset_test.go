@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: staging
+  context:
+    cluster: staging
+    user: admin
+users:
+- name: admin
+  user:
+    token: fake
+`
+
+func TestClientsetWithContext(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	err := os.WriteFile(kubeconfigPath, []byte(testKubeconfig), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantHost string
+	}{
+		{
+			name:     "current context",
+			wantHost: "https://dev.example.com",
+		},
+		{
+			name:     "non-current context",
+			opts:     []Option{WithContext("staging")},
+			wantHost: "https://staging.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset, err := NewClientset("", kubeconfigPath, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			restConfig, err := clientset.ToRESTConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if restConfig.Host != tt.wantHost {
+				t.Errorf("ToRESTConfig().Host = %q, want %q", restConfig.Host, tt.wantHost)
+			}
+		})
+	}
+
+	clientset, err := NewClientset("", kubeconfigPath, WithContext("missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = clientset.ToRESTConfig()
+	if err == nil {
+		t.Errorf("ToRESTConfig() with a missing context expected an error")
+	}
+}
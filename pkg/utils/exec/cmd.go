@@ -23,12 +23,16 @@ import (
 	"syscall"
 )
 
-// KillProcess kills the process with the given pid.
+// KillProcess kills the process with the given pid and the processes in its process group.
 func KillProcess(pid int) error {
 	process, err := os.FindProcess(pid)
 	if err != nil {
 		return fmt.Errorf("find process %d: %w", pid, err)
 	}
+	err = killProcessGroup(pid)
+	if err != nil {
+		return fmt.Errorf("kill process group: %w", err)
+	}
 	err = process.Kill()
 	if err != nil {
 		if errors.Is(err, os.ErrProcessDone) {
@@ -46,6 +50,12 @@ func KillProcess(pid int) error {
 	return nil
 }
 
+// KillWithCurrentProcess makes sure the process is killed when the current process is killed,
+// so that the child of a killed supervisor doesn't keep running without it.
+func KillWithCurrentProcess(pid int) error {
+	return killWithCurrentProcess(pid)
+}
+
 // IsRunning returns true if the process is running.
 func IsRunning(pid int) bool {
 	return isRunning(pid)
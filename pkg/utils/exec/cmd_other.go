@@ -20,6 +20,7 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"os/user"
@@ -106,3 +107,18 @@ func setUser(cmd *exec.Cmd, uid, gid *int64) error {
 	}
 	return nil
 }
+
+func killProcessGroup(pid int) error {
+	// The forked process is the leader of its process group,
+	// the processes started by it such as the supervised components are in the same group.
+	err := syscall.Kill(-pid, syscall.SIGKILL)
+	if err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
+
+func killWithCurrentProcess(pid int) error {
+	// The process is in the process group of the current process, which is killed as a whole.
+	return nil
+}
@@ -20,14 +20,19 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"os/exec"
+	"sync"
 	"syscall"
+	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
 
+// stillActive is the exit code of a process that hasn't exited yet.
+const stillActive = 259
+
 func startProcess(ctx context.Context, name string, arg ...string) *exec.Cmd {
 	cmd := command(ctx, name, arg...)
 	// CREATE_NEW_CONSOLE is used to detach the process from the parent (normally a shell)
@@ -45,8 +50,21 @@ func command(ctx context.Context, name string, arg ...string) *exec.Cmd {
 }
 
 func isRunning(pid int) bool {
-	_, err := os.FindProcess(pid)
-	return err == nil
+	// The process can still be opened after it exits as long as a handle to it is open,
+	// so the exit code is checked as well.
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = windows.CloseHandle(handle)
+	}()
+	var code uint32
+	err = windows.GetExitCodeProcess(handle, &code)
+	if err != nil {
+		return false
+	}
+	return code == stillActive
 }
 
 func setUser(cmd *exec.Cmd, uid, gid *int64) error {
@@ -55,3 +73,128 @@ func setUser(cmd *exec.Cmd, uid, gid *int64) error {
 	}
 	return fmt.Errorf("user and group are not supported in windows")
 }
+
+func killProcessGroup(pid int) error {
+	// There are no process groups on Windows,
+	// so the processes started by the process such as the supervised components are found by their parent.
+	descendants, err := descendantProcesses(uint32(pid))
+	if err != nil {
+		return err
+	}
+	for _, descendant := range descendants {
+		err = terminateProcess(descendant)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descendantProcesses returns the processes started by the process and their descendants.
+func descendantProcesses(pid uint32) ([]uint32, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot processes: %w", err)
+	}
+	defer func() {
+		_ = windows.CloseHandle(snapshot)
+	}()
+
+	children := map[uint32][]uint32{}
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		children[entry.ParentProcessID] = append(children[entry.ParentProcessID], entry.ProcessID)
+	}
+	if !errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+		return nil, fmt.Errorf("list processes: %w", err)
+	}
+
+	descendants := []uint32{}
+	queue := children[pid]
+	for len(queue) != 0 {
+		child := queue[0]
+		queue = queue[1:]
+		// The pid 0 is the idle process which is the parent of itself.
+		if child == pid || child == 0 {
+			continue
+		}
+		descendants = append(descendants, child)
+		queue = append(queue, children[child]...)
+	}
+	return descendants, nil
+}
+
+func terminateProcess(pid uint32) error {
+	handle, err := windows.OpenProcess(windows.PROCESS_TERMINATE, false, pid)
+	if err != nil {
+		// The process has exited
+		if errors.Is(err, windows.ERROR_INVALID_PARAMETER) {
+			return nil
+		}
+		return fmt.Errorf("open process %d: %w", pid, err)
+	}
+	defer func() {
+		_ = windows.CloseHandle(handle)
+	}()
+	err = windows.TerminateProcess(handle, 1)
+	if err != nil && !errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		return fmt.Errorf("terminate process %d: %w", pid, err)
+	}
+	return nil
+}
+
+var (
+	currentJob    windows.Handle
+	currentJobMut sync.Mutex
+)
+
+// killWithCurrentProcess assigns the process to a job which is shared by all the processes bound to the current process,
+// so only one handle is opened however many times the processes are restarted.
+func killWithCurrentProcess(pid int) error {
+	currentJobMut.Lock()
+	defer currentJobMut.Unlock()
+	if currentJob == 0 {
+		job, err := newKillOnCloseJob()
+		if err != nil {
+			return err
+		}
+		// The handle of the job is kept open until the current process exits,
+		// then the job is closed by the system and the processes in it are killed.
+		currentJob = job
+	}
+	return assignProcessToJob(currentJob, pid)
+}
+
+// newKillOnCloseJob creates a job whose processes are killed when its last handle is closed.
+func newKillOnCloseJob() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create job object: %w", err)
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	_, err = windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)))
+	if err != nil {
+		_ = windows.CloseHandle(job)
+		return 0, fmt.Errorf("set job object information: %w", err)
+	}
+	return job, nil
+}
+
+func assignProcessToJob(job windows.Handle, pid int) error {
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return fmt.Errorf("open process %d: %w", pid, err)
+	}
+	defer func() {
+		_ = windows.CloseHandle(handle)
+	}()
+	err = windows.AssignProcessToJobObject(job, handle)
+	if err != nil {
+		return fmt.Errorf("assign process %d to job object: %w", pid, err)
+	}
+	return nil
+}
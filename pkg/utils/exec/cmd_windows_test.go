@@ -0,0 +1,93 @@
+//go:build windows
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func startSleep(t *testing.T) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command("ping", "-n", "60", "127.0.0.1")
+	err := cmd.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func stopSleep(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+	_ = cmd.Wait()
+}
+
+func TestKillWithCurrentProcessReusesJob(t *testing.T) {
+	first := startSleep(t)
+	defer stopSleep(first)
+	err := killWithCurrentProcess(first.Process.Pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := currentJob
+
+	second := startSleep(t)
+	defer stopSleep(second)
+	err = killWithCurrentProcess(second.Process.Pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentJob != job {
+		t.Errorf("expected the job %v to be reused, got %v", job, currentJob)
+	}
+}
+
+func TestKillOnCloseJob(t *testing.T) {
+	job, err := newKillOnCloseJob()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := startSleep(t)
+	err = assignProcessToJob(job, cmd.Process.Pid)
+	if err != nil {
+		_ = windows.CloseHandle(job)
+		stopSleep(cmd)
+		t.Fatal(err)
+	}
+
+	err = windows.CloseHandle(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		_ = cmd.Process.Kill()
+		<-done
+		t.Errorf("expected the process %d to be killed on closing the job", cmd.Process.Pid)
+	}
+}
@@ -0,0 +1,114 @@
+//go:build linux
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is the USER_HZ of the kernel, which is 100 on all the supported architectures.
+const clockTicks = 100
+
+type procStat struct {
+	ppid  int
+	ticks uint64
+	rss   uint64
+}
+
+// ProcessUsage returns the CPU time and the resident memory of the process and its descendants.
+func ProcessUsage(pid int) (time.Duration, uint64, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, 0, err
+	}
+	stats := map[int]procStat{}
+	children := map[int][]int{}
+	for _, entry := range entries {
+		p, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile("/proc/" + entry.Name() + "/stat")
+		if err != nil {
+			// The process has exited
+			continue
+		}
+		stat, err := parseProcStat(string(data))
+		if err != nil {
+			continue
+		}
+		stats[p] = stat
+		children[stat.ppid] = append(children[stat.ppid], p)
+	}
+
+	if _, ok := stats[pid]; !ok {
+		return 0, 0, fmt.Errorf("process %d: %w", pid, os.ErrNotExist)
+	}
+
+	var ticks, rss uint64
+	queue := []int{pid}
+	for len(queue) != 0 {
+		p := queue[0]
+		queue = queue[1:]
+		ticks += stats[p].ticks
+		rss += stats[p].rss
+		queue = append(queue, children[p]...)
+	}
+	cpu := time.Duration(ticks) * time.Second / clockTicks
+	return cpu, rss * uint64(os.Getpagesize()), nil
+}
+
+// parseProcStat parses the /proc/<pid>/stat, see proc(5).
+func parseProcStat(data string) (procStat, error) {
+	// The command is in parentheses and can contain spaces
+	i := strings.LastIndexByte(data, ')')
+	if i < 0 {
+		return procStat{}, fmt.Errorf("invalid stat %q", data)
+	}
+	fields := strings.Fields(data[i+1:])
+	// The fields start from the state, the 3rd field
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("invalid stat %q", data)
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return procStat{}, err
+	}
+	utime, err := strconv.ParseUint(fields[11], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	stime, err := strconv.ParseUint(fields[12], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	rss, err := strconv.ParseUint(fields[21], 10, 64)
+	if err != nil {
+		return procStat{}, err
+	}
+	return procStat{
+		ppid:  ppid,
+		ticks: utime + stime,
+		rss:   rss,
+	}, nil
+}
@@ -0,0 +1,46 @@
+//go:build linux
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := "1234 (kube apiserver) S 1 1234 1234 0 -1 4194560 100 0 0 0 250 50 0 0 20 0 12 0 100 1000000 2048 18446744073709551615"
+	got, err := parseProcStat(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := procStat{ppid: 1, ticks: 300, rss: 2048}
+	if got != want {
+		t.Errorf("parseProcStat() = %+v, want %+v", got, want)
+	}
+}
+
+func TestProcessUsage(t *testing.T) {
+	_, memory, err := ProcessUsage(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if memory == 0 {
+		t.Error("expected the memory of the process")
+	}
+}
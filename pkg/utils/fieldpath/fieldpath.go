@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldpath gets and sets the fields of the objects by the dotted paths of their json names.
+package fieldpath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// keyFields are the json names of the fields that identify the elements of a list,
+// e.g. the name of a component patch or the key of an extra arg.
+var keyFields = []string{"name", "key"}
+
+// valueField is the json name of the field that is set when a scalar is given to an element of a list,
+// e.g. `extraArgs.v=4` sets the value of the extra arg with the key v.
+const valueField = "value"
+
+// Split splits the path by the dots, a dot in a segment is escaped by a backslash.
+func Split(path string) []string {
+	segments := []string{}
+	buf := strings.Builder{}
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			buf.WriteByte('.')
+			i++
+		case path[i] == '.':
+			segments = append(segments, buf.String())
+			buf.Reset()
+		default:
+			buf.WriteByte(path[i])
+		}
+	}
+	return append(segments, buf.String())
+}
+
+// Get returns the value of the field at the path of the object.
+func Get(obj any, path string) (any, error) {
+	v, err := walk(reflect.ValueOf(obj), Split(path), false)
+	if err != nil {
+		return nil, err
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if f, ok := elementValue(v); ok {
+		v = f
+	}
+	return v.Interface(), nil
+}
+
+// Set sets the field at the path of the object to the value,
+// the value is parsed as YAML into the type of the field except for the strings,
+// and the missing elements of the lists are added with their key fields set to the segments of the path.
+func Set(obj any, path string, value string) error {
+	root := reflect.ValueOf(obj)
+	if root.Kind() != reflect.Ptr || root.IsNil() {
+		return fmt.Errorf("object must be a non-nil pointer, got %T", obj)
+	}
+	v, err := walk(root, Split(path), true)
+	if err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if f, ok := elementValue(v); ok && !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		v = f
+	}
+	err = setValue(v, value)
+	if err != nil {
+		return fmt.Errorf("failed to set %q: %w", path, err)
+	}
+	return nil
+}
+
+func walk(v reflect.Value, segments []string, create bool) (reflect.Value, error) {
+	for i, segment := range segments {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				if !create || v.Kind() == reflect.Interface {
+					return reflect.Value{}, fmt.Errorf("%q is not set", strings.Join(segments[:i], "."))
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			f, ok := fieldByJSONName(v, segment)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("unknown field %q in %q", segment, v.Type().Name())
+			}
+			v = f
+		case reflect.Slice:
+			elem, err := sliceElement(v, segment, create)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%q: %w", strings.Join(segments[:i], "."), err)
+			}
+			v = elem
+		default:
+			return reflect.Value{}, fmt.Errorf("%q is a %s, which has no field %q", strings.Join(segments[:i], "."), v.Kind(), segment)
+		}
+	}
+	return v, nil
+}
+
+// fieldByJSONName returns the field by its json name, the fields of the inline structs are included.
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" || strings.Contains(opts, "inline") {
+			f := v.Field(i)
+			if f.Kind() == reflect.Struct {
+				if inline, ok := fieldByJSONName(f, name); ok {
+					return inline, true
+				}
+			}
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// sliceElement returns the element of the slice by its index or its key field,
+// the element is appended if it doesn't exist and create is true.
+func sliceElement(v reflect.Value, segment string, create bool) (reflect.Value, error) {
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Struct {
+		for _, key := range keyFields {
+			if _, ok := fieldByJSONName(reflect.New(elemType).Elem(), key); !ok {
+				continue
+			}
+			for i := 0; i < v.Len(); i++ {
+				f, _ := fieldByJSONName(v.Index(i), key)
+				if f.Kind() == reflect.String && f.String() == segment {
+					return v.Index(i), nil
+				}
+			}
+			if !create {
+				return reflect.Value{}, fmt.Errorf("no element with %s %q", key, segment)
+			}
+			elem := reflect.New(elemType).Elem()
+			f, _ := fieldByJSONName(elem, key)
+			f.SetString(segment)
+			v.Set(reflect.Append(v, elem))
+			return v.Index(v.Len() - 1), nil
+		}
+	}
+
+	index, err := strconv.Atoi(segment)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid index %q", segment)
+	}
+	if index < 0 || index > v.Len() || index == v.Len() && !create {
+		return reflect.Value{}, fmt.Errorf("index %d out of range", index)
+	}
+	if index == v.Len() {
+		v.Set(reflect.Append(v, reflect.New(elemType).Elem()))
+	}
+	return v.Index(index), nil
+}
+
+// elementValue returns the value field of an element of a list which is identified by a key field.
+func elementValue(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	hasKey := false
+	for _, key := range keyFields {
+		if _, ok := fieldByJSONName(v, key); ok {
+			hasKey = true
+			break
+		}
+	}
+	if !hasKey {
+		return reflect.Value{}, false
+	}
+	return fieldByJSONName(v, valueField)
+}
+
+func setValue(v reflect.Value, value string) error {
+	if !v.CanSet() {
+		return fmt.Errorf("can not be set")
+	}
+	if v.Kind() == reflect.String {
+		v.SetString(value)
+		return nil
+	}
+	ptr := reflect.New(v.Type())
+	err := yaml.Unmarshal([]byte(value), ptr.Interface())
+	if err != nil {
+		return err
+	}
+	v.Set(ptr.Elem())
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testArg struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type testPatch struct {
+	Name      string    `json:"name"`
+	ExtraArgs []testArg `json:"extraArgs,omitempty"`
+}
+
+type testOptions struct {
+	Port      uint32   `json:"port,omitempty"`
+	Secure    *bool    `json:"secure,omitempty"`
+	Runtimes  []string `json:"runtimes,omitempty"`
+	BindAddrs string   `json:"bindAddress,omitempty"`
+}
+
+type TypeMeta struct {
+	Kind string `json:"kind,omitempty"`
+}
+
+type testConfig struct {
+	TypeMeta `json:",inline"`
+	Options  testOptions `json:"options"`
+	Patches  []testPatch `json:"componentsPatches,omitempty"`
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{
+			path: "options.port",
+			want: []string{"options", "port"},
+		},
+		{
+			path: `componentsPatches.kube-apiserver.extraArgs.kwok\.x-k8s\.io/foo`,
+			want: []string{"componentsPatches", "kube-apiserver", "extraArgs", "kwok.x-k8s.io/foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := Split(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAndGet(t *testing.T) {
+	secure := true
+	tests := []struct {
+		name    string
+		path    string
+		value   string
+		want    testConfig
+		get     any
+		wantErr bool
+	}{
+		{
+			name:  "uint",
+			path:  "options.port",
+			value: "8080",
+			want:  testConfig{Options: testOptions{Port: 8080}},
+			get:   uint32(8080),
+		},
+		{
+			name:  "pointer",
+			path:  "options.secure",
+			value: "true",
+			want:  testConfig{Options: testOptions{Secure: &secure}},
+			get:   true,
+		},
+		{
+			name:  "string not parsed",
+			path:  "options.bindAddress",
+			value: "0.0.0.0",
+			want:  testConfig{Options: testOptions{BindAddrs: "0.0.0.0"}},
+			get:   "0.0.0.0",
+		},
+		{
+			name:  "list",
+			path:  "options.runtimes",
+			value: "[docker, podman]",
+			want:  testConfig{Options: testOptions{Runtimes: []string{"docker", "podman"}}},
+			get:   []string{"docker", "podman"},
+		},
+		{
+			name:  "inline",
+			path:  "kind",
+			value: "KwokctlConfiguration",
+			want:  testConfig{TypeMeta: TypeMeta{Kind: "KwokctlConfiguration"}},
+			get:   "KwokctlConfiguration",
+		},
+		{
+			name:  "element by key",
+			path:  "componentsPatches.kube-apiserver.extraArgs.v",
+			value: "4",
+			want: testConfig{Patches: []testPatch{
+				{Name: "kube-apiserver", ExtraArgs: []testArg{{Key: "v", Value: "4"}}},
+			}},
+			get: "4",
+		},
+		{
+			name:    "unknown field",
+			path:    "options.unknown",
+			value:   "1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			path:    "options.port",
+			value:   "foo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testConfig{}
+			err := Set(&got, tt.path, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Set() got = %+v, want %+v", got, tt.want)
+			}
+
+			value, err := Get(&got, tt.path)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.get) {
+				t.Errorf("Get() = %#v, want %#v", value, tt.get)
+			}
+		})
+	}
+}
+
+func TestSetExistingElement(t *testing.T) {
+	got := testConfig{Patches: []testPatch{
+		{Name: "kube-apiserver", ExtraArgs: []testArg{{Key: "v", Value: "2"}}},
+	}}
+	err := Set(&got, "componentsPatches.kube-apiserver.extraArgs.v", "4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := testConfig{Patches: []testPatch{
+		{Name: "kube-apiserver", ExtraArgs: []testArg{{Key: "v", Value: "4"}}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Set() got = %+v, want %+v", got, want)
+	}
+
+	_, err = Get(&got, "componentsPatches.kube-scheduler")
+	if err == nil {
+		t.Errorf("Get() expected error for missing element")
+	}
+}
@@ -34,7 +34,7 @@ import (
 
 // untar untars the given tarball to the given destination.
 func untar(ctx context.Context, src string, filter func(file string) (string, bool)) error {
-	if strings.HasSuffix(src, ".tar.gz") {
+	if strings.HasSuffix(src, ".tar.gz") || strings.HasSuffix(src, ".tgz") {
 		return untargz(ctx, src, filter)
 	} else if strings.HasSuffix(src, ".zip") {
 		return unzip(ctx, src, filter)
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format
+
+import (
+	"testing"
+)
+
+func TestHumanSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{size: 0, want: "0B"},
+		{size: 1023, want: "1023B"},
+		{size: 1024, want: "1.0KiB"},
+		{size: 1536, want: "1.5KiB"},
+		{size: 20 * 1024 * 1024, want: "20.0MiB"},
+		{size: 3 * 1024 * 1024 * 1024, want: "3.0GiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := HumanSize(tt.size); got != tt.want {
+				t.Errorf("HumanSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
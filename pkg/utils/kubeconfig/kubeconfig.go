@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/clientcmd"
@@ -104,8 +105,14 @@ func RemoveContext(kubeconfigPath, contextName string) error {
 	})
 }
 
+// modifyMut serializes the modifications of kubeconfig files, as multiple clusters may be operated concurrently.
+var modifyMut sync.Mutex
+
 // ModifyContext modifies the kubeconfig file
 func ModifyContext(kubeconfigPath string, fun func(kubeconfig *clientcmdapi.Config) error) error {
+	modifyMut.Lock()
+	defer modifyMut.Unlock()
+
 	// load kubeconfig file
 	kubeconfig, err := clientcmd.LoadFromFile(kubeconfigPath)
 	if err != nil {
@@ -165,6 +172,9 @@ type BuildKubeconfigConfig struct {
 	CACrtPath    string
 	AdminCrtPath string
 	AdminKeyPath string
+	// TLSServerName is the name to verify the certificate of the server with,
+	// the host of the address is used if it is empty.
+	TLSServerName string
 }
 
 // BuildKubeconfig builds a kubeconfig file from the given parameters.
@@ -189,6 +199,7 @@ func BuildKubeconfig(conf BuildKubeconfigConfig) *clientcmdapi.Config {
 			config.Clusters[conf.ProjectName].InsecureSkipTLSVerify = true
 		} else {
 			config.Clusters[conf.ProjectName].CertificateAuthority = conf.CACrtPath
+			config.Clusters[conf.ProjectName].TLSServerName = conf.TLSServerName
 		}
 		config.Contexts[conf.ProjectName].AuthInfo = conf.ProjectName
 		config.AuthInfos[conf.ProjectName] = &clientcmdapi.AuthInfo{
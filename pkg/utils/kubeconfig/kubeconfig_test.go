@@ -103,3 +103,33 @@ users: null
 		t.Errorf("got %q, want %q", string(got), want)
 	}
 }
+
+func TestBuildKubeconfigTLSServerName(t *testing.T) {
+	conf := BuildKubeconfig(BuildKubeconfigConfig{
+		ProjectName:   "kwok-test",
+		SecurePort:    true,
+		Address:       "https://127.0.0.1:6443",
+		CACrtPath:     "/pki/ca.crt",
+		AdminCrtPath:  "/pki/admin.crt",
+		AdminKeyPath:  "/pki/admin.key",
+		TLSServerName: "kwok.example.com",
+	})
+	cluster := conf.Clusters["kwok-test"]
+	if cluster.TLSServerName != "kwok.example.com" {
+		t.Errorf("TLSServerName = %q, want %q", cluster.TLSServerName, "kwok.example.com")
+	}
+	if cluster.Server != "https://127.0.0.1:6443" {
+		t.Errorf("Server = %q, want %q", cluster.Server, "https://127.0.0.1:6443")
+	}
+
+	conf = BuildKubeconfig(BuildKubeconfigConfig{
+		ProjectName:   "kwok-test",
+		SecurePort:    true,
+		Address:       "https://127.0.0.1:6443",
+		TLSServerName: "kwok.example.com",
+	})
+	cluster = conf.Clusters["kwok-test"]
+	if !cluster.InsecureSkipTLSVerify || cluster.TLSServerName != "" {
+		t.Errorf("expected skipping the TLS verification without a server name, got %+v", cluster)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// defaultDecisionTimeout is the timeout of the decision of the webhook or the plugin if it is not set.
+const defaultDecisionTimeout = 10 * time.Second
+
+// decisionRequest is the input of the webhook or the plugin.
+type decisionRequest struct {
+	Stage  string `json:"stage"`
+	Object any    `json:"object"`
+}
+
+// decisionResponse is the output of the webhook or the plugin.
+type decisionResponse struct {
+	// Skip means that the stage is not played for the resource.
+	Skip bool `json:"skip,omitempty"`
+	// DelayMilliseconds overrides the delay of the stage if set.
+	DelayMilliseconds *int64 `json:"delayMilliseconds,omitempty"`
+	// Delete means that the resource will be deleted if true.
+	Delete bool `json:"delete,omitempty"`
+	// Patches are applied after the patches of the stage.
+	Patches []decisionPatch `json:"patches,omitempty"`
+}
+
+// decisionPatch is a patch decided by the webhook or the plugin.
+type decisionPatch struct {
+	Subresource string                         `json:"subresource,omitempty"`
+	Type        internalversion.StagePatchType `json:"type,omitempty"`
+	Data        json.RawMessage                `json:"data"`
+}
+
+// decision is the decision of the webhook or the plugin for a resource.
+type decision struct {
+	delay   *time.Duration
+	delete  bool
+	patches []*Patch
+}
+
+// Decide asks the webhook or the plugin of the stage for the next of the resource,
+// and returns a copy of the stage with the decision.
+// It returns the stage itself if the stage has neither, and nil if the stage is skipped.
+func (s *Stage) Decide(ctx context.Context, resource any) (*Stage, error) {
+	var (
+		resp *decisionResponse
+		err  error
+	)
+	switch {
+	case s.next.Webhook != nil:
+		resp, err = callWebhook(ctx, s.next.Webhook, s.name, resource)
+		if err != nil && s.next.Webhook.FailurePolicy == internalversion.StageWebhookFailurePolicyIgnore {
+			logger := log.FromContext(ctx)
+			logger.Warn("Ignore the failed webhook", "stage", s.name, "err", err)
+			return s, nil
+		}
+	case s.next.Plugin != nil:
+		resp, err = callPlugin(ctx, s.next.Plugin, s.name, resource)
+	default:
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decision of stage %s: %w", s.name, err)
+	}
+	if resp.Skip {
+		return nil, nil
+	}
+
+	d, err := resp.toDecision()
+	if err != nil {
+		return nil, fmt.Errorf("decision of stage %s: %w", s.name, err)
+	}
+	stage := *s
+	stage.decision = d
+	return &stage, nil
+}
+
+func decisionTimeout(timeoutMilliseconds *int64) time.Duration {
+	if timeoutMilliseconds == nil {
+		return defaultDecisionTimeout
+	}
+	return time.Duration(*timeoutMilliseconds) * time.Millisecond
+}
+
+func (r decisionResponse) toDecision() (*decision, error) {
+	d := &decision{
+		delete:  r.Delete,
+		patches: make([]*Patch, 0, len(r.Patches)),
+	}
+	if r.DelayMilliseconds != nil {
+		delay := time.Duration(*r.DelayMilliseconds) * time.Millisecond
+		d.delay = &delay
+	}
+	for _, patch := range r.Patches {
+		var patchType types.PatchType
+		switch patch.Type {
+		case internalversion.StagePatchTypeJSONPatch:
+			patchType = types.JSONPatchType
+		case internalversion.StagePatchTypeStrategicMergePatch:
+			patchType = types.StrategicMergePatchType
+		case internalversion.StagePatchTypeMergePatch, "":
+			patchType = types.MergePatchType
+		default:
+			return nil, fmt.Errorf("unknown patch type %s", patch.Type)
+		}
+		d.patches = append(d.patches, &Patch{
+			Data:        patch.Data,
+			Type:        patchType,
+			Subresource: patch.Subresource,
+		})
+	}
+	return d, nil
+}
@@ -19,7 +19,7 @@ package lifecycle
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"strconv"
 	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 // NewLifecycle returns a new Lifecycle.
@@ -152,15 +153,14 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 		}
 	}
 
+	r := randForObject(ctx, data, append([]string{"match"}, stageNames(stages)...)...)
 	if countError == len(stages) {
-		//nolint:gosec
-		return stages[rand.Intn(len(stages))], nil
+		return stages[r.Intn(len(stages))], nil
 	}
 
 	if totalWeights == 0 {
 		if countError == 0 {
-			//nolint:gosec
-			return stages[rand.Intn(len(stages))], nil
+			return stages[r.Intn(len(stages))], nil
 		}
 
 		stagesWithWeights := make([]*Stage, 0, len(stages))
@@ -171,13 +171,11 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 			stagesWithWeights = append(stagesWithWeights, stage)
 		}
 
-		//nolint:gosec
-		off := rand.Intn(len(stagesWithWeights))
+		off := r.Intn(len(stagesWithWeights))
 		return stagesWithWeights[off], nil
 	}
 
-	//nolint:gosec
-	off := rand.Int63n(totalWeights)
+	off := r.Int63n(totalWeights)
 	for i, stage := range stages {
 		if weights[i] <= 0 {
 			continue
@@ -190,6 +188,43 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 	return stages[len(stages)-1], nil
 }
 
+func stageNames(stages []*Stage) []string {
+	names := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names = append(names, stage.name)
+	}
+	return names
+}
+
+// attemptKey is how we find the attempt in a context.Context.
+type attemptKey struct{}
+
+// NewAttemptContext returns a new context with the attempt of the transition of the object,
+// the decisions of each attempt get new random numbers.
+func NewAttemptContext(ctx context.Context, attempt uint64) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func attemptFromContext(ctx context.Context) uint64 {
+	attempt, _ := ctx.Value(attemptKey{}).(uint64)
+	return attempt
+}
+
+// randForObject returns the random numbers of the decision on the object,
+// which are derived from the namespace and the name of the object, the attempt and the keys of the decision,
+// so the decisions of the concurrent workers are reproducible with the seed.
+func randForObject(ctx context.Context, v interface{}, keys ...string) *rand.Source {
+	var namespace, name string
+	if obj, ok := v.(map[string]interface{}); ok {
+		if meta, ok := obj["metadata"].(map[string]interface{}); ok {
+			namespace, _ = meta["namespace"].(string)
+			name, _ = meta["name"].(string)
+		}
+	}
+	attempt := strconv.FormatUint(attemptFromContext(ctx), 10)
+	return rand.New(append([]string{namespace, name, attempt}, keys...)...)
+}
+
 // NewStage returns a new Stage.
 func NewStage(s *internalversion.Stage) (*Stage, error) {
 	stage := &Stage{
@@ -216,6 +251,9 @@ func NewStage(s *internalversion.Stage) (*Stage, error) {
 		}
 	}
 
+	if s.Spec.Next.Webhook != nil && s.Spec.Next.Plugin != nil {
+		return nil, fmt.Errorf("stage %s: webhook and plugin can't be used together", s.Name)
+	}
 	stage.next = &s.Spec.Next
 	if delay := s.Spec.Delay; delay != nil {
 		var durationFrom *string
@@ -263,6 +301,18 @@ func NewStage(s *internalversion.Stage) (*Stage, error) {
 
 	stage.immediateNextStage = s.Spec.ImmediateNextStage
 
+	if concurrency := s.Spec.Concurrency; concurrency != nil {
+		if concurrency.Limit == 0 {
+			return nil, fmt.Errorf("stage %s: concurrency limit must be greater than 0", s.Name)
+		}
+		switch concurrency.Scope {
+		case "", internalversion.StageConcurrencyScopeCluster, internalversion.StageConcurrencyScopeNode:
+		default:
+			return nil, fmt.Errorf("stage %s: unknown concurrency scope %q", s.Name, concurrency.Scope)
+		}
+		stage.concurrency = concurrency
+	}
+
 	return stage, nil
 }
 
@@ -280,6 +330,10 @@ type Stage struct {
 	jitterDuration expression.DurationGetter
 
 	immediateNextStage bool
+
+	concurrency *internalversion.StageConcurrency
+
+	decision *decision
 }
 
 func (s *Stage) match(label, annotation labels.Set, jsonStandard interface{}) (bool, error) {
@@ -311,6 +365,10 @@ func (s *Stage) match(label, annotation labels.Set, jsonStandard interface{}) (b
 // Delay returns the delay duration of the stage.
 // It's not a constant value, it can be a random value.
 func (s *Stage) Delay(ctx context.Context, v interface{}, now time.Time) (time.Duration, bool) {
+	if s.decision != nil && s.decision.delay != nil {
+		return *s.decision.delay, true
+	}
+
 	if s.duration == nil {
 		return 0, false
 	}
@@ -334,15 +392,14 @@ func (s *Stage) Delay(ctx context.Context, v interface{}, now time.Time) (time.D
 	}
 
 	if jitter := jitterDuration - duration; jitter > 0 {
-		//nolint:gosec
-		duration += time.Duration(rand.Int63n(int64(jitter)))
+		duration += time.Duration(randForObject(ctx, v, "delay", s.name).Int63n(int64(jitter)))
 	}
 	return duration, true
 }
 
 // Next returns the next of the stage.
 func (s *Stage) Next() *Next {
-	return newNext(s.next)
+	return newNext(s.next, s.decision)
 }
 
 // Name returns the name of the stage
@@ -355,6 +412,11 @@ func (s *Stage) ImmediateNextStage() bool {
 	return s.immediateNextStage
 }
 
+// Concurrency returns the concurrency limit of the stage, or nil if it is unlimited.
+func (s *Stage) Concurrency() *internalversion.StageConcurrency {
+	return s.concurrency
+}
+
 // Weight returns the weight of the stage.
 func (s *Stage) Weight(ctx context.Context, v interface{}) (int64, bool) {
 	return s.weight.Get(ctx, v)
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
+)
+
+func newWeightedStage(name string, weight int) *internalversion.Stage {
+	return &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: internalversion.StageSpec{
+			Selector: &internalversion.StageSelector{},
+			Weight:   weight,
+			Delay: &internalversion.StageDelay{
+				DurationMilliseconds:       format.Ptr[int64](1000),
+				JitterDurationMilliseconds: format.Ptr[int64](5000),
+			},
+		},
+	}
+}
+
+func TestLifecycleMatchSeedConcurrent(t *testing.T) {
+	rand.Seed(42)
+	defer rand.Seed(time.Now().UnixNano())
+
+	lc, err := NewLifecycle([]*internalversion.Stage{
+		newWeightedStage("fast", 1),
+		newWeightedStage("slow", 2),
+		newWeightedStage("failed", 3),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type decision struct {
+		stage string
+		delay time.Duration
+	}
+	const objects = 100
+	now := time.Now()
+	decide := func(i int) decision {
+		data := map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      "pod-" + strconv.Itoa(i),
+			},
+		}
+		stage, err := lc.Match(context.Background(), nil, nil, data)
+		if err != nil || stage == nil {
+			t.Errorf("Match() = %v, %v", stage, err)
+			return decision{}
+		}
+		delay, _ := stage.Delay(context.Background(), data, now)
+		return decision{stage: stage.Name(), delay: delay}
+	}
+
+	want := make([]decision, objects)
+	for i := range want {
+		want[i] = decide(i)
+	}
+
+	got := make([]decision, objects)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := objects - 1 - w; i >= 0; i -= 8 {
+				got[i] = decide(i)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	stages := map[string]int{}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected the same decision of object %d with concurrent workers, got %v and %v", i, got[i], want[i])
+		}
+		stages[want[i].stage]++
+	}
+	if len(stages) != 3 {
+		t.Errorf("expected all the stages to be matched, got %v", stages)
+	}
+}
+
+func TestLifecycleMatchAttempts(t *testing.T) {
+	rand.Seed(42)
+	defer rand.Seed(time.Now().UnixNano())
+
+	lc, err := NewLifecycle([]*internalversion.Stage{
+		newWeightedStage("running", 1),
+		newWeightedStage("failed", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"namespace": "default",
+			"name":      "pod",
+		},
+	}
+	now := time.Now()
+	decide := func(attempt uint64) (string, time.Duration) {
+		ctx := NewAttemptContext(context.Background(), attempt)
+		stage, err := lc.Match(ctx, nil, nil, data)
+		if err != nil || stage == nil {
+			t.Fatalf("Match() = %v, %v", stage, err)
+		}
+		delay, _ := stage.Delay(ctx, data, now)
+		return stage.Name(), delay
+	}
+
+	const attempts = 32
+	stages := map[string]int{}
+	delays := map[time.Duration]struct{}{}
+	for i := uint64(0); i < attempts; i++ {
+		stage, delay := decide(i)
+		stages[stage]++
+		delays[delay] = struct{}{}
+
+		// The same attempt is reproducible with the seed
+		if againStage, againDelay := decide(i); againStage != stage || againDelay != delay {
+			t.Fatalf("expected the same decision of attempt %d, got %s/%s and %s/%s", i, stage, delay, againStage, againDelay)
+		}
+	}
+	if stages["running"] == 0 || stages["failed"] == 0 {
+		t.Errorf("expected the object to land in both stages over %d attempts, got %v", attempts, stages)
+	}
+	if len(delays) == 1 {
+		t.Errorf("expected the jitter of the delay to change over %d attempts, got %v", attempts, delays)
+	}
+}
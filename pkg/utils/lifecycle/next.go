@@ -29,13 +29,15 @@ import (
 
 // Next represents the next step in the lifecycle
 type Next struct {
-	next *internalversion.StageNext
+	next     *internalversion.StageNext
+	decision *decision
 }
 
-// newNext creates a new Next from the stage
-func newNext(next *internalversion.StageNext) *Next {
+// newNext creates a new Next from the stage and the decision of its webhook
+func newNext(next *internalversion.StageNext, decision *decision) *Next {
 	return &Next{
-		next: next,
+		next:     next,
+		decision: decision,
 	}
 }
 
@@ -66,7 +68,7 @@ func (n *Next) Event() *internalversion.StageEvent {
 
 // Delete returns whether the resource should be deleted
 func (n *Next) Delete() bool {
-	return n.next.Delete
+	return n.next.Delete || (n.decision != nil && n.decision.delete)
 }
 
 // Patches returns the patches for the resource
@@ -84,6 +86,9 @@ func (n *Next) Patches(resource any, renderer gotpl.Renderer) ([]*Patch, error)
 			Impersonation: patch.Impersonation,
 		})
 	}
+	if n.decision != nil {
+		patches = append(patches, n.decision.patches...)
+	}
 	return patches, nil
 }
 
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// pluginMemoryLimitPages is the limit of the memory of a plugin, 64KiB per page.
+const pluginMemoryLimitPages = 1024
+
+// plugins is the cache of the compiled plugins by path.
+var plugins sync.Map
+
+// plugin is a WebAssembly module that is recompiled when the file is modified.
+type plugin struct {
+	mut     sync.Mutex
+	path    string
+	modTime time.Time
+	module  *pluginModule
+}
+
+// pluginModule is a compiled module with the runtime it is compiled by,
+// which is instantiated concurrently by the calls.
+type pluginModule struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	// calls is the running calls, the runtime is closed after them when the module is replaced.
+	calls sync.WaitGroup
+}
+
+func callPlugin(ctx context.Context, conf *internalversion.StagePlugin, stage string, resource any) (*decisionResponse, error) {
+	input, err := json.Marshal(decisionRequest{
+		Stage:  stage,
+		Object: resource,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := plugins.LoadOrStore(conf.Path, &plugin{path: conf.Path})
+	p := v.(*plugin)
+	// Only the load is serialized, the calls run concurrently on the compiled module
+	p.mut.Lock()
+	m, err := p.load(ctx)
+	if err == nil {
+		m.calls.Add(1)
+	}
+	p.mut.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("load plugin %s: %w", conf.Path, err)
+	}
+	defer m.calls.Done()
+
+	ctx, cancel := context.WithTimeout(ctx, decisionTimeout(conf.TimeoutMilliseconds))
+	defer cancel()
+	output, err := m.call(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("call plugin %s: %w", conf.Path, err)
+	}
+
+	var r decisionResponse
+	err = json.Unmarshal(output, &r)
+	if err != nil {
+		return nil, fmt.Errorf("decode output of plugin %s: %w", conf.Path, err)
+	}
+	return &r, nil
+}
+
+// load compiles the module if it is not compiled or the file is modified, it must be called with the lock held.
+func (p *plugin) load(ctx context.Context) (*pluginModule, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, err
+	}
+	if p.module != nil && info.ModTime().Equal(p.modTime) {
+		return p.module, nil
+	}
+
+	wasm, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The runtime has no access to the file system, the network or the environment of kwok,
+	// and the calls are interrupted when the context is done.
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(pluginMemoryLimitPages).
+		WithCloseOnContextDone(true))
+	_, err = wasi_snapshot_preview1.Instantiate(ctx, r)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, err
+	}
+	compiled, err := r.CompileModule(ctx, wasm)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, err
+	}
+
+	if old := p.module; old != nil {
+		// No more calls are added to the replaced module, so it is closed once the running calls are done
+		go func() {
+			old.calls.Wait()
+			_ = old.runtime.Close(context.Background())
+		}()
+	}
+	p.module = &pluginModule{
+		runtime:  r,
+		compiled: compiled,
+	}
+	p.modTime = info.ModTime()
+	return p.module, nil
+}
+
+// call runs the decide of a fresh instance of the module, so no state is kept between the calls.
+func (m *pluginModule) call(ctx context.Context, input []byte) ([]byte, error) {
+	mod, err := m.runtime.InstantiateModule(ctx, m.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = mod.Close(ctx)
+	}()
+
+	alloc := mod.ExportedFunction("alloc")
+	decide := mod.ExportedFunction("decide")
+	memory := mod.Memory()
+	if alloc == nil || decide == nil || memory == nil {
+		return nil, fmt.Errorf("module must export memory, alloc and decide")
+	}
+
+	results, err := alloc.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("alloc: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !memory.Write(ptr, input) {
+		return nil, fmt.Errorf("alloc: out of range pointer %d", ptr)
+	}
+
+	results, err = decide.Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("decide: %w", err)
+	}
+	outPtr := uint32(results[0] >> 32)
+	outSize := uint32(results[0])
+	output, ok := memory.Read(outPtr, outSize)
+	if !ok {
+		return nil, fmt.Errorf("decide: out of range output %d+%d", outPtr, outSize)
+	}
+	// The memory is released with the module.
+	return append([]byte(nil), output...), nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// buildPluginModule returns a WebAssembly module whose decide returns the output,
+// alloc returns 1024 and the output is placed at 2048.
+func buildPluginModule(output string) []byte {
+	const outPtr = 2048
+	section := func(id byte, content ...[]byte) []byte {
+		var body []byte
+		for _, c := range content {
+			body = append(body, c...)
+		}
+		return append(append([]byte{id}, uleb(uint64(len(body)))...), body...)
+	}
+	name := func(s string) []byte {
+		return append(uleb(uint64(len(s))), s...)
+	}
+	code := func(body ...byte) []byte {
+		body = append([]byte{0x00}, body...) // no locals
+		return append(uleb(uint64(len(body))), body...)
+	}
+
+	wasm := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	wasm = append(wasm, section(1, // types
+		[]byte{0x02},
+		[]byte{0x60, 0x01, 0x7f, 0x01, 0x7f},       // (i32) -> i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e}, // (i32, i32) -> i64
+	)...)
+	wasm = append(wasm, section(3, []byte{0x02, 0x00, 0x01})...) // functions
+	wasm = append(wasm, section(5, []byte{0x01, 0x00, 0x01})...) // one page of memory
+	wasm = append(wasm, section(7, []byte{0x03},                 // exports
+		name("memory"), []byte{0x02, 0x00},
+		name("alloc"), []byte{0x00, 0x00},
+		name("decide"), []byte{0x00, 0x01},
+	)...)
+	wasm = append(wasm, section(10, []byte{0x02}, // code
+		code(append(append([]byte{0x41}, sleb(1024)...), 0x0b)...),
+		code(append(append([]byte{0x42}, sleb(outPtr<<32|int64(len(output)))...), 0x0b)...),
+	)...)
+	wasm = append(wasm, section(11, []byte{0x01, 0x00}, // data
+		append(append([]byte{0x41}, sleb(outPtr)...), 0x0b),
+		name(output),
+	)...)
+	return wasm
+}
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func sleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func TestStageDecidePlugin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin.wasm")
+	err := os.WriteFile(path, buildPluginModule(`{"delayMilliseconds":100}`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stage, err := NewStage(&internalversion.Stage{
+		Spec: internalversion.StageSpec{
+			Selector: &internalversion.StageSelector{},
+			Next: internalversion.StageNext{
+				Plugin: &internalversion.StagePlugin{Path: path},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decided, err := stage.Decide(context.Background(), map[string]any{"kind": "Pod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delay, ok := decided.Delay(context.Background(), nil, time.Now())
+	if !ok || delay != 100*time.Millisecond {
+		t.Errorf("Delay() = %v, %v, want 100ms", delay, ok)
+	}
+
+	// The modified module is reloaded.
+	err = os.WriteFile(path, buildPluginModule(`{"skip":true}`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	err = os.Chtimes(path, later, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decided, err = stage.Decide(context.Background(), map[string]any{"kind": "Pod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decided != nil {
+		t.Error("expected the stage to be skipped")
+	}
+}
+
+func TestStageDecidePluginConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin.wasm")
+	err := os.WriteFile(path, buildPluginModule(`{"delayMilliseconds":100}`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stage, err := NewStage(&internalversion.Stage{
+		Spec: internalversion.StageSpec{
+			Selector: &internalversion.StageSelector{},
+			Next: internalversion.StageNext{
+				Plugin: &internalversion.StagePlugin{Path: path},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*20)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				_, err := stage.Decide(context.Background(), map[string]any{"kind": "Pod"})
+				if err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	// The module is replaced while the calls are running on it.
+	err = os.WriteFile(path, buildPluginModule(`{"delayMilliseconds":200}`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	err = os.Chtimes(path, later, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	decided, err := stage.Decide(context.Background(), map[string]any{"kind": "Pod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delay, ok := decided.Delay(context.Background(), nil, time.Now())
+	if !ok || delay != 200*time.Millisecond {
+		t.Errorf("Delay() = %v, %v, want 200ms", delay, ok)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+func newWebhookStage(t *testing.T, webhook *internalversion.StageWebhook) *Stage {
+	stage, err := NewStage(&internalversion.Stage{
+		Spec: internalversion.StageSpec{
+			Selector: &internalversion.StageSelector{},
+			Next: internalversion.StageNext{
+				Webhook: webhook,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stage.name = "test"
+	return stage
+}
+
+func TestStageDecide(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req decisionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Stage != "test" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"delayMilliseconds":1500,"patches":[{"subresource":"status","data":{"status":{"phase":"Running"}}}]}`))
+	}))
+	defer server.Close()
+
+	stage := newWebhookStage(t, &internalversion.StageWebhook{URL: server.URL})
+	decided, err := stage.Decide(context.Background(), map[string]any{"kind": "Pod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delay, ok := decided.Delay(context.Background(), nil, time.Now())
+	if !ok || delay != 1500*time.Millisecond {
+		t.Errorf("Delay() = %v, %v, want 1.5s", delay, ok)
+	}
+	patches, err := decided.Next().Patches(nil, gotpl.NewRenderer(gotpl.FuncMap{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].Type != types.MergePatchType || patches[0].Subresource != "status" ||
+		string(patches[0].Data) != `{"status":{"phase":"Running"}}` {
+		t.Errorf("unexpected patches %+v", patches)
+	}
+	if stage.decision != nil {
+		t.Error("the decision should not modify the original stage")
+	}
+}
+
+func TestStageDecideFailurePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	stage := newWebhookStage(t, &internalversion.StageWebhook{URL: server.URL})
+	if _, err := stage.Decide(context.Background(), nil); err == nil {
+		t.Error("expected error with the Fail policy")
+	}
+
+	stage = newWebhookStage(t, &internalversion.StageWebhook{
+		URL:           server.URL,
+		FailurePolicy: internalversion.StageWebhookFailurePolicyIgnore,
+	})
+	decided, err := stage.Decide(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decided != stage {
+		t.Error("expected the stage itself with the Ignore policy")
+	}
+}
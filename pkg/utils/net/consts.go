@@ -22,4 +22,7 @@ const (
 
 	// PublicAddress is the public address.
 	PublicAddress = "0.0.0.0"
+
+	// PublicIPv6Address is the public address of IPv6, which accepts IPv4 too on the dual-stack hosts.
+	PublicIPv6Address = "::"
 )
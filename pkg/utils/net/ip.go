@@ -19,6 +19,7 @@ package net
 import (
 	"encoding/binary"
 	"net"
+	"strconv"
 )
 
 // GetAllIPs returns all IPs of the host.
@@ -82,3 +83,8 @@ func AddCIDR(cidr string, index int) (string, error) {
 	ipnet.IP = AddIP(ipnet.IP, uint64((1<<(bits-ones))*index))
 	return ipnet.String(), nil
 }
+
+// JoinHostPort combines the host and the port into an address, the IPv6 host is enclosed in square brackets.
+func JoinHostPort(host string, port uint32) string {
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10))
+}
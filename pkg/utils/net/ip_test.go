@@ -85,3 +85,20 @@ func TestAddCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinHostPort(t *testing.T) {
+	tests := []struct {
+		host string
+		port uint32
+		want string
+	}{
+		{host: PublicAddress, port: 2379, want: "0.0.0.0:2379"},
+		{host: PublicIPv6Address, port: 2379, want: "[::]:2379"},
+		{host: "localhost", port: 8080, want: "localhost:8080"},
+	}
+	for _, tt := range tests {
+		if got := JoinHostPort(tt.host, tt.port); got != tt.want {
+			t.Errorf("JoinHostPort(%q, %d) = %q, want %q", tt.host, tt.port, got, tt.want)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync"
 
 	"sigs.k8s.io/kwok/pkg/utils/sets"
 )
@@ -27,10 +28,13 @@ import (
 var (
 	errGetUnusedPort        = fmt.Errorf("unable to get an unused port")
 	lastUsedPort     uint32 = 32767
+	lastUsedPortMut  sync.Mutex
 )
 
 // GetUnusedPort returns an unused port on the local machine.
 func GetUnusedPort(ctx context.Context, used sets.Sets[uint32]) (uint32, error) {
+	lastUsedPortMut.Lock()
+	defer lastUsedPortMut.Unlock()
 	for lastUsedPort > 10000 && ctx.Err() == nil {
 		lastUsedPort--
 		if used.Has(lastUsedPort) {
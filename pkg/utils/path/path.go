@@ -51,12 +51,21 @@ func WorkDir() string {
 	return workDir
 }
 
-// Expand expands absolute directory in file paths.
+// Expand expands absolute directory in file paths,
+// the references to environment variables such as $HOME or ${XDG_CACHE_HOME} and the leading ~ are resolved.
 func Expand(path string) (string, error) {
 	if path == "" {
 		return "", fmt.Errorf("empty path")
 	}
 
+	path = expandPlatformEnv(path)
+	if strings.Contains(path, "$") {
+		path = os.Expand(path, getenv)
+		if path == "" {
+			return "", fmt.Errorf("empty path after expanding environment variables")
+		}
+	}
+
 	if path[0] == '~' {
 		home := Home()
 		if len(path) == 1 {
@@ -73,6 +82,46 @@ func Expand(path string) (string, error) {
 	return Clean(p), nil
 }
 
+// getenv returns the value of the environment variable, the HOME is the home directory if it is not set.
+func getenv(key string) string {
+	value := os.Getenv(key)
+	if value == "" && key == "HOME" {
+		return Home()
+	}
+	return value
+}
+
+// expandPercentEnv expands the references to environment variables in the form of %NAME% used on Windows,
+// the references to unset variables and a single % are kept as they are.
+func expandPercentEnv(path string, getenv func(string) string) string {
+	var buf strings.Builder
+	for {
+		start := strings.IndexByte(path, '%')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(path[start+1:], '%')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+		value := ""
+		if name := path[start+1 : end]; name != "" {
+			value = getenv(name)
+		}
+		if value == "" {
+			buf.WriteString(path[:end])
+			path = path[end:]
+			continue
+		}
+		buf.WriteString(path[:start])
+		buf.WriteString(value)
+		path = path[end+1:]
+	}
+	buf.WriteString(path)
+	return buf.String()
+}
+
 // RelFromHome returns a path relative to the home directory.
 // If the path is not relative to the home directory, the original path is returned.
 func RelFromHome(target string) string {
@@ -22,6 +22,11 @@ import (
 	"path/filepath"
 )
 
+// expandPlatformEnv returns the path as is, only the references in the form of $NAME are expanded.
+func expandPlatformEnv(path string) string {
+	return path
+}
+
 // Clean is a wrapper around filepath.Clean.
 func Clean(p string) string {
 	return filepath.Clean(p)
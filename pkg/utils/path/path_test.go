@@ -40,6 +40,8 @@ func TestHome(t *testing.T) {
 
 func TestExpand(t *testing.T) {
 	home := Home()
+	t.Setenv("HOME", home)
+	t.Setenv("KWOK_TEST_EXPAND_DIR", "/tmp/kwok")
 
 	wd, err := os.Getwd()
 	if err != nil {
@@ -95,6 +97,24 @@ func TestExpand(t *testing.T) {
 			expected: Join(wd, "example.txt"),
 			wantErr:  false,
 		},
+		{
+			name:     "home env",
+			input:    "$HOME/example.txt",
+			expected: Join(home, "example.txt"),
+			wantErr:  false,
+		},
+		{
+			name:     "braced env",
+			input:    "${KWOK_TEST_EXPAND_DIR}/example.txt",
+			expected: "/tmp/kwok/example.txt",
+			wantErr:  false,
+		},
+		{
+			name:     "unset env",
+			input:    "${KWOK_TEST_EXPAND_UNSET}",
+			expected: "",
+			wantErr:  true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -120,6 +140,58 @@ func TestExpand(t *testing.T) {
 	}
 }
 
+func TestExpandPercentEnv(t *testing.T) {
+	getenv := func(key string) string {
+		return map[string]string{
+			"USERPROFILE":  `C:\Users\kwok`,
+			"LOCALAPPDATA": `C:\Users\kwok\AppData\Local`,
+		}[key]
+	}
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "no reference",
+			path: `C:\kwok\clusters`,
+			want: `C:\kwok\clusters`,
+		},
+		{
+			name: "reference",
+			path: `%USERPROFILE%\.kwok`,
+			want: `C:\Users\kwok\.kwok`,
+		},
+		{
+			name: "multiple references",
+			path: `%LOCALAPPDATA%\kwok\%USERPROFILE%`,
+			want: `C:\Users\kwok\AppData\Local\kwok\C:\Users\kwok`,
+		},
+		{
+			name: "unset reference",
+			path: `%UNSET%\%USERPROFILE%`,
+			want: `%UNSET%\C:\Users\kwok`,
+		},
+		{
+			name: "single percent",
+			path: `100%\kwok`,
+			want: `100%\kwok`,
+		},
+		{
+			name: "empty reference",
+			path: `%%USERPROFILE%`,
+			want: `%C:\Users\kwok`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandPercentEnv(tt.path, getenv); got != tt.want {
+				t.Errorf("expandPercentEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRelFromHome(t *testing.T) {
 	type args struct {
 		target string
@@ -23,6 +23,12 @@ import (
 	"strings"
 )
 
+// expandPlatformEnv expands the references to environment variables in the form of %NAME%,
+// such as %USERPROFILE% or %LOCALAPPDATA%.
+func expandPlatformEnv(path string) string {
+	return expandPercentEnv(path, getenv)
+}
+
 // Clean is a wrapper around filepath.Clean that converts all path separators to
 // forward slashes. This is useful for Windows paths that are used in URLs.
 func Clean(p string) string {
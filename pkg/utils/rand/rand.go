@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rand provides the random numbers of the simulation,
+// which are reproducible when the seed is set.
+package rand
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	mathrand "math/rand"
+	mathrandv2 "math/rand/v2"
+	"sync"
+	"time"
+)
+
+var (
+	mut    sync.Mutex
+	seeded bool
+	seed   int64
+	//nolint:gosec
+	r = mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+)
+
+// Seed resets the random numbers with the seed,
+// the same sequence of the calls returns the same numbers after the same seed,
+// and the sources of New return the same numbers for the same keys.
+func Seed(s int64) {
+	mut.Lock()
+	defer mut.Unlock()
+	seeded = true
+	seed = s
+	//nolint:gosec
+	r = mathrand.New(mathrand.NewSource(s))
+}
+
+// Source is the random numbers of one decision.
+type Source struct {
+	r *mathrandv2.Rand
+}
+
+// New returns the random numbers of one decision identified by the keys,
+// e.g. the name of the object and the stage, after Seed they are derived from the seed and the keys only,
+// so they don't depend on the order the decisions are made in by the concurrent workers.
+func New(keys ...string) *Source {
+	mut.Lock()
+	isSeeded, s := seeded, seed
+	mut.Unlock()
+	if !isSeeded {
+		//nolint:gosec
+		return &Source{r: mathrandv2.New(mathrandv2.NewPCG(mathrandv2.Uint64(), mathrandv2.Uint64()))}
+	}
+
+	h := fnv.New64a()
+	_ = binary.Write(h, binary.LittleEndian, s)
+	for _, key := range keys {
+		_, _ = h.Write([]byte(key))
+		// Separate the keys so that the keys "ab", "c" and "a", "bc" are different
+		_, _ = h.Write([]byte{0})
+	}
+	//nolint:gosec
+	return &Source{r: mathrandv2.New(mathrandv2.NewPCG(h.Sum64(), uint64(s)))}
+}
+
+// Intn returns a non-negative random number in [0,n).
+func (s *Source) Intn(n int) int {
+	return s.r.IntN(n)
+}
+
+// Int63n returns a non-negative random number in [0,n).
+func (s *Source) Int63n(n int64) int64 {
+	return s.r.Int64N(n)
+}
+
+// Float64 returns a random number in [0.0,1.0).
+func (s *Source) Float64() float64 {
+	return s.r.Float64()
+}
+
+// Attempts counts the attempts of the decisions on each key, e.g. the transitions of an object,
+// the count is passed to New as one of the keys, so a decision made again on the same key gets new numbers.
+type Attempts struct {
+	mut    sync.Mutex
+	counts map[string]uint64
+}
+
+// Next returns the count of the next attempt on the key, starting from 0.
+func (a *Attempts) Next(key string) uint64 {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if a.counts == nil {
+		a.counts = map[string]uint64{}
+	}
+	n := a.counts[key]
+	a.counts[key] = n + 1
+	return n
+}
+
+// Forget forgets the attempts on the key.
+func (a *Attempts) Forget(key string) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	delete(a.counts, key)
+}
+
+// Intn returns a non-negative random number in [0,n).
+func Intn(n int) int {
+	mut.Lock()
+	defer mut.Unlock()
+	return r.Intn(n)
+}
+
+// Int63n returns a non-negative random number in [0,n).
+func Int63n(n int64) int64 {
+	mut.Lock()
+	defer mut.Unlock()
+	return r.Int63n(n)
+}
+
+// Float64 returns a random number in [0.0,1.0).
+func Float64() float64 {
+	mut.Lock()
+	defer mut.Unlock()
+	return r.Float64()
+}
+
+// Shuffle randomizes the order of n elements with the swap function.
+func Shuffle(n int, swap func(i, j int)) {
+	mut.Lock()
+	defer mut.Unlock()
+	r.Shuffle(n, swap)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rand
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSeed(t *testing.T) {
+	sequence := func() []int64 {
+		out := []int64{}
+		for i := 0; i < 8; i++ {
+			out = append(out, Int63n(1000), int64(Intn(1000)), int64(Float64()*1000))
+		}
+		return out
+	}
+
+	Seed(42)
+	first := sequence()
+	Seed(42)
+	second := sequence()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected the same sequence with the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestNewConcurrent(t *testing.T) {
+	Seed(42)
+	defer Seed(time.Now().UnixNano())
+
+	const objects = 100
+	decide := func(i int) [3]int64 {
+		r := New("default", "pod-"+strconv.Itoa(i), "delay", "running")
+		return [3]int64{r.Int63n(1000), int64(r.Intn(1000)), int64(r.Float64() * 1000)}
+	}
+
+	want := make([][3]int64, objects)
+	for i := range want {
+		want[i] = decide(i)
+	}
+
+	got := make([][3]int64, objects)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Each worker goes through the objects in reverse to interleave the decisions
+			for i := objects - 1 - w; i >= 0; i -= 8 {
+				// Draw from the global sequence in between to show it doesn't affect the decisions
+				_ = Intn(1000)
+				got[i] = decide(i)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected the same numbers of object %d with concurrent workers, got %v and %v", i, got[i], want[i])
+		}
+	}
+
+	if a, b := New("a", "bc").Int63n(1<<62), New("ab", "c").Int63n(1<<62); a == b {
+		t.Errorf("expected the different numbers for the different keys, got %d", a)
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	var attempts Attempts
+	for i := uint64(0); i < 3; i++ {
+		if got := attempts.Next("a"); got != i {
+			t.Fatalf("expected attempt %d, got %d", i, got)
+		}
+	}
+	if got := attempts.Next("b"); got != 0 {
+		t.Fatalf("expected the attempts of another key start from 0, got %d", got)
+	}
+	attempts.Forget("a")
+	if got := attempts.Next("a"); got != 0 {
+		t.Fatalf("expected the attempts start from 0 after forget, got %d", got)
+	}
+}
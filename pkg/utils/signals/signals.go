@@ -18,10 +18,14 @@ package signals
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
 )
 
+// ErrInterrupted is the cause of the cancellation of the context returned by SetupSignalContext.
+var ErrInterrupted = errors.New("interrupted by signal")
+
 var (
 	onlyOneSignalHandler = make(chan struct{})
 	shutdownHandler      chan os.Signal
@@ -35,14 +39,20 @@ func SetupSignalContext() context.Context {
 
 	shutdownHandler = make(chan os.Signal, 2)
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancelCause(context.Background())
 	signal.Notify(shutdownHandler, shutdownSignals...)
 	go func() {
 		<-shutdownHandler
-		cancel()
+		cancel(ErrInterrupted)
 		<-shutdownHandler
 		os.Exit(1) // second signal. Exit directly.
 	}()
 
 	return ctx
 }
+
+// IsInterrupted returns true if the context is canceled by a shutdown signal,
+// so that the partial work can be rolled back or checkpointed with a context that isn't canceled.
+func IsInterrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrInterrupted)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package signals
 
 import (
+	"context"
 	"syscall"
 	"testing"
 	"time"
@@ -38,6 +39,11 @@ func TestSetupSignalContext(t *testing.T) {
 		t.Errorf("Context was not canceled after shutdown signal")
 	}
 
+	// Check if the cancellation is reported as an interrupt
+	if !IsInterrupted(ctx) {
+		t.Errorf("Context was not reported as interrupted")
+	}
+
 	// Reset global variables to allow repeated tests
 	onlyOneSignalHandler = make(chan struct{})
 	shutdownHandler = nil
@@ -57,3 +63,20 @@ func TestSetupSignalContextTwice(t *testing.T) {
 
 	_ = SetupSignalContext()
 }
+
+// TestIsInterrupted verifies that only the cancellation by a shutdown signal is reported as an interrupt.
+func TestIsInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if IsInterrupted(ctx) {
+		t.Errorf("Context canceled without a signal was reported as interrupted")
+	}
+
+	ctx, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(ErrInterrupted)
+	child, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if !IsInterrupted(child) {
+		t.Errorf("Child of an interrupted context was not reported as interrupted")
+	}
+}
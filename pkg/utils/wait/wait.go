@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait" //nolint:depguard
+
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 const (
@@ -122,5 +124,17 @@ func Poll(ctx context.Context, conditionFunc ConditionWithContextFunc, opts ...O
 
 // Jitter returns a time.Duration between duration and duration + maxFactor * duration.
 func Jitter(duration time.Duration, maxFactor float64) time.Duration {
-	return wait.Jitter(duration, maxFactor)
+	if maxFactor <= 0.0 {
+		maxFactor = 1.0
+	}
+	return duration + time.Duration(rand.Float64()*maxFactor*float64(duration))
+}
+
+// JitterWithSource is Jitter with the random numbers of the source,
+// which are reproducible with the seed regardless of the order of the calls.
+func JitterWithSource(source *rand.Source, duration time.Duration, maxFactor float64) time.Duration {
+	if maxFactor <= 0.0 {
+		maxFactor = 1.0
+	}
+	return duration + time.Duration(source.Float64()*maxFactor*float64(duration))
 }
@@ -121,6 +121,29 @@ func CaseDryrunWithExtra(clusterName string, kwokctlPath string, rootDir string,
 	return f
 }
 
+// CaseDryrunWithKindRealWorkers tests the real workers of kind which run alongside the fake nodes,
+// only for kind/kind-podman runtime.
+func CaseDryrunWithKindRealWorkers(clusterName string, kwokctlPath string, rootDir string, clusterRuntime string, updateTestdata bool) *features.FeatureBuilder {
+	f := features.New("Dry run with kind real workers")
+	f = f.Assess("test cluster dryrun with kind real workers", func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		absPath := "test/e2e/kwokctl/dryrun/testdata/" + clusterRuntime + "/create_cluster_with_kind_real_workers.txt"
+		args := []string{
+			"create", "cluster", "--dry-run", "--name", clusterName, "--timeout=30m",
+			"--wait=30m", "--quiet-pull", "--disable-qps-limits", "--runtime", clusterRuntime,
+			"--kind-real-workers=2",
+		}
+		diff, err := executeCommand(args, absPath, clusterName, kwokctlPath, rootDir, updateTestdata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != "" {
+			t.Fatalf("Expected vs got:\n%s", diff)
+		}
+		return ctx
+	})
+	return f
+}
+
 func CaseDryrunWithVerbosity(clusterName string, kwokctlPath string, rootDir string, clusterRuntime string, updateTestdata bool) *features.FeatureBuilder {
 	f := features.New("Dry run with verbosity")
 	f = f.Assess("test cluster dryrun with verbosity", func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
@@ -145,3 +168,66 @@ func CaseDryrunWithVerbosity(clusterName string, kwokctlPath string, rootDir str
 	})
 	return f
 }
+
+// CaseDryrunWithExistingData tests deleting the cluster with --keep-data and recreating it with --from-existing-data,
+// the data of the cluster is the kwok.yaml of the testdata.
+func CaseDryrunWithExistingData(clusterName string, kwokctlPath string, rootDir string, clusterRuntime string, updateTestdata bool) *features.FeatureBuilder {
+	f := features.New("Dry run with existing data")
+	workdir := path.Join(rootDir, "workdir", "clusters", clusterName)
+	f = f.Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		data, err := os.ReadFile(path.Join(rootDir, "test/e2e/kwokctl/dryrun/testdata", clusterRuntime, "kwok.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := string(data)
+		conf = strings.ReplaceAll(conf, "<CLUSTER_NAME>", clusterName)
+		conf = strings.ReplaceAll(conf, "<ROOT_DIR>", rootDir)
+		conf = strings.ReplaceAll(conf, "~/", homeDir+"/")
+		err = os.MkdirAll(workdir, fs.FileMode(0750))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path.Join(workdir, "kwok.yaml"), []byte(conf), fs.FileMode(0640))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ctx
+	})
+	f = f.Assess("test cluster dryrun delete with keep data", func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		absPath := "test/e2e/kwokctl/dryrun/testdata/" + clusterRuntime + "/delete_cluster_with_keep_data.txt"
+		args := []string{
+			"delete", "cluster", "--dry-run", "--name", clusterName, "--keep-data",
+		}
+		diff, err := executeCommand(args, absPath, clusterName, kwokctlPath, rootDir, updateTestdata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != "" {
+			t.Fatalf("Expected vs got:\n%s", diff)
+		}
+		return ctx
+	})
+	f = f.Assess("test cluster dryrun create from existing data", func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		absPath := "test/e2e/kwokctl/dryrun/testdata/" + clusterRuntime + "/create_cluster_from_existing_data.txt"
+		args := []string{
+			"create", "cluster", "--dry-run", "--name", clusterName, "--timeout=30m",
+			"--wait=30m", "--from-existing-data",
+		}
+		diff, err := executeCommand(args, absPath, clusterName, kwokctlPath, rootDir, updateTestdata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != "" {
+			t.Fatalf("Expected vs got:\n%s", diff)
+		}
+		return ctx
+	})
+	f = f.Teardown(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		err := os.RemoveAll(workdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ctx
+	})
+	return f
+}
@@ -36,3 +36,8 @@ func TestBinaryDryRunWithVerbosity(t *testing.T) {
 	f0 := e2e.CaseDryrunWithVerbosity(clusterName, kwokctlPath, rootDir, "binary", updateTestdata).Feature()
 	testEnv.Test(t, f0)
 }
+
+func TestBinaryDryRunWithExistingData(t *testing.T) {
+	f0 := e2e.CaseDryrunWithExistingData(clusterName, kwokctlPath, rootDir, "binary", updateTestdata).Feature()
+	testEnv.Test(t, f0)
+}
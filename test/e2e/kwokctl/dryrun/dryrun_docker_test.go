@@ -36,3 +36,8 @@ func TestDockerDryRunWithVerbosity(t *testing.T) {
 	f0 := e2e.CaseDryrunWithVerbosity(clusterName, kwokctlPath, rootDir, "docker", updateTestdata).Feature()
 	testEnv.Test(t, f0)
 }
+
+func TestDockerDryRunWithExistingData(t *testing.T) {
+	f0 := e2e.CaseDryrunWithExistingData(clusterName, kwokctlPath, rootDir, "docker", updateTestdata).Feature()
+	testEnv.Test(t, f0)
+}
@@ -36,3 +36,8 @@ func TestKindDryRunWithVerbosity(t *testing.T) {
 	f0 := e2e.CaseDryrunWithVerbosity(clusterName, kwokctlPath, rootDir, "kind", updateTestdata).Feature()
 	testEnv.Test(t, f0)
 }
+
+func TestKindDryRunWithKindRealWorkers(t *testing.T) {
+	f0 := e2e.CaseDryrunWithKindRealWorkers(clusterName, kwokctlPath, rootDir, "kind", updateTestdata).Feature()
+	testEnv.Test(t, f0)
+}